@@ -0,0 +1,223 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Collection files of an Anki package in the order of preference
+//
+// "collection.anki21b" of the latest Anki versions is compressed with zstd and is not supported,
+// such decks should be exported with the "Support older Anki versions" option.
+var collectionFiles = []string{"collection.anki21", "collection.anki2"}
+
+// maxEntrySize is the maximum uncompressed size of the collection and the media list of a package,
+// larger entries are rejected so a small archive cannot expand into an arbitrary amount of memory
+var maxEntrySize uint64 = 256 << 20 // 256MB
+
+// fieldSeparator separates field values of a note in a collection
+const fieldSeparator = "\x1f"
+
+// Column indexes in "notes" and "col" tables of a collection
+const (
+	notesModelColumn  = 2
+	notesTagsColumn   = 5
+	notesFieldsColumn = 6
+	colModelsColumn   = 9
+)
+
+// Note is a note of an Anki collection or a row of a text export
+type Note struct {
+	Fields map[string]string // Raw field values by field name, values may contain HTML and sound references
+	Tags   []string
+}
+
+// Package is an Anki package (.apkg) with its notes and bundled media
+type Package struct {
+	Notes []Note
+	media map[string]*zip.File // Bundled media files by their original names
+}
+
+// model is a note type of a collection, only field names are used
+type model struct {
+	Fields []struct {
+		Name string `json:"name"`
+		Ord  int    `json:"ord"`
+	} `json:"flds"`
+}
+
+// ReadPackage reads notes and media of an Anki package
+//
+// The package is a zip archive with an SQLite collection, a "media" JSON file mapping archive entries
+// to original media names and the media files themselves.
+// If the package cannot be read or its collection is too large, the error will be returned together with "nil" value.
+func ReadPackage(data []byte) (*Package, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open package: %w", err)
+	}
+	entries := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		entries[f.Name] = f
+	}
+
+	var collection *zip.File
+	for _, name := range collectionFiles {
+		if f, ok := entries[name]; ok {
+			collection = f
+			break
+		}
+	}
+	if collection == nil {
+		if _, ok := entries["collection.anki21b"]; ok {
+			return nil, fmt.Errorf("collection format of the latest Anki versions is not supported, export the deck with the \"Support older Anki versions\" option")
+		}
+		return nil, fmt.Errorf("collection not found in package")
+	}
+
+	collectionData, err := readZipFile(collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collection: %w", err)
+	}
+	notes, err := readCollection(collectionData)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make(map[string]*zip.File)
+	if f, ok := entries["media"]; ok {
+		mediaData, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read media list: %w", err)
+		}
+		var names map[string]string
+		if err := json.Unmarshal(mediaData, &names); err != nil {
+			return nil, fmt.Errorf("failed to parse media list: %w", err)
+		}
+		for entry, name := range names {
+			if f, ok := entries[entry]; ok {
+				media[name] = f
+			}
+		}
+	}
+
+	return &Package{Notes: notes, media: media}, nil
+}
+
+// OpenMedia opens a media file bundled into the package by its original name
+//
+// If the file is not bundled, the error will be returned together with "nil" value.
+func (p *Package) OpenMedia(name string) (io.ReadCloser, error) {
+	f, ok := p.media[name]
+	if !ok {
+		return nil, fmt.Errorf("media file %q is not bundled", name)
+	}
+	return f.Open()
+}
+
+// readCollection reads notes of a collection with field names taken from their note types
+func readCollection(data []byte) ([]Note, error) {
+	db, err := openDatabase(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open collection: %w", err)
+	}
+
+	colRows, err := db.readTable("col")
+	if err != nil {
+		return nil, err
+	}
+	if len(colRows) == 0 || len(colRows[0].values) <= colModelsColumn {
+		return nil, fmt.Errorf("collection has no note types")
+	}
+	modelsJSON, _ := colRows[0].values[colModelsColumn].(string)
+	var models map[string]model
+	if err := json.Unmarshal([]byte(modelsJSON), &models); err != nil {
+		return nil, fmt.Errorf("failed to parse note types: %w", err)
+	}
+
+	noteRows, err := db.readTable("notes")
+	if err != nil {
+		return nil, err
+	}
+	notes := make([]Note, 0, len(noteRows))
+	for _, r := range noteRows {
+		if len(r.values) <= notesFieldsColumn {
+			return nil, fmt.Errorf("note %d has too few columns", r.rowid)
+		}
+		modelID, _ := r.values[notesModelColumn].(int64)
+		m, ok := models[strconv.FormatInt(modelID, 10)]
+		if !ok {
+			return nil, fmt.Errorf("note type %d of note %d not found", modelID, r.rowid)
+		}
+		fields, _ := r.values[notesFieldsColumn].(string)
+		tags, _ := r.values[notesTagsColumn].(string)
+
+		values := strings.Split(fields, fieldSeparator)
+		note := Note{Fields: make(map[string]string, len(m.Fields)), Tags: strings.Fields(tags)}
+		for _, field := range m.Fields {
+			if field.Ord < len(values) {
+				note.Fields[field.Name] = values[field.Ord]
+			}
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// readZipFile reads the whole content of an archive entry
+//
+// If the entry is larger than maxEntrySize, the error will be returned. The size from the archive header
+// is checked first, and the entry is read through a limit as the header may understate the size.
+func readZipFile(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > maxEntrySize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, maxEntrySize)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, int64(maxEntrySize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) > maxEntrySize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, maxEntrySize)
+	}
+	return data, nil
+}
+
+var (
+	soundPattern = regexp.MustCompile(`\[sound:([^\]]+)\]`)
+	tagPattern   = regexp.MustCompile(`<[^>]*>`)
+	breakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>`)
+)
+
+// SoundFiles returns names of media files referenced by [sound:...] tags of a field value
+func SoundFiles(value string) []string {
+	var files []string
+	for _, match := range soundPattern.FindAllStringSubmatch(value, -1) {
+		files = append(files, match[1])
+	}
+	return files
+}
+
+// PlainText converts a field value to plain text
+//
+// Sound references and HTML tags are removed, line breaks become spaces, entities are unescaped
+// and whitespace is collapsed.
+func PlainText(value string) string {
+	value = soundPattern.ReplaceAllString(value, "")
+	value = breakPattern.ReplaceAllString(value, " ")
+	value = tagPattern.ReplaceAllString(value, "")
+	value = html.UnescapeString(value)
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,412 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/deck.apkg is a package exported in the format of older Anki versions.
+// Its collection uses 1 KiB pages, so the notes table spans interior b-tree pages and a long field uses overflow pages.
+// It has 203 notes of the "Japanese" note type (Expression, Meaning, Reading, Audio) and one "Basic" note (Front, Back).
+// Only "mizu.mp3" is bundled, "missing.mp3" is referenced but not bundled.
+func readTestPackage(t *testing.T) *Package {
+	t.Helper()
+	data, err := os.ReadFile("testdata/deck.apkg")
+	require.NoError(t, err)
+	pkg, err := ReadPackage(data)
+	require.NoError(t, err)
+	return pkg
+}
+
+func TestReadPackage(t *testing.T) {
+	pkg := readTestPackage(t)
+
+	require.Len(t, pkg.Notes, 204)
+
+	first := pkg.Notes[0]
+	assert.Equal(t, "水", first.Fields["Expression"])
+	assert.Equal(t, "<b>water</b>", first.Fields["Meaning"])
+	assert.Equal(t, "みず", first.Fields["Reading"])
+	assert.Equal(t, "[sound:mizu.mp3]", first.Fields["Audio"])
+	assert.Equal(t, []string{"n5", "nature"}, first.Tags)
+
+	// The long field is stored on overflow pages
+	long := pkg.Notes[2]
+	assert.Equal(t, "長文", long.Fields["Expression"])
+	assert.Equal(t, "long "+strings.Repeat("text ", 700), long.Fields["Meaning"])
+
+	// Notes keep rowid order across leaf pages
+	assert.Equal(t, "語0", pkg.Notes[3].Fields["Expression"])
+	assert.Equal(t, "語199", pkg.Notes[202].Fields["Expression"])
+
+	// Field names come from the note type of each note
+	basic := pkg.Notes[203]
+	assert.Equal(t, map[string]string{"Front": "Front side", "Back": "Back side"}, basic.Fields)
+}
+
+func TestPackage_OpenMedia(t *testing.T) {
+	pkg := readTestPackage(t)
+
+	rc, err := pkg.OpenMedia("mizu.mp3")
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "ID3 fake mizu audio", string(content))
+
+	_, err = pkg.OpenMedia("missing.mp3")
+	assert.Error(t, err)
+}
+
+func TestReadPackage_Errors(t *testing.T) {
+	zipWith := func(t *testing.T, files map[string][]byte) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write(content)
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name          string
+		data          func(t *testing.T) []byte
+		errorContains string
+	}{
+		{
+			name:          "not a zip archive",
+			data:          func(t *testing.T) []byte { return []byte("not a package") },
+			errorContains: "failed to open package",
+		},
+		{
+			name:          "no collection",
+			data:          func(t *testing.T) []byte { return zipWith(t, map[string][]byte{"media": []byte("{}")}) },
+			errorContains: "collection not found",
+		},
+		{
+			name: "latest collection format",
+			data: func(t *testing.T) []byte {
+				return zipWith(t, map[string][]byte{"collection.anki21b": []byte("zstd")})
+			},
+			errorContains: "not supported",
+		},
+		{
+			name: "collection is not an SQLite database",
+			data: func(t *testing.T) []byte {
+				return zipWith(t, map[string][]byte{"collection.anki2": []byte("garbage")})
+			},
+			errorContains: "not an SQLite database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg, err := ReadPackage(tt.data(t))
+
+			assert.Nil(t, pkg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestReadPackage_TooLarge(t *testing.T) {
+	defer func(size uint64) { maxEntrySize = size }(maxEntrySize)
+	maxEntrySize = 1024
+
+	data, err := os.ReadFile("testdata/deck.apkg")
+	require.NoError(t, err)
+
+	pkg, err := ReadPackage(data)
+
+	assert.Nil(t, pkg)
+	assert.EqualError(t, err, "failed to read collection: collection.anki21 is larger than 1024 bytes")
+}
+
+func TestDecodeRecord_Malformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		record []byte
+	}{
+		{name: "header size smaller than its varint", record: []byte{0x00, 0x01}},
+		{name: "header size larger than the record", record: []byte{0x05, 0x01}},
+		{name: "serial type varint beyond the header", record: []byte{0x02, 0x81, 0x01}},
+		{name: "value beyond the body", record: []byte{0x02, 0x06, 0x01}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := decodeRecord(tt.record)
+
+			assert.Error(t, err)
+			assert.Nil(t, values)
+		})
+	}
+}
+
+func TestReadTree_CorruptedPages(t *testing.T) {
+	// cyclicDatabase builds a database of two 512 byte pages with an interior root page pointing to the child pages
+	cyclicDatabase := func(childPage, rightPointer int) *database {
+		data := make([]byte, 1024)
+		copy(data, sqliteHeader)
+		data[16], data[17] = 0x02, 0x00 // Page size 512
+		cell := []byte{0, 0, 0, byte(childPage), 0x01}
+		fillPage(data[:512], 100, tableInteriorPage, [][]byte{cell}, rightPointer)
+		fillPage(data[512:], 0, tableLeafPage, nil, 0)
+		db, err := openDatabase(data)
+		require.NoError(t, err)
+		return db
+	}
+
+	tests := []struct {
+		name          string
+		db            *database
+		errorContains string
+	}{
+		{name: "page referenced twice", db: cyclicDatabase(2, 2), errorContains: "page 2 is referenced twice"},
+		{name: "root page references itself", db: cyclicDatabase(1, 2), errorContains: "page 1 is referenced twice"},
+		{name: "page beyond the page count", db: cyclicDatabase(2, 3), errorContains: "page 3 is out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.db.readTree(1)
+
+			assert.Nil(t, rows)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestReadText(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		separator     rune
+		expected      []Note
+		expectedError bool
+	}{
+		{
+			name:      "csv with header row",
+			data:      "Expression,Meaning\n水,water\n\"火, 炎\",fire\n",
+			separator: ',',
+			expected: []Note{
+				{Fields: map[string]string{"Expression": "水", "Meaning": "water"}},
+				{Fields: map[string]string{"Expression": "火, 炎", "Meaning": "fire"}},
+			},
+		},
+		{
+			name:      "anki text export headers",
+			data:      "\xef\xbb\xbf#separator:tab\n#html:true\n#columns:Expression\tMeaning\tTags\n#tags column:3\n水\t<b>water</b>\tn5 nature\n",
+			separator: ',',
+			expected: []Note{
+				{Fields: map[string]string{"Expression": "水", "Meaning": "<b>water</b>"}, Tags: []string{"n5", "nature"}},
+			},
+		},
+		{
+			name:      "separator header with literal character",
+			data:      "#separator:|\nExpression|Meaning\n水|water\n",
+			separator: '\t',
+			expected: []Note{
+				{Fields: map[string]string{"Expression": "水", "Meaning": "water"}},
+			},
+		},
+		{
+			name:      "short rows",
+			data:      "Expression\tMeaning\tReading\n水\twater\n",
+			separator: '\t',
+			expected: []Note{
+				{Fields: map[string]string{"Expression": "水", "Meaning": "water"}},
+			},
+		},
+		{
+			name:          "empty file",
+			data:          "",
+			separator:     ',',
+			expectedError: true,
+		},
+		{
+			name:          "invalid separator",
+			data:          "#separator:double\nA,B\n",
+			separator:     ',',
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, err := ReadText([]byte(tt.data), tt.separator)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, notes)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, notes)
+		})
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "water", PlainText("<b>water</b>"))
+	assert.Equal(t, "fire & flame", PlainText("fire&nbsp;&amp; flame"))
+	assert.Equal(t, "line one line two", PlainText("<div>line one</div><div>line two<br/></div>"))
+	assert.Equal(t, "水", PlainText("水[sound:mizu.mp3]"))
+	assert.Equal(t, "", PlainText("[sound:mizu.mp3]"))
+}
+
+func TestSoundFiles(t *testing.T) {
+	assert.Equal(t, []string{"mizu.mp3"}, SoundFiles("[sound:mizu.mp3]"))
+	assert.Equal(t, []string{"a.mp3", "b b.ogg"}, SoundFiles("x [sound:a.mp3] y [sound:b b.ogg]"))
+	assert.Nil(t, SoundFiles("no sound"))
+}
+
+// testDeck builds a deck with enough notes for interior b-tree pages and a long field for overflow pages
+func testDeck(now time.Time) *Deck {
+	deck := &Deck{
+		Name:   "Vocabulary",
+		Fields: []string{"Word", "Meaning", "Audio"},
+		Front:  "{{Word}}",
+		Back:   "{{FrontSide}}<hr id=answer>{{Meaning}} {{Audio}}",
+		Media:  map[string][]byte{"mizu.mp3": []byte("ID3 mizu")},
+	}
+	deck.Notes = append(deck.Notes,
+		DeckNote{
+			GUID:     "word-1",
+			Fields:   []string{"水", "water", SoundReference("mizu.mp3")},
+			Tags:     []string{"n5", "nature"},
+			Schedule: &Schedule{Due: now.AddDate(0, 0, -3), Interval: 6, EaseFactor: 2.36, Repetitions: 2, Lapses: 1},
+		},
+		DeckNote{
+			GUID:     "word-2",
+			Fields:   []string{"火", "fire\twith \"tab\"", ""},
+			Schedule: &Schedule{Due: now.AddDate(0, 0, 4), Interval: 1},
+		},
+		DeckNote{Fields: []string{"長文", "long " + strings.Repeat("text ", 2000), ""}},
+	)
+	for i := range 500 {
+		deck.Notes = append(deck.Notes, DeckNote{Fields: []string{fmt.Sprintf("語%d", i), "word", ""}})
+	}
+	return deck
+}
+
+func TestWritePackage(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	deck := testDeck(now)
+
+	data, err := WritePackage(deck, now)
+	require.NoError(t, err)
+
+	pkg, err := ReadPackage(data)
+	require.NoError(t, err)
+	require.Len(t, pkg.Notes, len(deck.Notes))
+	assert.Equal(t, Note{
+		Fields: map[string]string{"Word": "水", "Meaning": "water", "Audio": "[sound:mizu.mp3]"},
+		Tags:   []string{"n5", "nature"},
+	}, pkg.Notes[0])
+	assert.Equal(t, "long "+strings.Repeat("text ", 2000), pkg.Notes[2].Fields["Meaning"])
+	assert.Equal(t, "語499", pkg.Notes[len(pkg.Notes)-1].Fields["Word"])
+
+	rc, err := pkg.OpenMedia("mizu.mp3")
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "ID3 mizu", string(content))
+
+	// Scheduling state is kept in the cards table, due days are counted from the creation of the collection
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var collection []byte
+	for _, f := range archive.File {
+		if f.Name == "collection.anki2" {
+			collection, err = readZipFile(f)
+			require.NoError(t, err)
+		}
+	}
+	db, err := openDatabase(collection)
+	require.NoError(t, err)
+	colRows, err := db.readTable("col")
+	require.NoError(t, err)
+	require.Len(t, colRows, 1)
+	assert.Equal(t, time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC).Unix(), colRows[0].values[1], "collection is created on the earliest due day")
+
+	cards, err := db.readTable("cards")
+	require.NoError(t, err)
+	require.Len(t, cards, len(deck.Notes))
+	// Columns are type, queue, due, ivl, factor, reps and lapses
+	assert.Equal(t, []any{int64(2), int64(2), int64(0), int64(6), int64(2360), int64(2), int64(1)}, cards[0].values[6:13])
+	assert.Equal(t, []any{int64(2), int64(2), int64(7), int64(1), int64(2500), int64(0), int64(0)}, cards[1].values[6:13])
+	assert.Equal(t, []any{int64(0), int64(0), int64(3), int64(0), int64(0), int64(0), int64(0)}, cards[2].values[6:13])
+
+	notes, err := db.readTable("notes")
+	require.NoError(t, err)
+	assert.Equal(t, "word-1", notes[0].values[1])
+	assert.NotEmpty(t, notes[2].values[1], "a GUID is generated for notes without one")
+}
+
+func TestWriteText(t *testing.T) {
+	deck := testDeck(time.Now())
+
+	data, err := WriteText(deck)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "#separator:tab\n#html:true\n#columns:Word\tMeaning\tAudio\tTags\n#tags column:4\n"))
+
+	notes, err := ReadText(data, ',')
+	require.NoError(t, err)
+	require.Len(t, notes, len(deck.Notes))
+	assert.Equal(t, Note{
+		Fields: map[string]string{"Word": "水", "Meaning": "water", "Audio": "[sound:mizu.mp3]"},
+		Tags:   []string{"n5", "nature"},
+	}, notes[0])
+	assert.Equal(t, "fire\twith \"tab\"", notes[1].Fields["Meaning"])
+}
+
+func TestWriteDeck_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		deck          *Deck
+		errorContains string
+	}{
+		{
+			name:          "no fields",
+			deck:          &Deck{Name: "Vocabulary"},
+			errorContains: "deck has no fields",
+		},
+		{
+			name:          "wrong number of note fields",
+			deck:          &Deck{Name: "Vocabulary", Fields: []string{"Word", "Meaning"}, Notes: []DeckNote{{Fields: []string{"水"}}}},
+			errorContains: "note 1 has 1 fields, deck has 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := WritePackage(tt.deck, time.Now())
+			assert.Nil(t, data)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+
+			data, err = WriteText(tt.deck)
+			assert.Nil(t, data)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
@@ -0,0 +1,348 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deck is a deck of notes written to an Anki package or a text file
+type Deck struct {
+	Name   string
+	Fields []string // Field names of the note type, the first field is used for sorting and duplicate checks
+	Front  string   // Template of the question side of cards, e.g. "{{Word}}"
+	Back   string   // Template of the answer side of cards
+	Notes  []DeckNote
+	Media  map[string][]byte // Media files by the names used in [sound:...] references
+}
+
+// DeckNote is a note of an exported deck, every note has a single card
+type DeckNote struct {
+	GUID     string   // Stable identifier, Anki updates notes with the same GUID on repeated imports
+	Fields   []string // Values in the order of the deck fields, may contain HTML and sound references
+	Tags     []string
+	Schedule *Schedule // Review state of the card, "nil" for a card which has never been reviewed
+}
+
+// Schedule is the review state of a card
+type Schedule struct {
+	Due         time.Time // Day of the next review
+	Interval    int       // Days between the last and the next review
+	EaseFactor  float64   // SM-2 ease factor, e.g. 2.5
+	Repetitions int       // Number of reviews
+	Lapses      int       // Number of times the card was forgotten
+}
+
+// Card types and queues of the collection schema
+const (
+	cardTypeNew    = 0
+	cardTypeReview = 2
+)
+
+// defaultEaseFactor is used for cards without an ease factor
+const defaultEaseFactor = 2.5
+
+// Tables of a collection of schema version 11, the last one Anki writes to "collection.anki2"
+const (
+	collectionVersion = 11
+	colTableSQL       = "CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null, models text not null, decks text not null, dconf text not null, tags text not null)"
+	notesTableSQL     = "CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null, flags integer not null, data text not null)"
+	cardsTableSQL     = "CREATE TABLE cards (id integer primary key, nid integer not null, did integer not null, ord integer not null, mod integer not null, usn integer not null, type integer not null, queue integer not null, due integer not null, ivl integer not null, factor integer not null, reps integer not null, lapses integer not null, left integer not null, odue integer not null, odid integer not null, flags integer not null, data text not null)"
+	revlogTableSQL    = "CREATE TABLE revlog (id integer primary key, cid integer not null, usn integer not null, ease integer not null, ivl integer not null, lastIvl integer not null, factor integer not null, time integer not null, type integer not null)"
+	gravesTableSQL    = "CREATE TABLE graves (usn integer not null, oid integer not null, type integer not null)"
+)
+
+// SoundReference returns a field value playing a media file, e.g. "[sound:word.mp3]"
+func SoundReference(name string) string {
+	return "[sound:" + name + "]"
+}
+
+// WritePackage writes the deck as an Anki package (.apkg)
+//
+// "now" parameter is the time of the export, it is used for identifiers and modification times.
+//
+// Cards with a schedule become review cards keeping their due day, interval, ease factor, repetitions and lapses,
+// other cards are new. The note type and the deck get identifiers derived from the deck name,
+// so repeated exports are imported into the same deck.
+// If the deck cannot be written, the error will be returned together with "nil" value.
+func WritePackage(deck *Deck, now time.Time) ([]byte, error) {
+	if err := validateDeck(deck); err != nil {
+		return nil, err
+	}
+
+	collection, err := writeCollection(deck, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	if err := writeZipFile(archive, "collection.anki2", collection); err != nil {
+		return nil, err
+	}
+
+	// Media files are stored under their indexes, the "media" list maps them back to names
+	names := make([]string, 0, len(deck.Media))
+	for name := range deck.Media {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	mediaList := make(map[string]string, len(names))
+	for i, name := range names {
+		entry := strconv.Itoa(i)
+		mediaList[entry] = name
+		if err := writeZipFile(archive, entry, deck.Media[name]); err != nil {
+			return nil, err
+		}
+	}
+	mediaJSON, err := json.Marshal(mediaList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal media list: %w", err)
+	}
+	if err := writeZipFile(archive, "media", mediaJSON); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write package: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteText writes the deck as an Anki text export with tab separated columns
+//
+// Headers name the columns and the column of tags, so the file can be imported into Anki
+// and read back with ReadText method. Schedules and media files are not written.
+// If the deck cannot be written, the error will be returned together with "nil" value.
+func WriteText(deck *Deck) ([]byte, error) {
+	if err := validateDeck(deck); err != nil {
+		return nil, err
+	}
+	for _, field := range deck.Fields {
+		if strings.ContainsAny(field, "\t\r\n") {
+			return nil, fmt.Errorf("invalid field name: %q", field)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("#separator:tab\n")
+	buf.WriteString("#html:true\n")
+	fmt.Fprintf(&buf, "#columns:%s\tTags\n", strings.Join(deck.Fields, "\t"))
+	fmt.Fprintf(&buf, "#tags column:%d\n", len(deck.Fields)+1)
+
+	writer := csv.NewWriter(&buf)
+	writer.Comma = '\t'
+	for _, note := range deck.Notes {
+		record := append(slices.Clone(note.Fields), strings.Join(note.Tags, " "))
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write note: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write notes: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// validateDeck checks that the deck has fields and every note has a value for each of them
+func validateDeck(deck *Deck) error {
+	if deck == nil || len(deck.Fields) == 0 {
+		return fmt.Errorf("deck has no fields")
+	}
+	for i, note := range deck.Notes {
+		if len(note.Fields) != len(deck.Fields) {
+			return fmt.Errorf("note %d has %d fields, deck has %d", i+1, len(note.Fields), len(deck.Fields))
+		}
+	}
+	return nil
+}
+
+// writeCollection writes the SQLite collection of the deck
+func writeCollection(deck *Deck, now time.Time) ([]byte, error) {
+	modelID := stableID("model " + deck.Name)
+	deckID := stableID("deck " + deck.Name)
+	modified := now.Unix()
+
+	// The collection is created on the day of the export or on the earliest due day,
+	// so due days of overdue cards are not negative
+	created := civilDate(now)
+	for _, note := range deck.Notes {
+		if note.Schedule != nil && civilDate(note.Schedule.Due).Before(created) {
+			created = civilDate(note.Schedule.Due)
+		}
+	}
+
+	db := newDatabaseWriter()
+	col := db.createTable("col", colTableSQL)
+	notes := db.createTable("notes", notesTableSQL)
+	cards := db.createTable("cards", cardsTableSQL)
+	db.createTable("revlog", revlogTableSQL)
+	db.createTable("graves", gravesTableSQL)
+
+	conf, models, decks, dconf, err := collectionConfig(deck, modelID, deckID, modified)
+	if err != nil {
+		return nil, err
+	}
+	col.insert(1, nil, created.Unix(), now.UnixMilli(), now.UnixMilli(), collectionVersion, 0, 0, 0,
+		conf, models, decks, dconf, "{}")
+
+	// Notes and cards get identifiers from the export time in milliseconds, as Anki does
+	baseID := now.UnixMilli()
+	for i, note := range deck.Notes {
+		noteID := baseID + int64(i)
+		guid := note.GUID
+		if guid == "" {
+			guid = noteGUID(deck.Name, note.Fields)
+		}
+		tags := ""
+		if len(note.Tags) > 0 {
+			tags = " " + strings.Join(note.Tags, " ") + " "
+		}
+		sortField := PlainText(note.Fields[0])
+		notes.insert(noteID, nil, guid, modelID, modified, -1, tags,
+			strings.Join(note.Fields, fieldSeparator), sortField, fieldChecksum(sortField), 0, "")
+
+		cardType, due, interval, factor, reps, lapses := cardTypeNew, int64(i+1), 0, 0, 0, 0
+		if s := note.Schedule; s != nil {
+			cardType = cardTypeReview
+			due = int64(civilDate(s.Due).Sub(created).Hours() / 24)
+			interval = max(s.Interval, 1)
+			easeFactor := s.EaseFactor
+			if easeFactor <= 0 {
+				easeFactor = defaultEaseFactor
+			}
+			factor = int(math.Round(easeFactor * 1000))
+			reps = s.Repetitions
+			lapses = s.Lapses
+		}
+		// The queue of new and review cards is equal to their type
+		cards.insert(noteID, nil, noteID, deckID, 0, modified, -1, cardType, cardType, due, interval, factor,
+			reps, lapses, 0, 0, 0, 0, "")
+	}
+
+	return db.bytes()
+}
+
+// collectionConfig builds JSON columns of the "col" table with a single note type and deck
+func collectionConfig(deck *Deck, modelID, deckID, modified int64) (conf, models, decks, dconf string, err error) {
+	fields := make([]map[string]any, len(deck.Fields))
+	for i, name := range deck.Fields {
+		fields[i] = map[string]any{
+			"name": name, "ord": i, "sticky": false, "rtl": false, "font": "Arial", "size": 20, "media": []string{},
+		}
+	}
+	front, back := deck.Front, deck.Back
+	if front == "" {
+		front = "{{" + deck.Fields[0] + "}}"
+	}
+	if back == "" {
+		back = "{{FrontSide}}"
+	}
+
+	values := []any{
+		map[string]any{
+			"nextPos": len(deck.Notes) + 1, "estTimes": true, "activeDecks": []int64{deckID}, "sortType": "noteFld",
+			"timeLim": 0, "sortBackwards": false, "addToCur": true, "curDeck": deckID, "newBury": true,
+			"newSpread": 0, "dueCounts": true, "curModel": modelID, "collapseTime": 1200,
+		},
+		map[string]any{
+			strconv.FormatInt(modelID, 10): map[string]any{
+				"id": modelID, "name": deck.Name, "type": 0, "mod": modified, "usn": -1, "sortf": 0, "did": deckID,
+				"tmpls": []map[string]any{{
+					"name": "Card 1", "ord": 0, "qfmt": front, "afmt": back, "did": nil, "bqfmt": "", "bafmt": "",
+				}},
+				"flds": fields, "css": ".card { font-family: arial; font-size: 20px; text-align: center; }",
+				"latexPre":  "\\documentclass[12pt]{article}\n\\special{papersize=3in,5in}\n\\usepackage{amssymb,amsmath}\n\\pagestyle{empty}\n\\begin{document}\n",
+				"latexPost": "\\end{document}", "latexsvg": false, "req": []any{[]any{0, "any", []int{0}}},
+				"tags": []string{}, "vers": []int{},
+			},
+		},
+		map[string]any{
+			"1":                           deckJSON(1, "Default", modified),
+			strconv.FormatInt(deckID, 10): deckJSON(deckID, deck.Name, modified),
+		},
+		map[string]any{
+			"1": map[string]any{
+				"id": 1, "name": "Default", "mod": 0, "usn": 0, "maxTaken": 60, "autoplay": true, "timer": 0,
+				"replayq": true, "dyn": false,
+				"new": map[string]any{
+					"delays": []float64{1, 10}, "ints": []int{1, 4, 7}, "initialFactor": 2500, "order": 1,
+					"perDay": 20, "bury": false, "separate": true,
+				},
+				"rev": map[string]any{
+					"perDay": 200, "ease4": 1.3, "fuzz": 0.05, "minSpace": 1, "ivlFct": 1, "maxIvl": 36500,
+					"bury": false, "hardFactor": 1.2,
+				},
+				"lapse": map[string]any{
+					"delays": []float64{10}, "mult": 0, "minInt": 1, "leechFails": 8, "leechAction": 1,
+				},
+			},
+		},
+	}
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to marshal collection config: %w", err)
+		}
+		encoded[i] = string(data)
+	}
+	return encoded[0], encoded[1], encoded[2], encoded[3], nil
+}
+
+// deckJSON builds the configuration of a deck with the default options
+func deckJSON(id int64, name string, modified int64) map[string]any {
+	return map[string]any{
+		"id": id, "name": name, "desc": "", "mod": modified, "usn": -1, "collapsed": false,
+		"browserCollapsed": false, "dyn": 0, "conf": 1, "extendNew": 0, "extendRev": 0,
+		"newToday": []int{0, 0}, "revToday": []int{0, 0}, "lrnToday": []int{0, 0}, "timeToday": []int{0, 0},
+	}
+}
+
+// stableID derives a positive identifier from a name
+func stableID(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	// 40 bits keep the identifier in the range of millisecond timestamps used by Anki
+	return int64(h.Sum64()>>24) + 1
+}
+
+// noteGUID derives a GUID of a note from the deck name and its first field
+func noteGUID(deckName string, fields []string) string {
+	sum := sha1.Sum([]byte(deckName + fieldSeparator + fields[0]))
+	return hex.EncodeToString(sum[:8])
+}
+
+// fieldChecksum returns the checksum Anki uses to find duplicates: the first 8 hex digits of SHA-1 of the sort field
+func fieldChecksum(sortField string) int64 {
+	sum := sha1.Sum([]byte(sortField))
+	return int64(sum[0])<<24 | int64(sum[1])<<16 | int64(sum[2])<<8 | int64(sum[3])
+}
+
+// civilDate returns the start of the calendar day of the time in UTC
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// writeZipFile adds a file to the archive
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to package: %w", name, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to package: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package anki
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Read-only access to SQLite databases of Anki collections
+//
+// Only what is needed to read rows of rowid tables is implemented: table b-tree pages, overflow pages and records.
+// Index pages, WITHOUT ROWID tables, free pages and journals are ignored, text must be UTF-8 encoded.
+
+// sqliteHeader is the magic string every SQLite database starts with
+const sqliteHeader = "SQLite format 3\x00"
+
+// B-tree page types
+const (
+	tableInteriorPage = 0x05
+	tableLeafPage     = 0x0d
+)
+
+// maxTreeDepth limits the depth of b-trees in corrupted databases
+const maxTreeDepth = 64
+
+// database is an SQLite database loaded into memory
+type database struct {
+	data       []byte
+	pageSize   int
+	usableSize int // Page size without the reserved space at the end of every page
+	pageCount  int
+}
+
+// row is a row of a rowid table
+type row struct {
+	rowid  int64
+	values []any // int64, float64, string, []byte or nil
+}
+
+// openDatabase validates the header of an SQLite database
+func openDatabase(data []byte) (*database, error) {
+	if len(data) < 100 || !bytes.HasPrefix(data, []byte(sqliteHeader)) {
+		return nil, fmt.Errorf("not an SQLite database")
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("invalid SQLite page size: %d", pageSize)
+	}
+	if encoding := binary.BigEndian.Uint32(data[56:60]); encoding > 1 {
+		return nil, fmt.Errorf("unsupported SQLite text encoding: %d", encoding)
+	}
+	// The page count of the header is trusted only if it fits into the data, older writers may leave it empty
+	pageCount := int(binary.BigEndian.Uint32(data[28:32]))
+	if pageCount == 0 || pageCount > len(data)/pageSize {
+		pageCount = len(data) / pageSize
+	}
+	return &database{
+		data:       data,
+		pageSize:   pageSize,
+		usableSize: pageSize - int(data[20]),
+		pageCount:  pageCount,
+	}, nil
+}
+
+// readTable reads all rows of a table by its name
+func (db *database) readTable(name string) ([]row, error) {
+	// sqlite_master columns are type, name, tbl_name, rootpage and sql
+	master, err := db.readTree(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+	for _, r := range master {
+		if len(r.values) < 4 || r.values[0] != "table" || r.values[1] != name {
+			continue
+		}
+		rootPage, ok := r.values[3].(int64)
+		if !ok || rootPage < 1 {
+			return nil, fmt.Errorf("invalid root page of table %q", name)
+		}
+		rows, err := db.readTree(int(rootPage))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table %q: %w", name, err)
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("table %q not found", name)
+}
+
+// readTree reads all rows of a table b-tree in rowid order
+//
+// Every page is read at most once and pages beyond the page count of the header are out of range,
+// so a corrupted database cannot make the tree larger than the database itself.
+func (db *database) readTree(rootPage int) ([]row, error) {
+	var rows []row
+	visited := make(map[int]bool)
+	var walk func(pageNumber, depth int) error
+	walk = func(pageNumber, depth int) error {
+		if depth > maxTreeDepth {
+			return fmt.Errorf("b-tree is too deep")
+		}
+		if visited[pageNumber] {
+			return fmt.Errorf("page %d is referenced twice", pageNumber)
+		}
+		visited[pageNumber] = true
+		page, headerOffset, err := db.page(pageNumber)
+		if err != nil {
+			return err
+		}
+		if len(page) < headerOffset+8 {
+			return fmt.Errorf("page %d is truncated", pageNumber)
+		}
+		pageType := page[headerOffset]
+		cellCount := int(binary.BigEndian.Uint16(page[headerOffset+3:]))
+		cellPointers := headerOffset + 8
+		if pageType == tableInteriorPage {
+			cellPointers += 4
+		}
+		if len(page) < cellPointers+2*cellCount {
+			return fmt.Errorf("page %d is truncated", pageNumber)
+		}
+
+		for i := range cellCount {
+			offset := int(binary.BigEndian.Uint16(page[cellPointers+2*i:]))
+			if offset >= len(page) {
+				return fmt.Errorf("invalid cell offset on page %d", pageNumber)
+			}
+			switch pageType {
+			case tableInteriorPage:
+				if offset+4 > len(page) {
+					return fmt.Errorf("invalid cell offset on page %d", pageNumber)
+				}
+				if err := walk(int(binary.BigEndian.Uint32(page[offset:])), depth+1); err != nil {
+					return err
+				}
+			case tableLeafPage:
+				r, err := db.leafCell(page[offset:])
+				if err != nil {
+					return fmt.Errorf("failed to read cell on page %d: %w", pageNumber, err)
+				}
+				rows = append(rows, r)
+			default:
+				return fmt.Errorf("unsupported b-tree page type %#x on page %d", pageType, pageNumber)
+			}
+		}
+		if pageType == tableInteriorPage {
+			return walk(int(binary.BigEndian.Uint32(page[headerOffset+8:])), depth+1)
+		}
+		return nil
+	}
+	if err := walk(rootPage, 0); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// page returns the content of a page and the offset of its b-tree header
+//
+// The first page starts with the database header, so its b-tree header is found after it.
+func (db *database) page(number int) ([]byte, int, error) {
+	start := (number - 1) * db.pageSize
+	if number < 1 || number > db.pageCount || start+db.pageSize > len(db.data) {
+		return nil, 0, fmt.Errorf("page %d is out of range", number)
+	}
+	page := db.data[start : start+db.usableSize]
+	if number == 1 {
+		return page, 100, nil
+	}
+	return page, 0, nil
+}
+
+// leafCell reads a row from a cell of a table leaf page
+func (db *database) leafCell(cell []byte) (row, error) {
+	payloadSize, n := readVarint(cell)
+	if n == 0 {
+		return row{}, fmt.Errorf("invalid payload size")
+	}
+	rowid, m := readVarint(cell[n:])
+	if m == 0 {
+		return row{}, fmt.Errorf("invalid rowid")
+	}
+	cell = cell[n+m:]
+
+	payload, err := db.payload(cell, int(payloadSize))
+	if err != nil {
+		return row{}, err
+	}
+	values, err := decodeRecord(payload)
+	if err != nil {
+		return row{}, err
+	}
+	return row{rowid: int64(rowid), values: values}, nil
+}
+
+// payload collects the payload of a table leaf cell, following overflow pages if it does not fit into the page
+func (db *database) payload(cell []byte, size int) ([]byte, error) {
+	if size < 0 || size > len(db.data) {
+		return nil, fmt.Errorf("invalid payload size: %d", size)
+	}
+	local := size
+	maxLocal := db.usableSize - 35
+	if size > maxLocal {
+		minLocal := (db.usableSize-12)*32/255 - 23
+		local = minLocal + (size-minLocal)%(db.usableSize-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if len(cell) < local {
+		return nil, fmt.Errorf("cell is truncated")
+	}
+	if local == size {
+		return cell[:size], nil
+	}
+	if len(cell) < local+4 {
+		return nil, fmt.Errorf("cell is truncated")
+	}
+
+	payload := make([]byte, 0, size)
+	payload = append(payload, cell[:local]...)
+	next := int(binary.BigEndian.Uint32(cell[local:]))
+	for len(payload) < size {
+		page, _, err := db.page(next)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read overflow page: %w", err)
+		}
+		chunk := page[4:]
+		if remaining := size - len(payload); len(chunk) > remaining {
+			chunk = chunk[:remaining]
+		}
+		payload = append(payload, chunk...)
+		next = int(binary.BigEndian.Uint32(page))
+	}
+	return payload, nil
+}
+
+// decodeRecord decodes values of a record in the SQLite record format
+func decodeRecord(record []byte) ([]any, error) {
+	headerSize, n := readVarint(record)
+	if n == 0 || headerSize < uint64(n) || headerSize > uint64(len(record)) {
+		return nil, fmt.Errorf("invalid record header")
+	}
+	header := record[n:headerSize]
+	body := record[headerSize:]
+
+	var values []any
+	for len(header) > 0 {
+		serialType, n := readVarint(header)
+		if n == 0 || n > len(header) {
+			return nil, fmt.Errorf("invalid record header")
+		}
+		header = header[n:]
+
+		size := serialTypeSize(serialType)
+		if size > len(body) {
+			return nil, fmt.Errorf("record is truncated")
+		}
+		content := body[:size]
+		body = body[size:]
+
+		switch {
+		case serialType == 0:
+			values = append(values, nil)
+		case serialType <= 6:
+			// Big-endian two's complement integer of 1, 2, 3, 4, 6 or 8 bytes
+			value := int64(int8(content[0]))
+			for _, b := range content[1:] {
+				value = value<<8 | int64(b)
+			}
+			values = append(values, value)
+		case serialType == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(content)))
+		case serialType == 8:
+			values = append(values, int64(0))
+		case serialType == 9:
+			values = append(values, int64(1))
+		case serialType >= 12 && serialType%2 == 0:
+			values = append(values, bytes.Clone(content))
+		case serialType >= 13:
+			values = append(values, string(content))
+		default:
+			return nil, fmt.Errorf("invalid serial type: %d", serialType)
+		}
+	}
+	return values, nil
+}
+
+// serialTypeSize returns the size in bytes of a value of the serial type
+func serialTypeSize(serialType uint64) int {
+	switch {
+	case serialType <= 4:
+		return int(serialType)
+	case serialType == 5:
+		return 6
+	case serialType == 6 || serialType == 7:
+		return 8
+	case serialType < 12:
+		return 0
+	default:
+		return int((serialType - 12) / 2)
+	}
+}
+
+// readVarint reads a big-endian variable-length integer of 1-9 bytes
+//
+// The number of bytes read is returned together with the value, 0 means the buffer is too short.
+func readVarint(buf []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(buf) {
+			return 0, 0
+		}
+		if i == 8 {
+			return value<<8 | uint64(buf[i]), 9
+		}
+		value = value<<7 | uint64(buf[i]&0x7f)
+		if buf[i]&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return value, 9
+}
@@ -0,0 +1,349 @@
+package anki
+
+import (
+	"cmp"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Write-only creation of SQLite databases for Anki collections
+//
+// Databases are built in memory in a single pass: every table is a rowid table b-tree bulk loaded from its rows,
+// long payloads are moved to overflow pages. Indexes, free pages and journals are not written.
+
+// writerPageSize is the page size of written databases
+const writerPageSize = 4096
+
+// sqliteVersionNumber is stored in the header as the version of SQLite which wrote the database
+const sqliteVersionNumber = 3045000
+
+// databaseWriter collects tables and their rows of a new SQLite database
+type databaseWriter struct {
+	tables []*writerTable
+	pages  [][]byte // Content of pages, page number N is stored at index N-1
+}
+
+// writerTable is a table of a database being written
+type writerTable struct {
+	name string
+	sql  string
+	rows []row
+}
+
+// treeChild is a page of a b-tree level together with the largest rowid stored under it
+type treeChild struct {
+	page   int
+	maxKey int64
+}
+
+// newDatabaseWriter creates an empty database writer
+func newDatabaseWriter() *databaseWriter {
+	return &databaseWriter{}
+}
+
+// createTable adds a table to the database
+//
+// "sql" parameter is the CREATE TABLE statement stored in the schema, it is not parsed.
+func (w *databaseWriter) createTable(name, sql string) *writerTable {
+	t := &writerTable{name: name, sql: sql}
+	w.tables = append(w.tables, t)
+	return t
+}
+
+// insert adds a row to the table
+//
+// Values must be int64, int, float64, string, []byte or nil. A column declared as INTEGER PRIMARY KEY
+// is an alias of the rowid and must be passed as nil.
+func (t *writerTable) insert(rowid int64, values ...any) {
+	t.rows = append(t.rows, row{rowid: rowid, values: values})
+}
+
+// bytes builds the database file
+//
+// If a value of an unsupported type is inserted or the schema does not fit into the first page,
+// the error will be returned together with "nil" value.
+func (w *databaseWriter) bytes() ([]byte, error) {
+	// The first page holds the database header and the schema table, it is filled in the end
+	w.pages = [][]byte{make([]byte, writerPageSize)}
+
+	master := make([]row, len(w.tables))
+	for i, t := range w.tables {
+		slices.SortStableFunc(t.rows, func(a, b row) int { return cmp.Compare(a.rowid, b.rowid) })
+		root, err := w.writeTree(t.rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write table %q: %w", t.name, err)
+		}
+		master[i] = row{rowid: int64(i + 1), values: []any{"table", t.name, t.name, int64(root), t.sql}}
+	}
+
+	cells := make([][]byte, len(master))
+	for i, r := range master {
+		cell, err := w.leafCell(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write schema: %w", err)
+		}
+		cells[i] = cell
+	}
+	if n := fillPage(w.pages[0], 100, tableLeafPage, cells, 0); n != len(cells) {
+		return nil, fmt.Errorf("schema does not fit into the first page")
+	}
+	w.writeHeader()
+
+	data := make([]byte, 0, len(w.pages)*writerPageSize)
+	for _, page := range w.pages {
+		data = append(data, page...)
+	}
+	return data, nil
+}
+
+// writeHeader writes the database header at the start of the first page
+func (w *databaseWriter) writeHeader() {
+	header := w.pages[0][:100]
+	copy(header, sqliteHeader)
+	binary.BigEndian.PutUint16(header[16:], writerPageSize)
+	header[18] = 1                             // Legacy write version
+	header[19] = 1                             // Legacy read version
+	header[20] = 0                             // Reserved space at the end of every page
+	header[21] = 64                            // Maximum embedded payload fraction
+	header[22] = 32                            // Minimum embedded payload fraction
+	header[23] = 32                            // Leaf payload fraction
+	binary.BigEndian.PutUint32(header[24:], 1) // File change counter
+	binary.BigEndian.PutUint32(header[28:], uint32(len(w.pages)))
+	binary.BigEndian.PutUint32(header[40:], 1) // Schema cookie
+	binary.BigEndian.PutUint32(header[44:], 4) // Schema format number
+	binary.BigEndian.PutUint32(header[56:], 1) // UTF-8 text encoding
+	binary.BigEndian.PutUint32(header[92:], 1) // Version-valid-for number, equal to the change counter
+	binary.BigEndian.PutUint32(header[96:], sqliteVersionNumber)
+}
+
+// allocatePage appends an empty page and returns its number
+func (w *databaseWriter) allocatePage() int {
+	w.pages = append(w.pages, make([]byte, writerPageSize))
+	return len(w.pages)
+}
+
+// writeTree writes a table b-tree of rows sorted by rowid and returns its root page
+func (w *databaseWriter) writeTree(rows []row) (int, error) {
+	cells := make([][]byte, len(rows))
+	for i, r := range rows {
+		cell, err := w.leafCell(r)
+		if err != nil {
+			return 0, err
+		}
+		cells[i] = cell
+	}
+
+	// Leaf level, an empty table is a single empty leaf page
+	var level []treeChild
+	for start := 0; start < len(cells) || len(level) == 0; {
+		page := w.allocatePage()
+		n := fillPage(w.pages[page-1], 0, tableLeafPage, cells[start:], 0)
+		if n == 0 && start < len(cells) {
+			return 0, fmt.Errorf("cell of row %d does not fit into a page", rows[start].rowid)
+		}
+		child := treeChild{page: page}
+		if n > 0 {
+			child.maxKey = rows[start+n-1].rowid
+		}
+		level = append(level, child)
+		start += n
+	}
+
+	// Interior levels, every page points to its last child with the right-most pointer
+	for len(level) > 1 {
+		var parents []treeChild
+		for start := 0; start < len(level); {
+			page := w.allocatePage()
+			var children []treeChild
+			used := 12
+			for _, child := range level[start:] {
+				cost := 2 + 4 + len(putVarint(uint64(child.maxKey)))
+				if len(children) > 1 && used+cost > writerPageSize {
+					break
+				}
+				children = append(children, child)
+				used += cost
+			}
+			last := children[len(children)-1]
+			interior := make([][]byte, len(children)-1)
+			for i, child := range children[:len(children)-1] {
+				cell := binary.BigEndian.AppendUint32(nil, uint32(child.page))
+				interior[i] = append(cell, putVarint(uint64(child.maxKey))...)
+			}
+			fillPage(w.pages[page-1], 0, tableInteriorPage, interior, last.page)
+			parents = append(parents, treeChild{page: page, maxKey: last.maxKey})
+			start += len(children)
+		}
+		level = parents
+	}
+	return level[0].page, nil
+}
+
+// leafCell builds a cell of a table leaf page, moving the end of a long payload to overflow pages
+func (w *databaseWriter) leafCell(r row) ([]byte, error) {
+	payload, err := encodeRecord(r.values)
+	if err != nil {
+		return nil, err
+	}
+	cell := putVarint(uint64(len(payload)))
+	cell = append(cell, putVarint(uint64(r.rowid))...)
+
+	// Same computation of the local part as in the reader, the whole page is usable
+	local := len(payload)
+	maxLocal := writerPageSize - 35
+	if local > maxLocal {
+		minLocal := (writerPageSize-12)*32/255 - 23
+		local = minLocal + (len(payload)-minLocal)%(writerPageSize-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	cell = append(cell, payload[:local]...)
+	if local == len(payload) {
+		return cell, nil
+	}
+
+	// Overflow pages are chained, every page starts with the number of the next one
+	rest := payload[local:]
+	first := w.allocatePage()
+	cell = binary.BigEndian.AppendUint32(cell, uint32(first))
+	for page := first; ; {
+		content := w.pages[page-1]
+		n := copy(content[4:], rest)
+		rest = rest[n:]
+		if len(rest) == 0 {
+			break
+		}
+		next := w.allocatePage()
+		binary.BigEndian.PutUint32(content, uint32(next))
+		page = next
+	}
+	return cell, nil
+}
+
+// fillPage writes as many cells as fit into a b-tree page and returns their number
+//
+// "headerOffset" parameter is the offset of the b-tree header, 100 for the first page and 0 for others.
+// "rightPointer" parameter is the right-most child of an interior page.
+// Cell content is placed at the end of the page, cell pointers follow the b-tree header.
+func fillPage(page []byte, headerOffset int, pageType byte, cells [][]byte, rightPointer int) int {
+	headerSize := 8
+	if pageType == tableInteriorPage {
+		headerSize = 12
+	}
+	pointers := headerOffset + headerSize
+	contentStart := len(page)
+
+	n := 0
+	for _, cell := range cells {
+		if pointers+2*(n+1) > contentStart-len(cell) {
+			break
+		}
+		contentStart -= len(cell)
+		copy(page[contentStart:], cell)
+		binary.BigEndian.PutUint16(page[pointers+2*n:], uint16(contentStart))
+		n++
+	}
+
+	header := page[headerOffset:]
+	header[0] = pageType
+	binary.BigEndian.PutUint16(header[3:], uint16(n))
+	// A cell content area starting at 65536 is stored as 0
+	binary.BigEndian.PutUint16(header[5:], uint16(contentStart))
+	if pageType == tableInteriorPage {
+		binary.BigEndian.PutUint32(header[8:], uint32(rightPointer))
+	}
+	return n
+}
+
+// encodeRecord encodes values in the SQLite record format
+func encodeRecord(values []any) ([]byte, error) {
+	var types, body []byte
+	for _, value := range values {
+		var serialType uint64
+		switch v := value.(type) {
+		case nil:
+			serialType = 0
+		case int:
+			serialType, body = appendInteger(body, int64(v))
+		case int64:
+			serialType, body = appendInteger(body, v)
+		case float64:
+			serialType = 7
+			body = binary.BigEndian.AppendUint64(body, math.Float64bits(v))
+		case string:
+			serialType = uint64(len(v))*2 + 13
+			body = append(body, v...)
+		case []byte:
+			serialType = uint64(len(v))*2 + 12
+			body = append(body, v...)
+		default:
+			return nil, fmt.Errorf("unsupported value type: %T", value)
+		}
+		types = append(types, putVarint(serialType)...)
+	}
+
+	// The header size includes the varint of the size itself
+	headerSize := len(types) + 1
+	for len(putVarint(uint64(headerSize))) != headerSize-len(types) {
+		headerSize++
+	}
+	record := putVarint(uint64(headerSize))
+	record = append(record, types...)
+	return append(record, body...), nil
+}
+
+// appendInteger appends an integer in the smallest big-endian form and returns its serial type
+func appendInteger(body []byte, v int64) (uint64, []byte) {
+	switch {
+	case v == 0:
+		return 8, body
+	case v == 1:
+		return 9, body
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return 1, append(body, byte(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return 2, binary.BigEndian.AppendUint16(body, uint16(v))
+	case v >= -1<<23 && v < 1<<23:
+		return 3, append(body, byte(v>>16), byte(v>>8), byte(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return 4, binary.BigEndian.AppendUint32(body, uint32(v))
+	case v >= -1<<47 && v < 1<<47:
+		return 5, append(body, byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+	default:
+		return 6, binary.BigEndian.AppendUint64(body, uint64(v))
+	}
+}
+
+// putVarint encodes a big-endian variable-length integer of 1-9 bytes
+func putVarint(v uint64) []byte {
+	if v > 1<<56-1 {
+		// The ninth byte keeps all 8 bits
+		buf := make([]byte, 9)
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return buf
+	}
+	var groups []byte
+	for {
+		groups = append(groups, byte(v&0x7f))
+		v >>= 7
+		if v == 0 {
+			break
+		}
+	}
+	buf := make([]byte, len(groups))
+	for i, g := range groups {
+		if i > 0 {
+			g |= 0x80
+		}
+		buf[len(groups)-1-i] = g
+	}
+	return buf
+}
@@ -0,0 +1,100 @@
+package anki
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Separator names of the "#separator:" header of Anki text exports
+var separatorNames = map[string]rune{
+	"tab":       '\t',
+	"comma":     ',',
+	"semicolon": ';',
+	"space":     ' ',
+	"pipe":      '|',
+	"colon":     ':',
+}
+
+// ReadText reads notes of a CSV or TSV file
+//
+// "separator" parameter is the default separator of columns, e.g. ',' for CSV or '\t' for TSV.
+//
+// Headers of Anki text exports are supported: "#separator:" overrides the separator, "#columns:" names the columns
+// and "#tags column:" points to the column with space separated tags. Without "#columns:" header
+// the first row is used as column names. Other lines starting with "#" before the rows are ignored.
+// If the file cannot be read, the error will be returned together with "nil" value.
+func ReadText(data []byte, separator rune) ([]Note, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var columnsHeader string
+	tagsColumn := -1
+	for bytes.HasPrefix(data, []byte("#")) {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		key, value, _ := strings.Cut(strings.TrimRight(string(line[1:]), "\r"), ":")
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "separator":
+			if r, ok := separatorNames[strings.ToLower(value)]; ok {
+				separator = r
+			} else if r, size := utf8.DecodeRuneInString(value); size > 0 && size == len(value) {
+				separator = r
+			} else {
+				return nil, fmt.Errorf("invalid separator: %q", value)
+			}
+		case "columns":
+			columnsHeader = value
+		case "tags column":
+			column, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || column < 1 {
+				return nil, fmt.Errorf("invalid tags column: %q", value)
+			}
+			tagsColumn = column - 1
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = separator
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rows: %w", err)
+	}
+
+	var columns []string
+	if columnsHeader != "" {
+		columns = strings.Split(columnsHeader, string(separator))
+	} else {
+		if len(records) == 0 {
+			return nil, fmt.Errorf("header row not found")
+		}
+		columns, records = records[0], records[1:]
+	}
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+
+	notes := make([]Note, 0, len(records))
+	for _, record := range records {
+		note := Note{Fields: make(map[string]string, len(columns))}
+		for i, value := range record {
+			if i == tagsColumn {
+				note.Tags = strings.Fields(value)
+				continue
+			}
+			if i < len(columns) && columns[i] != "" {
+				note.Fields[columns[i]] = value
+			}
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
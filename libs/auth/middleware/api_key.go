@@ -25,3 +25,20 @@ func APIKeyMiddleware(apiKey string) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// APIKeyOrAuthMiddleware lets requests with a valid API key through and passes others to the auth middleware
+// It allows other services to access endpoints which otherwise require user authentication
+func APIKeyOrAuthMiddleware(apiKey string, authMw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		authHandler := authMw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Requests with a matching API key skip user authentication
+			if apiKey != "" && r.Header.Get("X-API-Key") == apiKey {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			authHandler.ServeHTTP(w, r)
+		})
+	}
+}
@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -20,6 +21,9 @@ type Config struct {
 	CORS                 CORSConfig
 	JWT                  JWTConfig
 	SMTP                 SMTPConfig
+	Mastery              MasteryConfig
+	Leech                LeechConfig
+	Locale               LocaleConfig
 	APIKey               string
 	MediaBasePath        string
 	MediaBaseURL         string
@@ -79,6 +83,51 @@ type SMTPConfig struct {
 	From     string
 }
 
+// MasteryConfig holds settings of the character mastery model
+type MasteryConfig struct {
+	LearningRate  float64 // Weight of the newest answer in the exponential moving average
+	PassThreshold float64 // Score from which a character is considered learned
+}
+
+// LeechConfig holds settings of leech detection in dictionary reviews
+type LeechConfig struct {
+	Threshold int    // Lapses after which a word is flagged as a leech, 0 disables leech detection
+	Action    string // "suspend" stops reviews of leeches, "review" moves them to the leech review list
+}
+
+// LocaleConfig holds settings of localized content
+type LocaleConfig struct {
+	Default   string              // Locale used when content has no translation in the requested locale and its fallbacks
+	Fallbacks map[string][]string // Locales tried in order before the default one, by requested locale
+}
+
+// localePattern matches lowercase language codes with an optional region, such as "en" or "pt-br"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// parseLocaleFallbacks parses fallbacks in the "de:en;uk:ru,en" format
+func parseLocaleFallbacks(value string) (map[string][]string, error) {
+	fallbacks := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		locale, chain, found := strings.Cut(entry, ":")
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if !found || !localePattern.MatchString(locale) {
+			return nil, fmt.Errorf("invalid entry %q", entry)
+		}
+		for _, fallback := range strings.Split(chain, ",") {
+			fallback = strings.ToLower(strings.TrimSpace(fallback))
+			if !localePattern.MatchString(fallback) {
+				return nil, fmt.Errorf("invalid fallback %q of locale %s", fallback, locale)
+			}
+			fallbacks[locale] = append(fallbacks[locale], fallback)
+		}
+	}
+	return fallbacks, nil
+}
+
 // Load reads configuration from environment variables
 func Load() (*Config, error) {
 	// Try to load .env file (optional)
@@ -270,6 +319,62 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid IS_DOCKER_CONTAINER: %w", err)
 	}
 
+	// Character mastery configuration (optional, for learn service)
+	masteryRateStr := os.Getenv("MASTERY_LEARNING_RATE")
+	if masteryRateStr == "" {
+		masteryRateStr = "0.3" // default
+	}
+	masteryRate, err := strconv.ParseFloat(masteryRateStr, 64)
+	if err != nil || masteryRate <= 0 || masteryRate > 1 {
+		return nil, fmt.Errorf("invalid MASTERY_LEARNING_RATE: must be a number in (0, 1]")
+	}
+	cfg.Mastery.LearningRate = masteryRate
+
+	masteryThresholdStr := os.Getenv("MASTERY_PASS_THRESHOLD")
+	if masteryThresholdStr == "" {
+		masteryThresholdStr = "0.8" // default
+	}
+	masteryThreshold, err := strconv.ParseFloat(masteryThresholdStr, 64)
+	if err != nil || masteryThreshold <= 0 || masteryThreshold > 1 {
+		return nil, fmt.Errorf("invalid MASTERY_PASS_THRESHOLD: must be a number in (0, 1]")
+	}
+	cfg.Mastery.PassThreshold = masteryThreshold
+
+	// Leech detection configuration (optional, for learn service)
+	leechThresholdStr := os.Getenv("LEECH_THRESHOLD")
+	if leechThresholdStr == "" {
+		leechThresholdStr = "8" // default
+	}
+	leechThreshold, err := strconv.Atoi(leechThresholdStr)
+	if err != nil || leechThreshold < 0 {
+		return nil, fmt.Errorf("invalid LEECH_THRESHOLD: must be a non-negative integer")
+	}
+	cfg.Leech.Threshold = leechThreshold
+
+	leechAction := strings.ToLower(strings.TrimSpace(os.Getenv("LEECH_ACTION")))
+	if leechAction == "" {
+		leechAction = "suspend" // default
+	}
+	if leechAction != "suspend" && leechAction != "review" {
+		return nil, fmt.Errorf("invalid LEECH_ACTION: must be 'suspend' or 'review'")
+	}
+	cfg.Leech.Action = leechAction
+
+	// Localized content configuration (optional, for learn service)
+	defaultLocale := strings.ToLower(strings.TrimSpace(os.Getenv("DEFAULT_LOCALE")))
+	if defaultLocale == "" {
+		defaultLocale = "en" // default
+	}
+	if !localePattern.MatchString(defaultLocale) {
+		return nil, fmt.Errorf("invalid DEFAULT_LOCALE: %s", defaultLocale)
+	}
+	cfg.Locale.Default = defaultLocale
+
+	cfg.Locale.Fallbacks, err = parseLocaleFallbacks(os.Getenv("LOCALE_FALLBACKS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOCALE_FALLBACKS: %w", err)
+	}
+
 	// Learn Service Base URL configuration (optional, for learn service to use inner bridge network)
 	cfg.LearnServiceBaseURL = os.Getenv("LEARN_SERVICE_BASE_URL")
 
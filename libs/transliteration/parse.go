@@ -0,0 +1,249 @@
+package transliteration
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// maxSyllableLength is the length in runes of the longest romanized syllable, e.g. "shi" or "дзя"
+const maxSyllableLength = 3
+
+// latinIndex and cyrillicIndex map romanized syllables of all systems to hiragana
+var latinIndex, cyrillicIndex = func() (map[string]string, map[string]string) {
+	latin := make(map[string]string)
+	cyrillic := make(map[string]string)
+	for _, s := range syllables {
+		for _, romanized := range []string{s.hepburn, s.kunrei, s.nihonShiki} {
+			if _, ok := latin[romanized]; !ok {
+				latin[romanized] = s.kana
+			}
+		}
+		if _, ok := cyrillic[s.polivanov]; !ok {
+			cyrillic[s.polivanov] = s.kana
+		}
+	}
+	// "во" is a common Cyrillic spelling of を
+	cyrillic["во"] = "を"
+	return latin, cyrillic
+}()
+
+// Expansions of vowels with a length mark into two vowels
+var (
+	latinLongVowels = map[rune]string{
+		'ā': "aa", 'ī': "ii", 'ū': "uu", 'ē': "ee", 'ō': "ou",
+		'â': "aa", 'î': "ii", 'û': "uu", 'ê': "ee", 'ô': "ou",
+	}
+	// Second vowel of a long vowel written with a combining mark
+	lengtheningVowels = map[rune]rune{
+		'a': 'a', 'i': 'i', 'u': 'u', 'e': 'e', 'o': 'u',
+		'а': 'а', 'и': 'и', 'у': 'у', 'э': 'э', 'о': 'у', 'я': 'а', 'ю': 'у', 'ё': 'у',
+	}
+)
+
+// ToKana converts romanized text to hiragana
+//
+// Text in any supported system is accepted, systems may even be mixed, e.g. "shi" and "si" both give "し".
+// Latin letters are read as Hepburn, Kunrei-shiki or Nihon-shiki, Cyrillic letters are read as Polivanov.
+// Long vowels may be written with a macron, a circumflex or as two vowels; "ō" gives "おう".
+// Doubled "n" which does not start a syllable is read as a single "ん", so "honn" gives "ほん" and "onna" gives "おんな".
+// Kana in the text is kept (katakana is converted to hiragana), spaces and hyphens are ignored.
+// If the text cannot be read as a romanization, the error will be returned.
+func ToKana(text string) (string, error) {
+	runes := []rune(text)
+	var result strings.Builder
+	for _, s := range toKana(text) {
+		if !s.converted {
+			if strings.Trim(string(runes[s.start:s.end]), " -") == "" {
+				continue
+			}
+			return "", fmt.Errorf("invalid romanization: %q", text)
+		}
+		result.WriteString(s.target)
+	}
+	return result.String(), nil
+}
+
+// sourceRune is a normalized rune of a romanized text
+type sourceRune struct {
+	r          rune
+	start, end int // Rune range in the text the rune was produced from
+}
+
+// normalize lower-cases the text and expands vowels with a length mark into two vowels
+//
+// "е", which is not used by Polivanov, is read as "э".
+func normalize(text string) []sourceRune {
+	runes := []rune(text)
+	normalized := make([]sourceRune, 0, len(runes))
+	for i, r := range runes {
+		r = unicode.ToLower(r)
+		if expanded, ok := latinLongVowels[r]; ok {
+			for _, vowel := range expanded {
+				normalized = append(normalized, sourceRune{r: vowel, start: i, end: i + 1})
+			}
+			continue
+		}
+		if r == macron || r == circumflex {
+			if n := len(normalized); n > 0 {
+				if second, ok := lengtheningVowels[normalized[n-1].r]; ok {
+					normalized = append(normalized, sourceRune{r: second, start: normalized[n-1].start, end: i + 1})
+					continue
+				}
+			}
+		}
+		if r == 'е' {
+			r = 'э'
+		}
+		normalized = append(normalized, sourceRune{r: r, start: i, end: i + 1})
+	}
+	return normalized
+}
+
+// toKana converts romanized text to hiragana and returns aligned segments
+//
+// Characters which are not a part of a romanized syllable are kept as is in unconverted segments.
+func toKana(text string) []segment {
+	runes := []rune(text)
+	normalized := normalize(text)
+	var segments []segment
+	for i := 0; i < len(normalized); {
+		r := normalized[i].r
+		next, afterNext := rune(0), rune(0)
+		if i+1 < len(normalized) {
+			next = normalized[i+1].r
+		}
+		if i+2 < len(normalized) {
+			afterNext = normalized[i+2].r
+		}
+
+		target, length := "", 1
+		switch {
+		case isKana(r):
+			target = ToHiragana(string(r))
+		case r == 'n' && (next == '\'' || (!isVowel(next) && next != 'y')),
+			r == 'н' && (next == 'ъ' || !isVowel(next)):
+			target = string(syllabicN)
+			switch {
+			case next == '\'' || next == 'ъ':
+				length = 2
+			case r == 'n' && next == 'n' && !isVowel(afterNext) && afterNext != 'y' && afterNext != '\'',
+				r == 'н' && next == 'н' && !isVowel(afterNext) && afterNext != 'ъ':
+				// "nn" which does not start a syllable is a common spelling of a single syllabic n, e.g. "honn"
+				length = 2
+			}
+		case r == 'm' && strings.ContainsRune("bpm", next),
+			r == 'м' && strings.ContainsRune("бпм", next):
+			// Syllabic n before labials
+			target = string(syllabicN)
+		case r == 'й':
+			target = "い"
+		case (r == next && unicode.IsLetter(r) && !isVowel(r)) || (r == 't' && next == 'c'):
+			// Sokuon, if the doubled consonant starts a syllable
+			if kana, _ := matchSyllable(normalized, i+1); kana != "" {
+				target = string(sokuon)
+			}
+		default:
+			if kana, matched := matchSyllable(normalized, i); kana != "" {
+				target, length = kana, matched
+			}
+		}
+
+		s := segment{target: target, start: normalized[i].start, end: normalized[i+length-1].end, converted: target != ""}
+		if !s.converted {
+			s.target = string(runes[s.start:s.end])
+		}
+		segments = appendSegment(segments, s)
+		i += length
+	}
+	return segments
+}
+
+// matchSyllable reads the longest romanized syllable starting at the i-th rune
+//
+// The hiragana of the syllable and its length in runes are returned, or an empty string if no syllable matches.
+func matchSyllable(runes []sourceRune, i int) (string, int) {
+	if i >= len(runes) {
+		return "", 0
+	}
+	index := latinIndex
+	if unicode.Is(unicode.Cyrillic, runes[i].r) {
+		index = cyrillicIndex
+	}
+	for length := min(maxSyllableLength, len(runes)-i); length > 0; length-- {
+		var syllable strings.Builder
+		for _, r := range runes[i : i+length] {
+			syllable.WriteRune(r.r)
+		}
+		if kana, ok := index[syllable.String()]; ok {
+			return kana, length
+		}
+	}
+	return "", 0
+}
+
+// isKana reports whether the rune is hiragana, katakana or the prolonged sound mark
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == longVowel
+}
+
+// Equivalent reports whether two spellings denote the same kana reading
+//
+// Each spelling may be kana or a romanization in any supported system, so "shi", "si", "си" and "し" are equivalent.
+// Distinctions which romanization systems do not keep are ignored: "ぢ" and "じ", "づ" and "ず", "を" and "お",
+// as well as "おう" and "おお" are considered equal. Letter case, spaces and hyphens are ignored.
+// If any of the spellings is empty or cannot be read, false is returned.
+func Equivalent(a, b string) bool {
+	kanaA, err := Normalize(a)
+	if err != nil || kanaA == "" {
+		return false
+	}
+	kanaB, err := Normalize(b)
+	if err != nil {
+		return false
+	}
+	return kanaA == kanaB
+}
+
+// Normalize converts a spelling to hiragana with distinctions lost in romanization folded
+//
+// Spellings are equivalent exactly when their normalized forms are equal, see Equivalent.
+// The normalized form is meant for comparison and search keys, e.g. "tōkyō", "toukyou" and "トーキョー" give "とうきょう".
+// If the spelling cannot be read, the error will be returned.
+func Normalize(text string) (string, error) {
+	kana, err := ToKana(text)
+	if err != nil {
+		return "", err
+	}
+	kana = strings.NewReplacer("ぢ", "じ", "づ", "ず", "を", "お").Replace(kana)
+
+	var result strings.Builder
+	var prevVowel byte
+	for _, t := range tokenize(kana) {
+		switch t.kind {
+		case syllableToken:
+			written := t.syllable.kana
+			if prevVowel == 'o' && written == "お" {
+				written = "う"
+			}
+			result.WriteString(written)
+			prevVowel = t.syllable.vowel
+		case longVowelToken:
+			if prevVowel != 0 {
+				result.WriteString(vowelKana[prevVowel])
+			}
+		case sokuonToken:
+			result.WriteRune(sokuon)
+			prevVowel = 0
+		case syllabicNToken:
+			result.WriteRune(syllabicN)
+			prevVowel = 0
+		case otherToken:
+			return "", fmt.Errorf("unsupported kana: %q", string([]rune(kana)[t.start:t.end]))
+		}
+	}
+	return result.String(), nil
+}
+
+// vowelKana maps a vowel sound to the kana lengthening it
+var vowelKana = map[byte]string{'a': "あ", 'i': "い", 'u': "う", 'e': "え", 'o': "う"}
@@ -0,0 +1,150 @@
+package transliteration
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Script is a script Japanese text can be written in: a kana syllabary or a romanization system
+type Script string
+
+const (
+	Hiragana Script = "hiragana"
+	Katakana Script = "katakana"
+)
+
+// ParseScript returns the script with the name
+//
+// Romanization systems are accepted by the names of their System constants. The name is case insensitive.
+// If the script is not supported, the error will be returned.
+func ParseScript(name string) (Script, error) {
+	script := Script(strings.ToLower(strings.TrimSpace(name)))
+	if script == Hiragana || script == Katakana {
+		return script, nil
+	}
+	if _, err := ParseSystem(string(script)); err == nil {
+		return script, nil
+	}
+	return "", fmt.Errorf("invalid script %q, must be 'hiragana', 'katakana', 'hepburn', 'kunrei', 'nihon-shiki' or 'polivanov'", name)
+}
+
+// isKana reports whether the script is a kana syllabary
+func (s Script) isKana() bool {
+	return s == Hiragana || s == Katakana
+}
+
+// Segment is a part of a converted text aligned with the part of the source text it was produced from
+//
+// Characters which cannot be converted (spaces, punctuation, kanji and so on) are kept as is,
+// so their segments have the same source and target.
+type Segment struct {
+	Source string
+	Target string
+}
+
+// segment is a converted part of a text with the rune range of its source
+type segment struct {
+	target     string
+	start, end int  // Rune range of the source in the text
+	converted  bool // False if the source is kept as is
+}
+
+// appendSegment appends the segment to the list
+//
+// A segment overlapping the last one (e.g. both halves of "ō" read as "ou") is merged with it,
+// as well as consecutive unconverted segments.
+func appendSegment(segments []segment, s segment) []segment {
+	if n := len(segments); n > 0 {
+		last := &segments[n-1]
+		if s.start < last.end || (!s.converted && !last.converted && s.start == last.end) {
+			last.target += s.target
+			last.end = max(last.end, s.end)
+			last.converted = last.converted && s.converted
+			return segments
+		}
+	}
+	return append(segments, s)
+}
+
+// Transliterate converts the text from one script to another
+//
+// The converted text is returned split in segments, each of them aligned with the part of the text it was produced from,
+// e.g. "きって" in Hepburn gives "ki", "t", "te" for "き", "っ", "て". Joined targets of the segments form the converted text.
+// Kana text is romanized as described in FromKana and romanized text is read as described in ToKana.
+// Conversion between romanization systems goes through kana, so "tu" in Hepburn gives "tsu".
+// Characters which cannot be converted are kept as is.
+// If any of the scripts is not supported, the error will be returned.
+func Transliterate(text string, from, to Script) ([]Segment, error) {
+	from, err := ParseScript(string(from))
+	if err != nil {
+		return nil, err
+	}
+	to, err = ParseScript(string(to))
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	switch {
+	case from.isKana() && to.isKana():
+		for i, r := range []rune(text) {
+			segments = appendSegment(segments, segment{
+				target:    toKanaScript(string(r), to),
+				start:     i,
+				end:       i + 1,
+				converted: isKana(r),
+			})
+		}
+	case from.isKana():
+		segments = fromKana(text, System(to))
+	case to.isKana():
+		segments = toKana(text)
+		for i := range segments {
+			segments[i].target = toKanaScript(segments[i].target, to)
+		}
+	default:
+		segments = romanizeAgain(toKana(text), System(to))
+	}
+
+	runes := []rune(text)
+	result := make([]Segment, len(segments))
+	for i, s := range segments {
+		result[i] = Segment{Source: string(runes[s.start:s.end]), Target: s.target}
+	}
+	return result, nil
+}
+
+// toKanaScript converts kana in the text to the kana script
+func toKanaScript(text string, script Script) string {
+	if script == Katakana {
+		return ToKatakana(text)
+	}
+	return ToHiragana(text)
+}
+
+// romanizeAgain romanizes kana segments read from a romanized text with the system
+//
+// Segments of the result are aligned with the romanized text the kana segments were read from.
+func romanizeAgain(kanaSegments []segment, system System) []segment {
+	var kana strings.Builder
+	// owners maps every rune of the kana text to its segment
+	var owners []int
+	for i, s := range kanaSegments {
+		kana.WriteString(s.target)
+		for range []rune(s.target) {
+			owners = append(owners, i)
+		}
+	}
+
+	var segments []segment
+	for _, s := range fromKana(kana.String(), system) {
+		first, last := kanaSegments[owners[s.start]], kanaSegments[owners[s.end-1]]
+		segments = appendSegment(segments, segment{
+			target:    s.target,
+			start:     first.start,
+			end:       last.end,
+			converted: s.converted,
+		})
+	}
+	return segments
+}
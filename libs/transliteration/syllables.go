@@ -0,0 +1,212 @@
+package transliteration
+
+import "slices"
+
+// syllable describes a hiragana syllable with its romanization in every supported system
+type syllable struct {
+	kana       string
+	vowel      byte // Vowel sound of the syllable: 'a', 'i', 'u', 'e' or 'o'
+	hepburn    string
+	kunrei     string
+	nihonShiki string
+	polivanov  string
+}
+
+// render returns the romanization of the syllable in the system
+func (s *syllable) render(system System) string {
+	switch system {
+	case Kunrei:
+		return s.kunrei
+	case NihonShiki:
+		return s.nihonShiki
+	case Polivanov:
+		return s.polivanov
+	default:
+		return s.hepburn
+	}
+}
+
+// syllables lists supported hiragana syllables
+//
+// The order matters for reverse lookup: when several syllables share a romanization
+// (e.g. "zi" for both じ and ぢ), the syllable listed first is used.
+// Native syllables are listed before loanword ones, so shared spellings are read as native syllables.
+var syllables = slices.Concat(nativeSyllables, loanwordSyllables)
+
+// nativeSyllables lists syllables of native Japanese words
+var nativeSyllables = []syllable{
+	{"あ", 'a', "a", "a", "a", "а"},
+	{"い", 'i', "i", "i", "i", "и"},
+	{"う", 'u', "u", "u", "u", "у"},
+	{"え", 'e', "e", "e", "e", "э"},
+	{"お", 'o', "o", "o", "o", "о"},
+
+	{"か", 'a', "ka", "ka", "ka", "ка"},
+	{"き", 'i', "ki", "ki", "ki", "ки"},
+	{"く", 'u', "ku", "ku", "ku", "ку"},
+	{"け", 'e', "ke", "ke", "ke", "кэ"},
+	{"こ", 'o', "ko", "ko", "ko", "ко"},
+
+	{"さ", 'a', "sa", "sa", "sa", "са"},
+	{"し", 'i', "shi", "si", "si", "си"},
+	{"す", 'u', "su", "su", "su", "су"},
+	{"せ", 'e', "se", "se", "se", "сэ"},
+	{"そ", 'o', "so", "so", "so", "со"},
+
+	{"た", 'a', "ta", "ta", "ta", "та"},
+	{"ち", 'i', "chi", "ti", "ti", "ти"},
+	{"つ", 'u', "tsu", "tu", "tu", "цу"},
+	{"て", 'e', "te", "te", "te", "тэ"},
+	{"と", 'o', "to", "to", "to", "то"},
+
+	{"な", 'a', "na", "na", "na", "на"},
+	{"に", 'i', "ni", "ni", "ni", "ни"},
+	{"ぬ", 'u', "nu", "nu", "nu", "ну"},
+	{"ね", 'e', "ne", "ne", "ne", "нэ"},
+	{"の", 'o', "no", "no", "no", "но"},
+
+	{"は", 'a', "ha", "ha", "ha", "ха"},
+	{"ひ", 'i', "hi", "hi", "hi", "хи"},
+	{"ふ", 'u', "fu", "hu", "hu", "фу"},
+	{"へ", 'e', "he", "he", "he", "хэ"},
+	{"ほ", 'o', "ho", "ho", "ho", "хо"},
+
+	{"ま", 'a', "ma", "ma", "ma", "ма"},
+	{"み", 'i', "mi", "mi", "mi", "ми"},
+	{"む", 'u', "mu", "mu", "mu", "му"},
+	{"め", 'e', "me", "me", "me", "мэ"},
+	{"も", 'o', "mo", "mo", "mo", "мо"},
+
+	{"や", 'a', "ya", "ya", "ya", "я"},
+	{"ゆ", 'u', "yu", "yu", "yu", "ю"},
+	{"よ", 'o', "yo", "yo", "yo", "ё"},
+
+	{"ら", 'a', "ra", "ra", "ra", "ра"},
+	{"り", 'i', "ri", "ri", "ri", "ри"},
+	{"る", 'u', "ru", "ru", "ru", "ру"},
+	{"れ", 'e', "re", "re", "re", "рэ"},
+	{"ろ", 'o', "ro", "ro", "ro", "ро"},
+
+	{"わ", 'a', "wa", "wa", "wa", "ва"},
+	{"を", 'o', "o", "o", "wo", "о"},
+
+	{"が", 'a', "ga", "ga", "ga", "га"},
+	{"ぎ", 'i', "gi", "gi", "gi", "ги"},
+	{"ぐ", 'u', "gu", "gu", "gu", "гу"},
+	{"げ", 'e', "ge", "ge", "ge", "гэ"},
+	{"ご", 'o', "go", "go", "go", "го"},
+
+	{"ざ", 'a', "za", "za", "za", "дза"},
+	{"じ", 'i', "ji", "zi", "zi", "дзи"},
+	{"ず", 'u', "zu", "zu", "zu", "дзу"},
+	{"ぜ", 'e', "ze", "ze", "ze", "дзэ"},
+	{"ぞ", 'o', "zo", "zo", "zo", "дзо"},
+
+	{"だ", 'a', "da", "da", "da", "да"},
+	{"ぢ", 'i', "ji", "zi", "di", "дзи"},
+	{"づ", 'u', "zu", "zu", "du", "дзу"},
+	{"で", 'e', "de", "de", "de", "дэ"},
+	{"ど", 'o', "do", "do", "do", "до"},
+
+	{"ば", 'a', "ba", "ba", "ba", "ба"},
+	{"び", 'i', "bi", "bi", "bi", "би"},
+	{"ぶ", 'u', "bu", "bu", "bu", "бу"},
+	{"べ", 'e', "be", "be", "be", "бэ"},
+	{"ぼ", 'o', "bo", "bo", "bo", "бо"},
+
+	{"ぱ", 'a', "pa", "pa", "pa", "па"},
+	{"ぴ", 'i', "pi", "pi", "pi", "пи"},
+	{"ぷ", 'u', "pu", "pu", "pu", "пу"},
+	{"ぺ", 'e', "pe", "pe", "pe", "пэ"},
+	{"ぽ", 'o', "po", "po", "po", "по"},
+
+	{"きゃ", 'a', "kya", "kya", "kya", "кя"},
+	{"きゅ", 'u', "kyu", "kyu", "kyu", "кю"},
+	{"きょ", 'o', "kyo", "kyo", "kyo", "кё"},
+
+	{"しゃ", 'a', "sha", "sya", "sya", "ся"},
+	{"しゅ", 'u', "shu", "syu", "syu", "сю"},
+	{"しょ", 'o', "sho", "syo", "syo", "сё"},
+
+	{"ちゃ", 'a', "cha", "tya", "tya", "тя"},
+	{"ちゅ", 'u', "chu", "tyu", "tyu", "тю"},
+	{"ちょ", 'o', "cho", "tyo", "tyo", "тё"},
+
+	{"にゃ", 'a', "nya", "nya", "nya", "ня"},
+	{"にゅ", 'u', "nyu", "nyu", "nyu", "ню"},
+	{"にょ", 'o', "nyo", "nyo", "nyo", "нё"},
+
+	{"ひゃ", 'a', "hya", "hya", "hya", "хя"},
+	{"ひゅ", 'u', "hyu", "hyu", "hyu", "хю"},
+	{"ひょ", 'o', "hyo", "hyo", "hyo", "хё"},
+
+	{"みゃ", 'a', "mya", "mya", "mya", "мя"},
+	{"みゅ", 'u', "myu", "myu", "myu", "мю"},
+	{"みょ", 'o', "myo", "myo", "myo", "мё"},
+
+	{"りゃ", 'a', "rya", "rya", "rya", "ря"},
+	{"りゅ", 'u', "ryu", "ryu", "ryu", "рю"},
+	{"りょ", 'o', "ryo", "ryo", "ryo", "рё"},
+
+	{"ぎゃ", 'a', "gya", "gya", "gya", "гя"},
+	{"ぎゅ", 'u', "gyu", "gyu", "gyu", "гю"},
+	{"ぎょ", 'o', "gyo", "gyo", "gyo", "гё"},
+
+	{"じゃ", 'a', "ja", "zya", "zya", "дзя"},
+	{"じゅ", 'u', "ju", "zyu", "zyu", "дзю"},
+	{"じょ", 'o', "jo", "zyo", "zyo", "дзё"},
+
+	{"ぢゃ", 'a', "ja", "zya", "dya", "дзя"},
+	{"ぢゅ", 'u', "ju", "zyu", "dyu", "дзю"},
+	{"ぢょ", 'o', "jo", "zyo", "dyo", "дзё"},
+
+	{"びゃ", 'a', "bya", "bya", "bya", "бя"},
+	{"びゅ", 'u', "byu", "byu", "byu", "бю"},
+	{"びょ", 'o', "byo", "byo", "byo", "бё"},
+
+	{"ぴゃ", 'a', "pya", "pya", "pya", "пя"},
+	{"ぴゅ", 'u', "pyu", "pyu", "pyu", "пю"},
+	{"ぴょ", 'o', "pyo", "pyo", "pyo", "пё"},
+}
+
+// loanwordSyllables lists syllables written with small vowels and ゔ, which are used in loanwords
+//
+// Kunrei-shiki and Nihon-shiki have no spellings for these sounds, so Hepburn ones are used, except for
+// palatal syllables of the e row, which are written with "y" like other palatal syllables of these systems.
+// Some spellings are shared with native syllables (e.g. "ti" is ち in Kunrei-shiki), such spellings are read back
+// as the native syllable. Polivanov uses "в" for both ゔ and う before small vowels, as Russian does.
+var loanwordSyllables = []syllable{
+	{"いぇ", 'e', "ye", "ye", "ye", "йэ"},
+
+	{"しぇ", 'e', "she", "sye", "sye", "сэ"},
+	{"ちぇ", 'e', "che", "tye", "tye", "тэ"},
+	{"じぇ", 'e', "je", "zye", "zye", "дзэ"},
+
+	{"てぃ", 'i', "ti", "ti", "ti", "ти"},
+	{"とぅ", 'u', "tu", "tu", "tu", "ту"},
+	{"てゅ", 'u', "tyu", "tyu", "tyu", "тю"},
+	{"でぃ", 'i', "di", "di", "di", "ди"},
+	{"どぅ", 'u', "du", "du", "du", "ду"},
+	{"でゅ", 'u', "dyu", "dyu", "dyu", "дю"},
+
+	{"つぁ", 'a', "tsa", "tsa", "tsa", "ца"},
+	{"つぃ", 'i', "tsi", "tsi", "tsi", "ци"},
+	{"つぇ", 'e', "tse", "tse", "tse", "цэ"},
+	{"つぉ", 'o', "tso", "tso", "tso", "цо"},
+
+	{"ふぁ", 'a', "fa", "fa", "fa", "фа"},
+	{"ふぃ", 'i', "fi", "fi", "fi", "фи"},
+	{"ふぇ", 'e', "fe", "fe", "fe", "фэ"},
+	{"ふぉ", 'o', "fo", "fo", "fo", "фо"},
+	{"ふゅ", 'u', "fyu", "fyu", "fyu", "фю"},
+
+	{"ゔぁ", 'a', "va", "va", "va", "ва"},
+	{"ゔぃ", 'i', "vi", "vi", "vi", "ви"},
+	{"ゔ", 'u', "vu", "vu", "vu", "ву"},
+	{"ゔぇ", 'e', "ve", "ve", "ve", "вэ"},
+	{"ゔぉ", 'o', "vo", "vo", "vo", "во"},
+
+	{"うぃ", 'i', "wi", "wi", "wi", "ви"},
+	{"うぇ", 'e', "we", "we", "we", "вэ"},
+	{"うぉ", 'o', "wo", "wo", "wo", "во"},
+}
@@ -0,0 +1,301 @@
+// Package transliteration converts Japanese kana to and from romanized spellings.
+//
+// Supported systems are Hepburn, Kunrei-shiki and Nihon-shiki romanization
+// and Polivanov Cyrillic transcription.
+package transliteration
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// System is a romanization system
+type System string
+
+const (
+	Hepburn    System = "hepburn"
+	Kunrei     System = "kunrei"
+	NihonShiki System = "nihon-shiki"
+	Polivanov  System = "polivanov"
+)
+
+// Systems lists all supported romanization systems
+var Systems = []System{Hepburn, Kunrei, NihonShiki, Polivanov}
+
+// ParseSystem returns the romanization system with the name
+//
+// The name is case insensitive.
+// If the system is not supported, the error will be returned.
+func ParseSystem(name string) (System, error) {
+	system := System(strings.ToLower(strings.TrimSpace(name)))
+	for _, s := range Systems {
+		if s == system {
+			return system, nil
+		}
+	}
+	return "", fmt.Errorf("invalid romanization system, must be 'hepburn', 'kunrei', 'nihon-shiki' or 'polivanov'")
+}
+
+const (
+	sokuon     = 'っ'
+	syllabicN  = 'ん'
+	longVowel  = 'ー'
+	macron     = '̄' // Combining macron
+	circumflex = '̂' // Combining circumflex accent
+)
+
+// Long vowels in Latin systems: Hepburn uses macrons, Kunrei-shiki and Nihon-shiki use circumflexes
+var (
+	hepburnLongVowels = map[rune]rune{'a': 'ā', 'i': 'ī', 'u': 'ū', 'e': 'ē', 'o': 'ō'}
+	kunreiLongVowels  = map[rune]rune{'a': 'â', 'i': 'î', 'u': 'û', 'e': 'ê', 'o': 'ô'}
+)
+
+// ToHiragana converts katakana in the text to hiragana, other characters are kept as is
+func ToHiragana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - ('ァ' - 'ぁ')
+		}
+		return r
+	}, text)
+}
+
+// ToKatakana converts hiragana in the text to katakana, other characters are kept as is
+func ToKatakana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + ('ァ' - 'ぁ')
+		}
+		return r
+	}, text)
+}
+
+// tokenKind is a kind of a kana text token
+type tokenKind int
+
+const (
+	syllableToken tokenKind = iota
+	sokuonToken
+	syllabicNToken
+	longVowelToken
+	otherToken // Any character which is not supported kana
+)
+
+// token is a single unit of a kana text
+type token struct {
+	kind       tokenKind
+	syllable   *syllable // Set for syllable tokens only
+	start, end int       // Rune range of the token in the text
+}
+
+// kanaIndex maps hiragana of a syllable to its description
+var kanaIndex = func() map[string]*syllable {
+	index := make(map[string]*syllable, len(syllables))
+	for i := range syllables {
+		index[syllables[i].kana] = &syllables[i]
+	}
+	return index
+}()
+
+// tokenize splits kana text into tokens
+//
+// Katakana is treated as hiragana. Characters which are not supported kana become other tokens.
+func tokenize(text string) []token {
+	runes := []rune(ToHiragana(text))
+	tokens := make([]token, 0, len(runes))
+	for i := 0; i < len(runes); {
+		t := token{kind: otherToken, start: i, end: i + 1}
+		switch runes[i] {
+		case sokuon:
+			t.kind = sokuonToken
+		case syllabicN:
+			t.kind = syllabicNToken
+		case longVowel:
+			t.kind = longVowelToken
+		default:
+			if i+1 < len(runes) {
+				if s, ok := kanaIndex[string(runes[i:i+2])]; ok {
+					t = token{kind: syllableToken, syllable: s, start: i, end: i + 2}
+					break
+				}
+			}
+			if s, ok := kanaIndex[string(runes[i])]; ok {
+				t = token{kind: syllableToken, syllable: s, start: i, end: i + 1}
+			}
+		}
+		tokens = append(tokens, t)
+		i = t.end
+	}
+	return tokens
+}
+
+// FromKana romanizes kana text with the system
+//
+// Hiragana and katakana are both accepted.
+// Sokuon doubles the following consonant ("tch" before "ch" in Hepburn), syllabic n is followed
+// by an apostrophe before vowels and "y" (in Polivanov it is "нъ" before vowels and "м" before labials).
+// Long vowels, written with a prolonged sound mark or as "aa", "uu", "ee", "oo" and "ou",
+// are marked with a macron in Hepburn and Polivanov and with a circumflex in Kunrei-shiki and Nihon-shiki.
+// If the text contains characters which are not supported kana, sokuon not followed by a syllable,
+// a prolonged sound mark not following a syllable or the system is not supported, the error will be returned.
+func FromKana(text string, system System) (string, error) {
+	if _, err := ParseSystem(string(system)); err != nil {
+		return "", err
+	}
+	runes := []rune(text)
+	var result strings.Builder
+	for _, s := range fromKana(text, system) {
+		if !s.converted {
+			source := string(runes[s.start:s.end])
+			switch []rune(ToHiragana(source))[0] {
+			case sokuon:
+				return "", fmt.Errorf("sokuon is not followed by a syllable: %q", text)
+			case longVowel:
+				return "", fmt.Errorf("prolonged sound mark does not follow a syllable: %q", text)
+			}
+			return "", fmt.Errorf("unsupported kana: %q", source)
+		}
+		result.WriteString(s.target)
+	}
+	return result.String(), nil
+}
+
+// fromKana romanizes kana text with the system and returns aligned segments
+//
+// Characters which are not supported kana are kept as is in unconverted segments.
+func fromKana(text string, system System) []segment {
+	runes := []rune(text)
+	tokens := tokenize(text)
+	segments := make([]segment, 0, len(tokens))
+	// prev is the previous syllable which can be lengthened by the current one
+	var prev *syllable
+	for i, t := range tokens {
+		s := segment{start: t.start, end: t.end, converted: true}
+		switch t.kind {
+		case syllableToken:
+			if prev != nil && lengthens(prev.vowel, t.syllable) {
+				extendLast(segments, t.end, system)
+				prev = nil
+				continue
+			}
+			if system == Polivanov && t.syllable.kana == "い" && prev != nil && prev.vowel != 'i' {
+				s.target = "й"
+				prev = nil
+				break
+			}
+			s.target = t.syllable.render(system)
+			prev = t.syllable
+		case sokuonToken:
+			prev = nil
+			next := nextSyllable(tokens, i, system)
+			if next == "" {
+				// Sokuon doubles the following consonant, so it cannot be romanized without a syllable after it
+				s.target = string(runes[t.start:t.end])
+				s.converted = false
+				break
+			}
+			first, _ := utf8.DecodeRuneInString(next)
+			switch {
+			case isVowel(first):
+			case system == Hepburn && strings.HasPrefix(next, "ch"):
+				s.target = "t"
+			default:
+				s.target = string(first)
+			}
+		case syllabicNToken:
+			next := nextSyllable(tokens, i, system)
+			first, _ := utf8.DecodeRuneInString(next)
+			switch {
+			case system != Polivanov && (isVowel(first) || first == 'y'):
+				s.target = "n'"
+			case system != Polivanov:
+				s.target = "n"
+			case isVowel(first):
+				s.target = "нъ"
+			case first == 'б' || first == 'п' || first == 'м':
+				s.target = "м"
+			default:
+				s.target = "н"
+			}
+			prev = nil
+		case longVowelToken:
+			prev = nil
+			if len(segments) > 0 && segments[len(segments)-1].converted {
+				extendLast(segments, t.end, system)
+				continue
+			}
+			// The prolonged sound mark lengthens the previous vowel, so it cannot be romanized without one
+			s.target = string(runes[t.start:t.end])
+			s.converted = false
+		case otherToken:
+			s.target = string(runes[t.start:t.end])
+			s.converted = false
+			prev = nil
+		}
+		segments = appendSegment(segments, s)
+	}
+	return segments
+}
+
+// extendLast extends the last segment up to the end of a kana lengthening its vowel
+func extendLast(segments []segment, end int, system System) {
+	last := &segments[len(segments)-1]
+	last.target = lengthen(last.target, system)
+	last.end = end
+}
+
+// nextSyllable returns the romanization of the syllable following the i-th token
+//
+// An empty string is returned if the token is not followed by a syllable.
+func nextSyllable(tokens []token, i int, system System) string {
+	if i+1 >= len(tokens) || tokens[i+1].kind != syllableToken {
+		return ""
+	}
+	return tokens[i+1].syllable.render(system)
+}
+
+// lengthens reports whether the syllable lengthens the previous vowel sound
+func lengthens(prevVowel byte, s *syllable) bool {
+	switch s.kana {
+	case "あ":
+		return prevVowel == 'a'
+	case "う":
+		return prevVowel == 'u' || prevVowel == 'o'
+	case "え":
+		return prevVowel == 'e'
+	case "お":
+		return prevVowel == 'o'
+	}
+	return false
+}
+
+// lengthen marks the last vowel of the romanized text as long in the system
+//
+// The text is returned unchanged if it does not end with a vowel.
+func lengthen(text string, system System) string {
+	last, size := utf8.DecodeLastRuneInString(text)
+	if size == 0 {
+		return text
+	}
+	switch system {
+	case Polivanov:
+		if isVowel(last) {
+			return text + string(macron)
+		}
+	case Kunrei, NihonShiki:
+		if long, ok := kunreiLongVowels[last]; ok {
+			return text[:len(text)-size] + string(long)
+		}
+	default:
+		if long, ok := hepburnLongVowels[last]; ok {
+			return text[:len(text)-size] + string(long)
+		}
+	}
+	return text
+}
+
+// isVowel reports whether the rune is a Latin or Cyrillic vowel letter
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aiueoаиуэояюёе", r)
+}
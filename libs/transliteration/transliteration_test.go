@@ -0,0 +1,420 @@
+package transliteration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSystem(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      System
+		expectedError bool
+	}{
+		{name: "hepburn", input: "hepburn", expected: Hepburn},
+		{name: "case insensitive", input: "Kunrei", expected: Kunrei},
+		{name: "nihon-shiki", input: "nihon-shiki", expected: NihonShiki},
+		{name: "polivanov", input: " polivanov ", expected: Polivanov},
+		{name: "unknown system", input: "wapuro", expectedError: true},
+		{name: "empty", input: "", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			system, err := ParseSystem(tt.input)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, system)
+		})
+	}
+}
+
+func TestKanaScriptConversion(t *testing.T) {
+	assert.Equal(t, "しんかんせん", ToHiragana("シンカンセン"))
+	assert.Equal(t, "らーめん", ToHiragana("ラーメン"))
+	assert.Equal(t, "シンカンセン", ToKatakana("しんかんせん"))
+	assert.Equal(t, "abc カ", ToKatakana("abc か"))
+}
+
+func TestFromKana(t *testing.T) {
+	tests := []struct {
+		kana      string
+		hepburn   string
+		kunrei    string
+		nihon     string
+		polivanov string
+	}{
+		{"し", "shi", "si", "si", "си"},
+		{"つ", "tsu", "tu", "tu", "цу"},
+		{"ふ", "fu", "hu", "hu", "фу"},
+		{"じ", "ji", "zi", "zi", "дзи"},
+		{"ぢ", "ji", "zi", "di", "дзи"},
+		{"づ", "zu", "zu", "du", "дзу"},
+		{"を", "o", "o", "wo", "о"},
+		{"しゃ", "sha", "sya", "sya", "ся"},
+		{"ちょ", "cho", "tyo", "tyo", "тё"},
+		{"ぢゃ", "ja", "zya", "dya", "дзя"},
+		{"きって", "kitte", "kitte", "kitte", "киттэ"},
+		{"まっちゃ", "matcha", "mattya", "mattya", "маття"},
+		{"こんや", "kon'ya", "kon'ya", "kon'ya", "конъя"},
+		{"きんえん", "kin'en", "kin'en", "kin'en", "кинъэн"},
+		{"しんぶん", "shinbun", "sinbun", "sinbun", "симбун"},
+		{"とうきょう", "tōkyō", "tôkyô", "tôkyô", "то̄кё̄"},
+		{"おおさか", "ōsaka", "ôsaka", "ôsaka", "о̄сака"},
+		{"おかあさん", "okāsan", "okâsan", "okâsan", "ока̄сан"},
+		{"せんせい", "sensei", "sensei", "sensei", "сэнсэй"},
+		{"ラーメン", "rāmen", "râmen", "râmen", "ра̄мэн"},
+		{"スーパー", "sūpā", "sûpâ", "sûpâ", "сӯпа̄"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			for system, expected := range map[System]string{
+				Hepburn:    tt.hepburn,
+				Kunrei:     tt.kunrei,
+				NihonShiki: tt.nihon,
+				Polivanov:  tt.polivanov,
+			} {
+				result, err := FromKana(tt.kana, system)
+				require.NoError(t, err)
+				assert.Equal(t, expected, result, "system %s", system)
+			}
+		})
+	}
+}
+
+func TestFromKana_Errors(t *testing.T) {
+	_, err := FromKana("かな", System("wapuro"))
+	assert.Error(t, err)
+
+	_, err = FromKana("漢字", Hepburn)
+	assert.Error(t, err)
+
+	_, err = FromKana("kana", Hepburn)
+	assert.Error(t, err)
+
+	_, err = FromKana("っ", Hepburn)
+	assert.EqualError(t, err, `sokuon is not followed by a syllable: "っ"`)
+
+	_, err = FromKana("あっ", Polivanov)
+	assert.EqualError(t, err, `sokuon is not followed by a syllable: "あっ"`)
+
+	_, err = FromKana("ー", Hepburn)
+	assert.EqualError(t, err, `prolonged sound mark does not follow a syllable: "ー"`)
+
+	_, err = FromKana("ーカ", Kunrei)
+	assert.EqualError(t, err, `prolonged sound mark does not follow a syllable: "ーカ"`)
+}
+
+func TestToKana(t *testing.T) {
+	tests := []struct {
+		input         string
+		expected      string
+		expectedError bool
+	}{
+		{input: "shi", expected: "し"},
+		{input: "si", expected: "し"},
+		{input: "tsu", expected: "つ"},
+		{input: "tu", expected: "つ"},
+		{input: "zi", expected: "じ"},
+		{input: "di", expected: "ぢ"},
+		{input: "wo", expected: "を"},
+		{input: "SHA", expected: "しゃ"},
+		{input: "sya", expected: "しゃ"},
+		{input: "kitte", expected: "きって"},
+		{input: "matcha", expected: "まっちゃ"},
+		{input: "mattya", expected: "まっちゃ"},
+		{input: "kon'ya", expected: "こんや"},
+		{input: "konya", expected: "こにゃ"},
+		{input: "konnichiwa", expected: "こんにちわ"},
+		{input: "konnnichiwa", expected: "こんにちわ"},
+		{input: "onna", expected: "おんな"},
+		{input: "honn", expected: "ほん"},
+		{input: "shinnbun", expected: "しんぶん"},
+		{input: "shinnyuu", expected: "しんにゅう"},
+		{input: "kinnen", expected: "きんねん"},
+		{input: "хонн", expected: "ほん"},
+		{input: "онна", expected: "おんな"},
+		{input: "shinbun", expected: "しんぶん"},
+		{input: "shimbun", expected: "しんぶん"},
+		{input: "hon", expected: "ほん"},
+		{input: "tōkyō", expected: "とうきょう"},
+		{input: "tôkyô", expected: "とうきょう"},
+		{input: "tōkyō", expected: "とうきょう"},
+		{input: "okāsan", expected: "おかあさん"},
+		{input: "sensei", expected: "せんせい"},
+		{input: "ni-hon", expected: "にほん"},
+		{input: "си", expected: "し"},
+		{input: "цу", expected: "つ"},
+		{input: "дзя", expected: "じゃ"},
+		{input: "киттэ", expected: "きって"},
+		{input: "конъя", expected: "こんや"},
+		{input: "симбун", expected: "しんぶん"},
+		{input: "сэнсэй", expected: "せんせい"},
+		{input: "сенсей", expected: "せんせい"},
+		{input: "то̄кё̄", expected: "とうきょう"},
+		{input: "Во", expected: "を"},
+		{input: "カタカナ", expected: "かたかな"},
+		{input: "fairu", expected: "ふぁいる"},
+		{input: "shefu", expected: "しぇふ"},
+		{input: "syehu", expected: "しぇふ"},
+		{input: "chekku", expected: "ちぇっく"},
+		{input: "jetto", expected: "じぇっと"},
+		{input: "tsā", expected: "つぁあ"},
+		{input: "webu", expected: "うぇぶ"},
+		{input: "vaiorin", expected: "ゔぁいおりん"},
+		{input: "ti", expected: "ち"},
+		{input: "дисуку", expected: "でぃすく"},
+		{input: "вики", expected: "ゔぃき"},
+		{input: "", expected: ""},
+		{input: "xyz", expectedError: true},
+		{input: "ши", expectedError: true},
+		{input: "l", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ToKana(tt.input)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestToKana_RoundTrip(t *testing.T) {
+	// Loanword syllables are left out, some of their spellings are read back as native syllables
+	for _, s := range nativeSyllables {
+		for _, system := range Systems {
+			romanized, err := FromKana(s.kana, system)
+			require.NoError(t, err)
+
+			kana, err := ToKana(romanized)
+			require.NoError(t, err)
+			assert.True(t, Equivalent(s.kana, kana), "%s -> %s (%s) -> %s", s.kana, romanized, system, kana)
+		}
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{name: "hepburn and kunrei", a: "shi", b: "si", expected: true},
+		{name: "hepburn and nihon-shiki", a: "ji", b: "di", expected: true},
+		{name: "zu and du", a: "zu", b: "du", expected: true},
+		{name: "tsu and tu", a: "tsu", b: "TU", expected: true},
+		{name: "wo and o", a: "wo", b: "o", expected: true},
+		{name: "polivanov and hepburn", a: "си", b: "shi", expected: true},
+		{name: "kana and romaji", a: "シ", b: "shi", expected: true},
+		{name: "yoon", a: "chu", b: "tyu", expected: true},
+		{name: "macron and doubled vowel", a: "tōkyō", b: "toukyou", expected: true},
+		{name: "macron and oo", a: "ōsaka", b: "oosaka", expected: true},
+		{name: "prolonged sound mark", a: "ラーメン", b: "raamen", expected: true},
+		{name: "circumflex", a: "tôkyô", b: "tōkyō", expected: true},
+		{name: "syllabic n with apostrophe", a: "kin'en", b: "きんえん", expected: true},
+		{name: "loanword in katakana and romaji", a: "ファイル", b: "fairu", expected: true},
+		{name: "small vowel is not a full one", a: "ファイル", b: "ふあいる", expected: false},
+		{name: "different syllables", a: "shi", b: "chi", expected: false},
+		{name: "different length", a: "tōkyō", b: "tokyo", expected: false},
+		{name: "syllabic n is not na", a: "kin'en", b: "kinen", expected: false},
+		{name: "empty answer", a: "shi", b: "", expected: false},
+		{name: "both empty", a: "", b: "", expected: false},
+		{name: "invalid spelling", a: "shi", b: "shy", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Equivalent(tt.a, tt.b))
+			assert.Equal(t, tt.expected, Equivalent(tt.b, tt.a))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "hiragana", text: "たべる", expected: "たべる"},
+		{name: "katakana", text: "タベル", expected: "たべる"},
+		{name: "hepburn", text: "taberu", expected: "たべる"},
+		{name: "kunrei", text: "tukau", expected: "つかう"},
+		{name: "long vowels", text: "トーキョー", expected: "とうきょう"},
+		{name: "macron", text: "Tōkyō", expected: "とうきょう"},
+		{name: "oo folded", text: "おおきい", expected: "おうきい"},
+		{name: "loanword", text: "パーティー", expected: "ぱあてぃい"},
+		{name: "empty", text: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Normalize(tt.text)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	t.Run("invalid spelling", func(t *testing.T) {
+		_, err := Normalize("water")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseScript(t *testing.T) {
+	for _, name := range []string{"hiragana", "Katakana", "hepburn", "kunrei", "nihon-shiki", "polivanov"} {
+		_, err := ParseScript(name)
+		assert.NoError(t, err, name)
+	}
+
+	_, err := ParseScript("kanji")
+	assert.EqualError(t, err, `invalid script "kanji", must be 'hiragana', 'katakana', 'hepburn', 'kunrei', 'nihon-shiki' or 'polivanov'`)
+}
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		from     Script
+		to       Script
+		expected []Segment
+	}{
+		{
+			name:     "sokuon",
+			text:     "きって",
+			from:     Hiragana,
+			to:       Script(Hepburn),
+			expected: []Segment{{"き", "ki"}, {"っ", "t"}, {"て", "te"}},
+		},
+		{
+			name:     "sokuon before ch",
+			text:     "まっちゃ",
+			from:     Hiragana,
+			to:       Script(Hepburn),
+			expected: []Segment{{"ま", "ma"}, {"っ", "t"}, {"ちゃ", "cha"}},
+		},
+		{
+			name:     "long vowels are aligned with both kana",
+			text:     "とうきょう",
+			from:     Hiragana,
+			to:       Script(Hepburn),
+			expected: []Segment{{"とう", "tō"}, {"きょう", "kyō"}},
+		},
+		{
+			name:     "prolonged sound mark in katakana",
+			text:     "ラーメン",
+			from:     Katakana,
+			to:       Script(Hepburn),
+			expected: []Segment{{"ラー", "rā"}, {"メ", "me"}, {"ン", "n"}},
+		},
+		{
+			name:     "syllabic n in polivanov",
+			text:     "こんや",
+			from:     Hiragana,
+			to:       Script(Polivanov),
+			expected: []Segment{{"こ", "ко"}, {"ん", "нъ"}, {"や", "я"}},
+		},
+		{
+			name:     "other characters are kept",
+			text:     "すし 2個",
+			from:     Hiragana,
+			to:       Script(Hepburn),
+			expected: []Segment{{"す", "su"}, {"し", "shi"}, {" 2個", " 2個"}},
+		},
+		{
+			name:     "romaji with macrons to hiragana",
+			text:     "Tōkyō",
+			from:     Script(Hepburn),
+			to:       Hiragana,
+			expected: []Segment{{"Tō", "とう"}, {"kyō", "きょう"}},
+		},
+		{
+			name:     "romaji to katakana",
+			text:     "kon'ya",
+			from:     Script(Hepburn),
+			to:       Katakana,
+			expected: []Segment{{"ko", "コ"}, {"n'", "ン"}, {"ya", "ヤ"}},
+		},
+		{
+			name:     "cyrillic with macron to hiragana",
+			text:     "то̄",
+			from:     Script(Polivanov),
+			to:       Hiragana,
+			expected: []Segment{{"то̄", "とう"}},
+		},
+		{
+			name:     "kunrei to hepburn",
+			text:     "tuki",
+			from:     Script(Kunrei),
+			to:       Script(Hepburn),
+			expected: []Segment{{"tu", "tsu"}, {"ki", "ki"}},
+		},
+		{
+			name:     "doubled vowels become long in another system",
+			text:     "toukyou",
+			from:     Script(Hepburn),
+			to:       Script(Kunrei),
+			expected: []Segment{{"tou", "tô"}, {"kyou", "kyô"}},
+		},
+		{
+			name:     "sokuon between systems",
+			text:     "mattya",
+			from:     Script(Kunrei),
+			to:       Script(Hepburn),
+			expected: []Segment{{"ma", "ma"}, {"t", "t"}, {"tya", "cha"}},
+		},
+		{
+			name:     "polivanov to hepburn",
+			text:     "кайси",
+			from:     Script(Polivanov),
+			to:       Script(Hepburn),
+			expected: []Segment{{"ка", "ka"}, {"й", "i"}, {"си", "shi"}},
+		},
+		{
+			name:     "hiragana to katakana",
+			text:     "ねこ!",
+			from:     Hiragana,
+			to:       Katakana,
+			expected: []Segment{{"ね", "ネ"}, {"こ", "コ"}, {"!", "!"}},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			from:     Hiragana,
+			to:       Script(Hepburn),
+			expected: []Segment{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := Transliterate(tt.text, tt.from, tt.to)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, segments)
+		})
+	}
+}
+
+func TestTransliterate_InvalidScript(t *testing.T) {
+	_, err := Transliterate("かな", "kanji", Script(Hepburn))
+	assert.Error(t, err)
+
+	_, err = Transliterate("かな", Hiragana, "")
+	assert.Error(t, err)
+}
@@ -352,7 +352,7 @@ func (h *AdminHandler) CreateUserSettings(w http.ResponseWriter, r *http.Request
 // @Param newWordCount formData int false "New word count"
 // @Param oldWordCount formData int false "Old word count"
 // @Param alphabetLearnCount formData int false "Alphabet learn count"
-// @Param language formData string false "Language (en, ru, de)"
+// @Param language formData string false "Language code (e.g. en, ru, pt-br)"
 // @Param alphabetRepeat formData string false "Alphabet repeat (in question, ignore, repeat)"
 // @Param avatar formData file false "Avatar image (optional)"
 // @Success 204 "No Content"
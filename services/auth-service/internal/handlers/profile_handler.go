@@ -383,7 +383,12 @@ func (h *ProfileHandler) UpdateUserSettings(w http.ResponseWriter, r *http.Reque
 		if errMsg == "newWordCount must be between 10 and 40" ||
 			errMsg == "oldWordCount must be between 10 and 40" ||
 			errMsg == "alphabetLearnCount must be between 5 and 15" ||
-			(len(errMsg) >= 16 && errMsg[:16] == "invalid language") {
+			(len(errMsg) >= 16 && errMsg[:16] == "invalid language") ||
+			strings.HasPrefix(errMsg, "invalid wordJlptLevels") ||
+			strings.HasPrefix(errMsg, "invalid wordTags") ||
+			strings.HasPrefix(errMsg, "wordTags must contain") ||
+			strings.HasPrefix(errMsg, "invalid furigana") ||
+			errMsg == "furiganaLevel must be between 1 and 5" {
 			statusCode = http.StatusBadRequest
 		}
 		h.RespondError(w, statusCode, err.Error())
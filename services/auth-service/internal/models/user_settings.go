@@ -1,13 +1,22 @@
 package models
 
-// Language represents the user's preferred language
+import "regexp"
+
+// Language represents the user's preferred language as a language code, such as "en" or "pt-br"
+//
+// Any language may be chosen, content missing in it is shown in fallback languages by the learn service.
 type Language string
 
-const (
-	LanguageEnglish Language = "en"
-	LanguageRussian Language = "ru"
-	LanguageGerman  Language = "de"
-)
+// LanguageEnglish is the default language of new users
+const LanguageEnglish Language = "en"
+
+// languagePattern matches a lowercase language code with an optional region or script subtag
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// IsValid checks if the language is a well-formed language code
+func (l Language) IsValid() bool {
+	return languagePattern.MatchString(string(l))
+}
 
 // AlphabetRepeat represents the user's alphabet repeat types
 type RepeatType string
@@ -18,24 +27,41 @@ const (
 	RepeatTypeRepeat     RepeatType = "repeat"
 )
 
+// FuriganaMode represents when furigana are shown above kanji of example sentences and lesson texts
+type FuriganaMode string
+
+const (
+	FuriganaModeAlways     FuriganaMode = "always"
+	FuriganaModeAboveLevel FuriganaMode = "above level" // Only above kanji harder than the learner's JLPT level
+	FuriganaModeNever      FuriganaMode = "never"
+)
+
 // UserSettings represents user preferences and settings
 type UserSettings struct {
-	ID                 int        `json:"id"`
-	UserID             int        `json:"userId"`
-	NewWordCount       int        `json:"newWordCount"`       // Default: 20
-	OldWordCount       int        `json:"oldWordCount"`       // Default: 20
-	AlphabetLearnCount int        `json:"alphabetLearnCount"` // Default: 10
-	Language           Language   `json:"language"`           // Default: "en"
-	AlphabetRepeat     RepeatType `json:"alphabetRepeat"`     // Default: "in question"
+	ID                 int          `json:"id"`
+	UserID             int          `json:"userId"`
+	NewWordCount       int          `json:"newWordCount"`       // Default: 20
+	OldWordCount       int          `json:"oldWordCount"`       // Default: 20
+	AlphabetLearnCount int          `json:"alphabetLearnCount"` // Default: 10
+	Language           Language     `json:"language"`           // Default: "en"
+	AlphabetRepeat     RepeatType   `json:"alphabetRepeat"`     // Default: "in question"
+	WordJLPTLevels     []int        `json:"wordJlptLevels"`     // Default: empty, new words of all levels
+	WordTags           []string     `json:"wordTags"`           // Default: empty, new words of all topics
+	Furigana           FuriganaMode `json:"furigana"`           // Default: "always"
+	FuriganaLevel      int          `json:"furiganaLevel"`      // Default: 5, JLPT level of the learner, kanji of this and easier levels are known
 }
 
 // UserSettingsResponse represents user settings in API responses (without IDs)
 type UserSettingsResponse struct {
-	NewWordCount       int        `json:"newWordCount"`
-	OldWordCount       int        `json:"oldWordCount"`
-	AlphabetLearnCount int        `json:"alphabetLearnCount"`
-	Language           Language   `json:"language"`
-	AlphabetRepeat     RepeatType `json:"alphabetRepeat"`
+	NewWordCount       int          `json:"newWordCount"`
+	OldWordCount       int          `json:"oldWordCount"`
+	AlphabetLearnCount int          `json:"alphabetLearnCount"`
+	Language           Language     `json:"language"`
+	AlphabetRepeat     RepeatType   `json:"alphabetRepeat"`
+	WordJLPTLevels     []int        `json:"wordJlptLevels"`
+	WordTags           []string     `json:"wordTags"`
+	Furigana           FuriganaMode `json:"furigana"`
+	FuriganaLevel      int          `json:"furiganaLevel"`
 }
 
 // UpdateUserSettingsRequest represents a request to update user settings
@@ -45,4 +71,10 @@ type UpdateUserSettingsRequest struct {
 	AlphabetLearnCount *int       `json:"alphabetLearnCount,omitempty"`
 	Language           Language   `json:"language,omitempty"`
 	AlphabetRepeat     RepeatType `json:"alphabetRepeat,omitempty"`
+	// WordJLPTLevels and WordTags restrict new words of the daily list,
+	// nil leaves the filter unchanged and an empty list removes it
+	WordJLPTLevels []int        `json:"wordJlptLevels,omitempty"`
+	WordTags       []string     `json:"wordTags,omitempty"`
+	Furigana       FuriganaMode `json:"furigana,omitempty"`
+	FuriganaLevel  *int         `json:"furiganaLevel,omitempty"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/auth-service/internal/models"
@@ -37,14 +38,15 @@ func (r *userSettingsRepository) Create(ctx context.Context, userId int) error {
 // GetByUserId retrieves user settings by user ID
 func (r *userSettingsRepository) GetByUserId(ctx context.Context, userId int) (*models.UserSettings, error) {
 	query := `
-		SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat
+		SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat,
+			word_jlpt_levels, word_tags, furigana, furigana_level
 		FROM user_settings
 		WHERE user_id = ?
 		LIMIT 1
 	`
 
 	userSettings := &models.UserSettings{}
-	var languageStr string
+	var languageStr, jlptLevelsStr, tagsStr string
 	err := r.db.QueryRowContext(ctx, query, userId).Scan(
 		&userSettings.ID,
 		&userSettings.UserID,
@@ -53,6 +55,10 @@ func (r *userSettingsRepository) GetByUserId(ctx context.Context, userId int) (*
 		&userSettings.AlphabetLearnCount,
 		&languageStr,
 		&userSettings.AlphabetRepeat,
+		&jlptLevelsStr,
+		&tagsStr,
+		&userSettings.Furigana,
+		&userSettings.FuriganaLevel,
 	)
 
 	if err == sql.ErrNoRows {
@@ -63,6 +69,13 @@ func (r *userSettingsRepository) GetByUserId(ctx context.Context, userId int) (*
 	}
 
 	userSettings.Language = models.Language(languageStr)
+	userSettings.WordJLPTLevels = []int{}
+	for _, level := range splitList(jlptLevelsStr) {
+		if parsed, err := strconv.Atoi(level); err == nil {
+			userSettings.WordJLPTLevels = append(userSettings.WordJLPTLevels, parsed)
+		}
+	}
+	userSettings.WordTags = splitList(tagsStr)
 	return userSettings, nil
 }
 
@@ -91,6 +104,26 @@ func (r *userSettingsRepository) Update(ctx context.Context, userId int, setting
 		setClauses = append(setClauses, "alphabet_repeat = ?")
 		args = append(args, settings.AlphabetRepeat)
 	}
+	if settings.WordJLPTLevels != nil {
+		levels := make([]string, len(settings.WordJLPTLevels))
+		for i, level := range settings.WordJLPTLevels {
+			levels[i] = strconv.Itoa(level)
+		}
+		setClauses = append(setClauses, "word_jlpt_levels = ?")
+		args = append(args, strings.Join(levels, ","))
+	}
+	if settings.WordTags != nil {
+		setClauses = append(setClauses, "word_tags = ?")
+		args = append(args, strings.Join(settings.WordTags, ","))
+	}
+	if settings.Furigana != "" {
+		setClauses = append(setClauses, "furigana = ?")
+		args = append(args, settings.Furigana)
+	}
+	if settings.FuriganaLevel != 0 {
+		setClauses = append(setClauses, "furigana_level = ?")
+		args = append(args, settings.FuriganaLevel)
+	}
 	if len(setClauses) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
@@ -131,3 +164,11 @@ func (r *userSettingsRepository) ExistsByUserId(ctx context.Context, userId int)
 
 	return exists, nil
 }
+
+// splitList splits a comma-separated column value, an empty value is an empty list
+func splitList(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
@@ -110,27 +110,33 @@ func TestUserSettingsRepository_GetByUserId(t *testing.T) {
 		name          string
 		userId        int
 		setupMock     func(sqlmock.Sqlmock)
-		expectedError bool
-		expectedID    int
+		expectedError  bool
+		expectedID     int
+		expectedLevels []int
+		expectedTags   []string
+		expectedLevel  int
 	}{
 		{
 			name:   "success",
 			userId: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat"}).
-					AddRow(1, 1, 20, 20, 10, "en", "in question")
-				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat FROM user_settings WHERE user_id = \? LIMIT 1`).
+				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat", "word_jlpt_levels", "word_tags", "furigana", "furigana_level"}).
+					AddRow(1, 1, 20, 20, 10, "en", "in question", "", "", "always", 5)
+				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat, word_jlpt_levels, word_tags, furigana, furigana_level FROM user_settings WHERE user_id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
-			expectedError: false,
-			expectedID:    1,
+			expectedError:  false,
+			expectedID:     1,
+			expectedLevels: []int{},
+			expectedTags:   []string{},
+			expectedLevel:  5,
 		},
 		{
 			name:   "not found",
 			userId: 999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat FROM user_settings WHERE user_id = \? LIMIT 1`).
+				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat, word_jlpt_levels, word_tags, furigana, furigana_level FROM user_settings WHERE user_id = \? LIMIT 1`).
 					WithArgs(999).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -141,7 +147,7 @@ func TestUserSettingsRepository_GetByUserId(t *testing.T) {
 			name:   "database error",
 			userId: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat FROM user_settings WHERE user_id = \? LIMIT 1`).
+				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat, word_jlpt_levels, word_tags, furigana, furigana_level FROM user_settings WHERE user_id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -152,9 +158,9 @@ func TestUserSettingsRepository_GetByUserId(t *testing.T) {
 			name:   "scan error - invalid data types",
 			userId: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat"}).
-					AddRow("invalid", 1, 20, 20, 10, "en", "in question")
-				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat FROM user_settings WHERE user_id = \? LIMIT 1`).
+				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat", "word_jlpt_levels", "word_tags", "furigana", "furigana_level"}).
+					AddRow("invalid", 1, 20, 20, 10, "en", "in question", "", "", "always", 5)
+				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat, word_jlpt_levels, word_tags, furigana, furigana_level FROM user_settings WHERE user_id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -165,14 +171,17 @@ func TestUserSettingsRepository_GetByUserId(t *testing.T) {
 			name:   "success with all language types",
 			userId: 2,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat"}).
-					AddRow(2, 2, 30, 25, 15, "ru", "in question")
-				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat FROM user_settings WHERE user_id = \? LIMIT 1`).
+				rows := sqlmock.NewRows([]string{"id", "user_id", "new_word_count", "old_word_count", "alphabet_learn_count", "language", "alphabet_repeat", "word_jlpt_levels", "word_tags", "furigana", "furigana_level"}).
+					AddRow(2, 2, 30, 25, 15, "ru", "in question", "5,4", "food,travel", "above level", 4)
+				mock.ExpectQuery(`SELECT id, user_id, new_word_count, old_word_count, alphabet_learn_count, language, alphabet_repeat, word_jlpt_levels, word_tags, furigana, furigana_level FROM user_settings WHERE user_id = \? LIMIT 1`).
 					WithArgs(2).
 					WillReturnRows(rows)
 			},
-			expectedError: false,
-			expectedID:    2,
+			expectedError:  false,
+			expectedID:     2,
+			expectedLevels: []int{5, 4},
+			expectedTags:   []string{"food", "travel"},
+			expectedLevel:  4,
 		},
 	}
 
@@ -193,6 +202,9 @@ func TestUserSettingsRepository_GetByUserId(t *testing.T) {
 				assert.NotNil(t, userSettings)
 				assert.Equal(t, tt.expectedID, userSettings.ID)
 				assert.Equal(t, tt.userId, userSettings.UserID)
+				assert.Equal(t, tt.expectedLevels, userSettings.WordJLPTLevels)
+				assert.Equal(t, tt.expectedTags, userSettings.WordTags)
+				assert.Equal(t, tt.expectedLevel, userSettings.FuriganaLevel)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -272,6 +284,48 @@ func TestUserSettingsRepository_Update(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name:   "success with word filters",
+			userId: 1,
+			settings: &models.UserSettings{
+				WordJLPTLevels: []int{5, 4},
+				WordTags:       []string{"food", "travel"},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_settings SET word_jlpt_levels = \?, word_tags = \? WHERE user_id = \?`).
+					WithArgs("5,4", "food,travel", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name:   "success with furigana",
+			userId: 1,
+			settings: &models.UserSettings{
+				Furigana:      models.FuriganaModeAboveLevel,
+				FuriganaLevel: 4,
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_settings SET furigana = \?, furigana_level = \? WHERE user_id = \?`).
+					WithArgs(models.FuriganaModeAboveLevel, 4, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name:   "success removing word filters",
+			userId: 1,
+			settings: &models.UserSettings{
+				WordJLPTLevels: []int{},
+				WordTags:       []string{},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_settings SET word_jlpt_levels = \?, word_tags = \? WHERE user_id = \?`).
+					WithArgs("", "", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
 		{
 			name:   "success with russian language",
 			userId: 2,
@@ -279,7 +333,7 @@ func TestUserSettingsRepository_Update(t *testing.T) {
 				NewWordCount:       30,
 				OldWordCount:       35,
 				AlphabetLearnCount: 15,
-				Language:           models.LanguageRussian,
+				Language:           models.Language("ru"),
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`UPDATE user_settings SET new_word_count = \?, old_word_count = \?, alphabet_learn_count = \?, language = \? WHERE user_id = \?`).
@@ -295,7 +349,7 @@ func TestUserSettingsRepository_Update(t *testing.T) {
 				NewWordCount:       20,
 				OldWordCount:       20,
 				AlphabetLearnCount: 10,
-				Language:           models.LanguageGerman,
+				Language:           models.Language("de"),
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`UPDATE user_settings SET new_word_count = \?, old_word_count = \?, alphabet_learn_count = \?, language = \? WHERE user_id = \?`).
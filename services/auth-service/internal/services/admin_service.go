@@ -430,8 +430,7 @@ func (s *adminService) checkUpdateUserCredentials(ctx context.Context, userID in
 	// Check settings validity
 	go func() {
 		if settings != nil {
-			if settings.Language != "" && settings.Language != models.LanguageEnglish &&
-				settings.Language != models.LanguageRussian && settings.Language != models.LanguageGerman {
+			if settings.Language != "" && !settings.Language.IsValid() {
 				validationErrors <- fmt.Errorf("invalid language")
 				return
 			}
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/auth-service/internal/models"
 )
@@ -36,6 +38,12 @@ type UserSettingsRepository interface {
 	ExistsByUserId(ctx context.Context, userId int) (bool, error)
 }
 
+// Limits of the word tags filter, they match the limits of word tags in learn-service
+const (
+	maxWordTags      = 10
+	maxWordTagLength = 50
+)
+
 // userSettingsService implements UserSettingsService
 type userSettingsService struct {
 	repo UserSettingsRepository
@@ -61,6 +69,10 @@ func (s *userSettingsService) GetUserSettings(ctx context.Context, userId int) (
 		AlphabetLearnCount: settings.AlphabetLearnCount,
 		Language:           settings.Language,
 		AlphabetRepeat:     settings.AlphabetRepeat,
+		WordJLPTLevels:     settings.WordJLPTLevels,
+		WordTags:           settings.WordTags,
+		Furigana:           settings.Furigana,
+		FuriganaLevel:      settings.FuriganaLevel,
 	}, nil
 }
 
@@ -72,7 +84,15 @@ func (s *userSettingsService) GetUserSettings(ctx context.Context, userId int) (
 //
 // - alphabetLearnCount must be between 5 and 15
 //
-// - language must be "en", "ru", or "de"
+// - language must be a language code, such as "en" or "pt-br"
+//
+// - wordJlptLevels must be between 1 and 5
+//
+// - wordTags must contain at most 10 tags of at most 50 characters
+//
+// - furigana must be "always", "above level" or "never"
+//
+// - furiganaLevel must be between 1 and 5
 //
 // "userId" parameter is used to update user settings by user ID.
 // "updateRequest" parameter is used to update user settings.
@@ -99,6 +119,21 @@ func (s *userSettingsService) UpdateUserSettings(ctx context.Context, userId int
 	if updateRequest.AlphabetLearnCount != nil {
 		settings.AlphabetLearnCount = *updateRequest.AlphabetLearnCount
 	}
+	if updateRequest.WordJLPTLevels != nil {
+		settings.WordJLPTLevels = []int{}
+		seen := make(map[int]bool)
+		for _, level := range updateRequest.WordJLPTLevels {
+			if !seen[level] {
+				seen[level] = true
+				settings.WordJLPTLevels = append(settings.WordJLPTLevels, level)
+			}
+		}
+	}
+	settings.WordTags = normalizeWordTags(updateRequest.WordTags)
+	settings.Furigana = updateRequest.Furigana
+	if updateRequest.FuriganaLevel != nil {
+		settings.FuriganaLevel = *updateRequest.FuriganaLevel
+	}
 
 	err := s.repo.Update(ctx, userId, settings)
 	if err != nil {
@@ -115,10 +150,19 @@ func (s *userSettingsService) UpdateUserSettings(ctx context.Context, userId int
 //
 // - alphabetLearnCount must be between 5 and 15
 //
-// - language must be "en", "ru", or "de"
+// - language must be a language code, such as "en" or "pt-br"
+//
+// - wordJlptLevels must be between 1 and 5
+//
+// - wordTags must contain at most 10 tags of at most 50 characters
+//
+// - furigana must be "always", "above level" or "never"
+//
+// - furiganaLevel must be between 1 and 5
 func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context, userId int, updateRequest *models.UpdateUserSettingsRequest) error {
 	// Validate that at least one field is provided
-	if updateRequest.NewWordCount == nil && updateRequest.OldWordCount == nil && updateRequest.AlphabetLearnCount == nil && updateRequest.Language == "" && updateRequest.AlphabetRepeat == "" {
+	if updateRequest.NewWordCount == nil && updateRequest.OldWordCount == nil && updateRequest.AlphabetLearnCount == nil && updateRequest.Language == "" && updateRequest.AlphabetRepeat == "" &&
+		updateRequest.WordJLPTLevels == nil && updateRequest.WordTags == nil && updateRequest.Furigana == "" && updateRequest.FuriganaLevel == nil {
 		return fmt.Errorf("at least one field must be provided")
 	}
 
@@ -126,7 +170,7 @@ func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context
 		return fmt.Errorf("invalid user id")
 	}
 
-	errorChan := make(chan error, 6)
+	errorChan := make(chan error, 10)
 
 	go func() {
 		if updateRequest.NewWordCount != nil && (*updateRequest.NewWordCount < 10 || *updateRequest.NewWordCount > 40) {
@@ -156,8 +200,8 @@ func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context
 
 	// Validate language
 	go func() {
-		if updateRequest.Language != "" && updateRequest.Language != models.LanguageEnglish && updateRequest.Language != models.LanguageRussian && updateRequest.Language != models.LanguageGerman {
-			errorChan <- fmt.Errorf("invalid language: %s, must be 'en', 'ru', or 'de'", updateRequest.Language)
+		if updateRequest.Language != "" && !updateRequest.Language.IsValid() {
+			errorChan <- fmt.Errorf("invalid language: %s, must be a language code such as 'en' or 'pt-br'", updateRequest.Language)
 			return
 		}
 		errorChan <- nil
@@ -172,6 +216,56 @@ func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context
 		errorChan <- nil
 	}()
 
+	// Validate wordJlptLevels
+	go func() {
+		for _, level := range updateRequest.WordJLPTLevels {
+			if level < 1 || level > 5 {
+				errorChan <- fmt.Errorf("invalid wordJlptLevels: %d, must be between 1 and 5", level)
+				return
+			}
+		}
+		errorChan <- nil
+	}()
+
+	// Validate wordTags
+	go func() {
+		tags := normalizeWordTags(updateRequest.WordTags)
+		if len(tags) > maxWordTags {
+			errorChan <- fmt.Errorf("wordTags must contain at most %d tags", maxWordTags)
+			return
+		}
+		for _, tag := range tags {
+			if utf8.RuneCountInString(tag) > maxWordTagLength {
+				errorChan <- fmt.Errorf("invalid wordTags: '%s' must be at most %d characters long", tag, maxWordTagLength)
+				return
+			}
+			if strings.Contains(tag, ",") {
+				errorChan <- fmt.Errorf("invalid wordTags: '%s' must not contain commas", tag)
+				return
+			}
+		}
+		errorChan <- nil
+	}()
+
+	// Validate furigana
+	go func() {
+		switch updateRequest.Furigana {
+		case "", models.FuriganaModeAlways, models.FuriganaModeAboveLevel, models.FuriganaModeNever:
+			errorChan <- nil
+		default:
+			errorChan <- fmt.Errorf("invalid furigana: %s, must be 'always', 'above level', or 'never'", updateRequest.Furigana)
+		}
+	}()
+
+	// Validate furiganaLevel
+	go func() {
+		if updateRequest.FuriganaLevel != nil && (*updateRequest.FuriganaLevel < 1 || *updateRequest.FuriganaLevel > 5) {
+			errorChan <- fmt.Errorf("furiganaLevel must be between 1 and 5")
+			return
+		}
+		errorChan <- nil
+	}()
+
 	// Get existing settings to preserve unchanged fields
 	go func() {
 		exists, err := s.repo.ExistsByUserId(ctx, userId)
@@ -187,7 +281,7 @@ func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context
 	}()
 
 	// Wait for all validations to complete
-	for range 6 {
+	for range 10 {
 		err := <-errorChan
 		if err != nil {
 			return err
@@ -196,3 +290,23 @@ func (s *userSettingsService) validateUpdateUserSettingsData(ctx context.Context
 
 	return nil
 }
+
+// normalizeWordTags trims and lowercases tags, dropping empty and duplicate ones
+//
+// A nil slice stays nil, so the filter is left unchanged.
+func normalizeWordTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
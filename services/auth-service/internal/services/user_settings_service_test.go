@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/auth-service/internal/models"
@@ -11,9 +12,10 @@ import (
 
 // mockUserSettingsRepositoryForService is a mock implementation of UserSettingsRepository for service tests
 type mockUserSettingsRepositoryForService struct {
-	settings  *models.UserSettings
-	err       error
-	updateErr error
+	settings        *models.UserSettings
+	err             error
+	updateErr       error
+	updatedSettings *models.UserSettings
 }
 
 func (m *mockUserSettingsRepositoryForService) Create(ctx context.Context, userId int) error {
@@ -31,6 +33,7 @@ func (m *mockUserSettingsRepositoryForService) Update(ctx context.Context, userI
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	m.updatedSettings = settings
 	return m.err
 }
 
@@ -97,7 +100,7 @@ func TestUserSettingsService_GetUserSettings(t *testing.T) {
 					NewWordCount:       30,
 					OldWordCount:       25,
 					AlphabetLearnCount: 15,
-					Language:           models.LanguageRussian,
+					Language:           models.Language("ru"),
 					AlphabetRepeat:     models.RepeatTypeInQuestion,
 				},
 			},
@@ -129,6 +132,153 @@ func TestUserSettingsService_GetUserSettings(t *testing.T) {
 	}
 }
 
+func TestUserSettingsService_UpdateUserSettings_WordFilters(t *testing.T) {
+	tests := []struct {
+		name           string
+		updateRequest  *models.UpdateUserSettingsRequest
+		expectedLevels []int
+		expectedTags   []string
+		errorContains  string
+	}{
+		{
+			name: "success with levels and tags",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordJLPTLevels: []int{5, 4, 5},
+				WordTags:       []string{" Food ", "travel", "food", ""},
+			},
+			expectedLevels: []int{5, 4},
+			expectedTags:   []string{"food", "travel"},
+		},
+		{
+			name: "empty lists remove filters",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordJLPTLevels: []int{},
+				WordTags:       []string{},
+			},
+			expectedLevels: []int{},
+			expectedTags:   []string{},
+		},
+		{
+			name: "missing filters are left unchanged",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				NewWordCount: intPtr(20),
+			},
+		},
+		{
+			name: "invalid jlpt level",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordJLPTLevels: []int{0},
+			},
+			errorContains: "invalid wordJlptLevels",
+		},
+		{
+			name: "too many tags",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordTags: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			errorContains: "wordTags must contain at most 10 tags",
+		},
+		{
+			name: "tag too long",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordTags: []string{strings.Repeat("a", 51)},
+			},
+			errorContains: "must be at most 50 characters long",
+		},
+		{
+			name: "tag with comma",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				WordTags: []string{"food,travel"},
+			},
+			errorContains: "must not contain commas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockUserSettingsRepositoryForService{settings: &models.UserSettings{ID: 1, UserID: 1}}
+			svc := NewUserSettingsService(mockRepo)
+
+			err := svc.UpdateUserSettings(context.Background(), 1, tt.updateRequest)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, mockRepo.updatedSettings)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLevels, mockRepo.updatedSettings.WordJLPTLevels)
+			assert.Equal(t, tt.expectedTags, mockRepo.updatedSettings.WordTags)
+		})
+	}
+}
+
+func TestUserSettingsService_UpdateUserSettings_Furigana(t *testing.T) {
+	tests := []struct {
+		name             string
+		updateRequest    *models.UpdateUserSettingsRequest
+		expectedFurigana models.FuriganaMode
+		expectedLevel    int
+		errorContains    string
+	}{
+		{
+			name: "success with mode and level",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				Furigana:      models.FuriganaModeAboveLevel,
+				FuriganaLevel: intPtr(3),
+			},
+			expectedFurigana: models.FuriganaModeAboveLevel,
+			expectedLevel:    3,
+		},
+		{
+			name: "success with mode only",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				Furigana: models.FuriganaModeNever,
+			},
+			expectedFurigana: models.FuriganaModeNever,
+		},
+		{
+			name: "invalid mode",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				Furigana: "sometimes",
+			},
+			errorContains: "invalid furigana: sometimes",
+		},
+		{
+			name: "level too low",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				FuriganaLevel: intPtr(0),
+			},
+			errorContains: "furiganaLevel must be between 1 and 5",
+		},
+		{
+			name: "level too high",
+			updateRequest: &models.UpdateUserSettingsRequest{
+				FuriganaLevel: intPtr(6),
+			},
+			errorContains: "furiganaLevel must be between 1 and 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockUserSettingsRepositoryForService{settings: &models.UserSettings{ID: 1, UserID: 1}}
+			svc := NewUserSettingsService(mockRepo)
+
+			err := svc.UpdateUserSettings(context.Background(), 1, tt.updateRequest)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, mockRepo.updatedSettings)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFurigana, mockRepo.updatedSettings.Furigana)
+			assert.Equal(t, tt.expectedLevel, mockRepo.updatedSettings.FuriganaLevel)
+		})
+	}
+}
+
 func TestUserSettingsService_UpdateUserSettings(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -303,7 +453,7 @@ func TestUserSettingsService_UpdateUserSettings(t *testing.T) {
 			name:   "invalid language",
 			userId: 1,
 			updateRequest: &models.UpdateUserSettingsRequest{
-				Language: models.Language("fr"),
+				Language: models.Language("french"),
 			},
 			mockRepo: &mockUserSettingsRepositoryForService{
 				settings: &models.UserSettings{
@@ -342,7 +492,7 @@ func TestUserSettingsService_UpdateUserSettings(t *testing.T) {
 			name:   "success with valid language - russian",
 			userId: 1,
 			updateRequest: &models.UpdateUserSettingsRequest{
-				Language: models.LanguageRussian,
+				Language: models.Language("ru"),
 			},
 			mockRepo: &mockUserSettingsRepositoryForService{
 				settings: &models.UserSettings{
@@ -361,7 +511,26 @@ func TestUserSettingsService_UpdateUserSettings(t *testing.T) {
 			name:   "success with valid language - german",
 			userId: 1,
 			updateRequest: &models.UpdateUserSettingsRequest{
-				Language: models.LanguageGerman,
+				Language: models.Language("de"),
+			},
+			mockRepo: &mockUserSettingsRepositoryForService{
+				settings: &models.UserSettings{
+					ID:                 1,
+					UserID:             1,
+					NewWordCount:       20,
+					OldWordCount:       20,
+					AlphabetLearnCount: 10,
+					Language:           models.LanguageEnglish,
+					AlphabetRepeat:     models.RepeatTypeInQuestion,
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name:   "success with valid language - regional",
+			userId: 1,
+			updateRequest: &models.UpdateUserSettingsRequest{
+				Language: models.Language("pt-br"),
 			},
 			mockRepo: &mockUserSettingsRepositoryForService{
 				settings: &models.UserSettings{
@@ -0,0 +1,3 @@
+ALTER TABLE user_settings
+DROP COLUMN word_tags,
+DROP COLUMN word_jlpt_levels;
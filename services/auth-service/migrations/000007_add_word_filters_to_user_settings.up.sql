@@ -0,0 +1,3 @@
+ALTER TABLE user_settings
+ADD COLUMN word_jlpt_levels VARCHAR(20) NOT NULL DEFAULT '',
+ADD COLUMN word_tags VARCHAR(600) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+UPDATE user_settings SET language = 'en' WHERE language NOT IN ('en', 'ru', 'de');
+ALTER TABLE user_settings
+MODIFY COLUMN language VARCHAR(2) DEFAULT 'en',
+ADD CONSTRAINT chk_language CHECK (language IN ('en', 'ru', 'de'));
@@ -0,0 +1,4 @@
+-- The language check of 000003 has no name, MariaDB named it after its position among the unnamed checks
+ALTER TABLE user_settings
+DROP CONSTRAINT IF EXISTS CONSTRAINT_4,
+MODIFY COLUMN language VARCHAR(10) DEFAULT 'en';
@@ -0,0 +1,5 @@
+ALTER TABLE user_settings
+DROP CONSTRAINT chk_furigana_level,
+DROP CONSTRAINT chk_furigana,
+DROP COLUMN furigana_level,
+DROP COLUMN furigana;
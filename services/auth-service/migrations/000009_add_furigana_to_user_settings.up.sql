@@ -0,0 +1,5 @@
+ALTER TABLE user_settings
+ADD COLUMN furigana VARCHAR(20) NOT NULL DEFAULT 'always',
+ADD COLUMN furigana_level INT NOT NULL DEFAULT 5,
+ADD CONSTRAINT chk_furigana CHECK (furigana IN ('always', 'above level', 'never')),
+ADD CONSTRAINT chk_furigana_level CHECK (furigana_level BETWEEN 1 AND 5);
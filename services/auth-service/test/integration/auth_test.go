@@ -268,6 +268,10 @@ func setupTestSchemaForMain(db *sql.DB) {
 			alphabet_learn_count INT NOT NULL DEFAULT 10,
 			language VARCHAR(10) NOT NULL DEFAULT 'en',
 			alphabet_repeat VARCHAR(20) NOT NULL DEFAULT 'in question',
+			word_jlpt_levels VARCHAR(20) NOT NULL DEFAULT '',
+			word_tags VARCHAR(600) NOT NULL DEFAULT '',
+			furigana VARCHAR(20) NOT NULL DEFAULT 'always',
+			furigana_level INT NOT NULL DEFAULT 5,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
@@ -840,7 +844,7 @@ func TestIntegration_UserSettings(t *testing.T) {
 				assert.Equal(t, 30, settings.NewWordCount)
 				assert.Equal(t, 35, settings.OldWordCount)
 				assert.Equal(t, 12, settings.AlphabetLearnCount)
-				assert.Equal(t, models.LanguageRussian, settings.Language)
+				assert.Equal(t, models.Language("ru"), settings.Language)
 			},
 		},
 		{
@@ -876,7 +880,7 @@ func TestIntegration_UserSettings(t *testing.T) {
 			userID:         1,
 			method:         http.MethodPatch,
 			url:            "/api/v6/profile/settings",
-			requestBody:    map[string]any{"language": "fr"},
+			requestBody:    map[string]any{"language": "french"},
 			expectedStatus: http.StatusBadRequest,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response map[string]string
@@ -963,7 +967,7 @@ func TestIntegration_UserSettingsRepositoryLayer(t *testing.T) {
 			NewWordCount:       25,
 			OldWordCount:       30,
 			AlphabetLearnCount: 12,
-			Language:           models.LanguageRussian,
+			Language:           models.Language("ru"),
 		}
 		err := userSettingsRepo.Update(ctx, 1, settings)
 		require.NoError(t, err)
@@ -974,7 +978,7 @@ func TestIntegration_UserSettingsRepositoryLayer(t *testing.T) {
 		assert.Equal(t, 25, updated.NewWordCount)
 		assert.Equal(t, 30, updated.OldWordCount)
 		assert.Equal(t, 12, updated.AlphabetLearnCount)
-		assert.Equal(t, models.LanguageRussian, updated.Language)
+		assert.Equal(t, models.Language("ru"), updated.Language)
 	})
 }
 
@@ -1491,7 +1495,7 @@ func TestIntegration_AdminUpdateUserWithSettings(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, 30, settings.NewWordCount)
 				assert.Equal(t, 35, settings.OldWordCount)
-				assert.Equal(t, models.LanguageRussian, settings.Language)
+				assert.Equal(t, models.Language("ru"), settings.Language)
 			},
 		},
 		{
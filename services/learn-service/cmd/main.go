@@ -85,31 +85,78 @@ func main() {
 		cfg.JWT.RefreshTokenExpiry,
 	)
 
+	// Locale fallbacks of translations and readings
+	localeConfig := services.LocaleConfig{
+		Default:   cfg.Locale.Default,
+		Fallbacks: cfg.Locale.Fallbacks,
+	}
+
 	// Initialize layers
 	repo := repositories.NewCharactersRepository(db)
 	historyRepo := repositories.NewCharacterLearnHistoryRepository(db)
-	svc := services.NewCharactersService(repo, historyRepo)
+	sessionRepo := repositories.NewTestSessionRepository(db)
+	strokeRepo := repositories.NewCharacterStrokeRepository(db)
+	attemptRepo := repositories.NewCharacterTestAttemptRepository(db)
+	svc := services.NewCharactersService(repo, historyRepo, sessionRepo, strokeRepo, attemptRepo, localeConfig)
 	charHandler := handlers.NewCharactersHandler(svc, logger.Logger)
-	adminCharService := services.NewAdminService(repo, cfg.MediaBaseURL, cfg.APIKey)
+	adminCharService := services.NewAdminService(repo, strokeRepo, cfg.MediaBaseURL, cfg.APIKey, localeConfig)
 	adminCharHandler := handlers.NewAdminCharactersHandler(adminCharService, logger.Logger)
 
 	// Initialize test result layers
-	testResultService := services.NewTestResultService(historyRepo, repo)
+	testResultService := services.NewTestResultService(historyRepo, repo, attemptRepo, sessionRepo, services.MasteryConfig{
+		LearningRate:  float32(cfg.Mastery.LearningRate),
+		PassThreshold: float32(cfg.Mastery.PassThreshold),
+	})
 	testResultHandler := handlers.NewTestResultHandler(testResultService, logger.Logger)
 
 	// Initialize dictionary layers
 	wordRepo := repositories.NewWordRepository(db)
+	// Fill search readings of words created before word search was added
+	if count, err := wordRepo.FillSearchReadings(context.Background()); err != nil {
+		logger.Logger.Error("Failed to fill word search readings", zap.Error(err))
+	} else if count > 0 {
+		logger.Logger.Info("Filled word search readings", zap.Int("count", count))
+	}
+	wordExampleRepo := repositories.NewWordExampleRepository(db)
 	dictionaryHistoryRepo := repositories.NewDictionaryHistoryRepository(db)
-	dictionaryService := services.NewDictionaryService(wordRepo, dictionaryHistoryRepo)
-	dictionaryHandler := handlers.NewDictionaryHandler(dictionaryService, logger.Logger)
-	adminWordService := services.NewAdminWordService(wordRepo, dictionaryHistoryRepo, cfg.MediaBaseURL, cfg.APIKey)
+	userWordRepo := repositories.NewUserWordRepository(db)
+	wordQuizSessionRepo := repositories.NewWordQuizSessionRepository(db)
+	kanjiRepo := repositories.NewKanjiRepository(db)
+	dictionaryService := services.NewDictionaryService(wordRepo, wordExampleRepo, userWordRepo, dictionaryHistoryRepo, wordQuizSessionRepo, kanjiRepo, services.LeechConfig{
+		Threshold: cfg.Leech.Threshold,
+		Action:    services.LeechAction(cfg.Leech.Action),
+	}, localeConfig)
+	dictionaryExportService := services.NewDictionaryExportService(dictionaryHistoryRepo, cfg.MediaBaseURL, cfg.APIKey, localeConfig)
+	dictionaryHandler := handlers.NewDictionaryHandler(dictionaryService, dictionaryExportService, logger.Logger)
+	userDictionaryHandler := handlers.NewUserDictionaryHandler(services.NewUserDictionaryService(userWordRepo), logger.Logger)
+	adminWordService := services.NewAdminWordService(wordRepo, wordExampleRepo, dictionaryHistoryRepo, kanjiRepo, cfg.MediaBaseURL, cfg.APIKey, localeConfig)
 	adminWordHandler := handlers.NewAdminWordsHandler(adminWordService, logger.Logger)
+	wordImportJobRepo := repositories.NewWordImportJobRepository(db)
+	wordImportService := services.NewWordImportService(wordRepo, kanjiRepo, wordImportJobRepo, cfg.MediaBaseURL, cfg.APIKey, logger.Logger)
+	// Finish imports interrupted by a restart, they are never resumed
+	if count, err := wordImportService.FailInterruptedImports(context.Background()); err != nil {
+		logger.Logger.Error("Failed to finish interrupted word imports", zap.Error(err))
+	} else if count > 0 {
+		logger.Logger.Info("Marked interrupted word imports as failed", zap.Int("count", count))
+	}
+	adminWordImportHandler := handlers.NewAdminWordImportHandler(wordImportService, logger.Logger)
+
+	// Initialize kanji layers
+	kanjiService := services.NewKanjiService(kanjiRepo, localeConfig)
+	kanjiHandler := handlers.NewKanjiHandler(kanjiService, logger.Logger)
+	adminKanjiService := services.NewAdminKanjiService(kanjiRepo, cfg.MediaBaseURL, cfg.APIKey, localeConfig)
+	adminKanjiHandler := handlers.NewAdminKanjiHandler(adminKanjiService, logger.Logger)
+
+	// Initialize transliteration layers
+	transliterationHandler := handlers.NewTransliterationHandler(services.NewTransliterationService(), logger.Logger)
 
 	// Initialize lesson layers
 	courseRepo := repositories.NewCourseRepository(db)
 	lessonRepo := repositories.NewLessonRepository(db)
 	lessonBlockRepo := repositories.NewLessonBlockRepository(db)
 	lessonUserHistoryRepo := repositories.NewLessonUserHistoryRepository(db)
+	courseEnrollmentRepo := repositories.NewCourseEnrollmentRepository(db)
+	lessonQuizAttemptRepo := repositories.NewLessonQuizAttemptRepository(db)
 	tutorMediaRepo := repositories.NewTutorMediaRepository(db)
 
 	// Initialize user lesson service and handler
@@ -118,6 +165,10 @@ func main() {
 		lessonRepo,
 		lessonBlockRepo,
 		lessonUserHistoryRepo,
+		courseEnrollmentRepo,
+		historyRepo,
+		lessonQuizAttemptRepo,
+		kanjiRepo,
 	)
 	userLessonHandler := handlers.NewUserLessonHandler(userLessonService, logger.Logger)
 
@@ -166,6 +217,15 @@ func main() {
 		// Register dictionary routes with auth middleware
 		dictionaryHandler.RegisterRoutes(r, authMw)
 
+		// Register personal dictionary routes with auth middleware
+		userDictionaryHandler.RegisterRoutes(r, authMw)
+
+		// Register kanji routes
+		kanjiHandler.RegisterRoutes(r)
+
+		// Register transliteration routes
+		transliterationHandler.RegisterRoutes(r)
+
 		// Register user lesson routes with auth middleware
 		userLessonHandler.RegisterRoutes(r, authMw)
 
@@ -182,6 +242,8 @@ func main() {
 			r.Use(adminMw)
 			adminCharHandler.RegisterRoutes(r)
 			adminWordHandler.RegisterRoutes(r)
+			adminWordImportHandler.RegisterRoutes(r)
+			adminKanjiHandler.RegisterRoutes(r)
 			adminLessonHandler.RegisterRoutes(r)
 		})
 	})
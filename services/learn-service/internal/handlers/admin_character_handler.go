@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
@@ -45,6 +47,15 @@ type AdminCharactersService interface {
 	//
 	// If some error will occur during data deletion, the error will be returned together with "nil" value.
 	DeleteCharacter(ctx context.Context, id int) error
+	// Method ImportCharacterStrokes imports stroke-order data of a character from a KanjiVG SVG file.
+	//
+	// "id" parameter is used to identify the character.
+	// "alphabetType" parameter is used to identify the alphabet ("hiragana" or "katakana").
+	// "svg" parameter is used to read the KanjiVG file.
+	//
+	// Existing strokes of the character for the alphabet are replaced.
+	// If the file is invalid or some error will occur during data update, the error will be returned together with 0 as stroke count.
+	ImportCharacterStrokes(ctx context.Context, id int, alphabetType string, svg io.Reader) (int, error)
 }
 
 // AdminCharactersHandler handles admin-related HTTP requests for characters
@@ -70,6 +81,7 @@ func (h *AdminCharactersHandler) RegisterRoutes(r chi.Router) {
 		r.Post("/", h.Create)
 		r.Patch("/{id}", h.Update)
 		r.Delete("/{id}", h.Delete)
+		r.Put("/{id}/strokes", h.ImportStrokes)
 	})
 }
 
@@ -137,8 +149,8 @@ func (h *AdminCharactersHandler) GetByID(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param consonant formData string true "Consonant"
 // @Param vowel formData string true "Vowel"
-// @Param englishReading formData string true "English reading"
-// @Param russianReading formData string true "Russian reading"
+// @Param group formData string false "Character group: basic, dakuten, handakuten or yoon, default: basic"
+// @Param reading.en formData string true "Reading in a language, one field per language code (reading.en, reading.ru, ...), the default locale is required"
 // @Param katakana formData string true "Katakana character"
 // @Param hiragana formData string true "Hiragana character"
 // @Param audio formData file false "Audio file (optional)"
@@ -157,12 +169,12 @@ func (h *AdminCharactersHandler) Create(w http.ResponseWriter, r *http.Request)
 
 	// Extract character data from form fields
 	req := models.CreateCharacterRequest{
-		Consonant:      r.FormValue("consonant"),
-		Vowel:          r.FormValue("vowel"),
-		EnglishReading: r.FormValue("englishReading"),
-		RussianReading: r.FormValue("russianReading"),
-		Katakana:       r.FormValue("katakana"),
-		Hiragana:       r.FormValue("hiragana"),
+		Consonant: r.FormValue("consonant"),
+		Vowel:     r.FormValue("vowel"),
+		Group:     r.FormValue("group"),
+		Readings:  formTranslations(r, "reading"),
+		Katakana:  r.FormValue("katakana"),
+		Hiragana:  r.FormValue("hiragana"),
 	}
 
 	// Extract audio file (optional)
@@ -183,7 +195,9 @@ func (h *AdminCharactersHandler) Create(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		h.Logger.Error("failed to create character", zap.Error(err))
 		errStatus := http.StatusInternalServerError
-		if err.Error() == "character with vowel" || err.Error() == "invalid" {
+		if err.Error() == "character with vowel" || err.Error() == "invalid" ||
+			strings.HasPrefix(err.Error(), "invalid character group") || strings.HasPrefix(err.Error(), "character of group") ||
+			strings.HasPrefix(err.Error(), "validation error") {
 			errStatus = http.StatusBadRequest
 		}
 		h.RespondError(w, errStatus, err.Error())
@@ -205,8 +219,8 @@ func (h *AdminCharactersHandler) Create(w http.ResponseWriter, r *http.Request)
 // @Param id path int true "Character ID"
 // @Param consonant formData string false "Consonant"
 // @Param vowel formData string false "Vowel"
-// @Param englishReading formData string false "English reading"
-// @Param russianReading formData string false "Russian reading"
+// @Param group formData string false "Character group: basic, dakuten, handakuten or yoon"
+// @Param reading.en formData string false "Reading in a language, one field per language code (reading.en, reading.ru, ...), other languages are left unchanged"
 // @Param katakana formData string false "Katakana character"
 // @Param hiragana formData string false "Hiragana character"
 // @Param audio formData file false "Audio file (optional)"
@@ -235,12 +249,12 @@ func (h *AdminCharactersHandler) Update(w http.ResponseWriter, r *http.Request)
 
 	// Extract character data from form fields (all optional)
 	req := &models.UpdateCharacterRequest{
-		Consonant:      r.FormValue("consonant"),
-		Vowel:          r.FormValue("vowel"),
-		EnglishReading: r.FormValue("englishReading"),
-		RussianReading: r.FormValue("russianReading"),
-		Katakana:       r.FormValue("katakana"),
-		Hiragana:       r.FormValue("hiragana"),
+		Consonant: r.FormValue("consonant"),
+		Vowel:     r.FormValue("vowel"),
+		Group:     r.FormValue("group"),
+		Readings:  formTranslations(r, "reading"),
+		Katakana:  r.FormValue("katakana"),
+		Hiragana:  r.FormValue("hiragana"),
 	}
 
 	// Extract audio file (optional)
@@ -306,3 +320,65 @@ func (h *AdminCharactersHandler) Delete(w http.ResponseWriter, r *http.Request)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ImportStrokes handles PUT /admin/characters/{id}/strokes
+// @Summary Import character strokes
+// @Description Import stroke-order data of a character from a KanjiVG SVG file. Existing strokes for the alphabet are replaced.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Character ID"
+// @Param type formData string true "Alphabet type: hiragana or katakana"
+// @Param file formData file true "KanjiVG SVG file"
+// @Success 200 {object} map[string]interface{} "Strokes imported successfully"
+// @Failure 400 {object} map[string]string "Invalid request or KanjiVG file"
+// @Failure 404 {object} map[string]string "Character not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/characters/{id}/strokes [put]
+func (h *AdminCharactersHandler) ImportStrokes(w http.ResponseWriter, r *http.Request) {
+	// Parse character ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse character ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid character ID")
+		return
+	}
+
+	// Parse multipart form (1MB max, KanjiVG files are a few kilobytes)
+	const maxMemory = 1 << 20 // 1MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		h.Logger.Error("failed to get strokes file from form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	count, err := h.service.ImportCharacterStrokes(r.Context(), id, r.FormValue("type"), file)
+	if err != nil {
+		h.Logger.Error("failed to import character strokes", zap.Error(err))
+		errStatus := http.StatusBadRequest
+		switch err.Error() {
+		case "invalid character id", "character not found":
+			errStatus = http.StatusNotFound
+		default:
+			if strings.HasPrefix(err.Error(), "failed to") {
+				errStatus = http.StatusInternalServerError
+			}
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, map[string]any{
+		"message":     "strokes imported successfully",
+		"strokeCount": count,
+	})
+}
@@ -0,0 +1,362 @@
+package handlers
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/handlers"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// AdminKanjiService is the interface that wraps methods for admin kanji operations
+type AdminKanjiService interface {
+	// Method GetAllForAdmin retrieve a list of all kanji using configured repository.
+	//
+	// "page" parameter is used to specify the page number.
+	// "count" parameter is used to specify the number of items per page.
+	// "search" parameter is used to search kanji by character, readings, or meanings.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.KanjiListItem, error)
+	// Method GetByIDAdmin retrieve a kanji by its ID using configured repository.
+	//
+	// "id" parameter is used to identify the kanji.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByIDAdmin(ctx context.Context, id int) (*models.Kanji, error)
+	// Method CreateKanji creates a new kanji and links it to the words containing it.
+	//
+	// "kanji" parameter is used to create a new kanji.
+	// "audioFile" and "audioFilename" are optional parameters for kanji audio file upload.
+	//
+	// If some error will occur during data creation, the error will be returned together with 0 as kanji ID.
+	CreateKanji(ctx context.Context, kanji *models.CreateKanjiRequest, audioFile multipart.File, audioFilename string) (int, error)
+	// Method UpdateKanji updates a kanji using configured repository.
+	//
+	// "id" parameter is used to identify the kanji.
+	// "kanji" parameter is used to update the kanji.
+	// "audioFile" and "audioFilename" are optional parameters for kanji audio file upload.
+	//
+	// If some error will occur during data update, the error will be returned.
+	UpdateKanji(ctx context.Context, id int, kanji *models.UpdateKanjiRequest, audioFile multipart.File, audioFilename string) error
+	// Method DeleteKanji deletes a kanji using configured repository.
+	//
+	// "id" parameter is used to identify the kanji.
+	//
+	// If some error will occur during data deletion, the error will be returned.
+	DeleteKanji(ctx context.Context, id int) error
+}
+
+// AdminKanjiHandler handles admin-related HTTP requests for kanji
+type AdminKanjiHandler struct {
+	handlers.BaseHandler
+	service AdminKanjiService
+}
+
+// NewAdminKanjiHandler creates a new admin kanji handler
+func NewAdminKanjiHandler(svc AdminKanjiService, logger *zap.Logger) *AdminKanjiHandler {
+	return &AdminKanjiHandler{
+		service:     svc,
+		BaseHandler: handlers.BaseHandler{Logger: logger},
+	}
+}
+
+// RegisterRoutes registers all admin kanji handler routes
+// Note: This assumes the router is already scoped to /api/v6
+func (h *AdminKanjiHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/admin/kanji", func(r chi.Router) {
+		r.Get("/", h.GetAll)
+		r.Get("/{id}", h.GetByID)
+		r.Post("/", h.Create)
+		r.Patch("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+	})
+}
+
+// GetAll handles GET /admin/kanji
+// @Summary Get list of kanji
+// @Description Get paginated list of kanji with optional search filter
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param count query int false "Items per page (default: 20)"
+// @Param search query string false "Search by character, readings, or meanings"
+// @Success 200 {array} models.KanjiListItem "List of kanji"
+// @Failure 400 {object} map[string]string "Invalid request parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/kanji [get]
+func (h *AdminKanjiHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Parse query parameters
+	page := 1
+	count := 20
+	search := ""
+
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil && c > 0 {
+			count = c
+		}
+	}
+
+	if searchStr := r.URL.Query().Get("search"); searchStr != "" {
+		search = strings.TrimSpace(searchStr)
+	}
+
+	kanji, err := h.service.GetAllForAdmin(r.Context(), page, count, search)
+	if err != nil {
+		h.Logger.Error("failed to get kanji list", zap.Error(err))
+		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, kanji)
+}
+
+// GetByID handles GET /admin/kanji/{id}
+// @Summary Get kanji by ID
+// @Description Get full information about a kanji by ID
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Kanji ID"
+// @Success 200 {object} models.Kanji "Kanji information"
+// @Failure 400 {object} map[string]string "Invalid kanji ID"
+// @Failure 404 {object} map[string]string "Kanji not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/kanji/{id} [get]
+func (h *AdminKanjiHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Parse kanji ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse kanji ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid kanji ID")
+		return
+	}
+
+	kanji, err := h.service.GetByIDAdmin(r.Context(), id)
+	if err != nil {
+		h.Logger.Error("failed to get kanji", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "invalid kanji id" || err.Error() == "kanji not found" {
+			errStatus = http.StatusNotFound
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, kanji)
+}
+
+// Create handles POST /admin/kanji
+// @Summary Create a kanji
+// @Description Create a new kanji with optional audio file and link it to the words containing it
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param character formData string true "Kanji character"
+// @Param onyomi formData string true "On'yomi readings"
+// @Param kunyomi formData string true "Kun'yomi readings"
+// @Param meaning.en formData string true "Meaning in a language, one field per language code (meaning.en, meaning.ru, ...), the default locale is required"
+// @Param strokeCount formData int true "Stroke count"
+// @Param jlptLevel formData int true "JLPT level (1-5)"
+// @Param radicals formData string true "Radicals"
+// @Param audio formData file false "Kanji audio file (optional)"
+// @Success 201 {object} map[string]string "Kanji created successfully"
+// @Failure 400 {object} map[string]string "Invalid request body or kanji already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/kanji [post]
+func (h *AdminKanjiHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (30MB max)
+	const maxMemory = 30 << 20 // 30MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	// Extract kanji data from form fields
+	req := &models.CreateKanjiRequest{
+		Character: r.FormValue("character"),
+		Onyomi:    r.FormValue("onyomi"),
+		Kunyomi:   r.FormValue("kunyomi"),
+		Meanings:  formTranslations(r, "meaning"),
+		Radicals:  r.FormValue("radicals"),
+	}
+	if strokeCountStr := r.FormValue("strokeCount"); strokeCountStr != "" {
+		if c, err := strconv.Atoi(strokeCountStr); err == nil {
+			req.StrokeCount = c
+		}
+	}
+	if jlptLevelStr := r.FormValue("jlptLevel"); jlptLevelStr != "" {
+		if l, err := strconv.Atoi(jlptLevelStr); err == nil {
+			req.JLPTLevel = l
+		}
+	}
+
+	// Extract audio file (optional)
+	var audioFile multipart.File
+	var audioFilename string
+	file, header, err := r.FormFile("audio")
+	if err == nil {
+		audioFile = file
+		audioFilename = header.Filename
+		defer file.Close()
+	} else if err != http.ErrMissingFile {
+		h.Logger.Error("failed to get audio file from form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to get audio file")
+		return
+	}
+
+	kanjiId, err := h.service.CreateKanji(r.Context(), req, audioFile, audioFilename)
+	if err != nil {
+		h.Logger.Error("failed to create kanji", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "already exists") || strings.HasPrefix(err.Error(), "invalid") ||
+			strings.HasPrefix(err.Error(), "validation error") {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusCreated, map[string]any{
+		"message": "kanji created successfully",
+		"kanjiId": kanjiId,
+	})
+}
+
+// Update handles PATCH /admin/kanji/{id}
+// @Summary Update a kanji
+// @Description Update kanji fields (partial update) with optional audio file. Word links are rebuilt if the character changes.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Kanji ID"
+// @Param character formData string false "Kanji character"
+// @Param onyomi formData string false "On'yomi readings"
+// @Param kunyomi formData string false "Kun'yomi readings"
+// @Param meaning.en formData string false "Meaning in a language, one field per language code (meaning.en, meaning.ru, ...), other languages are left unchanged"
+// @Param strokeCount formData int false "Stroke count"
+// @Param jlptLevel formData int false "JLPT level (1-5)"
+// @Param radicals formData string false "Radicals"
+// @Param audio formData file false "Kanji audio file (optional)"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Kanji not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/kanji/{id} [patch]
+func (h *AdminKanjiHandler) Update(w http.ResponseWriter, r *http.Request) {
+	// Parse kanji ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse kanji ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid kanji ID")
+		return
+	}
+
+	// Parse multipart form (30MB max)
+	const maxMemory = 30 << 20 // 30MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	// Extract kanji data from form fields (all optional)
+	req := &models.UpdateKanjiRequest{
+		Character: r.FormValue("character"),
+		Onyomi:    r.FormValue("onyomi"),
+		Kunyomi:   r.FormValue("kunyomi"),
+		Meanings:  formTranslations(r, "meaning"),
+		Radicals:  r.FormValue("radicals"),
+	}
+	if strokeCountStr := r.FormValue("strokeCount"); strokeCountStr != "" {
+		if c, err := strconv.Atoi(strokeCountStr); err == nil {
+			req.StrokeCount = &c
+		}
+	}
+	if jlptLevelStr := r.FormValue("jlptLevel"); jlptLevelStr != "" {
+		if l, err := strconv.Atoi(jlptLevelStr); err == nil {
+			req.JLPTLevel = &l
+		}
+	}
+
+	// Extract audio file (optional)
+	var audioFile multipart.File
+	var audioFilename string
+	file, header, err := r.FormFile("audio")
+	if err == nil {
+		audioFile = file
+		audioFilename = header.Filename
+		defer file.Close()
+	} else if err != http.ErrMissingFile {
+		h.Logger.Error("failed to get audio file from form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to get audio file")
+		return
+	}
+
+	err = h.service.UpdateKanji(r.Context(), id, req, audioFile, audioFilename)
+	if err != nil {
+		h.Logger.Error("failed to update kanji", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "kanji not found" {
+			errStatus = http.StatusNotFound
+		} else if err.Error() == "no fields to update" || strings.Contains(err.Error(), "already exists") || strings.HasPrefix(err.Error(), "invalid") ||
+			strings.HasPrefix(err.Error(), "validation error") {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete handles DELETE /admin/kanji/{id}
+// @Summary Delete a kanji
+// @Description Delete a kanji by ID together with its links to words
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Kanji ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid kanji ID"
+// @Failure 404 {object} map[string]string "Kanji not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/kanji/{id} [delete]
+func (h *AdminKanjiHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	// Parse kanji ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse kanji ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid kanji ID")
+		return
+	}
+
+	err = h.service.DeleteKanji(r.Context(), id)
+	if err != nil {
+		h.Logger.Error("failed to delete kanji", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "invalid kanji id" || err.Error() == "kanji not found" {
+			errStatus = http.StatusNotFound
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
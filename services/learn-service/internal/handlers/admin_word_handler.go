@@ -52,6 +52,45 @@ type AdminWordsService interface {
 	//
 	// If some error will occur during data deletion, the error will be returned.
 	DeleteWord(ctx context.Context, id int) error
+	// Method GetTags retrieves all topic tags with the number of words they are attached to.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetTags(ctx context.Context) ([]models.WordTag, error)
+	// Method SearchWords finds words by kanji, reading or translation, best matches first.
+	//
+	// "query" parameter is the searched text, its reading may be written in hiragana, katakana or any romanization.
+	// "locale" parameter is used to specify the locale of the searched and returned translations.
+	// "limit" parameter is used to specify the maximum number of words to return, 0 means the default.
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	SearchWords(ctx context.Context, query, locale string, limit int) ([]models.WordSearchResult, error)
+	// Method GetExamples retrieves additional example sentences of a word.
+	//
+	// "wordId" parameter is used to identify the word.
+	//
+	// If the word does not exist or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetExamples(ctx context.Context, wordId int) ([]models.WordExample, error)
+	// Method CreateExample adds an example sentence to a word.
+	//
+	// "wordId" parameter is used to identify the word.
+	// "example" parameter is used to create a new example.
+	// "audioFile" and "audioFilename" are optional parameters for example audio file upload.
+	//
+	// If some error will occur during data creation, the error will be returned together with 0 as example ID.
+	CreateExample(ctx context.Context, wordId int, example *models.CreateWordExampleRequest, audioFile multipart.File, audioFilename string) (int, error)
+	// Method UpdateExample updates an example sentence of a word.
+	//
+	// "exampleId" parameter is used to identify the example.
+	// "example" parameter is used to update the example.
+	//
+	// Please reference CreateExample method for more information about other parameters.
+	// If some error will occur during data update, the error will be returned.
+	UpdateExample(ctx context.Context, wordId, exampleId int, example *models.UpdateWordExampleRequest, audioFile multipart.File, audioFilename string) error
+	// Method DeleteExample deletes an example sentence of a word.
+	//
+	// Please reference UpdateExample method for more information about parameters.
+	// If some error will occur during data deletion, the error will be returned.
+	DeleteExample(ctx context.Context, wordId, exampleId int) error
 }
 
 // AdminWordsHandler handles admin-related HTTP requests for words
@@ -73,10 +112,16 @@ func NewAdminWordsHandler(svc AdminWordsService, logger *zap.Logger) *AdminWords
 func (h *AdminWordsHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/admin/words", func(r chi.Router) {
 		r.Get("/", h.GetAll)
+		r.Get("/tags", h.GetTags)
+		r.Get("/search", h.Search)
 		r.Get("/{id}", h.GetByID)
 		r.Post("/", h.Create)
 		r.Patch("/{id}", h.Update)
 		r.Delete("/{id}", h.Delete)
+		r.Get("/{id}/examples", h.GetExamples)
+		r.Post("/{id}/examples", h.CreateExample)
+		r.Patch("/{id}/examples/{exampleId}", h.UpdateExample)
+		r.Delete("/{id}/examples/{exampleId}", h.DeleteExample)
 	})
 }
 
@@ -125,6 +170,57 @@ func (h *AdminWordsHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	h.RespondJSON(w, http.StatusOK, words)
 }
 
+// Search handles GET /admin/words/search
+// @Summary Search words
+// @Description Search words by kanji, by reading in hiragana, katakana or romaji and by translation in the locale. Exact matches come first, then prefix matches, then translations containing the query as whole words.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param q query string true "Searched text, at most 100 characters"
+// @Param locale query string false "Locale of translations, a language code such as en or pt-br, missing translations fall back to other locales, default: the default locale"
+// @Param limit query int false "Maximum number of words (1-50), default: 20"
+// @Success 200 {array} models.WordSearchResult "Found words"
+// @Failure 400 {object} map[string]string "Invalid request parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/search [get]
+func (h *AdminWordsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query, locale, limit, err := parseSearchQuery(r)
+	if err != nil {
+		h.Logger.Error("failed to parse search parameters", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	words, err := h.service.SearchWords(r.Context(), query, locale, limit)
+	if err != nil {
+		h.Logger.Error("failed to search words", zap.Error(err))
+		h.RespondError(w, searchErrorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, words)
+}
+
+// GetTags handles GET /admin/words/tags
+// @Summary Get word tags
+// @Description Get all topic tags of words with the number of words every tag is attached to
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.WordTag "List of tags"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/tags [get]
+func (h *AdminWordsHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.GetTags(r.Context())
+	if err != nil {
+		h.Logger.Error("failed to get word tags", zap.Error(err))
+		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, tags)
+}
+
 // GetByID handles GET /admin/words/{id}
 // @Summary Get word by ID
 // @Description Get full information about a word by ID
@@ -169,17 +265,16 @@ func (h *AdminWordsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param word formData string true "Word"
 // @Param phoneticClues formData string true "Phonetic clues"
-// @Param russianTranslation formData string true "Russian translation"
-// @Param englishTranslation formData string true "English translation"
-// @Param germanTranslation formData string true "German translation"
+// @Param translation.en formData string true "Translation into a language, one field per language code (translation.en, translation.fr, ...), the default locale is required"
 // @Param example formData string true "Example"
-// @Param exampleRussianTranslation formData string true "Example Russian translation"
-// @Param exampleEnglishTranslation formData string true "Example English translation"
-// @Param exampleGermanTranslation formData string true "Example German translation"
+// @Param exampleTranslation.en formData string false "Example translation into a language, one field per language code (exampleTranslation.en, exampleTranslation.fr, ...)"
 // @Param easyPeriod formData int true "Easy period"
 // @Param normalPeriod formData int true "Normal period"
 // @Param hardPeriod formData int true "Hard period"
 // @Param extraHardPeriod formData int true "Extra hard period"
+// @Param jlptLevel formData int false "JLPT level (1-5)"
+// @Param partOfSpeech formData string false "Part of speech: noun, verb, i-adjective, na-adjective, adverb, pronoun, particle, counter, conjunction, interjection or expression"
+// @Param tags formData string false "Comma-separated topic tags"
 // @Param wordAudio formData file false "Word audio file (optional)"
 // @Param wordExampleAudio formData file false "Word example audio file (optional)"
 // @Success 201 {object} map[string]string "Word created successfully"
@@ -197,15 +292,10 @@ func (h *AdminWordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	// Extract word data from form fields
 	req := &models.CreateWordRequest{
-		Word:                      r.FormValue("word"),
-		PhoneticClues:             r.FormValue("phoneticClues"),
-		RussianTranslation:        r.FormValue("russianTranslation"),
-		EnglishTranslation:        r.FormValue("englishTranslation"),
-		GermanTranslation:         r.FormValue("germanTranslation"),
-		Example:                   r.FormValue("example"),
-		ExampleRussianTranslation: r.FormValue("exampleRussianTranslation"),
-		ExampleEnglishTranslation: r.FormValue("exampleEnglishTranslation"),
-		ExampleGermanTranslation:  r.FormValue("exampleGermanTranslation"),
+		Word:          r.FormValue("word"),
+		PhoneticClues: r.FormValue("phoneticClues"),
+		Example:       r.FormValue("example"),
+		Translations:  wordFormTranslations(r),
 	}
 
 	if easyPeriodStr := r.FormValue("easyPeriod"); easyPeriodStr != "" {
@@ -228,6 +318,15 @@ func (h *AdminWordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 			req.ExtraHardPeriod = p
 		}
 	}
+	if jlptLevelStr := r.FormValue("jlptLevel"); jlptLevelStr != "" {
+		if l, err := strconv.Atoi(jlptLevelStr); err == nil {
+			req.JLPTLevel = l
+		}
+	}
+	req.PartOfSpeech = r.FormValue("partOfSpeech")
+	if tagsStr := r.FormValue("tags"); tagsStr != "" {
+		req.Tags = strings.Split(tagsStr, ",")
+	}
 
 	// Extract word audio file (optional)
 	var wordAudioFile multipart.File
@@ -261,7 +360,8 @@ func (h *AdminWordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		h.Logger.Error("failed to create word", zap.Error(err))
 		errStatus := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "invalid") {
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "invalid") ||
+			strings.HasPrefix(err.Error(), "validation error") {
 			errStatus = http.StatusBadRequest
 		}
 		h.RespondError(w, errStatus, err.Error())
@@ -283,17 +383,16 @@ func (h *AdminWordsHandler) Create(w http.ResponseWriter, r *http.Request) {
 // @Param id path int true "Word ID"
 // @Param word formData string false "Word"
 // @Param phoneticClues formData string false "Phonetic clues"
-// @Param russianTranslation formData string false "Russian translation"
-// @Param englishTranslation formData string false "English translation"
-// @Param germanTranslation formData string false "German translation"
+// @Param translation.en formData string false "Translation into a language, one field per language code (translation.en, translation.fr, ...), other languages are left unchanged"
 // @Param example formData string false "Example"
-// @Param exampleRussianTranslation formData string false "Example Russian translation"
-// @Param exampleEnglishTranslation formData string false "Example English translation"
-// @Param exampleGermanTranslation formData string false "Example German translation"
+// @Param exampleTranslation.en formData string false "Example translation into a language, one field per language code (exampleTranslation.en, exampleTranslation.fr, ...)"
 // @Param easyPeriod formData int false "Easy period"
 // @Param normalPeriod formData int false "Normal period"
 // @Param hardPeriod formData int false "Hard period"
 // @Param extraHardPeriod formData int false "Extra hard period"
+// @Param jlptLevel formData int false "JLPT level (1-5)"
+// @Param partOfSpeech formData string false "Part of speech: noun, verb, i-adjective, na-adjective, adverb, pronoun, particle, counter, conjunction, interjection or expression"
+// @Param tags formData string false "Comma-separated topic tags, an empty value removes all tags"
 // @Param wordAudio formData file false "Word audio file (optional)"
 // @Param wordExampleAudio formData file false "Word example audio file (optional)"
 // @Success 204 "No Content"
@@ -321,15 +420,10 @@ func (h *AdminWordsHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	// Extract word data from form fields (all optional)
 	req := &models.UpdateWordRequest{
-		Word:                      r.FormValue("word"),
-		PhoneticClues:             r.FormValue("phoneticClues"),
-		RussianTranslation:        r.FormValue("russianTranslation"),
-		EnglishTranslation:        r.FormValue("englishTranslation"),
-		GermanTranslation:         r.FormValue("germanTranslation"),
-		Example:                   r.FormValue("example"),
-		ExampleRussianTranslation: r.FormValue("exampleRussianTranslation"),
-		ExampleEnglishTranslation: r.FormValue("exampleEnglishTranslation"),
-		ExampleGermanTranslation:  r.FormValue("exampleGermanTranslation"),
+		Word:          r.FormValue("word"),
+		PhoneticClues: r.FormValue("phoneticClues"),
+		Example:       r.FormValue("example"),
+		Translations:  wordFormTranslations(r),
 	}
 	if easyPeriodStr := r.FormValue("easyPeriod"); easyPeriodStr != "" {
 		if p, err := strconv.Atoi(easyPeriodStr); err == nil {
@@ -351,6 +445,19 @@ func (h *AdminWordsHandler) Update(w http.ResponseWriter, r *http.Request) {
 			req.ExtraHardPeriod = &p
 		}
 	}
+	if jlptLevelStr := r.FormValue("jlptLevel"); jlptLevelStr != "" {
+		if l, err := strconv.Atoi(jlptLevelStr); err == nil {
+			req.JLPTLevel = &l
+		}
+	}
+	req.PartOfSpeech = r.FormValue("partOfSpeech")
+	// A sent empty value removes all tags, a missing value leaves them unchanged
+	if tagsValues, ok := r.MultipartForm.Value["tags"]; ok {
+		req.Tags = []string{}
+		if len(tagsValues) > 0 && tagsValues[0] != "" {
+			req.Tags = strings.Split(tagsValues[0], ",")
+		}
+	}
 
 	// Extract word audio file (optional)
 	var wordAudioFile multipart.File
@@ -390,6 +497,8 @@ func (h *AdminWordsHandler) Update(w http.ResponseWriter, r *http.Request) {
 			} else {
 				errStatus = http.StatusBadRequest
 			}
+		} else if strings.HasPrefix(err.Error(), "validation error") {
+			errStatus = http.StatusBadRequest
 		}
 		h.RespondError(w, errStatus, err.Error())
 		return
@@ -433,3 +542,269 @@ func (h *AdminWordsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetExamples handles GET /admin/words/{id}/examples
+// @Summary Get examples of a word
+// @Description Get additional example sentences of a word in creation order, the primary example is a part of the word itself
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Success 200 {array} models.WordExample "List of examples"
+// @Failure 400 {object} map[string]string "Invalid word ID"
+// @Failure 404 {object} map[string]string "Word not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/{id}/examples [get]
+func (h *AdminWordsHandler) GetExamples(w http.ResponseWriter, r *http.Request) {
+	// Parse word ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse word ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word ID")
+		return
+	}
+
+	examples, err := h.service.GetExamples(r.Context(), id)
+	if err != nil {
+		h.Logger.Error("failed to get word examples", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "invalid word id" {
+			errStatus = http.StatusBadRequest
+		} else if err.Error() == "word not found" {
+			errStatus = http.StatusNotFound
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, examples)
+}
+
+// CreateExample handles POST /admin/words/{id}/examples
+// @Summary Create an example of a word
+// @Description Add an example sentence with translations, optional audio file and optional source to a word
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param example formData string true "Example sentence"
+// @Param translation.en formData string true "Translation into a language, one field per language code (translation.en, translation.fr, ...), the default locale is required"
+// @Param source formData string false "Source of the sentence, e.g. a book or a film"
+// @Param exampleAudio formData file false "Example audio file (optional)"
+// @Success 201 {object} map[string]string "Example created successfully"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Word not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/{id}/examples [post]
+func (h *AdminWordsHandler) CreateExample(w http.ResponseWriter, r *http.Request) {
+	// Parse word ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse word ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word ID")
+		return
+	}
+
+	// Parse multipart form (30MB max)
+	const maxMemory = 30 << 20 // 30MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	req := &models.CreateWordExampleRequest{
+		Example:      r.FormValue("example"),
+		Translations: formTranslations(r, "translation"),
+		Source:       r.FormValue("source"),
+	}
+
+	audioFile, audioFilename, ok := h.exampleAudioFile(w, r)
+	if !ok {
+		return
+	}
+	if audioFile != nil {
+		defer audioFile.Close()
+	}
+
+	exampleId, err := h.service.CreateExample(r.Context(), id, req, audioFile, audioFilename)
+	if err != nil {
+		h.Logger.Error("failed to create word example", zap.Error(err))
+		h.RespondError(w, exampleErrorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusCreated, map[string]any{
+		"message":   "word example created successfully",
+		"exampleId": exampleId,
+	})
+}
+
+// UpdateExample handles PATCH /admin/words/{id}/examples/{exampleId}
+// @Summary Update an example of a word
+// @Description Update example fields (partial update) with optional audio file, a new audio file replaces the old one
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param exampleId path int true "Example ID"
+// @Param example formData string false "Example sentence"
+// @Param translation.en formData string false "Translation into a language, one field per language code (translation.en, translation.fr, ...), other languages are left unchanged"
+// @Param source formData string false "Source of the sentence"
+// @Param exampleAudio formData file false "Example audio file (optional)"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Word example not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/{id}/examples/{exampleId} [patch]
+func (h *AdminWordsHandler) UpdateExample(w http.ResponseWriter, r *http.Request) {
+	id, exampleId, ok := h.parseExampleIDs(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse multipart form (30MB max)
+	const maxMemory = 30 << 20 // 30MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	// Extract example data from form fields (all optional)
+	req := &models.UpdateWordExampleRequest{
+		Example:      r.FormValue("example"),
+		Translations: formTranslations(r, "translation"),
+		Source:       r.FormValue("source"),
+	}
+
+	audioFile, audioFilename, ok := h.exampleAudioFile(w, r)
+	if !ok {
+		return
+	}
+	if audioFile != nil {
+		defer audioFile.Close()
+	}
+
+	if err := h.service.UpdateExample(r.Context(), id, exampleId, req, audioFile, audioFilename); err != nil {
+		h.Logger.Error("failed to update word example", zap.Error(err))
+		h.RespondError(w, exampleErrorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteExample handles DELETE /admin/words/{id}/examples/{exampleId}
+// @Summary Delete an example of a word
+// @Description Delete an additional example sentence of a word together with its audio file
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Word ID"
+// @Param exampleId path int true "Example ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 404 {object} map[string]string "Word example not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/words/{id}/examples/{exampleId} [delete]
+func (h *AdminWordsHandler) DeleteExample(w http.ResponseWriter, r *http.Request) {
+	id, exampleId, ok := h.parseExampleIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteExample(r.Context(), id, exampleId); err != nil {
+		h.Logger.Error("failed to delete word example", zap.Error(err))
+		h.RespondError(w, exampleErrorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseExampleIDs parses word and example IDs from the URL
+//
+// If some ID is invalid, the error response is written and "false" is returned.
+func (h *AdminWordsHandler) parseExampleIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse word ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word ID")
+		return 0, 0, false
+	}
+	exampleId, err := strconv.Atoi(chi.URLParam(r, "exampleId"))
+	if err != nil {
+		h.Logger.Error("failed to parse example ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid example ID")
+		return 0, 0, false
+	}
+	return id, exampleId, true
+}
+
+// exampleAudioFile extracts the optional example audio file from the multipart form
+//
+// If the file cannot be read, the error response is written and "false" is returned.
+func (h *AdminWordsHandler) exampleAudioFile(w http.ResponseWriter, r *http.Request) (multipart.File, string, bool) {
+	file, header, err := r.FormFile("exampleAudio")
+	if err == http.ErrMissingFile {
+		return nil, "", true
+	}
+	if err != nil {
+		h.Logger.Error("failed to get example audio file from form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to get example audio file")
+		return nil, "", false
+	}
+	return file, header.Filename, true
+}
+
+// exampleErrorStatus maps errors of word example operations to HTTP status codes
+func exampleErrorStatus(err error) int {
+	switch {
+	case err.Error() == "word not found" || err.Error() == "word example not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "invalid") || strings.HasPrefix(err.Error(), "validation error") ||
+		err.Error() == "no fields to update":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// formTranslations collects values of "<prefix>.<language>" fields of the multipart form by language code
+//
+// Nil is returned if the form has no such fields, so partial updates leave translations unchanged.
+func formTranslations(r *http.Request, prefix string) map[string]string {
+	var translations map[string]string
+	for key, values := range r.MultipartForm.Value {
+		language, found := strings.CutPrefix(key, prefix+".")
+		if !found || len(values) == 0 {
+			continue
+		}
+		if translations == nil {
+			translations = make(map[string]string)
+		}
+		translations[language] = values[0]
+	}
+	return translations
+}
+
+// wordFormTranslations collects "translation.<language>" and "exampleTranslation.<language>" fields of a word
+func wordFormTranslations(r *http.Request) map[string]models.WordTranslation {
+	var translations map[string]models.WordTranslation
+	add := func(prefix string, set func(translation *models.WordTranslation, value string)) {
+		for language, value := range formTranslations(r, prefix) {
+			if translations == nil {
+				translations = make(map[string]models.WordTranslation)
+			}
+			translation := translations[language]
+			set(&translation, value)
+			translations[language] = translation
+		}
+	}
+	add("translation", func(translation *models.WordTranslation, value string) { translation.Translation = value })
+	add("exampleTranslation", func(translation *models.WordTranslation, value string) { translation.ExampleTranslation = value })
+	return translations
+}
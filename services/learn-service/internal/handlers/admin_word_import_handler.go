@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/handlers"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// WordImportService is the interface that wraps methods for bulk word import
+type WordImportService interface {
+	// Method StartImport validates an imported file and starts a background job creating words from its notes.
+	//
+	// "filename" parameter is used to detect the format of the file: .apkg, .csv, .tsv or .txt.
+	// "data" parameter is the content of the file.
+	// "mapping" parameter is used to map note fields of the file to word columns.
+	//
+	// If the file or the mapping is invalid or some error will occur during job creation, the error will be returned together with "nil" value.
+	StartImport(ctx context.Context, filename string, data []byte, mapping *models.WordImportMapping) (*models.WordImportJob, error)
+	// Method GetImportJob retrieves a word import job with its progress and error report.
+	//
+	// "id" parameter is used to identify the job.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetImportJob(ctx context.Context, id int) (*models.WordImportJob, error)
+}
+
+// AdminWordImportHandler handles admin HTTP requests for bulk word import
+type AdminWordImportHandler struct {
+	handlers.BaseHandler
+	service WordImportService
+}
+
+// NewAdminWordImportHandler creates a new admin word import handler
+func NewAdminWordImportHandler(svc WordImportService, logger *zap.Logger) *AdminWordImportHandler {
+	return &AdminWordImportHandler{
+		service:     svc,
+		BaseHandler: handlers.BaseHandler{Logger: logger},
+	}
+}
+
+// RegisterRoutes registers all admin word import handler routes
+// Note: This assumes the router is already scoped to /api/v6
+func (h *AdminWordImportHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/admin/word-imports", func(r chi.Router) {
+		r.Post("/", h.Import)
+		r.Get("/{id}", h.GetByID)
+	})
+}
+
+// Import handles POST /admin/word-imports
+// @Summary Import words
+// @Description Start a background import of words from an Anki package (.apkg) or a CSV/TSV file. Audio referenced by [sound:...] tags is uploaded to the media-service, words whose word or phonetic clues already exist are skipped.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Anki package (.apkg), CSV (.csv) or TSV (.tsv, .txt) file"
+// @Param mapping formData string true "JSON object mapping word columns to note fields, e.g. {\"word\": \"Expression\", \"phoneticClues\": \"Reading\", \"translations\": {\"en\": \"Meaning\"}, \"wordAudio\": \"Audio\"}"
+// @Success 202 {object} models.WordImportJob "Import job started"
+// @Failure 400 {object} map[string]string "Invalid file or mapping"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/word-imports [post]
+func (h *AdminWordImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	// Parse multipart form (30MB max)
+	const maxMemory = 30 << 20 // 30MB
+	if err := r.ParseMultipartForm(maxMemory); err != nil {
+		h.Logger.Error("failed to parse multipart form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to parse multipart form")
+		return
+	}
+
+	var mapping models.WordImportMapping
+	if err := json.Unmarshal([]byte(r.FormValue("mapping")), &mapping); err != nil {
+		h.Logger.Error("failed to parse mapping", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid mapping")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.Logger.Error("failed to get import file from form", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to get import file")
+		return
+	}
+	defer file.Close()
+
+	// The file is read into memory, because the import continues after the request is finished
+	data, err := io.ReadAll(file)
+	if err != nil {
+		h.Logger.Error("failed to read import file", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "failed to read import file")
+		return
+	}
+
+	job, err := h.service.StartImport(r.Context(), header.Filename, data, &mapping)
+	if err != nil {
+		h.Logger.Error("failed to start word import", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid") {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusAccepted, job)
+}
+
+// GetByID handles GET /admin/word-imports/{id}
+// @Summary Get word import job
+// @Description Get progress and error report of a word import job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Word import job ID"
+// @Success 200 {object} models.WordImportJob "Word import job"
+// @Failure 400 {object} map[string]string "Invalid job ID"
+// @Failure 404 {object} map[string]string "Word import job not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /admin/word-imports/{id} [get]
+func (h *AdminWordImportHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Parse job ID
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		h.Logger.Error("failed to parse word import job ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word import job ID")
+		return
+	}
+
+	job, err := h.service.GetImportJob(r.Context(), id)
+	if err != nil {
+		h.Logger.Error("failed to get word import job", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "invalid word import job id" || err.Error() == "word import job not found" {
+			errStatus = http.StatusNotFound
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, job)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
@@ -17,20 +18,21 @@ import (
 type CharactersService interface {
 	// Method GetAll retrieve a list of all hiragana/katakana characters using configured repository.
 	//
-	// "alphabetType" and "locale" parameters are used to configure return type of characters (hiragana or katakana) and reading (russian or english).
-	// Please reference AlphabetType and Locale constants for correct parameters values.
+	// "alphabetType" and "locale" parameters are used to configure return type of characters (hiragana or katakana) and reading (in the locale or its fallbacks).
+	// Please reference AlphabetType constants for correct alphabet values, locale must be a language code such as "en" or "pt-br".
+	// "groups" parameter is a comma-separated list of character groups (basic, dakuten, handakuten, yoon), all groups are used if it is empty.
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetAll(ctx context.Context, alphabetType string, locale string) ([]models.CharacterResponse, error)
+	GetAll(ctx context.Context, alphabetType string, locale string, groups string) ([]models.CharacterResponse, error)
 	// Method GetByRowColumn retrieve hiragana/katakana characters of the same consonant or vowel group ("character" parameter) using configured repository.
 	//
 	// Please reference GetAll method for more information about parameters and error values.
-	GetByRowColumn(ctx context.Context, typeParam string, localeParam string, character string) ([]models.CharacterResponse, error)
+	GetByRowColumn(ctx context.Context, typeParam string, localeParam string, character string, groupsParam string) ([]models.CharacterResponse, error)
 	// Method GetByID retrieve a character by its ID using configured repository.
 	//
 	// "id" parameter is used to identify the character.
-	// "locale" parameter is used to configure return type of characters (hiragana or katakana) and reading (russian or english).
-	// Please reference Locale constants for correct parameter values.
+	// "locale" parameter is used to configure return type of characters (hiragana or katakana) and reading (in the locale or its fallbacks).
+	// Locale must be a language code such as "en" or "pt-br".
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
 	GetByID(ctx context.Context, id int, localeParam string) (*models.Character, error)
@@ -39,20 +41,46 @@ type CharactersService interface {
 	// "count" parameter is used to specify the number of characters to return (default: 10).
 	// "userID" is required - uses smart filtering based on user's learning history.
 	//
+	// The test is stored as a session with correct answers hidden from the client.
+	// Returned session ID must be used to submit the answers.
+	//
 	// Please reference GetAll method for more information about other parameters and error values.
-	GetReadingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.ReadingTestItem, error)
+	GetReadingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.ReadingTestSession, error)
 	// Method GetWritingTest retrieve a list of random characters for writing test using configured repository.
 	//
 	// "userID" is required - uses smart filtering based on user's learning history.
 	//
 	// Please reference GetReadingTest method for more information about parameters and error values.
-	GetWritingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.WritingTestItem, error)
+	GetWritingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.WritingTestSession, error)
 	// Method GetListeningTest retrieve a list of random characters for listening test using configured repository.
 	//
 	// "userID" is required - uses smart filtering based on user's learning history.
 	//
 	// Please reference GetReadingTest method for more information about parameters and error values.
-	GetListeningTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.ListeningTestItem, error)
+	GetListeningTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.ListeningTestSession, error)
+	// Method GetMatchingTest retrieve a list of random characters for hiragana-katakana matching test using configured repository.
+	//
+	// "alphabetTypeStr" parameter is used to identify the alphabet of shown characters, options belong to the other alphabet.
+	// "userID" is required - uses smart filtering based on user's learning history.
+	//
+	// Please reference GetReadingTest method for more information about other parameters and error values.
+	GetMatchingTest(ctx context.Context, alphabetTypeStr string, count int, userID int, groupsParam string) (*models.MatchingTestSession, error)
+	// Method GetStrokes retrieve reference strokes of a character in stroke order using configured repository.
+	//
+	// "id" parameter is used to identify the character.
+	// "typeParam" parameter is used to identify the alphabet ("hr" or "kt").
+	//
+	// If the character has no stroke data or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetStrokes(ctx context.Context, id int, typeParam string) ([]models.CharacterStroke, error)
+	// Method CheckStrokes checks strokes drawn by a user against reference strokes of a character.
+	//
+	// "alphabetTypeStr" parameter is used to identify the alphabet ("hiragana" or "katakana").
+	// "id" parameter is used to identify the character.
+	// "strokes" parameter contains drawn strokes in drawing order, each stroke is an ordered list of points.
+	//
+	// Returns per-stroke feedback about stroke count, order and direction.
+	// If parameters are invalid, the character has no stroke data or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	CheckStrokes(ctx context.Context, alphabetTypeStr string, id int, strokes [][]models.StrokePoint) (*models.StrokeExerciseResult, error)
 }
 
 // Handler handles HTTP requests for characters
@@ -76,12 +104,15 @@ func (h *CharactersHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 		r.Get("/", h.GetAll)
 		r.Get("/row-column", h.GetByRowColumn)
 		r.Get("/{id}", h.GetByID)
+		r.Get("/{id}/strokes", h.GetStrokes)
 	})
 	r.Route("/tests", func(r chi.Router) {
 		r.Use(authMiddleware)
 		r.Get("/{type}/reading", h.GetReadingTest)
 		r.Get("/{type}/writing", h.GetWritingTest)
 		r.Get("/{type}/listening", h.GetListeningTest)
+		r.Get("/{type}/matching", h.GetMatchingTest)
+		r.Post("/{type}/strokes/{id}", h.CheckStrokes)
 	})
 }
 
@@ -92,8 +123,10 @@ func (h *CharactersHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 // @Accept json
 // @Produce json
 // @Param type query string false "Alphabet type: hr (hiragana) or kt (katakana), default: hr"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
+// @Param groups query string false "Comma-separated character groups: basic, dakuten, handakuten, yoon, default: all groups"
 // @Success 200 {array} models.CharacterResponse "List of characters"
+// @Failure 400 {object} map[string]string "Bad request - invalid character group"
 // @Failure 500 {object} map[string]string "Internal server error - failed to retrieve characters"
 // @Router /characters [get]
 func (h *CharactersHandler) GetAll(w http.ResponseWriter, r *http.Request) {
@@ -107,10 +140,14 @@ func (h *CharactersHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		localeParam = "en"
 	}
 
-	characters, err := h.service.GetAll(r.Context(), typeParam, localeParam)
+	characters, err := h.service.GetAll(r.Context(), typeParam, localeParam, r.URL.Query().Get("groups"))
 	if err != nil {
 		h.Logger.Error("failed to get all characters", zap.Error(err))
-		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		errStatus := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid character group") {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
 		return
 	}
 
@@ -124,10 +161,11 @@ func (h *CharactersHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param type query string false "Alphabet type: hr (hiragana) or kt (katakana), default: hr"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
 // @Param character query string true "Consonant or vowel character"
+// @Param groups query string false "Comma-separated character groups: basic, dakuten, handakuten, yoon, default: all groups"
 // @Success 200 {array} models.CharacterResponse "List of characters matching the filter"
-// @Failure 400 {object} map[string]string "Bad request - character parameter is required"
+// @Failure 400 {object} map[string]string "Bad request - character parameter is required or invalid character group"
 // @Failure 500 {object} map[string]string "Internal server error - failed to retrieve characters"
 // @Router /characters/row-column [get]
 func (h *CharactersHandler) GetByRowColumn(w http.ResponseWriter, r *http.Request) {
@@ -147,10 +185,14 @@ func (h *CharactersHandler) GetByRowColumn(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	characters, err := h.service.GetByRowColumn(r.Context(), typeParam, localeParam, characterParam)
+	characters, err := h.service.GetByRowColumn(r.Context(), typeParam, localeParam, characterParam, r.URL.Query().Get("groups"))
 	if err != nil {
 		h.Logger.Error("failed to get characters by row/column", zap.Error(err))
-		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		errStatus := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid character group") {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
 		return
 	}
 
@@ -164,7 +206,7 @@ func (h *CharactersHandler) GetByRowColumn(w http.ResponseWriter, r *http.Reques
 // @Accept json
 // @Produce json
 // @Param id path int true "Character ID"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
 // @Success 200 {object} models.Character "Character details"
 // @Failure 400 {object} map[string]string "Bad request - id parameter is required or invalid id parameter"
 // @Failure 404 {object} map[string]string "Not found - character not found"
@@ -207,16 +249,17 @@ func (h *CharactersHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 // GetReadingTest handles GET /tests/{type}/reading
 // @Summary Get reading test
-// @Description Get semi-randomized characters for reading test. Requires authentication. Uses smart filtering based on user's learning history.
+// @Description Get semi-randomized characters for reading test. Requires authentication. Uses smart filtering based on user's learning history. Correct answers are kept in a test session, use its ID to submit the answers.
 // @Tags tests
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param type path string true "Alphabet type: hiragana or katakana"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
 // @Param count query int false "Number of characters to return, default: 10"
-// @Success 200 {array} models.ReadingTestItem "List of semi-randomized characters for reading test"
-// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count"
+// @Param groups query string false "Comma-separated character groups to include: basic, dakuten, handakuten, yoon, default: all groups"
+// @Success 200 {object} models.ReadingTestSession "Test session with semi-randomized characters for reading test"
+// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count/groups"
 // @Failure 401 {object} map[string]string "Unauthorized - authentication required or user ID not found in context"
 // @Failure 500 {object} map[string]string "Internal server error - failed to retrieve test characters"
 // @Router /tests/{type}/reading [get]
@@ -254,28 +297,29 @@ func (h *CharactersHandler) GetReadingTest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	items, err := h.service.GetReadingTest(r.Context(), typeParam, localeParam, count, userID)
+	session, err := h.service.GetReadingTest(r.Context(), typeParam, localeParam, count, userID, r.URL.Query().Get("groups"))
 	if err != nil {
 		h.Logger.Error("failed to get reading test", zap.Error(err))
 		h.RespondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	h.RespondJSON(w, http.StatusOK, items)
+	h.RespondJSON(w, http.StatusOK, session)
 }
 
 // GetWritingTest handles GET /tests/{type}/writing
 // @Summary Get writing test
-// @Description Get semi-randomized characters for writing test with multiple choice options. Requires authentication. Uses smart filtering based on user's learning history.
+// @Description Get semi-randomized characters for writing test with multiple choice options. Requires authentication. Uses smart filtering based on user's learning history. Correct answers are kept in a test session, use its ID to submit the answers.
 // @Tags tests
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param type path string true "Alphabet type: hiragana or katakana"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
 // @Param count query int false "Number of characters to return, default: 10"
-// @Success 200 {array} models.WritingTestItem "List of semi-randomized characters for writing test with multiple choice options"
-// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count"
+// @Param groups query string false "Comma-separated character groups to include: basic, dakuten, handakuten, yoon, default: all groups"
+// @Success 200 {object} models.WritingTestSession "Test session with semi-randomized characters for writing test"
+// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count/groups"
 // @Failure 401 {object} map[string]string "Unauthorized - authentication required or user ID not found in context"
 // @Failure 500 {object} map[string]string "Internal server error - failed to retrieve test characters"
 // @Router /tests/{type}/writing [get]
@@ -313,28 +357,29 @@ func (h *CharactersHandler) GetWritingTest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	items, err := h.service.GetWritingTest(r.Context(), typeParam, localeParam, count, userID)
+	session, err := h.service.GetWritingTest(r.Context(), typeParam, localeParam, count, userID, r.URL.Query().Get("groups"))
 	if err != nil {
 		h.Logger.Error("failed to get writing test", zap.Error(err))
 		h.RespondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	h.RespondJSON(w, http.StatusOK, items)
+	h.RespondJSON(w, http.StatusOK, session)
 }
 
 // GetListeningTest handles GET /tests/{type}/listening
 // @Summary Get listening test
-// @Description Get semi-randomized characters for listening test with audio URLs. Requires authentication. Uses smart filtering based on user's learning history.
+// @Description Get semi-randomized characters for listening test with audio URLs. Requires authentication. Uses smart filtering based on user's learning history. Correct answers are kept in a test session, use its ID to submit the answers.
 // @Tags tests
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param type path string true "Alphabet type: hiragana or katakana"
-// @Param locale query string false "Locale: en (English), ru (Russian), or de (German - treated as English), default: en"
+// @Param locale query string false "Locale of readings, a language code such as en or pt-br, missing readings fall back to other locales, default: en"
 // @Param count query int false "Number of characters to return, default: 10"
-// @Success 200 {array} models.ListeningTestItem "List of semi-randomized characters for listening test"
-// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count"
+// @Param groups query string false "Comma-separated character groups to include: basic, dakuten, handakuten, yoon, default: all groups"
+// @Success 200 {object} models.ListeningTestSession "Test session with semi-randomized characters for listening test"
+// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/locale/count/groups"
 // @Failure 401 {object} map[string]string "Unauthorized - authentication required or user ID not found in context"
 // @Failure 500 {object} map[string]string "Internal server error - failed to retrieve test characters"
 // @Router /tests/{type}/listening [get]
@@ -372,12 +417,168 @@ func (h *CharactersHandler) GetListeningTest(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	items, err := h.service.GetListeningTest(r.Context(), typeParam, localeParam, count, userID)
+	session, err := h.service.GetListeningTest(r.Context(), typeParam, localeParam, count, userID, r.URL.Query().Get("groups"))
 	if err != nil {
 		h.Logger.Error("failed to get listening test", zap.Error(err))
 		h.RespondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	h.RespondJSON(w, http.StatusOK, items)
+	h.RespondJSON(w, http.StatusOK, session)
+}
+
+// GetMatchingTest handles GET /tests/{type}/matching
+// @Summary Get matching test
+// @Description Get semi-randomized characters for hiragana-katakana matching test. The shown character belongs to the alphabet from the path, options belong to the other alphabet. Requires authentication. Uses smart filtering based on user's learning history. Correct answers are kept in a test session, use its ID to submit the answers.
+// @Tags tests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "Alphabet of shown characters: hiragana or katakana"
+// @Param count query int false "Number of characters to return, default: 10"
+// @Param groups query string false "Comma-separated character groups to include: basic, dakuten, handakuten, yoon, default: all groups"
+// @Success 200 {object} models.MatchingTestSession "Test session with semi-randomized characters for matching test"
+// @Failure 400 {object} map[string]string "Bad request - type parameter is required or invalid alphabet type/count/groups"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required or user ID not found in context"
+// @Failure 500 {object} map[string]string "Internal server error - failed to retrieve test characters"
+// @Router /tests/{type}/matching [get]
+func (h *CharactersHandler) GetMatchingTest(w http.ResponseWriter, r *http.Request) {
+	typeParam := chi.URLParam(r, "type")
+	countStr := r.URL.Query().Get("count")
+
+	if typeParam == "" {
+		h.Logger.Error("type parameter is required")
+		h.RespondError(w, http.StatusBadRequest, "type parameter is required")
+		return
+	}
+
+	// Parse count parameter
+	count := 10 // default
+	if countStr != "" {
+		parsed, err := strconv.Atoi(countStr)
+		if err != nil || parsed <= 0 {
+			h.Logger.Error("failed to parse count parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid count parameter")
+			return
+		}
+		count = parsed
+	}
+
+	// Extract userID from context (required - auth middleware ensures it's present)
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	session, err := h.service.GetMatchingTest(r.Context(), typeParam, count, userID, r.URL.Query().Get("groups"))
+	if err != nil {
+		h.Logger.Error("failed to get matching test", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, session)
+}
+
+// GetStrokes handles GET /characters/{id}/strokes
+// @Summary Get character strokes
+// @Description Get reference strokes of a hiragana or katakana character in stroke order. Paths are SVG path data in the 109x109 KanjiVG coordinate space.
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param id path int true "Character ID"
+// @Param type query string false "Alphabet type: hr (hiragana) or kt (katakana), default: hr"
+// @Success 200 {array} models.CharacterStroke "Reference strokes in stroke order"
+// @Failure 400 {object} map[string]string "Bad request - invalid id or alphabet type"
+// @Failure 404 {object} map[string]string "Not found - character has no stroke data"
+// @Failure 500 {object} map[string]string "Internal server error - failed to retrieve strokes"
+// @Router /characters/{id}/strokes [get]
+func (h *CharactersHandler) GetStrokes(w http.ResponseWriter, r *http.Request) {
+	typeParam := r.URL.Query().Get("type")
+	if typeParam == "" {
+		typeParam = "hr"
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse id parameter", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid id parameter")
+		return
+	}
+
+	strokes, err := h.service.GetStrokes(r.Context(), id, typeParam)
+	if err != nil {
+		h.Logger.Error("failed to get character strokes", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		switch {
+		case err.Error() == "character strokes not found":
+			errStatus = http.StatusNotFound
+		case err.Error() == "invalid character id", strings.HasPrefix(err.Error(), "invalid alphabet type"):
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, strokes)
+}
+
+// CheckStrokes handles POST /tests/{type}/strokes/{id}
+// @Summary Check drawn strokes
+// @Description Check strokes of a hiragana or katakana character drawn by the user. Each stroke is an ordered list of points in the 109x109 KanjiVG coordinate space. Stroke count, order and direction are checked against the reference and feedback is returned per stroke. Requires authentication.
+// @Tags tests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param type path string true "Alphabet type: hiragana or katakana"
+// @Param id path int true "Character ID"
+// @Param strokes body models.StrokeExerciseRequest true "Drawn strokes"
+// @Success 200 {object} models.StrokeExerciseResult "Per-stroke feedback"
+// @Failure 400 {object} map[string]string "Bad request - invalid request body, alphabet type, character ID or strokes"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Not found - character has no stroke data"
+// @Failure 500 {object} map[string]string "Internal server error - failed to check strokes"
+// @Router /tests/{type}/strokes/{id} [post]
+func (h *CharactersHandler) CheckStrokes(w http.ResponseWriter, r *http.Request) {
+	typeParam := chi.URLParam(r, "type")
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse id parameter", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid id parameter")
+		return
+	}
+
+	var req models.StrokeExerciseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.service.CheckStrokes(r.Context(), typeParam, id, req.Strokes)
+	if err != nil {
+		h.Logger.Error("failed to check strokes", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		switch err.Error() {
+		case "character strokes not found":
+			errStatus = http.StatusNotFound
+		case "invalid character id",
+			"strokes cannot be empty",
+			"too many strokes",
+			"stroke must contain at least one point",
+			"too many points in a stroke":
+			errStatus = http.StatusBadRequest
+		default:
+			if strings.HasPrefix(err.Error(), "invalid alphabet type") {
+				errStatus = http.StatusBadRequest
+			}
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, result)
 }
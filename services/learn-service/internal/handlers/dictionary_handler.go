@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -22,30 +23,101 @@ type DictionaryService interface {
 	// "newCount" parameter is used to specify the number of new words to return.
 	// "oldCount" parameter is used to specify the number of old words to return.
 	// "locale" parameter is used to specify the locale of the words.
-	// Please reference Locale constants for correct parameter values.
+	// Locale must be a language code such as "en" or "pt-br".
+	// "jlptLevels" parameter is a comma-separated list of JLPT levels (1-5) of new words, all levels are used if it is empty.
+	// "tags" parameter is a comma-separated list of topic tags of new words, all words are used if it is empty.
+	// "furigana" parameter is the furigana mode of the user: "always", "above level" or "never", "always" is used if it is empty.
+	// "furiganaLevel" parameter is the JLPT level (1-5) of the user for "above level" mode, 5 is used if it is 0.
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetWordList(ctx context.Context, userId, newCount, oldCount int, locale string) ([]models.WordResponse, error)
-	// SubmitWordResults validates and upserts word learning results
+	GetWordList(ctx context.Context, userId, newCount, oldCount int, locale, jlptLevels, tags, furigana string, furiganaLevel int) ([]models.WordResponse, error)
+	// SubmitWordResults validates word review grades and schedules the next appearance of the words
 	//
 	// "userId" parameter is used to identify the user.
-	// "results" parameter is used to submit the word learning results.
+	// "results" parameter is used to submit review grades of the words.
+	// Please reference ReviewGrade constants for correct grade values.
 	//
 	// If wrong parameters will be used or some error will occur during data submission, the error will be returned together with "nil" value.
 	SubmitWordResults(ctx context.Context, userId int, results []models.WordResult) error
+	// GetReviewForecast retrieves the dictionary review workload of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "days" parameter is used to specify the number of days after today to forecast.
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetReviewForecast(ctx context.Context, userId int, days int) (*models.ReviewForecast, error)
+	// SearchWords finds dictionary words by kanji, reading or translation, best matches first
+	//
+	// "query" parameter is the searched text, its reading may be written in hiragana, katakana or any romanization.
+	// "locale" parameter is used to specify the locale of the searched and returned translations.
+	// "limit" parameter is used to specify the maximum number of words to return, 0 means the default.
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	SearchWords(ctx context.Context, query, locale string, limit int) ([]models.WordSearchResult, error)
+	// GetWordQuiz builds a vocabulary quiz of dictionary words for the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "mode" parameter is used to specify the kind of quiz questions.
+	// Please reference WordQuizMode constants for correct parameter values.
+	// "count" parameter is used to specify the number of questions.
+	// "locale" parameter is used to specify the locale of the translations.
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetWordQuiz(ctx context.Context, userId int, mode string, count int, locale string) (*models.WordQuiz, error)
+	// SubmitWordQuiz grades answers of a quiz session and schedules the next appearance of its words
+	//
+	// "userId" parameter is used to identify the user.
+	// "sessionId" parameter is the ID of the quiz session returned by GetWordQuiz.
+	// "answers" parameter is used to submit the chosen options.
+	//
+	// If wrong parameters will be used or some error will occur during data submission, the error will be returned together with "nil" value.
+	SubmitWordQuiz(ctx context.Context, userId int, sessionId string, answers []models.WordQuizAnswer) ([]models.GradedWordAnswer, error)
+	// GetLeeches retrieves words of the user flagged as leeches, most lapses first
+	//
+	// "userId" parameter is used to identify the user.
+	// "locale" parameter is used to specify the locale of the translations.
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetLeeches(ctx context.Context, userId int, locale string) ([]models.Leech, error)
+	// UnsuspendLeech returns a leech of the user to regular reviews keeping its review state
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the word, it is a custom word ID if "isCustom" is true.
+	//
+	// If the word is not a leech of the user or some error will occur during data update, the error will be returned.
+	UnsuspendLeech(ctx context.Context, userId, wordId int, isCustom bool) error
+	// ResetLeech returns a leech of the user to regular reviews as a word learned from scratch
+	//
+	// Please reference UnsuspendLeech method for more information about parameters and error values.
+	ResetLeech(ctx context.Context, userId, wordId int, isCustom bool) error
+}
+
+// DictionaryExportService is the interface that wraps methods for dictionary export
+type DictionaryExportService interface {
+	// ExportDictionary builds a deck of all words reviewed by the user and returns the file content with its name
+	//
+	// "userId" parameter is used to identify the user.
+	// "format" parameter is used to specify the file format.
+	// Please reference DictionaryExportFormat constants for correct parameter values.
+	// "locale" parameter is used to specify the locale of the translations.
+	//
+	// If wrong parameters will be used or some error will occur during export, the error will be returned together with "nil" value.
+	ExportDictionary(ctx context.Context, userId int, format, locale string) ([]byte, string, error)
 }
 
 // DictionaryHandler handles dictionary-related HTTP requests
 type DictionaryHandler struct {
 	handlers.BaseHandler
-	service DictionaryService
+	service       DictionaryService
+	exportService DictionaryExportService
 }
 
 // NewDictionaryHandler creates a new dictionary handler
-func NewDictionaryHandler(service DictionaryService, logger *zap.Logger) *DictionaryHandler {
+func NewDictionaryHandler(service DictionaryService, exportService DictionaryExportService, logger *zap.Logger) *DictionaryHandler {
 	return &DictionaryHandler{
-		BaseHandler: handlers.BaseHandler{Logger: logger},
-		service:     service,
+		BaseHandler:   handlers.BaseHandler{Logger: logger},
+		service:       service,
+		exportService: exportService,
 	}
 }
 
@@ -55,6 +127,14 @@ func (h *DictionaryHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 		r.Use(authMiddleware)
 		r.Get("/", h.GetWordList)
 		r.Post("/results", h.SubmitWordResults)
+		r.Get("/forecast", h.GetReviewForecast)
+		r.Get("/search", h.SearchWords)
+		r.Get("/quiz", h.GetWordQuiz)
+		r.Post("/quiz/{sessionId}", h.SubmitWordQuiz)
+		r.Get("/export", h.ExportDictionary)
+		r.Get("/leeches", h.GetLeeches)
+		r.Post("/leeches/{wordId}/unsuspend", h.UnsuspendLeech)
+		r.Post("/leeches/{wordId}/reset", h.ResetLeech)
 	})
 }
 
@@ -67,7 +147,11 @@ func (h *DictionaryHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 // @Security ApiKeyAuth
 // @Param newCount query int false "Number of new words (10-40), default: 20"
 // @Param oldCount query int false "Number of old words (10-40), default: 20"
-// @Param locale query string false "Locale: en, ru, or de, default: en"
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Param jlptLevels query string false "Comma-separated JLPT levels (1-5) of new words, default: all levels"
+// @Param tags query string false "Comma-separated topic tags of new words, default: all words"
+// @Param furigana query string false "Furigana of examples: always, above level or never, default: always"
+// @Param furiganaLevel query int false "JLPT level (1-5) of the user, furigana are shown above harder kanji in above level mode, default: 5"
 // @Success 200 {array} models.WordResponse "List of words"
 // @Failure 400 {object} map[string]string "Bad request - invalid parameters"
 // @Failure 401 {object} map[string]string "Unauthorized - authentication required"
@@ -116,15 +200,27 @@ func (h *DictionaryHandler) GetWordList(w http.ResponseWriter, r *http.Request)
 		locale = "en"
 	}
 
+	furiganaLevel, err := parseFuriganaLevel(r)
+	if err != nil {
+		h.Logger.Error("failed to parse furiganaLevel parameter", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid furiganaLevel parameter")
+		return
+	}
+
 	// Get word list
-	words, err := h.service.GetWordList(r.Context(), userID, newCount, oldCount, locale)
+	words, err := h.service.GetWordList(r.Context(), userID, newCount, oldCount, locale, r.URL.Query().Get("jlptLevels"), r.URL.Query().Get("tags"),
+		r.URL.Query().Get("furigana"), furiganaLevel)
 	if err != nil {
 		h.Logger.Error("failed to get word list", zap.Error(err))
 		statusCode := http.StatusInternalServerError
 		// Check if it's a validation error
 		if err.Error() == "newWordCount must be between 10 and 40" ||
 			err.Error() == "oldWordCount must be between 10 and 40" ||
-			err.Error() == "invalid locale: "+locale+", must be 'en', 'ru', or 'de'" {
+			strings.HasPrefix(err.Error(), "invalid locale") ||
+			strings.HasPrefix(err.Error(), "invalid jlpt level") ||
+			strings.HasPrefix(err.Error(), "invalid tag") ||
+			strings.HasPrefix(err.Error(), "invalid furigana mode") ||
+			err.Error() == "furiganaLevel must be between 1 and 5" {
 			statusCode = http.StatusBadRequest
 		}
 		h.RespondError(w, statusCode, err.Error())
@@ -134,6 +230,15 @@ func (h *DictionaryHandler) GetWordList(w http.ResponseWriter, r *http.Request)
 	h.RespondJSON(w, http.StatusOK, words)
 }
 
+// parseFuriganaLevel parses the optional "furiganaLevel" query parameter, 0 is returned if it is missing
+func parseFuriganaLevel(r *http.Request) (int, error) {
+	levelStr := r.URL.Query().Get("furiganaLevel")
+	if levelStr == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(levelStr)
+}
+
 // SubmitWordResultsRequest represents a word results submission request
 type SubmitWordResultsRequest struct {
 	Results []models.WordResult `json:"results"`
@@ -141,7 +246,7 @@ type SubmitWordResultsRequest struct {
 
 // SubmitWordResults handles POST /words/results
 // @Summary Submit word learning results
-// @Description Submit word learning results with period values. Requires authentication.
+// @Description Submit review grades of words (1 - again, 2 - hard, 3 - good, 4 - easy). The server schedules the next appearance of every word with the SM-2 algorithm. Requires authentication.
 // @Tags dictionary
 // @Accept json
 // @Produce json
@@ -184,7 +289,8 @@ func (h *DictionaryHandler) SubmitWordResults(w http.ResponseWriter, r *http.Req
 		errMsg := err.Error()
 		if errMsg == "results list cannot be empty" ||
 			errMsg == "one or more word IDs do not exist" ||
-			strings.Contains(errMsg, "period must be between") {
+			errMsg == "duplicate result for the same word" ||
+			strings.Contains(errMsg, "grade must be between") {
 			statusCode = http.StatusBadRequest
 		}
 		h.RespondError(w, statusCode, errMsg)
@@ -194,3 +300,423 @@ func (h *DictionaryHandler) SubmitWordResults(w http.ResponseWriter, r *http.Req
 	// Return 204 No Content
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// GetReviewForecast handles GET /words/forecast
+// @Summary Get review forecast
+// @Description Get the number of words due today (backlog), the number of words coming due on each of the next days and the number of words never reviewed. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Number of days to forecast (1-90), default: 7"
+// @Success 200 {object} models.ReviewForecast "Review forecast"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/forecast [get]
+func (h *DictionaryHandler) GetReviewForecast(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	// Parse and validate days
+	days := 7 // default
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil {
+			h.Logger.Error("failed to parse days parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid days parameter")
+			return
+		}
+		days = parsed
+	}
+
+	forecast, err := h.service.GetReviewForecast(r.Context(), userID, days)
+	if err != nil {
+		h.Logger.Error("failed to get review forecast", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		// Check if it's a validation error
+		if strings.HasPrefix(err.Error(), "days must be between") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, forecast)
+}
+
+// SearchWords handles GET /words/search
+// @Summary Search words
+// @Description Search dictionary words by kanji, by reading in hiragana, katakana or romaji (Hepburn, Kunrei-shiki or Nihon-shiki) and by translation in the locale. Exact matches come first, then prefix matches, then translations containing the query as whole words. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "Searched text, at most 100 characters"
+// @Param locale query string false "Locale of translations, a language code such as en or pt-br, missing translations fall back to other locales, default: the default locale"
+// @Param limit query int false "Maximum number of words (1-50), default: 20"
+// @Success 200 {array} models.WordSearchResult "Found words"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/search [get]
+func (h *DictionaryHandler) SearchWords(w http.ResponseWriter, r *http.Request) {
+	query, locale, limit, err := parseSearchQuery(r)
+	if err != nil {
+		h.Logger.Error("failed to parse search parameters", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	words, err := h.service.SearchWords(r.Context(), query, locale, limit)
+	if err != nil {
+		h.Logger.Error("failed to search words", zap.Error(err))
+		h.RespondError(w, searchErrorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, words)
+}
+
+// parseSearchQuery extracts word search parameters from the query string
+func parseSearchQuery(r *http.Request) (string, string, int, error) {
+	limit := 0 // default is set by the service
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return "", "", 0, fmt.Errorf("invalid limit parameter")
+		}
+		limit = parsed
+	}
+	return r.URL.Query().Get("q"), r.URL.Query().Get("locale"), limit, nil
+}
+
+// searchErrorStatus returns the HTTP status code of a word search error
+func searchErrorStatus(err error) int {
+	errMsg := err.Error()
+	if strings.HasPrefix(errMsg, "search query") ||
+		strings.HasPrefix(errMsg, "invalid limit") ||
+		strings.HasPrefix(errMsg, "invalid locale") {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// ExportDictionary handles GET /words/export
+// @Summary Export dictionary
+// @Description Download all words reviewed by the authenticated user as an Anki deck. Anki packages (apkg) contain word and example audio and keep the review state of every word, TSV files contain only the text. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce application/octet-stream
+// @Security ApiKeyAuth
+// @Param format query string false "File format: apkg or tsv, default: apkg"
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Success 200 {file} file "Deck file"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/export [get]
+func (h *DictionaryHandler) ExportDictionary(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	// Default format and locale
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = string(models.DictionaryExportFormatAPKG)
+	}
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	data, filename, err := h.exportService.ExportDictionary(r.Context(), userID, format, locale)
+	if err != nil {
+		h.Logger.Error("failed to export dictionary", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		// Check if it's a validation error
+		if strings.HasPrefix(err.Error(), "invalid format") || strings.HasPrefix(err.Error(), "invalid locale") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	contentType := "application/octet-stream"
+	if format == string(models.DictionaryExportFormatTSV) {
+		contentType = "text/tab-separated-values; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		h.Logger.Error("failed to write export file", zap.Error(err))
+	}
+}
+
+// GetWordQuiz handles GET /words/quiz
+// @Summary Get vocabulary quiz
+// @Description Get a vocabulary quiz for the authenticated user. Words due for review are asked first. In "reverse" mode the translation is shown and the word is chosen, in "choice" mode the word is shown and the translation is chosen, in "listening" mode the word audio is played and the word is chosen. Correct answers are kept on the server until the quiz is submitted. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param mode query string true "Quiz mode: reverse, choice or listening"
+// @Param count query int false "Number of questions (1-40), default: 20"
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Success 200 {object} models.WordQuiz "Quiz session with questions"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters or no words available"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/quiz [get]
+func (h *DictionaryHandler) GetWordQuiz(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	// Parse and validate count
+	count := 20 // default
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		parsed, err := strconv.Atoi(countStr)
+		if err != nil {
+			h.Logger.Error("failed to parse count parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid count parameter")
+			return
+		}
+		count = parsed
+	}
+
+	// Default locale
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	quiz, err := h.service.GetWordQuiz(r.Context(), userID, r.URL.Query().Get("mode"), count, locale)
+	if err != nil {
+		h.Logger.Error("failed to get word quiz", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		// Check if it's a validation error
+		errMsg := err.Error()
+		if strings.HasPrefix(errMsg, "invalid quiz mode") ||
+			strings.HasPrefix(errMsg, "count must be between") ||
+			strings.HasPrefix(errMsg, "invalid locale") ||
+			errMsg == "no words available for the quiz" {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, errMsg)
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, quiz)
+}
+
+// SubmitWordQuizRequest represents a vocabulary quiz submission request
+type SubmitWordQuizRequest struct {
+	Answers []models.WordQuizAnswer `json:"answers"`
+}
+
+// SubmitWordQuiz handles POST /words/quiz/{sessionId}
+// @Summary Submit vocabulary quiz
+// @Description Submit answers of a vocabulary quiz. Every answer is graded against the option kept on the server, unanswered questions are failed. Correct answers are reviewed as "good" and wrong ones as "again", so the words are scheduled like self-graded reviews. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param sessionId path string true "Quiz session ID"
+// @Param answers body SubmitWordQuizRequest true "Quiz answers"
+// @Success 200 {array} models.GradedWordAnswer "Graded answers"
+// @Failure 400 {object} map[string]string "Bad request - invalid request body, empty answers array, or answers not matching the session"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Quiz session not found"
+// @Failure 409 {object} map[string]string "Quiz session has already been submitted"
+// @Failure 410 {object} map[string]string "Quiz session has expired"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/quiz/{sessionId} [post]
+func (h *DictionaryHandler) SubmitWordQuiz(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	sessionID := chi.URLParam(r, "sessionId")
+
+	// Parse request body
+	var req SubmitWordQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if len(req.Answers) == 0 {
+		h.Logger.Error("answers array cannot be empty")
+		h.RespondError(w, http.StatusBadRequest, "answers array cannot be empty")
+		return
+	}
+
+	// Grade and submit quiz answers
+	results, err := h.service.SubmitWordQuiz(r.Context(), userID, sessionID, req.Answers)
+	if err != nil {
+		h.Logger.Error("failed to submit word quiz", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		switch err.Error() {
+		case "session id is required",
+			"answer does not belong to the word quiz session",
+			"duplicate answer for the same word",
+			"answer is too long":
+			statusCode = http.StatusBadRequest
+		case "word quiz session not found":
+			statusCode = http.StatusNotFound
+		case "word quiz session has already been submitted":
+			statusCode = http.StatusConflict
+		case "word quiz session has expired":
+			statusCode = http.StatusGone
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, results)
+}
+
+// GetLeeches handles GET /words/leeches
+// @Summary Get leeches
+// @Description Get words of the authenticated user flagged as leeches after being forgotten too many times, most lapses first. Leeches are not offered in regular reviews: suspended leeches are not reviewed at all, other leeches are reviewed from this list. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Success 200 {array} models.Leech "List of leeches"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/leeches [get]
+func (h *DictionaryHandler) GetLeeches(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	// Default locale
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	leeches, err := h.service.GetLeeches(r.Context(), userID, locale)
+	if err != nil {
+		h.Logger.Error("failed to get leeches", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid locale") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, leeches)
+}
+
+// UnsuspendLeech handles POST /words/leeches/{wordId}/unsuspend
+// @Summary Unsuspend leech
+// @Description Return a leech of the authenticated user to regular reviews keeping its review state. The word is flagged again if it keeps being forgotten. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param wordId path int true "Word ID, a custom word ID if isCustom is true"
+// @Param isCustom query bool false "The word is a custom word of the user, default: false"
+// @Success 204 "Leech unsuspended"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Leech not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/leeches/{wordId}/unsuspend [post]
+func (h *DictionaryHandler) UnsuspendLeech(w http.ResponseWriter, r *http.Request) {
+	h.updateLeech(w, r, h.service.UnsuspendLeech)
+}
+
+// ResetLeech handles POST /words/leeches/{wordId}/reset
+// @Summary Reset leech
+// @Description Return a leech of the authenticated user to regular reviews as a word learned from scratch: its lapses and review schedule are reset and it comes due today. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param wordId path int true "Word ID, a custom word ID if isCustom is true"
+// @Param isCustom query bool false "The word is a custom word of the user, default: false"
+// @Success 204 "Leech reset"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Leech not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /words/leeches/{wordId}/reset [post]
+func (h *DictionaryHandler) ResetLeech(w http.ResponseWriter, r *http.Request) {
+	h.updateLeech(w, r, h.service.ResetLeech)
+}
+
+// updateLeech parses parameters of a leech update, calls the update and writes its response
+func (h *DictionaryHandler) updateLeech(w http.ResponseWriter, r *http.Request, update func(ctx context.Context, userId, wordId int, isCustom bool) error) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	wordID, err := strconv.Atoi(chi.URLParam(r, "wordId"))
+	if err != nil {
+		h.Logger.Error("failed to parse wordId parameter", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word id")
+		return
+	}
+
+	isCustom := false
+	if isCustomStr := r.URL.Query().Get("isCustom"); isCustomStr != "" {
+		isCustom, err = strconv.ParseBool(isCustomStr)
+		if err != nil {
+			h.Logger.Error("failed to parse isCustom parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid isCustom parameter")
+			return
+		}
+	}
+
+	if err := update(r.Context(), userID, wordID, isCustom); err != nil {
+		h.Logger.Error("failed to update leech", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		switch err.Error() {
+		case "invalid word id":
+			statusCode = http.StatusBadRequest
+		case "leech not found":
+			statusCode = http.StatusNotFound
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/handlers"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// KanjiService is the interface that wraps methods for kanji business logic
+type KanjiService interface {
+	// GetKanjiList retrieves a paginated list of kanji filtered by JLPT level
+	//
+	// "level" parameter is used to filter kanji by JLPT level (1-5), 0 means all levels.
+	// "page" parameter is used to specify the page number.
+	// "count" parameter is used to specify the number of items per page.
+	// "locale" parameter is used to specify the locale of the meanings.
+	// Locale must be a language code such as "en" or "pt-br".
+	//
+	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetKanjiList(ctx context.Context, level, page, count int, locale string) ([]models.KanjiResponse, error)
+	// GetKanjiWords retrieves words from the dictionary containing the kanji
+	//
+	// "id" parameter is used to identify the kanji.
+	// "locale" parameter is used to specify the locale of the translations.
+	//
+	// Please reference GetKanjiList method for more information about error values.
+	GetKanjiWords(ctx context.Context, id int, locale string) ([]models.WordResponse, error)
+}
+
+// KanjiHandler handles kanji-related HTTP requests
+type KanjiHandler struct {
+	handlers.BaseHandler
+	service KanjiService
+}
+
+// NewKanjiHandler creates a new kanji handler
+func NewKanjiHandler(service KanjiService, logger *zap.Logger) *KanjiHandler {
+	return &KanjiHandler{
+		BaseHandler: handlers.BaseHandler{Logger: logger},
+		service:     service,
+	}
+}
+
+// RegisterRoutes registers all kanji handler routes
+func (h *KanjiHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/kanji", func(r chi.Router) {
+		r.Get("/", h.GetKanjiList)
+		r.Get("/{id}/words", h.GetKanjiWords)
+	})
+}
+
+// GetKanjiList handles GET /kanji
+// @Summary Get kanji list
+// @Description Get a paginated list of kanji, optionally filtered by JLPT level
+// @Tags kanji
+// @Accept json
+// @Produce json
+// @Param level query int false "JLPT level (1-5), all levels if omitted"
+// @Param page query int false "Page number (default: 1)"
+// @Param count query int false "Items per page (1-100), default: 50"
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Success 200 {array} models.KanjiResponse "List of kanji"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /kanji [get]
+func (h *KanjiHandler) GetKanjiList(w http.ResponseWriter, r *http.Request) {
+	level := 0
+	if levelStr := r.URL.Query().Get("level"); levelStr != "" {
+		parsed, err := strconv.Atoi(levelStr)
+		if err != nil {
+			h.Logger.Error("failed to parse level parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid level parameter")
+			return
+		}
+		level = parsed
+	}
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	count := 50
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil && c > 0 {
+			count = c
+		}
+	}
+
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	kanji, err := h.service.GetKanjiList(r.Context(), level, page, count, locale)
+	if err != nil {
+		h.Logger.Error("failed to get kanji list", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, kanji)
+}
+
+// GetKanjiWords handles GET /kanji/{id}/words
+// @Summary Get words containing a kanji
+// @Description Get dictionary words which contain the kanji
+// @Tags kanji
+// @Accept json
+// @Produce json
+// @Param id path int true "Kanji ID"
+// @Param locale query string false "Locale, a language code such as en or pt-br, missing translations fall back to other locales, default: en"
+// @Success 200 {array} models.WordResponse "List of words"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 404 {object} map[string]string "Kanji not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /kanji/{id}/words [get]
+func (h *KanjiHandler) GetKanjiWords(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse kanji ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid kanji ID")
+		return
+	}
+
+	locale := r.URL.Query().Get("locale")
+	if locale == "" {
+		locale = "en"
+	}
+
+	words, err := h.service.GetKanjiWords(r.Context(), id, locale)
+	if err != nil {
+		h.Logger.Error("failed to get kanji words", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "kanji not found" {
+			statusCode = http.StatusNotFound
+		} else if strings.HasPrefix(err.Error(), "invalid") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, words)
+}
@@ -15,16 +15,15 @@ import (
 
 // TestResultService defines methods for test result business logic
 type TestResultService interface {
-	// SubmitTestResults updates or creates a list of learn history records for chosen characters.
+	// SubmitTestSession grades raw answers against a stored test session and updates learn history records.
 	//
 	// "userID" parameter is used to identify the user.
-	// "alphabetType" parameter is used to identify the alphabet type.
-	// "testType" parameter is used to identify the test type.
-	// "results" parameter is used to update or create a list of learn history records.
+	// "sessionID" parameter is used to identify the test session issued by one of the test endpoints.
+	// "answers" parameter contains raw answers, items without an answer are graded as failed.
 	// "repeat" parameter indicates if user wants to repeat alphabet ("in question" by default).
-	// Returns result with askForRepeat flag and error.
-	// If some error occurs during data update or creation, the error will be returned.
-	SubmitTestResults(ctx context.Context, userID int, alphabetType, testType string, results []models.TestResultItem, repeat string) (*models.SubmitTestResultsResult, error)
+	// Returns graded answers with askForRepeat flag and error.
+	// If the session is missing, expired or already submitted, or some error occurs during data update, the error will be returned.
+	SubmitTestSession(ctx context.Context, userID int, sessionID string, answers []models.TestSessionAnswer, repeat string) (*models.SubmitTestSessionResult, error)
 	// GetUserHistory retrieves all learn history records for a user.
 	//
 	// "userID" parameter is used to identify the user.
@@ -36,6 +35,19 @@ type TestResultService interface {
 	// "userID" parameter is used to identify the user.
 	// If some error occurs during the update, the error will be returned.
 	DropUserMarks(ctx context.Context, userID int) error
+	// GetAttemptTimeline retrieves a paginated list of user's test attempts, newest first.
+	//
+	// "userID" parameter is used to identify the user.
+	// "characterID" parameter is used to filter attempts by character (0 means all characters).
+	// "page" and "count" parameters are used for pagination.
+	// If some error occurs during data retrieval, the error will be returned.
+	GetAttemptTimeline(ctx context.Context, userID, characterID, page, count int) ([]models.CharacterTestAttempt, error)
+	// GetAccuracyOverTime retrieves per-day accuracy of a user for each practised character.
+	//
+	// "days" parameter is used to limit the period (1-365 days including the current one).
+	//
+	// Please reference GetAttemptTimeline method for more information about other parameters and error values.
+	GetAccuracyOverTime(ctx context.Context, userID, characterID, days int) ([]models.CharacterAccuracyPoint, error)
 }
 
 // TestResultHandler handles test result submission
@@ -52,10 +64,10 @@ func NewTestResultHandler(service TestResultService, logger *zap.Logger) *TestRe
 	}
 }
 
-// TestResultRequest represents a test result submission request
-type TestResultRequest struct {
-	Results []models.TestResultItem `json:"results"`
-	Repeat  string                  `json:"repeat,omitempty"`
+// TestSessionSubmitRequest represents a test session submission request
+type TestSessionSubmitRequest struct {
+	Answers []models.TestSessionAnswer `json:"answers"`
+	Repeat  string                     `json:"repeat,omitempty"`
 }
 
 // RegisterRoutes registers all test result handler routes
@@ -64,8 +76,10 @@ func (h *TestResultHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 		// Apply auth middleware to user-facing routes
 		r.Group(func(r chi.Router) {
 			r.Use(authMiddleware)
-			r.Post("/{type}/{testType}", h.SubmitTestResult)
+			r.Post("/sessions/{sessionId}", h.SubmitTestSession)
 			r.Get("/history", h.GetUserHistory)
+			r.Get("/attempts", h.GetAttemptTimeline)
+			r.Get("/attempts/accuracy", h.GetAccuracyOverTime)
 		})
 		// Apply API key middleware to service-to-service routes
 		r.Group(func(r chi.Router) {
@@ -75,22 +89,24 @@ func (h *TestResultHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 	})
 }
 
-// SubmitTestResult handles POST /tests/{type}/{testType}
-// @Summary Submit test results
-// @Description Submit test results for hiragana or katakana reading, writing, or listening tests. Requires authentication.
+// SubmitTestSession handles POST /test-results/sessions/{sessionId}
+// @Summary Submit test session answers
+// @Description Submit raw answers for a hiragana or katakana reading, writing, or listening test session. Answers are graded on the server, each session can be submitted only once before it expires. Requires authentication.
 // @Tags tests
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param type path string true "Alphabet type: hiragana or katakana"
-// @Param testType path string true "Test type: reading, writing, or listening"
-// @Param results body TestResultRequest true "Test results"
-// @Success 200 {object} map[string]string "Test results submitted successfully"
-// @Failure 400 {object} map[string]string "Bad request - invalid request body, empty results array, or invalid alphabet/test type"
+// @Param sessionId path string true "Test session ID"
+// @Param answers body TestSessionSubmitRequest true "Test session answers"
+// @Success 200 {object} map[string]interface{} "Test results submitted successfully with graded answers"
+// @Failure 400 {object} map[string]string "Bad request - invalid request body, empty answers array, or answers not matching the session"
 // @Failure 401 {object} map[string]string "Unauthorized - authentication required, invalid/expired token, or user ID not found in context"
+// @Failure 404 {object} map[string]string "Test session not found"
+// @Failure 409 {object} map[string]string "Test session has already been submitted"
+// @Failure 410 {object} map[string]string "Test session has expired"
 // @Failure 500 {object} map[string]string "Internal server error - failed to process or save test results"
-// @Router /test-results/{type}/{testType} [post]
-func (h *TestResultHandler) SubmitTestResult(w http.ResponseWriter, r *http.Request) {
+// @Router /test-results/sessions/{sessionId} [post]
+func (h *TestResultHandler) SubmitTestSession(w http.ResponseWriter, r *http.Request) {
 	// Extract userID from auth middleware context
 	userID, ok := middleware.GetUserID(r.Context())
 	if !ok {
@@ -99,34 +115,40 @@ func (h *TestResultHandler) SubmitTestResult(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Get path parameters
-	alphabetType := chi.URLParam(r, "type") // "hiragana" or "katakana"
-	testType := chi.URLParam(r, "testType") // "reading", "writing", or "listening"
+	sessionID := chi.URLParam(r, "sessionId")
 
 	// Parse request body
-	var req TestResultRequest
+	var req TestSessionSubmitRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.Logger.Error("failed to decode request body", zap.Error(err))
 		h.RespondError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	if len(req.Results) == 0 {
-		h.Logger.Error("results array cannot be empty")
-		h.RespondError(w, http.StatusBadRequest, "results array cannot be empty")
+	if len(req.Answers) == 0 {
+		h.Logger.Error("answers array cannot be empty")
+		h.RespondError(w, http.StatusBadRequest, "answers array cannot be empty")
 		return
 	}
 
-	// Submit test results
-	result, err := h.service.SubmitTestResults(r.Context(), userID, alphabetType, testType, req.Results, req.Repeat)
+	// Grade and submit test session answers
+	result, err := h.service.SubmitTestSession(r.Context(), userID, sessionID, req.Answers, req.Repeat)
 	if err != nil {
-		h.Logger.Error("failed to submit test results", zap.Error(err))
+		h.Logger.Error("failed to submit test session", zap.Error(err))
 		statusCode := http.StatusInternalServerError
-		// Check if it's a validation error (should return 400)
-		if err.Error() == "invalid alphabet type, must be 'hiragana' or 'katakana'" ||
-			err.Error() == "invalid test type, must be 'reading', 'writing', or 'listening'" ||
-			err.Error() == "invalid alphabet type or test type" {
+		switch err.Error() {
+		case "session id is required",
+			"answer does not belong to the test session",
+			"duplicate answer for the same character",
+			"answer is too long",
+			"response time cannot be negative":
 			statusCode = http.StatusBadRequest
+		case "test session not found":
+			statusCode = http.StatusNotFound
+		case "test session has already been submitted":
+			statusCode = http.StatusConflict
+		case "test session has expired":
+			statusCode = http.StatusGone
 		}
 		h.RespondError(w, statusCode, err.Error())
 		return
@@ -135,6 +157,7 @@ func (h *TestResultHandler) SubmitTestResult(w http.ResponseWriter, r *http.Requ
 	h.RespondJSON(w, http.StatusOK, map[string]any{
 		"message":      "test results submitted successfully",
 		"askForRepeat": result.AskForRepeat,
+		"results":      result.Results,
 	})
 }
 
@@ -169,6 +192,129 @@ func (h *TestResultHandler) GetUserHistory(w http.ResponseWriter, r *http.Reques
 	h.RespondJSON(w, http.StatusOK, histories)
 }
 
+// GetAttemptTimeline handles GET /test-results/attempts
+// @Summary Get user's test attempt timeline
+// @Description Get a paginated list of all answers given by the authenticated user in kana tests, newest first. Requires authentication.
+// @Tags tests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param characterId query int false "Filter by character ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param count query int false "Items per page (default: 50, max: 100)"
+// @Success 200 {array} models.CharacterTestAttempt "List of test attempts (empty array if no records found)"
+// @Failure 400 {object} map[string]string "Bad request - invalid character ID"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required, invalid/expired token, or user ID not found in context"
+// @Failure 500 {object} map[string]string "Internal server error - failed to retrieve test attempts"
+// @Router /test-results/attempts [get]
+func (h *TestResultHandler) GetAttemptTimeline(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	characterID, ok := h.parseCharacterID(w, r)
+	if !ok {
+		return
+	}
+
+	page := 1
+	count := 50
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+	if countStr := r.URL.Query().Get("count"); countStr != "" {
+		if c, err := strconv.Atoi(countStr); err == nil && c > 0 {
+			count = c
+		}
+	}
+
+	attempts, err := h.service.GetAttemptTimeline(r.Context(), userID, characterID, page, count)
+	if err != nil {
+		h.Logger.Error("failed to get test attempts", zap.Error(err))
+		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, attempts)
+}
+
+// GetAccuracyOverTime handles GET /test-results/attempts/accuracy
+// @Summary Get user's accuracy over time
+// @Description Get per-day accuracy and average answer time of the authenticated user for each practised character. Requires authentication.
+// @Tags tests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param characterId query int false "Filter by character ID"
+// @Param days query int false "Number of days including today (1-365, default: 30)"
+// @Success 200 {array} models.CharacterAccuracyPoint "Accuracy per character and day (empty array if no records found)"
+// @Failure 400 {object} map[string]string "Bad request - invalid character ID or days parameter"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required, invalid/expired token, or user ID not found in context"
+// @Failure 500 {object} map[string]string "Internal server error - failed to retrieve accuracy"
+// @Router /test-results/attempts/accuracy [get]
+func (h *TestResultHandler) GetAccuracyOverTime(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from auth middleware context
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	characterID, ok := h.parseCharacterID(w, r)
+	if !ok {
+		return
+	}
+
+	days := 30 // default
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil {
+			h.Logger.Error("failed to parse days parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid days parameter")
+			return
+		}
+		days = parsed
+	}
+
+	points, err := h.service.GetAccuracyOverTime(r.Context(), userID, characterID, days)
+	if err != nil {
+		h.Logger.Error("failed to get accuracy over time", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if err.Error() == "days must be between 1 and 365" {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, points)
+}
+
+// parseCharacterID parses optional "characterId" query parameter
+//
+// Returns 0 if the parameter is not provided.
+// If the parameter is invalid, the error response is written and false is returned.
+func (h *TestResultHandler) parseCharacterID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	characterIDStr := r.URL.Query().Get("characterId")
+	if characterIDStr == "" {
+		return 0, true
+	}
+	characterID, err := strconv.Atoi(characterIDStr)
+	if err != nil || characterID <= 0 {
+		h.Logger.Error("failed to parse characterId parameter", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid characterId parameter")
+		return 0, false
+	}
+	return characterID, true
+}
+
 // DropUserMarks handles GET /test-results/drop-marks/{userId}
 // @Summary Drop user marks
 // @Description Lowers all CharacterLearnHistory results by 0.01 for a user. Requires API key authentication.
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/handlers"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// TransliterationService is the interface that wraps methods for text transliteration
+type TransliterationService interface {
+	// Transliterate converts text between kana and romanization scripts
+	//
+	// "text" parameter is the text to convert, characters which cannot be converted are kept as is.
+	// "from" parameter is used to specify the script of the text.
+	// "to" parameter is used to specify the script to convert the text to.
+	// Supported scripts are "hiragana", "katakana", "hepburn", "kunrei", "nihon-shiki" and "polivanov".
+	//
+	// The response contains the converted text and its segments aligned with the parts of the text they were produced from.
+	// If wrong parameters will be used, the error will be returned together with "nil" value.
+	Transliterate(ctx context.Context, text, from, to string) (*models.TransliterationResponse, error)
+}
+
+// TransliterationHandler handles transliteration HTTP requests
+type TransliterationHandler struct {
+	handlers.BaseHandler
+	service TransliterationService
+}
+
+// NewTransliterationHandler creates a new transliteration handler
+func NewTransliterationHandler(service TransliterationService, logger *zap.Logger) *TransliterationHandler {
+	return &TransliterationHandler{
+		BaseHandler: handlers.BaseHandler{Logger: logger},
+		service:     service,
+	}
+}
+
+// RegisterRoutes registers all transliteration handler routes
+func (h *TransliterationHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/transliterate", func(r chi.Router) {
+		r.Get("/", h.TransliterateQuery)
+		r.Post("/", h.Transliterate)
+	})
+}
+
+// TransliterateRequest represents the request body for text transliteration
+type TransliterateRequest struct {
+	Text string `json:"text"`
+}
+
+// TransliterateQuery handles GET /transliterate
+// @Summary Transliterate short text
+// @Description Convert text between hiragana, katakana, Hepburn, Kunrei-shiki and Nihon-shiki romaji and Polivanov Cyrillic. The response contains segments aligned with the source text.
+// @Tags transliteration
+// @Accept json
+// @Produce json
+// @Param text query string true "Text to convert"
+// @Param from query string true "Source script: hiragana, katakana, hepburn, kunrei, nihon-shiki or polivanov"
+// @Param to query string true "Target script: hiragana, katakana, hepburn, kunrei, nihon-shiki or polivanov"
+// @Success 200 {object} models.TransliterationResponse "Converted text with aligned segments"
+// @Failure 400 {object} map[string]string "Bad request - invalid parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /transliterate [get]
+func (h *TransliterationHandler) TransliterateQuery(w http.ResponseWriter, r *http.Request) {
+	h.transliterate(w, r, r.URL.Query().Get("text"))
+}
+
+// Transliterate handles POST /transliterate
+// @Summary Transliterate text
+// @Description Convert text from the request body between hiragana, katakana, Hepburn, Kunrei-shiki and Nihon-shiki romaji and Polivanov Cyrillic. Suitable for texts too long for a query string.
+// @Tags transliteration
+// @Accept json
+// @Produce json
+// @Param from query string true "Source script: hiragana, katakana, hepburn, kunrei, nihon-shiki or polivanov"
+// @Param to query string true "Target script: hiragana, katakana, hepburn, kunrei, nihon-shiki or polivanov"
+// @Param request body TransliterateRequest true "Text to convert"
+// @Success 200 {object} models.TransliterationResponse "Converted text with aligned segments"
+// @Failure 400 {object} map[string]string "Bad request - invalid request body or parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /transliterate [post]
+func (h *TransliterationHandler) Transliterate(w http.ResponseWriter, r *http.Request) {
+	var req TransliterateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	h.transliterate(w, r, req.Text)
+}
+
+// transliterate converts the text with scripts from the query string and writes the response
+func (h *TransliterationHandler) transliterate(w http.ResponseWriter, r *http.Request, text string) {
+	query := r.URL.Query()
+	result, err := h.service.Transliterate(r.Context(), text, query.Get("from"), query.Get("to"))
+	if err != nil {
+		h.Logger.Error("failed to transliterate text", zap.Error(err))
+		statusCode := http.StatusInternalServerError
+		if strings.HasPrefix(err.Error(), "invalid") {
+			statusCode = http.StatusBadRequest
+		}
+		h.RespondError(w, statusCode, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/auth/middleware"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/handlers"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// UserDictionaryService is the interface that wraps methods for custom words and decks of users
+type UserDictionaryService interface {
+	// GetDecks retrieves all custom decks of the user
+	//
+	// "userId" parameter is used to identify the user.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetDecks(ctx context.Context, userId int) ([]models.UserDeck, error)
+	// CreateDeck creates a new custom deck of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "req" parameter is used to create a new deck.
+	//
+	// If wrong parameters will be used or some error will occur during data creation, the error will be returned together with 0 as deck ID.
+	CreateDeck(ctx context.Context, userId int, req *models.CreateUserDeckRequest) (int, error)
+	// UpdateDeck renames a custom deck of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to identify the deck.
+	// "req" parameter is used to rename the deck.
+	//
+	// If wrong parameters will be used or some error will occur during data update, the error will be returned.
+	UpdateDeck(ctx context.Context, userId, deckId int, req *models.UpdateUserDeckRequest) error
+	// DeleteDeck deletes a custom deck of the user, words of the deck stay in the personal dictionary
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to identify the deck.
+	//
+	// If some error will occur during data deletion, the error will be returned.
+	DeleteDeck(ctx context.Context, userId, deckId int) error
+	// GetWords retrieves custom words of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to filter words by their deck, nil returns all words.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetWords(ctx context.Context, userId int, deckId *int) ([]models.UserWord, error)
+	// CreateWord saves a custom word to the personal dictionary of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "req" parameter is used to create a new custom word.
+	//
+	// If wrong parameters will be used or some error will occur during data creation, the error will be returned together with 0 as word ID.
+	CreateWord(ctx context.Context, userId int, req *models.CreateUserWordRequest) (int, error)
+	// UpdateWord updates a custom word of the user (partial update)
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the custom word.
+	// "req" parameter is used to update the custom word.
+	//
+	// If wrong parameters will be used or some error will occur during data update, the error will be returned.
+	UpdateWord(ctx context.Context, userId, wordId int, req *models.UpdateUserWordRequest) error
+	// DeleteWord deletes a custom word of the user together with its review history
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the custom word.
+	//
+	// If some error will occur during data deletion, the error will be returned.
+	DeleteWord(ctx context.Context, userId, wordId int) error
+}
+
+// UserDictionaryHandler handles HTTP requests for custom words and decks of users
+type UserDictionaryHandler struct {
+	handlers.BaseHandler
+	service UserDictionaryService
+}
+
+// NewUserDictionaryHandler creates a new user dictionary handler
+func NewUserDictionaryHandler(svc UserDictionaryService, logger *zap.Logger) *UserDictionaryHandler {
+	return &UserDictionaryHandler{
+		BaseHandler: handlers.BaseHandler{Logger: logger},
+		service:     svc,
+	}
+}
+
+// RegisterRoutes registers all user dictionary handler routes
+// Note: This assumes the router is already scoped to /api/v6
+func (h *UserDictionaryHandler) RegisterRoutes(r chi.Router, authMiddleware func(http.Handler) http.Handler) {
+	r.Route("/dictionary", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.Get("/decks", h.GetDecks)
+		r.Post("/decks", h.CreateDeck)
+		r.Patch("/decks/{id}", h.UpdateDeck)
+		r.Delete("/decks/{id}", h.DeleteDeck)
+		r.Get("/words", h.GetWords)
+		r.Post("/words", h.CreateWord)
+		r.Patch("/words/{id}", h.UpdateWord)
+		r.Delete("/words/{id}", h.DeleteWord)
+	})
+}
+
+// errorStatus maps errors of the user dictionary service to HTTP status codes
+//
+// Missing decks and words, including ones of other users, are reported as not found,
+// database errors as internal errors and other errors as validation errors.
+func (h *UserDictionaryHandler) errorStatus(err error) int {
+	errMsg := err.Error()
+	switch {
+	case errMsg == "deck not found" || errMsg == "word not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(errMsg, "failed to"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// GetDecks handles GET /dictionary/decks
+// @Summary Get custom decks
+// @Description Get all custom decks of the authenticated user with the number of words in every deck. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} models.UserDeck "List of decks"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/decks [get]
+func (h *UserDictionaryHandler) GetDecks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	decks, err := h.service.GetDecks(r.Context(), userID)
+	if err != nil {
+		h.Logger.Error("failed to get decks", zap.Error(err))
+		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, decks)
+}
+
+// CreateDeck handles POST /dictionary/decks
+// @Summary Create a custom deck
+// @Description Create a named deck for custom words of the authenticated user. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateUserDeckRequest true "Deck creation request"
+// @Success 201 {object} map[string]any "Deck created successfully"
+// @Failure 400 {object} map[string]string "Invalid request body or deck already exists"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/decks [post]
+func (h *UserDictionaryHandler) CreateDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	var req models.CreateUserDeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	deckID, err := h.service.CreateDeck(r.Context(), userID, &req)
+	if err != nil {
+		h.Logger.Error("failed to create deck", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusCreated, map[string]any{
+		"id":      deckID,
+		"message": "deck created successfully",
+	})
+}
+
+// UpdateDeck handles PATCH /dictionary/decks/{id}
+// @Summary Rename a custom deck
+// @Description Rename a custom deck of the authenticated user. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Deck ID"
+// @Param request body models.UpdateUserDeckRequest true "Deck update request"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid request body or deck already exists"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Deck not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/decks/{id} [patch]
+func (h *UserDictionaryHandler) UpdateDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	deckID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse deck ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid deck ID")
+		return
+	}
+
+	var req models.UpdateUserDeckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.UpdateDeck(r.Context(), userID, deckID, &req); err != nil {
+		h.Logger.Error("failed to update deck", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteDeck handles DELETE /dictionary/decks/{id}
+// @Summary Delete a custom deck
+// @Description Delete a custom deck of the authenticated user. Words of the deck stay in the personal dictionary. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Deck ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid deck ID"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Deck not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/decks/{id} [delete]
+func (h *UserDictionaryHandler) DeleteDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	deckID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse deck ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid deck ID")
+		return
+	}
+
+	if err := h.service.DeleteDeck(r.Context(), userID, deckID); err != nil {
+		h.Logger.Error("failed to delete deck", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWords handles GET /dictionary/words
+// @Summary Get custom words
+// @Description Get custom words of the authenticated user, newest first. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param deckId query int false "Return only words of the deck"
+// @Success 200 {array} models.UserWord "List of custom words"
+// @Failure 400 {object} map[string]string "Invalid deck ID"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Deck not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/words [get]
+func (h *UserDictionaryHandler) GetWords(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	var deckID *int
+	if deckIDStr := r.URL.Query().Get("deckId"); deckIDStr != "" {
+		parsed, err := strconv.Atoi(deckIDStr)
+		if err != nil {
+			h.Logger.Error("failed to parse deckId parameter", zap.Error(err))
+			h.RespondError(w, http.StatusBadRequest, "invalid deckId parameter")
+			return
+		}
+		deckID = &parsed
+	}
+
+	words, err := h.service.GetWords(r.Context(), userID, deckID)
+	if err != nil {
+		h.Logger.Error("failed to get custom words", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, words)
+}
+
+// CreateWord handles POST /dictionary/words
+// @Summary Save a custom word
+// @Description Save a word to the personal dictionary of the authenticated user, optionally into one of their decks. The word is mixed into the daily review. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateUserWordRequest true "Custom word creation request"
+// @Success 201 {object} map[string]any "Word created successfully"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Deck not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/words [post]
+func (h *UserDictionaryHandler) CreateWord(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	var req models.CreateUserWordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	wordID, err := h.service.CreateWord(r.Context(), userID, &req)
+	if err != nil {
+		h.Logger.Error("failed to create custom word", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusCreated, map[string]any{
+		"id":      wordID,
+		"message": "word created successfully",
+	})
+}
+
+// UpdateWord handles PATCH /dictionary/words/{id}
+// @Summary Update a custom word
+// @Description Update a custom word of the authenticated user (partial update). deckId 0 removes the word from its deck. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Custom word ID"
+// @Param request body models.UpdateUserWordRequest true "Custom word update request"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid request body"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Word or deck not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/words/{id} [patch]
+func (h *UserDictionaryHandler) UpdateWord(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	wordID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse word ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word ID")
+		return
+	}
+
+	var req models.UpdateUserWordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.Logger.Error("failed to decode request body", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.UpdateWord(r.Context(), userID, wordID, &req); err != nil {
+		h.Logger.Error("failed to update custom word", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteWord handles DELETE /dictionary/words/{id}
+// @Summary Delete a custom word
+// @Description Delete a custom word of the authenticated user together with its review history. Requires authentication.
+// @Tags dictionary
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Custom word ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Invalid word ID"
+// @Failure 401 {object} map[string]string "Unauthorized - authentication required"
+// @Failure 404 {object} map[string]string "Word not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /dictionary/words/{id} [delete]
+func (h *UserDictionaryHandler) DeleteWord(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	wordID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.Logger.Error("failed to parse word ID", zap.Error(err))
+		h.RespondError(w, http.StatusBadRequest, "invalid word ID")
+		return
+	}
+
+	if err := h.service.DeleteWord(r.Context(), userID, wordID); err != nil {
+		h.Logger.Error("failed to delete custom word", zap.Error(err))
+		h.RespondError(w, h.errorStatus(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
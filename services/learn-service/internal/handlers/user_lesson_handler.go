@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
@@ -20,7 +22,7 @@ type UserLessonService interface {
 	// "userID" is the ID of the user.
 	// "complexityLevel" is the complexity level of the courses to retrieve.
 	// "search" is the search query for the courses.
-	// "isMine" is a flag to filter courses by user's completion history.
+	// "isMine" is a flag to filter courses the user is enrolled in.
 	// "page" is the page number to retrieve.
 	// "count" is the number of items per page.
 	//
@@ -34,14 +36,39 @@ type UserLessonService interface {
 	//
 	// Returns the course details, a list of lessons, and an error if any.
 	GetLessonsInCourse(ctx context.Context, courseSlug string, userID int) (*models.CourseDetailResponse, []models.LessonListItem, error)
+	// EnrollInCourse enrolls a user in a course
+	//
+	// "ctx" is the context for the request.
+	// "courseSlug" is the slug of the course.
+	// "userID" is the ID of the user.
+	//
+	// Returns an error if any.
+	EnrollInCourse(ctx context.Context, courseSlug string, userID int) error
+	// UnenrollFromCourse removes a course from the user's courses keeping completed lessons
+	//
+	// "ctx" is the context for the request.
+	// "courseSlug" is the slug of the course.
+	// "userID" is the ID of the user.
+	//
+	// Returns an error if any.
+	UnenrollFromCourse(ctx context.Context, courseSlug string, userID int) error
+	// GetContinueLesson retrieves the next incomplete lesson across the user's enrolled courses
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	//
+	// Returns the lesson with its course and an error if any.
+	GetContinueLesson(ctx context.Context, userID int) (*models.ContinueLessonResponse, error)
 	// GetLesson retrieves the details of a lesson for a user
 	//
 	// "ctx" is the context for the request.
 	// "lessonSlug" is the slug of the lesson.
 	// "userID" is the ID of the user.
+	// "furigana" is the furigana mode of the user: "always", "above level" or "never", "always" is used if it is empty.
+	// "furiganaLevel" is the JLPT level (1-5) of the user for "above level" mode, 5 is used if it is 0.
 	//
 	// Returns the lesson details, a list of lesson blocks, and an error if any.
-	GetLesson(ctx context.Context, lessonSlug string, userID int) (*models.LessonListItem, []models.LessonBlockResponse, error)
+	GetLesson(ctx context.Context, lessonSlug string, userID int, furigana string, furiganaLevel int) (*models.LessonListItem, []models.LessonBlockResponse, error)
 	// ToggleLessonCompletion toggles the completion status of a lesson for a user
 	//
 	// "ctx" is the context for the request.
@@ -50,6 +77,16 @@ type UserLessonService interface {
 	//
 	// Returns an error if any.
 	ToggleLessonCompletion(ctx context.Context, lessonSlug string, userID int) error
+	// SubmitQuiz grades answers to a quiz block of a lesson for a user
+	//
+	// "ctx" is the context for the request.
+	// "lessonSlug" is the slug of the lesson.
+	// "blockID" is the ID of the quiz block.
+	// "userID" is the ID of the user.
+	// "answers" is the list of answers in the order of the quiz questions.
+	//
+	// Returns the quiz result and an error if any.
+	SubmitQuiz(ctx context.Context, lessonSlug string, blockID, userID int, answers []models.QuizAnswer) (*models.QuizResult, error)
 }
 
 // UserLessonHandler handles HTTP requests for user lesson operations
@@ -71,12 +108,16 @@ func (h *UserLessonHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 	r.Route("/courses", func(r chi.Router) {
 		r.Use(authMiddleware)
 		r.Get("/", h.GetCoursesList)
+		r.Get("/continue", h.GetContinueLesson)
 		r.Get("/{slug}/lessons", h.GetLessonsInCourse)
+		r.Post("/{slug}/enroll", h.EnrollInCourse)
+		r.Delete("/{slug}/enroll", h.UnenrollFromCourse)
 	})
 	r.Route("/lessons", func(r chi.Router) {
 		r.Use(authMiddleware)
 		r.Get("/{slug}", h.GetLesson)
 		r.Post("/{slug}/complete", h.ToggleLessonCompletion)
+		r.Post("/{slug}/quizzes/{blockId}", h.SubmitQuiz)
 	})
 }
 
@@ -89,7 +130,7 @@ func (h *UserLessonHandler) RegisterRoutes(r chi.Router, authMiddleware func(htt
 // @Security ApiKeyAuth
 // @Param complexityLevel query string false "Complexity level (ab, b, i, ui, a)"
 // @Param search query string false "Search by course title"
-// @Param isMine query bool false "Filter courses by user's completion history"
+// @Param isMine query bool false "Filter courses the user is enrolled in"
 // @Param page query int false "Page number (default: 1)"
 // @Param count query int false "Items per page (default: 10)"
 // @Success 200 {array} models.CourseDetailResponse "List of courses"
@@ -158,7 +199,7 @@ func (h *UserLessonHandler) GetCoursesList(w http.ResponseWriter, r *http.Reques
 
 // GetLessonsInCourse handles GET /courses/{slug}/lessons
 // @Summary Get lessons in a course
-// @Description Get course details with list of lessons and completion status
+// @Description Get course details with list of lessons, completion and lock status
 // @Tags lessons
 // @Accept json
 // @Produce json
@@ -204,6 +245,130 @@ func (h *UserLessonHandler) GetLessonsInCourse(w http.ResponseWriter, r *http.Re
 	h.RespondJSON(w, http.StatusOK, response)
 }
 
+// EnrollInCourse handles POST /courses/{slug}/enroll
+// @Summary Enroll in a course
+// @Description Add a course to the user's courses
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param slug path string true "Course slug"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Course not found"
+// @Failure 409 {object} map[string]string "Already enrolled in course"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /courses/{slug}/enroll [post]
+func (h *UserLessonHandler) EnrollInCourse(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from context
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	courseSlug := chi.URLParam(r, "slug")
+	if courseSlug == "" {
+		h.RespondError(w, http.StatusBadRequest, "course slug is required")
+		return
+	}
+
+	err := h.service.EnrollInCourse(r.Context(), courseSlug, userID)
+	if err != nil {
+		h.Logger.Error("failed to enroll in course", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "failed to get course: course not found" {
+			errStatus = http.StatusNotFound
+		} else if err.Error() == "already enrolled in course" {
+			errStatus = http.StatusConflict
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnenrollFromCourse handles DELETE /courses/{slug}/enroll
+// @Summary Unenroll from a course
+// @Description Remove a course from the user's courses, completed lessons are kept
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param slug path string true "Course slug"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Course not found or not enrolled in course"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /courses/{slug}/enroll [delete]
+func (h *UserLessonHandler) UnenrollFromCourse(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from context
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	courseSlug := chi.URLParam(r, "slug")
+	if courseSlug == "" {
+		h.RespondError(w, http.StatusBadRequest, "course slug is required")
+		return
+	}
+
+	err := h.service.UnenrollFromCourse(r.Context(), courseSlug, userID)
+	if err != nil {
+		h.Logger.Error("failed to unenroll from course", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "failed to get course: course not found" || err.Error() == "not enrolled in course" {
+			errStatus = http.StatusNotFound
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetContinueLesson handles GET /courses/continue
+// @Summary Continue where you left off
+// @Description Get the first incomplete lesson by order in the enrolled course with the most recent activity
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.ContinueLessonResponse "Next lesson"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No incomplete lesson in enrolled courses"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /courses/continue [get]
+func (h *UserLessonHandler) GetContinueLesson(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from context
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	lesson, err := h.service.GetContinueLesson(r.Context(), userID)
+	if err != nil {
+		if err.Error() == "next lesson not found" {
+			h.RespondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		h.Logger.Error("failed to get continue lesson", zap.Error(err))
+		h.RespondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, lesson)
+}
+
 // GetLesson handles GET /lessons/{slug}
 // @Summary Get lesson details
 // @Description Get full lesson details with blocks and completion status
@@ -212,9 +377,12 @@ func (h *UserLessonHandler) GetLessonsInCourse(w http.ResponseWriter, r *http.Re
 // @Produce json
 // @Security ApiKeyAuth
 // @Param slug path string true "Lesson slug"
+// @Param furigana query string false "Furigana of text blocks: always, above level or never (default: always)"
+// @Param furiganaLevel query int false "JLPT level (1-5) of the user, furigana are shown above harder kanji in above level mode (default: 5)"
 // @Success 200 {object} map[string]any{} "Lesson details"
 // @Failure 400 {object} map[string]string "Bad request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Lesson is locked"
 // @Failure 404 {object} map[string]string "Lesson not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /lessons/{slug} [get]
@@ -233,12 +401,22 @@ func (h *UserLessonHandler) GetLesson(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	lesson, blocks, err := h.service.GetLesson(r.Context(), lessonSlug, userID)
+	furiganaLevel, err := parseFuriganaLevel(r)
+	if err != nil {
+		h.RespondError(w, http.StatusBadRequest, "invalid furiganaLevel parameter")
+		return
+	}
+
+	lesson, blocks, err := h.service.GetLesson(r.Context(), lessonSlug, userID, r.URL.Query().Get("furigana"), furiganaLevel)
 	if err != nil {
 		h.Logger.Error("failed to get lesson", zap.Error(err))
 		errStatus := http.StatusInternalServerError
 		if err.Error() == "lesson not found" || err.Error() == "failed to get lesson: lesson not found" {
 			errStatus = http.StatusNotFound
+		} else if err.Error() == "lesson is locked" {
+			errStatus = http.StatusForbidden
+		} else if strings.HasPrefix(err.Error(), "invalid furigana mode") || err.Error() == "furiganaLevel must be between 1 and 5" {
+			errStatus = http.StatusBadRequest
 		}
 		h.RespondError(w, errStatus, err.Error())
 		return
@@ -263,6 +441,7 @@ func (h *UserLessonHandler) GetLesson(w http.ResponseWriter, r *http.Request) {
 // @Success 204 "No Content"
 // @Failure 400 {object} map[string]string "Bad request"
 // @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Lesson is locked or its quizzes are not passed"
 // @Failure 404 {object} map[string]string "Lesson not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /lessons/{slug}/complete [post]
@@ -287,6 +466,8 @@ func (h *UserLessonHandler) ToggleLessonCompletion(w http.ResponseWriter, r *htt
 		errStatus := http.StatusInternalServerError
 		if err.Error() == "lesson not found" || err.Error() == "failed to get lesson: lesson not found" {
 			errStatus = http.StatusNotFound
+		} else if err.Error() == "lesson is locked" || err.Error() == "lesson quizzes are not passed" {
+			errStatus = http.StatusForbidden
 		}
 		h.RespondError(w, errStatus, err.Error())
 		return
@@ -294,3 +475,65 @@ func (h *UserLessonHandler) ToggleLessonCompletion(w http.ResponseWriter, r *htt
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// SubmitQuiz handles POST /lessons/{slug}/quizzes/{blockId}
+// @Summary Submit quiz answers
+// @Description Grade answers to a quiz block of a lesson and store the attempt
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param slug path string true "Lesson slug"
+// @Param blockId path int true "Quiz block ID"
+// @Param request body models.SubmitQuizRequest true "Answers in the order of the questions"
+// @Success 200 {object} models.QuizResult "Quiz result"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Lesson is locked"
+// @Failure 404 {object} map[string]string "Lesson or quiz not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /lessons/{slug}/quizzes/{blockId} [post]
+func (h *UserLessonHandler) SubmitQuiz(w http.ResponseWriter, r *http.Request) {
+	// Extract userID from context
+	userID, ok := authMiddleware.GetUserID(r.Context())
+	if !ok {
+		h.Logger.Error("user ID not found in context")
+		h.RespondError(w, http.StatusUnauthorized, "user ID not found in context")
+		return
+	}
+
+	lessonSlug := chi.URLParam(r, "slug")
+	if lessonSlug == "" {
+		h.RespondError(w, http.StatusBadRequest, "lesson slug is required")
+		return
+	}
+
+	blockID, err := strconv.Atoi(chi.URLParam(r, "blockId"))
+	if err != nil || blockID <= 0 {
+		h.RespondError(w, http.StatusBadRequest, "invalid block ID")
+		return
+	}
+
+	var req models.SubmitQuizRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.RespondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.service.SubmitQuiz(r.Context(), lessonSlug, blockID, userID, req.Answers)
+	if err != nil {
+		h.Logger.Error("failed to submit quiz", zap.Error(err))
+		errStatus := http.StatusInternalServerError
+		if err.Error() == "lesson not found" || err.Error() == "failed to get lesson: lesson not found" || err.Error() == "quiz not found" {
+			errStatus = http.StatusNotFound
+		} else if err.Error() == "lesson is locked" {
+			errStatus = http.StatusForbidden
+		} else if err.Error() == "answers count must match questions count" {
+			errStatus = http.StatusBadRequest
+		}
+		h.RespondError(w, errStatus, err.Error())
+		return
+	}
+
+	h.RespondJSON(w, http.StatusOK, result)
+}
@@ -1,15 +1,20 @@
 package models
 
+import "regexp"
+
 // Character represents a hiragana/katakana character
+//
+// Yōon characters consist of two kana (きゃ), so Katakana and Hiragana fields may contain more than one rune.
 type Character struct {
-	ID             int    `json:"id"`
-	Consonant      string `json:"consonant"` // defines a membership to a consonant group
-	Vowel          string `json:"vowel"`     // defines a membership to a vowel group
-	EnglishReading string `json:"englishReading"`
-	RussianReading string `json:"russianReading"`
-	Katakana       string `json:"katakana"`
-	Hiragana       string `json:"hiragana"`
-	Audio          string `json:"audio,omitempty"` // URL to audio file on media server
+	ID        int               `json:"id"`
+	Consonant string            `json:"consonant"`          // defines a membership to a consonant group
+	Vowel     string            `json:"vowel"`              // defines a membership to a vowel group
+	Group     CharacterGroup    `json:"group"`              // defines a membership to a character group (basic, dakuten, handakuten, yoon)
+	Reading   string            `json:"reading,omitempty"`  // Reading in the requested locale, empty for admin endpoints
+	Readings  map[string]string `json:"readings,omitempty"` // Readings by language code, set for admin endpoints only
+	Katakana  string            `json:"katakana"`
+	Hiragana  string            `json:"hiragana"`
+	Audio     string            `json:"audio,omitempty"` // URL to audio file on media server
 }
 
 // AlphabetType represents the type of alphabet (hiragana or katakana)
@@ -21,73 +26,124 @@ const (
 	AlphabetTypeKatakana AlphabetType = "kt"
 )
 
-// Locale represents the locale for reading (english or russian)
-// Used for filtering characters by locale
-type Locale string
+// CharacterGroup represents the group of a kana character
+// Used for filtering characters in charts and tests
+type CharacterGroup string
 
 const (
-	LocaleEnglish Locale = "en"
-	LocaleRussian Locale = "ru"
-	LocaleGerman  Locale = "de"
+	CharacterGroupBasic      CharacterGroup = "basic"      // Basic characters (あ, か)
+	CharacterGroupDakuten    CharacterGroup = "dakuten"    // Voiced characters (が, ざ)
+	CharacterGroupHandakuten CharacterGroup = "handakuten" // Semi-voiced characters (ぱ, ぴ)
+	CharacterGroupYoon       CharacterGroup = "yoon"       // Combinations with small ya, yu or yo (きゃ, ぎゅ)
 )
 
+// IsValid checks if the character group is one of the known groups
+func (g CharacterGroup) IsValid() bool {
+	switch g {
+	case CharacterGroupBasic, CharacterGroupDakuten, CharacterGroupHandakuten, CharacterGroupYoon:
+		return true
+	}
+	return false
+}
+
+// KanaLength returns the number of kana (runes) a character of the group consists of
+func (g CharacterGroup) KanaLength() int {
+	if g == CharacterGroupYoon {
+		return 2
+	}
+	return 1
+}
+
+// Locale represents a language code of readings and translations, such as "en" or "pt-br"
+//
+// Any language may be used, readings and translations missing in it are taken from its fallback languages.
+type Locale string
+
+// localePattern matches a lowercase language code with an optional region or script subtag
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})?$`)
+
+// IsValid checks if the locale is a well-formed language code
+func (l Locale) IsValid() bool {
+	return localePattern.MatchString(string(l))
+}
+
 // CharacterResponse represents a character in most of the API responses
 type CharacterResponse struct {
-	ID        int    `json:"id"`
-	Consonant string `json:"consonant,omitempty"` // Not always present, depends on the context
-	Vowel     string `json:"vowel,omitempty"`     // Not always present, depends on the context
-	Character string `json:"character"`           // Hiragana or Katakana
-	Reading   string `json:"reading"`             // English or Russian reading
+	ID        int            `json:"id"`
+	Consonant string         `json:"consonant,omitempty"` // Not always present, depends on the context
+	Vowel     string         `json:"vowel,omitempty"`     // Not always present, depends on the context
+	Group     CharacterGroup `json:"group,omitempty"`
+	Character string         `json:"character"` // Hiragana or Katakana
+	Reading   string         `json:"reading"`   // Reading in the requested locale
 }
 
 // ReadingTestItem represents an item in a reading test
+//
+// Correct and wrong characters are kept on the server in the test session, the client only receives shuffled options.
 type ReadingTestItem struct {
 	ID           int      `json:"id"`
-	WrongOptions []string `json:"wrongOptions"` // Two wrong character options
-	Reading      string   `json:"reading"`      // English or Russian reading
-	CorrectChar  string   `json:"correctChar"`  // Correct character
+	WrongOptions []string `json:"-"`       // Two wrong character options
+	Reading      string   `json:"reading"` // Reading in the requested locale
+	CorrectChar  string   `json:"-"`       // Correct character
+	Options      []string `json:"options"` // Correct and wrong characters in random order
 }
 
 // WritingTestItem represents an item in a writing test
 type WritingTestItem struct {
 	ID             int    `json:"id"`
-	CorrectReading string `json:"correctReading"` // English or Russian reading correct reading
-	Character      string `json:"Character"`      // Correct character whose reading is to be guessed
+	CorrectReading string `json:"-"`         // Correct reading in the requested locale
+	Character      string `json:"Character"` // Correct character whose reading is to be guessed
 }
 
 // ListeningTestItem represents an item in a listening test
+//
+// Please reference ReadingTestItem for more information about hidden fields.
 type ListeningTestItem struct {
 	ID           int      `json:"id"`
 	AudioURL     string   `json:"audioUrl"`
-	CorrectChar  string   `json:"correctChar"`
-	WrongOptions []string `json:"wrongOptions"` // Two wrong character options
+	CorrectChar  string   `json:"-"`
+	WrongOptions []string `json:"-"`       // Two wrong character options
+	Options      []string `json:"options"` // Correct and wrong characters in random order
+}
+
+// MatchingTestItem represents an item in a matching test
+//
+// The shown character belongs to the tested alphabet, options are characters of the other alphabet.
+// Please reference ReadingTestItem for more information about hidden fields.
+type MatchingTestItem struct {
+	ID           int      `json:"id"`
+	Character    string   `json:"character"` // Shown character whose counterpart is to be chosen
+	CorrectChar  string   `json:"-"`         // Counterpart of the shown character in the other alphabet
+	WrongOptions []string `json:"-"`         // Two wrong character options
+	Options      []string `json:"options"`   // Correct and wrong characters in random order
 }
 
 // CharacterListItem represents a character in the list of characters for admin endpoints
 type CharacterListItem struct {
-	ID        int    `json:"id"`
-	Consonant string `json:"consonant"`
-	Vowel     string `json:"vowel"`
-	Katakana  string `json:"katakana"`
-	Hiragana  string `json:"hiragana"`
+	ID        int            `json:"id"`
+	Consonant string         `json:"consonant"`
+	Vowel     string         `json:"vowel"`
+	Group     CharacterGroup `json:"group"`
+	Katakana  string         `json:"katakana"`
+	Hiragana  string         `json:"hiragana"`
 }
 
 // CreateCharacterRequest represents a request to create a character
 type CreateCharacterRequest struct {
-	Consonant      string `json:"consonant"`
-	Vowel          string `json:"vowel"`
-	EnglishReading string `json:"englishReading"`
-	RussianReading string `json:"russianReading"`
-	Katakana       string `json:"katakana"`
-	Hiragana       string `json:"hiragana"`
+	Consonant string            `json:"consonant"`
+	Vowel     string            `json:"vowel"`
+	Group     string            `json:"group,omitempty"` // "basic" by default
+	Readings  map[string]string `json:"readings"`        // Readings by language code, the default locale is required
+	Katakana  string            `json:"katakana"`
+	Hiragana  string            `json:"hiragana"`
 }
 
 // UpdateCharacterRequest represents a request to update a character (partial update)
 type UpdateCharacterRequest struct {
-	Consonant      string `json:"consonant,omitempty"`
-	Vowel          string `json:"vowel,omitempty"`
-	EnglishReading string `json:"englishReading,omitempty"`
-	RussianReading string `json:"russianReading,omitempty"`
-	Katakana       string `json:"katakana,omitempty"`
-	Hiragana       string `json:"hiragana,omitempty"`
+	Consonant string            `json:"consonant,omitempty"`
+	Vowel     string            `json:"vowel,omitempty"`
+	Group     string            `json:"group,omitempty"`
+	Readings  map[string]string `json:"readings,omitempty"` // Set for the listed languages only, empty values are ignored
+	Katakana  string            `json:"katakana,omitempty"`
+	Hiragana  string            `json:"hiragana,omitempty"`
 }
@@ -2,17 +2,20 @@ package models
 
 // CharacterLearnHistory represents a user's learning history for a character
 //
-// Result fields have their values in float because in the future they will have float values.
+// Result fields hold mastery scores from 0 to 1. Every submitted answer moves the score
+// towards 1 (passed) or 0 (failed), so a single answer never marks a character as learned.
 type CharacterLearnHistory struct {
 	ID                      int     `json:"id"`
 	UserID                  int     `json:"userId"`
 	CharacterID             int     `json:"characterId"`
-	HiraganaReadingResult   float32 `json:"hiraganaReadingResult"`   // 0 or 1
-	HiraganaWritingResult   float32 `json:"hiraganaWritingResult"`   // 0 or 1
-	HiraganaListeningResult float32 `json:"hiraganaListeningResult"` // 0 or 1
-	KatakanaReadingResult   float32 `json:"katakanaReadingResult"`   // 0 or 1
-	KatakanaWritingResult   float32 `json:"katakanaWritingResult"`   // 0 or 1
-	KatakanaListeningResult float32 `json:"katakanaListeningResult"` // 0 or 1
+	HiraganaReadingResult   float32 `json:"hiraganaReadingResult"`   // 0..1
+	HiraganaWritingResult   float32 `json:"hiraganaWritingResult"`   // 0..1
+	HiraganaListeningResult float32 `json:"hiraganaListeningResult"` // 0..1
+	KatakanaReadingResult   float32 `json:"katakanaReadingResult"`   // 0..1
+	KatakanaWritingResult   float32 `json:"katakanaWritingResult"`   // 0..1
+	KatakanaListeningResult float32 `json:"katakanaListeningResult"` // 0..1
+	HiraganaMatchingResult  float32 `json:"hiraganaMatchingResult"`  // 0..1, hiragana shown, katakana chosen
+	KatakanaMatchingResult  float32 `json:"katakanaMatchingResult"`  // 0..1, katakana shown, hiragana chosen
 }
 
 // UserLearnHistory represents a user's learning history
@@ -25,12 +28,16 @@ type UserLearnHistory struct {
 	KatakanaReadingResult   float32 `json:"katakanaReadingResult"`
 	KatakanaWritingResult   float32 `json:"katakanaWritingResult"`
 	KatakanaListeningResult float32 `json:"katakanaListeningResult"`
+	HiraganaMatchingResult  float32 `json:"hiraganaMatchingResult"`
+	KatakanaMatchingResult  float32 `json:"katakanaMatchingResult"`
 }
 
 // TestResultItem represents a single test result
 type TestResultItem struct {
-	CharacterID int  `json:"characterId"`
-	Passed      bool `json:"passed"`
+	CharacterID    int    `json:"characterId"`
+	Passed         bool   `json:"passed"`
+	ChosenOption   string `json:"chosenOption,omitempty"`   // Wrong option picked by the user (optional)
+	ResponseTimeMs int    `json:"responseTimeMs,omitempty"` // Answer time in milliseconds (optional)
 }
 
 // SubmitTestResultsResult represents the result of submitting test results
@@ -0,0 +1,48 @@
+package models
+
+// CharacterStroke represents a single reference stroke of a kana in KanjiVG format
+type CharacterStroke struct {
+	CharacterID  int    `json:"characterId"`
+	AlphabetType string `json:"alphabetType"` // "hiragana" or "katakana"
+	StrokeNumber int    `json:"strokeNumber"` // 1-based position in the stroke order
+	Path         string `json:"path"`         // SVG path data in the 109x109 KanjiVG coordinate space
+}
+
+// StrokePoint represents a point of a drawn stroke in the 109x109 KanjiVG coordinate space
+type StrokePoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// StrokeStatus represents the result of checking a single drawn stroke
+type StrokeStatus string
+
+const (
+	StrokeStatusCorrect        StrokeStatus = "correct"
+	StrokeStatusWrongDirection StrokeStatus = "wrong_direction" // Stroke matches the reference but is drawn backwards
+	StrokeStatusWrongOrder     StrokeStatus = "wrong_order"     // Stroke matches another reference stroke
+	StrokeStatusWrongShape     StrokeStatus = "wrong_shape"     // Stroke doesn't match any reference stroke
+	StrokeStatusExtra          StrokeStatus = "extra"           // Stroke is drawn after all reference strokes
+	StrokeStatusMissing        StrokeStatus = "missing"         // Reference stroke has not been drawn
+)
+
+// StrokeExerciseRequest represents strokes drawn by a user, each stroke is an ordered list of points
+type StrokeExerciseRequest struct {
+	Strokes [][]StrokePoint `json:"strokes"`
+}
+
+// StrokeFeedback represents the check result of a single stroke
+type StrokeFeedback struct {
+	StrokeNumber         int          `json:"strokeNumber"` // 1-based position of the drawn stroke (of the reference stroke for missing ones)
+	Status               StrokeStatus `json:"status"`
+	ExpectedStrokeNumber int          `json:"expectedStrokeNumber,omitempty"` // Reference stroke matched by a stroke drawn out of order
+	Deviation            float64      `json:"deviation,omitempty"`            // Mean distance to the reference stroke in KanjiVG units
+}
+
+// StrokeExerciseResult represents the result of a stroke writing exercise
+type StrokeExerciseResult struct {
+	Passed              bool             `json:"passed"`
+	ExpectedStrokeCount int              `json:"expectedStrokeCount"`
+	DrawnStrokeCount    int              `json:"drawnStrokeCount"`
+	Strokes             []StrokeFeedback `json:"strokes"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// CharacterTestAttempt represents a single answer given by a user in a kana test
+type CharacterTestAttempt struct {
+	ID             int       `json:"id"`
+	UserID         int       `json:"userId,omitempty"`
+	CharacterID    int       `json:"characterId"`
+	Character      string    `json:"character,omitempty"` // Hiragana or Katakana, depends on the alphabet type
+	AlphabetType   string    `json:"alphabetType"`        // "hiragana" or "katakana"
+	TestType       string    `json:"testType"`            // "reading", "writing", "listening" or "matching"
+	Passed         bool      `json:"passed"`
+	ChosenOption   string    `json:"chosenOption,omitempty"`   // Wrong option picked by the user, empty for passed attempts
+	ResponseTimeMs int       `json:"responseTimeMs,omitempty"` // Time between showing the question and answering it
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// CharacterAccuracyPoint represents user's accuracy for a character on a single day
+type CharacterAccuracyPoint struct {
+	CharacterID           int     `json:"characterId"`
+	Character             string  `json:"character"` // Hiragana or Katakana, depends on the alphabet type
+	AlphabetType          string  `json:"alphabetType"`
+	Date                  string  `json:"date"` // YYYY-MM-DD
+	Attempts              int     `json:"attempts"`
+	PassedAttempts        int     `json:"passedAttempts"`
+	Accuracy              float64 `json:"accuracy"` // PassedAttempts / Attempts
+	AverageResponseTimeMs float64 `json:"averageResponseTimeMs"`
+}
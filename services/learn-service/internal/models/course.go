@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // ComplexityLevel represents the complexity level of a course
 type ComplexityLevel string
 
@@ -20,14 +22,23 @@ var ComplexityLevelAbbreviation = map[string]ComplexityLevel{
 	"a":  ComplexityLevelAdvanced,
 }
 
+// CourseNavigation represents how learners can move between lessons of a course
+type CourseNavigation string
+
+const (
+	CourseNavigationFree       CourseNavigation = "free"       // Lessons can be taken in any order
+	CourseNavigationSequential CourseNavigation = "sequential" // A lesson is unlocked when all previous lessons are completed
+)
+
 // Course represents a course in the learning system
 type Course struct {
-	ID              int             `json:"id"`
-	Slug            string          `json:"slug"`
-	AuthorID        int             `json:"authorId"`
-	Title           string          `json:"title"`
-	ShortSummary    string          `json:"shortSummary"`
-	ComplexityLevel ComplexityLevel `json:"complexityLevel"`
+	ID              int              `json:"id"`
+	Slug            string           `json:"slug"`
+	AuthorID        int              `json:"authorId"`
+	Title           string           `json:"title"`
+	ShortSummary    string           `json:"shortSummary"`
+	ComplexityLevel ComplexityLevel  `json:"complexityLevel"`
+	Navigation      CourseNavigation `json:"navigation"`
 }
 
 // CourseListItem represents a course in list responses
@@ -41,31 +52,38 @@ type CourseListItem struct {
 
 // CourseDetailResponse represents a course with additional details for user endpoints
 type CourseDetailResponse struct {
-	ID               int             `json:"id,omitempty"`
-	Slug             string          `json:"slug,omitempty"`
-	Title            string          `json:"title"`
-	ShortSummary     string          `json:"shortSummary,omitempty"`
-	ComplexityLevel  ComplexityLevel `json:"complexityLevel"`
-	TotalLessons     int             `json:"totalLessons"`
-	CompletedLessons int             `json:"completedLessons"`
+	ID               int              `json:"id,omitempty"`
+	Slug             string           `json:"slug,omitempty"`
+	Title            string           `json:"title"`
+	ShortSummary     string           `json:"shortSummary,omitempty"`
+	ComplexityLevel  ComplexityLevel  `json:"complexityLevel"`
+	Navigation       CourseNavigation `json:"navigation,omitempty"`
+	TotalLessons     int              `json:"totalLessons"`
+	CompletedLessons int              `json:"completedLessons"`
+	ProgressPercent  int              `json:"progressPercent"`
+	IsEnrolled       bool             `json:"isEnrolled"`
+	EnrolledAt       *time.Time       `json:"enrolledAt,omitempty"`
+	LastActivityAt   *time.Time       `json:"lastActivityAt,omitempty"`
 }
 
 // CreateCourseRequest represents a request to create a course
 type CreateCourseRequest struct {
-	AuthorID        int             `json:"authorId"`
-	Slug            string          `json:"slug"`
-	Title           string          `json:"title"`
-	ShortSummary    string          `json:"shortSummary"`
-	ComplexityLevel ComplexityLevel `json:"complexityLevel"`
+	AuthorID        int              `json:"authorId"`
+	Slug            string           `json:"slug"`
+	Title           string           `json:"title"`
+	ShortSummary    string           `json:"shortSummary"`
+	ComplexityLevel ComplexityLevel  `json:"complexityLevel"`
+	Navigation      CourseNavigation `json:"navigation,omitempty"` // "free" if empty
 }
 
 // UpdateCourseRequest represents a request to update a course (partial update)
 type UpdateCourseRequest struct {
-	AuthorID        *int            `json:"authorId,omitempty"`
-	Slug            string          `json:"slug,omitempty"`
-	Title           string          `json:"title,omitempty"`
-	ShortSummary    string          `json:"shortSummary,omitempty"`
-	ComplexityLevel ComplexityLevel `json:"complexityLevel,omitempty"`
+	AuthorID        *int             `json:"authorId,omitempty"`
+	Slug            string           `json:"slug,omitempty"`
+	Title           string           `json:"title,omitempty"`
+	ShortSummary    string           `json:"shortSummary,omitempty"`
+	ComplexityLevel ComplexityLevel  `json:"complexityLevel,omitempty"`
+	Navigation      CourseNavigation `json:"navigation,omitempty"`
 }
 
 // CourseShortInfo represents a course with only ID and Title (for select options)
@@ -4,8 +4,52 @@ import "time"
 
 // DictionaryHistory represents a user's learning history for a word
 type DictionaryHistory struct {
-	ID             int       `json:"id"`
-	WordID         int       `json:"wordId"`
-	UserID         int       `json:"userId"`
-	NextAppearance time.Time `json:"nextAppearance"`
+	ID             int        `json:"id"`
+	WordID         int        `json:"wordId"`     // 0 for custom words
+	UserWordID     int        `json:"userWordId"` // 0 for dictionary words
+	UserID         int        `json:"userId"`
+	EaseFactor     float64    `json:"easeFactor"`   // SM-2 ease factor, 2.5 for a new word and never below 1.3
+	IntervalDays   int        `json:"intervalDays"` // Days between the last review and the next appearance
+	Repetitions    int        `json:"repetitions"`  // Successful reviews in a row
+	Lapses         int        `json:"lapses"`       // Times the word was forgotten after being learned
+	ReviewCount    int        `json:"reviewCount"`  // Total number of reviews, used to rotate example sentences
+	IsLeech        bool       `json:"isLeech"`      // The word keeps being forgotten and is kept out of regular reviews
+	Suspended      bool       `json:"suspended"`    // The leech is not reviewed until it is unsuspended
+	NextAppearance time.Time  `json:"nextAppearance"`
+	LastReviewedAt *time.Time `json:"lastReviewedAt"`
+}
+
+// Leech represents a word the user keeps forgetting together with its review state
+type Leech struct {
+	Word           WordResponse `json:"word"`
+	Lapses         int          `json:"lapses"`
+	Suspended      bool         `json:"suspended"` // Suspended leeches are not reviewed, other leeches are reviewed from the leech list
+	NextAppearance time.Time    `json:"nextAppearance"`
+}
+
+// ReviewForecastDay represents the number of dictionary reviews coming due on a day
+type ReviewForecastDay struct {
+	Day   int `json:"day"`   // Days from today, 1 is tomorrow
+	Count int `json:"count"` // Number of words coming due on the day
+}
+
+// ReviewForecast represents the dictionary review workload of a user
+type ReviewForecast struct {
+	Backlog  int                 `json:"backlog"`  // Number of words due today, including overdue words
+	NewWords int                 `json:"newWords"` // Number of words the user has never reviewed
+	Days     []ReviewForecastDay `json:"days"`
+}
+
+// DictionaryExportFormat represents a file format of the dictionary export
+type DictionaryExportFormat string
+
+const (
+	DictionaryExportFormatAPKG DictionaryExportFormat = "apkg" // Anki package with audio and review state
+	DictionaryExportFormatTSV  DictionaryExportFormat = "tsv"  // Anki text export without audio and review state
+)
+
+// DictionaryExportEntry represents a reviewed word of a user together with its review state
+type DictionaryExportEntry struct {
+	Word    WordResponse
+	History DictionaryHistory
 }
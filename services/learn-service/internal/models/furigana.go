@@ -0,0 +1,33 @@
+package models
+
+// FuriganaMode represents when furigana are shown above annotated kanji
+type FuriganaMode string
+
+const (
+	FuriganaModeAlways     FuriganaMode = "always"
+	FuriganaModeAboveLevel FuriganaMode = "above level" // Only above kanji harder than the learner's JLPT level
+	FuriganaModeNever      FuriganaMode = "never"
+)
+
+// IsValid checks if the furigana mode is one of the known modes
+func (m FuriganaMode) IsValid() bool {
+	switch m {
+	case FuriganaModeAlways, FuriganaModeAboveLevel, FuriganaModeNever:
+		return true
+	}
+	return false
+}
+
+// RubySegment represents a part of a Japanese text with ruby markup
+//
+// Texts are written as "{漢字|かんじ}を読む", every annotated part becomes a segment with its reading.
+type RubySegment struct {
+	Text    string `json:"text"`
+	Reading string `json:"reading,omitempty"` // Furigana of the text, empty if they are not shown
+}
+
+// RubyText represents a Japanese text with furigana served to the client
+type RubyText struct {
+	Segments []RubySegment `json:"segments"`
+	HTML     string        `json:"html"` // Escaped text with <ruby> elements for segments with furigana
+}
@@ -0,0 +1,57 @@
+package models
+
+// Kanji represents a Japanese kanji
+type Kanji struct {
+	ID          int               `json:"id"`
+	Character   string            `json:"character"` // Single kanji character
+	Onyomi      string            `json:"onyomi"`    // On'yomi readings in katakana, separated by commas
+	Kunyomi     string            `json:"kunyomi"`   // Kun'yomi readings in hiragana, separated by commas
+	Meanings    map[string]string `json:"meanings"`  // Meanings by language code
+	StrokeCount int               `json:"strokeCount"`
+	JLPTLevel   int               `json:"jlptLevel"` // 5 (N5, easiest) to 1 (N1, hardest)
+	Radicals    string            `json:"radicals"`  // Radicals the kanji consists of
+	Audio       string            `json:"audio"`     // URL to kanji audio metadata on media server
+}
+
+// KanjiResponse represents a kanji in API responses with a locale-specific meaning
+type KanjiResponse struct {
+	ID          int    `json:"id"`
+	Character   string `json:"character"`
+	Onyomi      string `json:"onyomi"`
+	Kunyomi     string `json:"kunyomi"`
+	Meaning     string `json:"meaning"` // Locale-specific meaning
+	StrokeCount int    `json:"strokeCount"`
+	JLPTLevel   int    `json:"jlptLevel"`
+	Radicals    string `json:"radicals"`
+	Audio       string `json:"audio"` // URL to kanji audio metadata on media server
+}
+
+// KanjiListItem represents a kanji in the admin list response
+type KanjiListItem struct {
+	ID        int    `json:"id"`
+	Character string `json:"character"`
+	Meaning   string `json:"meaning"` // Meaning in the default locale
+	JLPTLevel int    `json:"jlptLevel"`
+}
+
+// CreateKanjiRequest represents a request to create a kanji
+type CreateKanjiRequest struct {
+	Character   string            `json:"character"`
+	Onyomi      string            `json:"onyomi"`
+	Kunyomi     string            `json:"kunyomi"`
+	Meanings    map[string]string `json:"meanings"` // Meanings by language code, the default locale is required
+	StrokeCount int               `json:"strokeCount"`
+	JLPTLevel   int               `json:"jlptLevel"`
+	Radicals    string            `json:"radicals"`
+}
+
+// UpdateKanjiRequest represents a request to update a kanji (partial update)
+type UpdateKanjiRequest struct {
+	Character   string            `json:"character,omitempty"`
+	Onyomi      string            `json:"onyomi,omitempty"`
+	Kunyomi     string            `json:"kunyomi,omitempty"`
+	Meanings    map[string]string `json:"meanings,omitempty"` // Set for the listed languages only, empty values are ignored
+	StrokeCount *int              `json:"strokeCount,omitempty"`
+	JLPTLevel   *int              `json:"jlptLevel,omitempty"`
+	Radicals    string            `json:"radicals,omitempty"`
+}
@@ -2,41 +2,61 @@ package models
 
 // Lesson represents a lesson in a course
 type Lesson struct {
-	ID           int    `json:"id"`
-	Slug         string `json:"slug"`
-	CourseID     int    `json:"courseId,omitempty"`
-	Title        string `json:"title"`
-	ShortSummary string `json:"shortSummary"`
-	Order        int    `json:"order"`
+	ID              int    `json:"id"`
+	Slug            string `json:"slug"`
+	CourseID        int    `json:"courseId,omitempty"`
+	Title           string `json:"title"`
+	ShortSummary    string `json:"shortSummary"`
+	Order           int    `json:"order"`
+	MinKanaMastery  int    `json:"minKanaMastery"`            // Percent of kana mastery needed to unlock the lesson, 0 if not needed
+	PrerequisiteIDs []int  `json:"prerequisiteIds,omitempty"` // Lessons which must be completed to unlock the lesson
 }
 
 // LessonListItem represents a lesson in user list responses
 type LessonListItem struct {
-	ID           int    `json:"id,omitempty"`
-	Slug         string `json:"slug,omitempty"`
-	CourseID     int    `json:"courseId,omitempty"`
-	Title        string `json:"title"`
-	ShortSummary string `json:"shortSummary,omitempty"`
-	Order        int    `json:"order,omitempty"`
-	Completed    bool   `json:"completed"`
+	ID             int    `json:"id,omitempty"`
+	Slug           string `json:"slug,omitempty"`
+	CourseID       int    `json:"courseId,omitempty"`
+	Title          string `json:"title"`
+	ShortSummary   string `json:"shortSummary,omitempty"`
+	Order          int    `json:"order,omitempty"`
+	MinKanaMastery int    `json:"minKanaMastery,omitempty"`
+	Completed      bool   `json:"completed"`
+	Locked         bool   `json:"locked"`
 }
 
-// CreateLessonRequest represents a request to create a lesson
-type CreateLessonRequest struct {
-	Slug         string `json:"slug"`
-	CourseID     int    `json:"courseId"`
-	Title        string `json:"title"`
+// ContinueLessonResponse represents the next incomplete lesson of a user's enrolled courses
+type ContinueLessonResponse struct {
+	CourseID     int    `json:"-"`
+	LessonID     int    `json:"-"`
+	CourseSlug   string `json:"courseSlug"`
+	CourseTitle  string `json:"courseTitle"`
+	LessonSlug   string `json:"lessonSlug"`
+	LessonTitle  string `json:"lessonTitle"`
 	ShortSummary string `json:"shortSummary"`
 	Order        int    `json:"order"`
 }
 
+// CreateLessonRequest represents a request to create a lesson
+type CreateLessonRequest struct {
+	Slug            string `json:"slug"`
+	CourseID        int    `json:"courseId"`
+	Title           string `json:"title"`
+	ShortSummary    string `json:"shortSummary"`
+	Order           int    `json:"order"`
+	MinKanaMastery  int    `json:"minKanaMastery,omitempty"`
+	PrerequisiteIDs []int  `json:"prerequisiteIds,omitempty"`
+}
+
 // UpdateLessonRequest represents a request to update a lesson (partial update)
 type UpdateLessonRequest struct {
-	Slug         string `json:"slug,omitempty"`
-	CourseID     *int   `json:"courseId,omitempty"`
-	Title        string `json:"title,omitempty"`
-	ShortSummary string `json:"shortSummary,omitempty"`
-	Order        *int   `json:"order,omitempty"`
+	Slug            string `json:"slug,omitempty"`
+	CourseID        *int   `json:"courseId,omitempty"`
+	Title           string `json:"title,omitempty"`
+	ShortSummary    string `json:"shortSummary,omitempty"`
+	Order           *int   `json:"order,omitempty"`
+	MinKanaMastery  *int   `json:"minKanaMastery,omitempty"`
+	PrerequisiteIDs []int  `json:"prerequisiteIds,omitempty"` // Replaces the prerequisites if not nil, an empty list removes them
 }
 
 // LessonShortInfo represents a lesson with only ID and Title (for select options)
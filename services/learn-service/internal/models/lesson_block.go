@@ -11,6 +11,7 @@ const (
 	BlockTypeText     BlockType = "text"
 	BlockTypeDocument BlockType = "document"
 	BlockTypeList     BlockType = "list"
+	BlockTypeQuiz     BlockType = "quiz"
 )
 
 // LessonBlock represents a block within a lesson
@@ -22,12 +23,20 @@ type LessonBlock struct {
 	BlockData  json.RawMessage `json:"blockData"`
 }
 
+// TextBlockData represents data of a text lesson block
+//
+// Content may annotate kanji with readings as "{漢字|かんじ}".
+type TextBlockData struct {
+	Content string `json:"content"`
+}
+
 // LessonBlockResponse represents a lesson block in API responses
 type LessonBlockResponse struct {
 	ID         int             `json:"id,omitempty"`
 	BlockType  BlockType       `json:"blockType"`
 	BlockOrder int             `json:"blockOrder"`
 	BlockData  json.RawMessage `json:"blockData"`
+	Ruby       *RubyText       `json:"ruby,omitempty"` // Content of a text block with furigana shown according to the user's settings
 }
 
 // CreateLessonBlockRequest represents a request to create a lesson block
@@ -0,0 +1,59 @@
+package models
+
+import "encoding/json"
+
+// QuizQuestionType represents the kind of a question of a quiz lesson block
+type QuizQuestionType string
+
+const (
+	QuizQuestionTypeSingleChoice   QuizQuestionType = "single_choice"   // Exactly one option is correct
+	QuizQuestionTypeMultipleChoice QuizQuestionType = "multiple_choice" // One or more options are correct
+	QuizQuestionTypeFillBlank      QuizQuestionType = "fill_blank"      // The answer is typed and compared with kana normalization
+	QuizQuestionTypeOrdering       QuizQuestionType = "ordering"        // The options are put in the correct order
+)
+
+// QuizBlockData represents data of a quiz lesson block
+type QuizBlockData struct {
+	Questions    []QuizQuestion `json:"questions"`
+	PassingScore int            `json:"passingScore,omitempty"` // Percent of correct answers required to complete the lesson, 0 if not required
+}
+
+// QuizQuestion represents a question of a quiz lesson block
+//
+// CorrectOptions and Answers are hidden from users before the block data is returned.
+type QuizQuestion struct {
+	Type           QuizQuestionType `json:"type"`
+	Question       string           `json:"question"`
+	Options        []string         `json:"options,omitempty"`        // Options of choice and ordering questions, ordering options are shuffled for users
+	CorrectOptions []int            `json:"correctOptions,omitempty"` // Indexes of correct options, or of all options in the correct order for ordering questions
+	Answers        []string         `json:"answers,omitempty"`        // Accepted answers of fill-in-the-blank questions
+}
+
+// QuizAnswer represents an answer of a user to a quiz question
+type QuizAnswer struct {
+	Options []int    `json:"options,omitempty"` // Indexes of chosen options of choice questions
+	Order   []string `json:"order,omitempty"`   // All options of an ordering question in the chosen order
+	Text    string   `json:"text,omitempty"`    // Answer to a fill-in-the-blank question
+}
+
+// SubmitQuizRequest represents a request to submit answers to a quiz lesson block
+type SubmitQuizRequest struct {
+	Answers []QuizAnswer `json:"answers"` // Answers in the order of the questions
+}
+
+// QuizResult represents the result of a submitted quiz
+type QuizResult struct {
+	Score        int    `json:"score"` // Percent of correct answers rounded down
+	PassingScore int    `json:"passingScore"`
+	Passed       bool   `json:"passed"`
+	Correct      []bool `json:"correct"` // Correctness of answers in the order of the questions
+}
+
+// LessonQuizAttempt represents a graded attempt of a user at a quiz lesson block
+type LessonQuizAttempt struct {
+	ID      int             `json:"id"`
+	UserID  int             `json:"userId"`
+	BlockID int             `json:"blockId"`
+	Score   int             `json:"score"`
+	Answers json.RawMessage `json:"answers"`
+}
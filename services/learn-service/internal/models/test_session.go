@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// TestSession represents a kana test issued to a user
+//
+// The session keeps correct answers on the server, so the submitted answers can be graded without trusting the client.
+type TestSession struct {
+	ID           string            `json:"id"`
+	UserID       int               `json:"userId"`
+	AlphabetType string            `json:"alphabetType"` // "hiragana" or "katakana"
+	TestType     string            `json:"testType"`     // "reading", "writing", "listening" or "matching"
+	Items        []TestSessionItem `json:"items"`
+	ExpiresAt    time.Time         `json:"expiresAt"`
+	SubmittedAt  *time.Time        `json:"submittedAt,omitempty"`
+}
+
+// TestSessionItem represents a single question of a test session with its correct answer
+type TestSessionItem struct {
+	CharacterID   int    `json:"characterId"`
+	CorrectAnswer string `json:"correctAnswer"` // Character for reading, listening and matching tests, reading for writing tests
+}
+
+// ReadingTestSession represents a reading test returned to the client
+type ReadingTestSession struct {
+	SessionID string            `json:"sessionId"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	Items     []ReadingTestItem `json:"items"`
+}
+
+// WritingTestSession represents a writing test returned to the client
+type WritingTestSession struct {
+	SessionID string            `json:"sessionId"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	Items     []WritingTestItem `json:"items"`
+}
+
+// ListeningTestSession represents a listening test returned to the client
+type ListeningTestSession struct {
+	SessionID string              `json:"sessionId"`
+	ExpiresAt time.Time           `json:"expiresAt"`
+	Items     []ListeningTestItem `json:"items"`
+}
+
+// MatchingTestSession represents a matching test returned to the client
+type MatchingTestSession struct {
+	SessionID string             `json:"sessionId"`
+	ExpiresAt time.Time          `json:"expiresAt"`
+	Items     []MatchingTestItem `json:"items"`
+}
+
+// TestSessionAnswer represents a raw answer given by the user for a test session item
+type TestSessionAnswer struct {
+	CharacterID    int    `json:"characterId"`
+	Answer         string `json:"answer"`                   // Chosen character for reading, listening and matching tests, typed reading for writing tests
+	ResponseTimeMs int    `json:"responseTimeMs,omitempty"` // Answer time in milliseconds (optional)
+}
+
+// GradedAnswer represents the result of grading a single answer
+type GradedAnswer struct {
+	CharacterID   int    `json:"characterId"`
+	Passed        bool   `json:"passed"`
+	CorrectAnswer string `json:"correctAnswer"`
+}
+
+// SubmitTestSessionResult represents the result of submitting a test session
+type SubmitTestSessionResult struct {
+	AskForRepeat bool           `json:"askForRepeat"`
+	Results      []GradedAnswer `json:"results"`
+}
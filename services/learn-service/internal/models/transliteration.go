@@ -0,0 +1,15 @@
+package models
+
+// TransliterationSegment represents a part of a transliterated text aligned with the source it was produced from
+type TransliterationSegment struct {
+	Source string `json:"source"` // Part of the source text, e.g. "きょう"
+	Target string `json:"target"` // Converted part, e.g. "kyō"
+}
+
+// TransliterationResponse represents a transliterated text
+type TransliterationResponse struct {
+	From     string                   `json:"from"` // Source script
+	To       string                   `json:"to"`   // Target script
+	Text     string                   `json:"text"` // Converted text, equal to joined segment targets
+	Segments []TransliterationSegment `json:"segments"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// UserDeck represents a named deck of custom words created by a user
+type UserDeck struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"userId"`
+	Name      string    `json:"name"`
+	WordCount int       `json:"wordCount"` // Number of custom words in the deck
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// UserWord represents a custom word saved by a user to their personal dictionary
+type UserWord struct {
+	ID                 int       `json:"id"`
+	UserID             int       `json:"userId"`
+	DeckID             *int      `json:"deckId"` // Nil for words outside of any deck
+	Word               string    `json:"word"`
+	PhoneticClues      string    `json:"phoneticClues"`
+	Translation        string    `json:"translation"` // Written by the user in any language
+	Example            string    `json:"example"`
+	ExampleTranslation string    `json:"exampleTranslation"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+// CreateUserDeckRequest represents a request to create a custom deck
+type CreateUserDeckRequest struct {
+	Name string `json:"name"`
+}
+
+// UpdateUserDeckRequest represents a request to rename a custom deck
+type UpdateUserDeckRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateUserWordRequest represents a request to save a custom word
+type CreateUserWordRequest struct {
+	DeckID             *int   `json:"deckId,omitempty"`
+	Word               string `json:"word"`
+	PhoneticClues      string `json:"phoneticClues"`
+	Translation        string `json:"translation"`
+	Example            string `json:"example"`
+	ExampleTranslation string `json:"exampleTranslation"`
+}
+
+// UpdateUserWordRequest represents a request to update a custom word (partial update)
+type UpdateUserWordRequest struct {
+	DeckID             *int   `json:"deckId,omitempty"` // 0 removes the word from its deck
+	Word               string `json:"word,omitempty"`
+	PhoneticClues      string `json:"phoneticClues,omitempty"`
+	Translation        string `json:"translation,omitempty"`
+	Example            string `json:"example,omitempty"`
+	ExampleTranslation string `json:"exampleTranslation,omitempty"`
+}
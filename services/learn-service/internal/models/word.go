@@ -2,22 +2,67 @@ package models
 
 // Word represents a Japanese word in the dictionary
 type Word struct {
-	ID                        int    `json:"id"`
-	Word                      string `json:"word"`          // Kanji word
-	PhoneticClues             string `json:"phoneticClues"` // Hiragana reading
-	RussianTranslation        string `json:"russianTranslation"`
-	EnglishTranslation        string `json:"englishTranslation"`
-	GermanTranslation         string `json:"germanTranslation"`
-	Example                   string `json:"example"` // Japanese sentence
-	ExampleRussianTranslation string `json:"exampleRussianTranslation"`
-	ExampleEnglishTranslation string `json:"exampleEnglishTranslation"`
-	ExampleGermanTranslation  string `json:"exampleGermanTranslation"`
-	EasyPeriod                int    `json:"easyPeriod"`      // Days
-	NormalPeriod              int    `json:"normalPeriod"`    // Days
-	HardPeriod                int    `json:"hardPeriod"`      // Days
-	ExtraHardPeriod           int    `json:"extraHardPeriod"` // Days
-	WordAudio                 string `json:"wordAudio"`       // URL to word audio metadata on media server
-	WordExampleAudio          string `json:"wordExampleAudio"` // URL to word example audio metadata on media server
+	ID               int                        `json:"id"`
+	Word             string                     `json:"word"`             // Kanji word
+	PhoneticClues    string                     `json:"phoneticClues"`    // Hiragana reading
+	Example          string                     `json:"example"`          // Japanese sentence
+	Translations     map[string]WordTranslation `json:"translations"`     // Translations of the word and its example by language code
+	EasyPeriod       int                        `json:"easyPeriod"`       // Days
+	NormalPeriod     int                        `json:"normalPeriod"`     // Days
+	HardPeriod       int                        `json:"hardPeriod"`       // Days
+	ExtraHardPeriod  int                        `json:"extraHardPeriod"`  // Days
+	WordAudio        string                     `json:"wordAudio"`        // URL to word audio metadata on media server
+	WordExampleAudio string                     `json:"wordExampleAudio"` // URL to word example audio metadata on media server
+
+	JLPTLevel    int      `json:"jlptLevel"`    // JLPT level from 1 (N1) to 5 (N5), 0 if the word is not classified
+	PartOfSpeech string   `json:"partOfSpeech"` // Please reference PartOfSpeech constants, empty if the word is not classified
+	Tags         []string `json:"tags"`         // Topic tags such as "food" or "travel"
+}
+
+// WordTranslation represents a translation of a word and its example sentence into a single language
+type WordTranslation struct {
+	Translation        string `json:"translation"`
+	ExampleTranslation string `json:"exampleTranslation"`
+}
+
+// Parts of speech of a word
+const (
+	PartOfSpeechNoun         = "noun"
+	PartOfSpeechVerb         = "verb"
+	PartOfSpeechIAdjective   = "i-adjective"
+	PartOfSpeechNaAdjective  = "na-adjective"
+	PartOfSpeechAdverb       = "adverb"
+	PartOfSpeechPronoun      = "pronoun"
+	PartOfSpeechParticle     = "particle"
+	PartOfSpeechCounter      = "counter"
+	PartOfSpeechConjunction  = "conjunction"
+	PartOfSpeechInterjection = "interjection"
+	PartOfSpeechExpression   = "expression"
+)
+
+// WordTag represents a topic tag with the number of words it is attached to
+type WordTag struct {
+	Tag       string `json:"tag"`
+	WordCount int    `json:"wordCount"`
+}
+
+// WordFilter restricts new words introduced to the user
+//
+// Empty fields do not restrict words. A word must have one of the levels and one of the tags.
+type WordFilter struct {
+	JLPTLevels    []int
+	Tags          []string
+	WithWordAudio bool // Only words with word audio, used by listening quizzes
+}
+
+// WordSearchResult represents a word found by search with a locale-specific translation
+type WordSearchResult struct {
+	ID            int    `json:"id"`
+	Word          string `json:"word"`
+	PhoneticClues string `json:"phoneticClues"`
+	Translation   string `json:"translation"` // Locale-specific word translation
+	JLPTLevel     int    `json:"jlptLevel"`   // 0 if the level is not set
+	PartOfSpeech  string `json:"partOfSpeech"`
 }
 
 // WordResponse represents a word in API responses with locale-specific translations
@@ -32,54 +77,65 @@ type WordResponse struct {
 	NormalPeriod       int    `json:"normalPeriod"`
 	HardPeriod         int    `json:"hardPeriod"`
 	ExtraHardPeriod    int    `json:"extraHardPeriod"`
-	WordAudio          string `json:"wordAudio"`       // URL to word audio metadata on media server
+	WordAudio          string `json:"wordAudio"`        // URL to word audio metadata on media server
 	WordExampleAudio   string `json:"wordExampleAudio"` // URL to word example audio metadata on media server
+	ExampleSource      string `json:"exampleSource"`    // Source of the example sentence, empty if unknown
+	IsCustom           bool   `json:"isCustom"`         // The word is saved by the user, ID refers to their custom words
+	// Example with furigana shown according to the user's settings, set only for examples with ruby markup.
+	// "example" contains the same text without readings.
+	ExampleRuby *RubyText `json:"exampleRuby,omitempty"`
 }
 
+// Review grades of a word, from forgotten to remembered without effort
+const (
+	ReviewGradeAgain = 1 // The word was forgotten
+	ReviewGradeHard  = 2 // Remembered with serious difficulty
+	ReviewGradeGood  = 3 // Remembered after some hesitation
+	ReviewGradeEasy  = 4 // Remembered immediately
+)
+
 // WordResult represents a word learning result submission
 type WordResult struct {
-	WordID int `json:"wordId"`
-	Period int `json:"period"` // Days (1-30)
+	WordID   int  `json:"wordId"`
+	IsCustom bool `json:"isCustom"` // WordID refers to a custom word of the user
+	Grade    int  `json:"grade"`    // Review grade (1-4), please reference ReviewGrade constants
 }
 
 // WordListItem represents a word in the list response
 type WordListItem struct {
-	ID                 int    `json:"id"`
-	Word               string `json:"word"`
-	PhoneticClues      string `json:"phoneticClues"`
-	EnglishTranslation string `json:"englishTranslation"`
+	ID            int    `json:"id"`
+	Word          string `json:"word"`
+	PhoneticClues string `json:"phoneticClues"`
+	Translation   string `json:"translation"` // Translation in the default locale
 }
 
 // CreateWordRequest represents a request to create a word
 type CreateWordRequest struct {
-	Word                      string `json:"word"`
-	PhoneticClues             string `json:"phoneticClues"`
-	RussianTranslation        string `json:"russianTranslation"`
-	EnglishTranslation        string `json:"englishTranslation"`
-	GermanTranslation         string `json:"germanTranslation"`
-	Example                   string `json:"example"`
-	ExampleRussianTranslation string `json:"exampleRussianTranslation"`
-	ExampleEnglishTranslation string `json:"exampleEnglishTranslation"`
-	ExampleGermanTranslation  string `json:"exampleGermanTranslation"`
-	EasyPeriod                int    `json:"easyPeriod"`
-	NormalPeriod              int    `json:"normalPeriod"`
-	HardPeriod                int    `json:"hardPeriod"`
-	ExtraHardPeriod           int    `json:"extraHardPeriod"`
+	Word            string                     `json:"word"`
+	PhoneticClues   string                     `json:"phoneticClues"`
+	Example         string                     `json:"example"`
+	Translations    map[string]WordTranslation `json:"translations"` // Translations by language code, the default locale is required
+	EasyPeriod      int                        `json:"easyPeriod"`
+	NormalPeriod    int                        `json:"normalPeriod"`
+	HardPeriod      int                        `json:"hardPeriod"`
+	ExtraHardPeriod int                        `json:"extraHardPeriod"`
+	JLPTLevel       int                        `json:"jlptLevel"`    // Optional, 0 if the word is not classified
+	PartOfSpeech    string                     `json:"partOfSpeech"` // Optional
+	Tags            []string                   `json:"tags"`         // Optional
 }
 
 // UpdateWordRequest represents a request to update a word (partial update)
 type UpdateWordRequest struct {
-	Word                      string `json:"word,omitempty"`
-	PhoneticClues             string `json:"phoneticClues,omitempty"`
-	RussianTranslation        string `json:"russianTranslation,omitempty"`
-	EnglishTranslation        string `json:"englishTranslation,omitempty"`
-	GermanTranslation         string `json:"germanTranslation,omitempty"`
-	Example                   string `json:"example,omitempty"`
-	ExampleRussianTranslation string `json:"exampleRussianTranslation,omitempty"`
-	ExampleEnglishTranslation string `json:"exampleEnglishTranslation,omitempty"`
-	ExampleGermanTranslation  string `json:"exampleGermanTranslation,omitempty"`
-	EasyPeriod                *int   `json:"easyPeriod,omitempty"`
-	NormalPeriod              *int   `json:"normalPeriod,omitempty"`
-	HardPeriod                *int   `json:"hardPeriod,omitempty"`
-	ExtraHardPeriod           *int   `json:"extraHardPeriod,omitempty"`
+	Word          string `json:"word,omitempty"`
+	PhoneticClues string `json:"phoneticClues,omitempty"`
+	Example       string `json:"example,omitempty"`
+	// Translations are set for the listed languages only, empty values leave the current translation unchanged
+	Translations    map[string]WordTranslation `json:"translations,omitempty"`
+	EasyPeriod      *int                       `json:"easyPeriod,omitempty"`
+	NormalPeriod    *int                       `json:"normalPeriod,omitempty"`
+	HardPeriod      *int                       `json:"hardPeriod,omitempty"`
+	ExtraHardPeriod *int                       `json:"extraHardPeriod,omitempty"`
+	JLPTLevel       *int                       `json:"jlptLevel,omitempty"`
+	PartOfSpeech    string                     `json:"partOfSpeech,omitempty"`
+	Tags            []string                   `json:"tags,omitempty"` // nil leaves tags unchanged, an empty list removes all tags
 }
@@ -0,0 +1,36 @@
+package models
+
+// WordExample represents an additional example sentence of a word
+//
+// The example stored in the Word itself is the primary one, additional examples are rotated with it during reviews.
+type WordExample struct {
+	ID           int               `json:"id"`
+	WordID       int               `json:"wordId"`
+	Example      string            `json:"example"`      // Japanese sentence
+	Translations map[string]string `json:"translations"` // Translations of the sentence by language code
+	ExampleAudio string            `json:"exampleAudio"` // URL to example audio metadata on media server
+	Source       string            `json:"source"`       // Optional source of the sentence such as a book or a film
+}
+
+// LocalizedWordExample represents an additional example sentence with a locale-specific translation
+type LocalizedWordExample struct {
+	WordID       int
+	Example      string
+	Translation  string
+	ExampleAudio string
+	Source       string
+}
+
+// CreateWordExampleRequest represents a request to create an example sentence of a word
+type CreateWordExampleRequest struct {
+	Example      string            `json:"example"`
+	Translations map[string]string `json:"translations"` // Translations by language code, the default locale is required
+	Source       string            `json:"source"`       // Optional
+}
+
+// UpdateWordExampleRequest represents a request to update an example sentence of a word (partial update)
+type UpdateWordExampleRequest struct {
+	Example      string            `json:"example,omitempty"`
+	Translations map[string]string `json:"translations,omitempty"` // Set for the listed languages only, empty values are ignored
+	Source       string            `json:"source,omitempty"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// WordImportFormat represents a format of an imported word file
+type WordImportFormat string
+
+const (
+	WordImportFormatAPKG WordImportFormat = "apkg" // Anki package with bundled media
+	WordImportFormatCSV  WordImportFormat = "csv"
+	WordImportFormatTSV  WordImportFormat = "tsv"
+)
+
+// WordImportStatus represents the status of a word import job
+type WordImportStatus string
+
+const (
+	WordImportStatusRunning   WordImportStatus = "Running"
+	WordImportStatusCompleted WordImportStatus = "Completed"
+	WordImportStatusFailed    WordImportStatus = "Failed" // The import stopped before all notes were handled
+)
+
+// WordImportMapping maps note fields of an imported file to Word columns
+//
+// Every value is a name of a note field or a column of a text file, empty values leave the column empty.
+// Translations map language codes to fields, so a file may bring translations into any language.
+// Audio fields are expected to contain [sound:...] references to media bundled into an Anki package.
+type WordImportMapping struct {
+	Word                string            `json:"word"`
+	PhoneticClues       string            `json:"phoneticClues"`
+	Example             string            `json:"example"`
+	Translations        map[string]string `json:"translations"`        // Fields of word translations by language code
+	ExampleTranslations map[string]string `json:"exampleTranslations"` // Fields of example translations by language code
+	WordAudio           string            `json:"wordAudio"`
+	WordExampleAudio    string            `json:"wordExampleAudio"`
+}
+
+// WordImportJob represents a background import of words with its progress and error report
+type WordImportJob struct {
+	ID              int               `json:"id"`
+	Filename        string            `json:"filename"`
+	Format          WordImportFormat  `json:"format"`
+	Status          WordImportStatus  `json:"status"`
+	Total           int               `json:"total"`     // Number of notes in the file
+	Processed       int               `json:"processed"` // Number of notes handled so far
+	Created         int               `json:"created"`   // Number of created words
+	Skipped         int               `json:"skipped"`   // Number of notes skipped as duplicates
+	Failed          int               `json:"failed"`    // Number of notes which could not be imported
+	Errors          []WordImportError `json:"errors"`
+	TruncatedErrors int               `json:"truncatedErrors"` // Number of errors left out of the report once it is full
+	CreatedAt       time.Time         `json:"createdAt"`
+	FinishedAt      *time.Time        `json:"finishedAt,omitempty"`
+}
+
+// WordImportError represents a problem with a single note of an import
+type WordImportError struct {
+	Row   int    `json:"row"` // 1-based number of the note in the file
+	Word  string `json:"word,omitempty"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// WordQuizMode represents the kind of questions of a vocabulary quiz
+type WordQuizMode string
+
+const (
+	WordQuizModeReverse   WordQuizMode = "reverse"   // The translation is shown, the word is chosen
+	WordQuizModeChoice    WordQuizMode = "choice"    // The word is shown, the translation is chosen
+	WordQuizModeListening WordQuizMode = "listening" // The word audio is played, the word is chosen
+)
+
+// IsValid checks if the quiz mode is one of the known modes
+func (m WordQuizMode) IsValid() bool {
+	switch m {
+	case WordQuizModeReverse, WordQuizModeChoice, WordQuizModeListening:
+		return true
+	}
+	return false
+}
+
+// WordQuizSession represents a vocabulary quiz issued to a user
+//
+// Please reference TestSession for more information about keeping correct answers on the server.
+type WordQuizSession struct {
+	ID          string                `json:"id"`
+	UserID      int                   `json:"userId"`
+	Mode        WordQuizMode          `json:"mode"`
+	Items       []WordQuizSessionItem `json:"items"`
+	ExpiresAt   time.Time             `json:"expiresAt"`
+	SubmittedAt *time.Time            `json:"submittedAt,omitempty"`
+}
+
+// WordQuizSessionItem represents a single question of a quiz session with its correct answer
+type WordQuizSessionItem struct {
+	WordID        int    `json:"wordId"`
+	CorrectAnswer string `json:"correctAnswer"` // Translation for choice quizzes, word for reverse and listening quizzes
+}
+
+// WordQuizItem represents a question of a vocabulary quiz
+//
+// Please reference ReadingTestItem for more information about hidden fields.
+type WordQuizItem struct {
+	WordID        int      `json:"wordId"`
+	Prompt        string   `json:"prompt,omitempty"`   // Word for choice quizzes, translation for reverse quizzes, empty for listening quizzes
+	AudioURL      string   `json:"audioUrl,omitempty"` // Word audio for listening quizzes
+	CorrectAnswer string   `json:"-"`
+	WrongOptions  []string `json:"-"`       // Words or translations of related words
+	Options       []string `json:"options"` // Correct and wrong answers in random order
+}
+
+// WordQuiz represents a vocabulary quiz returned to the client
+type WordQuiz struct {
+	SessionID string         `json:"sessionId"`
+	Mode      WordQuizMode   `json:"mode"`
+	ExpiresAt time.Time      `json:"expiresAt"`
+	Items     []WordQuizItem `json:"items"`
+}
+
+// WordQuizDistractor represents a word which may be offered as a wrong answer of a quiz question
+type WordQuizDistractor struct {
+	WordID      int    `json:"wordId"`
+	Word        string `json:"word"`
+	Translation string `json:"translation"` // Locale-specific word translation
+}
+
+// WordQuizAnswer represents an answer chosen by the user for a quiz question
+type WordQuizAnswer struct {
+	WordID int    `json:"wordId"`
+	Answer string `json:"answer"` // Chosen option
+}
+
+// GradedWordAnswer represents the result of grading a single quiz answer
+type GradedWordAnswer struct {
+	WordID        int    `json:"wordId"`
+	Passed        bool   `json:"passed"`
+	CorrectAnswer string `json:"correctAnswer"`
+}
@@ -43,7 +43,7 @@ func (r *characterLearnHistoryRepository) GetByUserIDAndCharacterIDs(ctx context
 	query := fmt.Sprintf(`
 		SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result,
 		       hiragana_listening_result, katakana_reading_result, katakana_writing_result,
-		       katakana_listening_result
+		       katakana_listening_result, hiragana_matching_result, katakana_matching_result
 		FROM character_learn_history
 		WHERE user_id = ? AND character_id IN (%s)`, strings.Join(charPlaceholders, ","))
 
@@ -66,6 +66,8 @@ func (r *characterLearnHistoryRepository) GetByUserIDAndCharacterIDs(ctx context
 			&history.KatakanaReadingResult,
 			&history.KatakanaWritingResult,
 			&history.KatakanaListeningResult,
+			&history.HiraganaMatchingResult,
+			&history.KatakanaMatchingResult,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan character learn history: %w", err)
@@ -90,6 +92,8 @@ func (r *characterLearnHistoryRepository) GetByUserID(ctx context.Context, userI
 		character_learn_history.katakana_reading_result,
 		character_learn_history.katakana_writing_result,
 		character_learn_history.katakana_listening_result,
+		character_learn_history.hiragana_matching_result,
+		character_learn_history.katakana_matching_result,
 		characters.hiragana,
 		characters.katakana
 		FROM character_learn_history
@@ -114,6 +118,8 @@ func (r *characterLearnHistoryRepository) GetByUserID(ctx context.Context, userI
 			&history.KatakanaReadingResult,
 			&history.KatakanaWritingResult,
 			&history.KatakanaListeningResult,
+			&history.HiraganaMatchingResult,
+			&history.KatakanaMatchingResult,
 			&history.CharacterHiragana,
 			&history.CharacterKatakana,
 		)
@@ -130,8 +136,10 @@ func (r *characterLearnHistoryRepository) GetByUserID(ctx context.Context, userI
 	return histories, nil
 }
 
-// Upsert inserts or updates a list of character learn history records
-func (r *characterLearnHistoryRepository) Upsert(ctx context.Context, histories []models.CharacterLearnHistory) error {
+// Upsert inserts or updates a list of character learn history records and stores test attempts which led to them
+//
+// Both are saved in one transaction, so the history never changes without its attempts being logged.
+func (r *characterLearnHistoryRepository) Upsert(ctx context.Context, histories []models.CharacterLearnHistory, attempts []models.CharacterTestAttempt) error {
 	if len(histories) == 0 {
 		return fmt.Errorf("no histories to upsert")
 	}
@@ -139,10 +147,11 @@ func (r *characterLearnHistoryRepository) Upsert(ctx context.Context, histories
 	charPlaceholders := make([]string, len(histories))
 	args := []any{}
 	for i := range charPlaceholders {
-		charPlaceholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?)"
+		charPlaceholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
 		args = append(args, histories[i].UserID, histories[i].CharacterID, histories[i].HiraganaReadingResult,
 			histories[i].HiraganaWritingResult, histories[i].HiraganaListeningResult, histories[i].KatakanaReadingResult,
-			histories[i].KatakanaWritingResult, histories[i].KatakanaListeningResult)
+			histories[i].KatakanaWritingResult, histories[i].KatakanaListeningResult, histories[i].HiraganaMatchingResult,
+			histories[i].KatakanaMatchingResult)
 	}
 
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -155,7 +164,7 @@ func (r *characterLearnHistoryRepository) Upsert(ctx context.Context, histories
 		INSERT INTO character_learn_history
 		(user_id, character_id, hiragana_reading_result, hiragana_writing_result,
 		 hiragana_listening_result, katakana_reading_result, katakana_writing_result,
-		 katakana_listening_result)
+		 katakana_listening_result, hiragana_matching_result, katakana_matching_result)
 		VALUES %s
 		ON DUPLICATE KEY UPDATE
 			hiragana_reading_result = VALUES(hiragana_reading_result),
@@ -163,13 +172,21 @@ func (r *characterLearnHistoryRepository) Upsert(ctx context.Context, histories
 			hiragana_listening_result = VALUES(hiragana_listening_result),
 			katakana_reading_result = VALUES(katakana_reading_result),
 			katakana_writing_result = VALUES(katakana_writing_result),
-			katakana_listening_result = VALUES(katakana_listening_result)
+			katakana_listening_result = VALUES(katakana_listening_result),
+			hiragana_matching_result = VALUES(hiragana_matching_result),
+			katakana_matching_result = VALUES(katakana_matching_result)
 	`, strings.Join(charPlaceholders, ","))
 
 	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
 		return fmt.Errorf("failed to upsert character learn history: %w", err)
 	}
 
+	if len(attempts) > 0 {
+		if err := insertTestAttempts(ctx, tx, attempts); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -189,7 +206,9 @@ func (r *characterLearnHistoryRepository) LowerResultsByUserID(ctx context.Conte
 			hiragana_listening_result = GREATEST(0, hiragana_listening_result - 0.01),
 			katakana_reading_result = GREATEST(0, katakana_reading_result - 0.01),
 			katakana_writing_result = GREATEST(0, katakana_writing_result - 0.01),
-			katakana_listening_result = GREATEST(0, katakana_listening_result - 0.01)
+			katakana_listening_result = GREATEST(0, katakana_listening_result - 0.01),
+			hiragana_matching_result = GREATEST(0, hiragana_matching_result - 0.01),
+			katakana_matching_result = GREATEST(0, katakana_matching_result - 0.01)
 		WHERE user_id = ?
 	`
 
@@ -201,3 +220,28 @@ func (r *characterLearnHistoryRepository) LowerResultsByUserID(ctx context.Conte
 	// Return nil even if no rows affected (user has no history - not an error)
 	return nil
 }
+
+// GetKanaMastery calculates the overall kana mastery of a user
+//
+// The mastery is the average of the six reading, writing and listening results over all
+// characters, the same categories used to decide whether a character is mastered. Matching
+// results are not included. Characters the user has never been tested on count as 0.
+// Returns a value from 0 to 1.
+func (r *characterLearnHistoryRepository) GetKanaMastery(ctx context.Context, userID int) (float32, error) {
+	query := `
+		SELECT COALESCE(AVG(COALESCE((
+			h.hiragana_reading_result + h.hiragana_writing_result + h.hiragana_listening_result +
+			h.katakana_reading_result + h.katakana_writing_result + h.katakana_listening_result
+		) / 6, 0)), 0)
+		FROM characters c
+		LEFT JOIN character_learn_history h ON h.character_id = c.id AND h.user_id = ?
+	`
+
+	var mastery float32
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&mastery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get kana mastery: %w", err)
+	}
+
+	return mastery, nil
+}
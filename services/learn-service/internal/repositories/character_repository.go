@@ -22,7 +22,10 @@ func NewCharactersRepository(db *sql.DB) *charactersRepository {
 }
 
 // Method GetAll is a CharactersRepository implementation for retrieving all hiragana/katakana characters from a database.
-func (r *charactersRepository) GetAll(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale) ([]models.CharacterResponse, error) {
+//
+// Readings are taken in the first language of the chain which has them.
+// If "groups" is empty, characters of all groups are returned.
+func (r *charactersRepository) GetAll(ctx context.Context, alphabetType models.AlphabetType, languages []string, groups []models.CharacterGroup) ([]models.CharacterResponse, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -33,25 +36,25 @@ func (r *charactersRepository) GetAll(ctx context.Context, alphabetType models.A
 		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
 	}
 
-	var readingField string
-	switch locale {
-	case models.LocaleEnglish:
-		readingField = "english_reading"
-	case models.LocaleRussian:
-		readingField = "russian_reading"
-	default:
-		return nil, fmt.Errorf("invalid locale: %s", locale)
+	reading, args := localizedReading(languages)
+
+	whereClause := ""
+	groupCondition, groupArgs := groupFilter("character_group", groups)
+	if groupCondition != "" {
+		whereClause = "WHERE " + groupCondition
+		args = append(args, groupArgs...)
 	}
 
 	// Query to retrieve all characters from the database
 	// Names of optional fields are specified in the parameters of the method
 	query := fmt.Sprintf(`
-		SELECT id, consonant, vowel, %s AS display_character, %s AS reading
+		SELECT id, consonant, vowel, character_group, %s AS display_character, %s AS reading
 		FROM characters
+		%s
 		ORDER BY id
-	`, charField, readingField)
+	`, charField, reading, whereClause)
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query characters: %w", err)
 	}
@@ -60,7 +63,7 @@ func (r *charactersRepository) GetAll(ctx context.Context, alphabetType models.A
 	var characters []models.CharacterResponse
 	for rows.Next() {
 		var char models.CharacterResponse
-		if err := rows.Scan(&char.ID, &char.Consonant, &char.Vowel, &char.Character, &char.Reading); err != nil {
+		if err := rows.Scan(&char.ID, &char.Consonant, &char.Vowel, &char.Group, &char.Character, &char.Reading); err != nil {
 			return nil, fmt.Errorf("failed to scan character: %w", err)
 		}
 		characters = append(characters, char)
@@ -74,7 +77,11 @@ func (r *charactersRepository) GetAll(ctx context.Context, alphabetType models.A
 }
 
 // GetByRowColumn retrieves characters filtered by consonant or vowel
-func (r *charactersRepository) GetByRowColumn(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, character string) ([]models.CharacterResponse, error) {
+//
+// Consonants of yōon characters consist of several letters ("ky", "sh"), vowels are always a single letter.
+// Readings are taken in the first language of the chain which has them.
+// If "groups" is empty, characters of all groups are returned.
+func (r *charactersRepository) GetByRowColumn(ctx context.Context, alphabetType models.AlphabetType, languages []string, character string, groups []models.CharacterGroup) ([]models.CharacterResponse, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -85,26 +92,25 @@ func (r *charactersRepository) GetByRowColumn(ctx context.Context, alphabetType
 		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
 	}
 
-	var readingField string
-	switch locale {
-	case models.LocaleEnglish:
-		readingField = "english_reading"
-	case models.LocaleRussian:
-		readingField = "russian_reading"
-	default:
-		return nil, fmt.Errorf("invalid locale: %s", locale)
+	reading, args := localizedReading(languages)
+
+	whereClause := "WHERE (consonant = ? OR vowel = ?)"
+	args = append(args, character, character)
+	if groupCondition, groupArgs := groupFilter("character_group", groups); groupCondition != "" {
+		whereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
 	}
 
 	// Check if character is a vowel or consonant and filter accordingly
 	// If it's a vowel, return consonant field; if consonant, return vowel field
 	query := fmt.Sprintf(`
-		SELECT id, consonant, vowel, %s AS display_character, %s AS reading
+		SELECT id, consonant, vowel, character_group, %s AS display_character, %s AS reading
 		FROM characters
-		WHERE (consonant = ? OR vowel = ?)
+		%s
 		ORDER BY id
-	`, charField, readingField)
+	`, charField, reading, whereClause)
 
-	rows, err := r.db.QueryContext(ctx, query, character, character)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query characters: %w", err)
 	}
@@ -114,7 +120,7 @@ func (r *charactersRepository) GetByRowColumn(ctx context.Context, alphabetType
 	for rows.Next() {
 		var char models.CharacterResponse
 		var consonant, vowel string
-		if err := rows.Scan(&char.ID, &consonant, &vowel, &char.Character, &char.Reading); err != nil {
+		if err := rows.Scan(&char.ID, &consonant, &vowel, &char.Group, &char.Character, &char.Reading); err != nil {
 			return nil, fmt.Errorf("failed to scan character: %w", err)
 		}
 		// Populate the field that matches the search parameter
@@ -142,33 +148,50 @@ func isVowel(char string) bool {
 	return slices.Contains(vowels, char)
 }
 
-// GetByID retrieves a character by its ID
-func (r *charactersRepository) GetByID(ctx context.Context, id int, locale models.Locale) (*models.Character, error) {
-	var readingField string
-	switch locale {
-	case models.LocaleEnglish:
-		readingField = "english_reading"
-	case models.LocaleRussian:
-		readingField = "russian_reading"
-	default:
-		return nil, fmt.Errorf("invalid locale: %s", locale)
+// groupFilter builds a condition limiting characters to the given groups
+//
+// "column" parameter is the name of the character group column (with table alias if needed).
+// If "groups" is empty, an empty condition and no arguments are returned.
+func groupFilter(column string, groups []models.CharacterGroup) (string, []any) {
+	if len(groups) == 0 {
+		return "", nil
 	}
 
+	placeholders := make([]string, len(groups))
+	args := make([]any, len(groups))
+	for i, group := range groups {
+		placeholders[i] = "?"
+		args[i] = string(group)
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ",")), args
+}
+
+// localizedReading returns an expression selecting the reading of a character in the first language of the chain
+// which has it together with arguments of its placeholders
+//
+// The expression refers to the "characters" table without an alias.
+func localizedReading(languages []string) (string, []any) {
+	return localizedColumn("character_readings", "reading", "character_id = characters.id", languages)
+}
+
+// GetByID retrieves a character by its ID with the reading in the first language of the chain which has it
+func (r *charactersRepository) GetByID(ctx context.Context, id int, languages []string) (*models.Character, error) {
+	reading, args := localizedReading(languages)
+
 	// Query to retrieve a character by its ID
-	// Reading field is retrieved based on the locale parameter.
 	query := fmt.Sprintf(`
-		SELECT id, consonant, vowel, %s as reading, katakana, hiragana
+		SELECT id, consonant, vowel, character_group, %s as reading, katakana, hiragana
 		FROM characters
 		WHERE id = ?
-	`, readingField)
+	`, reading)
 
 	var char models.Character
-	var reading string
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, append(args, id)...).Scan(
 		&char.ID,
 		&char.Consonant,
 		&char.Vowel,
-		&reading,
+		&char.Group,
+		&char.Reading,
 		&char.Katakana,
 		&char.Hiragana,
 	)
@@ -179,24 +202,18 @@ func (r *charactersRepository) GetByID(ctx context.Context, id int, locale model
 		return nil, fmt.Errorf("failed to query character: %w", err)
 	}
 
-	// Set the appropriate reading field
-	if locale == models.LocaleEnglish {
-		char.EnglishReading = reading
-	} else {
-		char.RussianReading = reading
-	}
-
 	return &char, nil
 }
 
 // GetCharactersForReadingTest retrieves characters for reading test with defined IDs
 //
 // "alphabetType" parameter is used to identify the alphabet type.
-// "locale" parameter is used to identify the locale.
+// "languages" parameter is the locale fallback chain, readings are taken in its first language which has them.
 // "characterIDs" parameter is used to identify the character IDs.
+// "groups" parameter is used to limit wrong options to the character groups of the test (all groups if empty).
 //
 // If some error will occur during data retrieval, the error will be returned together with "nil" value.
-func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.ReadingTestItem, error) {
+func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context, alphabetType models.AlphabetType, languages []string, characterIDs []int, groups []models.CharacterGroup) ([]models.ReadingTestItem, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -207,15 +224,7 @@ func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context,
 		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
 	}
 
-	var readingField string
-	switch locale {
-	case models.LocaleEnglish:
-		readingField = "english_reading"
-	case models.LocaleRussian:
-		readingField = "russian_reading"
-	default:
-		return nil, fmt.Errorf("invalid locale: %s", locale)
-	}
+	reading, readingArgs := localizedReading(languages)
 
 	// Prepare the query for IN clause
 	// The query is prepared for IN clause to avoid multiple queries.
@@ -231,9 +240,9 @@ func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context,
 		FROM characters
 		WHERE id IN (%s)
 		ORDER BY RAND()
-	`, charField, readingField, strings.Join(charPlaceholders, ","))
+	`, charField, reading, strings.Join(charPlaceholders, ","))
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.QueryContext(ctx, query, append(readingArgs, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query characters: %w", err)
 	}
@@ -254,13 +263,18 @@ func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context,
 	}
 
 	// Query to retrieve wrong options.
+	wrongWhereClause := fmt.Sprintf("WHERE id NOT IN (%s) AND %s IS NOT NULL AND %s != ''", strings.Join(charPlaceholders, ","), charField, charField)
+	if groupCondition, groupArgs := groupFilter("character_group", groups); groupCondition != "" {
+		wrongWhereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
+	}
 	wrongQuery := fmt.Sprintf(`
 		SELECT %s AS display_character
 		FROM characters
-		WHERE id NOT IN (%s) AND %s IS NOT NULL AND %s != ''
+		%s
 		ORDER BY RAND()
 		LIMIT ?
-		`, charField, strings.Join(charPlaceholders, ","), charField, charField)
+		`, charField, wrongWhereClause)
 	args = append(args, len(items)*2)
 	wrongRows, err := r.db.QueryContext(ctx, wrongQuery, args...)
 	if err != nil {
@@ -293,11 +307,11 @@ func (r *charactersRepository) GetCharactersForReadingTest(ctx context.Context,
 // GetCharactersForWritingTest retrieves characters for writing test with defined IDs
 //
 // "alphabetType" parameter is used to identify the alphabet type.
-// "locale" parameter is used to identify the locale.
+// "languages" parameter is the locale fallback chain, readings are taken in its first language which has them.
 // "characterIDs" parameter is used to identify the character IDs.
 //
 // If some error will occur during data retrieval, the error will be returned together with "nil" value.
-func (r *charactersRepository) GetCharactersForWritingTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.WritingTestItem, error) {
+func (r *charactersRepository) GetCharactersForWritingTest(ctx context.Context, alphabetType models.AlphabetType, languages []string, characterIDs []int) ([]models.WritingTestItem, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -308,31 +322,22 @@ func (r *charactersRepository) GetCharactersForWritingTest(ctx context.Context,
 		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
 	}
 
-	var readingField string
-	switch locale {
-	case models.LocaleEnglish:
-		readingField = "english_reading"
-	case models.LocaleRussian:
-		readingField = "russian_reading"
-	default:
-		return nil, fmt.Errorf("invalid locale: %s", locale)
-	}
+	reading, args := localizedReading(languages)
 
 	// Prepare the query for IN clause
 	// The query is prepared for IN clause to avoid multiple queries.
 	// Placeholders are transformed into "?, ?, ..., ?" string for slice insertion.
 	charPlaceholders := make([]string, len(characterIDs))
-	args := make([]any, len(characterIDs))
 	for i := range charPlaceholders {
 		charPlaceholders[i] = "?"
-		args[i] = characterIDs[i]
+		args = append(args, characterIDs[i])
 	}
 	query := fmt.Sprintf(`
 		SELECT DISTINCT id, %s AS display_character, %s AS reading
 		FROM characters
 		WHERE id IN (%s)
 		ORDER BY RAND()
-	`, charField, readingField, strings.Join(charPlaceholders, ","))
+	`, charField, reading, strings.Join(charPlaceholders, ","))
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -359,11 +364,11 @@ func (r *charactersRepository) GetCharactersForWritingTest(ctx context.Context,
 // GetCharactersForListeningTest retrieves characters for listening test with defined IDs
 //
 // "alphabetType" parameter is used to identify the alphabet type.
-// "locale" parameter is used to identify the locale.
 // "characterIDs" parameter is used to identify the character IDs.
+// "groups" parameter is used to limit wrong options to the character groups of the test (all groups if empty).
 //
 // If some error will occur during data retrieval, the error will be returned together with "nil" value.
-func (r *charactersRepository) GetCharactersForListeningTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.ListeningTestItem, error) {
+func (r *charactersRepository) GetCharactersForListeningTest(ctx context.Context, alphabetType models.AlphabetType, characterIDs []int, groups []models.CharacterGroup) ([]models.ListeningTestItem, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -411,13 +416,115 @@ func (r *charactersRepository) GetCharactersForListeningTest(ctx context.Context
 	}
 
 	// Query to retrieve wrong options.
+	wrongWhereClause := fmt.Sprintf("WHERE id NOT IN (%s) AND audio IS NOT NULL AND audio != ''", strings.Join(charPlaceholders, ","))
+	if groupCondition, groupArgs := groupFilter("character_group", groups); groupCondition != "" {
+		wrongWhereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
+	}
 	wrongQuery := fmt.Sprintf(`
 		SELECT %s AS display_character
 		FROM characters
-		WHERE id NOT IN (%s) AND audio IS NOT NULL AND audio != ''
+		%s
+		ORDER BY RAND()
+		LIMIT ?
+		`, charField, wrongWhereClause)
+	args = append(args, len(items)*2)
+	wrongRows, err := r.db.QueryContext(ctx, wrongQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query wrong options: %w", err)
+	}
+	defer wrongRows.Close()
+
+	for i := range items {
+		var wrongCharFirst, wrongCharSecond string
+		if hasNext, err := wrongRows.Next(), wrongRows.Scan(&wrongCharFirst); !hasNext || err != nil {
+			wrongRows.Close()
+			if !hasNext {
+				return nil, fmt.Errorf("failed to scan wrong option: %w", fmt.Errorf("no more rows"))
+			}
+			return nil, fmt.Errorf("failed to scan wrong option: %w", err)
+		}
+		if hasNext, err := wrongRows.Next(), wrongRows.Scan(&wrongCharSecond); !hasNext || err != nil {
+			wrongRows.Close()
+			if !hasNext {
+				return nil, fmt.Errorf("failed to scan wrong option: %w", fmt.Errorf("no more rows"))
+			}
+			return nil, fmt.Errorf("failed to scan wrong option: %w", err)
+		}
+		items[i].WrongOptions = append(items[i].WrongOptions, wrongCharFirst, wrongCharSecond)
+	}
+
+	return items, nil
+}
+
+// GetCharactersForMatchingTest retrieves characters for matching test with defined IDs
+//
+// The shown character belongs to the tested alphabet, the correct and wrong options belong to the other one.
+// "alphabetType" parameter is used to identify the alphabet of the shown character.
+// "characterIDs" parameter is used to identify the character IDs.
+// "groups" parameter is used to limit wrong options to the character groups of the test (all groups if empty).
+//
+// If some error will occur during data retrieval, the error will be returned together with "nil" value.
+func (r *charactersRepository) GetCharactersForMatchingTest(ctx context.Context, alphabetType models.AlphabetType, characterIDs []int, groups []models.CharacterGroup) ([]models.MatchingTestItem, error) {
+	var charField, optionField string
+	switch alphabetType {
+	case models.AlphabetTypeHiragana:
+		charField, optionField = "hiragana", "katakana"
+	case models.AlphabetTypeKatakana:
+		charField, optionField = "katakana", "hiragana"
+	default:
+		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
+	}
+
+	// Prepare the query for IN clause
+	// The query is prepared for IN clause to avoid multiple queries.
+	// Placeholders are transformed into "?, ?, ..., ?" string for slice insertion.
+	charPlaceholders := make([]string, len(characterIDs))
+	args := make([]any, len(characterIDs))
+	for i := range charPlaceholders {
+		charPlaceholders[i] = "?"
+		args[i] = characterIDs[i]
+	}
+	query := fmt.Sprintf(`
+		SELECT DISTINCT id, %s AS display_character, %s AS option_character
+		FROM characters
+		WHERE id IN (%s) AND %s IS NOT NULL AND %s != ''
+		ORDER BY RAND()
+	`, charField, optionField, strings.Join(charPlaceholders, ","), optionField, optionField)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query characters: %w", err)
+	}
+	defer rows.Close()
+
+	var items []models.MatchingTestItem
+	for rows.Next() {
+		var testItem models.MatchingTestItem
+		if err := rows.Scan(&testItem.ID, &testItem.Character, &testItem.CorrectChar); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		testItem.WrongOptions = make([]string, 0, 2)
+		items = append(items, testItem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	// Query to retrieve wrong options from the other alphabet.
+	wrongWhereClause := fmt.Sprintf("WHERE id NOT IN (%s) AND %s IS NOT NULL AND %s != ''", strings.Join(charPlaceholders, ","), optionField, optionField)
+	if groupCondition, groupArgs := groupFilter("character_group", groups); groupCondition != "" {
+		wrongWhereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
+	}
+	wrongQuery := fmt.Sprintf(`
+		SELECT %s AS option_character
+		FROM characters
+		%s
 		ORDER BY RAND()
 		LIMIT ?
-		`, charField, strings.Join(charPlaceholders, ","))
+		`, optionField, wrongWhereClause)
 	args = append(args, len(items)*2)
 	wrongRows, err := r.db.QueryContext(ctx, wrongQuery, args...)
 	if err != nil {
@@ -450,7 +557,7 @@ func (r *charactersRepository) GetCharactersForListeningTest(ctx context.Context
 // GetAllForAdmin retrieves all characters ordered by ID for admin endpoints
 func (r *charactersRepository) GetAllForAdmin(ctx context.Context) ([]models.Character, error) {
 	query := `
-		SELECT id, consonant, vowel, katakana, hiragana
+		SELECT id, consonant, vowel, character_group, katakana, hiragana
 		FROM characters
 		ORDER BY id
 	`
@@ -468,6 +575,7 @@ func (r *charactersRepository) GetAllForAdmin(ctx context.Context) ([]models.Cha
 			&char.ID,
 			&char.Consonant,
 			&char.Vowel,
+			&char.Group,
 			&char.Katakana,
 			&char.Hiragana,
 		)
@@ -484,10 +592,10 @@ func (r *charactersRepository) GetAllForAdmin(ctx context.Context) ([]models.Cha
 	return characters, nil
 }
 
-// GetByID retrieves a character by ID
+// GetByIDAdmin retrieves a character by ID with readings in all languages
 func (r *charactersRepository) GetByIDAdmin(ctx context.Context, id int) (*models.Character, error) {
 	query := `
-		SELECT consonant, vowel, english_reading, russian_reading, katakana, hiragana, audio
+		SELECT consonant, vowel, character_group, katakana, hiragana, audio
 		FROM characters
 		WHERE id = ?
 		LIMIT 1
@@ -498,8 +606,7 @@ func (r *charactersRepository) GetByIDAdmin(ctx context.Context, id int) (*model
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&char.Consonant,
 		&char.Vowel,
-		&char.EnglishReading,
-		&char.RussianReading,
+		&char.Group,
 		&char.Katakana,
 		&char.Hiragana,
 		&audio,
@@ -516,9 +623,54 @@ func (r *charactersRepository) GetByIDAdmin(ctx context.Context, id int) (*model
 	if audio.Valid {
 		char.Audio = audio.String
 	}
+
+	char.Readings, err = r.getReadings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 	return char, nil
 }
 
+// getReadings retrieves readings of a character in all languages
+func (r *charactersRepository) getReadings(ctx context.Context, characterID int) (map[string]string, error) {
+	query := `
+		SELECT language, reading
+		FROM character_readings
+		WHERE character_id = ?
+		ORDER BY language
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query character readings: %w", err)
+	}
+	defer rows.Close()
+
+	readings := make(map[string]string)
+	for rows.Next() {
+		var language, reading string
+		if err := rows.Scan(&language, &reading); err != nil {
+			return nil, fmt.Errorf("failed to scan character reading: %w", err)
+		}
+		readings[language] = reading
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return readings, nil
+}
+
+// upsertReadings writes readings of a character, empty values keep the current ones
+func upsertReadings(ctx context.Context, exec execer, characterID int, readings map[string]string) error {
+	values := make(map[string][]string, len(readings))
+	for language, reading := range readings {
+		values[language] = []string{reading}
+	}
+	return upsertTranslations(ctx, exec, "character_readings", "character_id", characterID, []string{"reading"}, values)
+}
+
 // ExistsByVowelConsonant checks if a character with the given vowel and consonant exists
 func (r *charactersRepository) ExistsByVowelConsonant(ctx context.Context, vowel, consonant string) (bool, error) {
 	query := `SELECT EXISTS(SELECT * FROM characters WHERE vowel = ? AND consonant = ?)`
@@ -559,11 +711,11 @@ func (r *charactersRepository) ExistsByKatakanaOrHiragana(ctx context.Context, k
 	return exists, nil
 }
 
-// Create inserts a new character into the database
+// Create inserts a new character with its readings into the database
 func (r *charactersRepository) Create(ctx context.Context, character *models.Character) error {
 	query := `
-		INSERT INTO characters (consonant, vowel, english_reading, russian_reading, katakana, hiragana, audio)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO characters (consonant, vowel, character_group, katakana, hiragana, audio)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	var audioValue interface{}
@@ -573,11 +725,22 @@ func (r *charactersRepository) Create(ctx context.Context, character *models.Cha
 		audioValue = character.Audio
 	}
 
-	result, err := r.db.ExecContext(ctx, query,
+	// Characters without explicit group are basic ones
+	group := character.Group
+	if group == "" {
+		group = models.CharacterGroupBasic
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
 		character.Consonant,
 		character.Vowel,
-		character.EnglishReading,
-		character.RussianReading,
+		group,
 		character.Katakana,
 		character.Hiragana,
 		audioValue,
@@ -591,11 +754,21 @@ func (r *charactersRepository) Create(ctx context.Context, character *models.Cha
 		return fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	if err := upsertReadings(ctx, tx, int(id), character.Readings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	character.ID = int(id)
 	return nil
 }
 
 // Update updates character fields (partial update)
+//
+// Readings are written for the listed languages only, the other languages are left unchanged.
 func (r *charactersRepository) Update(ctx context.Context, id int, character *models.Character) error {
 	// Build dynamic UPDATE query based on provided fields
 	var setParts []string
@@ -609,13 +782,9 @@ func (r *charactersRepository) Update(ctx context.Context, id int, character *mo
 		setParts = append(setParts, "vowel = ?")
 		args = append(args, character.Vowel)
 	}
-	if character.EnglishReading != "" {
-		setParts = append(setParts, "english_reading = ?")
-		args = append(args, character.EnglishReading)
-	}
-	if character.RussianReading != "" {
-		setParts = append(setParts, "russian_reading = ?")
-		args = append(args, character.RussianReading)
+	if character.Group != "" {
+		setParts = append(setParts, "character_group = ?")
+		args = append(args, character.Group)
 	}
 	if character.Katakana != "" {
 		setParts = append(setParts, "katakana = ?")
@@ -630,33 +799,35 @@ func (r *charactersRepository) Update(ctx context.Context, id int, character *mo
 		args = append(args, character.Audio)
 	}
 
-	if len(setParts) == 0 {
+	if len(setParts) == 0 && len(character.Readings) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
 
-	query := fmt.Sprintf(`
-		UPDATE characters
-		SET %s
-		WHERE id = ?
-	`, strings.Join(setParts, ", "))
+	if len(setParts) > 0 {
+		query := fmt.Sprintf(`
+			UPDATE characters
+			SET %s
+			WHERE id = ?
+		`, strings.Join(setParts, ", "))
 
-	args = append(args, id)
+		args = append(args, id)
 
-	result, err := r.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update character: %w", err)
-	}
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update character: %w", err)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("character not found")
+		if rowsAffected == 0 {
+			return fmt.Errorf("character not found")
+		}
 	}
 
-	return nil
+	return upsertReadings(ctx, r.db, id, character.Readings)
 }
 
 // Delete deletes a character by ID
@@ -681,7 +852,9 @@ func (r *charactersRepository) Delete(ctx context.Context, id int) error {
 }
 
 // GetCharactersWithoutHistory retrieves characters that don't have CharacterLearnHistory records for the user and specific test type
-func (r *charactersRepository) GetCharactersWithoutHistory(ctx context.Context, userID int, alphabetType models.AlphabetType, count int) ([]int, error) {
+//
+// If "groups" is empty, characters of all groups are considered.
+func (r *charactersRepository) GetCharactersWithoutHistory(ctx context.Context, userID int, alphabetType models.AlphabetType, groups []models.CharacterGroup, count int) ([]int, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -692,16 +865,24 @@ func (r *charactersRepository) GetCharactersWithoutHistory(ctx context.Context,
 		return nil, fmt.Errorf("invalid alphabet type: %s", alphabetType)
 	}
 
+	whereClause := fmt.Sprintf("WHERE c.%s IS NOT NULL AND c.%s != '' AND clh.id IS NULL", charField, charField)
+	args := []any{userID}
+	if groupCondition, groupArgs := groupFilter("c.character_group", groups); groupCondition != "" {
+		whereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
+	}
+	args = append(args, count)
+
 	query := fmt.Sprintf(`
 		SELECT c.id
 		FROM characters c
 		LEFT JOIN character_learn_history clh ON c.id = clh.character_id AND clh.user_id = ?
-		WHERE c.%s IS NOT NULL AND c.%s != '' AND clh.id IS NULL
+		%s
 		ORDER BY RAND()
 		LIMIT ?
-	`, charField, charField)
+	`, whereClause)
 
-	rows, err := r.db.QueryContext(ctx, query, userID, count)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query characters without history: %w", err)
 	}
@@ -723,10 +904,14 @@ func (r *charactersRepository) GetCharactersWithoutHistory(ctx context.Context,
 	return characterIDs, nil
 }
 
-// GetCharactersWithLowestResults retrieves characters with lowest result values for the user and specific test type
+// GetCharactersWithLowestResults retrieves characters with lowest mastery scores for the user and specific test type
+//
+// Characters with equal scores are returned in random order, so learners do not get the same set on every test.
 // testTypeResultField should be one of: "hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
-// "katakana_reading_result", "katakana_writing_result", "katakana_listening_result"
-func (r *charactersRepository) GetCharactersWithLowestResults(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, count int) ([]int, error) {
+// "hiragana_matching_result", "katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+// "katakana_matching_result"
+// If "groups" is empty, characters of all groups are considered.
+func (r *charactersRepository) GetCharactersWithLowestResults(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, groups []models.CharacterGroup, count int) ([]int, error) {
 	var charField string
 	switch alphabetType {
 	case models.AlphabetTypeHiragana:
@@ -745,21 +930,31 @@ func (r *charactersRepository) GetCharactersWithLowestResults(ctx context.Contex
 		"katakana_reading_result",
 		"katakana_writing_result",
 		"katakana_listening_result",
+		"hiragana_matching_result",
+		"katakana_matching_result",
 	}
 	if !slices.Contains(validFields, testTypeResultField) {
 		return nil, fmt.Errorf("invalid test type result field: %s", testTypeResultField)
 	}
 
+	whereClause := fmt.Sprintf("WHERE c.%s IS NOT NULL AND c.%s != ''", charField, charField)
+	args := []any{userID}
+	if groupCondition, groupArgs := groupFilter("c.character_group", groups); groupCondition != "" {
+		whereClause += " AND " + groupCondition
+		args = append(args, groupArgs...)
+	}
+	args = append(args, count)
+
 	query := fmt.Sprintf(`
 		SELECT c.id
 		FROM characters c
 		INNER JOIN character_learn_history clh ON c.id = clh.character_id AND clh.user_id = ?
-		WHERE c.%s IS NOT NULL AND c.%s != ''
-		ORDER BY clh.%s ASC
+		%s
+		ORDER BY clh.%s ASC, RAND()
 		LIMIT ?
-	`, charField, charField, testTypeResultField)
+	`, whereClause, testTypeResultField)
 
-	rows, err := r.db.QueryContext(ctx, query, userID, count)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query characters with lowest results: %w", err)
 	}
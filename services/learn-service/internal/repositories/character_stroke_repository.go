@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// characterStrokeRepository implements CharacterStrokeRepository
+type characterStrokeRepository struct {
+	db *sql.DB
+}
+
+// NewCharacterStrokeRepository creates a new character stroke repository
+func NewCharacterStrokeRepository(db *sql.DB) *characterStrokeRepository {
+	return &characterStrokeRepository{
+		db: db,
+	}
+}
+
+// GetByCharacterID retrieves reference strokes of a character in stroke order
+//
+// If the character has no strokes for the alphabet type, an empty slice is returned.
+func (r *characterStrokeRepository) GetByCharacterID(ctx context.Context, characterID int, alphabetType string) ([]models.CharacterStroke, error) {
+	query := `
+		SELECT character_id, alphabet_type, stroke_number, path
+		FROM character_strokes
+		WHERE character_id = ? AND alphabet_type = ?
+		ORDER BY stroke_number
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, characterID, alphabetType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query character strokes: %w", err)
+	}
+	defer rows.Close()
+
+	strokes := []models.CharacterStroke{}
+	for rows.Next() {
+		var stroke models.CharacterStroke
+		if err := rows.Scan(&stroke.CharacterID, &stroke.AlphabetType, &stroke.StrokeNumber, &stroke.Path); err != nil {
+			return nil, fmt.Errorf("failed to scan character stroke: %w", err)
+		}
+		strokes = append(strokes, stroke)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating character strokes: %w", err)
+	}
+
+	return strokes, nil
+}
+
+// ReplaceForCharacter replaces all strokes of a character for the alphabet type
+//
+// Old strokes are deleted and new ones are inserted in a single transaction.
+func (r *characterStrokeRepository) ReplaceForCharacter(ctx context.Context, characterID int, alphabetType string, strokes []models.CharacterStroke) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM character_strokes WHERE character_id = ? AND alphabet_type = ?`, characterID, alphabetType); err != nil {
+		return fmt.Errorf("failed to delete character strokes: %w", err)
+	}
+
+	if len(strokes) > 0 {
+		placeholders := make([]string, len(strokes))
+		args := make([]any, 0, len(strokes)*4)
+		for i, stroke := range strokes {
+			placeholders[i] = "(?, ?, ?, ?)"
+			args = append(args, characterID, alphabetType, stroke.StrokeNumber, stroke.Path)
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO character_strokes (character_id, alphabet_type, stroke_number, path)
+			VALUES %s
+		`, strings.Join(placeholders, ","))
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert character strokes: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupStrokeTestRepository creates a character stroke repository with a mock database
+func setupStrokeTestRepository(t *testing.T) (*characterStrokeRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewCharacterStrokeRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewCharacterStrokeRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewCharacterStrokeRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestCharacterStrokeRepository_GetByCharacterID(t *testing.T) {
+	columns := []string{"character_id", "alphabet_type", "stroke_number", "path"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(6, "hiragana", 1, "M31,30c10,1,20,1,30,-1").
+					AddRow(6, "hiragana", 2, "M60,20c-5,20,-10,40,-20,60").
+					AddRow(6, "hiragana", 3, "M70,50c5,5,10,10,12,20")
+				mock.ExpectQuery(`SELECT character_id, alphabet_type, stroke_number, path FROM character_strokes WHERE character_id = \? AND alphabet_type = \? ORDER BY stroke_number`).
+					WithArgs(6, "hiragana").
+					WillReturnRows(rows)
+			},
+			expectedCount: 3,
+		},
+		{
+			name: "no strokes",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM character_strokes`).
+					WithArgs(6, "hiragana").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM character_strokes`).
+					WithArgs(6, "hiragana").
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupStrokeTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			strokes, err := repo.GetByCharacterID(context.Background(), 6, "hiragana")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, strokes)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, strokes, tt.expectedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCharacterStrokeRepository_ReplaceForCharacter(t *testing.T) {
+	strokes := []models.CharacterStroke{
+		{StrokeNumber: 1, Path: "M31,30c10,1,20,1,30,-1"},
+		{StrokeNumber: 2, Path: "M60,20c-5,20,-10,40,-20,60"},
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM character_strokes WHERE character_id = \? AND alphabet_type = \?`).
+					WithArgs(6, "katakana").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(`INSERT INTO character_strokes \(character_id, alphabet_type, stroke_number, path\) VALUES \(\?, \?, \?, \?\),\(\?, \?, \?, \?\)`).
+					WithArgs(6, "katakana", 1, strokes[0].Path, 6, "katakana", 2, strokes[1].Path).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "insert error rolls back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM character_strokes`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO character_strokes`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupStrokeTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.ReplaceForCharacter(context.Background(), 6, "katakana", strokes)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
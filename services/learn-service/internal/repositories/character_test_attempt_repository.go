@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// characterTestAttemptRepository implements CharacterTestAttemptRepository
+type characterTestAttemptRepository struct {
+	db *sql.DB
+}
+
+// NewCharacterTestAttemptRepository creates a new character test attempt repository
+func NewCharacterTestAttemptRepository(db *sql.DB) *characterTestAttemptRepository {
+	return &characterTestAttemptRepository{
+		db: db,
+	}
+}
+
+// insertTestAttempts inserts a list of test attempts
+func insertTestAttempts(ctx context.Context, exec execer, attempts []models.CharacterTestAttempt) error {
+	placeholders := make([]string, len(attempts))
+	args := []any{}
+	for i, attempt := range attempts {
+		placeholders[i] = "(?, ?, ?, ?, ?, ?, ?)"
+
+		// Optional fields are stored as NULL when not provided
+		var chosenOption, responseTime any
+		if attempt.ChosenOption != "" {
+			chosenOption = attempt.ChosenOption
+		}
+		if attempt.ResponseTimeMs > 0 {
+			responseTime = attempt.ResponseTimeMs
+		}
+		args = append(args, attempt.UserID, attempt.CharacterID, attempt.AlphabetType, attempt.TestType,
+			attempt.Passed, chosenOption, responseTime)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO character_test_attempts
+		(user_id, character_id, alphabet_type, test_type, passed, chosen_option, response_time_ms)
+		VALUES %s
+	`, strings.Join(placeholders, ","))
+
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to create character test attempts: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves a paginated timeline of test attempts for a user, newest first
+//
+// If "characterID" is 0, attempts for all characters are returned.
+func (r *characterTestAttemptRepository) GetByUserID(ctx context.Context, userID, characterID, page, count int) ([]models.CharacterTestAttempt, error) {
+	whereClause := "WHERE a.user_id = ?"
+	args := []any{userID}
+	if characterID > 0 {
+		whereClause += " AND a.character_id = ?"
+		args = append(args, characterID)
+	}
+
+	// Calculate offset
+	offset := (page - 1) * count
+	args = append(args, count, offset)
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.character_id,
+		       CASE a.alphabet_type WHEN 'hiragana' THEN c.hiragana ELSE c.katakana END AS display_character,
+		       a.alphabet_type, a.test_type, a.passed, a.chosen_option, a.response_time_ms, a.created_at
+		FROM character_test_attempts a
+		JOIN characters c ON c.id = a.character_id
+		%s
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT ? OFFSET ?
+	`, whereClause)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query character test attempts: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []models.CharacterTestAttempt{}
+	for rows.Next() {
+		var attempt models.CharacterTestAttempt
+		var chosenOption sql.NullString
+		var responseTime sql.NullInt64
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.CharacterID,
+			&attempt.Character,
+			&attempt.AlphabetType,
+			&attempt.TestType,
+			&attempt.Passed,
+			&chosenOption,
+			&responseTime,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan character test attempt: %w", err)
+		}
+		if chosenOption.Valid {
+			attempt.ChosenOption = chosenOption.String
+		}
+		if responseTime.Valid {
+			attempt.ResponseTimeMs = int(responseTime.Int64)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// GetAccuracyByDay retrieves per-day accuracy of a user for every practised character during the last "days" days
+//
+// If "characterID" is 0, accuracy for all characters is returned.
+func (r *characterTestAttemptRepository) GetAccuracyByDay(ctx context.Context, userID, characterID, days int) ([]models.CharacterAccuracyPoint, error) {
+	whereClause := "WHERE a.user_id = ? AND a.created_at >= DATE_SUB(CURDATE(), INTERVAL ? DAY)"
+	args := []any{userID, days - 1}
+	if characterID > 0 {
+		whereClause += " AND a.character_id = ?"
+		args = append(args, characterID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT a.character_id,
+		       CASE a.alphabet_type WHEN 'hiragana' THEN c.hiragana ELSE c.katakana END AS display_character,
+		       a.alphabet_type, DATE(a.created_at) AS attempt_date,
+		       COUNT(*) AS attempts, SUM(a.passed) AS passed_attempts,
+		       COALESCE(AVG(a.response_time_ms), 0) AS average_response_time
+		FROM character_test_attempts a
+		JOIN characters c ON c.id = a.character_id
+		%s
+		GROUP BY a.character_id, display_character, a.alphabet_type, attempt_date
+		ORDER BY attempt_date ASC, a.character_id ASC
+	`, whereClause)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query character accuracy: %w", err)
+	}
+	defer rows.Close()
+
+	points := []models.CharacterAccuracyPoint{}
+	for rows.Next() {
+		var point models.CharacterAccuracyPoint
+		var date time.Time
+		err := rows.Scan(
+			&point.CharacterID,
+			&point.Character,
+			&point.AlphabetType,
+			&date,
+			&point.Attempts,
+			&point.PassedAttempts,
+			&point.AverageResponseTimeMs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan character accuracy: %w", err)
+		}
+		point.Date = date.Format(time.DateOnly)
+		if point.Attempts > 0 {
+			point.Accuracy = float64(point.PassedAttempts) / float64(point.Attempts)
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return points, nil
+}
+
+// GetMistakenOptions retrieves wrong options chosen by a user instead of the characters, the most frequent mistake first
+//
+// Only reading and listening attempts are considered, as their chosen options are characters of the tested alphabet.
+func (r *characterTestAttemptRepository) GetMistakenOptions(ctx context.Context, userID int, alphabetType string, characterIDs []int) (map[int][]string, error) {
+	mistakes := make(map[int][]string)
+	if len(characterIDs) == 0 {
+		return mistakes, nil
+	}
+
+	// Prepare the query for IN clause
+	args := []any{userID, alphabetType}
+	placeholders := make([]string, len(characterIDs))
+	for i := range placeholders {
+		placeholders[i] = "?"
+		args = append(args, characterIDs[i])
+	}
+
+	query := fmt.Sprintf(`
+		SELECT character_id, chosen_option, COUNT(*) AS mistakes
+		FROM character_test_attempts
+		WHERE user_id = ? AND alphabet_type = ? AND passed = FALSE AND chosen_option IS NOT NULL
+		  AND test_type IN ('reading', 'listening') AND character_id IN (%s)
+		GROUP BY character_id, chosen_option
+		ORDER BY character_id ASC, mistakes DESC, chosen_option ASC
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query mistaken options: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var characterID, count int
+		var chosenOption string
+		if err := rows.Scan(&characterID, &chosenOption, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan mistaken option: %w", err)
+		}
+		mistakes[characterID] = append(mistakes[characterID], chosenOption)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return mistakes, nil
+}
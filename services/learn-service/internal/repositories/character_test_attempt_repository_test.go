@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCharacterTestAttemptTestRepository creates a character test attempt repository with a mock database
+func setupCharacterTestAttemptTestRepository(t *testing.T) (*characterTestAttemptRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewCharacterTestAttemptRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewCharacterTestAttemptRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewCharacterTestAttemptRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestCharacterTestAttemptRepository_GetByUserID(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "character_id", "display_character", "alphabet_type", "test_type", "passed", "chosen_option", "response_time_ms", "created_at"}
+
+	tests := []struct {
+		name          string
+		characterID   int
+		page          int
+		count         int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		validate      func(*testing.T, []models.CharacterTestAttempt)
+	}{
+		{
+			name:  "success all characters",
+			page:  2,
+			count: 10,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(2, 5, "お", "hiragana", "reading", false, "あ", 2100, createdAt).
+					AddRow(1, 5, "オ", "katakana", "listening", true, nil, nil, createdAt)
+				mock.ExpectQuery(`SELECT .+ FROM character_test_attempts a JOIN characters c ON c.id = a.character_id WHERE a.user_id = \? ORDER BY a.created_at DESC, a.id DESC LIMIT \? OFFSET \?`).
+					WithArgs(1, 10, 10).
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, attempts []models.CharacterTestAttempt) {
+				require.Len(t, attempts, 2)
+				assert.Equal(t, "あ", attempts[0].ChosenOption)
+				assert.Equal(t, 2100, attempts[0].ResponseTimeMs)
+				assert.Empty(t, attempts[1].ChosenOption)
+				assert.Zero(t, attempts[1].ResponseTimeMs)
+				assert.Equal(t, createdAt, attempts[1].CreatedAt)
+			},
+		},
+		{
+			name:        "success filtered by character",
+			characterID: 5,
+			page:        1,
+			count:       50,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE a.user_id = \? AND a.character_id = \?`).
+					WithArgs(1, 5, 50, 0).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			validate: func(t *testing.T, attempts []models.CharacterTestAttempt) {
+				assert.NotNil(t, attempts)
+				assert.Len(t, attempts, 0)
+			},
+		},
+		{
+			name:  "database error",
+			page:  1,
+			count: 50,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM character_test_attempts`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCharacterTestAttemptTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			attempts, err := repo.GetByUserID(context.Background(), 1, tt.characterID, tt.page, tt.count)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, attempts)
+			} else {
+				assert.NoError(t, err)
+				tt.validate(t, attempts)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCharacterTestAttemptRepository_GetAccuracyByDay(t *testing.T) {
+	columns := []string{"character_id", "display_character", "alphabet_type", "attempt_date", "attempts", "passed_attempts", "average_response_time"}
+
+	tests := []struct {
+		name          string
+		characterID   int
+		days          int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		validate      func(*testing.T, []models.CharacterAccuracyPoint)
+	}{
+		{
+			name:        "success",
+			characterID: 3,
+			days:        7,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(3, "う", "hiragana", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 4, 1, 2500.0).
+					AddRow(3, "う", "hiragana", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), 4, 3, 1500.0)
+				mock.ExpectQuery(`FROM character_test_attempts a JOIN characters c ON c.id = a.character_id WHERE a.user_id = \? AND a.created_at >= DATE_SUB\(CURDATE\(\), INTERVAL \? DAY\) AND a.character_id = \? GROUP BY`).
+					WithArgs(1, 6, 3).
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, points []models.CharacterAccuracyPoint) {
+				require.Len(t, points, 2)
+				assert.Equal(t, "2026-01-01", points[0].Date)
+				assert.InDelta(t, 0.25, points[0].Accuracy, 0.0001)
+				assert.InDelta(t, 0.75, points[1].Accuracy, 0.0001)
+				assert.Equal(t, 1500.0, points[1].AverageResponseTimeMs)
+			},
+		},
+		{
+			name: "database error",
+			days: 30,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM character_test_attempts`).
+					WithArgs(1, 29).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCharacterTestAttemptTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			points, err := repo.GetAccuracyByDay(context.Background(), 1, tt.characterID, tt.days)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, points)
+			} else {
+				assert.NoError(t, err)
+				tt.validate(t, points)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCharacterTestAttemptRepository_GetMistakenOptions(t *testing.T) {
+	tests := []struct {
+		name          string
+		characterIDs  []int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      map[int][]string
+	}{
+		{
+			name:         "success",
+			characterIDs: []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"character_id", "chosen_option", "mistakes"}).
+					AddRow(1, "ツ", 3).
+					AddRow(1, "ソ", 1).
+					AddRow(2, "ン", 2)
+				mock.ExpectQuery(`FROM character_test_attempts WHERE user_id = \? AND alphabet_type = \? AND passed = FALSE AND chosen_option IS NOT NULL AND test_type IN \('reading', 'listening'\) AND character_id IN \(\?,\?\) GROUP BY character_id, chosen_option ORDER BY character_id ASC, mistakes DESC`).
+					WithArgs(1, "katakana", 1, 2).
+					WillReturnRows(rows)
+			},
+			expected: map[int][]string{1: {"ツ", "ソ"}, 2: {"ン"}},
+		},
+		{
+			name:         "empty character IDs",
+			characterIDs: []int{},
+			setupMock:    func(mock sqlmock.Sqlmock) {},
+			expected:     map[int][]string{},
+		},
+		{
+			name:         "database error",
+			characterIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM character_test_attempts`).
+					WithArgs(1, "katakana", 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCharacterTestAttemptTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			mistakes, err := repo.GetMistakenOptions(context.Background(), 1, "katakana", tt.characterIDs)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, mistakes)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, mistakes)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
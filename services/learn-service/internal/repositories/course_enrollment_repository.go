@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+)
+
+type courseEnrollmentRepository struct {
+	db *sql.DB
+}
+
+// NewCourseEnrollmentRepository creates a new course enrollment repository
+func NewCourseEnrollmentRepository(db *sql.DB) *courseEnrollmentRepository {
+	return &courseEnrollmentRepository{
+		db: db,
+	}
+}
+
+// Create enrolls a user in a course
+//
+// Returns false if the user is already enrolled in the course.
+func (r *courseEnrollmentRepository) Create(ctx context.Context, userID, courseID int) (bool, error) {
+	query := `
+		INSERT IGNORE INTO course_enrollments (user_id, course_id)
+		VALUES (?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, courseID)
+	if err != nil {
+		return false, fmt.Errorf("failed to create enrollment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete unenrolls a user from a course
+func (r *courseEnrollmentRepository) Delete(ctx context.Context, userID, courseID int) error {
+	query := `
+		DELETE FROM course_enrollments
+		WHERE user_id = ? AND course_id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, userID, courseID)
+	if err != nil {
+		return fmt.Errorf("failed to delete enrollment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("enrollment not found")
+	}
+
+	return nil
+}
+
+// TouchActivity updates the last activity time of a user in a course
+//
+// The user is enrolled in the course if they are not enrolled yet.
+func (r *courseEnrollmentRepository) TouchActivity(ctx context.Context, userID, courseID int) error {
+	query := `
+		INSERT INTO course_enrollments (user_id, course_id)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE last_activity_at = CURRENT_TIMESTAMP
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID, courseID)
+	if err != nil {
+		return fmt.Errorf("failed to update enrollment activity: %w", err)
+	}
+
+	return nil
+}
+
+// GetIncompleteLessons retrieves incomplete lessons in the enrolled courses of a user
+//
+// Lessons are grouped by course, courses with the most recent activity come first and lessons of a course are sorted by order.
+// If the user has no incomplete lessons in the enrolled courses, an empty slice is returned.
+func (r *courseEnrollmentRepository) GetIncompleteLessons(ctx context.Context, userID int) ([]models.ContinueLessonResponse, error) {
+	query := `
+		SELECT c.id, c.slug, c.title, l.id, l.slug, l.title, l.short_summary, l.` + "`order`" + `
+		FROM course_enrollments ce
+		INNER JOIN courses c ON c.id = ce.course_id
+		INNER JOIN lessons l ON l.course_id = ce.course_id
+		LEFT JOIN lesson_user_history luh ON luh.lesson_id = l.id AND luh.user_id = ce.user_id AND luh.course_id = ce.course_id
+		WHERE ce.user_id = ? AND luh.id IS NULL
+		ORDER BY ce.last_activity_at DESC, ce.id DESC, l.` + "`order`" + `, l.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incomplete lessons: %w", err)
+	}
+	defer rows.Close()
+
+	lessons := []models.ContinueLessonResponse{}
+	for rows.Next() {
+		var lesson models.ContinueLessonResponse
+		if err := rows.Scan(
+			&lesson.CourseID,
+			&lesson.CourseSlug,
+			&lesson.CourseTitle,
+			&lesson.LessonID,
+			&lesson.LessonSlug,
+			&lesson.LessonTitle,
+			&lesson.ShortSummary,
+			&lesson.Order,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan incomplete lesson: %w", err)
+		}
+		lessons = append(lessons, lesson)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return lessons, nil
+}
@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCourseEnrollmentTestRepository creates a course enrollment repository with a mock database
+func setupCourseEnrollmentTestRepository(t *testing.T) (*courseEnrollmentRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewCourseEnrollmentRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewCourseEnrollmentRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewCourseEnrollmentRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestCourseEnrollmentRepository_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expected      bool
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT IGNORE INTO course_enrollments \(user_id, course_id\)`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expected: true,
+		},
+		{
+			name: "already enrolled",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT IGNORE INTO course_enrollments`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expected: false,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT IGNORE INTO course_enrollments`).
+					WithArgs(1, 2).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCourseEnrollmentTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			created, err := repo.Create(context.Background(), 1, 2)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "failed to create enrollment")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, created)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCourseEnrollmentRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM course_enrollments.*WHERE user_id = \? AND course_id = \?`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "enrollment not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM course_enrollments`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			errorContains: "enrollment not found",
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM course_enrollments`).
+					WithArgs(1, 2).
+					WillReturnError(errors.New("database error"))
+			},
+			errorContains: "failed to delete enrollment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCourseEnrollmentTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Delete(context.Background(), 1, 2)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCourseEnrollmentRepository_TouchActivity(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := setupCourseEnrollmentTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectExec(`(?s)INSERT INTO course_enrollments \(user_id, course_id\).*ON DUPLICATE KEY UPDATE last_activity_at = CURRENT_TIMESTAMP`).
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.TouchActivity(context.Background(), 1, 2)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, cleanup := setupCourseEnrollmentTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectExec(`INSERT INTO course_enrollments`).
+			WithArgs(1, 2).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.TouchActivity(context.Background(), 1, 2)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update enrollment activity")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCourseEnrollmentRepository_GetIncompleteLessons(t *testing.T) {
+	query := `(?s)SELECT c\.id, c\.slug, c\.title, l\.id, l\.slug, l\.title, l\.short_summary.*FROM course_enrollments ce.*LEFT JOIN lesson_user_history luh.*WHERE ce\.user_id = \? AND luh\.id IS NULL.*ORDER BY ce\.last_activity_at DESC`
+	columns := []string{"id", "slug", "title", "id", "slug", "title", "short_summary", "order"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expected      []models.ContinueLessonResponse
+		errorContains string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "course-1", "Course 1", 12, "lesson-2", "Lesson 2", "Summary", 2).
+					AddRow(1, "course-1", "Course 1", 13, "lesson-3", "Lesson 3", "", 3)
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expected: []models.ContinueLessonResponse{
+				{
+					CourseID:     1,
+					LessonID:     12,
+					CourseSlug:   "course-1",
+					CourseTitle:  "Course 1",
+					LessonSlug:   "lesson-2",
+					LessonTitle:  "Lesson 2",
+					ShortSummary: "Summary",
+					Order:        2,
+				},
+				{
+					CourseID:    1,
+					LessonID:    13,
+					CourseSlug:  "course-1",
+					CourseTitle: "Course 1",
+					LessonSlug:  "lesson-3",
+					LessonTitle: "Lesson 3",
+					Order:       3,
+				},
+			},
+		},
+		{
+			name: "no incomplete lesson",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expected: []models.ContinueLessonResponse{},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			errorContains: "failed to get incomplete lessons",
+		},
+		{
+			name: "scan error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("invalid", "course-1", "Course 1", 12, "lesson-2", "Lesson 2", "Summary", 2)
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			errorContains: "failed to scan incomplete lesson",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCourseEnrollmentTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetIncompleteLessons(context.Background(), 1)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
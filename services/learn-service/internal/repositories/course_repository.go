@@ -28,24 +28,32 @@ func (r *courseRepository) GetBySlug(ctx context.Context, slug string, userID in
 			c.short_summary,
 			c.title,
 			c.complexity_level,
+			c.navigation,
 			COUNT(DISTINCT l.id) as total_lessons,
-			COUNT(DISTINCT luh.lesson_id) as completed_lessons
+			COUNT(DISTINCT luh.lesson_id) as completed_lessons,
+			ce.enrolled_at,
+			ce.last_activity_at
 		FROM courses c
 		LEFT JOIN lessons l ON l.course_id = c.id
 		LEFT JOIN lesson_user_history luh ON luh.course_id = c.id AND luh.user_id = ? AND luh.lesson_id = l.id
+		LEFT JOIN course_enrollments ce ON ce.course_id = c.id AND ce.user_id = ?
 		WHERE c.slug = ?
-		GROUP BY c.id, c.slug, c.title, c.complexity_level
+		GROUP BY c.id, c.slug, c.title, c.complexity_level, c.navigation, ce.enrolled_at, ce.last_activity_at
 		LIMIT 1
 	`
 
 	var course models.CourseDetailResponse
-	err := r.db.QueryRowContext(ctx, query, userID, slug).Scan(
+	var enrolledAt, lastActivityAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, userID, userID, slug).Scan(
 		&course.ID,
 		&course.ShortSummary,
 		&course.Title,
 		&course.ComplexityLevel,
+		&course.Navigation,
 		&course.TotalLessons,
 		&course.CompletedLessons,
+		&enrolledAt,
+		&lastActivityAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -55,13 +63,14 @@ func (r *courseRepository) GetBySlug(ctx context.Context, slug string, userID in
 		return nil, fmt.Errorf("failed to get course by slug: %w", err)
 	}
 
+	setEnrollment(&course, enrolledAt, lastActivityAt)
 	return &course, nil
 }
 
 // GetByID retrieves a course by its ID
 func (r *courseRepository) GetByID(ctx context.Context, id int) (*models.Course, error) {
 	query := `
-		SELECT id, slug, author_id, title, short_summary, complexity_level
+		SELECT id, slug, author_id, title, short_summary, complexity_level, navigation
 		FROM courses
 		WHERE id = ?
 		LIMIT 1
@@ -75,6 +84,7 @@ func (r *courseRepository) GetByID(ctx context.Context, id int) (*models.Course,
 		&course.Title,
 		&course.ShortSummary,
 		&course.ComplexityLevel,
+		&course.Navigation,
 	)
 
 	if err == sql.ErrNoRows {
@@ -87,19 +97,30 @@ func (r *courseRepository) GetByID(ctx context.Context, id int) (*models.Course,
 	return &course, nil
 }
 
+// GetNavigation retrieves the navigation mode of a course
+func (r *courseRepository) GetNavigation(ctx context.Context, id int) (models.CourseNavigation, error) {
+	query := "SELECT navigation FROM courses WHERE id = ?"
+
+	var navigation models.CourseNavigation
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&navigation)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("course not found")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get course navigation: %w", err)
+	}
+
+	return navigation, nil
+}
+
 // GetAll retrieves courses with filtering and pagination
 func (r *courseRepository) GetAll(ctx context.Context, userID int, complexityLevel *models.ComplexityLevel, search string, isMine bool, page, count int) ([]models.CourseDetailResponse, error) {
 	var whereClauses []string
-	args := []any{userID}
+	args := []any{userID, userID}
 
 	// Build WHERE clause
 	if isMine {
-		whereClauses = append(whereClauses, `EXISTS (
-			SELECT 1 FROM lesson_user_history 
-			WHERE lesson_user_history.course_id = c.id 
-			AND lesson_user_history.user_id = ?
-		)`)
-		args = append(args, userID)
+		whereClauses = append(whereClauses, "ce.id IS NOT NULL")
 	}
 
 	if complexityLevel != nil {
@@ -126,12 +147,15 @@ func (r *courseRepository) GetAll(ctx context.Context, userID int, complexityLev
 			c.title,
 			c.complexity_level,
 			COUNT(DISTINCT l.id) as total_lessons,
-			COUNT(DISTINCT luh.lesson_id) as completed_lessons
+			COUNT(DISTINCT luh.lesson_id) as completed_lessons,
+			ce.enrolled_at,
+			ce.last_activity_at
 		FROM courses c
 		LEFT JOIN lessons l ON l.course_id = c.id
 		LEFT JOIN lesson_user_history luh ON luh.course_id = c.id AND luh.user_id = ? AND luh.lesson_id = l.id
+		LEFT JOIN course_enrollments ce ON ce.course_id = c.id AND ce.user_id = ?
 		%s
-		GROUP BY c.id, c.slug, c.title, c.complexity_level
+		GROUP BY c.id, c.slug, c.title, c.complexity_level, ce.enrolled_at, ce.last_activity_at
 		ORDER BY c.id
 		LIMIT ? OFFSET ?
 	`, whereClause)
@@ -147,16 +171,20 @@ func (r *courseRepository) GetAll(ctx context.Context, userID int, complexityLev
 	var courses []models.CourseDetailResponse
 	for rows.Next() {
 		var course models.CourseDetailResponse
+		var enrolledAt, lastActivityAt sql.NullTime
 		err := rows.Scan(
 			&course.Slug,
 			&course.Title,
 			&course.ComplexityLevel,
 			&course.TotalLessons,
 			&course.CompletedLessons,
+			&enrolledAt,
+			&lastActivityAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan course: %w", err)
 		}
+		setEnrollment(&course, enrolledAt, lastActivityAt)
 		courses = append(courses, course)
 	}
 
@@ -288,8 +316,8 @@ func (r *courseRepository) GetShortInfo(ctx context.Context, authorID *int) ([]m
 // Create creates a new course
 func (r *courseRepository) Create(ctx context.Context, course *models.Course) error {
 	query := `
-		INSERT INTO courses (slug, author_id, title, short_summary, complexity_level)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO courses (slug, author_id, title, short_summary, complexity_level, navigation)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -298,6 +326,7 @@ func (r *courseRepository) Create(ctx context.Context, course *models.Course) er
 		course.Title,
 		course.ShortSummary,
 		course.ComplexityLevel,
+		course.Navigation,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create course: %w", err)
@@ -333,6 +362,10 @@ func (r *courseRepository) Update(ctx context.Context, course *models.Course) er
 		setParts = append(setParts, "complexity_level = ?")
 		args = append(args, course.ComplexityLevel)
 	}
+	if course.Navigation != "" {
+		setParts = append(setParts, "navigation = ?")
+		args = append(args, course.Navigation)
+	}
 	if course.AuthorID != 0 {
 		setParts = append(setParts, "author_id = ?")
 		args = append(args, course.AuthorID)
@@ -420,3 +453,15 @@ func (r *courseRepository) ExistsByTitle(ctx context.Context, title string) (boo
 	}
 	return exists, nil
 }
+
+// setEnrollment sets enrollment fields of a course from nullable enrollment columns
+func setEnrollment(course *models.CourseDetailResponse, enrolledAt, lastActivityAt sql.NullTime) {
+	if !enrolledAt.Valid {
+		return
+	}
+	course.IsEnrolled = true
+	course.EnrolledAt = &enrolledAt.Time
+	if lastActivityAt.Valid {
+		course.LastActivityAt = &lastActivityAt.Time
+	}
+}
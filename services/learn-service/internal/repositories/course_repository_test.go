@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
@@ -37,6 +38,8 @@ func TestNewCourseRepository(t *testing.T) {
 }
 
 func TestCourseRepository_GetBySlug(t *testing.T) {
+	enrolledAt := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name          string
 		slug          string
@@ -50,10 +53,10 @@ func TestCourseRepository_GetBySlug(t *testing.T) {
 			slug:   "test-course",
 			userID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "short_summary", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow(1, "Summary", "Test Course", "Beginner", 10, 5)
-				mock.ExpectQuery(`SELECT.*FROM courses c.*WHERE c.slug = \?`).
-					WithArgs(1, "test-course").
+				rows := sqlmock.NewRows([]string{"id", "short_summary", "title", "complexity_level", "navigation", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow(1, "Summary", "Test Course", "Beginner", "sequential", 10, 5, enrolledAt, enrolledAt)
+				mock.ExpectQuery(`SELECT.*FROM courses c.*LEFT JOIN course_enrollments ce.*WHERE c.slug = \?`).
+					WithArgs(1, 1, "test-course").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -64,7 +67,7 @@ func TestCourseRepository_GetBySlug(t *testing.T) {
 			userID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(`SELECT.*FROM courses c.*WHERE c.slug = \?`).
-					WithArgs(1, "nonexistent").
+					WithArgs(1, 1, "nonexistent").
 					WillReturnError(sql.ErrNoRows)
 			},
 			expectedError: true,
@@ -76,7 +79,7 @@ func TestCourseRepository_GetBySlug(t *testing.T) {
 			userID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(`SELECT.*FROM courses c.*WHERE c.slug = \?`).
-					WithArgs(1, "test-course").
+					WithArgs(1, 1, "test-course").
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -87,10 +90,10 @@ func TestCourseRepository_GetBySlug(t *testing.T) {
 			slug:   "test-course",
 			userID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "short_summary", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("invalid", "Summary", "Test Course", "Beginner", 10, 5)
+				rows := sqlmock.NewRows([]string{"id", "short_summary", "title", "complexity_level", "navigation", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("invalid", "Summary", "Test Course", "Beginner", "sequential", 10, 5, nil, nil)
 				mock.ExpectQuery(`SELECT.*FROM courses c.*WHERE c.slug = \?`).
-					WithArgs(1, "test-course").
+					WithArgs(1, 1, "test-course").
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -118,6 +121,10 @@ func TestCourseRepository_GetBySlug(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, 1, result.ID)
 				assert.Equal(t, "Test Course", result.Title)
+				assert.Equal(t, models.CourseNavigationSequential, result.Navigation)
+				assert.True(t, result.IsEnrolled)
+				assert.Equal(t, &enrolledAt, result.EnrolledAt)
+				assert.Equal(t, &enrolledAt, result.LastActivityAt)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -137,9 +144,9 @@ func TestCourseRepository_GetByID(t *testing.T) {
 			name: "success",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "author_id", "title", "short_summary", "complexity_level"}).
-					AddRow(1, "test-course", 1, "Test Course", "Summary", "Beginner")
-				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level FROM courses WHERE id = \?`).
+				rows := sqlmock.NewRows([]string{"id", "slug", "author_id", "title", "short_summary", "complexity_level", "navigation"}).
+					AddRow(1, "test-course", 1, "Test Course", "Summary", "Beginner", "free")
+				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level, navigation FROM courses WHERE id = \?`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -149,7 +156,7 @@ func TestCourseRepository_GetByID(t *testing.T) {
 			name: "course not found",
 			id:   999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level FROM courses WHERE id = \?`).
+				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level, navigation FROM courses WHERE id = \?`).
 					WithArgs(999).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -160,7 +167,7 @@ func TestCourseRepository_GetByID(t *testing.T) {
 			name: "database error",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level FROM courses WHERE id = \?`).
+				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level, navigation FROM courses WHERE id = \?`).
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -171,9 +178,9 @@ func TestCourseRepository_GetByID(t *testing.T) {
 			name: "scan error",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "author_id", "title", "short_summary", "complexity_level"}).
-					AddRow("invalid", "test-course", 1, "Test Course", "Summary", "Beginner")
-				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level FROM courses WHERE id = \?`).
+				rows := sqlmock.NewRows([]string{"id", "slug", "author_id", "title", "short_summary", "complexity_level", "navigation"}).
+					AddRow("invalid", "test-course", 1, "Test Course", "Summary", "Beginner", "free")
+				mock.ExpectQuery(`SELECT id, slug, author_id, title, short_summary, complexity_level, navigation FROM courses WHERE id = \?`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -203,6 +210,73 @@ func TestCourseRepository_GetByID(t *testing.T) {
 				assert.Equal(t, 1, result.ID)
 				assert.Equal(t, "test-course", result.Slug)
 				assert.Equal(t, "Test Course", result.Title)
+				assert.Equal(t, models.CourseNavigationFree, result.Navigation)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCourseRepository_GetNavigation(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int
+		setupMock     func(sqlmock.Sqlmock)
+		expected      models.CourseNavigation
+		expectedError bool
+		errorContains string
+	}{
+		{
+			name: "success",
+			id:   1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"navigation"}).AddRow("sequential")
+				mock.ExpectQuery(`SELECT navigation FROM courses WHERE id = \?`).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expected: models.CourseNavigationSequential,
+		},
+		{
+			name: "course not found",
+			id:   999,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT navigation FROM courses WHERE id = \?`).
+					WithArgs(999).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: true,
+			errorContains: "course not found",
+		},
+		{
+			name: "database error",
+			id:   1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT navigation FROM courses WHERE id = \?`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+			errorContains: "failed to get course navigation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupCourseTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetNavigation(context.Background(), tt.id)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -229,11 +303,11 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   1,
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("course-1", "Course 1", "Beginner", 10, 5).
-					AddRow("course-2", "Course 2", "Intermediate", 15, 8)
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("course-1", "Course 1", "Beginner", 10, 5, nil, nil).
+					AddRow("course-2", "Course 2", "Intermediate", 15, 8, nil, nil)
 				mock.ExpectQuery(`SELECT.*FROM courses c.*ORDER BY c.id LIMIT \? OFFSET \?`).
-					WithArgs(1, 10, 0).
+					WithArgs(1, 1, 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -246,10 +320,10 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:          1,
 			count:         10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("course-1", "Course 1", "Beginner", 10, 5)
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("course-1", "Course 1", "Beginner", 10, 5, nil, nil)
 				mock.ExpectQuery(`SELECT.*WHERE complexity_level = \?.*LIMIT \? OFFSET \?`).
-					WithArgs(1, "Beginner", 10, 0).
+					WithArgs(1, 1, "Beginner", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -262,10 +336,10 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   1,
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("test-course", "Test Course", "Beginner", 10, 5)
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("test-course", "Test Course", "Beginner", 10, 5, nil, nil)
 				mock.ExpectQuery(`SELECT.*FROM courses c.*WHERE c\.title LIKE \?.*GROUP BY.*ORDER BY.*LIMIT \? OFFSET \?`).
-					WithArgs(1, "%test%", 10, 0).
+					WithArgs(1, 1, "%test%", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -278,9 +352,9 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   1,
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("course-1", "Course 1", "Beginner", 10, 5)
-				mock.ExpectQuery(`SELECT.*WHERE EXISTS.*LIMIT \? OFFSET \?`).
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("course-1", "Course 1", "Beginner", 10, 5, nil, nil)
+				mock.ExpectQuery(`SELECT.*LEFT JOIN course_enrollments ce ON ce\.course_id = c\.id AND ce\.user_id = \?.*WHERE ce\.id IS NOT NULL.*LIMIT \? OFFSET \?`).
 					WithArgs(1, 1, 10, 0).
 					WillReturnRows(rows)
 			},
@@ -293,10 +367,10 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   2,
 			count:  5,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("course-6", "Course 6", "Beginner", 10, 5)
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("course-6", "Course 6", "Beginner", 10, 5, nil, nil)
 				mock.ExpectQuery(`SELECT.*ORDER BY c.id LIMIT \? OFFSET \?`).
-					WithArgs(1, 5, 5).
+					WithArgs(1, 1, 5, 5).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -308,9 +382,9 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   1,
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"})
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"})
 				mock.ExpectQuery(`SELECT.*FROM courses c.*ORDER BY c.id LIMIT \? OFFSET \?`).
-					WithArgs(1, 10, 0).
+					WithArgs(1, 1, 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -323,7 +397,7 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectQuery(`SELECT.*FROM courses c.*ORDER BY c.id LIMIT \? OFFSET \?`).
-					WithArgs(1, 10, 0).
+					WithArgs(1, 1, 10, 0).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -335,10 +409,10 @@ func TestCourseRepository_GetAll(t *testing.T) {
 			page:   1,
 			count:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons"}).
-					AddRow("course-1", "Course 1", "Beginner", "invalid", 5)
+				rows := sqlmock.NewRows([]string{"slug", "title", "complexity_level", "total_lessons", "completed_lessons", "enrolled_at", "last_activity_at"}).
+					AddRow("course-1", "Course 1", "Beginner", "invalid", 5, nil, nil)
 				mock.ExpectQuery(`SELECT.*FROM courses c.*ORDER BY c.id LIMIT \? OFFSET \?`).
-					WithArgs(1, 10, 0).
+					WithArgs(1, 1, 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -583,10 +657,11 @@ func TestCourseRepository_Create(t *testing.T) {
 				Title:           "Test Course",
 				ShortSummary:    "Summary",
 				ComplexityLevel: models.ComplexityLevelBeginner,
+				Navigation:      models.CourseNavigationFree,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`INSERT INTO courses \(slug, author_id, title, short_summary, complexity_level\) VALUES \(\?, \?, \?, \?, \?\)`).
-					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner").
+				mock.ExpectExec(`INSERT INTO courses \(slug, author_id, title, short_summary, complexity_level, navigation\) VALUES \(\?, \?, \?, \?, \?, \?\)`).
+					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner", "free").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedError: false,
@@ -600,10 +675,11 @@ func TestCourseRepository_Create(t *testing.T) {
 				Title:           "Test Course",
 				ShortSummary:    "Summary",
 				ComplexityLevel: models.ComplexityLevelBeginner,
+				Navigation:      models.CourseNavigationFree,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO courses`).
-					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner").
+					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner", "free").
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -616,10 +692,11 @@ func TestCourseRepository_Create(t *testing.T) {
 				Title:           "Test Course",
 				ShortSummary:    "Summary",
 				ComplexityLevel: models.ComplexityLevelBeginner,
+				Navigation:      models.CourseNavigationFree,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO courses`).
-					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner").
+					WithArgs("test-course", 1, "Test Course", "Summary", "Beginner", "free").
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
 			},
 			expectedError: true,
@@ -678,10 +755,11 @@ func TestCourseRepository_Update(t *testing.T) {
 				Title:           "Updated Title",
 				ShortSummary:    "Updated Summary",
 				ComplexityLevel: models.ComplexityLevelIntermediate,
+				Navigation:      models.CourseNavigationSequential,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE courses SET slug = \?, title = \?, short_summary = \?, complexity_level = \?, author_id = \? WHERE id = \?`).
-					WithArgs("updated-slug", "Updated Title", "Updated Summary", "Intermediate", 2, 1).
+				mock.ExpectExec(`UPDATE courses SET slug = \?, title = \?, short_summary = \?, complexity_level = \?, navigation = \?, author_id = \? WHERE id = \?`).
+					WithArgs("updated-slug", "Updated Title", "Updated Summary", "Intermediate", "sequential", 2, 1).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			expectedError: false,
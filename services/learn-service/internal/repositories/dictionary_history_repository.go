@@ -25,12 +25,13 @@ func NewDictionaryHistoryRepository(db *sql.DB) *dictionaryHistoryRepository {
 //
 // "userId" parameter is used to identify the user.
 // "limit" parameter is used to specify the number of words to return.
+// Leeches are not returned, they are reviewed from the leech list.
 // Please reference GetByIDs method for more information about other parameters and error values.
 func (r *dictionaryHistoryRepository) GetOldWordIds(ctx context.Context, userId int, limit int) ([]int, error) {
 	query := `
 		SELECT word_id
 		FROM dictionary_history
-		WHERE user_id = ? AND next_appearance <= CURDATE()
+		WHERE user_id = ? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE()
 		ORDER BY next_appearance ASC
 		LIMIT ?
 	`
@@ -57,21 +58,271 @@ func (r *dictionaryHistoryRepository) GetOldWordIds(ctx context.Context, userId
 	return wordIds, nil
 }
 
+// GetOldUserWordIds retrieves custom word IDs from dictionary history where NextAppearance <= current day
+//
+// "userId" parameter is used to identify the user.
+// "limit" parameter is used to specify the number of words to return.
+// Leeches are not returned, they are reviewed from the leech list.
+func (r *dictionaryHistoryRepository) GetOldUserWordIds(ctx context.Context, userId int, limit int) ([]int, error) {
+	query := `
+		SELECT user_word_id
+		FROM dictionary_history
+		WHERE user_id = ? AND user_word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE()
+		ORDER BY next_appearance ASC
+		LIMIT ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query old custom word IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var userWordIds []int
+	for rows.Next() {
+		var userWordId int
+		if err := rows.Scan(&userWordId); err != nil {
+			return nil, fmt.Errorf("failed to scan custom word ID: %w", err)
+		}
+		userWordIds = append(userWordIds, userWordId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return userWordIds, nil
+}
+
+// GetDueCounts counts dictionary history records of a user by the day they come due
+//
+// "userId" parameter is used to identify the user.
+// "days" parameter is used to specify the number of days after today to count.
+// The result maps days from today to the number of words, overdue words are counted at day 0.
+// Suspended words are not counted.
+func (r *dictionaryHistoryRepository) GetDueCounts(ctx context.Context, userId int, days int) (map[int]int, error) {
+	query := `
+		SELECT GREATEST(DATEDIFF(next_appearance, CURDATE()), 0) AS day, COUNT(*) AS count
+		FROM dictionary_history
+		WHERE user_id = ? AND suspended = FALSE AND next_appearance <= DATE_ADD(CURDATE(), INTERVAL ? DAY)
+		GROUP BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userId, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var day, count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan due count: %w", err)
+		}
+		counts[day] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetByUserIDAndWordIDs retrieves dictionary history records for a user and set of word IDs
+//
+// "userId" parameter is used to identify the user.
+// "wordIds" parameter is used to identify the words.
+// Words which have never been reviewed by the user have no records.
+func (r *dictionaryHistoryRepository) GetByUserIDAndWordIDs(ctx context.Context, userId int, wordIds []int) ([]models.DictionaryHistory, error) {
+	return r.getByUserIDAndColumn(ctx, userId, "word_id", wordIds)
+}
+
+// GetByUserIDAndUserWordIDs retrieves dictionary history records for a user and set of custom word IDs
+//
+// "userId" parameter is used to identify the user.
+// "userWordIds" parameter is used to identify the custom words.
+// Custom words which have never been reviewed by the user have no records.
+func (r *dictionaryHistoryRepository) GetByUserIDAndUserWordIDs(ctx context.Context, userId int, userWordIds []int) ([]models.DictionaryHistory, error) {
+	return r.getByUserIDAndColumn(ctx, userId, "user_word_id", userWordIds)
+}
+
+// getByUserIDAndColumn retrieves dictionary history records of a user where the column is one of the IDs
+//
+// "column" parameter is "word_id" or "user_word_id".
+func (r *dictionaryHistoryRepository) getByUserIDAndColumn(ctx context.Context, userId int, column string, ids []int) ([]models.DictionaryHistory, error) {
+	if len(ids) == 0 {
+		return []models.DictionaryHistory{}, nil
+	}
+
+	args := []any{userId}
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM dictionary_history
+		WHERE user_id = ? AND %s IN (%s)`, historyColumns, column, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dictionary history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHistories(rows)
+}
+
+// GetLeeches retrieves dictionary history records of words flagged as leeches for a user
+//
+// "userId" parameter is used to identify the user.
+// Leeches with most lapses come first.
+func (r *dictionaryHistoryRepository) GetLeeches(ctx context.Context, userId int) ([]models.DictionaryHistory, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM dictionary_history
+		WHERE user_id = ? AND is_leech = TRUE
+		ORDER BY lapses DESC, next_appearance ASC`, historyColumns)
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query leeches: %w", err)
+	}
+	defer rows.Close()
+
+	return scanHistories(rows)
+}
+
+// UnsuspendLeech returns a leech to regular reviews keeping its review state
+//
+// "userId" parameter is used to identify the user.
+// "wordId" parameter is used to identify the word, it is a custom word ID if "isCustom" is true.
+// Returns false if the word is not a leech of the user.
+func (r *dictionaryHistoryRepository) UnsuspendLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE dictionary_history
+		SET is_leech = FALSE, suspended = FALSE
+		WHERE user_id = ? AND %s = ? AND is_leech = TRUE`, historyWordColumn(isCustom))
+
+	result, err := r.db.ExecContext(ctx, query, userId, wordId)
+	if err != nil {
+		return false, fmt.Errorf("failed to unsuspend leech: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ResetLeech returns a leech to regular reviews as a word learned from scratch
+//
+// Lapses and the SM-2 state are reset and the word comes due today.
+// Please reference UnsuspendLeech method for more information about parameters and return values.
+func (r *dictionaryHistoryRepository) ResetLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE dictionary_history
+		SET is_leech = FALSE, suspended = FALSE, lapses = 0, repetitions = 0, interval_days = 0,
+			ease_factor = DEFAULT(ease_factor), next_appearance = CURDATE()
+		WHERE user_id = ? AND %s = ? AND is_leech = TRUE`, historyWordColumn(isCustom))
+
+	result, err := r.db.ExecContext(ctx, query, userId, wordId)
+	if err != nil {
+		return false, fmt.Errorf("failed to reset leech: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetExportEntries retrieves all words reviewed by a user together with their review state
+//
+// Translations are taken in the first language of the chain which has them.
+func (r *dictionaryHistoryRepository) GetExportEntries(ctx context.Context, userId int, languages []string) ([]models.DictionaryExportEntry, error) {
+	translation, args := localizedColumn("word_translations", "translation", "word_id = w.id", languages)
+	exampleTranslation, exampleArgs := localizedColumn("word_translations", "example_translation", "word_id = w.id", languages)
+	args = append(append(args, exampleArgs...), userId)
+
+	query := fmt.Sprintf(`
+		SELECT w.id, w.word, w.phonetic_clues, %s as translation, w.example, %s as example_translation,
+		       w.word_audio, w.word_example_audio,
+		       dh.id, dh.ease_factor, dh.interval_days, dh.repetitions, dh.lapses, dh.next_appearance, dh.last_reviewed_at
+		FROM dictionary_history dh
+		INNER JOIN words w ON w.id = dh.word_id
+		WHERE dh.user_id = ?
+		ORDER BY dh.next_appearance, w.id`, translation, exampleTranslation)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dictionary export entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.DictionaryExportEntry
+	for rows.Next() {
+		var entry models.DictionaryExportEntry
+		var wordAudio, wordExampleAudio sql.NullString
+		var lastReviewedAt sql.NullTime
+		if err := rows.Scan(
+			&entry.Word.ID,
+			&entry.Word.Word,
+			&entry.Word.PhoneticClues,
+			&entry.Word.Translation,
+			&entry.Word.Example,
+			&entry.Word.ExampleTranslation,
+			&wordAudio,
+			&wordExampleAudio,
+			&entry.History.ID,
+			&entry.History.EaseFactor,
+			&entry.History.IntervalDays,
+			&entry.History.Repetitions,
+			&entry.History.Lapses,
+			&entry.History.NextAppearance,
+			&lastReviewedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dictionary export entry: %w", err)
+		}
+		entry.Word.WordAudio = wordAudio.String
+		entry.Word.WordExampleAudio = wordExampleAudio.String
+		entry.History.WordID = entry.Word.ID
+		entry.History.UserID = userId
+		if lastReviewedAt.Valid {
+			entry.History.LastReviewedAt = &lastReviewedAt.Time
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
 // UpsertResults inserts or updates dictionary history records
 //
 // "userId" parameter is used to identify the user.
-// "results" parameter is used to submit the word learning results.
-func (r *dictionaryHistoryRepository) UpsertResults(ctx context.Context, userId int, results []models.WordResult) error {
-	if len(results) == 0 {
+// "histories" parameter contains review states of the words computed by the scheduler.
+// Records of custom words have UserWordID set and WordID equal to 0.
+// The next appearance of every word is set to the current day plus its interval, the review time is set to now.
+func (r *dictionaryHistoryRepository) UpsertResults(ctx context.Context, userId int, histories []models.DictionaryHistory) error {
+	if len(histories) == 0 {
 		return fmt.Errorf("no results to upsert")
 	}
 
 	// Build placeholders and args for batch insert
-	placeholders := make([]string, len(results))
+	placeholders := make([]string, len(histories))
 	args := []any{}
-	for i, result := range results {
-		placeholders[i] = "(?, ?, DATE_ADD(CURDATE(), INTERVAL ? DAY))"
-		args = append(args, userId, result.WordID, result.Period)
+	for i, history := range histories {
+		placeholders[i] = "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, DATE_ADD(CURDATE(), INTERVAL ? DAY), NOW())"
+		args = append(args, userId, nullableID(history.WordID), nullableID(history.UserWordID), history.EaseFactor,
+			history.IntervalDays, history.Repetitions, history.Lapses, history.ReviewCount, history.IsLeech, history.Suspended, history.IntervalDays)
 	}
 
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -81,10 +332,18 @@ func (r *dictionaryHistoryRepository) UpsertResults(ctx context.Context, userId
 	defer tx.Rollback()
 
 	query := fmt.Sprintf(`
-		INSERT INTO dictionary_history (user_id, word_id, next_appearance)
+		INSERT INTO dictionary_history (user_id, word_id, user_word_id, ease_factor, interval_days, repetitions, lapses, review_count, is_leech, suspended, next_appearance, last_reviewed_at)
 		VALUES %s
 		ON DUPLICATE KEY UPDATE
-			next_appearance = VALUES(next_appearance)
+			ease_factor = VALUES(ease_factor),
+			interval_days = VALUES(interval_days),
+			repetitions = VALUES(repetitions),
+			lapses = VALUES(lapses),
+			review_count = VALUES(review_count),
+			is_leech = VALUES(is_leech),
+			suspended = VALUES(suspended),
+			next_appearance = VALUES(next_appearance),
+			last_reviewed_at = VALUES(last_reviewed_at)
 	`, strings.Join(placeholders, ","))
 
 	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
@@ -97,3 +356,61 @@ func (r *dictionaryHistoryRepository) UpsertResults(ctx context.Context, userId
 
 	return nil
 }
+
+// nullableID returns NULL for a zero ID
+func nullableID(id int) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// historyColumns are the selected columns of dictionary history records, in the order of scanHistories
+const historyColumns = "id, word_id, user_word_id, user_id, ease_factor, interval_days, repetitions, lapses, review_count, is_leech, suspended, next_appearance, last_reviewed_at"
+
+// historyWordColumn returns the column identifying custom or dictionary words
+func historyWordColumn(isCustom bool) string {
+	if isCustom {
+		return "user_word_id"
+	}
+	return "word_id"
+}
+
+// scanHistories scans dictionary history records selected with historyColumns
+func scanHistories(rows *sql.Rows) ([]models.DictionaryHistory, error) {
+	var histories []models.DictionaryHistory
+	for rows.Next() {
+		var history models.DictionaryHistory
+		var wordId, userWordId sql.NullInt64
+		var lastReviewedAt sql.NullTime
+		if err := rows.Scan(
+			&history.ID,
+			&wordId,
+			&userWordId,
+			&history.UserID,
+			&history.EaseFactor,
+			&history.IntervalDays,
+			&history.Repetitions,
+			&history.Lapses,
+			&history.ReviewCount,
+			&history.IsLeech,
+			&history.Suspended,
+			&history.NextAppearance,
+			&lastReviewedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan dictionary history: %w", err)
+		}
+		history.WordID = int(wordId.Int64)
+		history.UserWordID = int(userWordId.Int64)
+		if lastReviewedAt.Valid {
+			history.LastReviewedAt = &lastReviewedAt.Time
+		}
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return histories, nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
@@ -54,7 +55,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 					AddRow(1).
 					AddRow(2).
 					AddRow(3)
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 10).
 					WillReturnRows(rows)
 			},
@@ -68,7 +69,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"word_id"}).
 					AddRow(1)
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 5).
 					WillReturnRows(rows)
 			},
@@ -81,7 +82,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 			limit:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"word_id"})
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 10).
 					WillReturnRows(rows)
 			},
@@ -93,7 +94,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 			userId: 1,
 			limit:  10,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 10).
 					WillReturnError(errors.New("database error"))
 			},
@@ -107,7 +108,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"word_id"}).
 					AddRow("invalid")
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 10).
 					WillReturnRows(rows)
 			},
@@ -122,7 +123,7 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"word_id"}).
 					AddRow(1).
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
+				mock.ExpectQuery(`SELECT word_id FROM dictionary_history WHERE user_id = \? AND word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`).
 					WithArgs(1, 10).
 					WillReturnRows(rows)
 			},
@@ -158,25 +159,356 @@ func TestDictionaryHistoryRepository_GetOldWordIds(t *testing.T) {
 	}
 }
 
+func TestDictionaryHistoryRepository_GetOldUserWordIds(t *testing.T) {
+	query := `SELECT user_word_id FROM dictionary_history WHERE user_id = \? AND user_word_id IS NOT NULL AND is_leech = FALSE AND next_appearance <= CURDATE\(\) ORDER BY next_appearance ASC LIMIT \?`
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"user_word_id"}).
+					AddRow(5).
+					AddRow(7)
+				mock.ExpectQuery(query).
+					WithArgs(1, 10).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected:      []int{5, 7},
+		},
+		{
+			name: "empty result - no old custom words",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"user_word_id"}))
+			},
+			expectedError: false,
+		},
+		{
+			name: "database query error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1, 10).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetOldUserWordIds(context.Background(), 1, 10)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDictionaryHistoryRepository_GetDueCounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		userId        int
+		days          int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      map[int]int
+	}{
+		{
+			name:   "success",
+			userId: 1,
+			days:   7,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"day", "count"}).
+					AddRow(0, 12).
+					AddRow(1, 3).
+					AddRow(6, 5)
+				mock.ExpectQuery(`(?s)SELECT GREATEST\(DATEDIFF\(next_appearance, CURDATE\(\)\), 0\) AS day.*WHERE user_id = \? AND suspended = FALSE AND next_appearance <= DATE_ADD\(CURDATE\(\), INTERVAL \? DAY\).*GROUP BY day`).
+					WithArgs(1, 7).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected:      map[int]int{0: 12, 1: 3, 6: 5},
+		},
+		{
+			name:   "no reviews",
+			userId: 1,
+			days:   7,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT GREATEST.*FROM dictionary_history`).
+					WithArgs(1, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"day", "count"}))
+			},
+			expectedError: false,
+			expected:      map[int]int{},
+		},
+		{
+			name:   "database error",
+			userId: 1,
+			days:   7,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT GREATEST.*FROM dictionary_history`).
+					WithArgs(1, 7).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name:   "scan error",
+			userId: 1,
+			days:   7,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"day", "count"}).AddRow("invalid", 1)
+				mock.ExpectQuery(`(?s)SELECT GREATEST.*FROM dictionary_history`).
+					WithArgs(1, 7).
+					WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetDueCounts(context.Background(), tt.userId, tt.days)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDictionaryHistoryRepository_GetByUserIDAndWordIDs(t *testing.T) {
+	nextAppearance := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	lastReviewedAt := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "word_id", "user_word_id", "user_id", "ease_factor", "interval_days", "repetitions", "lapses", "review_count", "is_leech", "suspended", "next_appearance", "last_reviewed_at"}
+
+	tests := []struct {
+		name          string
+		userId        int
+		wordIds       []int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []models.DictionaryHistory
+	}{
+		{
+			name:    "success",
+			userId:  1,
+			wordIds: []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(10, 1, nil, 1, 2.36, 6, 2, 0, 3, false, false, nextAppearance, lastReviewedAt).
+					AddRow(11, 2, nil, 1, 2.5, 0, 0, 0, 0, false, false, nextAppearance, nil)
+				mock.ExpectQuery(`(?s)SELECT id, word_id, user_word_id, user_id, ease_factor.*FROM dictionary_history.*WHERE user_id = \? AND word_id IN \(\?,\?\)`).
+					WithArgs(1, 1, 2).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected: []models.DictionaryHistory{
+				{ID: 10, WordID: 1, UserID: 1, EaseFactor: 2.36, IntervalDays: 6, Repetitions: 2, ReviewCount: 3, NextAppearance: nextAppearance, LastReviewedAt: &lastReviewedAt},
+				{ID: 11, WordID: 2, UserID: 1, EaseFactor: 2.5, NextAppearance: nextAppearance},
+			},
+		},
+		{
+			name:          "empty word IDs",
+			userId:        1,
+			wordIds:       []int{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: false,
+			expected:      []models.DictionaryHistory{},
+		},
+		{
+			name:    "database error",
+			userId:  1,
+			wordIds: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM dictionary_history`).
+					WithArgs(1, 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetByUserIDAndWordIDs(context.Background(), tt.userId, tt.wordIds)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDictionaryHistoryRepository_GetByUserIDAndUserWordIDs(t *testing.T) {
+	nextAppearance := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "word_id", "user_word_id", "user_id", "ease_factor", "interval_days", "repetitions", "lapses", "review_count", "is_leech", "suspended", "next_appearance", "last_reviewed_at"}).
+		AddRow(12, nil, 5, 1, 2.5, 1, 1, 0, 1, false, false, nextAppearance, nil)
+	mock.ExpectQuery(`(?s)SELECT id, word_id, user_word_id.*FROM dictionary_history.*WHERE user_id = \? AND user_word_id IN \(\?\)`).
+		WithArgs(1, 5).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByUserIDAndUserWordIDs(context.Background(), 1, []int{5})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.DictionaryHistory{
+		{ID: 12, UserWordID: 5, UserID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, ReviewCount: 1, NextAppearance: nextAppearance},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDictionaryHistoryRepository_GetExportEntries(t *testing.T) {
+	nextAppearance := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	lastReviewedAt := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "word", "phonetic_clues", "translation", "example", "example_translation", "word_audio", "word_example_audio",
+		"id", "ease_factor", "interval_days", "repetitions", "lapses", "next_appearance", "last_reviewed_at"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []models.DictionaryExportEntry
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "みず", "water", "水を飲む", "drink water", "http://media/api/v6/media/a.mp3", nil, 10, 2.36, 6, 2, 1, nextAppearance, lastReviewedAt).
+					AddRow(2, "火", "ひ", "fire", "", "", nil, nil, 11, 2.5, 1, 1, 0, nextAppearance, nil)
+				mock.ExpectQuery(`(?s)SELECT w.id, w.word, w.phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = w.id AND t.language IN \(\?,\?\).*\) as translation.*SELECT t.example_translation FROM word_translations t.*as example_translation.*FROM dictionary_history dh.*INNER JOIN words w ON w.id = dh.word_id.*WHERE dh.user_id = \?`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected: []models.DictionaryExportEntry{
+				{
+					Word: models.WordResponse{ID: 1, Word: "水", PhoneticClues: "みず", Translation: "water", Example: "水を飲む", ExampleTranslation: "drink water", WordAudio: "http://media/api/v6/media/a.mp3"},
+					History: models.DictionaryHistory{ID: 10, WordID: 1, UserID: 1, EaseFactor: 2.36, IntervalDays: 6, Repetitions: 2, Lapses: 1,
+						NextAppearance: nextAppearance, LastReviewedAt: &lastReviewedAt},
+				},
+				{
+					Word:    models.WordResponse{ID: 2, Word: "火", PhoneticClues: "ひ", Translation: "fire"},
+					History: models.DictionaryHistory{ID: 11, WordID: 2, UserID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, NextAppearance: nextAppearance},
+				},
+			},
+		},
+		{
+			name: "no reviewed words",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM dictionary_history dh`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedError: false,
+			expected:      nil,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM dictionary_history dh`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name: "scan error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("invalid", "水", "みず", "water", "", "", nil, nil, 10, 2.36, 6, 2, 1, nextAppearance, nil)
+				mock.ExpectQuery(`(?s)SELECT.*FROM dictionary_history dh`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetExportEntries(context.Background(), 1, []string{"ru", "en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestDictionaryHistoryRepository_UpsertResults(t *testing.T) {
 	tests := []struct {
 		name          string
 		userId        int
-		results       []models.WordResult
+		histories     []models.DictionaryHistory
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
 	}{
 		{
 			name:   "success insert new records",
 			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 3},
-				{WordID: 2, Period: 7},
+			histories: []models.DictionaryHistory{
+				{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, ReviewCount: 1},
+				{WordID: 2, EaseFactor: 2.18, IntervalDays: 1, ReviewCount: 1},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*VALUES.*ON DUPLICATE KEY UPDATE.*`).
-					WithArgs(1, 1, 3, 1, 2, 7).
+					WithArgs(1, 1, nil, 2.5, 1, 1, 0, 1, false, false, 1, 1, 2, nil, 2.18, 1, 0, 0, 1, false, false, 1).
 					WillReturnResult(sqlmock.NewResult(1, 2))
 				mock.ExpectCommit()
 			},
@@ -185,30 +517,46 @@ func TestDictionaryHistoryRepository_UpsertResults(t *testing.T) {
 		{
 			name:   "success update existing records",
 			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 5},
+			histories: []models.DictionaryHistory{
+				{ID: 10, WordID: 1, EaseFactor: 2.36, IntervalDays: 15, Repetitions: 3, Lapses: 1, ReviewCount: 5},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*VALUES.*ON DUPLICATE KEY UPDATE.*`).
-					WithArgs(1, 1, 5).
+				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*VALUES.*ON DUPLICATE KEY UPDATE.*ease_factor = VALUES\(ease_factor\).*review_count = VALUES\(review_count\).*`).
+					WithArgs(1, 1, nil, 2.36, 15, 3, 1, 5, false, false, 15).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			expectedError: false,
 		},
 		{
-			name:          "empty results slice",
+			name:   "success with custom and dictionary words",
+			userId: 1,
+			histories: []models.DictionaryHistory{
+				{UserWordID: 5, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+				{WordID: 2, EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO dictionary_history \(user_id, word_id, user_word_id,.*VALUES.*ON DUPLICATE KEY UPDATE.*`).
+					WithArgs(1, nil, 5, 2.5, 1, 1, 0, 0, false, false, 1, 1, 2, nil, 2.5, 6, 2, 0, 0, false, false, 6).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+		},
+		{
+			name:          "empty histories slice",
 			userId:        1,
-			results:       []models.WordResult{},
+			histories:     []models.DictionaryHistory{},
 			setupMock:     func(mock sqlmock.Sqlmock) {},
 			expectedError: true,
 		},
 		{
 			name:   "transaction begin error",
 			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 3},
+			histories: []models.DictionaryHistory{
+				{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin().WillReturnError(errors.New("begin error"))
@@ -218,13 +566,13 @@ func TestDictionaryHistoryRepository_UpsertResults(t *testing.T) {
 		{
 			name:   "database error on insert",
 			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 3},
+			histories: []models.DictionaryHistory{
+				{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*`).
-					WithArgs(1, 1, 3).
+					WithArgs(1, 1, nil, 2.5, 1, 1, 0, 0, false, false, 1).
 					WillReturnError(errors.New("insert error"))
 				mock.ExpectRollback()
 			},
@@ -233,65 +581,146 @@ func TestDictionaryHistoryRepository_UpsertResults(t *testing.T) {
 		{
 			name:   "transaction commit error",
 			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 3},
+			histories: []models.DictionaryHistory{
+				{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*`).
-					WithArgs(1, 1, 3).
+					WithArgs(1, 1, nil, 2.5, 1, 1, 0, 0, false, false, 1).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit().WillReturnError(errors.New("commit error"))
 			},
 			expectedError: true,
 		},
 		{
-			name:   "success with multiple results and different periods",
+			name:   "success with maximum interval",
 			userId: 2,
-			results: []models.WordResult{
-				{WordID: 1, Period: 1},
-				{WordID: 2, Period: 3},
-				{WordID: 3, Period: 7},
-				{WordID: 4, Period: 14},
+			histories: []models.DictionaryHistory{
+				{WordID: 1, EaseFactor: 2.7, IntervalDays: 365, Repetitions: 9},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*`).
-					WithArgs(2, 1, 1, 2, 2, 3, 2, 3, 7, 2, 4, 14).
-					WillReturnResult(sqlmock.NewResult(1, 4))
+					WithArgs(2, 1, nil, 2.7, 365, 9, 0, 0, false, false, 365).
+					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			expectedError: false,
 		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.UpsertResults(context.Background(), tt.userId, tt.histories)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDictionaryHistoryRepository_GetLeeches(t *testing.T) {
+	nextAppearance := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "word_id", "user_word_id", "user_id", "ease_factor", "interval_days", "repetitions", "lapses", "review_count", "is_leech", "suspended", "next_appearance", "last_reviewed_at"}
+
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+		defer cleanup()
+
+		rows := sqlmock.NewRows(columns).
+			AddRow(10, 1, nil, 1, 1.3, 1, 0, 9, 20, true, true, nextAppearance, nil).
+			AddRow(11, nil, 5, 1, 1.3, 1, 0, 8, 15, true, false, nextAppearance, nil)
+		mock.ExpectQuery(`(?s)SELECT id, word_id, user_word_id.*FROM dictionary_history.*WHERE user_id = \? AND is_leech = TRUE.*ORDER BY lapses DESC, next_appearance ASC`).
+			WithArgs(1).
+			WillReturnRows(rows)
+
+		result, err := repo.GetLeeches(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.DictionaryHistory{
+			{ID: 10, WordID: 1, UserID: 1, EaseFactor: 1.3, IntervalDays: 1, Lapses: 9, ReviewCount: 20, IsLeech: true, Suspended: true, NextAppearance: nextAppearance},
+			{ID: 11, UserWordID: 5, UserID: 1, EaseFactor: 1.3, IntervalDays: 1, Lapses: 8, ReviewCount: 15, IsLeech: true, NextAppearance: nextAppearance},
+		}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`(?s)SELECT.*FROM dictionary_history`).
+			WithArgs(1).
+			WillReturnError(errors.New("database error"))
+
+		result, err := repo.GetLeeches(context.Background(), 1)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to query leeches")
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDictionaryHistoryRepository_UnsuspendLeech(t *testing.T) {
+	tests := []struct {
+		name          string
+		wordId        int
+		isCustom      bool
+		setupMock     func(sqlmock.Sqlmock)
+		expected      bool
+		expectedError bool
+	}{
 		{
-			name:   "success with maximum period (30 days)",
-			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 30},
+			name:   "success dictionary word",
+			wordId: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE dictionary_history.*SET is_leech = FALSE, suspended = FALSE.*WHERE user_id = \? AND word_id = \? AND is_leech = TRUE`).
+					WithArgs(1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
+			expected: true,
+		},
+		{
+			name:     "success custom word",
+			wordId:   5,
+			isCustom: true,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectBegin()
-				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*`).
-					WithArgs(1, 1, 30).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectCommit()
+				mock.ExpectExec(`(?s)UPDATE dictionary_history.*WHERE user_id = \? AND user_word_id = \? AND is_leech = TRUE`).
+					WithArgs(1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
-			expectedError: false,
+			expected: true,
 		},
 		{
-			name:   "success with minimum period (1 day)",
-			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 1},
+			name:   "not a leech",
+			wordId: 2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE dictionary_history`).
+					WithArgs(1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
 			},
+			expected: false,
+		},
+		{
+			name:   "database error",
+			wordId: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectBegin()
-				mock.ExpectExec(`(?s)INSERT INTO dictionary_history.*`).
-					WithArgs(1, 1, 1).
-					WillReturnResult(sqlmock.NewResult(1, 1))
-				mock.ExpectCommit()
+				mock.ExpectExec(`(?s)UPDATE dictionary_history`).
+					WithArgs(1, 1).
+					WillReturnError(errors.New("database error"))
 			},
-			expectedError: false,
+			expectedError: true,
 		},
 	}
 
@@ -302,15 +731,62 @@ func TestDictionaryHistoryRepository_UpsertResults(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			err := repo.UpsertResults(context.Background(), tt.userId, tt.results)
+			found, err := repo.UnsuspendLeech(context.Background(), 1, tt.wordId, tt.isCustom)
 
 			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, found)
 			}
-
 			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func TestDictionaryHistoryRepository_ResetLeech(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectExec(`(?s)UPDATE dictionary_history.*SET is_leech = FALSE, suspended = FALSE, lapses = 0, repetitions = 0, interval_days = 0,.*ease_factor = DEFAULT\(ease_factor\), next_appearance = CURDATE\(\).*WHERE user_id = \? AND word_id = \? AND is_leech = TRUE`).
+			WithArgs(1, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		found, err := repo.ResetLeech(context.Background(), 1, 1, false)
+
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not a leech", func(t *testing.T) {
+		repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectExec(`(?s)UPDATE dictionary_history.*WHERE user_id = \? AND user_word_id = \?`).
+			WithArgs(1, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		found, err := repo.ResetLeech(context.Background(), 1, 5, true)
+
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, cleanup := setupDictionaryHistoryTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectExec(`(?s)UPDATE dictionary_history`).
+			WithArgs(1, 1).
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.ResetLeech(context.Background(), 1, 1, false)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to reset leech")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
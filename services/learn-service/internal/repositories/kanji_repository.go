@@ -0,0 +1,517 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// kanjiRepository implements KanjiRepository
+type kanjiRepository struct {
+	db *sql.DB
+}
+
+// NewKanjiRepository creates a new kanji repository
+func NewKanjiRepository(db *sql.DB) *kanjiRepository {
+	return &kanjiRepository{
+		db: db,
+	}
+}
+
+// GetByJLPTLevel retrieves a paginated list of kanji with meanings in the first language of the chain which has them
+//
+// If level is 0, kanji of all JLPT levels are returned starting from the easiest ones.
+func (r *kanjiRepository) GetByJLPTLevel(ctx context.Context, level, page, count int, languages []string) ([]models.KanjiResponse, error) {
+	meaning, args := localizedColumn("kanji_meanings", "meaning", "kanji_id = kanji.id", languages)
+
+	var whereClause string
+	if level != 0 {
+		whereClause = "WHERE jlpt_level = ?"
+		args = append(args, level)
+	}
+
+	offset := (page - 1) * count
+
+	query := fmt.Sprintf(`
+		SELECT id, kanji_character, onyomi, kunyomi, %s as meaning, stroke_count, jlpt_level, radicals, audio
+		FROM kanji
+		%s
+		ORDER BY jlpt_level DESC, stroke_count, id
+		LIMIT ? OFFSET ?
+	`, meaning, whereClause)
+
+	args = append(args, count, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kanji: %w", err)
+	}
+	defer rows.Close()
+
+	kanji := []models.KanjiResponse{}
+	for rows.Next() {
+		var item models.KanjiResponse
+		var audio sql.NullString
+		err := rows.Scan(
+			&item.ID,
+			&item.Character,
+			&item.Onyomi,
+			&item.Kunyomi,
+			&item.Meaning,
+			&item.StrokeCount,
+			&item.JLPTLevel,
+			&item.Radicals,
+			&audio,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan kanji: %w", err)
+		}
+		if audio.Valid {
+			item.Audio = audio.String
+		}
+		kanji = append(kanji, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return kanji, nil
+}
+
+// GetWordsByKanjiID retrieves words linked to a kanji with translations in the first language of the chain which has them
+func (r *kanjiRepository) GetWordsByKanjiID(ctx context.Context, kanjiID int, languages []string) ([]models.WordResponse, error) {
+	translation, args := localizedColumn("word_translations", "translation", "word_id = w.id", languages)
+	exampleTranslation, exampleArgs := localizedColumn("word_translations", "example_translation", "word_id = w.id", languages)
+	args = append(append(args, exampleArgs...), kanjiID)
+
+	query := fmt.Sprintf(`
+		SELECT w.id, w.word, w.phonetic_clues, %s as translation, w.example, %s as example_translation,
+		       w.easy_period, w.normal_period, w.hard_period, w.extra_hard_period, w.word_audio, w.word_example_audio
+		FROM kanji_words kw
+		JOIN words w ON w.id = kw.word_id
+		WHERE kw.kanji_id = ?
+		ORDER BY w.id
+	`, translation, exampleTranslation)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kanji words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.WordResponse{}
+	for rows.Next() {
+		var word models.WordResponse
+		var wordAudio, wordExampleAudio sql.NullString
+		err := rows.Scan(
+			&word.ID,
+			&word.Word,
+			&word.PhoneticClues,
+			&word.Translation,
+			&word.Example,
+			&word.ExampleTranslation,
+			&word.EasyPeriod,
+			&word.NormalPeriod,
+			&word.HardPeriod,
+			&word.ExtraHardPeriod,
+			&wordAudio,
+			&wordExampleAudio,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		if wordAudio.Valid {
+			word.WordAudio = wordAudio.String
+		}
+		if wordExampleAudio.Valid {
+			word.WordExampleAudio = wordExampleAudio.String
+		}
+		words = append(words, word)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return words, nil
+}
+
+// ExistsByID checks if a kanji with the given ID exists
+func (r *kanjiRepository) ExistsByID(ctx context.Context, id int) (bool, error) {
+	query := `SELECT EXISTS(SELECT * FROM kanji WHERE id = ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check kanji existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetJLPTLevels retrieves JLPT levels of kanji characters, unknown characters are left out
+func (r *kanjiRepository) GetJLPTLevels(ctx context.Context, characters []string) (map[string]int, error) {
+	levels := make(map[string]int)
+	if len(characters) == 0 {
+		return levels, nil
+	}
+
+	args := make([]any, len(characters))
+	for i, character := range characters {
+		args[i] = character
+	}
+	query := fmt.Sprintf(`SELECT kanji_character, jlpt_level FROM kanji WHERE kanji_character IN (%s)`, queryPlaceholders(len(characters)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kanji levels: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var character string
+		var level int
+		if err := rows.Scan(&character, &level); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji level: %w", err)
+		}
+		levels[character] = level
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return levels, nil
+}
+
+// GetAllForAdmin retrieves a paginated list of kanji with optional search filter
+//
+// Meanings are searched in all languages and returned in the first language of the chain which has them.
+func (r *kanjiRepository) GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.KanjiListItem, error) {
+	meaning, args := localizedColumn("kanji_meanings", "meaning", "kanji_id = kanji.id", languages)
+
+	var whereClause string
+	if search != "" {
+		whereClause = `WHERE kanji_character = ? OR onyomi LIKE ? OR kunyomi LIKE ?
+			OR EXISTS (SELECT 1 FROM kanji_meanings m WHERE m.kanji_id = kanji.id AND m.meaning LIKE ?)`
+		searchValue := "%" + search + "%"
+		args = append(args, search, searchValue, searchValue, searchValue)
+	}
+
+	offset := (page - 1) * count
+
+	query := fmt.Sprintf(`
+		SELECT id, kanji_character, %s as meaning, jlpt_level
+		FROM kanji
+		%s
+		ORDER BY jlpt_level DESC, id
+		LIMIT ? OFFSET ?
+	`, meaning, whereClause)
+
+	args = append(args, count, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kanji: %w", err)
+	}
+	defer rows.Close()
+
+	var kanji []models.KanjiListItem
+	for rows.Next() {
+		var item models.KanjiListItem
+		err := rows.Scan(
+			&item.ID,
+			&item.Character,
+			&item.Meaning,
+			&item.JLPTLevel,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan kanji: %w", err)
+		}
+		kanji = append(kanji, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return kanji, nil
+}
+
+// GetByIDAdmin retrieves a kanji by ID with meanings in all languages
+func (r *kanjiRepository) GetByIDAdmin(ctx context.Context, id int) (*models.Kanji, error) {
+	query := `
+		SELECT id, kanji_character, onyomi, kunyomi, stroke_count, jlpt_level, radicals, audio
+		FROM kanji
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	kanji := &models.Kanji{}
+	var audio sql.NullString
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&kanji.ID,
+		&kanji.Character,
+		&kanji.Onyomi,
+		&kanji.Kunyomi,
+		&kanji.StrokeCount,
+		&kanji.JLPTLevel,
+		&kanji.Radicals,
+		&audio,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("kanji not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji by ID: %w", err)
+	}
+
+	if audio.Valid {
+		kanji.Audio = audio.String
+	}
+
+	kanji.Meanings, err = r.getMeanings(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return kanji, nil
+}
+
+// getMeanings retrieves meanings of a kanji in all languages
+func (r *kanjiRepository) getMeanings(ctx context.Context, kanjiID int) (map[string]string, error) {
+	query := `
+		SELECT language, meaning
+		FROM kanji_meanings
+		WHERE kanji_id = ?
+		ORDER BY language
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, kanjiID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query kanji meanings: %w", err)
+	}
+	defer rows.Close()
+
+	meanings := make(map[string]string)
+	for rows.Next() {
+		var language, meaning string
+		if err := rows.Scan(&language, &meaning); err != nil {
+			return nil, fmt.Errorf("failed to scan kanji meaning: %w", err)
+		}
+		meanings[language] = meaning
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return meanings, nil
+}
+
+// upsertMeanings writes meanings of a kanji, empty values keep the current ones
+func upsertMeanings(ctx context.Context, exec execer, kanjiID int, meanings map[string]string) error {
+	values := make(map[string][]string, len(meanings))
+	for language, meaning := range meanings {
+		values[language] = []string{meaning}
+	}
+	return upsertTranslations(ctx, exec, "kanji_meanings", "kanji_id", kanjiID, []string{"meaning"}, values)
+}
+
+// ExistsByCharacter checks if a kanji with the given character exists
+func (r *kanjiRepository) ExistsByCharacter(ctx context.Context, character string) (bool, error) {
+	query := `SELECT EXISTS(SELECT * FROM kanji WHERE kanji_character = ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, character).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check kanji existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// Create inserts a new kanji with its meanings into the database
+func (r *kanjiRepository) Create(ctx context.Context, kanji *models.Kanji) error {
+	query := `
+		INSERT INTO kanji (kanji_character, onyomi, kunyomi, stroke_count, jlpt_level, radicals, audio)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		kanji.Character,
+		kanji.Onyomi,
+		kanji.Kunyomi,
+		kanji.StrokeCount,
+		kanji.JLPTLevel,
+		kanji.Radicals,
+		kanji.Audio,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create kanji: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := upsertMeanings(ctx, tx, int(id), kanji.Meanings); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	kanji.ID = int(id)
+	return nil
+}
+
+// Update updates kanji fields (partial update)
+//
+// Meanings are written for the listed languages only, the other languages are left unchanged.
+func (r *kanjiRepository) Update(ctx context.Context, id int, kanji *models.Kanji) error {
+	var setParts []string
+	var args []any
+
+	if kanji.Character != "" {
+		setParts = append(setParts, "kanji_character = ?")
+		args = append(args, kanji.Character)
+	}
+	if kanji.Onyomi != "" {
+		setParts = append(setParts, "onyomi = ?")
+		args = append(args, kanji.Onyomi)
+	}
+	if kanji.Kunyomi != "" {
+		setParts = append(setParts, "kunyomi = ?")
+		args = append(args, kanji.Kunyomi)
+	}
+	if kanji.StrokeCount != 0 {
+		setParts = append(setParts, "stroke_count = ?")
+		args = append(args, kanji.StrokeCount)
+	}
+	if kanji.JLPTLevel != 0 {
+		setParts = append(setParts, "jlpt_level = ?")
+		args = append(args, kanji.JLPTLevel)
+	}
+	if kanji.Radicals != "" {
+		setParts = append(setParts, "radicals = ?")
+		args = append(args, kanji.Radicals)
+	}
+	if kanji.Audio != "" {
+		setParts = append(setParts, "audio = ?")
+		args = append(args, kanji.Audio)
+	}
+
+	if len(setParts) == 0 && len(kanji.Meanings) == 0 {
+		return fmt.Errorf("no fields to update")
+	}
+
+	if len(setParts) > 0 {
+		query := fmt.Sprintf(`
+			UPDATE kanji
+			SET %s
+			WHERE id = ?
+		`, strings.Join(setParts, ", "))
+
+		args = append(args, id)
+
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update kanji: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("kanji not found")
+		}
+	}
+
+	return upsertMeanings(ctx, r.db, id, kanji.Meanings)
+}
+
+// Delete deletes a kanji by ID
+//
+// Links to words are removed by the foreign key cascade.
+func (r *kanjiRepository) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM kanji WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete kanji: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("kanji not found")
+	}
+
+	return nil
+}
+
+// LinkWords rebuilds links between a kanji and all words containing its character
+func (r *kanjiRepository) LinkWords(ctx context.Context, kanjiID int) error {
+	return r.relink(ctx,
+		`DELETE FROM kanji_words WHERE kanji_id = ?`,
+		`
+		INSERT INTO kanji_words (kanji_id, word_id)
+		SELECT k.id, w.id
+		FROM kanji k
+		JOIN words w ON LOCATE(k.kanji_character, w.word COLLATE utf8mb4_bin) > 0
+		WHERE k.id = ?
+	`, kanjiID)
+}
+
+// LinkWord rebuilds links between a word and all kanji its Word field contains
+func (r *kanjiRepository) LinkWord(ctx context.Context, wordID int) error {
+	return r.relink(ctx,
+		`DELETE FROM kanji_words WHERE word_id = ?`,
+		`
+		INSERT INTO kanji_words (kanji_id, word_id)
+		SELECT k.id, w.id
+		FROM words w
+		JOIN kanji k ON LOCATE(k.kanji_character, w.word COLLATE utf8mb4_bin) > 0
+		WHERE w.id = ?
+	`, wordID)
+}
+
+// relink executes the delete and insert link queries in a single transaction
+func (r *kanjiRepository) relink(ctx context.Context, deleteQuery, insertQuery string, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, id); err != nil {
+		return fmt.Errorf("failed to delete kanji word links: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, insertQuery, id); err != nil {
+		return fmt.Errorf("failed to insert kanji word links: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,620 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupKanjiTestRepository creates a kanji repository with a mock database
+func setupKanjiTestRepository(t *testing.T) (*kanjiRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewKanjiRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewKanjiRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewKanjiRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestKanjiRepository_GetByJLPTLevel(t *testing.T) {
+	columns := []string{"id", "kanji_character", "onyomi", "kunyomi", "meaning", "stroke_count", "jlpt_level", "radicals", "audio"}
+
+	tests := []struct {
+		name          string
+		level         int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name:  "filtered by level",
+			level: 5,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "スイ", "みず", "water", 4, 5, "水", "http://media/kanji/1").
+					AddRow(2, "火", "カ", "ひ", "fire", 4, 5, "火", nil)
+				mock.ExpectQuery(`SELECT id, kanji_character, onyomi, kunyomi, COALESCE\(\(SELECT t.meaning FROM kanji_meanings t WHERE t.kanji_id = kanji.id AND t.language IN \(\?,\?\) AND t.meaning != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as meaning, stroke_count, jlpt_level, radicals, audio FROM kanji WHERE jlpt_level = \? ORDER BY jlpt_level DESC, stroke_count, id LIMIT \? OFFSET \?`).
+					WithArgs("ru", "en", "ru", "en", 5, 20, 20).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:  "all levels",
+			level: 0,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "スイ", "みず", "water", 4, 5, "水", nil)
+				mock.ExpectQuery(`SELECT .+ FROM kanji ORDER BY`).
+					WithArgs("ru", "en", "ru", "en", 20, 20).
+					WillReturnRows(rows)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:  "database error",
+			level: 5,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			kanji, err := repo.GetByJLPTLevel(context.Background(), tt.level, 2, 20, []string{"ru", "en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, kanji)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, kanji, tt.expectedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_GetWordsByKanjiID(t *testing.T) {
+	columns := []string{"id", "word", "phonetic_clues", "translation", "example", "example_translation",
+		"easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "みず", "вода", "水を飲む", "пить воду", 1, 3, 7, 14, nil, nil).
+					AddRow(4, "水曜日", "すいようび", "среда", "水曜日に会う", "встретиться в среду", 1, 3, 7, 14, "http://media/word/4", nil)
+				mock.ExpectQuery(`SELECT w.id, w.word, w.phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = w.id .+\) as translation, w.example, COALESCE\(\(SELECT t.example_translation FROM word_translations t .+\) as example_translation, .+ FROM kanji_words kw JOIN words w ON w.id = kw.word_id WHERE kw.kanji_id = \?`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "no words",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji_words`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji_words`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			words, err := repo.GetWordsByKanjiID(context.Background(), 1, []string{"ru", "en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, words)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, words, tt.expectedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_GetJLPTLevels(t *testing.T) {
+	tests := []struct {
+		name           string
+		characters     []string
+		setupMock      func(sqlmock.Sqlmock)
+		expectedError  bool
+		expectedLevels map[string]int
+	}{
+		{
+			name:       "success",
+			characters: []string{"水", "曜", "鬱"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"kanji_character", "jlpt_level"}).
+					AddRow("水", 5).
+					AddRow("曜", 4)
+				mock.ExpectQuery(`SELECT kanji_character, jlpt_level FROM kanji WHERE kanji_character IN \(\?,\?,\?\)`).
+					WithArgs("水", "曜", "鬱").
+					WillReturnRows(rows)
+			},
+			expectedLevels: map[string]int{"水": 5, "曜": 4},
+		},
+		{
+			name:           "no characters",
+			characters:     nil,
+			setupMock:      func(mock sqlmock.Sqlmock) {},
+			expectedLevels: map[string]int{},
+		},
+		{
+			name:       "database error",
+			characters: []string{"水"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT kanji_character, jlpt_level FROM kanji`).
+					WithArgs("水").
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			levels, err := repo.GetJLPTLevels(context.Background(), tt.characters)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, levels)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLevels, levels)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_GetAllForAdmin(t *testing.T) {
+	columns := []string{"id", "kanji_character", "meaning", "jlpt_level"}
+
+	tests := []struct {
+		name          string
+		search        string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "without search",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "water", 5).
+					AddRow(2, "火", "", 5)
+				mock.ExpectQuery(`SELECT id, kanji_character, COALESCE\(\(SELECT t.meaning FROM kanji_meanings t WHERE t.kanji_id = kanji.id AND t.language IN \(\?\) AND t.meaning != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as meaning, jlpt_level FROM kanji ORDER BY jlpt_level DESC, id LIMIT \? OFFSET \?`).
+					WithArgs("en", "en", 20, 0).
+					WillReturnRows(rows)
+			},
+			expectedCount: 2,
+		},
+		{
+			name:   "search in meanings of all languages",
+			search: "Wasser",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "water", 5)
+				mock.ExpectQuery(`SELECT .+ FROM kanji WHERE kanji_character = \? OR onyomi LIKE \? OR kunyomi LIKE \? OR EXISTS \(SELECT 1 FROM kanji_meanings m WHERE m.kanji_id = kanji.id AND m.meaning LIKE \?\) ORDER BY`).
+					WithArgs("en", "en", "Wasser", "%Wasser%", "%Wasser%", "%Wasser%", 20, 0).
+					WillReturnRows(rows)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			kanji, err := repo.GetAllForAdmin(context.Background(), 1, 20, tt.search, []string{"en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, kanji)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, kanji, tt.expectedCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_GetByIDAdmin(t *testing.T) {
+	columns := []string{"id", "kanji_character", "onyomi", "kunyomi", "stroke_count", "jlpt_level", "radicals", "audio"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "スイ", "みず", 4, 5, "水", nil)
+				mock.ExpectQuery(`SELECT .+ FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnRows(rows)
+				meanings := sqlmock.NewRows([]string{"language", "meaning"}).
+					AddRow("de", "Wasser").
+					AddRow("en", "water")
+				mock.ExpectQuery(`SELECT language, meaning FROM kanji_meanings WHERE kanji_id = \? ORDER BY language`).
+					WithArgs(1).
+					WillReturnRows(meanings)
+			},
+		},
+		{
+			name: "meanings error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, "水", "スイ", "みず", 4, 5, "水", nil)
+				mock.ExpectQuery(`SELECT .+ FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnRows(rows)
+				mock.ExpectQuery(`SELECT language, meaning FROM kanji_meanings`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: "failed to query kanji meanings",
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: "kanji not found",
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: "failed to get kanji by ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			kanji, err := repo.GetByIDAdmin(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, kanji)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "水", kanji.Character)
+				assert.Equal(t, map[string]string{"de": "Wasser", "en": "water"}, kanji.Meanings)
+				assert.Empty(t, kanji.Audio)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_Create(t *testing.T) {
+	newKanji := func() *models.Kanji {
+		return &models.Kanji{
+			Character:   "水",
+			Onyomi:      "スイ",
+			Kunyomi:     "みず",
+			Meanings:    map[string]string{"en": "water", "ru": "вода"},
+			StrokeCount: 4,
+			JLPTLevel:   5,
+			Radicals:    "水",
+		}
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO kanji \(kanji_character, onyomi, kunyomi, stroke_count, jlpt_level, radicals, audio\)`).
+					WithArgs("水", "スイ", "みず", 4, 5, "水", "").
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec(`INSERT INTO kanji_meanings \(kanji_id, language, meaning\) VALUES \(\?,\?,\?\), \(\?,\?,\?\) ON DUPLICATE KEY UPDATE`).
+					WithArgs(7, "en", "water", 7, "ru", "вода").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "meanings error rolls back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO kanji`).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec(`INSERT INTO kanji_meanings`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: "failed to upsert kanji meanings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			kanji := newKanji()
+			err := repo.Create(context.Background(), kanji)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, kanji.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		kanji         *models.Kanji
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name:  "partial update",
+			kanji: &models.Kanji{Character: "氷", JLPTLevel: 3},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE kanji SET kanji_character = \?, jlpt_level = \? WHERE id = \?`).
+					WithArgs("氷", 3, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "meanings only",
+			kanji: &models.Kanji{Meanings: map[string]string{"de": "Eis"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO kanji_meanings \(kanji_id, language, meaning\) VALUES \(\?,\?,\?\) ON DUPLICATE KEY UPDATE meaning = IF\(VALUES\(meaning\) = '', meaning, VALUES\(meaning\)\)`).
+					WithArgs(1, "de", "Eis").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "no fields to update",
+			kanji:         &models.Kanji{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: "no fields to update",
+		},
+		{
+			name:  "not found",
+			kanji: &models.Kanji{Radicals: "水"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE kanji SET radicals = \? WHERE id = \?`).
+					WithArgs("水", 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: "kanji not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Update(context.Background(), 1, tt.kanji)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM kanji WHERE id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: "kanji not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Delete(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_LinkWords(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM kanji_words WHERE kanji_id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`INSERT INTO kanji_words \(kanji_id, word_id\) SELECT k.id, w.id FROM kanji k JOIN words w ON LOCATE\(k.kanji_character, w.word COLLATE utf8mb4_bin\) > 0 WHERE k.id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "insert error rolls back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM kanji_words`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO kanji_words`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupKanjiTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.LinkWords(context.Background(), 1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestKanjiRepository_LinkWord(t *testing.T) {
+	repo, mock, cleanup := setupKanjiTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM kanji_words WHERE word_id = \?`).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO kanji_words \(kanji_id, word_id\) SELECT k.id, w.id FROM words w JOIN kanji k ON .+ WHERE w.id = \?`).
+		WithArgs(4).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	err := repo.LinkWord(context.Background(), 4)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
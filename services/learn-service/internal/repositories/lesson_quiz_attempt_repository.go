@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+)
+
+type lessonQuizAttemptRepository struct {
+	db *sql.DB
+}
+
+// NewLessonQuizAttemptRepository creates a new lesson quiz attempt repository
+func NewLessonQuizAttemptRepository(db *sql.DB) *lessonQuizAttemptRepository {
+	return &lessonQuizAttemptRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new lesson quiz attempt
+func (r *lessonQuizAttemptRepository) Create(ctx context.Context, attempt *models.LessonQuizAttempt) error {
+	query := `
+		INSERT INTO lesson_quiz_attempts (user_id, block_id, score, answers)
+		VALUES (?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, attempt.UserID, attempt.BlockID, attempt.Score, string(attempt.Answers))
+	if err != nil {
+		return fmt.Errorf("failed to create lesson quiz attempt: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	attempt.ID = int(id)
+	return nil
+}
+
+// GetBestScores retrieves the best scores of a user for the given quiz blocks
+//
+// Blocks without attempts are not included in the result.
+func (r *lessonQuizAttemptRepository) GetBestScores(ctx context.Context, userID int, blockIDs []int) (map[int]int, error) {
+	scores := make(map[int]int)
+	if len(blockIDs) == 0 {
+		return scores, nil
+	}
+
+	placeholders := make([]string, len(blockIDs))
+	args := []any{userID}
+	for i, id := range blockIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT block_id, MAX(score)
+		FROM lesson_quiz_attempts
+		WHERE user_id = ? AND block_id IN (%s)
+		GROUP BY block_id
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query best quiz scores: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var blockID, score int
+		if err := rows.Scan(&blockID, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan best quiz score: %w", err)
+		}
+		scores[blockID] = score
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return scores, nil
+}
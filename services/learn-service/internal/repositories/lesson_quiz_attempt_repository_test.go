@@ -0,0 +1,173 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLessonQuizAttemptTestRepository creates a lesson quiz attempt repository with a mock database
+func setupLessonQuizAttemptTestRepository(t *testing.T) (*lessonQuizAttemptRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewLessonQuizAttemptRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewLessonQuizAttemptRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewLessonQuizAttemptRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestLessonQuizAttemptRepository_Create(t *testing.T) {
+	answers := json.RawMessage(`[{"options":[1]},{"text":"ねこ"}]`)
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedID    int
+		errorContains string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO lesson_quiz_attempts \(user_id, block_id, score, answers\)`).
+					WithArgs(1, 2, 50, string(answers)).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			expectedID: 7,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO lesson_quiz_attempts`).
+					WithArgs(1, 2, 50, string(answers)).
+					WillReturnError(errors.New("database error"))
+			},
+			errorContains: "failed to create lesson quiz attempt",
+		},
+		{
+			name: "last insert id error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO lesson_quiz_attempts`).
+					WithArgs(1, 2, 50, string(answers)).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("id error")))
+			},
+			errorContains: "failed to get last insert id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonQuizAttemptTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			attempt := &models.LessonQuizAttempt{UserID: 1, BlockID: 2, Score: 50, Answers: answers}
+			err := repo.Create(context.Background(), attempt)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, attempt.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLessonQuizAttemptRepository_GetBestScores(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockIDs      []int
+		setupMock     func(sqlmock.Sqlmock)
+		expected      map[int]int
+		errorContains string
+	}{
+		{
+			name:     "success",
+			blockIDs: []int{2, 3, 4},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"block_id", "MAX(score)"}).
+					AddRow(2, 80).
+					AddRow(4, 100)
+				mock.ExpectQuery(`SELECT block_id, MAX\(score\).*FROM lesson_quiz_attempts.*WHERE user_id = \? AND block_id IN \(\?,\?,\?\).*GROUP BY block_id`).
+					WithArgs(1, 2, 3, 4).
+					WillReturnRows(rows)
+			},
+			expected: map[int]int{2: 80, 4: 100},
+		},
+		{
+			name:      "empty block IDs",
+			blockIDs:  []int{},
+			setupMock: func(mock sqlmock.Sqlmock) {},
+			expected:  map[int]int{},
+		},
+		{
+			name:     "database error",
+			blockIDs: []int{2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT block_id, MAX\(score\)`).
+					WithArgs(1, 2).
+					WillReturnError(errors.New("database error"))
+			},
+			errorContains: "failed to query best quiz scores",
+		},
+		{
+			name:     "scan error",
+			blockIDs: []int{2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"block_id", "MAX(score)"}).
+					AddRow("invalid", 80)
+				mock.ExpectQuery(`SELECT block_id, MAX\(score\)`).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			errorContains: "failed to scan best quiz score",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonQuizAttemptTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			scores, err := repo.GetBestScores(context.Background(), 1, tt.blockIDs)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, scores)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, scores)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -59,7 +59,7 @@ func (r *lessonRepository) GetBySlug(ctx context.Context, slug string, userID in
 // GetByID retrieves a lesson by its ID
 func (r *lessonRepository) GetByID(ctx context.Context, id int) (*models.Lesson, error) {
 	query := `
-		SELECT id, slug, course_id, title, short_summary, ` + "`order`" + `
+		SELECT id, slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery
 		FROM lessons
 		WHERE id = ?
 		LIMIT 1
@@ -73,6 +73,7 @@ func (r *lessonRepository) GetByID(ctx context.Context, id int) (*models.Lesson,
 		&lesson.Title,
 		&lesson.ShortSummary,
 		&lesson.Order,
+		&lesson.MinKanaMastery,
 	)
 
 	if err == sql.ErrNoRows {
@@ -88,7 +89,7 @@ func (r *lessonRepository) GetByID(ctx context.Context, id int) (*models.Lesson,
 // GetByCourseID retrieves all lessons for a course, sorted by order
 func (r *lessonRepository) GetByCourseID(ctx context.Context, courseID int) ([]models.Lesson, error) {
 	query := `
-		SELECT id, slug, title, short_summary, ` + "`order`" + `
+		SELECT id, slug, title, short_summary, ` + "`order`" + `, min_kana_mastery
 		FROM lessons
 		WHERE course_id = ?
 		ORDER BY ` + "`order`" + `
@@ -109,6 +110,7 @@ func (r *lessonRepository) GetByCourseID(ctx context.Context, courseID int) ([]m
 			&lesson.Title,
 			&lesson.ShortSummary,
 			&lesson.Order,
+			&lesson.MinKanaMastery,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan lesson: %w", err)
@@ -127,9 +129,11 @@ func (r *lessonRepository) GetByCourseID(ctx context.Context, courseID int) ([]m
 func (r *lessonRepository) GetByCourseIDWithCompletion(ctx context.Context, courseID, userID int) ([]models.LessonListItem, error) {
 	query := `
 		SELECT 
+			l.id,
 			l.slug,
 			l.title,
 			l.` + "`order`" + `,
+			l.min_kana_mastery,
 			CASE WHEN luh.id IS NOT NULL THEN 1 ELSE 0 END as completed
 		FROM lessons l
 		LEFT JOIN lesson_user_history luh ON luh.lesson_id = l.id AND luh.user_id = ? AND luh.course_id = ?
@@ -148,9 +152,11 @@ func (r *lessonRepository) GetByCourseIDWithCompletion(ctx context.Context, cour
 		var lesson models.LessonListItem
 		var completed int
 		err := rows.Scan(
+			&lesson.ID,
 			&lesson.Slug,
 			&lesson.Title,
 			&lesson.Order,
+			&lesson.MinKanaMastery,
 			&completed,
 		)
 		if err != nil {
@@ -263,8 +269,8 @@ func (r *lessonRepository) IncrementOrderForLessons(ctx context.Context, courseI
 // Create creates a new lesson
 func (r *lessonRepository) Create(ctx context.Context, lesson *models.Lesson) error {
 	query := `
-		INSERT INTO lessons (slug, course_id, title, short_summary, ` + "`order`" + `)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO lessons (slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -273,6 +279,7 @@ func (r *lessonRepository) Create(ctx context.Context, lesson *models.Lesson) er
 		lesson.Title,
 		lesson.ShortSummary,
 		lesson.Order,
+		lesson.MinKanaMastery,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create lesson: %w", err)
@@ -373,3 +380,126 @@ func (r *lessonRepository) CheckOwnership(ctx context.Context, id, tutorID int)
 	}
 	return exists, nil
 }
+
+// UpdateMinKanaMastery sets the kana mastery percent needed to unlock a lesson
+func (r *lessonRepository) UpdateMinKanaMastery(ctx context.Context, id, minKanaMastery int) error {
+	query := `UPDATE lessons SET min_kana_mastery = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, minKanaMastery, id)
+	if err != nil {
+		return fmt.Errorf("failed to update lesson kana mastery: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateIDs checks if all lesson IDs exist in the database
+func (r *lessonRepository) ValidateIDs(ctx context.Context, ids []int) (bool, error) {
+	if len(ids) == 0 {
+		return false, fmt.Errorf("lesson IDs list cannot be empty")
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) as count
+		FROM lessons
+		WHERE id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to validate lesson IDs: %w", err)
+	}
+
+	return count == len(ids), nil
+}
+
+// GetPrerequisiteIDs retrieves prerequisite lesson IDs for a set of lessons
+//
+// Returns a map from lesson ID to IDs of its prerequisite lessons, lessons without prerequisites are omitted.
+func (r *lessonRepository) GetPrerequisiteIDs(ctx context.Context, lessonIDs []int) (map[int][]int, error) {
+	prerequisites := make(map[int][]int)
+	if len(lessonIDs) == 0 {
+		return prerequisites, nil
+	}
+
+	placeholders := make([]string, len(lessonIDs))
+	args := make([]any, len(lessonIDs))
+	for i, id := range lessonIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT lesson_id, prerequisite_lesson_id
+		FROM lesson_prerequisites
+		WHERE lesson_id IN (%s)
+		ORDER BY lesson_id, prerequisite_lesson_id
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lesson prerequisites: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lessonID, prerequisiteID int
+		if err := rows.Scan(&lessonID, &prerequisiteID); err != nil {
+			return nil, fmt.Errorf("failed to scan lesson prerequisite: %w", err)
+		}
+		prerequisites[lessonID] = append(prerequisites[lessonID], prerequisiteID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return prerequisites, nil
+}
+
+// SetPrerequisites replaces prerequisite lessons of a lesson
+//
+// Old prerequisites are deleted and new ones are inserted in a single transaction.
+func (r *lessonRepository) SetPrerequisites(ctx context.Context, lessonID int, prerequisiteIDs []int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lesson_prerequisites WHERE lesson_id = ?`, lessonID); err != nil {
+		return fmt.Errorf("failed to delete lesson prerequisites: %w", err)
+	}
+
+	if len(prerequisiteIDs) > 0 {
+		placeholders := make([]string, len(prerequisiteIDs))
+		args := make([]any, 0, len(prerequisiteIDs)*2)
+		for i, prerequisiteID := range prerequisiteIDs {
+			placeholders[i] = "(?, ?)"
+			args = append(args, lessonID, prerequisiteID)
+		}
+
+		query := fmt.Sprintf(`
+			INSERT INTO lesson_prerequisites (lesson_id, prerequisite_lesson_id)
+			VALUES %s
+		`, strings.Join(placeholders, ","))
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert lesson prerequisites: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -136,9 +136,9 @@ func TestLessonRepository_GetByID(t *testing.T) {
 			name: "success",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "course_id", "title", "short_summary", "order"}).
-					AddRow(1, "test-lesson", 1, "Test Lesson", "Summary", 1)
-				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + ` FROM lessons WHERE id = \?`).
+				rows := sqlmock.NewRows([]string{"id", "slug", "course_id", "title", "short_summary", "order", "min_kana_mastery"}).
+					AddRow(1, "test-lesson", 1, "Test Lesson", "Summary", 1, 60)
+				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE id = \?`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -148,7 +148,7 @@ func TestLessonRepository_GetByID(t *testing.T) {
 			name: "lesson not found",
 			id:   999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + ` FROM lessons WHERE id = \?`).
+				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE id = \?`).
 					WithArgs(999).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -159,7 +159,7 @@ func TestLessonRepository_GetByID(t *testing.T) {
 			name: "database error",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + ` FROM lessons WHERE id = \?`).
+				mock.ExpectQuery(`SELECT id, slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE id = \?`).
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -188,6 +188,7 @@ func TestLessonRepository_GetByID(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, 1, result.ID)
 				assert.Equal(t, "test-lesson", result.Slug)
+				assert.Equal(t, 60, result.MinKanaMastery)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -207,10 +208,10 @@ func TestLessonRepository_GetByCourseID(t *testing.T) {
 			name:     "success",
 			courseID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order"}).
-					AddRow(1, "lesson-1", "Lesson 1", "Summary 1", 1).
-					AddRow(2, "lesson-2", "Lesson 2", "Summary 2", 2)
-				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + ` FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
+				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order", "min_kana_mastery"}).
+					AddRow(1, "lesson-1", "Lesson 1", "Summary 1", 1, 0).
+					AddRow(2, "lesson-2", "Lesson 2", "Summary 2", 2, 40)
+				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -221,8 +222,8 @@ func TestLessonRepository_GetByCourseID(t *testing.T) {
 			name:     "empty results",
 			courseID: 999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order"})
-				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + ` FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
+				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order", "min_kana_mastery"})
+				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
 					WithArgs(999).
 					WillReturnRows(rows)
 			},
@@ -233,7 +234,7 @@ func TestLessonRepository_GetByCourseID(t *testing.T) {
 			name:     "database query error",
 			courseID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + ` FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
+				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -244,9 +245,9 @@ func TestLessonRepository_GetByCourseID(t *testing.T) {
 			name:     "scan error",
 			courseID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order"}).
-					AddRow("invalid", "lesson-1", "Lesson 1", "Summary 1", 1)
-				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + ` FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
+				rows := sqlmock.NewRows([]string{"id", "slug", "title", "short_summary", "order", "min_kana_mastery"}).
+					AddRow("invalid", "lesson-1", "Lesson 1", "Summary 1", 1, 0)
+				mock.ExpectQuery(`SELECT id, slug, title, short_summary, ` + "`order`" + `, min_kana_mastery FROM lessons WHERE course_id = \? ORDER BY ` + "`order`").
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -291,9 +292,9 @@ func TestLessonRepository_GetByCourseIDWithCompletion(t *testing.T) {
 			courseID: 1,
 			userID:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"slug", "title", "order", "completed"}).
-					AddRow("lesson-1", "Lesson 1", 1, 1).
-					AddRow("lesson-2", "Lesson 2", 2, 0)
+				rows := sqlmock.NewRows([]string{"id", "slug", "title", "order", "min_kana_mastery", "completed"}).
+					AddRow(1, "lesson-1", "Lesson 1", 1, 0, 1).
+					AddRow(2, "lesson-2", "Lesson 2", 2, 50, 0)
 				mock.ExpectQuery(`SELECT.*FROM lessons l.*WHERE l.course_id = \?`).
 					WithArgs(1, 1, 1).
 					WillReturnRows(rows)
@@ -331,6 +332,9 @@ func TestLessonRepository_GetByCourseIDWithCompletion(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
 				assert.Len(t, result, tt.expectedCount)
+				assert.Equal(t, 2, result[1].ID)
+				assert.Equal(t, 50, result[1].MinKanaMastery)
+				assert.False(t, result[1].Completed)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -695,8 +699,8 @@ func TestLessonRepository_Create(t *testing.T) {
 				Order:        1,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`INSERT INTO lessons \(slug, course_id, title, short_summary, ` + "`order`" + `\) VALUES \(\?, \?, \?, \?, \?\)`).
-					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1).
+				mock.ExpectExec(`INSERT INTO lessons \(slug, course_id, title, short_summary, ` + "`order`" + `, min_kana_mastery\) VALUES \(\?, \?, \?, \?, \?, \?\)`).
+					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1, 0).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 			expectedError: false,
@@ -713,7 +717,7 @@ func TestLessonRepository_Create(t *testing.T) {
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO lessons`).
-					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1).
+					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1, 0).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -729,7 +733,7 @@ func TestLessonRepository_Create(t *testing.T) {
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectExec(`INSERT INTO lessons`).
-					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1).
+					WithArgs("test-lesson", 1, "Test Lesson", "Summary", 1, 0).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
 			},
 			expectedError: true,
@@ -1020,3 +1024,247 @@ func TestLessonRepository_CheckOwnership(t *testing.T) {
 		})
 	}
 }
+
+func TestLessonRepository_UpdateMinKanaMastery(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE lessons SET min_kana_mastery = \? WHERE id = \?`).
+					WithArgs(70, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE lessons SET min_kana_mastery = \? WHERE id = \?`).
+					WithArgs(70, 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.UpdateMinKanaMastery(context.Background(), 1, 70)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLessonRepository_ValidateIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		ids           []int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedValue bool
+		expectedError bool
+	}{
+		{
+			name: "all lessons exist",
+			ids:  []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM lessons WHERE id IN \(\?,\?\)`).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			expectedValue: true,
+		},
+		{
+			name: "some lessons do not exist",
+			ids:  []int{1, 999},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM lessons WHERE id IN \(\?,\?\)`).
+					WithArgs(1, 999).
+					WillReturnRows(rows)
+			},
+			expectedValue: false,
+		},
+		{
+			name:          "empty list",
+			ids:           []int{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: true,
+		},
+		{
+			name: "database error",
+			ids:  []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM lessons`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.ValidateIDs(context.Background(), tt.ids)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.False(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedValue, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLessonRepository_GetPrerequisiteIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		lessonIDs     []int
+		setupMock     func(sqlmock.Sqlmock)
+		expected      map[int][]int
+		expectedError bool
+	}{
+		{
+			name:      "success",
+			lessonIDs: []int{2, 3, 4},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"lesson_id", "prerequisite_lesson_id"}).
+					AddRow(2, 1).
+					AddRow(3, 1).
+					AddRow(3, 7)
+				mock.ExpectQuery(`SELECT lesson_id, prerequisite_lesson_id FROM lesson_prerequisites WHERE lesson_id IN \(\?,\?,\?\)`).
+					WithArgs(2, 3, 4).
+					WillReturnRows(rows)
+			},
+			expected: map[int][]int{2: {1}, 3: {1, 7}},
+		},
+		{
+			name:      "empty list",
+			lessonIDs: []int{},
+			setupMock: func(mock sqlmock.Sqlmock) {},
+			expected:  map[int][]int{},
+		},
+		{
+			name:      "database error",
+			lessonIDs: []int{2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT lesson_id, prerequisite_lesson_id FROM lesson_prerequisites`).
+					WithArgs(2).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetPrerequisiteIDs(context.Background(), tt.lessonIDs)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLessonRepository_SetPrerequisites(t *testing.T) {
+	tests := []struct {
+		name            string
+		prerequisiteIDs []int
+		setupMock       func(sqlmock.Sqlmock)
+		expectedError   bool
+	}{
+		{
+			name:            "success",
+			prerequisiteIDs: []int{1, 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM lesson_prerequisites WHERE lesson_id = \?`).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO lesson_prerequisites \(lesson_id, prerequisite_lesson_id\) VALUES \(\?, \?\),\(\?, \?\)`).
+					WithArgs(3, 1, 3, 7).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:            "empty list removes prerequisites",
+			prerequisiteIDs: []int{},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM lesson_prerequisites WHERE lesson_id = \?`).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:            "insert error rolls back",
+			prerequisiteIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM lesson_prerequisites`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO lesson_prerequisites`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.SetPrerequisites(context.Background(), 3, tt.prerequisiteIDs)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 )
@@ -97,3 +98,44 @@ func (r *lessonUserHistoryRepository) CountCompletedLessonsByCourse(ctx context.
 
 	return count, nil
 }
+
+// GetCompletedLessonIDs retrieves IDs of the given lessons completed by a user
+func (r *lessonUserHistoryRepository) GetCompletedLessonIDs(ctx context.Context, userID int, lessonIDs []int) ([]int, error) {
+	if len(lessonIDs) == 0 {
+		return []int{}, nil
+	}
+
+	placeholders := make([]string, len(lessonIDs))
+	args := []any{userID}
+	for i, id := range lessonIDs {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT DISTINCT lesson_id
+		FROM lesson_user_history
+		WHERE user_id = ? AND lesson_id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query completed lessons: %w", err)
+	}
+	defer rows.Close()
+
+	var completedIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan completed lesson: %w", err)
+		}
+		completedIDs = append(completedIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return completedIDs, nil
+}
@@ -345,3 +345,64 @@ func TestLessonUserHistoryRepository_CountCompletedLessonsByCourse(t *testing.T)
 		})
 	}
 }
+
+func TestLessonUserHistoryRepository_GetCompletedLessonIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		lessonIDs     []int
+		setupMock     func(sqlmock.Sqlmock)
+		expected      []int
+		expectedError bool
+	}{
+		{
+			name:      "success",
+			lessonIDs: []int{1, 2, 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"lesson_id"}).
+					AddRow(1).
+					AddRow(7)
+				mock.ExpectQuery(`SELECT DISTINCT lesson_id FROM lesson_user_history WHERE user_id = \? AND lesson_id IN \(\?,\?,\?\)`).
+					WithArgs(1, 1, 2, 7).
+					WillReturnRows(rows)
+			},
+			expected: []int{1, 7},
+		},
+		{
+			name:      "empty list",
+			lessonIDs: []int{},
+			setupMock: func(mock sqlmock.Sqlmock) {},
+			expected:  []int{},
+		},
+		{
+			name:      "database error",
+			lessonIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT lesson_id FROM lesson_user_history`).
+					WithArgs(1, 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupLessonUserHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetCompletedLessonIDs(context.Background(), 1, tt.lessonIDs)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
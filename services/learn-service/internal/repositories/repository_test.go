@@ -40,7 +40,8 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 	tests := []struct {
 		name          string
 		alphabetType  models.AlphabetType
-		locale        models.Locale
+		languages     []string
+		groups        []models.CharacterGroup
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
 		expectedCount int
@@ -48,12 +49,13 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "success hiragana english",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow(1, "", "a", "あ", "a").
-					AddRow(2, "k", "a", "か", "ka")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters ORDER BY id`).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(1, "", "a", "basic", "あ", "a").
+					AddRow(2, "k", "a", "basic", "か", "ka")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("en", "en").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -62,33 +64,40 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "success katakana russian",
 			alphabetType: models.AlphabetTypeKatakana,
-			locale:       models.LocaleRussian,
+			languages:    []string{"ru", "en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "katakana", "russian_reading"}).
-					AddRow(1, "", "a", "ア", "а").
-					AddRow(2, "k", "a", "カ", "ка")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, katakana AS display_character, russian_reading AS reading FROM characters ORDER BY id`).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "katakana", "reading"}).
+					AddRow(1, "", "a", "basic", "ア", "а").
+					AddRow(2, "k", "a", "basic", "カ", "ка")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, katakana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?,\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("ru", "en", "ru", "en").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 2,
 		},
 		{
-			name:         "invalid alphabet type",
-			alphabetType: "invalid",
-			locale:       models.LocaleEnglish,
+			name:         "success filtered by groups",
+			alphabetType: models.AlphabetTypeHiragana,
+			languages:    []string{"en"},
+			groups:       []models.CharacterGroup{models.CharacterGroupDakuten, models.CharacterGroupYoon},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected for invalid type
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(47, "g", "a", "dakuten", "が", "ga").
+					AddRow(72, "ky", "a", "yoon", "きゃ", "kya")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE character_group IN \(\?,\?\) ORDER BY id`).
+					WithArgs("en", "en", "dakuten", "yoon").
+					WillReturnRows(rows)
 			},
-			expectedError: true,
-			expectedCount: 0,
+			expectedError: false,
+			expectedCount: 2,
 		},
 		{
-			name:         "invalid locale",
-			alphabetType: models.AlphabetTypeHiragana,
-			locale:       "invalid",
+			name:         "invalid alphabet type",
+			alphabetType: "invalid",
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected for invalid locale
+				// No query expected for invalid type
 			},
 			expectedError: true,
 			expectedCount: 0,
@@ -96,9 +105,10 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "database query error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters ORDER BY id`).
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("en", "en").
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -107,11 +117,12 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "scan error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow("invalid", "", "a", "あ", "a") // Invalid type for id
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters ORDER BY id`).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow("invalid", "", "a", "basic", "あ", "a") // Invalid type for id
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("en", "en").
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -120,12 +131,13 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "rows iteration error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow(1, "", "a", "あ", "a").
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(1, "", "a", "basic", "あ", "a").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters ORDER BY id`).
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("en", "en").
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -134,10 +146,11 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 		{
 			name:         "empty result",
 			alphabetType: models.AlphabetTypeKatakana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "katakana", "english_reading"})
-				mock.ExpectQuery(`SELECT id, consonant, vowel, katakana AS display_character, english_reading AS reading FROM characters ORDER BY id`).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "katakana", "reading"})
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, katakana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters ORDER BY id`).
+					WithArgs("en", "en").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -152,7 +165,7 @@ func TestCharactersRepository_GetAll(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetAll(context.Background(), tt.alphabetType, tt.locale)
+			result, err := repo.GetAll(context.Background(), tt.alphabetType, tt.languages, tt.groups)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -178,8 +191,9 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 	tests := []struct {
 		name          string
 		alphabetType  models.AlphabetType
-		locale        models.Locale
+		languages     []string
 		character     string
+		groups        []models.CharacterGroup
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
 		expectedCount int
@@ -187,14 +201,14 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 		{
 			name:         "success with vowel filter",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			character:    "a",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow(1, "", "a", "あ", "a").
-					AddRow(2, "k", "a", "か", "ka")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
-					WithArgs("a", "a").
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(1, "", "a", "basic", "あ", "a").
+					AddRow(2, "k", "a", "basic", "か", "ka")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
+					WithArgs("en", "en", "a", "a").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -203,34 +217,41 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 		{
 			name:         "success with consonant filter",
 			alphabetType: models.AlphabetTypeKatakana,
-			locale:       models.LocaleRussian,
+			languages:    []string{"ru", "en"},
 			character:    "k",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "katakana", "russian_reading"}).
-					AddRow(1, "k", "a", "カ", "ка").
-					AddRow(2, "k", "i", "キ", "ки")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, katakana AS display_character, russian_reading AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
-					WithArgs("k", "k").
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "katakana", "reading"}).
+					AddRow(1, "k", "a", "basic", "カ", "ка").
+					AddRow(2, "k", "i", "basic", "キ", "ки")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, katakana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?,\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
+					WithArgs("ru", "en", "ru", "en", "k", "k").
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 2,
 		},
 		{
-			name:         "invalid alphabet type",
-			alphabetType: "invalid",
-			locale:       models.LocaleEnglish,
-			character:    "a",
+			name:         "success yoon row filtered by group",
+			alphabetType: models.AlphabetTypeHiragana,
+			languages:    []string{"en"},
+			character:    "ky",
+			groups:       []models.CharacterGroup{models.CharacterGroupYoon},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(72, "ky", "a", "yoon", "きゃ", "kya").
+					AddRow(73, "ky", "u", "yoon", "きゅ", "kyu").
+					AddRow(74, "ky", "o", "yoon", "きょ", "kyo")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) AND character_group IN \(\?\) ORDER BY id`).
+					WithArgs("en", "en", "ky", "ky", "yoon").
+					WillReturnRows(rows)
 			},
-			expectedError: true,
-			expectedCount: 0,
+			expectedError: false,
+			expectedCount: 3,
 		},
 		{
-			name:         "invalid locale",
-			alphabetType: models.AlphabetTypeHiragana,
-			locale:       "invalid",
+			name:         "invalid alphabet type",
+			alphabetType: "invalid",
+			languages:    []string{"en"},
 			character:    "a",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				// No query expected
@@ -241,11 +262,11 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 		{
 			name:         "database query error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			character:    "a",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
-					WithArgs("a", "a").
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
+					WithArgs("en", "en", "a", "a").
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -254,13 +275,13 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 		{
 			name:         "scan error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			character:    "a",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow("invalid", "", "a", "あ", "a")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
-					WithArgs("a", "a").
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow("invalid", "", "a", "basic", "あ", "a")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
+					WithArgs("en", "en", "a", "a").
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -269,14 +290,14 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 		{
 			name:         "rows iteration error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			character:    "a",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "hiragana", "english_reading"}).
-					AddRow(1, "", "a", "あ", "a").
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "hiragana", "reading"}).
+					AddRow(1, "", "a", "basic", "あ", "a").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, consonant, vowel, hiragana AS display_character, english_reading AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
-					WithArgs("a", "a").
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE \(consonant = \? OR vowel = \?\) ORDER BY id`).
+					WithArgs("en", "en", "a", "a").
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -291,7 +312,7 @@ func TestCharactersRepository_GetByRowColumn(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetByRowColumn(context.Background(), tt.alphabetType, tt.locale, tt.character)
+			result, err := repo.GetByRowColumn(context.Background(), tt.alphabetType, tt.languages, tt.character, tt.groups)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -311,68 +332,58 @@ func TestCharactersRepository_GetByID(t *testing.T) {
 	tests := []struct {
 		name          string
 		id            int
-		locale        models.Locale
+		languages     []string
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
 		expectedID    int
 	}{
 		{
-			name:   "success english locale",
-			id:     1,
-			locale: models.LocaleEnglish,
+			name:      "success english locale",
+			id:        1,
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "reading", "katakana", "hiragana"}).
-					AddRow(1, "", "a", "a", "ア", "あ")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, english_reading as reading, katakana, hiragana FROM characters WHERE id = \?`).
-					WithArgs(1).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "reading", "katakana", "hiragana"}).
+					AddRow(1, "", "a", "basic", "a", "ア", "あ")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as reading, katakana, hiragana FROM characters WHERE id = \?`).
+					WithArgs("en", "en", 1).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedID:    1,
 		},
 		{
-			name:   "success russian locale",
-			id:     2,
-			locale: models.LocaleRussian,
+			name:      "success russian locale",
+			id:        2,
+			languages: []string{"ru", "en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "reading", "katakana", "hiragana"}).
-					AddRow(2, "k", "a", "ка", "カ", "か")
-				mock.ExpectQuery(`SELECT id, consonant, vowel, russian_reading as reading, katakana, hiragana FROM characters WHERE id = \?`).
-					WithArgs(2).
+				rows := sqlmock.NewRows([]string{"id", "consonant", "vowel", "character_group", "reading", "katakana", "hiragana"}).
+					AddRow(2, "k", "a", "basic", "ка", "カ", "か")
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?,\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as reading, katakana, hiragana FROM characters WHERE id = \?`).
+					WithArgs("ru", "en", "ru", "en", 2).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedID:    2,
 		},
 		{
-			name:   "not found",
-			id:     999,
-			locale: models.LocaleEnglish,
+			name:      "not found",
+			id:        999,
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, consonant, vowel, english_reading as reading, katakana, hiragana FROM characters WHERE id = \?`).
-					WithArgs(999).
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as reading, katakana, hiragana FROM characters WHERE id = \?`).
+					WithArgs("en", "en", 999).
 					WillReturnError(sql.ErrNoRows)
 			},
 			expectedError: true,
 			expectedID:    0,
 		},
 		{
-			name:   "invalid locale",
-			id:     1,
-			locale: "invalid",
+			name:      "database error",
+			id:        1,
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected
-			},
-			expectedError: true,
-			expectedID:    0,
-		},
-		{
-			name:   "database error",
-			id:     1,
-			locale: models.LocaleEnglish,
-			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, consonant, vowel, english_reading as reading, katakana, hiragana FROM characters WHERE id = \?`).
-					WithArgs(1).
+				mock.ExpectQuery(`SELECT id, consonant, vowel, character_group, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as reading, katakana, hiragana FROM characters WHERE id = \?`).
+					WithArgs("en", "en", 1).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -387,7 +398,7 @@ func TestCharactersRepository_GetByID(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetByID(context.Background(), tt.id, tt.locale)
+			result, err := repo.GetByID(context.Background(), tt.id, tt.languages)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -407,7 +418,7 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 	tests := []struct {
 		name          string
 		alphabetType  models.AlphabetType
-		locale        models.Locale
+		languages     []string
 		characterIDs  []int
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
@@ -416,15 +427,15 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 		{
 			name:         "success hiragana english",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				// First query for correct characters
 				rows1 := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow(1, "あ", "a").
 					AddRow(2, "い", "i")
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1, 2).
 					WillReturnRows(rows1)
 
 				// Second query for wrong options (filters by ID, not character)
@@ -443,18 +454,7 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 		{
 			name:         "invalid alphabet type",
 			alphabetType: "invalid",
-			locale:       models.LocaleEnglish,
-			characterIDs: []int{1, 2},
-			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected
-			},
-			expectedError: true,
-			expectedCount: 0,
-		},
-		{
-			name:         "invalid locale",
-			alphabetType: models.AlphabetTypeHiragana,
-			locale:       "invalid",
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				// No query expected
@@ -465,11 +465,11 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 		{
 			name:         "database query error on correct chars",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1, 2).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -478,13 +478,13 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 		{
 			name:         "database query error on wrong options",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows1 := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow(1, "あ", "a")
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
-					WithArgs(1).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1).
 					WillReturnRows(rows1)
 
 				mock.ExpectQuery(`SELECT hiragana AS display_character FROM characters WHERE id NOT IN \(\?\) AND hiragana IS NOT NULL AND hiragana != '' ORDER BY RAND\(\) LIMIT \?`).
@@ -497,13 +497,13 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 		{
 			name:         "insufficient wrong options",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows1 := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow(1, "あ", "a")
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
-					WithArgs(1).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1).
 					WillReturnRows(rows1)
 
 				rows2 := sqlmock.NewRows([]string{"display_character"}).
@@ -524,7 +524,7 @@ func TestCharactersRepository_GetCharactersForReadingTest(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetCharactersForReadingTest(context.Background(), tt.alphabetType, tt.locale, tt.characterIDs)
+			result, err := repo.GetCharactersForReadingTest(context.Background(), tt.alphabetType, tt.languages, tt.characterIDs, nil)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -556,7 +556,7 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 	tests := []struct {
 		name          string
 		alphabetType  models.AlphabetType
-		locale        models.Locale
+		languages     []string
 		characterIDs  []int
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
@@ -565,15 +565,15 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "success katakana russian",
 			alphabetType: models.AlphabetTypeKatakana,
-			locale:       models.LocaleRussian,
+			languages:    []string{"ru", "en"},
 			characterIDs: []int{1, 2, 3},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow(1, "ア", "а").
 					AddRow(2, "イ", "и").
 					AddRow(3, "ウ", "у")
-				mock.ExpectQuery(`SELECT DISTINCT id, katakana AS display_character, russian_reading AS reading FROM characters WHERE id IN \(\?,\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2, 3).
+				mock.ExpectQuery(`SELECT DISTINCT id, katakana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?,\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("ru", "en", "ru", "en", 1, 2, 3).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -582,18 +582,7 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "invalid alphabet type",
 			alphabetType: "invalid",
-			locale:       models.LocaleEnglish,
-			characterIDs: []int{1, 2},
-			setupMock: func(mock sqlmock.Sqlmock) {
-				// No query expected
-			},
-			expectedError: true,
-			expectedCount: 0,
-		},
-		{
-			name:         "invalid locale",
-			alphabetType: models.AlphabetTypeHiragana,
-			locale:       "invalid",
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				// No query expected
@@ -604,11 +593,11 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "database query error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1, 2).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -617,13 +606,13 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "scan error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow("invalid", "あ", "a")
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
-					WithArgs(1).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -632,14 +621,14 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "rows iteration error",
 			alphabetType: models.AlphabetTypeHiragana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "display_character", "reading"}).
 					AddRow(1, "あ", "a").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1, 2).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -648,12 +637,12 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 		{
 			name:         "empty result",
 			alphabetType: models.AlphabetTypeKatakana,
-			locale:       models.LocaleEnglish,
+			languages:    []string{"en"},
 			characterIDs: []int{1, 2, 3, 4, 5},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{"id", "display_character", "reading"})
-				mock.ExpectQuery(`SELECT DISTINCT id, katakana AS display_character, english_reading AS reading FROM characters WHERE id IN \(\?,\?,\?,\?,\?\) ORDER BY RAND\(\)`).
-					WithArgs(1, 2, 3, 4, 5).
+				mock.ExpectQuery(`SELECT DISTINCT id, katakana AS display_character, COALESCE\(\(SELECT t.reading FROM character_readings t WHERE t.character_id = characters.id AND t.language IN \(\?\) AND t.reading != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) AS reading FROM characters WHERE id IN \(\?,\?,\?,\?,\?\) ORDER BY RAND\(\)`).
+					WithArgs("en", "en", 1, 2, 3, 4, 5).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -668,7 +657,7 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetCharactersForWritingTest(context.Background(), tt.alphabetType, tt.locale, tt.characterIDs)
+			result, err := repo.GetCharactersForWritingTest(context.Background(), tt.alphabetType, tt.languages, tt.characterIDs)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -690,6 +679,132 @@ func TestCharactersRepository_GetCharactersForWritingTest(t *testing.T) {
 	}
 }
 
+func TestCharactersRepository_GetCharactersForMatchingTest(t *testing.T) {
+	tests := []struct {
+		name          string
+		alphabetType  models.AlphabetType
+		characterIDs  []int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name:         "success hiragana shown, katakana options",
+			alphabetType: models.AlphabetTypeHiragana,
+			characterIDs: []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows1 := sqlmock.NewRows([]string{"id", "display_character", "option_character"}).
+					AddRow(1, "あ", "ア").
+					AddRow(2, "い", "イ")
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, katakana AS option_character FROM characters WHERE id IN \(\?,\?\) AND katakana IS NOT NULL AND katakana != '' ORDER BY RAND\(\)`).
+					WithArgs(1, 2).
+					WillReturnRows(rows1)
+
+				rows2 := sqlmock.NewRows([]string{"option_character"}).
+					AddRow("ウ").
+					AddRow("エ").
+					AddRow("オ").
+					AddRow("カ")
+				mock.ExpectQuery(`SELECT katakana AS option_character FROM characters WHERE id NOT IN \(\?,\?\) AND katakana IS NOT NULL AND katakana != '' ORDER BY RAND\(\) LIMIT \?`).
+					WithArgs(1, 2, 4).
+					WillReturnRows(rows2)
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+		{
+			name:         "success katakana shown, hiragana options",
+			alphabetType: models.AlphabetTypeKatakana,
+			characterIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows1 := sqlmock.NewRows([]string{"id", "display_character", "option_character"}).
+					AddRow(1, "ア", "あ")
+				mock.ExpectQuery(`SELECT DISTINCT id, katakana AS display_character, hiragana AS option_character FROM characters WHERE id IN \(\?\) AND hiragana IS NOT NULL AND hiragana != '' ORDER BY RAND\(\)`).
+					WithArgs(1).
+					WillReturnRows(rows1)
+
+				rows2 := sqlmock.NewRows([]string{"option_character"}).
+					AddRow("い").
+					AddRow("う")
+				mock.ExpectQuery(`SELECT hiragana AS option_character FROM characters WHERE id NOT IN \(\?\) AND hiragana IS NOT NULL AND hiragana != '' ORDER BY RAND\(\) LIMIT \?`).
+					WithArgs(1, 2).
+					WillReturnRows(rows2)
+			},
+			expectedError: false,
+			expectedCount: 1,
+		},
+		{
+			name:         "invalid alphabet type",
+			alphabetType: "invalid",
+			characterIDs: []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				// No query expected
+			},
+			expectedError: true,
+			expectedCount: 0,
+		},
+		{
+			name:         "database query error on correct chars",
+			alphabetType: models.AlphabetTypeHiragana,
+			characterIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, katakana AS option_character FROM characters WHERE id IN \(\?\)`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+			expectedCount: 0,
+		},
+		{
+			name:         "insufficient wrong options",
+			alphabetType: models.AlphabetTypeHiragana,
+			characterIDs: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows1 := sqlmock.NewRows([]string{"id", "display_character", "option_character"}).
+					AddRow(1, "あ", "ア")
+				mock.ExpectQuery(`SELECT DISTINCT id, hiragana AS display_character, katakana AS option_character FROM characters WHERE id IN \(\?\)`).
+					WithArgs(1).
+					WillReturnRows(rows1)
+
+				rows2 := sqlmock.NewRows([]string{"option_character"}).
+					AddRow("イ") // Only one wrong option, need 2
+				mock.ExpectQuery(`SELECT katakana AS option_character FROM characters WHERE id NOT IN \(\?\)`).
+					WithArgs(1, 2).
+					WillReturnRows(rows2)
+			},
+			expectedError: true,
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetCharactersForMatchingTest(context.Background(), tt.alphabetType, tt.characterIDs, nil)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+				for _, item := range result {
+					assert.NotEmpty(t, item.Character)
+					assert.NotEmpty(t, item.CorrectChar)
+					assert.NotEqual(t, item.Character, item.CorrectChar)
+					assert.Len(t, item.WrongOptions, 2)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestIsVowel(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -746,6 +861,22 @@ func TestIsVowel(t *testing.T) {
 	}
 }
 
+func TestGroupFilter(t *testing.T) {
+	t.Run("no groups", func(t *testing.T) {
+		condition, args := groupFilter("character_group", nil)
+
+		assert.Empty(t, condition)
+		assert.Nil(t, args)
+	})
+
+	t.Run("several groups", func(t *testing.T) {
+		condition, args := groupFilter("c.character_group", []models.CharacterGroup{models.CharacterGroupBasic, models.CharacterGroupHandakuten})
+
+		assert.Equal(t, "c.character_group IN (?,?)", condition)
+		assert.Equal(t, []any{"basic", "handakuten"}, args)
+	})
+}
+
 // setupHistoryTestRepository creates a history repository with a mock database
 func setupHistoryTestRepository(t *testing.T) (*characterLearnHistoryRepository, sqlmock.Sqlmock, func()) {
 	t.Helper()
@@ -788,11 +919,12 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 					"id", "user_id", "character_id",
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 				}).
-					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0).
-					AddRow(2, 1, 2, 0.8, 0.9, 0.7, 0.0, 0.0, 0.0).
-					AddRow(3, 1, 3, 1.0, 1.0, 1.0, 1.0, 1.0, 1.0)
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?,\?,\?\)`).
+					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
+					AddRow(2, 1, 2, 0.8, 0.9, 0.7, 0.0, 0.0, 0.0, 0.0, 0.0).
+					AddRow(3, 1, 3, 1.0, 1.0, 1.0, 1.0, 1.0, 1.0, 0.0, 0.0)
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?,\?,\?\)`).
 					WithArgs(1, 1, 2, 3).
 					WillReturnRows(rows)
 			},
@@ -808,9 +940,10 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 					"id", "user_id", "character_id",
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 				}).
-					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0)
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
+					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0)
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
 					WithArgs(1, 1).
 					WillReturnRows(rows)
 			},
@@ -834,8 +967,9 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 					"id", "user_id", "character_id",
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 				})
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
 					WithArgs(1, 999).
 					WillReturnRows(rows)
 			},
@@ -847,7 +981,7 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 			userID:       1,
 			characterIDs: []int{1},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
 					WithArgs(1, 1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -863,9 +997,10 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 					"id", "user_id", "character_id",
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 				}).
-					AddRow("invalid", 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0)
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
+					AddRow("invalid", 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0)
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
 					WithArgs(1, 1).
 					WillReturnRows(rows)
 			},
@@ -881,10 +1016,11 @@ func TestCharacterLearnHistoryRepository_GetByUserIDAndCharacterIDs(t *testing.T
 					"id", "user_id", "character_id",
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 				}).
-					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0).
+					AddRow(1, 1, 1, 1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
+				mock.ExpectQuery(`SELECT id, user_id, character_id, hiragana_reading_result, hiragana_writing_result, hiragana_listening_result, katakana_reading_result, katakana_writing_result, katakana_listening_result, hiragana_matching_result, katakana_matching_result FROM character_learn_history WHERE user_id = \? AND character_id IN \(\?\)`).
 					WithArgs(1, 1).
 					WillReturnRows(rows)
 			},
@@ -930,11 +1066,12 @@ func TestCharacterLearnHistoryRepository_GetByUserID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 					"hiragana", "katakana",
 				}).
-					AddRow(1.0, 0.5, 0.0, 0.0, 0.0, 0.0, "あ", "ア").
-					AddRow(0.8, 0.9, 0.7, 0.6, 0.5, 0.4, "い", "イ")
-				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
+					AddRow(1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, "あ", "ア").
+					AddRow(0.8, 0.9, 0.7, 0.6, 0.5, 0.4, 0.0, 0.0, "い", "イ")
+				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, character_learn_history.hiragana_matching_result, character_learn_history.katakana_matching_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -948,9 +1085,10 @@ func TestCharacterLearnHistoryRepository_GetByUserID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 					"hiragana", "katakana",
 				})
-				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
+				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, character_learn_history.hiragana_matching_result, character_learn_history.katakana_matching_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
 					WithArgs(999).
 					WillReturnRows(rows)
 			},
@@ -961,7 +1099,7 @@ func TestCharacterLearnHistoryRepository_GetByUserID(t *testing.T) {
 			name:   "database error",
 			userID: 1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
+				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, character_learn_history.hiragana_matching_result, character_learn_history.katakana_matching_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -975,10 +1113,11 @@ func TestCharacterLearnHistoryRepository_GetByUserID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 					"hiragana", "katakana",
 				}).
-					AddRow("invalid", 0.5, 0.0, 0.0, 0.0, 0.0, "あ", "ア")
-				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
+					AddRow("invalid", 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, "あ", "ア")
+				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, character_learn_history.hiragana_matching_result, character_learn_history.katakana_matching_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -992,11 +1131,12 @@ func TestCharacterLearnHistoryRepository_GetByUserID(t *testing.T) {
 				rows := sqlmock.NewRows([]string{
 					"hiragana_reading_result", "hiragana_writing_result", "hiragana_listening_result",
 					"katakana_reading_result", "katakana_writing_result", "katakana_listening_result",
+					"hiragana_matching_result", "katakana_matching_result",
 					"hiragana", "katakana",
 				}).
-					AddRow(1.0, 0.5, 0.0, 0.0, 0.0, 0.0, "あ", "ア").
+					AddRow(1.0, 0.5, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, "あ", "ア").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
+				mock.ExpectQuery(`SELECT character_learn_history.hiragana_reading_result, character_learn_history.hiragana_writing_result, character_learn_history.hiragana_listening_result, character_learn_history.katakana_reading_result, character_learn_history.katakana_writing_result, character_learn_history.katakana_listening_result, character_learn_history.hiragana_matching_result, character_learn_history.katakana_matching_result, characters.hiragana, characters.katakana FROM character_learn_history JOIN characters ON character_learn_history.character_id = characters.id WHERE user_id = \? ORDER BY characters.id ASC`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -1031,6 +1171,7 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 	tests := []struct {
 		name          string
 		histories     []models.CharacterLearnHistory
+		attempts      []models.CharacterTestAttempt
 		setupMock     func(sqlmock.Sqlmock)
 		expectedError bool
 	}{
@@ -1044,8 +1185,8 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO character_learn_history`).
 					WithArgs(
-						1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0,
-						1, 2, 0.0, sqlmock.AnyArg(), 0.0, 0.0, 0.0, 0.0,
+						1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
+						1, 2, 0.0, sqlmock.AnyArg(), 0.0, 0.0, 0.0, 0.0, 0.0, 0.0,
 					).
 					WillReturnResult(sqlmock.NewResult(1, 2))
 				mock.ExpectCommit()
@@ -1060,12 +1201,33 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO character_learn_history`).
-					WithArgs(1, 1, 1.0, 0.0, 0.0, sqlmock.AnyArg(), 0.0, 0.0).
+					WithArgs(1, 1, 1.0, 0.0, 0.0, sqlmock.AnyArg(), 0.0, 0.0, 0.0, 0.0).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit()
 			},
 			expectedError: false,
 		},
+		{
+			name: "success with test attempts",
+			histories: []models.CharacterLearnHistory{
+				{UserID: 1, CharacterID: 1, HiraganaReadingResult: 1.0},
+			},
+			attempts: []models.CharacterTestAttempt{
+				{UserID: 1, CharacterID: 1, AlphabetType: "hiragana", TestType: "reading", Passed: true, ResponseTimeMs: 900},
+				{UserID: 1, CharacterID: 2, AlphabetType: "hiragana", TestType: "reading", Passed: false, ChosenOption: "め"},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO character_learn_history`).
+					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO character_test_attempts \(user_id, character_id, alphabet_type, test_type, passed, chosen_option, response_time_ms\) VALUES \(\?, \?, \?, \?, \?, \?, \?\),\(\?, \?, \?, \?, \?, \?, \?\)`).
+					WithArgs(1, 1, "hiragana", "reading", true, nil, 900, 1, 2, "hiragana", "reading", false, "め", nil).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+		},
 		{
 			name:          "empty histories slice",
 			histories:     []models.CharacterLearnHistory{},
@@ -1090,12 +1252,31 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO character_learn_history`).
-					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0).
+					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
 					WillReturnError(errors.New("insert error"))
 				mock.ExpectRollback()
 			},
 			expectedError: true,
 		},
+		{
+			name: "database error on test attempts insert",
+			histories: []models.CharacterLearnHistory{
+				{UserID: 1, CharacterID: 1, HiraganaReadingResult: 1.0},
+			},
+			attempts: []models.CharacterTestAttempt{
+				{UserID: 1, CharacterID: 1, AlphabetType: "katakana", TestType: "listening", Passed: true},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO character_learn_history`).
+					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO character_test_attempts`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
 		{
 			name: "transaction commit error",
 			histories: []models.CharacterLearnHistory{
@@ -1104,7 +1285,7 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO character_learn_history`).
-					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0).
+					WithArgs(1, 1, 1.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, 0.0).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 				mock.ExpectCommit().WillReturnError(errors.New("commit error"))
 			},
@@ -1119,12 +1300,67 @@ func TestCharacterLearnHistoryRepository_Upsert(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			err := repo.Upsert(context.Background(), tt.histories)
+			err := repo.Upsert(context.Background(), tt.histories, tt.attempts)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCharacterLearnHistoryRepository_GetKanaMastery(t *testing.T) {
+	query := `SELECT COALESCE\(AVG\(COALESCE\(\(\s*h\.hiragana_reading_result \+ h\.hiragana_writing_result \+ h\.hiragana_listening_result \+\s*h\.katakana_reading_result \+ h\.katakana_writing_result \+ h\.katakana_listening_result\s*\) / 6, 0\)\), 0\)\s*FROM characters c\s*LEFT JOIN character_learn_history h ON h\.character_id = c\.id AND h\.user_id = \?`
+
+	tests := []struct {
+		name            string
+		userID          int
+		setupMock       func(sqlmock.Sqlmock)
+		expectedError   bool
+		expectedMastery float32
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"mastery"}).AddRow(0.75)
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expectedError:   false,
+			expectedMastery: 0.75,
+		},
+		{
+			name:   "database error",
+			userID: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(query).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupHistoryTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			mastery, err := repo.GetKanaMastery(context.Background(), tt.userID)
 
 			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMastery, mastery)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet())
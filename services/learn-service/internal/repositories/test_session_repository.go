@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// testSessionRepository implements TestSessionRepository
+type testSessionRepository struct {
+	db *sql.DB
+}
+
+// NewTestSessionRepository creates a new test session repository
+func NewTestSessionRepository(db *sql.DB) *testSessionRepository {
+	return &testSessionRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new test session
+func (r *testSessionRepository) Create(ctx context.Context, session *models.TestSession) error {
+	itemsJSON, err := json.Marshal(session.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test session items: %w", err)
+	}
+
+	query := `
+		INSERT INTO test_sessions (id, user_id, alphabet_type, test_type, items, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.AlphabetType,
+		session.TestType,
+		string(itemsJSON),
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create test session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a test session by its ID
+func (r *testSessionRepository) GetByID(ctx context.Context, id string) (*models.TestSession, error) {
+	query := `
+		SELECT id, user_id, alphabet_type, test_type, items, expires_at, submitted_at
+		FROM test_sessions
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	var session models.TestSession
+	var itemsJSON string
+	var submittedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.AlphabetType,
+		&session.TestType,
+		&itemsJSON,
+		&session.ExpiresAt,
+		&submittedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("test session not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test session by id: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(itemsJSON), &session.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal test session items: %w", err)
+	}
+	if submittedAt.Valid {
+		session.SubmittedAt = &submittedAt.Time
+	}
+
+	return &session, nil
+}
+
+// MarkSubmitted marks a test session as submitted
+//
+// Returns false if the session has already been submitted, which allows to reject replays atomically.
+func (r *testSessionRepository) MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error) {
+	query := `
+		UPDATE test_sessions
+		SET submitted_at = ?
+		WHERE id = ? AND submitted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, submittedAt, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark test session as submitted: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ClearSubmitted marks a submitted test session as not submitted again
+//
+// It is used to release the session when its results could not be saved, so the user can retry the submission.
+func (r *testSessionRepository) ClearSubmitted(ctx context.Context, id string) error {
+	query := `UPDATE test_sessions SET submitted_at = NULL WHERE id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to clear test session submission: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredByUserID deletes all test sessions of a user that expired before the given time
+func (r *testSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error {
+	query := `DELETE FROM test_sessions WHERE user_id = ? AND expires_at < ?`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, before); err != nil {
+		return fmt.Errorf("failed to delete expired test sessions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// localizedColumn returns an expression selecting a column of a translation table in the first language
+// of the chain which has a non-empty value, the expression results in an empty string if no language has it
+//
+// "table" and "column" are the translation table and its column.
+// "match" is the condition matching translation rows to the row of the outer query, such as "word_id = words.id".
+// The returned arguments belong to the placeholders of the expression and must be placed accordingly.
+func localizedColumn(table, column, match string, languages []string) (string, []any) {
+	if len(languages) == 0 {
+		return "''", nil
+	}
+
+	placeholders := queryPlaceholders(len(languages))
+	expression := fmt.Sprintf(
+		"COALESCE((SELECT t.%[2]s FROM %[1]s t WHERE t.%[3]s AND t.language IN (%[4]s) AND t.%[2]s != '' ORDER BY FIELD(t.language, %[4]s) LIMIT 1), '')",
+		table, column, match, placeholders,
+	)
+
+	args := make([]any, 0, len(languages)*2)
+	for range 2 {
+		for _, language := range languages {
+			args = append(args, language)
+		}
+	}
+	return expression, args
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// upsertTranslations inserts translations of a row or replaces the existing ones of the same languages
+//
+// "values" maps language codes to values of "columns" in order. Empty values keep the current values of existing rows,
+// so translations may be updated partially. Languages are written in sorted order.
+func upsertTranslations(ctx context.Context, exec execer, table, keyColumn string, key int, columns []string, values map[string][]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	rows := make([]string, 0, len(values))
+	args := make([]any, 0, len(values)*(len(columns)+2))
+	rowPlaceholders := "(" + queryPlaceholders(len(columns)+2) + ")"
+	for _, language := range slices.Sorted(maps.Keys(values)) {
+		rows = append(rows, rowPlaceholders)
+		args = append(args, key, language)
+		for _, value := range values[language] {
+			args = append(args, value)
+		}
+	}
+
+	updates := make([]string, len(columns))
+	for i, column := range columns {
+		updates[i] = fmt.Sprintf("%[1]s = IF(VALUES(%[1]s) = '', %[1]s, VALUES(%[1]s))", column)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (%s, language, %s)
+		VALUES %s
+		ON DUPLICATE KEY UPDATE %s
+	`, table, keyColumn, strings.Join(columns, ", "), strings.Join(rows, ", "), strings.Join(updates, ", "))
+
+	if _, err := exec.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to upsert %s: %w", strings.ReplaceAll(table, "_", " "), err)
+	}
+	return nil
+}
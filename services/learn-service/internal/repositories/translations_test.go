@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizedColumn(t *testing.T) {
+	tests := []struct {
+		name               string
+		languages          []string
+		expectedExpression string
+		expectedArgs       []any
+	}{
+		{
+			name:               "single language",
+			languages:          []string{"en"},
+			expectedExpression: "COALESCE((SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN (?) AND t.translation != '' ORDER BY FIELD(t.language, ?) LIMIT 1), '')",
+			expectedArgs:       []any{"en", "en"},
+		},
+		{
+			name:               "fallback chain",
+			languages:          []string{"pt-br", "pt", "en"},
+			expectedExpression: "COALESCE((SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN (?,?,?) AND t.translation != '' ORDER BY FIELD(t.language, ?,?,?) LIMIT 1), '')",
+			expectedArgs:       []any{"pt-br", "pt", "en", "pt-br", "pt", "en"},
+		},
+		{
+			name:               "no languages",
+			languages:          nil,
+			expectedExpression: "''",
+			expectedArgs:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, args := localizedColumn("word_translations", "translation", "word_id = words.id", tt.languages)
+
+			assert.Equal(t, tt.expectedExpression, expression)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+}
+
+func TestUpsertTranslations(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        map[string][]string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name:   "success in sorted order",
+			values: map[string][]string{"ru": {"вода", "пить воду"}, "de": {"Wasser", ""}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_translations \(word_id, language, translation, example_translation\) VALUES \(\?,\?,\?,\?\), \(\?,\?,\?,\?\) ON DUPLICATE KEY UPDATE translation = IF\(VALUES\(translation\) = '', translation, VALUES\(translation\)\), example_translation = IF\(VALUES\(example_translation\) = '', example_translation, VALUES\(example_translation\)\)`).
+					WithArgs(1, "de", "Wasser", "", 1, "ru", "вода", "пить воду").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expectedError: false,
+		},
+		{
+			name:          "no translations",
+			values:        nil,
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: false,
+		},
+		{
+			name:   "database error",
+			values: map[string][]string{"en": {"water", ""}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_translations`).
+					WithArgs(1, "en", "water", "").
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+
+			err = upsertTranslations(context.Background(), db, "word_translations", "word_id", 1, []string{"translation", "example_translation"}, tt.values)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// userWordRepository implements UserWordRepository
+type userWordRepository struct {
+	db *sql.DB
+}
+
+// NewUserWordRepository creates a new user word repository
+func NewUserWordRepository(db *sql.DB) *userWordRepository {
+	return &userWordRepository{
+		db: db,
+	}
+}
+
+// GetDecks retrieves all custom decks of a user with the number of words in every deck
+func (r *userWordRepository) GetDecks(ctx context.Context, userId int) ([]models.UserDeck, error) {
+	query := `
+		SELECT d.id, d.user_id, d.name, COUNT(w.id) AS word_count, d.created_at
+		FROM user_decks d
+		LEFT JOIN user_words w ON w.deck_id = d.id
+		WHERE d.user_id = ?
+		GROUP BY d.id, d.user_id, d.name, d.created_at
+		ORDER BY d.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query decks: %w", err)
+	}
+	defer rows.Close()
+
+	decks := []models.UserDeck{}
+	for rows.Next() {
+		var deck models.UserDeck
+		if err := rows.Scan(&deck.ID, &deck.UserID, &deck.Name, &deck.WordCount, &deck.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan deck: %w", err)
+		}
+		decks = append(decks, deck)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return decks, nil
+}
+
+// DeckExists checks if a deck with the given ID belongs to the user
+func (r *userWordRepository) DeckExists(ctx context.Context, userId, deckId int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_decks WHERE id = ? AND user_id = ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, deckId, userId).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check deck existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// DeckExistsByName checks if the user has another deck with the given name
+//
+// "excludeDeckId" parameter is the deck being renamed, 0 when a new deck is created.
+func (r *userWordRepository) DeckExistsByName(ctx context.Context, userId int, name string, excludeDeckId int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_decks WHERE user_id = ? AND name = ? AND id <> ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, userId, name, excludeDeckId).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check deck existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CreateDeck inserts a new deck and sets its ID
+func (r *userWordRepository) CreateDeck(ctx context.Context, deck *models.UserDeck) error {
+	query := `INSERT INTO user_decks (user_id, name) VALUES (?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, deck.UserID, deck.Name)
+	if err != nil {
+		return fmt.Errorf("failed to create deck: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	deck.ID = int(id)
+	return nil
+}
+
+// UpdateDeck renames a deck of the user
+//
+// Renaming a deck to its current name changes no rows, so existence of the deck is checked by DeckExists.
+func (r *userWordRepository) UpdateDeck(ctx context.Context, userId, deckId int, name string) error {
+	query := `UPDATE user_decks SET name = ? WHERE id = ? AND user_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, name, deckId, userId); err != nil {
+		return fmt.Errorf("failed to update deck: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDeck deletes a deck of the user, words of the deck are kept without a deck
+func (r *userWordRepository) DeleteDeck(ctx context.Context, userId, deckId int) error {
+	query := `DELETE FROM user_decks WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, deckId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("deck not found")
+	}
+
+	return nil
+}
+
+// GetWords retrieves custom words of a user, newest first
+//
+// If deckId is not nil, only words of the deck are returned.
+func (r *userWordRepository) GetWords(ctx context.Context, userId int, deckId *int) ([]models.UserWord, error) {
+	query := `
+		SELECT id, user_id, deck_id, word, phonetic_clues, translation, example, example_translation, created_at
+		FROM user_words
+		WHERE user_id = ?`
+	args := []any{userId}
+	if deckId != nil {
+		query += " AND deck_id = ?"
+		args = append(args, *deckId)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.UserWord{}
+	for rows.Next() {
+		var word models.UserWord
+		var wordDeckId sql.NullInt64
+		if err := rows.Scan(
+			&word.ID,
+			&word.UserID,
+			&wordDeckId,
+			&word.Word,
+			&word.PhoneticClues,
+			&word.Translation,
+			&word.Example,
+			&word.ExampleTranslation,
+			&word.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan custom word: %w", err)
+		}
+		if wordDeckId.Valid {
+			id := int(wordDeckId.Int64)
+			word.DeckID = &id
+		}
+		words = append(words, word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return words, nil
+}
+
+// WordExists checks if a custom word with the given ID belongs to the user
+func (r *userWordRepository) WordExists(ctx context.Context, userId, wordId int) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_words WHERE id = ? AND user_id = ?)`
+
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, wordId, userId).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check custom word existence: %w", err)
+	}
+
+	return exists, nil
+}
+
+// CreateWord inserts a new custom word and sets its ID
+func (r *userWordRepository) CreateWord(ctx context.Context, word *models.UserWord) error {
+	query := `
+		INSERT INTO user_words (user_id, deck_id, word, phonetic_clues, translation, example, example_translation)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	var deckId any
+	if word.DeckID != nil {
+		deckId = *word.DeckID
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		word.UserID,
+		deckId,
+		word.Word,
+		word.PhoneticClues,
+		word.Translation,
+		word.Example,
+		word.ExampleTranslation,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create custom word: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	word.ID = int(id)
+	return nil
+}
+
+// UpdateWord updates custom word fields of the user (partial update)
+//
+// Empty fields are left unchanged. A nil DeckID leaves the deck unchanged, 0 removes the word from its deck.
+// Unchanged values change no rows, so existence of the word is checked by WordExists.
+func (r *userWordRepository) UpdateWord(ctx context.Context, userId, wordId int, word *models.UserWord) error {
+	var setParts []string
+	var args []any
+
+	if word.DeckID != nil {
+		setParts = append(setParts, "deck_id = ?")
+		args = append(args, nullableID(*word.DeckID))
+	}
+	if word.Word != "" {
+		setParts = append(setParts, "word = ?")
+		args = append(args, word.Word)
+	}
+	if word.PhoneticClues != "" {
+		setParts = append(setParts, "phonetic_clues = ?")
+		args = append(args, word.PhoneticClues)
+	}
+	if word.Translation != "" {
+		setParts = append(setParts, "translation = ?")
+		args = append(args, word.Translation)
+	}
+	if word.Example != "" {
+		setParts = append(setParts, "example = ?")
+		args = append(args, word.Example)
+	}
+	if word.ExampleTranslation != "" {
+		setParts = append(setParts, "example_translation = ?")
+		args = append(args, word.ExampleTranslation)
+	}
+
+	if len(setParts) == 0 {
+		return fmt.Errorf("no fields to update")
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE user_words
+		SET %s
+		WHERE id = ? AND user_id = ?
+	`, strings.Join(setParts, ", "))
+	args = append(args, wordId, userId)
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update custom word: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWord deletes a custom word of the user together with its review history
+func (r *userWordRepository) DeleteWord(ctx context.Context, userId, wordId int) error {
+	query := `DELETE FROM user_words WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, wordId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom word: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("word not found")
+	}
+
+	return nil
+}
+
+// GetByIDs retrieves custom words of the user by their IDs for a review
+func (r *userWordRepository) GetByIDs(ctx context.Context, userId int, wordIds []int) ([]models.WordResponse, error) {
+	if len(wordIds) == 0 {
+		return []models.WordResponse{}, nil
+	}
+
+	placeholders := make([]string, len(wordIds))
+	args := []any{userId}
+	for i, id := range wordIds {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, word, phonetic_clues, translation, example, example_translation
+		FROM user_words
+		WHERE user_id = ? AND id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	return r.queryReviewWords(ctx, query, args...)
+}
+
+// GetUnseen retrieves custom words of the user which have never been reviewed, oldest first
+func (r *userWordRepository) GetUnseen(ctx context.Context, userId int, limit int) ([]models.WordResponse, error) {
+	query := `
+		SELECT id, word, phonetic_clues, translation, example, example_translation
+		FROM user_words
+		WHERE user_id = ? AND NOT EXISTS (SELECT 1 FROM dictionary_history WHERE user_word_id = user_words.id)
+		ORDER BY created_at, id
+		LIMIT ?
+	`
+
+	return r.queryReviewWords(ctx, query, userId, limit)
+}
+
+// queryReviewWords runs a query selecting custom words in the shape of dictionary words
+func (r *userWordRepository) queryReviewWords(ctx context.Context, query string, args ...any) ([]models.WordResponse, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query custom words: %w", err)
+	}
+	defer rows.Close()
+
+	var words []models.WordResponse
+	for rows.Next() {
+		word := models.WordResponse{IsCustom: true}
+		if err := rows.Scan(
+			&word.ID,
+			&word.Word,
+			&word.PhoneticClues,
+			&word.Translation,
+			&word.Example,
+			&word.ExampleTranslation,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan custom word: %w", err)
+		}
+		words = append(words, word)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return words, nil
+}
+
+// ValidateIDs checks if all custom word IDs exist and belong to the user
+func (r *userWordRepository) ValidateIDs(ctx context.Context, userId int, wordIds []int) (bool, error) {
+	if len(wordIds) == 0 {
+		return false, fmt.Errorf("word IDs list cannot be empty")
+	}
+	// The database counts a repeated ID once, so the IDs are deduplicated before the count is compared
+	wordIds = slices.Compact(slices.Sorted(slices.Values(wordIds)))
+
+	placeholders := make([]string, len(wordIds))
+	args := []any{userId}
+	for i, id := range wordIds {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COUNT(*) as count
+		FROM user_words
+		WHERE user_id = ? AND id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to validate custom word IDs: %w", err)
+	}
+
+	return count == len(wordIds), nil
+}
+
+// CountUnseen counts custom words of the user which have never been reviewed
+func (r *userWordRepository) CountUnseen(ctx context.Context, userId int) (int, error) {
+	query := `
+		SELECT COUNT(*) as count
+		FROM user_words
+		WHERE user_id = ? AND NOT EXISTS (SELECT 1 FROM dictionary_history WHERE user_word_id = user_words.id)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unseen custom words: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,508 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupUserWordTestRepository creates a user word repository with a mock database
+func setupUserWordTestRepository(t *testing.T) (*userWordRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewUserWordRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewUserWordRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewUserWordRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestUserWordRepository_GetDecks(t *testing.T) {
+	createdAt := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []models.UserDeck
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "word_count", "created_at"}).
+					AddRow(1, 1, "Anime", 3, createdAt).
+					AddRow(2, 1, "Work", 0, createdAt)
+				mock.ExpectQuery(`(?s)SELECT d.id, d.user_id, d.name, COUNT\(w.id\) AS word_count.*FROM user_decks d.*LEFT JOIN user_words w ON w.deck_id = d.id.*WHERE d.user_id = \?`).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected: []models.UserDeck{
+				{ID: 1, UserID: 1, Name: "Anime", WordCount: 3, CreatedAt: createdAt},
+				{ID: 2, UserID: 1, Name: "Work", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "no decks",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_decks d`).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "word_count", "created_at"}))
+			},
+			expectedError: false,
+			expected:      []models.UserDeck{},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_decks d`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetDecks(context.Background(), 1)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserWordRepository_CreateDeck(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectExec(`INSERT INTO user_decks \(user_id, name\) VALUES \(\?, \?\)`).
+		WithArgs(1, "Anime").
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	deck := &models.UserDeck{UserID: 1, Name: "Anime"}
+	err := repo.CreateDeck(context.Background(), deck)
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, deck.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_UpdateDeck(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_decks SET name = \? WHERE id = \? AND user_id = \?`).
+					WithArgs("Anime", 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "same name changes no rows",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_decks`).
+					WithArgs("Anime", 2, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE user_decks`).
+					WithArgs("Anime", 2, 1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.UpdateDeck(context.Background(), 1, 2, "Anime")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserWordRepository_DeckExistsByName(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM user_decks WHERE user_id = \? AND name = \? AND id <> \?\)`).
+		WithArgs(1, "Anime", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := repo.DeckExistsByName(context.Background(), 1, "Anime", 2)
+
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_DeleteDeck(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectExec(`DELETE FROM user_decks WHERE id = \? AND user_id = \?`).
+		WithArgs(2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.DeleteDeck(context.Background(), 1, 2)
+
+	require.Error(t, err)
+	assert.Equal(t, "deck not found", err.Error())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_GetWords(t *testing.T) {
+	createdAt := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "deck_id", "word", "phonetic_clues", "translation", "example", "example_translation", "created_at"}
+	deckId := 2
+
+	tests := []struct {
+		name          string
+		deckId        *int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []models.UserWord
+	}{
+		{
+			name: "all words",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(5, 1, 2, "推し", "おし", "favourite", "", "", createdAt).
+					AddRow(4, 1, nil, "残業", "ざんぎょう", "overtime", "", "", createdAt)
+				mock.ExpectQuery(`(?s)SELECT id, user_id, deck_id.*FROM user_words.*WHERE user_id = \? ORDER BY created_at DESC, id DESC`).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected: []models.UserWord{
+				{ID: 5, UserID: 1, DeckID: &deckId, Word: "推し", PhoneticClues: "おし", Translation: "favourite", CreatedAt: createdAt},
+				{ID: 4, UserID: 1, Word: "残業", PhoneticClues: "ざんぎょう", Translation: "overtime", CreatedAt: createdAt},
+			},
+		},
+		{
+			name:   "words of a deck",
+			deckId: &deckId,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_words.*WHERE user_id = \? AND deck_id = \?`).
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedError: false,
+			expected:      []models.UserWord{},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_words`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetWords(context.Background(), 1, tt.deckId)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserWordRepository_CreateWord(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectExec(`(?s)INSERT INTO user_words \(user_id, deck_id, word, phonetic_clues, translation, example, example_translation\)`).
+		WithArgs(1, nil, "推し", "おし", "favourite", "", "").
+		WillReturnResult(sqlmock.NewResult(5, 1))
+
+	word := &models.UserWord{UserID: 1, Word: "推し", PhoneticClues: "おし", Translation: "favourite"}
+	err := repo.CreateWord(context.Background(), word)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, word.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_UpdateWord(t *testing.T) {
+	noDeck := 0
+
+	tests := []struct {
+		name          string
+		word          *models.UserWord
+		setupMock     func(sqlmock.Sqlmock)
+		errorContains string
+	}{
+		{
+			name: "success removing word from deck",
+			word: &models.UserWord{DeckID: &noDeck, Translation: "favourite"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE user_words.*SET deck_id = \?, translation = \?.*WHERE id = \? AND user_id = \?`).
+					WithArgs(nil, "favourite", 5, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "no fields to update",
+			word:          &models.UserWord{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			errorContains: "no fields to update",
+		},
+		{
+			name: "database error",
+			word: &models.UserWord{Word: "推し"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE user_words`).
+					WithArgs("推し", 5, 1).
+					WillReturnError(errors.New("database error"))
+			},
+			errorContains: "failed to update custom word",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.UpdateWord(context.Background(), 1, 5, tt.word)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserWordRepository_DeleteWord(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	mock.ExpectExec(`DELETE FROM user_words WHERE id = \? AND user_id = \?`).
+		WithArgs(5, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.DeleteWord(context.Background(), 1, 5)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_GetByIDs(t *testing.T) {
+	repo, mock, cleanup := setupUserWordTestRepository(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation", "example", "example_translation"}).
+		AddRow(5, "推し", "おし", "favourite", "", "")
+	mock.ExpectQuery(`(?s)SELECT id, word, phonetic_clues, translation.*FROM user_words.*WHERE user_id = \? AND id IN \(\?,\?\)`).
+		WithArgs(1, 5, 6).
+		WillReturnRows(rows)
+
+	result, err := repo.GetByIDs(context.Background(), 1, []int{5, 6})
+
+	require.NoError(t, err)
+	assert.Equal(t, []models.WordResponse{
+		{ID: 5, Word: "推し", PhoneticClues: "おし", Translation: "favourite", IsCustom: true},
+	}, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserWordRepository_GetUnseen(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation", "example", "example_translation"}).
+					AddRow(5, "推し", "おし", "favourite", "", "").
+					AddRow(6, "残業", "ざんぎょう", "overtime", "", "")
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_words.*WHERE user_id = \? AND NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE user_word_id = user_words.id\).*LIMIT \?`).
+					WithArgs(1, 10).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+		{
+			name: "scan error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation", "example", "example_translation"}).
+					AddRow("invalid", "推し", "おし", "favourite", "", "")
+				mock.ExpectQuery(`(?s)SELECT.*FROM user_words`).
+					WithArgs(1, 10).
+					WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetUnseen(context.Background(), 1, 10)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+				for _, word := range result {
+					assert.True(t, word.IsCustom)
+				}
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserWordRepository_ValidateIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		wordIds       []int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      bool
+	}{
+		{
+			name:    "all words belong to the user",
+			wordIds: []int{5, 6},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT COUNT\(\*\) as count.*FROM user_words.*WHERE user_id = \? AND id IN \(\?,\?\)`).
+					WithArgs(1, 5, 6).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			expected: true,
+		},
+		{
+			name:    "word of another user",
+			wordIds: []int{5, 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*FROM user_words`).
+					WithArgs(1, 5, 7).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expected: false,
+		},
+		{
+			name:    "duplicated words are counted once",
+			wordIds: []int{6, 5, 6},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT COUNT\(\*\).*FROM user_words.*id IN \(\?,\?\)`).
+					WithArgs(1, 5, 6).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+			},
+			expected: true,
+		},
+		{
+			name:          "empty word IDs",
+			wordIds:       []int{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupUserWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.ValidateIDs(context.Background(), 1, tt.wordIds)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
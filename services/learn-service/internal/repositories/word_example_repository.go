@@ -0,0 +1,323 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// wordExampleRepository implements WordExampleRepository
+type wordExampleRepository struct {
+	db *sql.DB
+}
+
+// NewWordExampleRepository creates a new word example repository
+func NewWordExampleRepository(db *sql.DB) *wordExampleRepository {
+	return &wordExampleRepository{
+		db: db,
+	}
+}
+
+// GetByWordID retrieves all additional examples of a word in creation order with translations into all languages
+func (r *wordExampleRepository) GetByWordID(ctx context.Context, wordId int) ([]models.WordExample, error) {
+	query := `
+		SELECT id, word_id, example, example_audio, source
+		FROM word_examples
+		WHERE word_id = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wordId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word examples: %w", err)
+	}
+	defer rows.Close()
+
+	examples := []models.WordExample{}
+	for rows.Next() {
+		example, err := scanWordExample(rows)
+		if err != nil {
+			return nil, err
+		}
+		examples = append(examples, *example)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if len(examples) == 0 {
+		return examples, nil
+	}
+
+	exampleIds := make([]int, len(examples))
+	for i, example := range examples {
+		exampleIds[i] = example.ID
+	}
+	translations, err := r.getTranslations(ctx, exampleIds)
+	if err != nil {
+		return nil, err
+	}
+	for i := range examples {
+		examples[i].Translations = translations[examples[i].ID]
+		if examples[i].Translations == nil {
+			examples[i].Translations = map[string]string{}
+		}
+	}
+
+	return examples, nil
+}
+
+// getTranslations retrieves translations of examples into all languages
+//
+// The result maps example IDs to translations by language code, examples without translations are absent.
+func (r *wordExampleRepository) getTranslations(ctx context.Context, exampleIds []int) (map[int]map[string]string, error) {
+	args := make([]any, len(exampleIds))
+	for i, id := range exampleIds {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT example_id, language, translation
+		FROM word_example_translations
+		WHERE example_id IN (%s)
+		ORDER BY example_id, language
+	`, queryPlaceholders(len(exampleIds)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word example translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[int]map[string]string)
+	for rows.Next() {
+		var exampleId int
+		var language, translation string
+		if err := rows.Scan(&exampleId, &language, &translation); err != nil {
+			return nil, fmt.Errorf("failed to scan word example translation: %w", err)
+		}
+		if translations[exampleId] == nil {
+			translations[exampleId] = make(map[string]string)
+		}
+		translations[exampleId][language] = translation
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return translations, nil
+}
+
+// GetByID retrieves an additional example of a word by its ID with translations into all languages
+func (r *wordExampleRepository) GetByID(ctx context.Context, wordId, exampleId int) (*models.WordExample, error) {
+	query := `
+		SELECT id, word_id, example, example_audio, source
+		FROM word_examples
+		WHERE id = ? AND word_id = ?
+	`
+
+	example, err := scanWordExample(r.db.QueryRowContext(ctx, query, exampleId, wordId))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("word example not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := r.getTranslations(ctx, []int{exampleId})
+	if err != nil {
+		return nil, err
+	}
+	example.Translations = translations[exampleId]
+	if example.Translations == nil {
+		example.Translations = map[string]string{}
+	}
+
+	return example, nil
+}
+
+// scanWordExample scans a word example row, NULL audio and source become empty strings
+//
+// sql.ErrNoRows is returned as it is so callers can tell a missing example from other errors.
+func scanWordExample(row interface{ Scan(dest ...any) error }) (*models.WordExample, error) {
+	var example models.WordExample
+	var exampleAudio, source sql.NullString
+	err := row.Scan(
+		&example.ID,
+		&example.WordID,
+		&example.Example,
+		&exampleAudio,
+		&source,
+	)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan word example: %w", err)
+	}
+	example.ExampleAudio = exampleAudio.String
+	example.Source = source.String
+	return &example, nil
+}
+
+// GetLocalizedByWordIDs retrieves additional examples of words with translations in the locale
+//
+// Translations are taken in the first language of the chain which has them.
+// The result maps word IDs to their examples in creation order, words without additional examples are absent.
+func (r *wordExampleRepository) GetLocalizedByWordIDs(ctx context.Context, wordIds []int, languages []string) (map[int][]models.LocalizedWordExample, error) {
+	examples := make(map[int][]models.LocalizedWordExample)
+	if len(wordIds) == 0 {
+		return examples, nil
+	}
+
+	translation, args := localizedColumn("word_example_translations", "translation", "example_id = word_examples.id", languages)
+	for _, id := range wordIds {
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT word_id, example, %s as example_translation, example_audio, source
+		FROM word_examples
+		WHERE word_id IN (%s)
+		ORDER BY word_id, id
+	`, translation, queryPlaceholders(len(wordIds)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word examples: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var example models.LocalizedWordExample
+		var exampleAudio, source sql.NullString
+		if err := rows.Scan(&example.WordID, &example.Example, &example.Translation, &exampleAudio, &source); err != nil {
+			return nil, fmt.Errorf("failed to scan word example: %w", err)
+		}
+		example.ExampleAudio = exampleAudio.String
+		example.Source = source.String
+		examples[example.WordID] = append(examples[example.WordID], example)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return examples, nil
+}
+
+// Create inserts a new word example with its translations and sets its ID
+func (r *wordExampleRepository) Create(ctx context.Context, example *models.WordExample) error {
+	query := `
+		INSERT INTO word_examples (word_id, example, example_audio, source)
+		VALUES (?, ?, ?, ?)
+	`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
+		example.WordID,
+		example.Example,
+		sql.NullString{String: example.ExampleAudio, Valid: example.ExampleAudio != ""},
+		sql.NullString{String: example.Source, Valid: example.Source != ""},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create word example: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	if err := upsertExampleTranslations(ctx, tx, int(id), example.Translations); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	example.ID = int(id)
+	return nil
+}
+
+// upsertExampleTranslations writes translations of an example, empty values keep the current ones
+func upsertExampleTranslations(ctx context.Context, exec execer, exampleId int, translations map[string]string) error {
+	values := make(map[string][]string, len(translations))
+	for language, translation := range translations {
+		values[language] = []string{translation}
+	}
+	return upsertTranslations(ctx, exec, "word_example_translations", "example_id", exampleId, []string{"translation"}, values)
+}
+
+// Update updates word example fields (partial update)
+//
+// Empty fields are left unchanged, translations are written for the listed languages only.
+// Unchanged values change no rows, so existence of the example is checked by GetByID.
+func (r *wordExampleRepository) Update(ctx context.Context, wordId, exampleId int, example *models.WordExample) error {
+	var setParts []string
+	var args []any
+
+	if example.Example != "" {
+		setParts = append(setParts, "example = ?")
+		args = append(args, example.Example)
+	}
+	if example.ExampleAudio != "" {
+		setParts = append(setParts, "example_audio = ?")
+		args = append(args, example.ExampleAudio)
+	}
+	if example.Source != "" {
+		setParts = append(setParts, "source = ?")
+		args = append(args, example.Source)
+	}
+
+	if len(setParts) == 0 && len(example.Translations) == 0 {
+		return fmt.Errorf("no fields to update")
+	}
+
+	if len(setParts) > 0 {
+		query := fmt.Sprintf(`
+			UPDATE word_examples
+			SET %s
+			WHERE id = ? AND word_id = ?
+		`, strings.Join(setParts, ", "))
+		args = append(args, exampleId, wordId)
+
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to update word example: %w", err)
+		}
+	}
+
+	return upsertExampleTranslations(ctx, r.db, exampleId, example.Translations)
+}
+
+// Delete deletes an additional example of a word
+func (r *wordExampleRepository) Delete(ctx context.Context, wordId, exampleId int) error {
+	query := `DELETE FROM word_examples WHERE id = ? AND word_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, exampleId, wordId)
+	if err != nil {
+		return fmt.Errorf("failed to delete word example: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("word example not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,467 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWordExampleTestRepository creates a word example repository with a mock database
+func setupWordExampleTestRepository(t *testing.T) (*wordExampleRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewWordExampleRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+var (
+	wordExampleColumns            = []string{"id", "word_id", "example", "example_audio", "source"}
+	wordExampleTranslationColumns = []string{"example_id", "language", "translation"}
+)
+
+func TestNewWordExampleRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewWordExampleRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestWordExampleRepository_GetByWordID(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      []models.WordExample
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(wordExampleColumns).
+					AddRow(1, 5, "水が冷たい", "http://media/api/v6/media/a.mp3", "NHK Easy").
+					AddRow(2, 5, "水をください", nil, nil)
+				mock.ExpectQuery(`(?s)SELECT id, word_id, example, example_audio, source.*FROM word_examples.*WHERE word_id = \?.*ORDER BY id`).
+					WithArgs(5).
+					WillReturnRows(rows)
+				translations := sqlmock.NewRows(wordExampleTranslationColumns).
+					AddRow(1, "en", "The water is cold").
+					AddRow(1, "ru", "Вода холодная")
+				mock.ExpectQuery(`(?s)SELECT example_id, language, translation.*FROM word_example_translations.*WHERE example_id IN \(\?,\?\)`).
+					WithArgs(1, 2).
+					WillReturnRows(translations)
+			},
+			expectedError: false,
+			expected: []models.WordExample{
+				{ID: 1, WordID: 5, Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold", "ru": "Вода холодная"},
+					ExampleAudio: "http://media/api/v6/media/a.mp3", Source: "NHK Easy"},
+				{ID: 2, WordID: 5, Example: "水をください", Translations: map[string]string{}},
+			},
+		},
+		{
+			name: "no examples",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(wordExampleColumns))
+			},
+			expectedError: false,
+			expected:      []models.WordExample{},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+					WithArgs(5).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name: "translations database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+					WithArgs(5).
+					WillReturnRows(sqlmock.NewRows(wordExampleColumns).AddRow(1, 5, "水が冷たい", nil, nil))
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_example_translations`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordExampleTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetByWordID(context.Background(), 5)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordExampleRepository_GetByID(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+		expected      *models.WordExample
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(wordExampleColumns).
+					AddRow(2, 5, "水をください", nil, "Genki I")
+				mock.ExpectQuery(`(?s)SELECT id, word_id, example, example_audio, source.*FROM word_examples.*WHERE id = \? AND word_id = \?`).
+					WithArgs(2, 5).
+					WillReturnRows(rows)
+				translations := sqlmock.NewRows(wordExampleTranslationColumns).
+					AddRow(2, "de", "Wasser, bitte").
+					AddRow(2, "en", "Water, please")
+				mock.ExpectQuery(`(?s)SELECT example_id, language, translation.*FROM word_example_translations.*WHERE example_id IN \(\?\)`).
+					WithArgs(2).
+					WillReturnRows(translations)
+			},
+			expected: &models.WordExample{ID: 2, WordID: 5, Example: "水をください",
+				Translations: map[string]string{"de": "Wasser, bitte", "en": "Water, please"}, Source: "Genki I"},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+					WithArgs(2, 5).
+					WillReturnRows(sqlmock.NewRows(wordExampleColumns))
+			},
+			expectedError: "word example not found",
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+					WithArgs(2, 5).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: "failed to scan word example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordExampleTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetByID(context.Background(), 5, 2)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordExampleRepository_GetLocalizedByWordIDs(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo, mock, cleanup := setupWordExampleTestRepository(t)
+		defer cleanup()
+
+		rows := sqlmock.NewRows([]string{"word_id", "example", "example_translation", "example_audio", "source"}).
+			AddRow(1, "水が冷たい", "The water is cold", "http://media/api/v6/media/a.mp3", nil).
+			AddRow(1, "水をください", "Water, please", nil, "Genki I").
+			AddRow(3, "猫がいる", "There is a cat", nil, nil)
+		mock.ExpectQuery(`(?s)SELECT word_id, example, COALESCE\(\(SELECT t.translation FROM word_example_translations t WHERE t.example_id = word_examples.id AND t.language IN \(\?,\?\) .*\), ''\) as example_translation.*FROM word_examples.*WHERE word_id IN \(\?,\?,\?\).*ORDER BY word_id, id`).
+			WithArgs("de", "en", "de", "en", 1, 2, 3).
+			WillReturnRows(rows)
+
+		result, err := repo.GetLocalizedByWordIDs(context.Background(), []int{1, 2, 3}, []string{"de", "en"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[int][]models.LocalizedWordExample{
+			1: {
+				{WordID: 1, Example: "水が冷たい", Translation: "The water is cold", ExampleAudio: "http://media/api/v6/media/a.mp3"},
+				{WordID: 1, Example: "水をください", Translation: "Water, please", Source: "Genki I"},
+			},
+			3: {
+				{WordID: 3, Example: "猫がいる", Translation: "There is a cat"},
+			},
+		}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty word IDs", func(t *testing.T) {
+		repo, mock, cleanup := setupWordExampleTestRepository(t)
+		defer cleanup()
+
+		result, err := repo.GetLocalizedByWordIDs(context.Background(), []int{}, []string{"en"})
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo, mock, cleanup := setupWordExampleTestRepository(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`(?s)SELECT.*FROM word_examples`).
+			WithArgs("ru", "en", "ru", "en", 1).
+			WillReturnError(errors.New("database error"))
+
+		result, err := repo.GetLocalizedByWordIDs(context.Background(), []int{1}, []string{"ru", "en"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestWordExampleRepository_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		example       *models.WordExample
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedID    int
+	}{
+		{
+			name: "success with audio and source",
+			example: &models.WordExample{WordID: 5, Example: "水が冷たい",
+				Translations: map[string]string{"ru": "Вода холодная", "en": "The water is cold", "de": "Das Wasser ist kalt"},
+				ExampleAudio: "http://media/api/v6/media/a.mp3", Source: "NHK Easy"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO word_examples \(word_id, example, example_audio, source\).*VALUES \(\?, \?, \?, \?\)`).
+					WithArgs(5, "水が冷たい", "http://media/api/v6/media/a.mp3", "NHK Easy").
+					WillReturnResult(sqlmock.NewResult(7, 1))
+				mock.ExpectExec(`(?s)INSERT INTO word_example_translations \(example_id, language, translation\).*VALUES \(\?,\?,\?\), \(\?,\?,\?\), \(\?,\?,\?\).*ON DUPLICATE KEY UPDATE`).
+					WithArgs(7, "de", "Das Wasser ist kalt", 7, "en", "The water is cold", 7, "ru", "Вода холодная").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			expectedID: 7,
+		},
+		{
+			name:    "success without audio and source",
+			example: &models.WordExample{WordID: 5, Example: "水をください", Translations: map[string]string{"en": "Water, please"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO word_examples`).
+					WithArgs(5, "水をください", nil, nil).
+					WillReturnResult(sqlmock.NewResult(8, 1))
+				mock.ExpectExec(`(?s)INSERT INTO word_example_translations`).
+					WithArgs(8, "en", "Water, please").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedID: 8,
+		},
+		{
+			name:    "database error",
+			example: &models.WordExample{WordID: 5, Example: "水をください"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO word_examples`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+		{
+			name:    "translations database error",
+			example: &models.WordExample{WordID: 5, Example: "水をください", Translations: map[string]string{"en": "Water, please"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`(?s)INSERT INTO word_examples`).
+					WillReturnResult(sqlmock.NewResult(8, 1))
+				mock.ExpectExec(`(?s)INSERT INTO word_example_translations`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordExampleTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Create(context.Background(), tt.example)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, tt.example.ID)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordExampleRepository_Update(t *testing.T) {
+	tests := []struct {
+		name          string
+		example       *models.WordExample
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name:    "success partial update",
+			example: &models.WordExample{Translations: map[string]string{"en": "Cold water"}, Source: "Genki I"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE word_examples.*SET source = \?.*WHERE id = \? AND word_id = \?`).
+					WithArgs("Genki I", 2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`(?s)INSERT INTO word_example_translations.*ON DUPLICATE KEY UPDATE translation = IF\(VALUES\(translation\) = '', translation, VALUES\(translation\)\)`).
+					WithArgs(2, "en", "Cold water").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "success translations only",
+			example: &models.WordExample{Translations: map[string]string{"pt-br": "Água fria"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)INSERT INTO word_example_translations`).
+					WithArgs(2, "pt-br", "Água fria").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:    "success audio update",
+			example: &models.WordExample{ExampleAudio: "http://media/api/v6/media/b.mp3"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE word_examples.*SET example_audio = \?`).
+					WithArgs("http://media/api/v6/media/b.mp3", 2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:          "no fields to update",
+			example:       &models.WordExample{},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: true,
+		},
+		{
+			name:    "database error",
+			example: &models.WordExample{Example: "水が冷たい"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`(?s)UPDATE word_examples`).
+					WithArgs("水が冷たい", 2, 5).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordExampleTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Update(context.Background(), 5, 2, tt.example)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordExampleRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM word_examples WHERE id = \? AND word_id = \?`).
+					WithArgs(2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM word_examples`).
+					WithArgs(2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: "word example not found",
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM word_examples`).
+					WithArgs(2, 5).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: "failed to delete word example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordExampleTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Delete(context.Background(), 5, 2)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
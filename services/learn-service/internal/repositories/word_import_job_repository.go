@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// wordImportJobRepository implements WordImportJobRepository
+type wordImportJobRepository struct {
+	db *sql.DB
+}
+
+// NewWordImportJobRepository creates a new word import job repository
+func NewWordImportJobRepository(db *sql.DB) *wordImportJobRepository {
+	return &wordImportJobRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new word import job and sets its ID
+func (r *wordImportJobRepository) Create(ctx context.Context, job *models.WordImportJob) error {
+	errorsJSON, err := marshalImportErrors(job.Errors)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO word_import_jobs (filename, format, status, total, processed, created, skipped, failed, errors, truncated_errors)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		job.Filename,
+		job.Format,
+		job.Status,
+		job.Total,
+		job.Processed,
+		job.Created,
+		job.Skipped,
+		job.Failed,
+		errorsJSON,
+		job.TruncatedErrors,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create word import job: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	job.ID = int(id)
+
+	return nil
+}
+
+// UpdateProgress saves the status, counters and error report of a word import job
+func (r *wordImportJobRepository) UpdateProgress(ctx context.Context, job *models.WordImportJob) error {
+	errorsJSON, err := marshalImportErrors(job.Errors)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE word_import_jobs
+		SET status = ?, processed = ?, created = ?, skipped = ?, failed = ?, errors = ?, truncated_errors = ?, finished_at = ?
+		WHERE id = ?
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		job.Status,
+		job.Processed,
+		job.Created,
+		job.Skipped,
+		job.Failed,
+		errorsJSON,
+		job.TruncatedErrors,
+		job.FinishedAt,
+		job.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update word import job: %w", err)
+	}
+
+	return nil
+}
+
+// FailRunning marks all running word import jobs as failed and returns their number
+//
+// It is meant to be called at startup, when no import can be running, so jobs interrupted by a restart are finished.
+func (r *wordImportJobRepository) FailRunning(ctx context.Context) (int, error) {
+	query := `
+		UPDATE word_import_jobs
+		SET status = ?, finished_at = CURRENT_TIMESTAMP
+		WHERE status = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, models.WordImportStatusFailed, models.WordImportStatusRunning)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail running word import jobs: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetByID retrieves a word import job by its ID
+func (r *wordImportJobRepository) GetByID(ctx context.Context, id int) (*models.WordImportJob, error) {
+	query := `
+		SELECT id, filename, format, status, total, processed, created, skipped, failed, errors, truncated_errors, created_at, finished_at
+		FROM word_import_jobs
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	var job models.WordImportJob
+	var errorsJSON string
+	var finishedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&job.ID,
+		&job.Filename,
+		&job.Format,
+		&job.Status,
+		&job.Total,
+		&job.Processed,
+		&job.Created,
+		&job.Skipped,
+		&job.Failed,
+		&errorsJSON,
+		&job.TruncatedErrors,
+		&job.CreatedAt,
+		&finishedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("word import job not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word import job by id: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(errorsJSON), &job.Errors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal word import errors: %w", err)
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
+
+// marshalImportErrors encodes an error report, an empty report is stored as an empty array
+func marshalImportErrors(importErrors []models.WordImportError) (string, error) {
+	if importErrors == nil {
+		importErrors = []models.WordImportError{}
+	}
+	errorsJSON, err := json.Marshal(importErrors)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal word import errors: %w", err)
+	}
+	return string(errorsJSON), nil
+}
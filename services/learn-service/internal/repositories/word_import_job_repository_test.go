@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWordImportJobTestRepository creates a word import job repository with a mock database
+func setupWordImportJobTestRepository(t *testing.T) (*wordImportJobRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewWordImportJobRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewWordImportJobRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewWordImportJobRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestWordImportJobRepository_Create(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedID    int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_import_jobs \(filename, format, status, total, processed, created, skipped, failed, errors, truncated_errors\)`).
+					WithArgs("deck.apkg", models.WordImportFormatAPKG, models.WordImportStatusRunning, 10, 0, 0, 0, 0, `[]`, 0).
+					WillReturnResult(sqlmock.NewResult(7, 1))
+			},
+			expectedError: false,
+			expectedID:    7,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_import_jobs`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordImportJobTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			job := &models.WordImportJob{
+				Filename: "deck.apkg",
+				Format:   models.WordImportFormatAPKG,
+				Status:   models.WordImportStatusRunning,
+				Total:    10,
+			}
+			err := repo.Create(context.Background(), job)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedID, job.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordImportJobRepository_UpdateProgress(t *testing.T) {
+	finishedAt := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	job := &models.WordImportJob{
+		ID:              7,
+		Status:          models.WordImportStatusCompleted,
+		Total:           3,
+		Processed:       3,
+		Created:         1,
+		Skipped:         1,
+		Failed:          1,
+		Errors:          []models.WordImportError{{Row: 2, Word: "水", Error: "word already exists"}},
+		TruncatedErrors: 4,
+		FinishedAt:      &finishedAt,
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_import_jobs SET status = \?, processed = \?, created = \?, skipped = \?, failed = \?, errors = \?, truncated_errors = \?, finished_at = \? WHERE id = \?`).
+					WithArgs(models.WordImportStatusCompleted, 3, 1, 1, 1, `[{"row":2,"word":"水","error":"word already exists"}]`, 4, &finishedAt, 7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_import_jobs`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordImportJobTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.UpdateProgress(context.Background(), job)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordImportJobRepository_FailRunning(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expected      int
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_import_jobs SET status = \?, finished_at = CURRENT_TIMESTAMP WHERE status = \?`).
+					WithArgs(models.WordImportStatusFailed, models.WordImportStatusRunning).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			expected: 2,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_import_jobs`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordImportJobTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			count, err := repo.FailRunning(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Equal(t, 0, count)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, count)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordImportJobRepository_GetByID(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	finishedAt := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	columns := []string{"id", "filename", "format", "status", "total", "processed", "created", "skipped", "failed", "errors", "truncated_errors", "created_at", "finished_at"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+		validate      func(*testing.T, *models.WordImportJob)
+	}{
+		{
+			name: "running job",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(7, "deck.apkg", "apkg", "Running", 10, 4, 3, 1, 0, `[]`, 0, createdAt, nil)
+				mock.ExpectQuery(`SELECT id, filename, format, status, total, processed, created, skipped, failed, errors, truncated_errors, created_at, finished_at FROM word_import_jobs WHERE id = \?`).
+					WithArgs(7).
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, job *models.WordImportJob) {
+				assert.Equal(t, models.WordImportFormatAPKG, job.Format)
+				assert.Equal(t, models.WordImportStatusRunning, job.Status)
+				assert.Equal(t, 10, job.Total)
+				assert.Equal(t, 4, job.Processed)
+				assert.Empty(t, job.Errors)
+				assert.Nil(t, job.FinishedAt)
+			},
+		},
+		{
+			name: "completed job",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(7, "words.csv", "csv", "Completed", 2, 2, 1, 0, 1, `[{"row":2,"error":"word is empty"}]`, 3, createdAt, finishedAt)
+				mock.ExpectQuery(`SELECT .+ FROM word_import_jobs`).
+					WithArgs(7).
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, job *models.WordImportJob) {
+				assert.Equal(t, models.WordImportStatusCompleted, job.Status)
+				assert.Equal(t, []models.WordImportError{{Row: 2, Error: "word is empty"}}, job.Errors)
+				assert.Equal(t, 3, job.TruncatedErrors)
+				require.NotNil(t, job.FinishedAt)
+				assert.Equal(t, finishedAt, *job.FinishedAt)
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM word_import_jobs`).
+					WithArgs(7).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: "word import job not found",
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM word_import_jobs`).
+					WithArgs(7).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: "failed to get word import job by id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordImportJobTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			job, err := repo.GetByID(context.Background(), 7)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, job)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				tt.validate(t, job)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
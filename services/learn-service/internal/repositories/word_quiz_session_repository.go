@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// wordQuizSessionRepository implements WordQuizSessionRepository
+type wordQuizSessionRepository struct {
+	db *sql.DB
+}
+
+// NewWordQuizSessionRepository creates a new word quiz session repository
+func NewWordQuizSessionRepository(db *sql.DB) *wordQuizSessionRepository {
+	return &wordQuizSessionRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new word quiz session
+func (r *wordQuizSessionRepository) Create(ctx context.Context, session *models.WordQuizSession) error {
+	itemsJSON, err := json.Marshal(session.Items)
+	if err != nil {
+		return fmt.Errorf("failed to marshal word quiz session items: %w", err)
+	}
+
+	query := `
+		INSERT INTO word_quiz_sessions (id, user_id, mode, items, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		session.ID,
+		session.UserID,
+		session.Mode,
+		string(itemsJSON),
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create word quiz session: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a word quiz session by its ID
+func (r *wordQuizSessionRepository) GetByID(ctx context.Context, id string) (*models.WordQuizSession, error) {
+	query := `
+		SELECT id, user_id, mode, items, expires_at, submitted_at
+		FROM word_quiz_sessions
+		WHERE id = ?
+		LIMIT 1
+	`
+
+	var session models.WordQuizSession
+	var itemsJSON string
+	var submittedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.Mode,
+		&itemsJSON,
+		&session.ExpiresAt,
+		&submittedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("word quiz session not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word quiz session by id: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(itemsJSON), &session.Items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal word quiz session items: %w", err)
+	}
+	if submittedAt.Valid {
+		session.SubmittedAt = &submittedAt.Time
+	}
+
+	return &session, nil
+}
+
+// MarkSubmitted marks a word quiz session as submitted
+//
+// Returns false if the session has already been submitted, which allows to reject replays atomically.
+func (r *wordQuizSessionRepository) MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error) {
+	query := `
+		UPDATE word_quiz_sessions
+		SET submitted_at = ?
+		WHERE id = ? AND submitted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, submittedAt, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark word quiz session as submitted: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// ClearSubmitted marks a submitted word quiz session as not submitted again
+//
+// It is used to release the session when its results could not be saved, so the user can retry the submission.
+func (r *wordQuizSessionRepository) ClearSubmitted(ctx context.Context, id string) error {
+	query := `UPDATE word_quiz_sessions SET submitted_at = NULL WHERE id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to clear word quiz session submission: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredByUserID deletes all word quiz sessions of a user that expired before the given time
+func (r *wordQuizSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error {
+	query := `DELETE FROM word_quiz_sessions WHERE user_id = ? AND expires_at < ?`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, before); err != nil {
+		return fmt.Errorf("failed to delete expired word quiz sessions: %w", err)
+	}
+
+	return nil
+}
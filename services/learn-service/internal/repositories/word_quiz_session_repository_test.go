@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupWordQuizSessionTestRepository creates a word quiz session repository with a mock database
+func setupWordQuizSessionTestRepository(t *testing.T) (*wordQuizSessionRepository, sqlmock.Sqlmock, func()) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	repo := NewWordQuizSessionRepository(db)
+
+	cleanup := func() {
+		db.Close()
+	}
+
+	return repo, mock, cleanup
+}
+
+func TestNewWordQuizSessionRepository(t *testing.T) {
+	db := &sql.DB{}
+
+	repo := NewWordQuizSessionRepository(db)
+
+	assert.NotNil(t, repo)
+	assert.Equal(t, db, repo.db)
+}
+
+func TestWordQuizSessionRepository_Create(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	session := &models.WordQuizSession{
+		ID:        "5f0c6c1e-9f63-4d5e-8a5a-0d0f8f6f4a10",
+		UserID:    1,
+		Mode:      models.WordQuizModeChoice,
+		Items:     []models.WordQuizSessionItem{{WordID: 1, CorrectAnswer: "water"}},
+		ExpiresAt: expiresAt,
+	}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_quiz_sessions \(id, user_id, mode, items, expires_at\)`).
+					WithArgs(session.ID, 1, models.WordQuizModeChoice, `[{"wordId":1,"correctAnswer":"water"}]`, expiresAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO word_quiz_sessions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordQuizSessionTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.Create(context.Background(), session)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordQuizSessionRepository_GetByID(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	submittedAt := time.Date(2026, 1, 2, 10, 10, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "mode", "items", "expires_at", "submitted_at"}
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError string
+		validate      func(*testing.T, *models.WordQuizSession)
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("quiz-1", 1, "listening", `[{"wordId":2,"correctAnswer":"火"}]`, expiresAt, nil)
+				mock.ExpectQuery(`SELECT id, user_id, mode, items, expires_at, submitted_at FROM word_quiz_sessions WHERE id = \?`).
+					WithArgs("quiz-1").
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, session *models.WordQuizSession) {
+				assert.Equal(t, models.WordQuizModeListening, session.Mode)
+				require.Len(t, session.Items, 1)
+				assert.Equal(t, "火", session.Items[0].CorrectAnswer)
+				assert.Equal(t, expiresAt, session.ExpiresAt)
+				assert.Nil(t, session.SubmittedAt)
+			},
+		},
+		{
+			name: "submitted session",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("quiz-1", 1, "choice", `[]`, expiresAt, submittedAt)
+				mock.ExpectQuery(`SELECT .+ FROM word_quiz_sessions`).
+					WithArgs("quiz-1").
+					WillReturnRows(rows)
+			},
+			validate: func(t *testing.T, session *models.WordQuizSession) {
+				require.NotNil(t, session.SubmittedAt)
+				assert.Equal(t, submittedAt, *session.SubmittedAt)
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .+ FROM word_quiz_sessions`).
+					WithArgs("quiz-1").
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectedError: "word quiz session not found",
+		},
+		{
+			name: "invalid items",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("quiz-1", 1, "choice", `not json`, expiresAt, nil)
+				mock.ExpectQuery(`SELECT .+ FROM word_quiz_sessions`).
+					WithArgs("quiz-1").
+					WillReturnRows(rows)
+			},
+			expectedError: "failed to unmarshal word quiz session items",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordQuizSessionTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			session, err := repo.GetByID(context.Background(), "quiz-1")
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+				tt.validate(t, session)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordQuizSessionRepository_MarkSubmitted(t *testing.T) {
+	submittedAt := time.Date(2026, 1, 2, 10, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedMark  bool
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_quiz_sessions SET submitted_at = \? WHERE id = \? AND submitted_at IS NULL`).
+					WithArgs(submittedAt, "quiz-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedMark: true,
+		},
+		{
+			name: "already submitted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_quiz_sessions`).
+					WithArgs(submittedAt, "quiz-1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedMark: false,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_quiz_sessions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordQuizSessionTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			marked, err := repo.MarkSubmitted(context.Background(), "quiz-1", submittedAt)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedMark, marked)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordQuizSessionRepository_ClearSubmitted(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_quiz_sessions SET submitted_at = NULL WHERE id = \?`).
+					WithArgs("quiz-1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE word_quiz_sessions`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordQuizSessionTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			err := repo.ClearSubmitted(context.Background(), "quiz-1")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordQuizSessionRepository_DeleteExpiredByUserID(t *testing.T) {
+	repo, mock, cleanup := setupWordQuizSessionTestRepository(t)
+	defer cleanup()
+
+	now := time.Date(2026, 1, 2, 10, 10, 0, 0, time.UTC)
+	mock.ExpectExec(`DELETE FROM word_quiz_sessions WHERE user_id = \? AND expires_at < \?`).
+		WithArgs(1, now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err := repo.DeleteExpiredByUserID(context.Background(), 1, now)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math/rand"
+	"slices"
 	"strings"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/transliteration"
 )
 
 // wordRepository implements WordRepository
@@ -22,25 +25,24 @@ func NewWordRepository(db *sql.DB) *wordRepository {
 }
 
 // GetByIDs retrieves words by their IDs
-func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, translationField, exampleTranslationField string) ([]models.WordResponse, error) {
+//
+// Translations are taken in the first language of the chain which has them.
+func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, languages []string) ([]models.WordResponse, error) {
 	if len(wordIds) == 0 {
 		return []models.WordResponse{}, nil
 	}
 
-	// Build query with placeholders
-	placeholders := make([]string, len(wordIds))
-	args := make([]any, len(wordIds))
-	for i, id := range wordIds {
-		placeholders[i] = "?"
-		args[i] = id
+	translationColumns, args := localizedWordColumns(languages)
+	for _, id := range wordIds {
+		args = append(args, id)
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, word, phonetic_clues, %s as translation, example, %s as example_translation,
+		SELECT id, word, phonetic_clues, example, %s,
 		       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio
 		FROM words
 		WHERE id IN (%s)
-	`, translationField, exampleTranslationField, strings.Join(placeholders, ","))
+	`, translationColumns, queryPlaceholders(len(wordIds)))
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -48,6 +50,21 @@ func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, translatio
 	}
 	defer rows.Close()
 
+	return scanWordResponses(rows)
+}
+
+// localizedWordColumns returns the select list of word and example translations in the first language of the chain
+// which has them together with arguments of its placeholders
+func localizedWordColumns(languages []string) (string, []any) {
+	translation, args := localizedColumn("word_translations", "translation", "word_id = words.id", languages)
+	exampleTranslation, exampleArgs := localizedColumn("word_translations", "example_translation", "word_id = words.id", languages)
+	return translation + " as translation, " + exampleTranslation + " as example_translation", append(args, exampleArgs...)
+}
+
+// scanWordResponses scans rows of localized words
+//
+// Rows must contain id, word, phonetic_clues, example, translation, example_translation, periods and audio columns in order.
+func scanWordResponses(rows *sql.Rows) ([]models.WordResponse, error) {
 	var words []models.WordResponse
 	for rows.Next() {
 		var word models.WordResponse
@@ -56,8 +73,8 @@ func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, translatio
 			&word.ID,
 			&word.Word,
 			&word.PhoneticClues,
-			&word.Translation,
 			&word.Example,
+			&word.Translation,
 			&word.ExampleTranslation,
 			&word.EasyPeriod,
 			&word.NormalPeriod,
@@ -78,7 +95,7 @@ func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, translatio
 		words = append(words, word)
 	}
 
-	if err = rows.Err(); err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
@@ -86,82 +103,56 @@ func (r *wordRepository) GetByIDs(ctx context.Context, wordIds []int, translatio
 }
 
 // GetExcludingIDs retrieves words not in the provided ID list
-func (r *wordRepository) GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, translationField, exampleTranslationField string) ([]models.WordResponse, error) {
-	var query string
-	var args []any
-
-	if len(excludeIds) == 0 {
-		// If no IDs to exclude, get random words
-		query = fmt.Sprintf(`
-			SELECT id, word, phonetic_clues, %s as translation, example, %s as example_translation,
-			       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio
-			FROM words
-			ORDER BY (EXISTS (SELECT 1 FROM dictionary_history WHERE word_id = words.id AND user_id = ?)), RAND()
-			LIMIT ?
-		`, translationField, exampleTranslationField)
-		args = []any{userId, limit}
-	} else {
-		// Build query with placeholders for exclusion
-		placeholders := make([]string, len(excludeIds))
-		args = make([]any, len(excludeIds)+2)
-		for i, id := range excludeIds {
-			placeholders[i] = "?"
-			args[i] = id
+//
+// Words are taken at random, never reviewed words first. Leeches of the user and words not matching the filter are not returned.
+// Translations are taken in the first language of the chain which has them.
+func (r *wordRepository) GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, filter models.WordFilter, languages []string) ([]models.WordResponse, error) {
+	translationColumns, args := localizedWordColumns(languages)
+
+	// Leeches are reviewed from the leech list only, so they never come back as new words
+	conditions := []string{"NOT EXISTS (SELECT 1 FROM dictionary_history WHERE word_id = words.id AND user_id = ? AND is_leech = TRUE)"}
+	args = append(args, userId)
+	if len(excludeIds) > 0 {
+		conditions = append(conditions, fmt.Sprintf("id NOT IN (%s)", queryPlaceholders(len(excludeIds))))
+		for _, id := range excludeIds {
+			args = append(args, id)
+		}
+	}
+	if len(filter.JLPTLevels) > 0 {
+		conditions = append(conditions, fmt.Sprintf("jlpt_level IN (%s)", queryPlaceholders(len(filter.JLPTLevels))))
+		for _, level := range filter.JLPTLevels {
+			args = append(args, level)
 		}
-		args[len(excludeIds)] = userId
-		args[len(excludeIds)+1] = limit
+	}
+	if len(filter.Tags) > 0 {
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM word_tags WHERE word_id = words.id AND tag IN (%s))", queryPlaceholders(len(filter.Tags))))
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+	}
 
-		query = fmt.Sprintf(`
-			SELECT id, word, phonetic_clues, %s as translation, example, %s as example_translation,
-			       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio
-			FROM words
-			WHERE id NOT IN (%s)
-			ORDER BY (EXISTS (SELECT 1 FROM dictionary_history WHERE word_id = words.id AND user_id = ?)), RAND()
-			LIMIT ?
-		`, translationField, exampleTranslationField, strings.Join(placeholders, ","))
+	if filter.WithWordAudio {
+		conditions = append(conditions, "word_audio IS NOT NULL AND word_audio != ''")
 	}
 
+	args = append(args, userId, limit)
+
+	query := fmt.Sprintf(`
+		SELECT id, word, phonetic_clues, example, %s,
+		       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio
+		FROM words
+		WHERE %s
+		ORDER BY (EXISTS (SELECT 1 FROM dictionary_history WHERE word_id = words.id AND user_id = ?)), RAND()
+		LIMIT ?
+	`, translationColumns, strings.Join(conditions, " AND "))
+
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query words: %w", err)
 	}
 	defer rows.Close()
 
-	var words []models.WordResponse
-	for rows.Next() {
-		var word models.WordResponse
-		var wordAudio, wordExampleAudio sql.NullString
-		err := rows.Scan(
-			&word.ID,
-			&word.Word,
-			&word.PhoneticClues,
-			&word.Translation,
-			&word.Example,
-			&word.ExampleTranslation,
-			&word.EasyPeriod,
-			&word.NormalPeriod,
-			&word.HardPeriod,
-			&word.ExtraHardPeriod,
-			&wordAudio,
-			&wordExampleAudio,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan word: %w", err)
-		}
-		if wordAudio.Valid {
-			word.WordAudio = wordAudio.String
-		}
-		if wordExampleAudio.Valid {
-			word.WordExampleAudio = wordExampleAudio.String
-		}
-		words = append(words, word)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
-	}
-
-	return words, nil
+	return scanWordResponses(rows)
 }
 
 // ValidateWordIDs checks if all word IDs exist in the database
@@ -169,6 +160,8 @@ func (r *wordRepository) ValidateWordIDs(ctx context.Context, wordIds []int) (bo
 	if len(wordIds) == 0 {
 		return false, fmt.Errorf("word IDs list cannot be empty")
 	}
+	// The database counts a repeated ID once, so the IDs are deduplicated before the count is compared
+	wordIds = slices.Compact(slices.Sorted(slices.Values(wordIds)))
 
 	// Build query with placeholders
 	placeholders := make([]string, len(wordIds))
@@ -193,28 +186,47 @@ func (r *wordRepository) ValidateWordIDs(ctx context.Context, wordIds []int) (bo
 	return count == len(wordIds), nil
 }
 
+// CountUnseen counts words the user has never reviewed
+func (r *wordRepository) CountUnseen(ctx context.Context, userId int) (int, error) {
+	query := `
+		SELECT COUNT(*) as count
+		FROM words
+		WHERE NOT EXISTS (SELECT 1 FROM dictionary_history WHERE word_id = words.id AND user_id = ?)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userId).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unseen words: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetAllForAdmin retrieves a paginated list of words with optional search filter
-func (r *wordRepository) GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.Word, error) {
+//
+// Words are searched in translations of all languages and ordered by the translation in the first language of the chain which has it.
+func (r *wordRepository) GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.WordListItem, error) {
+	translation, args := localizedColumn("word_translations", "translation", "word_id = words.id", languages)
+
 	// Build WHERE clause
 	var whereClause string
-	var args []any
-
 	if search != "" {
-		whereClause = `WHERE word LIKE ? OR phonetic_clues LIKE ? OR english_translation LIKE ? OR russian_translation LIKE ? OR german_translation LIKE ?`
+		whereClause = `WHERE word LIKE ? OR phonetic_clues LIKE ? OR EXISTS (SELECT 1 FROM word_translations WHERE word_id = words.id AND translation LIKE ?)`
 		searchValue := "%" + search + "%"
-		args = append(args, searchValue, searchValue, searchValue, searchValue, searchValue)
+		args = append(args, searchValue, searchValue, searchValue)
 	}
 
 	// Calculate offset
 	offset := (page - 1) * count
 
 	query := fmt.Sprintf(`
-		SELECT id, word, phonetic_clues, english_translation
+		SELECT id, word, phonetic_clues, %s as translation
 		FROM words
 		%s
-		ORDER BY english_translation
+		ORDER BY translation
 		LIMIT ? OFFSET ?
-	`, whereClause)
+	`, translation, whereClause)
 
 	args = append(args, count, offset)
 
@@ -224,14 +236,14 @@ func (r *wordRepository) GetAllForAdmin(ctx context.Context, page, count int, se
 	}
 	defer rows.Close()
 
-	var words []models.Word
+	var words []models.WordListItem
 	for rows.Next() {
-		var word models.Word
+		var word models.WordListItem
 		err := rows.Scan(
 			&word.ID,
 			&word.Word,
 			&word.PhoneticClues,
-			&word.EnglishTranslation,
+			&word.Translation,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan word: %w", err)
@@ -246,36 +258,35 @@ func (r *wordRepository) GetAllForAdmin(ctx context.Context, page, count int, se
 	return words, nil
 }
 
-// GetByID retrieves a word by ID
+// GetByIDAdmin retrieves a word by ID with translations into all languages
 func (r *wordRepository) GetByIDAdmin(ctx context.Context, id int) (*models.Word, error) {
 	query := `
-		SELECT id, word, phonetic_clues, russian_translation, english_translation, german_translation,
-		       example, example_russian_translation, example_english_translation, example_german_translation,
-		       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio
+		SELECT id, word, phonetic_clues, example,
+		       easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio,
+		       jlpt_level, part_of_speech,
+		       (SELECT GROUP_CONCAT(tag ORDER BY tag SEPARATOR ',') FROM word_tags WHERE word_id = words.id) as tags
 		FROM words
 		WHERE id = ?
 		LIMIT 1
 	`
 
 	word := &models.Word{}
-	var wordAudio, wordExampleAudio sql.NullString
+	var wordAudio, wordExampleAudio, partOfSpeech, tags sql.NullString
+	var jlptLevel sql.NullInt64
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&word.ID,
 		&word.Word,
 		&word.PhoneticClues,
-		&word.RussianTranslation,
-		&word.EnglishTranslation,
-		&word.GermanTranslation,
 		&word.Example,
-		&word.ExampleRussianTranslation,
-		&word.ExampleEnglishTranslation,
-		&word.ExampleGermanTranslation,
 		&word.EasyPeriod,
 		&word.NormalPeriod,
 		&word.HardPeriod,
 		&word.ExtraHardPeriod,
 		&wordAudio,
 		&wordExampleAudio,
+		&jlptLevel,
+		&partOfSpeech,
+		&tags,
 	)
 
 	if err == sql.ErrNoRows {
@@ -291,10 +302,62 @@ func (r *wordRepository) GetByIDAdmin(ctx context.Context, id int) (*models.Word
 	if wordExampleAudio.Valid {
 		word.WordExampleAudio = wordExampleAudio.String
 	}
+	word.JLPTLevel = int(jlptLevel.Int64)
+	word.PartOfSpeech = partOfSpeech.String
+	word.Tags = []string{}
+	if tags.Valid && tags.String != "" {
+		word.Tags = strings.Split(tags.String, ",")
+	}
+
+	word.Translations, err = r.getTranslations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
 	return word, nil
 }
 
+// getTranslations retrieves translations of a word into all languages
+func (r *wordRepository) getTranslations(ctx context.Context, wordId int) (map[string]models.WordTranslation, error) {
+	query := `
+		SELECT language, translation, example_translation
+		FROM word_translations
+		WHERE word_id = ?
+		ORDER BY language
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wordId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word translations: %w", err)
+	}
+	defer rows.Close()
+
+	translations := make(map[string]models.WordTranslation)
+	for rows.Next() {
+		var language string
+		var translation models.WordTranslation
+		if err := rows.Scan(&language, &translation.Translation, &translation.ExampleTranslation); err != nil {
+			return nil, fmt.Errorf("failed to scan word translation: %w", err)
+		}
+		translations[language] = translation
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return translations, nil
+}
+
+// upsertWordTranslations writes translations of a word, empty values keep the current ones
+func upsertWordTranslations(ctx context.Context, exec execer, wordId int, translations map[string]models.WordTranslation) error {
+	values := make(map[string][]string, len(translations))
+	for language, translation := range translations {
+		values[language] = []string{translation.Translation, translation.ExampleTranslation}
+	}
+	return upsertTranslations(ctx, exec, "word_translations", "word_id", wordId, []string{"translation", "example_translation"}, values)
+}
+
 // ExistsByWord checks if a word with the given Word field exists
 func (r *wordRepository) ExistsByWord(ctx context.Context, word string) (bool, error) {
 	query := `SELECT EXISTS(SELECT * FROM words WHERE word = ?)`
@@ -308,31 +371,34 @@ func (r *wordRepository) ExistsByWord(ctx context.Context, word string) (bool, e
 	return exists, nil
 }
 
-// Create inserts a new word into the database
+// Create inserts a new word with its translations into the database
 func (r *wordRepository) Create(ctx context.Context, word *models.Word) error {
 	query := `
-		INSERT INTO words (word, phonetic_clues, russian_translation, english_translation, german_translation,
-		                  example, example_russian_translation, example_english_translation, example_german_translation,
-		                  easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO words (word, phonetic_clues, search_reading, example,
+		                  easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio,
+		                  jlpt_level, part_of_speech)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query,
 		word.Word,
 		word.PhoneticClues,
-		word.RussianTranslation,
-		word.EnglishTranslation,
-		word.GermanTranslation,
+		searchReading(word.PhoneticClues),
 		word.Example,
-		word.ExampleRussianTranslation,
-		word.ExampleEnglishTranslation,
-		word.ExampleGermanTranslation,
 		word.EasyPeriod,
 		word.NormalPeriod,
 		word.HardPeriod,
 		word.ExtraHardPeriod,
 		word.WordAudio,
 		word.WordExampleAudio,
+		sql.NullInt64{Int64: int64(word.JLPTLevel), Valid: word.JLPTLevel != 0},
+		sql.NullString{String: word.PartOfSpeech, Valid: word.PartOfSpeech != ""},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create word: %w", err)
@@ -343,7 +409,18 @@ func (r *wordRepository) Create(ctx context.Context, word *models.Word) error {
 		return fmt.Errorf("failed to get last insert id: %w", err)
 	}
 
+	if err := upsertWordTranslations(ctx, tx, int(id), word.Translations); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	word.ID = int(id)
+	if len(word.Tags) > 0 {
+		return r.replaceTags(ctx, word.ID, word.Tags)
+	}
 	return nil
 }
 
@@ -361,6 +438,8 @@ func (r *wordRepository) ExistsByClues(ctx context.Context, clues string) (bool,
 }
 
 // Update updates word fields (partial update)
+//
+// Translations are written for the listed languages only, the other languages are left unchanged.
 func (r *wordRepository) Update(ctx context.Context, id int, word *models.Word) error {
 	// Build dynamic UPDATE query based on provided fields
 	var setParts []string
@@ -371,37 +450,13 @@ func (r *wordRepository) Update(ctx context.Context, id int, word *models.Word)
 		args = append(args, word.Word)
 	}
 	if word.PhoneticClues != "" {
-		setParts = append(setParts, "phonetic_clues = ?")
-		args = append(args, word.PhoneticClues)
-	}
-	if word.RussianTranslation != "" {
-		setParts = append(setParts, "russian_translation = ?")
-		args = append(args, word.RussianTranslation)
-	}
-	if word.EnglishTranslation != "" {
-		setParts = append(setParts, "english_translation = ?")
-		args = append(args, word.EnglishTranslation)
-	}
-	if word.GermanTranslation != "" {
-		setParts = append(setParts, "german_translation = ?")
-		args = append(args, word.GermanTranslation)
+		setParts = append(setParts, "phonetic_clues = ?", "search_reading = ?")
+		args = append(args, word.PhoneticClues, searchReading(word.PhoneticClues))
 	}
 	if word.Example != "" {
 		setParts = append(setParts, "example = ?")
 		args = append(args, word.Example)
 	}
-	if word.ExampleRussianTranslation != "" {
-		setParts = append(setParts, "example_russian_translation = ?")
-		args = append(args, word.ExampleRussianTranslation)
-	}
-	if word.ExampleEnglishTranslation != "" {
-		setParts = append(setParts, "example_english_translation = ?")
-		args = append(args, word.ExampleEnglishTranslation)
-	}
-	if word.ExampleGermanTranslation != "" {
-		setParts = append(setParts, "example_german_translation = ?")
-		args = append(args, word.ExampleGermanTranslation)
-	}
 	if word.EasyPeriod != 0 {
 		setParts = append(setParts, "easy_period = ?")
 		args = append(args, word.EasyPeriod)
@@ -426,34 +481,310 @@ func (r *wordRepository) Update(ctx context.Context, id int, word *models.Word)
 		setParts = append(setParts, "word_example_audio = ?")
 		args = append(args, word.WordExampleAudio)
 	}
+	if word.JLPTLevel != 0 {
+		setParts = append(setParts, "jlpt_level = ?")
+		args = append(args, word.JLPTLevel)
+	}
+	if word.PartOfSpeech != "" {
+		setParts = append(setParts, "part_of_speech = ?")
+		args = append(args, word.PartOfSpeech)
+	}
 
-	if len(setParts) == 0 {
+	if len(setParts) == 0 && len(word.Translations) == 0 && word.Tags == nil {
 		return fmt.Errorf("no fields to update")
 	}
 
+	if len(setParts) > 0 {
+		query := fmt.Sprintf(`
+			UPDATE words
+			SET %s
+			WHERE id = ?
+		`, strings.Join(setParts, ", "))
+
+		args = append(args, id)
+
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update word: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("word not found")
+		}
+	}
+
+	if err := upsertWordTranslations(ctx, r.db, id, word.Translations); err != nil {
+		return err
+	}
+
+	if word.Tags != nil {
+		return r.replaceTags(ctx, id, word.Tags)
+	}
+	return nil
+}
+
+// replaceTags replaces all tags of a word, an empty list removes them
+func (r *wordRepository) replaceTags(ctx context.Context, wordId int, tags []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM word_tags WHERE word_id = ?`, wordId); err != nil {
+		return fmt.Errorf("failed to delete word tags: %w", err)
+	}
+
+	if len(tags) > 0 {
+		values := make([]string, len(tags))
+		args := make([]any, 0, len(tags)*2)
+		for i, tag := range tags {
+			values[i] = "(?, ?)"
+			args = append(args, wordId, tag)
+		}
+		query := fmt.Sprintf(`INSERT INTO word_tags (word_id, tag) VALUES %s`, strings.Join(values, ", "))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert word tags: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetTags retrieves all topic tags with the number of words they are attached to
+func (r *wordRepository) GetTags(ctx context.Context) ([]models.WordTag, error) {
+	query := `
+		SELECT tag, COUNT(*) as word_count
+		FROM word_tags
+		GROUP BY tag
+		ORDER BY tag
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query word tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.WordTag{}
+	for rows.Next() {
+		var tag models.WordTag
+		if err := rows.Scan(&tag.Tag, &tag.WordCount); err != nil {
+			return nil, fmt.Errorf("failed to scan word tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+// Search finds words by kanji, reading or translation
+//
+// The reading is compared in its normalized form, so the query may be written in hiragana, katakana or any romanization.
+// Words are ranked by exact match, then prefix match of the kanji, reading or translation,
+// then match of whole words inside the translation, shorter words first.
+// Every match is looked up by an index, so substrings in the middle of the kanji or reading are not matched.
+// Translations are searched in the languages of the chain and returned in the first language which has them.
+func (r *wordRepository) Search(ctx context.Context, query string, limit int, languages []string) ([]models.WordSearchResult, error) {
+	reading := searchReading(query)
+	translation, translationArgs := localizedColumn("word_translations", "translation", "word_id = w.id", languages)
+	languagePlaceholders := queryPlaceholders(len(languages))
+	sqlQuery := fmt.Sprintf(`
+		SELECT w.id, w.word, w.phonetic_clues, %s as translation, w.jlpt_level, w.part_of_speech, matches.match_rank
+		FROM (
+			SELECT id, MIN(match_rank) as match_rank
+			FROM (
+				SELECT id, IF(word = ?, 0, 1) as match_rank FROM words WHERE word LIKE ?
+				UNION ALL
+				SELECT id, IF(search_reading = ?, 0, 1) FROM words WHERE search_reading LIKE ?
+				UNION ALL
+				SELECT word_id, IF(translation = ?, 0, 1) FROM word_translations
+				WHERE language IN (%[2]s) AND translation LIKE ?
+				UNION ALL
+				SELECT word_id, 2 FROM word_translations
+				WHERE language IN (%[2]s) AND MATCH(translation) AGAINST (? IN BOOLEAN MODE)
+			) candidates
+			GROUP BY id
+		) matches
+		JOIN words w ON w.id = matches.id
+		ORDER BY matches.match_rank, CHAR_LENGTH(w.word), w.id
+		LIMIT ?
+	`, translation, languagePlaceholders)
+
+	queryPattern, readingPattern := escapeLike(query)+"%", escapeLike(reading)+"%"
+	args := append([]any{}, translationArgs...)
+	args = append(args, query, queryPattern, reading, readingPattern, query)
+	for _, language := range languages {
+		args = append(args, language)
+	}
+	args = append(args, queryPattern)
+	for _, language := range languages {
+		args = append(args, language)
+	}
+	// The query is searched as a phrase, so operators of the boolean mode are not interpreted
+	args = append(args, `"`+strings.ReplaceAll(query, `"`, " ")+`"`, limit)
+	rows, err := r.db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.WordSearchResult{}
+	for rows.Next() {
+		var word models.WordSearchResult
+		var jlptLevel sql.NullInt64
+		var partOfSpeech sql.NullString
+		var matchRank int
+		if err := rows.Scan(&word.ID, &word.Word, &word.PhoneticClues, &word.Translation, &jlptLevel, &partOfSpeech, &matchRank); err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		word.JLPTLevel = int(jlptLevel.Int64)
+		word.PartOfSpeech = partOfSpeech.String
+		words = append(words, word)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return words, nil
+}
+
+// quizDistractorSampleSize is the number of words sampled on each side of a random pivot as quiz distractor candidates
+const quizDistractorSampleSize = 100
+
+// GetQuizDistractors retrieves words which may be offered as wrong answers of quiz questions about the words
+//
+// Candidates are sampled from a window of consecutive words around a random pivot, so the cost does not grow with the dictionary.
+// Candidates of the same part of speech or sharing a topic tag come first, other candidates fill the rest at random.
+// The translation is taken in the first language of the chain which has it, only for the chosen distractors.
+// The result maps word IDs to at most "count" distractors each.
+func (r *wordRepository) GetQuizDistractors(ctx context.Context, wordIds []int, count int, languages []string) (map[int][]models.WordQuizDistractor, error) {
+	distractors := make(map[int][]models.WordQuizDistractor)
+	if len(wordIds) == 0 || count <= 0 {
+		return distractors, nil
+	}
+
+	translation, args := localizedColumn("word_translations", "translation", "word_id = d.id", languages)
+	pivot := rand.Float64()
+	args = append(args, pivot, quizDistractorSampleSize, pivot, quizDistractorSampleSize)
+	for _, id := range wordIds {
+		args = append(args, id)
+	}
+	args = append(args, count)
+
 	query := fmt.Sprintf(`
-		UPDATE words
-		SET %s
-		WHERE id = ?
-	`, strings.Join(setParts, ", "))
+		SELECT candidates.quiz_word_id, d.id, d.word, %s as translation
+		FROM (
+			SELECT q.id as quiz_word_id, s.id,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY q.id
+			           ORDER BY (COALESCE(s.part_of_speech = q.part_of_speech, FALSE) OR EXISTS (
+			               SELECT 1 FROM word_tags qt
+			               JOIN word_tags dt ON dt.tag = qt.tag
+			               WHERE qt.word_id = q.id AND dt.word_id = s.id
+			           )) DESC, RAND()
+			       ) as position
+			FROM words q
+			JOIN (
+				(
+					SELECT id, part_of_speech FROM words
+					WHERE id >= (SELECT MIN(id) + FLOOR(? * (MAX(id) - MIN(id) + 1)) FROM words)
+					ORDER BY id LIMIT ?
+				)
+				UNION ALL
+				(
+					SELECT id, part_of_speech FROM words
+					WHERE id < (SELECT MIN(id) + FLOOR(? * (MAX(id) - MIN(id) + 1)) FROM words)
+					ORDER BY id DESC LIMIT ?
+				)
+			) s ON s.id != q.id
+			WHERE q.id IN (%s)
+		) candidates
+		JOIN words d ON d.id = candidates.id
+		WHERE candidates.position <= ?
+		ORDER BY candidates.quiz_word_id, candidates.position
+	`, translation, queryPlaceholders(len(wordIds)))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query quiz distractors: %w", err)
+	}
+	defer rows.Close()
 
-	args = append(args, id)
+	for rows.Next() {
+		var quizWordId int
+		var distractor models.WordQuizDistractor
+		if err := rows.Scan(&quizWordId, &distractor.WordID, &distractor.Word, &distractor.Translation); err != nil {
+			return nil, fmt.Errorf("failed to scan quiz distractor: %w", err)
+		}
+		distractors[quizWordId] = append(distractors[quizWordId], distractor)
+	}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return distractors, nil
+}
+
+// FillSearchReadings sets the search reading of words created before the column was added
+//
+// The number of updated words is returned.
+func (r *wordRepository) FillSearchReadings(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, phonetic_clues FROM words WHERE search_reading IS NULL`)
 	if err != nil {
-		return fmt.Errorf("failed to update word: %w", err)
+		return 0, fmt.Errorf("failed to query words: %w", err)
+	}
+	var ids []int
+	var readings []string
+	for rows.Next() {
+		var id int
+		var clues string
+		if err := rows.Scan(&id, &clues); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan word: %w", err)
+		}
+		ids = append(ids, id)
+		readings = append(readings, searchReading(clues))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("word not found")
+	for i, id := range ids {
+		if _, err := tx.ExecContext(ctx, `UPDATE words SET search_reading = ? WHERE id = ?`, readings[i], id); err != nil {
+			return 0, fmt.Errorf("failed to update search reading: %w", err)
+		}
 	}
 
-	return nil
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return len(ids), nil
 }
 
 // Delete deletes a word by ID
@@ -476,3 +807,25 @@ func (r *wordRepository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// queryPlaceholders returns a comma separated list of n query placeholders
+func queryPlaceholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// searchReading returns the normalized reading of a text used for search
+//
+// Kana and romaji are converted to hiragana with romanization differences folded.
+// Text which cannot be read (kanji, words of other languages) is kept lowercased with katakana converted to hiragana.
+func searchReading(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if reading, err := transliteration.Normalize(text); err == nil {
+		return reading
+	}
+	return transliteration.ToHiragana(text)
+}
+
+// escapeLike escapes wildcard characters of a LIKE pattern
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text)
+}
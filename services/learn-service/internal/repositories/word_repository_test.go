@@ -38,106 +38,99 @@ func TestNewWordRepository(t *testing.T) {
 
 func TestWordRepository_GetByIDs(t *testing.T) {
 	tests := []struct {
-		name                    string
-		wordIds                 []int
-		translationField        string
-		exampleTranslationField string
-		setupMock               func(sqlmock.Sqlmock)
-		expectedError           bool
-		expectedCount           int
+		name          string
+		wordIds       []int
+		languages     []string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
 	}{
 		{
-			name:                    "success with multiple IDs",
-			wordIds:                 []int{1, 2, 3},
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:      "success with multiple IDs",
+			wordIds:   []int{1, 2, 3},
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(1, "水", "みず", "water", "水を飲む", "drink water", 1, 3, 7, 14, "", "").
-					AddRow(2, "火", "ひ", "fire", "火をつける", "light a fire", 1, 3, 7, 14, "", "").
-					AddRow(3, "風", "かぜ", "wind", "風が吹く", "wind blows", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?,\?\)`).
-					WithArgs(1, 2, 3).
+					AddRow(1, "水", "みず", "水を飲む", "water", "drink water", 1, 3, 7, 14, "", "").
+					AddRow(2, "火", "ひ", "火をつける", "fire", "light a fire", 1, 3, 7, 14, "", "").
+					AddRow(3, "風", "かぜ", "風が吹く", "wind", "wind blows", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?,\?\)`).
+					WithArgs("en", "en", "en", "en", 1, 2, 3).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 3,
 		},
 		{
-			name:                    "success with single ID",
-			wordIds:                 []int{1},
-			translationField:        "russian_translation",
-			exampleTranslationField: "example_russian_translation",
+			name:      "success with single ID",
+			wordIds:   []int{1},
+			languages: []string{"ru", "en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(1, "水", "みず", "вода", "水を飲む", "пить воду", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation as translation, example, example_russian_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?\)`).
-					WithArgs(1).
+					AddRow(1, "水", "みず", "水を飲む", "вода", "пить воду", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?\)`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 1,
 		},
 		{
-			name:                    "empty wordIds slice",
-			wordIds:                 []int{},
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
-			setupMock:               func(mock sqlmock.Sqlmock) {},
-			expectedError:           false,
-			expectedCount:           0,
+			name:          "empty wordIds slice",
+			wordIds:       []int{},
+			languages:     []string{"en"},
+			setupMock:     func(mock sqlmock.Sqlmock) {},
+			expectedError: false,
+			expectedCount: 0,
 		},
 		{
-			name:                    "database query error",
-			wordIds:                 []int{1, 2},
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:      "database query error",
+			wordIds:   []int{1, 2},
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?\)`).
+					WithArgs("en", "en", "en", "en", 1, 2).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
 			expectedCount: 0,
 		},
 		{
-			name:                    "scan error",
-			wordIds:                 []int{1},
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:      "scan error",
+			wordIds:   []int{1},
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow("invalid", "水", "みず", "water", "水を飲む", "drink water", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?\)`).
-					WithArgs(1).
+					AddRow("invalid", "水", "みず", "水を飲む", "water", "drink water", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?\)`).
+					WithArgs("en", "en", "en", "en", 1).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
 			expectedCount: 0,
 		},
 		{
-			name:                    "rows iteration error",
-			wordIds:                 []int{1, 2},
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:      "rows iteration error",
+			wordIds:   []int{1, 2},
+			languages: []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(1, "水", "みず", "water", "水を飲む", "drink water", 1, 3, 7, 14, "", "").
+					AddRow(1, "水", "みず", "水を飲む", "water", "drink water", 1, 3, 7, 14, "", "").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?\)`).
-					WithArgs(1, 2).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id IN \(\?,\?\)`).
+					WithArgs("en", "en", "en", "en", 1, 2).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -152,7 +145,7 @@ func TestWordRepository_GetByIDs(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetByIDs(context.Background(), tt.wordIds, tt.translationField, tt.exampleTranslationField)
+			result, err := repo.GetByIDs(context.Background(), tt.wordIds, tt.languages)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -170,104 +163,156 @@ func TestWordRepository_GetByIDs(t *testing.T) {
 
 func TestWordRepository_GetExcludingIDs(t *testing.T) {
 	tests := []struct {
-		name                    string
-		excludeIds              []int
-		limit                   int
-		translationField        string
-		exampleTranslationField string
-		setupMock               func(sqlmock.Sqlmock)
-		expectedError           bool
-		expectedCount           int
+		name          string
+		excludeIds    []int
+		limit         int
+		filter        models.WordFilter
+		languages     []string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
 	}{
 		{
-			name:                    "success with exclusion list",
-			excludeIds:              []int{1, 2, 3},
-			limit:                   5,
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:       "success with exclusion list",
+			excludeIds: []int{1, 2, 3},
+			limit:      5,
+			languages:  []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(4, "木", "き", "tree", "木を植える", "plant a tree", 1, 3, 7, 14, "", "").
-					AddRow(5, "土", "つち", "earth", "土を耕す", "till the earth", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id NOT IN \(\?,\?,\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
-					WithArgs(1, 2, 3, 1, 5).
+					AddRow(4, "木", "き", "木を植える", "tree", "plant a tree", 1, 3, 7, 14, "", "").
+					AddRow(5, "土", "つち", "土を耕す", "earth", "till the earth", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND id NOT IN \(\?,\?,\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
+					WithArgs("en", "en", "en", "en", 1, 1, 2, 3, 1, 5).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 2,
 		},
 		{
-			name:                    "success with empty exclusion list",
-			excludeIds:              []int{},
-			limit:                   3,
-			translationField:        "russian_translation",
-			exampleTranslationField: "example_russian_translation",
+			name:       "success with empty exclusion list",
+			excludeIds: []int{},
+			limit:      3,
+			languages:  []string{"ru", "en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(1, "水", "みず", "вода", "水を飲む", "пить воду", 1, 3, 7, 14, "", "").
-					AddRow(2, "火", "ひ", "огонь", "火をつける", "зажечь огонь", 1, 3, 7, 14, "", "").
-					AddRow(3, "風", "かぜ", "ветер", "風が吹く", "дует ветер", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation as translation, example, example_russian_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
-					WithArgs(1, 3).
+					AddRow(1, "水", "みず", "水を飲む", "вода", "пить воду", 1, 3, 7, 14, "", "").
+					AddRow(2, "火", "ひ", "火をつける", "огонь", "зажечь огонь", 1, 3, 7, 14, "", "").
+					AddRow(3, "風", "かぜ", "風が吹く", "ветер", "дует ветер", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
+					WithArgs("ru", "en", "ru", "en", "ru", "en", "ru", "en", 1, 1, 3).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
 			expectedCount: 3,
 		},
 		{
-			name:                    "database query error",
-			excludeIds:              []int{1},
-			limit:                   5,
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:       "success with jlpt levels and tags",
+			excludeIds: []int{1},
+			limit:      5,
+			filter:     models.WordFilter{JLPTLevels: []int{5, 4}, Tags: []string{"food"}},
+			languages:  []string{"en"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "word", "phonetic_clues", "example", "translation",
+					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+				}).
+					AddRow(2, "水", "みず", "水を飲む", "water", "drink water", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND id NOT IN \(\?\) AND jlpt_level IN \(\?,\?\) AND EXISTS \(SELECT 1 FROM word_tags WHERE word_id = words\.id AND tag IN \(\?\)\) ORDER BY`).
+					WithArgs("en", "en", "en", "en", 1, 1, 5, 4, "food", 1, 5).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedCount: 1,
+		},
+		{
+			name:       "success with filter and empty exclusion list",
+			excludeIds: []int{},
+			limit:      5,
+			filter:     models.WordFilter{JLPTLevels: []int{5}},
+			languages:  []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
-					WithArgs(1, 1, 5).
+				rows := sqlmock.NewRows([]string{
+					"id", "word", "phonetic_clues", "example", "translation",
+					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+				}).
+					AddRow(3, "木", "き", "木を植える", "tree", "plant a tree", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND jlpt_level IN \(\?\) ORDER BY`).
+					WithArgs("en", "en", "en", "en", 1, 5, 1, 5).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedCount: 1,
+		},
+		{
+			name:       "success with word audio only",
+			excludeIds: []int{},
+			limit:      5,
+			filter:     models.WordFilter{WithWordAudio: true},
+			languages:  []string{"en"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "word", "phonetic_clues", "example", "translation",
+					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+				}).
+					AddRow(3, "木", "き", "木を植える", "tree", "plant a tree", 1, 3, 7, 14, "http://media/3", "")
+				mock.ExpectQuery(`FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND word_audio IS NOT NULL AND word_audio != '' ORDER BY`).
+					WithArgs("en", "en", "en", "en", 1, 1, 5).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedCount: 1,
+		},
+		{
+			name:       "database query error",
+			excludeIds: []int{1},
+			limit:      5,
+			languages:  []string{"en"},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
+					WithArgs("en", "en", "en", "en", 1, 1, 1, 5).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
 			expectedCount: 0,
 		},
 		{
-			name:                    "scan error",
-			excludeIds:              []int{1},
-			limit:                   2,
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:       "scan error",
+			excludeIds: []int{1},
+			limit:      2,
+			languages:  []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow("invalid", "水", "みず", "water", "水を飲む", "drink water", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
-					WithArgs(1, 1, 2).
+					AddRow("invalid", "水", "みず", "水を飲む", "water", "drink water", 1, 3, 7, 14, "", "")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
+					WithArgs("en", "en", "en", "en", 1, 1, 1, 2).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
 			expectedCount: 0,
 		},
 		{
-			name:                    "rows iteration error",
-			excludeIds:              []int{1},
-			limit:                   2,
-			translationField:        "english_translation",
-			exampleTranslationField: "example_english_translation",
+			name:       "rows iteration error",
+			excludeIds: []int{1},
+			limit:      2,
+			languages:  []string{"en"},
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "translation", "example",
+					"id", "word", "phonetic_clues", "example", "translation",
 					"example_translation", "easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
 				}).
-					AddRow(2, "火", "ひ", "fire", "火をつける", "light a fire", 1, 3, 7, 14, "", "").
+					AddRow(2, "火", "ひ", "火をつける", "fire", "light a fire", 1, 3, 7, 14, "", "").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation as translation, example, example_english_translation as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
-					WithArgs(1, 1, 2).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, COALESCE\(\(SELECT t.example_translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?\) AND t.example_translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as example_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE NOT EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \? AND is_leech = TRUE\) AND id NOT IN \(\?\) ORDER BY \(EXISTS \(SELECT 1 FROM dictionary_history WHERE word_id = words\.id AND user_id = \?\)\), RAND\(\) LIMIT \?`).
+					WithArgs("en", "en", "en", "en", 1, 1, 1, 2).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -282,7 +327,7 @@ func TestWordRepository_GetExcludingIDs(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetExcludingIDs(context.Background(), 1, tt.excludeIds, tt.limit, tt.translationField, tt.exampleTranslationField)
+			result, err := repo.GetExcludingIDs(context.Background(), 1, tt.excludeIds, tt.limit, tt.filter, tt.languages)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -330,6 +375,18 @@ func TestWordRepository_ValidateWordIDs(t *testing.T) {
 			expectedError: false,
 			expectedValid: false,
 		},
+		{
+			name:    "success - duplicated IDs are counted once",
+			wordIds: []int{2, 1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(2)
+				mock.ExpectQuery(`SELECT COUNT\(\*\) as count FROM words WHERE id IN \(\?,\?\)`).
+					WithArgs(1, 2).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedValid: true,
+		},
 		{
 			name:    "empty wordIds slice",
 			wordIds: []int{},
@@ -410,6 +467,60 @@ func TestWordRepository_ValidateWordIDs(t *testing.T) {
 	}
 }
 
+func TestWordRepository_CountUnseen(t *testing.T) {
+	tests := []struct {
+		name          string
+		userId        int
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expected      int
+	}{
+		{
+			name:   "success",
+			userId: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"count"}).AddRow(42)
+				mock.ExpectQuery(`(?s)SELECT COUNT\(\*\) as count.*FROM words.*WHERE NOT EXISTS.*dictionary_history`).
+					WithArgs(1).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expected:      42,
+		},
+		{
+			name:   "database error",
+			userId: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`(?s)SELECT COUNT\(\*\) as count.*FROM words`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			count, err := repo.CountUnseen(context.Background(), tt.userId)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Zero(t, count)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, count)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestWordRepository_GetAllForAdmin(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -426,11 +537,11 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "english_translation"}).
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation"}).
 					AddRow(1, "水", "みず", "water").
 					AddRow(2, "火", "ひ", "fire")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs(10, 0).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -442,10 +553,10 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "water",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "english_translation"}).
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation"}).
 					AddRow(1, "水", "みず", "water")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words WHERE word LIKE \? OR phonetic_clues LIKE \? OR english_translation LIKE \? OR russian_translation LIKE \? OR german_translation LIKE \? ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs("%water%", "%water%", "%water%", "%water%", "%water%", 10, 0).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words WHERE word LIKE \? OR phonetic_clues LIKE \? OR EXISTS \(SELECT 1 FROM word_translations WHERE word_id = words\.id AND translation LIKE \?\) ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", "%water%", "%water%", "%water%", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -457,10 +568,10 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  5,
 			search: "",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "english_translation"}).
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation"}).
 					AddRow(6, "木", "き", "tree")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs(5, 5).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", 5, 5).
 					WillReturnRows(rows)
 			},
 			expectedError: false,
@@ -472,8 +583,8 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs(10, 0).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", 10, 0).
 					WillReturnError(errors.New("database error"))
 			},
 			expectedError: true,
@@ -485,10 +596,10 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "english_translation"}).
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation"}).
 					AddRow("invalid", "水", "みず", "water")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs(10, 0).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -500,11 +611,11 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "english_translation"}).
+				rows := sqlmock.NewRows([]string{"id", "word", "phonetic_clues", "translation"}).
 					AddRow(1, "水", "みず", "water").
 					RowError(0, errors.New("row error"))
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, english_translation FROM words ORDER BY english_translation LIMIT \? OFFSET \?`).
-					WithArgs(10, 0).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = words.id AND t.language IN \(\?,\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?,\?\) LIMIT 1\), ''\) as translation FROM words ORDER BY translation LIMIT \? OFFSET \?`).
+					WithArgs("de", "en", "de", "en", 10, 0).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
@@ -519,7 +630,7 @@ func TestWordRepository_GetAllForAdmin(t *testing.T) {
 
 			tt.setupMock(mock)
 
-			result, err := repo.GetAllForAdmin(context.Background(), tt.page, tt.count, tt.search)
+			result, err := repo.GetAllForAdmin(context.Background(), tt.page, tt.count, tt.search, []string{"de", "en"})
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -548,40 +659,75 @@ func TestWordRepository_GetByIDAdmin(t *testing.T) {
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "russian_translation", "english_translation", "german_translation",
-					"example", "example_russian_translation", "example_english_translation", "example_german_translation",
+					"id", "word", "phonetic_clues", "example",
 					"easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+					"jlpt_level", "part_of_speech", "tags",
 				}).
-					AddRow(1, "水", "みず", "вода", "water", "Wasser", "水を飲む", "пить воду", "drink water", "Wasser trinken", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation, english_translation, german_translation, example, example_russian_translation, example_english_translation, example_german_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id = \? LIMIT 1`).
+					AddRow(1, "水", "みず", "水を飲む", 1, 3, 7, 14, "", "", 5, "noun", "drink,nature")
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio, jlpt_level, part_of_speech, \(SELECT GROUP_CONCAT\(tag ORDER BY tag SEPARATOR ','\) FROM word_tags WHERE word_id = words\.id\) as tags FROM words WHERE id = \? LIMIT 1`).
+					WithArgs(1).
+					WillReturnRows(rows)
+				translations := sqlmock.NewRows([]string{"language", "translation", "example_translation"}).
+					AddRow("de", "Wasser", "Wasser trinken").
+					AddRow("en", "water", "drink water").
+					AddRow("ru", "вода", "пить воду")
+				mock.ExpectQuery(`SELECT language, translation, example_translation FROM word_translations WHERE word_id = \? ORDER BY language`).
 					WithArgs(1).
+					WillReturnRows(translations)
+			},
+			expectedError: false,
+			expectedWord: &models.Word{
+				ID:            1,
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations: map[string]models.WordTranslation{
+					"de": {Translation: "Wasser", ExampleTranslation: "Wasser trinken"},
+					"en": {Translation: "water", ExampleTranslation: "drink water"},
+					"ru": {Translation: "вода", ExampleTranslation: "пить воду"},
+				},
+				Example:          "水を飲む",
+				EasyPeriod:       1,
+				NormalPeriod:     3,
+				HardPeriod:       7,
+				ExtraHardPeriod:  14,
+				WordAudio:        "",
+				WordExampleAudio: "",
+				JLPTLevel:        5,
+				PartOfSpeech:     "noun",
+				Tags:             []string{"drink", "nature"},
+			},
+		},
+		{
+			name: "success without classification",
+			id:   2,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "word", "phonetic_clues", "example",
+					"easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+					"jlpt_level", "part_of_speech", "tags",
+				}).
+					AddRow(2, "火", "ひ", "火をつける", 1, 3, 7, 14, nil, nil, nil, nil, nil)
+				mock.ExpectQuery(`FROM words WHERE id = \? LIMIT 1`).
+					WithArgs(2).
 					WillReturnRows(rows)
+				mock.ExpectQuery(`FROM word_translations WHERE word_id = \?`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"language", "translation", "example_translation"}))
 			},
 			expectedError: false,
 			expectedWord: &models.Word{
-				ID:                        1,
-				Word:                      "水",
-				PhoneticClues:             "みず",
-				RussianTranslation:        "вода",
-				EnglishTranslation:        "water",
-				GermanTranslation:         "Wasser",
-				Example:                   "水を飲む",
-				ExampleRussianTranslation: "пить воду",
-				ExampleEnglishTranslation: "drink water",
-				ExampleGermanTranslation:  "Wasser trinken",
-				EasyPeriod:                1,
-				NormalPeriod:              3,
-				HardPeriod:                7,
-				ExtraHardPeriod:           14,
-				WordAudio:                 "",
-				WordExampleAudio:          "",
+				ID:            2,
+				Word:          "火",
+				PhoneticClues: "ひ",
+				Translations:  map[string]models.WordTranslation{},
+				Tags:          []string{},
 			},
 		},
 		{
 			name: "not found",
 			id:   999,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation, english_translation, german_translation, example, example_russian_translation, example_english_translation, example_german_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id = \? LIMIT 1`).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio, jlpt_level, part_of_speech, \(SELECT GROUP_CONCAT\(tag ORDER BY tag SEPARATOR ','\) FROM word_tags WHERE word_id = words\.id\) as tags FROM words WHERE id = \? LIMIT 1`).
 					WithArgs(999).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -592,7 +738,7 @@ func TestWordRepository_GetByIDAdmin(t *testing.T) {
 			name: "database error",
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation, english_translation, german_translation, example, example_russian_translation, example_english_translation, example_german_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id = \? LIMIT 1`).
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio, jlpt_level, part_of_speech, \(SELECT GROUP_CONCAT\(tag ORDER BY tag SEPARATOR ','\) FROM word_tags WHERE word_id = words\.id\) as tags FROM words WHERE id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnError(errors.New("database error"))
 			},
@@ -604,18 +750,38 @@ func TestWordRepository_GetByIDAdmin(t *testing.T) {
 			id:   1,
 			setupMock: func(mock sqlmock.Sqlmock) {
 				rows := sqlmock.NewRows([]string{
-					"id", "word", "phonetic_clues", "russian_translation", "english_translation", "german_translation",
-					"example", "example_russian_translation", "example_english_translation", "example_german_translation",
+					"id", "word", "phonetic_clues", "example",
 					"easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+					"jlpt_level", "part_of_speech", "tags",
 				}).
-					AddRow("invalid", "水", "みず", "вода", "water", "Wasser", "水を飲む", "пить воду", "drink water", "Wasser trinken", 1, 3, 7, 14, "", "")
-				mock.ExpectQuery(`SELECT id, word, phonetic_clues, russian_translation, english_translation, german_translation, example, example_russian_translation, example_english_translation, example_german_translation, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio FROM words WHERE id = \? LIMIT 1`).
+					AddRow("invalid", "水", "みず", "水を飲む", 1, 3, 7, 14, "", "", nil, nil, nil)
+				mock.ExpectQuery(`SELECT id, word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period, word_audio, word_example_audio, jlpt_level, part_of_speech, \(SELECT GROUP_CONCAT\(tag ORDER BY tag SEPARATOR ','\) FROM word_tags WHERE word_id = words\.id\) as tags FROM words WHERE id = \? LIMIT 1`).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
 			expectedError: true,
 			expectedWord:  nil,
 		},
+		{
+			name: "translations database error",
+			id:   1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{
+					"id", "word", "phonetic_clues", "example",
+					"easy_period", "normal_period", "hard_period", "extra_hard_period", "word_audio", "word_example_audio",
+					"jlpt_level", "part_of_speech", "tags",
+				}).
+					AddRow(1, "水", "みず", "水を飲む", 1, 3, 7, 14, "", "", nil, nil, nil)
+				mock.ExpectQuery(`FROM words WHERE id = \? LIMIT 1`).
+					WithArgs(1).
+					WillReturnRows(rows)
+				mock.ExpectQuery(`FROM word_translations WHERE word_id = \?`).
+					WithArgs(1).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+			expectedWord:  nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -637,6 +803,10 @@ func TestWordRepository_GetByIDAdmin(t *testing.T) {
 					assert.Equal(t, tt.expectedWord.ID, result.ID)
 					assert.Equal(t, tt.expectedWord.Word, result.Word)
 					assert.Equal(t, tt.expectedWord.PhoneticClues, result.PhoneticClues)
+					assert.Equal(t, tt.expectedWord.JLPTLevel, result.JLPTLevel)
+					assert.Equal(t, tt.expectedWord.PartOfSpeech, result.PartOfSpeech)
+					assert.Equal(t, tt.expectedWord.Tags, result.Tags)
+					assert.Equal(t, tt.expectedWord.Translations, result.Translations)
 				}
 			}
 
@@ -735,49 +905,94 @@ func TestWordRepository_Create(t *testing.T) {
 		{
 			name: "success",
 			word: &models.Word{
-				Word:                      "水",
-				PhoneticClues:             "みず",
-				RussianTranslation:        "вода",
-				EnglishTranslation:        "water",
-				GermanTranslation:         "Wasser",
-				Example:                   "水を飲む",
-				ExampleRussianTranslation: "пить воду",
-				ExampleEnglishTranslation: "drink water",
-				ExampleGermanTranslation:  "Wasser trinken",
-				EasyPeriod:                1,
-				NormalPeriod:              3,
-				HardPeriod:                7,
-				ExtraHardPeriod:           14,
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations: map[string]models.WordTranslation{
+					"ru": {Translation: "вода", ExampleTranslation: "пить воду"},
+					"en": {Translation: "water", ExampleTranslation: "drink water"},
+					"de": {Translation: "Wasser", ExampleTranslation: "Wasser trinken"},
+				},
+				Example:         "水を飲む",
+				EasyPeriod:      1,
+				NormalPeriod:    3,
+				HardPeriod:      7,
+				ExtraHardPeriod: 14,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO words`).
-					WithArgs("水", "みず", "вода", "water", "Wasser", "水を飲む", "пить воду", "drink water", "Wasser trinken", 1, 3, 7, 14, "", "").
+					WithArgs("水", "みず", "みず", "水を飲む", 1, 3, 7, 14, "", "", nil, nil).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO word_translations \(word_id, language, translation, example_translation\) VALUES \(\?,\?,\?,\?\), \(\?,\?,\?,\?\), \(\?,\?,\?,\?\) ON DUPLICATE KEY UPDATE`).
+					WithArgs(1, "de", "Wasser", "Wasser trinken", 1, "en", "water", "drink water", 1, "ru", "вода", "пить воду").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
 			},
 			expectedError: false,
 			expectedID:    1,
 		},
+		{
+			name: "success with classification and tags",
+			word: &models.Word{
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations: map[string]models.WordTranslation{
+					"ru": {Translation: "вода", ExampleTranslation: "пить воду"},
+					"en": {Translation: "water", ExampleTranslation: "drink water"},
+					"de": {Translation: "Wasser", ExampleTranslation: "Wasser trinken"},
+				},
+				Example:         "水を飲む",
+				EasyPeriod:      1,
+				NormalPeriod:    3,
+				HardPeriod:      7,
+				ExtraHardPeriod: 14,
+				JLPTLevel:       5,
+				PartOfSpeech:    "noun",
+				Tags:            []string{"drink", "nature"},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO words`).
+					WithArgs("水", "みず", "みず", "水を飲む", 1, 3, 7, 14, "", "", 5, "noun").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec(`INSERT INTO word_translations \(word_id, language, translation, example_translation\) VALUES \(\?,\?,\?,\?\), \(\?,\?,\?,\?\), \(\?,\?,\?,\?\) ON DUPLICATE KEY UPDATE`).
+					WithArgs(2, "de", "Wasser", "Wasser trinken", 2, "en", "water", "drink water", 2, "ru", "вода", "пить воду").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM word_tags WHERE word_id = \?`).
+					WithArgs(2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO word_tags \(word_id, tag\) VALUES \(\?, \?\), \(\?, \?\)`).
+					WithArgs(2, "drink", 2, "nature").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+			expectedID:    2,
+		},
 		{
 			name: "database error",
 			word: &models.Word{
-				Word:                      "水",
-				PhoneticClues:             "みず",
-				RussianTranslation:        "вода",
-				EnglishTranslation:        "water",
-				GermanTranslation:         "Wasser",
-				Example:                   "水を飲む",
-				ExampleRussianTranslation: "пить воду",
-				ExampleEnglishTranslation: "drink water",
-				ExampleGermanTranslation:  "Wasser trinken",
-				EasyPeriod:                1,
-				NormalPeriod:              3,
-				HardPeriod:                7,
-				ExtraHardPeriod:           14,
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations: map[string]models.WordTranslation{
+					"ru": {Translation: "вода", ExampleTranslation: "пить воду"},
+					"en": {Translation: "water", ExampleTranslation: "drink water"},
+					"de": {Translation: "Wasser", ExampleTranslation: "Wasser trinken"},
+				},
+				Example:         "水を飲む",
+				EasyPeriod:      1,
+				NormalPeriod:    3,
+				HardPeriod:      7,
+				ExtraHardPeriod: 14,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO words`).
-					WithArgs("水", "みず", "вода", "water", "Wasser", "水を飲む", "пить воду", "drink water", "Wasser trinken", 1, 3, 7, 14, "", "").
+					WithArgs("水", "みず", "みず", "水を飲む", 1, 3, 7, 14, "", "", nil, nil).
 					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
 			},
 			expectedError: true,
 			expectedID:    0,
@@ -785,24 +1000,44 @@ func TestWordRepository_Create(t *testing.T) {
 		{
 			name: "error getting last insert id",
 			word: &models.Word{
-				Word:                      "水",
-				PhoneticClues:             "みず",
-				RussianTranslation:        "вода",
-				EnglishTranslation:        "water",
-				GermanTranslation:         "Wasser",
-				Example:                   "水を飲む",
-				ExampleRussianTranslation: "пить воду",
-				ExampleEnglishTranslation: "drink water",
-				ExampleGermanTranslation:  "Wasser trinken",
-				EasyPeriod:                1,
-				NormalPeriod:              3,
-				HardPeriod:                7,
-				ExtraHardPeriod:           14,
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations: map[string]models.WordTranslation{
+					"ru": {Translation: "вода", ExampleTranslation: "пить воду"},
+					"en": {Translation: "water", ExampleTranslation: "drink water"},
+					"de": {Translation: "Wasser", ExampleTranslation: "Wasser trinken"},
+				},
+				Example:         "水を飲む",
+				EasyPeriod:      1,
+				NormalPeriod:    3,
+				HardPeriod:      7,
+				ExtraHardPeriod: 14,
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO words`).
-					WithArgs("水", "みず", "вода", "water", "Wasser", "水を飲む", "пить воду", "drink water", "Wasser trinken", 1, 3, 7, 14, "", "").
+					WithArgs("水", "みず", "みず", "水を飲む", 1, 3, 7, 14, "", "", nil, nil).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("last insert id error")))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+			expectedID:    0,
+		},
+		{
+			name: "translations database error",
+			word: &models.Word{
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations:  map[string]models.WordTranslation{"en": {Translation: "water"}},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`INSERT INTO words`).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(`INSERT INTO word_translations`).
+					WithArgs(1, "en", "water", "").
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
 			},
 			expectedError: true,
 			expectedID:    0,
@@ -921,24 +1156,26 @@ func TestWordRepository_Update(t *testing.T) {
 			name: "success - update all fields",
 			id:   1,
 			word: &models.Word{
-				Word:                      "火",
-				PhoneticClues:             "ひ",
-				RussianTranslation:        "огонь",
-				EnglishTranslation:        "fire",
-				GermanTranslation:         "Feuer",
-				Example:                   "火をつける",
-				ExampleRussianTranslation: "зажечь огонь",
-				ExampleEnglishTranslation: "light a fire",
-				ExampleGermanTranslation:  "Feuer anzünden",
-				EasyPeriod:                2,
-				NormalPeriod:              4,
-				HardPeriod:                8,
-				ExtraHardPeriod:           16,
-			},
-			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE words SET word = \?, phonetic_clues = \?, russian_translation = \?, english_translation = \?, german_translation = \?, example = \?, example_russian_translation = \?, example_english_translation = \?, example_german_translation = \?, easy_period = \?, normal_period = \?, hard_period = \?, extra_hard_period = \? WHERE id = \?`).
-					WithArgs("火", "ひ", "огонь", "fire", "Feuer", "火をつける", "зажечь огонь", "light a fire", "Feuer anzünden", 2, 4, 8, 16, 1).
+				Word:          "火",
+				PhoneticClues: "ひ",
+				Translations: map[string]models.WordTranslation{
+					"ru": {Translation: "огонь", ExampleTranslation: "зажечь огонь"},
+					"en": {Translation: "fire", ExampleTranslation: "light a fire"},
+					"de": {Translation: "Feuer", ExampleTranslation: "Feuer anzünden"},
+				},
+				Example:         "火をつける",
+				EasyPeriod:      2,
+				NormalPeriod:    4,
+				HardPeriod:      8,
+				ExtraHardPeriod: 16,
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE words SET word = \?, phonetic_clues = \?, search_reading = \?, example = \?, easy_period = \?, normal_period = \?, hard_period = \?, extra_hard_period = \? WHERE id = \?`).
+					WithArgs("火", "ひ", "ひ", "火をつける", 2, 4, 8, 16, 1).
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO word_translations \(word_id, language, translation, example_translation\) VALUES \(\?,\?,\?,\?\), \(\?,\?,\?,\?\), \(\?,\?,\?,\?\) ON DUPLICATE KEY UPDATE translation = IF\(VALUES\(translation\) = '', translation, VALUES\(translation\)\), example_translation = IF\(VALUES\(example_translation\) = '', example_translation, VALUES\(example_translation\)\)`).
+					WithArgs(1, "de", "Feuer", "Feuer anzünden", 1, "en", "fire", "light a fire", 1, "ru", "огонь", "зажечь огонь").
+					WillReturnResult(sqlmock.NewResult(0, 3))
 			},
 			expectedError: false,
 		},
@@ -946,16 +1183,97 @@ func TestWordRepository_Update(t *testing.T) {
 			name: "success - partial update",
 			id:   1,
 			word: &models.Word{
-				Word:               "火",
-				EnglishTranslation: "fire",
+				Word:         "火",
+				Translations: map[string]models.WordTranslation{"en": {Translation: "fire"}},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE words SET word = \? WHERE id = \?`).
+					WithArgs("火", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO word_translations`).
+					WithArgs(1, "en", "fire", "").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "success - translations only",
+			id:   1,
+			word: &models.Word{
+				Translations: map[string]models.WordTranslation{"uk": {Translation: "вогонь"}},
 			},
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(`UPDATE words SET word = \?, english_translation = \? WHERE id = \?`).
-					WithArgs("火", "fire", 1).
+				mock.ExpectExec(`INSERT INTO word_translations`).
+					WithArgs(1, "uk", "вогонь", "").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "success - katakana clues get hiragana search reading",
+			id:   1,
+			word: &models.Word{
+				PhoneticClues: "ミズ",
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE words SET phonetic_clues = \?, search_reading = \? WHERE id = \?`).
+					WithArgs("ミズ", "みず", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedError: false,
+		},
+		{
+			name: "success - classification and tags",
+			id:   1,
+			word: &models.Word{
+				JLPTLevel:    4,
+				PartOfSpeech: "verb",
+				Tags:         []string{"travel"},
+			},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE words SET jlpt_level = \?, part_of_speech = \? WHERE id = \?`).
+					WithArgs(4, "verb", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM word_tags WHERE word_id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`INSERT INTO word_tags \(word_id, tag\) VALUES \(\?, \?\)`).
+					WithArgs(1, "travel").
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			expectedError: false,
 		},
+		{
+			name: "success - remove all tags",
+			id:   1,
+			word: &models.Word{Tags: []string{}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM word_tags WHERE word_id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+		},
+		{
+			name: "tags insert error",
+			id:   1,
+			word: &models.Word{Tags: []string{"travel"}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM word_tags WHERE word_id = \?`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`INSERT INTO word_tags`).
+					WithArgs(1, "travel").
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
 		{
 			name: "no fields to update",
 			id:   1,
@@ -1026,6 +1344,316 @@ func TestWordRepository_Update(t *testing.T) {
 	}
 }
 
+func TestWordRepository_GetTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedTags  []models.WordTag
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"tag", "word_count"}).
+					AddRow("food", 12).
+					AddRow("travel", 3)
+				mock.ExpectQuery(`SELECT tag, COUNT\(\*\) as word_count FROM word_tags GROUP BY tag ORDER BY tag`).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedTags:  []models.WordTag{{Tag: "food", WordCount: 12}, {Tag: "travel", WordCount: 3}},
+		},
+		{
+			name: "no tags",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM word_tags`).
+					WillReturnRows(sqlmock.NewRows([]string{"tag", "word_count"}))
+			},
+			expectedError: false,
+			expectedTags:  []models.WordTag{},
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM word_tags`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetTags(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTags, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepository_Search(t *testing.T) {
+	searchColumns := []string{"id", "word", "phonetic_clues", "translation", "jlpt_level", "part_of_speech", "match_rank"}
+	tests := []struct {
+		name          string
+		query         string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedWords []models.WordSearchResult
+	}{
+		{
+			name:  "success with romaji query",
+			query: "Taberu",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(searchColumns).
+					AddRow(1, "食べる", "たべる", "to eat", 5, "verb", 0).
+					AddRow(2, "食べ物", "たべもの", "food", nil, nil, 1)
+				mock.ExpectQuery(`SELECT w.id, w.word, w.phonetic_clues, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = w.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation, w.jlpt_level, w.part_of_speech, matches.match_rank FROM \( SELECT id, MIN\(match_rank\) as match_rank FROM \( SELECT id, IF\(word = \?, 0, 1\) as match_rank FROM words WHERE word LIKE \? UNION ALL SELECT id, IF\(search_reading = \?, 0, 1\) FROM words WHERE search_reading LIKE \? UNION ALL SELECT word_id, IF\(translation = \?, 0, 1\) FROM word_translations WHERE language IN \(\?\) AND translation LIKE \? UNION ALL SELECT word_id, 2 FROM word_translations WHERE language IN \(\?\) AND MATCH\(translation\) AGAINST \(\? IN BOOLEAN MODE\) \) candidates GROUP BY id \) matches JOIN words w ON w.id = matches.id ORDER BY matches.match_rank, CHAR_LENGTH\(w.word\), w.id LIMIT \?`).
+					WithArgs("en", "en", "Taberu", "Taberu%", "たべる", "たべる%", "Taberu", "en", "Taberu%", "en", `"Taberu"`, 20).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedWords: []models.WordSearchResult{
+				{ID: 1, Word: "食べる", PhoneticClues: "たべる", Translation: "to eat", JLPTLevel: 5, PartOfSpeech: "verb"},
+				{ID: 2, Word: "食べ物", PhoneticClues: "たべもの", Translation: "food"},
+			},
+		},
+		{
+			name:  "query with wildcards, quotes and kanji",
+			query: `100%_"水"`,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM words`).
+					WithArgs("en", "en", `100%_"水"`, `100\%\_"水"%`, `100%_"水"`, `100\%\_"水"%`, `100%_"水"`, "en", `100\%\_"水"%`, "en", `"100%_ 水 "`, 20).
+					WillReturnRows(sqlmock.NewRows(searchColumns))
+			},
+			expectedError: false,
+			expectedWords: []models.WordSearchResult{},
+		},
+		{
+			name:  "database error",
+			query: "mizu",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM words`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name:  "scan error",
+			query: "mizu",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(searchColumns).
+					AddRow("invalid", "水", "みず", "water", nil, nil, 0)
+				mock.ExpectQuery(`FROM words`).
+					WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.Search(context.Background(), tt.query, 20, []string{"en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedWords, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepository_GetQuizDistractors(t *testing.T) {
+	columns := []string{"quiz_word_id", "id", "word", "translation"}
+	tests := []struct {
+		name                string
+		wordIds             []int
+		setupMock           func(sqlmock.Sqlmock)
+		expectedError       bool
+		expectedDistractors map[int][]models.WordQuizDistractor
+	}{
+		{
+			name:    "success",
+			wordIds: []int{1, 2},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(1, 4, "お茶", "tea").
+					AddRow(1, 5, "牛乳", "milk").
+					AddRow(2, 6, "風", "")
+				mock.ExpectQuery(`SELECT candidates.quiz_word_id, d.id, d.word, COALESCE\(\(SELECT t.translation FROM word_translations t WHERE t.word_id = d.id AND t.language IN \(\?\) AND t.translation != '' ORDER BY FIELD\(t.language, \?\) LIMIT 1\), ''\) as translation FROM \( SELECT q.id as quiz_word_id, s.id, ROW_NUMBER\(\) OVER \( PARTITION BY q.id ORDER BY \(COALESCE\(s.part_of_speech = q.part_of_speech, FALSE\) OR EXISTS \( SELECT 1 FROM word_tags qt JOIN word_tags dt ON dt.tag = qt.tag WHERE qt.word_id = q.id AND dt.word_id = s.id \)\) DESC, RAND\(\) \) as position FROM words q JOIN \( \( SELECT id, part_of_speech FROM words WHERE id >= \(SELECT MIN\(id\) \+ FLOOR\(\? \* \(MAX\(id\) - MIN\(id\) \+ 1\)\) FROM words\) ORDER BY id LIMIT \? \) UNION ALL \( SELECT id, part_of_speech FROM words WHERE id < \(SELECT MIN\(id\) \+ FLOOR\(\? \* \(MAX\(id\) - MIN\(id\) \+ 1\)\) FROM words\) ORDER BY id DESC LIMIT \? \) \) s ON s.id != q.id WHERE q.id IN \(\?,\?\) \) candidates JOIN words d ON d.id = candidates.id WHERE candidates.position <= \? ORDER BY candidates.quiz_word_id, candidates.position`).
+					WithArgs("en", "en", sqlmock.AnyArg(), 100, sqlmock.AnyArg(), 100, 1, 2, 6).
+					WillReturnRows(rows)
+			},
+			expectedError: false,
+			expectedDistractors: map[int][]models.WordQuizDistractor{
+				1: {{WordID: 4, Word: "お茶", Translation: "tea"}, {WordID: 5, Word: "牛乳", Translation: "milk"}},
+				2: {{WordID: 6, Word: "風", Translation: ""}},
+			},
+		},
+		{
+			name:                "no words",
+			wordIds:             nil,
+			setupMock:           func(mock sqlmock.Sqlmock) {},
+			expectedError:       false,
+			expectedDistractors: map[int][]models.WordQuizDistractor{},
+		},
+		{
+			name:    "database error",
+			wordIds: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM words q`).
+					WillReturnError(errors.New("database error"))
+			},
+			expectedError: true,
+		},
+		{
+			name:    "scan error",
+			wordIds: []int{1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("invalid", 4, "お茶", "tea")
+				mock.ExpectQuery(`FROM words q`).
+					WillReturnRows(rows)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			result, err := repo.GetQuizDistractors(context.Background(), tt.wordIds, 6, []string{"en"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDistractors, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWordRepository_FillSearchReadings(t *testing.T) {
+	tests := []struct {
+		name          string
+		setupMock     func(sqlmock.Sqlmock)
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"id", "phonetic_clues"}).
+					AddRow(1, "みず").
+					AddRow(2, "ラーメン")
+				mock.ExpectQuery(`SELECT id, phonetic_clues FROM words WHERE search_reading IS NULL`).
+					WillReturnRows(rows)
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE words SET search_reading = \? WHERE id = \?`).
+					WithArgs("みず", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE words SET search_reading = \? WHERE id = \?`).
+					WithArgs("らあめん", 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+		{
+			name: "all readings are filled",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE search_reading IS NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "phonetic_clues"}))
+			},
+			expectedError: false,
+			expectedCount: 0,
+		},
+		{
+			name: "update error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE search_reading IS NULL`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "phonetic_clues"}).AddRow(1, "みず"))
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE words SET search_reading`).
+					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock, cleanup := setupWordTestRepository(t)
+			defer cleanup()
+
+			tt.setupMock(mock)
+
+			count, err := repo.FillSearchReadings(context.Background())
+
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSearchReading(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "たべる", expected: "たべる"},
+		{text: "タベル", expected: "たべる"},
+		{text: " TABERU ", expected: "たべる"},
+		{text: "tōkyō", expected: "とうきょう"},
+		{text: "食べる", expected: "食べる"},
+		{text: "Water", expected: "water"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.expected, searchReading(tt.text))
+		})
+	}
+}
+
 func TestWordRepository_Delete(t *testing.T) {
 	tests := []struct {
 		name          string
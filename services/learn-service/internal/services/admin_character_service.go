@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
 )
@@ -59,18 +62,34 @@ type AdminCharactersRepository interface {
 	Delete(ctx context.Context, id int) error
 }
 
+// AdminCharacterStrokeRepository is the interface that wraps methods for CharacterStrokes table data management
+type AdminCharacterStrokeRepository interface {
+	// Method ReplaceForCharacter replaces all strokes of a character for the alphabet type.
+	//
+	// "characterID" parameter is used to identify the character.
+	// "alphabetType" parameter is used to identify the alphabet ("hiragana" or "katakana").
+	// "strokes" parameter contains new strokes in stroke order.
+	//
+	// If some error will occur during data update, the error will be returned.
+	ReplaceForCharacter(ctx context.Context, characterID int, alphabetType string, strokes []models.CharacterStroke) error
+}
+
 type adminService struct {
 	repo         AdminCharactersRepository
+	strokeRepo   AdminCharacterStrokeRepository
 	mediaBaseURL string
 	apiKey       string
+	locale       LocaleConfig
 }
 
 // NewAdminService creates a new admin service
-func NewAdminService(repo AdminCharactersRepository, mediaBaseURL, apiKey string) *adminService {
+func NewAdminService(repo AdminCharactersRepository, strokeRepo AdminCharacterStrokeRepository, mediaBaseURL, apiKey string, locale LocaleConfig) *adminService {
 	return &adminService{
 		repo:         repo,
+		strokeRepo:   strokeRepo,
 		mediaBaseURL: mediaBaseURL,
 		apiKey:       apiKey,
+		locale:       locale,
 	}
 }
 
@@ -86,6 +105,7 @@ func (s *adminService) GetAllForAdmin(ctx context.Context) ([]models.CharacterLi
 			ID:        char.ID,
 			Consonant: char.Consonant,
 			Vowel:     char.Vowel,
+			Group:     char.Group,
 			Katakana:  char.Katakana,
 			Hiragana:  char.Hiragana,
 		}
@@ -103,19 +123,37 @@ func (s *adminService) GetByIDAdmin(ctx context.Context, id int) (*models.Charac
 }
 
 // CreateCharacter creates a new character
+//
+// Character group is "basic" if not specified.
+// Readings are keyed by language codes, the reading in the default locale is required.
 func (s *adminService) CreateCharacter(ctx context.Context, request *models.CreateCharacterRequest, audioFile multipart.File, audioFilename string) (int, error) {
+	group := models.CharacterGroup(request.Group)
+	if group == "" {
+		group = models.CharacterGroupBasic
+	}
+	if err := validateCharacterGroup(group, request.Katakana, request.Hiragana); err != nil {
+		return 0, err
+	}
+	readings, err := normalizeCharacterReadings(request.Readings)
+	if err != nil {
+		return 0, err
+	}
+	if readings[s.locale.Default] == "" {
+		return 0, fmt.Errorf("validation error: reading in the default locale '%s' is required", s.locale.Default)
+	}
+
 	// Perform validation before creating a new character
 	if err := s.checkCreateCharacterValidation(ctx, request); err != nil {
 		return 0, err
 	}
 
 	character := &models.Character{
-		Consonant:      request.Consonant,
-		Vowel:          request.Vowel,
-		EnglishReading: request.EnglishReading,
-		RussianReading: request.RussianReading,
-		Katakana:       request.Katakana,
-		Hiragana:       request.Hiragana,
+		Consonant: request.Consonant,
+		Vowel:     request.Vowel,
+		Group:     group,
+		Readings:  readings,
+		Katakana:  request.Katakana,
+		Hiragana:  request.Hiragana,
 	}
 
 	// Handle audio file upload if provided
@@ -127,7 +165,7 @@ func (s *adminService) CreateCharacter(ctx context.Context, request *models.Crea
 		character.Audio = audioURL
 	}
 
-	err := s.repo.Create(ctx, character)
+	err = s.repo.Create(ctx, character)
 	if err != nil {
 		return 0, err
 	}
@@ -176,6 +214,10 @@ func (s *adminService) UpdateCharacter(ctx context.Context, id int, request *mod
 	if id <= 0 {
 		return fmt.Errorf("invalid character id")
 	}
+	readings, err := normalizeCharacterReadings(request.Readings)
+	if err != nil {
+		return err
+	}
 
 	// Get current character to check for existing audio URL
 	currentCharacter, err := s.repo.GetByIDAdmin(ctx, id)
@@ -183,6 +225,24 @@ func (s *adminService) UpdateCharacter(ctx context.Context, id int, request *mod
 		return fmt.Errorf("character not found")
 	}
 
+	// Kana are checked against the resulting group, so changing only one of them keeps the character consistent
+	if request.Group != "" || request.Katakana != "" || request.Hiragana != "" {
+		group := currentCharacter.Group
+		if request.Group != "" {
+			group = models.CharacterGroup(request.Group)
+		}
+		katakana, hiragana := currentCharacter.Katakana, currentCharacter.Hiragana
+		if request.Katakana != "" {
+			katakana = request.Katakana
+		}
+		if request.Hiragana != "" {
+			hiragana = request.Hiragana
+		}
+		if err := validateCharacterGroup(group, katakana, hiragana); err != nil {
+			return err
+		}
+	}
+
 	if err := s.checkUpdateCharacterValidation(ctx, currentCharacter, request); err != nil {
 		return err
 	}
@@ -217,11 +277,11 @@ func (s *adminService) UpdateCharacter(ctx context.Context, id int, request *mod
 	if request.Vowel != "" {
 		characterToUpdate.Vowel = request.Vowel
 	}
-	if request.EnglishReading != "" {
-		characterToUpdate.EnglishReading = request.EnglishReading
+	if request.Group != "" {
+		characterToUpdate.Group = models.CharacterGroup(request.Group)
 	}
-	if request.RussianReading != "" {
-		characterToUpdate.RussianReading = request.RussianReading
+	if len(readings) > 0 {
+		characterToUpdate.Readings = readings
 	}
 	if request.Katakana != "" {
 		characterToUpdate.Katakana = request.Katakana
@@ -236,6 +296,49 @@ func (s *adminService) UpdateCharacter(ctx context.Context, id int, request *mod
 	return s.repo.Update(ctx, id, characterToUpdate)
 }
 
+// maxCharacterReadingLength is the maximum length of a reading of a character
+const maxCharacterReadingLength = 10
+
+// normalizeCharacterReadings trims readings of a character and lowercases their language codes
+//
+// Empty readings are dropped. If some reading is longer than 10 characters, the error will be returned.
+// Please reference normalizeLanguages function for more information about other error values.
+func normalizeCharacterReadings(readings map[string]string) (map[string]string, error) {
+	readings, err := normalizeLanguages(readings)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range slices.Sorted(maps.Keys(readings)) {
+		reading := strings.TrimSpace(readings[language])
+		if reading == "" {
+			delete(readings, language)
+			continue
+		}
+		if utf8.RuneCountInString(reading) > maxCharacterReadingLength {
+			return nil, fmt.Errorf("validation error: %s reading must be at most %d characters long", language, maxCharacterReadingLength)
+		}
+		readings[language] = reading
+	}
+	return readings, nil
+}
+
+// validateCharacterGroup checks that the group is known and that kana match the size of the group
+//
+// Yōon characters consist of two kana, all other characters consist of a single one.
+// Empty kana values are not checked.
+func validateCharacterGroup(group models.CharacterGroup, katakana, hiragana string) error {
+	if !group.IsValid() {
+		return fmt.Errorf("invalid character group: %s, must be 'basic', 'dakuten', 'handakuten' or 'yoon'", group)
+	}
+	for _, kana := range []string{katakana, hiragana} {
+		if kana != "" && utf8.RuneCountInString(kana) != group.KanaLength() {
+			return fmt.Errorf("character of group '%s' must consist of %d kana", group, group.KanaLength())
+		}
+	}
+	return nil
+}
+
 // checkUpdateCharacterValidation checks the validity of the character update request
 func (s *adminService) checkUpdateCharacterValidation(ctx context.Context, currentCharacter *models.Character, request *models.UpdateCharacterRequest) error {
 	validationErrors := make(chan error, 2)
@@ -285,6 +388,34 @@ func (s *adminService) checkUpdateCharacterValidation(ctx context.Context, curre
 	return nil
 }
 
+// ImportCharacterStrokes imports stroke-order data of a character from a KanjiVG SVG file
+//
+// For successful results alphabetType must be either "hiragana" or "katakana".
+// Existing strokes of the character for the alphabet are replaced.
+// Returns the number of imported strokes.
+func (s *adminService) ImportCharacterStrokes(ctx context.Context, id int, alphabetType string, svg io.Reader) (int, error) {
+	if id <= 0 {
+		return 0, fmt.Errorf("invalid character id")
+	}
+	if alphabetType != "hiragana" && alphabetType != "katakana" {
+		return 0, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetType)
+	}
+
+	if _, err := s.repo.GetByIDAdmin(ctx, id); err != nil {
+		return 0, fmt.Errorf("character not found")
+	}
+
+	strokes, err := parseKanjiVGStrokes(svg)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.strokeRepo.ReplaceForCharacter(ctx, id, alphabetType, strokes); err != nil {
+		return 0, err
+	}
+	return len(strokes), nil
+}
+
 // DeleteCharacter deletes a character by ID
 func (s *adminService) DeleteCharacter(ctx context.Context, id int) error {
 	if id <= 0 {
@@ -311,7 +442,7 @@ func (s *adminService) DeleteCharacter(ctx context.Context, id int) error {
 }
 
 // uploadFileToMediaService uploads a file to the media-service using io.Pipe for streaming
-func uploadFileToMediaService(ctx context.Context, mediaBaseURL, apiKey, mediaType string, file multipart.File, filename string) (string, error) {
+func uploadFileToMediaService(ctx context.Context, mediaBaseURL, apiKey, mediaType string, file io.Reader, filename string) (string, error) {
 	if mediaBaseURL == "" {
 		return "", fmt.Errorf("MEDIA_BASE_URL is not configured")
 	}
@@ -425,6 +556,45 @@ func deleteFileFromMediaService(ctx context.Context, mediaBaseURL, apiKey, media
 	return nil
 }
 
+// downloadFileFromMediaService sends a GET request to media service and returns the content of the file
+func downloadFileFromMediaService(ctx context.Context, mediaBaseURL, apiKey, mediaType, fileID string) ([]byte, error) {
+	if mediaBaseURL == "" || apiKey == "" {
+		return nil, fmt.Errorf("media service is not configured")
+	}
+
+	// Construct the download URL: {mediaBaseURL}/media/{mediaType}/{fileID}
+	downloadURL := strings.TrimSuffix(mediaBaseURL, "/") + "/media/" + mediaType + "/" + fileID
+
+	// Create GET request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	// Set API key header, files other than characters require authentication
+	req.Header.Set("X-API-Key", apiKey)
+
+	// Execute request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send download request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check response status
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("media service returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return content, nil
+}
+
 // extractFileIDFromURL extracts the file ID (filename) from the audio URL
 // The URL format is expected to be like: http://.../media/{mediaType}/{fileID}
 // Returns the last part of the URL path as the file ID
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
@@ -82,10 +83,24 @@ func (m *mockAdminCharactersRepository) Delete(ctx context.Context, id int) erro
 	return m.err
 }
 
+// mockAdminCharacterStrokeRepository is a mock implementation of AdminCharacterStrokeRepository
+type mockAdminCharacterStrokeRepository struct {
+	replaced []models.CharacterStroke
+	err      error
+}
+
+func (m *mockAdminCharacterStrokeRepository) ReplaceForCharacter(ctx context.Context, characterID int, alphabetType string, strokes []models.CharacterStroke) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.replaced = strokes
+	return nil
+}
+
 func TestNewAdminService(t *testing.T) {
 	mockRepo := &mockAdminCharactersRepository{}
 
-	svc := NewAdminService(mockRepo, "", "")
+	svc := NewAdminService(mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 
 	assert.NotNil(t, svc)
 	assert.Equal(t, mockRepo, svc.repo)
@@ -129,7 +144,7 @@ func TestAdminService_GetAllForAdmin(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminService(tt.mockRepo, "", "")
+			svc := NewAdminService(tt.mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.GetAllForAdmin(ctx)
@@ -159,14 +174,13 @@ func TestAdminService_GetByIDAdmin(t *testing.T) {
 			id:   1,
 			mockRepo: &mockAdminCharactersRepository{
 				character: &models.Character{
-					ID:             1,
-					Consonant:      "",
-					Vowel:          "a",
-					EnglishReading: "a",
-					RussianReading: "а",
-					Katakana:       "ア",
-					Hiragana:       "あ",
-					Audio:          "",
+					ID:        1,
+					Consonant: "",
+					Vowel:     "a",
+					Readings:  map[string]string{"en": "a", "ru": "а"},
+					Katakana:  "ア",
+					Hiragana:  "あ",
+					Audio:     "",
 				},
 			},
 			expectedError: false,
@@ -199,7 +213,7 @@ func TestAdminService_GetByIDAdmin(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminService(tt.mockRepo, "", "")
+			svc := NewAdminService(tt.mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.GetByIDAdmin(ctx, tt.id)
@@ -228,12 +242,27 @@ func TestAdminService_CreateCharacter(t *testing.T) {
 		{
 			name: "success",
 			request: &models.CreateCharacterRequest{
-				Consonant:      "k",
-				Vowel:          "a",
-				EnglishReading: "ka",
-				RussianReading: "ка",
-				Katakana:       "カ",
-				Hiragana:       "か",
+				Consonant: "k",
+				Vowel:     "a",
+				Readings:  map[string]string{"en": "ka", "ru": "ка"},
+				Katakana:  "カ",
+				Hiragana:  "か",
+			},
+			mockRepo: &mockAdminCharactersRepository{
+				exists: false,
+			},
+			expectedError: false,
+			expectedID:    1,
+		},
+		{
+			name: "success yoon character",
+			request: &models.CreateCharacterRequest{
+				Consonant: "ky",
+				Vowel:     "a",
+				Group:     "yoon",
+				Readings:  map[string]string{"en": "kya", "ru": "кя"},
+				Katakana:  "キャ",
+				Hiragana:  "きゃ",
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				exists: false,
@@ -241,11 +270,53 @@ func TestAdminService_CreateCharacter(t *testing.T) {
 			expectedError: false,
 			expectedID:    1,
 		},
+		{
+			name: "invalid character group",
+			request: &models.CreateCharacterRequest{
+				Consonant: "g",
+				Vowel:     "a",
+				Group:     "voiced",
+				Katakana:  "ガ",
+				Hiragana:  "が",
+			},
+			mockRepo:      &mockAdminCharactersRepository{},
+			expectedError: true,
+			expectedID:    0,
+			errorContains: "invalid character group",
+		},
+		{
+			name: "yoon character with a single kana",
+			request: &models.CreateCharacterRequest{
+				Consonant: "ky",
+				Vowel:     "a",
+				Group:     "yoon",
+				Katakana:  "キ",
+				Hiragana:  "き",
+			},
+			mockRepo:      &mockAdminCharactersRepository{},
+			expectedError: true,
+			expectedID:    0,
+			errorContains: "must consist of 2 kana",
+		},
+		{
+			name: "basic character with two kana",
+			request: &models.CreateCharacterRequest{
+				Consonant: "k",
+				Vowel:     "a",
+				Katakana:  "キャ",
+				Hiragana:  "きゃ",
+			},
+			mockRepo:      &mockAdminCharactersRepository{},
+			expectedError: true,
+			expectedID:    0,
+			errorContains: "must consist of 1 kana",
+		},
 		{
 			name: "character already exists",
 			request: &models.CreateCharacterRequest{
 				Consonant: "k",
 				Vowel:     "a",
+				Readings:  map[string]string{"en": "ka"},
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				exists: true,
@@ -259,6 +330,7 @@ func TestAdminService_CreateCharacter(t *testing.T) {
 			request: &models.CreateCharacterRequest{
 				Consonant: "k",
 				Vowel:     "a",
+				Readings:  map[string]string{"en": "ka"},
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				err: errors.New("database error"),
@@ -267,11 +339,40 @@ func TestAdminService_CreateCharacter(t *testing.T) {
 			expectedID:    0,
 			errorContains: "failed to check character existence",
 		},
+		{
+			name: "missing reading in the default locale",
+			request: &models.CreateCharacterRequest{
+				Consonant: "k",
+				Vowel:     "a",
+				Readings:  map[string]string{"ru": "ка"},
+				Katakana:  "カ",
+				Hiragana:  "か",
+			},
+			mockRepo:      &mockAdminCharactersRepository{},
+			expectedError: true,
+			expectedID:    0,
+			errorContains: "reading in the default locale 'en' is required",
+		},
+		{
+			name: "invalid reading language",
+			request: &models.CreateCharacterRequest{
+				Consonant: "k",
+				Vowel:     "a",
+				Readings:  map[string]string{"en": "ka", "english": "ka"},
+				Katakana:  "カ",
+				Hiragana:  "か",
+			},
+			mockRepo:      &mockAdminCharactersRepository{},
+			expectedError: true,
+			expectedID:    0,
+			errorContains: "invalid language: english",
+		},
 		{
 			name: "failed to create - repository error",
 			request: &models.CreateCharacterRequest{
 				Consonant: "k",
 				Vowel:     "a",
+				Readings:  map[string]string{"en": "ka"},
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				exists:    false,
@@ -284,7 +385,7 @@ func TestAdminService_CreateCharacter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminService(tt.mockRepo, "", "")
+			svc := NewAdminService(tt.mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.CreateCharacter(ctx, tt.request, nil, "")
@@ -316,7 +417,7 @@ func TestAdminService_UpdateCharacter(t *testing.T) {
 			name: "success partial update",
 			id:   1,
 			request: &models.UpdateCharacterRequest{
-				EnglishReading: "ka",
+				Readings: map[string]string{"en": "ka"},
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				character: &models.Character{
@@ -380,6 +481,73 @@ func TestAdminService_UpdateCharacter(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "success update of yoon kana",
+			id:   72,
+			request: &models.UpdateCharacterRequest{
+				Hiragana: "きゃ",
+			},
+			mockRepo: &mockAdminCharactersRepository{
+				character: &models.Character{
+					ID:       72,
+					Group:    models.CharacterGroupYoon,
+					Katakana: "キャ",
+					Hiragana: "きや",
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "single kana for yoon character",
+			id:   72,
+			request: &models.UpdateCharacterRequest{
+				Hiragana: "き",
+			},
+			mockRepo: &mockAdminCharactersRepository{
+				character: &models.Character{
+					ID:       72,
+					Group:    models.CharacterGroupYoon,
+					Katakana: "キャ",
+					Hiragana: "きゃ",
+				},
+			},
+			expectedError: true,
+			errorContains: "must consist of 2 kana",
+		},
+		{
+			name: "group change not matching current kana",
+			id:   1,
+			request: &models.UpdateCharacterRequest{
+				Group: "yoon",
+			},
+			mockRepo: &mockAdminCharactersRepository{
+				character: &models.Character{
+					ID:       1,
+					Group:    models.CharacterGroupBasic,
+					Katakana: "カ",
+					Hiragana: "か",
+				},
+			},
+			expectedError: true,
+			errorContains: "must consist of 2 kana",
+		},
+		{
+			name: "invalid character group",
+			id:   1,
+			request: &models.UpdateCharacterRequest{
+				Group: "voiced",
+			},
+			mockRepo: &mockAdminCharactersRepository{
+				character: &models.Character{
+					ID:       1,
+					Group:    models.CharacterGroupBasic,
+					Katakana: "カ",
+					Hiragana: "か",
+				},
+			},
+			expectedError: true,
+			errorContains: "invalid character group",
+		},
 		{
 			name:          "invalid id zero",
 			id:            0,
@@ -450,7 +618,7 @@ func TestAdminService_UpdateCharacter(t *testing.T) {
 			name: "failed to update",
 			id:   1,
 			request: &models.UpdateCharacterRequest{
-				EnglishReading: "ka",
+				Readings: map[string]string{"en": "ka"},
 			},
 			mockRepo: &mockAdminCharactersRepository{
 				character: &models.Character{
@@ -467,7 +635,7 @@ func TestAdminService_UpdateCharacter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminService(tt.mockRepo, "", "")
+			svc := NewAdminService(tt.mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			err := svc.UpdateCharacter(ctx, tt.id, tt.request, nil, "")
@@ -533,7 +701,7 @@ func TestAdminService_DeleteCharacter(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminService(tt.mockRepo, "", "")
+			svc := NewAdminService(tt.mockRepo, &mockAdminCharacterStrokeRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			err := svc.DeleteCharacter(ctx, tt.id)
@@ -549,3 +717,121 @@ func TestAdminService_DeleteCharacter(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminService_ImportCharacterStrokes(t *testing.T) {
+	// Shortened KanjiVG file of "い" (03044)
+	const kanjiVG = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.0//EN" "http://www.w3.org/TR/2001/REC-SVG-20010904/DTD/svg10.dtd" [
+<!ATTLIST g
+xmlns:kvg CDATA #FIXED "http://kanjivg.tagaini.net"
+kvg:element CDATA #IMPLIED >
+]>
+<svg xmlns="http://www.w3.org/2000/svg" width="109" height="109" viewBox="0 0 109 109">
+<g id="kvg:StrokePaths_03044" style="fill:none;stroke:#000000;stroke-width:3;">
+<g id="kvg:03044" kvg:element="い">
+	<path id="kvg:03044-s2" d="M67.25,38.25c6.5,4.5,11.75,14,13.5,23.75"/>
+	<path id="kvg:03044-s1" d="M22.25,34.5c0.5,2.25,0.75,6.5,1,12.25C23.84,60.22,26.75,75,31.5,80.5"/>
+</g>
+</g>
+<g id="kvg:StrokeNumbers_03044" style="font-size:8;fill:#808080">
+	<text transform="matrix(1 0 0 1 15.5 33.5)">1</text>
+	<text transform="matrix(1 0 0 1 60.5 36.5)">2</text>
+</g>
+</svg>`
+
+	tests := []struct {
+		name          string
+		id            int
+		alphabetType  string
+		svg           string
+		mockRepo      *mockAdminCharactersRepository
+		strokeRepo    *mockAdminCharacterStrokeRepository
+		expectedCount int
+		errorContains string
+	}{
+		{
+			name:          "success",
+			id:            2,
+			alphabetType:  "hiragana",
+			svg:           kanjiVG,
+			mockRepo:      &mockAdminCharactersRepository{character: &models.Character{ID: 2}},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			expectedCount: 2,
+		},
+		{
+			name:          "invalid alphabet type",
+			id:            2,
+			alphabetType:  "kanji",
+			svg:           kanjiVG,
+			mockRepo:      &mockAdminCharactersRepository{},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			errorContains: "invalid alphabet type",
+		},
+		{
+			name:          "character not found",
+			id:            999,
+			alphabetType:  "hiragana",
+			svg:           kanjiVG,
+			mockRepo:      &mockAdminCharactersRepository{err: errors.New("character not found")},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			errorContains: "character not found",
+		},
+		{
+			name:          "file without strokes",
+			id:            2,
+			alphabetType:  "hiragana",
+			svg:           `<svg xmlns="http://www.w3.org/2000/svg"><g id="kvg:03044"></g></svg>`,
+			mockRepo:      &mockAdminCharactersRepository{character: &models.Character{ID: 2}},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			errorContains: "contains no strokes",
+		},
+		{
+			name:          "gap in stroke numbers",
+			id:            2,
+			alphabetType:  "hiragana",
+			svg:           `<svg><path id="kvg:03044-s1" d="M1,1L2,2"/><path id="kvg:03044-s3" d="M1,1L2,2"/></svg>`,
+			mockRepo:      &mockAdminCharactersRepository{character: &models.Character{ID: 2}},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			errorContains: "inconsistent stroke numbers",
+		},
+		{
+			name:          "unsupported path",
+			id:            2,
+			alphabetType:  "hiragana",
+			svg:           `<svg><path id="kvg:03044-s1" d="M1,1A5,5,0,0,1,10,10"/></svg>`,
+			mockRepo:      &mockAdminCharactersRepository{character: &models.Character{ID: 2}},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{},
+			errorContains: "unsupported path command",
+		},
+		{
+			name:          "repository error",
+			id:            2,
+			alphabetType:  "hiragana",
+			svg:           kanjiVG,
+			mockRepo:      &mockAdminCharactersRepository{character: &models.Character{ID: 2}},
+			strokeRepo:    &mockAdminCharacterStrokeRepository{err: errors.New("database error")},
+			errorContains: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminService(tt.mockRepo, tt.strokeRepo, "", "", DefaultLocaleConfig())
+
+			count, err := svc.ImportCharacterStrokes(context.Background(), tt.id, tt.alphabetType, strings.NewReader(tt.svg))
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Equal(t, 0, count)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+			if assert.Len(t, tt.strokeRepo.replaced, 2) {
+				assert.Equal(t, 1, tt.strokeRepo.replaced[0].StrokeNumber)
+				assert.True(t, strings.HasPrefix(tt.strokeRepo.replaced[0].Path, "M22.25,34.5"))
+			}
+		})
+	}
+}
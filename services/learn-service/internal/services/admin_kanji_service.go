@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"mime/multipart"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// AdminKanjiRepository is the interface that wraps methods for Kanji table data access in admin endpoints
+type AdminKanjiRepository interface {
+	// Method GetAllForAdmin retrieves a paginated list of kanji.
+	//
+	// "page" parameter is used to specify the page number.
+	// "count" parameter is used to specify the number of items per page.
+	// "search" parameter is used to search kanji by character, readings, or meanings.
+	// "languages" parameter is the locale fallback chain, meanings are taken in its first language which has them.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.KanjiListItem, error)
+	// Method GetByIDAdmin retrieve a kanji by its ID.
+	//
+	// "id" parameter is used to identify the kanji.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByIDAdmin(ctx context.Context, id int) (*models.Kanji, error)
+	// Method ExistsByCharacter checks if a kanji with the same character exists.
+	//
+	// "character" parameter is used to check if a kanji with the same character exists.
+	//
+	// If some error will occur during data check, the error will be returned together with "false" value.
+	ExistsByCharacter(ctx context.Context, character string) (bool, error)
+	// Method Create creates a new kanji.
+	//
+	// "kanji" parameter is used to create a new kanji.
+	//
+	// If some error will occur during data creation, the error will be returned.
+	Create(ctx context.Context, kanji *models.Kanji) error
+	// Method Update updates a kanji.
+	//
+	// "id" parameter is used to identify the kanji.
+	// "kanji" parameter is used to update the kanji.
+	//
+	// If some error will occur during data update, the error will be returned.
+	Update(ctx context.Context, id int, kanji *models.Kanji) error
+	// Method Delete deletes a kanji.
+	//
+	// "id" parameter is used to identify the kanji.
+	//
+	// If some error will occur during data deletion, the error will be returned.
+	Delete(ctx context.Context, id int) error
+	// Method LinkWords rebuilds links between a kanji and all words containing it.
+	//
+	// "kanjiID" parameter is used to identify the kanji.
+	//
+	// If some error will occur during linking, the error will be returned.
+	LinkWords(ctx context.Context, kanjiID int) error
+}
+
+// adminKanjiService implements AdminKanjiService
+type adminKanjiService struct {
+	kanjiRepo    AdminKanjiRepository
+	mediaBaseURL string
+	apiKey       string
+	locale       LocaleConfig
+}
+
+// NewAdminKanjiService creates a new admin kanji service
+func NewAdminKanjiService(kanjiRepo AdminKanjiRepository, mediaBaseURL, apiKey string, locale LocaleConfig) *adminKanjiService {
+	return &adminKanjiService{
+		kanjiRepo:    kanjiRepo,
+		mediaBaseURL: mediaBaseURL,
+		apiKey:       apiKey,
+		locale:       locale,
+	}
+}
+
+// GetAllForAdmin retrieves a paginated list of kanji for admin endpoints
+//
+// Meanings are returned in the default locale or its fallbacks.
+func (s *adminKanjiService) GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.KanjiListItem, error) {
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 {
+		count = 20
+	}
+
+	kanji, err := s.kanjiRepo.GetAllForAdmin(ctx, page, count, search, s.locale.Chain(s.locale.Default))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji: %w", err)
+	}
+	return kanji, nil
+}
+
+// GetByIDAdmin retrieves a kanji by ID for admin endpoints
+func (s *adminKanjiService) GetByIDAdmin(ctx context.Context, id int) (*models.Kanji, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid kanji id")
+	}
+
+	return s.kanjiRepo.GetByIDAdmin(ctx, id)
+}
+
+// CreateKanji creates a new kanji and links it to the words containing it
+//
+// Meanings are keyed by language codes, the meaning in the default locale is required.
+func (s *adminKanjiService) CreateKanji(ctx context.Context, request *models.CreateKanjiRequest, audioFile multipart.File, audioFilename string) (int, error) {
+	if request.Character == "" {
+		return 0, fmt.Errorf("invalid kanji character: must not be empty")
+	}
+	if err := validateKanji(request.Character, &request.StrokeCount, &request.JLPTLevel); err != nil {
+		return 0, err
+	}
+	meanings, err := normalizeKanjiMeanings(request.Meanings)
+	if err != nil {
+		return 0, err
+	}
+	if meanings[s.locale.Default] == "" {
+		return 0, fmt.Errorf("validation error: meaning in the default locale '%s' is required", s.locale.Default)
+	}
+
+	exists, err := s.kanjiRepo.ExistsByCharacter(ctx, request.Character)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check kanji existence: %w", err)
+	}
+	if exists {
+		return 0, fmt.Errorf("kanji '%s' already exists", request.Character)
+	}
+
+	kanji := &models.Kanji{
+		Character:   request.Character,
+		Onyomi:      request.Onyomi,
+		Kunyomi:     request.Kunyomi,
+		Meanings:    meanings,
+		StrokeCount: request.StrokeCount,
+		JLPTLevel:   request.JLPTLevel,
+		Radicals:    request.Radicals,
+	}
+
+	// Handle audio file upload if provided
+	if audioFile != nil && audioFilename != "" {
+		audioURL, err := uploadFileToMediaService(ctx, s.mediaBaseURL, s.apiKey, "kanji", audioFile, audioFilename)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upload kanji audio: %w", err)
+		}
+		kanji.Audio = audioURL
+	}
+
+	if err := s.kanjiRepo.Create(ctx, kanji); err != nil {
+		return 0, fmt.Errorf("failed to create kanji: %w", err)
+	}
+
+	if err := s.kanjiRepo.LinkWords(ctx, kanji.ID); err != nil {
+		return 0, fmt.Errorf("failed to link kanji to words: %w", err)
+	}
+	return kanji.ID, nil
+}
+
+// UpdateKanji updates a kanji (partial update)
+//
+// If the character is changed, links to words are rebuilt.
+func (s *adminKanjiService) UpdateKanji(ctx context.Context, id int, request *models.UpdateKanjiRequest, audioFile multipart.File, audioFilename string) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid kanji id")
+	}
+
+	if err := validateKanji(request.Character, request.StrokeCount, request.JLPTLevel); err != nil {
+		return err
+	}
+	meanings, err := normalizeKanjiMeanings(request.Meanings)
+	if err != nil {
+		return err
+	}
+
+	// Get current kanji to check for existing audio URL
+	currentKanji, err := s.kanjiRepo.GetByIDAdmin(ctx, id)
+	if err != nil {
+		return fmt.Errorf("kanji not found")
+	}
+
+	// The current character of the kanji may be sent again, only a new character may conflict with other kanji
+	if request.Character != "" && request.Character != currentKanji.Character {
+		exists, err := s.kanjiRepo.ExistsByCharacter(ctx, request.Character)
+		if err != nil {
+			return fmt.Errorf("failed to check kanji existence: %w", err)
+		}
+		if exists {
+			return fmt.Errorf("kanji '%s' already exists", request.Character)
+		}
+	}
+
+	kanji := &models.Kanji{
+		ID:        id,
+		Character: request.Character,
+		Onyomi:    request.Onyomi,
+		Kunyomi:   request.Kunyomi,
+		Meanings:  meanings,
+		Radicals:  request.Radicals,
+	}
+	if request.StrokeCount != nil {
+		kanji.StrokeCount = *request.StrokeCount
+	}
+	if request.JLPTLevel != nil {
+		kanji.JLPTLevel = *request.JLPTLevel
+	}
+
+	// Handle audio file update if provided
+	if audioFile != nil && audioFilename != "" {
+		// Delete old audio file if it exists
+		if currentKanji.Audio != "" && s.mediaBaseURL != "" && s.apiKey != "" {
+			fileID := extractFileIDFromURL(currentKanji.Audio)
+			if fileID != "" {
+				if err := deleteFileFromMediaService(ctx, s.mediaBaseURL, s.apiKey, "kanji", fileID); err != nil {
+					return fmt.Errorf("failed to delete old kanji audio: %w", err)
+				}
+			}
+		}
+
+		audioURL, err := uploadFileToMediaService(ctx, s.mediaBaseURL, s.apiKey, "kanji", audioFile, audioFilename)
+		if err != nil {
+			return fmt.Errorf("failed to upload kanji audio: %w", err)
+		}
+		kanji.Audio = audioURL
+	}
+
+	if err := s.kanjiRepo.Update(ctx, id, kanji); err != nil {
+		return err
+	}
+
+	if request.Character != "" && request.Character != currentKanji.Character {
+		if err := s.kanjiRepo.LinkWords(ctx, id); err != nil {
+			return fmt.Errorf("failed to link kanji to words: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteKanji deletes a kanji by ID
+//
+// Links to words are removed together with the kanji.
+func (s *adminKanjiService) DeleteKanji(ctx context.Context, id int) error {
+	if id <= 0 {
+		return fmt.Errorf("invalid kanji id")
+	}
+
+	// Get kanji first to retrieve audio URL
+	kanji, err := s.kanjiRepo.GetByIDAdmin(ctx, id)
+	if err != nil {
+		return fmt.Errorf("kanji not found")
+	}
+
+	// Delete audio file from media service if audio URL exists
+	if kanji.Audio != "" && s.mediaBaseURL != "" && s.apiKey != "" {
+		fileID := extractFileIDFromURL(kanji.Audio)
+		if fileID != "" {
+			if err := deleteFileFromMediaService(ctx, s.mediaBaseURL, s.apiKey, "kanji", fileID); err != nil {
+				return fmt.Errorf("kanji audio file has not been deleted: %w", err)
+			}
+		}
+	}
+
+	return s.kanjiRepo.Delete(ctx, id)
+}
+
+// validateKanji validates kanji fields
+//
+// For successful results:
+//
+// - character (if provided) must be a single kanji
+//
+// - stroke count (if provided) must be between 1 and 84
+//
+// - JLPT level (if provided) must be between 1 and 5
+func validateKanji(character string, strokeCount, jlptLevel *int) error {
+	if character != "" {
+		r, _ := utf8.DecodeRuneInString(character)
+		if utf8.RuneCountInString(character) != 1 || !unicode.Is(unicode.Han, r) {
+			return fmt.Errorf("invalid kanji character: %s, must be a single kanji", character)
+		}
+	}
+	if strokeCount != nil && (*strokeCount < 1 || *strokeCount > 84) {
+		return fmt.Errorf("invalid stroke count: must be between 1 and 84")
+	}
+	if jlptLevel != nil && (*jlptLevel < 1 || *jlptLevel > 5) {
+		return fmt.Errorf("invalid jlpt level: must be between 1 and 5")
+	}
+	return nil
+}
+
+// maxKanjiMeaningLength is the maximum length of a meaning of a kanji
+const maxKanjiMeaningLength = 100
+
+// normalizeKanjiMeanings trims meanings of a kanji and lowercases their language codes
+//
+// Empty meanings are dropped. If some meaning is longer than 100 characters, the error will be returned.
+// Please reference normalizeLanguages function for more information about other error values.
+func normalizeKanjiMeanings(meanings map[string]string) (map[string]string, error) {
+	meanings, err := normalizeLanguages(meanings)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, language := range slices.Sorted(maps.Keys(meanings)) {
+		meaning := strings.TrimSpace(meanings[language])
+		if meaning == "" {
+			delete(meanings, language)
+			continue
+		}
+		if utf8.RuneCountInString(meaning) > maxKanjiMeaningLength {
+			return nil, fmt.Errorf("validation error: %s meaning must be at most %d characters long", language, maxKanjiMeaningLength)
+		}
+		meanings[language] = meaning
+	}
+	return meanings, nil
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockAdminKanjiRepository is a mock implementation of AdminKanjiRepository
+type mockAdminKanjiRepository struct {
+	kanjiList      []models.KanjiListItem
+	kanji          *models.Kanji
+	languages      []string
+	created        *models.Kanji
+	exists         bool
+	err            error
+	createErr      error
+	updateErr      error
+	linkErr        error
+	linkedKanjiIDs []int
+}
+
+func (m *mockAdminKanjiRepository) GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.KanjiListItem, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.languages = languages
+	return m.kanjiList, nil
+}
+
+func (m *mockAdminKanjiRepository) GetByIDAdmin(ctx context.Context, id int) (*models.Kanji, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.kanji == nil {
+		return nil, errors.New("kanji not found")
+	}
+	return m.kanji, nil
+}
+
+func (m *mockAdminKanjiRepository) ExistsByCharacter(ctx context.Context, character string) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.exists, nil
+}
+
+func (m *mockAdminKanjiRepository) Create(ctx context.Context, kanji *models.Kanji) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	kanji.ID = 1
+	m.created = kanji
+	return nil
+}
+
+func (m *mockAdminKanjiRepository) Update(ctx context.Context, id int, kanji *models.Kanji) error {
+	return m.updateErr
+}
+
+func (m *mockAdminKanjiRepository) Delete(ctx context.Context, id int) error {
+	return m.err
+}
+
+func (m *mockAdminKanjiRepository) LinkWords(ctx context.Context, kanjiID int) error {
+	if m.linkErr != nil {
+		return m.linkErr
+	}
+	m.linkedKanjiIDs = append(m.linkedKanjiIDs, kanjiID)
+	return nil
+}
+
+func TestAdminKanjiService_GetAllForAdmin(t *testing.T) {
+	repo := &mockAdminKanjiRepository{
+		kanjiList: []models.KanjiListItem{
+			{ID: 1, Character: "水", Meaning: "water", JLPTLevel: 5},
+			{ID: 2, Character: "火", Meaning: "fire", JLPTLevel: 5},
+		},
+	}
+	svc := NewAdminKanjiService(repo, "", "", LocaleConfig{Default: "de", Fallbacks: map[string][]string{"de": {"en"}}})
+
+	kanji, err := svc.GetAllForAdmin(context.Background(), 0, 0, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.KanjiListItem{
+		{ID: 1, Character: "水", Meaning: "water", JLPTLevel: 5},
+		{ID: 2, Character: "火", Meaning: "fire", JLPTLevel: 5},
+	}, kanji)
+	assert.Equal(t, []string{"de", "en"}, repo.languages)
+}
+
+func TestAdminKanjiService_CreateKanji(t *testing.T) {
+	validRequest := func() *models.CreateKanjiRequest {
+		return &models.CreateKanjiRequest{
+			Character:   "水",
+			Onyomi:      "スイ",
+			Kunyomi:     "みず",
+			Meanings:    map[string]string{"en": " water ", "RU": "вода"},
+			StrokeCount: 4,
+			JLPTLevel:   5,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		request          func() *models.CreateKanjiRequest
+		mockRepo         *mockAdminKanjiRepository
+		expectedID       int
+		expectedMeanings map[string]string
+		expectedLinked   []int
+		errorContains    string
+	}{
+		{
+			name:             "success links words",
+			request:          validRequest,
+			mockRepo:         &mockAdminKanjiRepository{},
+			expectedID:       1,
+			expectedMeanings: map[string]string{"en": "water", "ru": "вода"},
+			expectedLinked:   []int{1},
+		},
+		{
+			name: "meaning in the default locale is required",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Meanings = map[string]string{"en": " ", "ru": "вода"}
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "validation error: meaning in the default locale 'en' is required",
+		},
+		{
+			name: "invalid meaning language",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Meanings["english"] = "water"
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "validation error: invalid language",
+		},
+		{
+			name: "too long meaning",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Meanings["de"] = strings.Repeat("W", 101)
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "validation error: de meaning must be at most 100 characters long",
+		},
+		{
+			name: "empty character",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Character = ""
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid kanji character",
+		},
+		{
+			name: "kana is not a kanji",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Character = "み"
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid kanji character",
+		},
+		{
+			name: "several kanji",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.Character = "水火"
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid kanji character",
+		},
+		{
+			name: "invalid jlpt level",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.JLPTLevel = 0
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid jlpt level",
+		},
+		{
+			name: "invalid stroke count",
+			request: func() *models.CreateKanjiRequest {
+				req := validRequest()
+				req.StrokeCount = 0
+				return req
+			},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid stroke count",
+		},
+		{
+			name:          "kanji already exists",
+			request:       validRequest,
+			mockRepo:      &mockAdminKanjiRepository{exists: true},
+			errorContains: "already exists",
+		},
+		{
+			name:          "create error",
+			request:       validRequest,
+			mockRepo:      &mockAdminKanjiRepository{createErr: errors.New("database error")},
+			errorContains: "failed to create kanji",
+		},
+		{
+			name:          "link error",
+			request:       validRequest,
+			mockRepo:      &mockAdminKanjiRepository{linkErr: errors.New("database error")},
+			errorContains: "failed to link kanji to words",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminKanjiService(tt.mockRepo, "", "", DefaultLocaleConfig())
+
+			id, err := svc.CreateKanji(context.Background(), tt.request(), nil, "")
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Equal(t, 0, id)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedID, id)
+			assert.Equal(t, tt.expectedMeanings, tt.mockRepo.created.Meanings)
+			assert.Equal(t, tt.expectedLinked, tt.mockRepo.linkedKanjiIDs)
+		})
+	}
+}
+
+func TestAdminKanjiService_UpdateKanji(t *testing.T) {
+	level := 4
+	wrongLevel := 6
+
+	tests := []struct {
+		name           string
+		id             int
+		request        *models.UpdateKanjiRequest
+		mockRepo       *mockAdminKanjiRepository
+		expectedLinked []int
+		errorContains  string
+	}{
+		{
+			name:     "update without character change keeps links",
+			id:       1,
+			request:  &models.UpdateKanjiRequest{JLPTLevel: &level},
+			mockRepo: &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1, Character: "水"}},
+		},
+		{
+			name:           "character change rebuilds links",
+			id:             1,
+			request:        &models.UpdateKanjiRequest{Character: "氷"},
+			mockRepo:       &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1, Character: "水"}},
+			expectedLinked: []int{1},
+		},
+		{
+			name:          "invalid id",
+			id:            0,
+			request:       &models.UpdateKanjiRequest{JLPTLevel: &level},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid kanji id",
+		},
+		{
+			name:          "invalid jlpt level",
+			id:            1,
+			request:       &models.UpdateKanjiRequest{JLPTLevel: &wrongLevel},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid jlpt level",
+		},
+		{
+			name:          "character already exists",
+			id:            1,
+			request:       &models.UpdateKanjiRequest{Character: "火"},
+			mockRepo:      &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1, Character: "水"}, exists: true},
+			errorContains: "already exists",
+		},
+		{
+			name:     "current character sent again",
+			id:       1,
+			request:  &models.UpdateKanjiRequest{Character: "水", JLPTLevel: &level},
+			mockRepo: &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1, Character: "水"}, exists: true},
+		},
+		{
+			name:          "kanji not found",
+			id:            1,
+			request:       &models.UpdateKanjiRequest{JLPTLevel: &level},
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "kanji not found",
+		},
+		{
+			name:          "update error",
+			id:            1,
+			request:       &models.UpdateKanjiRequest{JLPTLevel: &level},
+			mockRepo:      &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1}, updateErr: errors.New("no fields to update")},
+			errorContains: "no fields to update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminKanjiService(tt.mockRepo, "", "", DefaultLocaleConfig())
+
+			err := svc.UpdateKanji(context.Background(), tt.id, tt.request, nil, "")
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLinked, tt.mockRepo.linkedKanjiIDs)
+		})
+	}
+}
+
+func TestAdminKanjiService_DeleteKanji(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int
+		mockRepo      *mockAdminKanjiRepository
+		errorContains string
+	}{
+		{
+			name:     "success",
+			id:       1,
+			mockRepo: &mockAdminKanjiRepository{kanji: &models.Kanji{ID: 1}},
+		},
+		{
+			name:          "invalid id",
+			id:            -1,
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "invalid kanji id",
+		},
+		{
+			name:          "kanji not found",
+			id:            1,
+			mockRepo:      &mockAdminKanjiRepository{},
+			errorContains: "kanji not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminKanjiService(tt.mockRepo, "", "", DefaultLocaleConfig())
+
+			err := svc.DeleteKanji(context.Background(), tt.id)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"maps"
 	"mime/multipart"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 )
@@ -15,9 +19,10 @@ type AdminWordRepository interface {
 	// "page" parameter is used to specify the page number.
 	// "count" parameter is used to specify the number of items per page.
 	// "search" parameter is used to search words by word, phonetic clues, or translations.
+	// "languages" parameter is the locale fallback chain, translations are taken in its first language which has them.
 
 	// // If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.Word, error)
+	GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.WordListItem, error)
 	// Method GetByIDAdmin retrieve a word by its ID using configured repository.
 	//
 	// "id" parameter is used to identify the word.
@@ -55,31 +60,116 @@ type AdminWordRepository interface {
 	//
 	// If some error will occur during data deletion, the error will be returned.
 	Delete(ctx context.Context, id int) error
+	// Method GetTags retrieves all topic tags with the number of words they are attached to.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetTags(ctx context.Context) ([]models.WordTag, error)
+	// Method Search finds words by kanji, reading or translation, best matches first.
+	//
+	// "query" parameter is the searched text, its reading may be written in hiragana, katakana or romaji.
+	// "limit" parameter is used to specify the maximum number of words to return.
+	// Translations are searched in the languages of "languages" and returned in the first of them which has them.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	Search(ctx context.Context, query string, limit int, languages []string) ([]models.WordSearchResult, error)
+}
+
+// AdminWordExampleRepository is the interface that wraps methods for WordExamples table data management
+type AdminWordExampleRepository interface {
+	// Method GetByWordID retrieves all additional examples of a word in creation order.
+	//
+	// "wordId" parameter is used to identify the word.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByWordID(ctx context.Context, wordId int) ([]models.WordExample, error)
+	// Method GetByID retrieves an additional example of a word by its ID.
+	//
+	// "wordId" parameter is used to identify the word.
+	// "exampleId" parameter is used to identify the example.
+	//
+	// If the example does not exist or some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByID(ctx context.Context, wordId, exampleId int) (*models.WordExample, error)
+	// Method Create creates a new word example and sets its ID.
+	//
+	// If some error will occur during data creation, the error will be returned.
+	Create(ctx context.Context, example *models.WordExample) error
+	// Method Update updates a word example (partial update), empty fields are left unchanged.
+	//
+	// Please reference GetByID method for more information about parameters.
+	//
+	// If some error will occur during data update, the error will be returned.
+	Update(ctx context.Context, wordId, exampleId int, example *models.WordExample) error
+	// Method Delete deletes a word example.
+	//
+	// Please reference GetByID method for more information about parameters.
+	//
+	// If the example does not exist or some error will occur during data deletion, the error will be returned.
+	Delete(ctx context.Context, wordId, exampleId int) error
+}
+
+// Limits of topic tags of a word
+const (
+	maxWordTags      = 10
+	maxWordTagLength = 50
+)
+
+// validPartsOfSpeech contains all parts of speech a word can be classified with
+var validPartsOfSpeech = map[string]bool{
+	models.PartOfSpeechNoun:         true,
+	models.PartOfSpeechVerb:         true,
+	models.PartOfSpeechIAdjective:   true,
+	models.PartOfSpeechNaAdjective:  true,
+	models.PartOfSpeechAdverb:       true,
+	models.PartOfSpeechPronoun:      true,
+	models.PartOfSpeechParticle:     true,
+	models.PartOfSpeechCounter:      true,
+	models.PartOfSpeechConjunction:  true,
+	models.PartOfSpeechInterjection: true,
+	models.PartOfSpeechExpression:   true,
+}
+
+// WordKanjiRepository is the interface that wraps methods for linking words to kanji
+type WordKanjiRepository interface {
+	// Method LinkWord rebuilds links between a word and all kanji its Word field contains.
+	//
+	// "wordID" parameter is used to identify the word.
+	//
+	// If some error will occur during linking, the error will be returned.
+	LinkWord(ctx context.Context, wordID int) error
 }
 
 // dictionaryService implements DictionaryService
 type adminWordService struct {
 	wordRepo              AdminWordRepository
+	wordExampleRepo       AdminWordExampleRepository
 	dictionaryHistoryRepo DictionaryHistoryRepository
+	wordKanjiRepo         WordKanjiRepository
 	mediaBaseURL          string
 	apiKey                string
+	locale                LocaleConfig
 }
 
 // NewAdminWordService creates a new admin word service
 func NewAdminWordService(
 	wordRepo AdminWordRepository,
+	wordExampleRepo AdminWordExampleRepository,
 	dictionaryHistoryRepo DictionaryHistoryRepository,
+	wordKanjiRepo WordKanjiRepository,
 	mediaBaseURL, apiKey string,
+	locale LocaleConfig,
 ) *adminWordService {
 	return &adminWordService{
 		wordRepo:              wordRepo,
+		wordExampleRepo:       wordExampleRepo,
 		dictionaryHistoryRepo: dictionaryHistoryRepo,
+		wordKanjiRepo:         wordKanjiRepo,
 		mediaBaseURL:          mediaBaseURL,
 		apiKey:                apiKey,
+		locale:                locale,
 	}
 }
 
-// GetAllForAdmin retrieves a paginated list of words for admin endpoints
+// GetAllForAdmin retrieves a paginated list of words for admin endpoints with translations in the default locale
 func (s *adminWordService) GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.WordListItem, error) {
 	if page < 1 {
 		page = 1
@@ -88,21 +178,11 @@ func (s *adminWordService) GetAllForAdmin(ctx context.Context, page, count int,
 		count = 20
 	}
 
-	words, err := s.wordRepo.GetAllForAdmin(ctx, page, count, search)
+	words, err := s.wordRepo.GetAllForAdmin(ctx, page, count, search, s.locale.Chain(s.locale.Default))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get words: %w", err)
 	}
-
-	wordList := make([]models.WordListItem, len(words))
-	for i, word := range words {
-		wordList[i] = models.WordListItem{
-			ID:                 word.ID,
-			Word:               word.Word,
-			PhoneticClues:      word.PhoneticClues,
-			EnglishTranslation: word.EnglishTranslation,
-		}
-	}
-	return wordList, nil
+	return words, nil
 }
 
 // GetByIDAdmin retrieves a word by ID for admin endpoints
@@ -115,6 +195,8 @@ func (s *adminWordService) GetByIDAdmin(ctx context.Context, id int) (*models.Wo
 }
 
 // CreateWord creates a new word
+//
+// Translations are keyed by language codes, the translation in the default locale is required.
 func (s *adminWordService) CreateWord(ctx context.Context, request *models.CreateWordRequest, wordAudioFile multipart.File, wordAudioFilename string, wordExampleAudioFile multipart.File, wordExampleAudioFilename string) (int, error) {
 	// Check if word with same Word field exists
 	exists, err := s.wordRepo.ExistsByWord(ctx, request.Word)
@@ -125,20 +207,40 @@ func (s *adminWordService) CreateWord(ctx context.Context, request *models.Creat
 		return 0, fmt.Errorf("word '%s' already exists", request.Word)
 	}
 
+	var jlptLevel *int
+	if request.JLPTLevel != 0 {
+		jlptLevel = &request.JLPTLevel
+	}
+	if err := validateWordClassification(jlptLevel, request.PartOfSpeech); err != nil {
+		return 0, err
+	}
+	if _, err := parseRuby(request.Example); err != nil {
+		return 0, fmt.Errorf("validation error: %w", err)
+	}
+	tags, err := normalizeWordTags(request.Tags)
+	if err != nil {
+		return 0, err
+	}
+	translations, err := normalizeWordTranslations(request.Translations)
+	if err != nil {
+		return 0, err
+	}
+	if translations[s.locale.Default].Translation == "" {
+		return 0, fmt.Errorf("validation error: translation in the default locale '%s' is required", s.locale.Default)
+	}
+
 	word := &models.Word{
-		Word:                      request.Word,
-		PhoneticClues:             request.PhoneticClues,
-		RussianTranslation:        request.RussianTranslation,
-		EnglishTranslation:        request.EnglishTranslation,
-		GermanTranslation:         request.GermanTranslation,
-		Example:                   request.Example,
-		ExampleRussianTranslation: request.ExampleRussianTranslation,
-		ExampleEnglishTranslation: request.ExampleEnglishTranslation,
-		ExampleGermanTranslation:  request.ExampleGermanTranslation,
-		EasyPeriod:                request.EasyPeriod,
-		NormalPeriod:              request.NormalPeriod,
-		HardPeriod:                request.HardPeriod,
-		ExtraHardPeriod:           request.ExtraHardPeriod,
+		Word:            request.Word,
+		PhoneticClues:   request.PhoneticClues,
+		Example:         request.Example,
+		Translations:    translations,
+		EasyPeriod:      request.EasyPeriod,
+		NormalPeriod:    request.NormalPeriod,
+		HardPeriod:      request.HardPeriod,
+		ExtraHardPeriod: request.ExtraHardPeriod,
+		JLPTLevel:       request.JLPTLevel,
+		PartOfSpeech:    request.PartOfSpeech,
+		Tags:            tags,
 	}
 
 	// Handle word audio file upload if provided
@@ -163,6 +265,10 @@ func (s *adminWordService) CreateWord(ctx context.Context, request *models.Creat
 	if err != nil {
 		return 0, fmt.Errorf("failed to create word: %w", err)
 	}
+
+	if err := s.wordKanjiRepo.LinkWord(ctx, word.ID); err != nil {
+		return 0, fmt.Errorf("failed to link word to kanji: %w", err)
+	}
 	return word.ID, nil
 }
 
@@ -176,6 +282,17 @@ func (s *adminWordService) UpdateWord(ctx context.Context, id int, request *mode
 	if err := s.validateWord(ctx, request); err != nil {
 		return err
 	}
+	tags, err := normalizeWordTags(request.Tags)
+	if err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	if _, err := parseRuby(request.Example); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	translations, err := normalizeWordTranslations(request.Translations)
+	if err != nil {
+		return err
+	}
 
 	// Get current word to check for existing audio URLs
 	currentWord, err := s.wordRepo.GetByIDAdmin(ctx, id)
@@ -184,16 +301,16 @@ func (s *adminWordService) UpdateWord(ctx context.Context, id int, request *mode
 	}
 
 	word := &models.Word{
-		ID:                        id,
-		Word:                      request.Word,
-		PhoneticClues:             request.PhoneticClues,
-		RussianTranslation:        request.RussianTranslation,
-		EnglishTranslation:        request.EnglishTranslation,
-		GermanTranslation:         request.GermanTranslation,
-		Example:                   request.Example,
-		ExampleRussianTranslation: request.ExampleRussianTranslation,
-		ExampleEnglishTranslation: request.ExampleEnglishTranslation,
-		ExampleGermanTranslation:  request.ExampleGermanTranslation,
+		ID:            id,
+		Word:          request.Word,
+		PhoneticClues: request.PhoneticClues,
+		Example:       request.Example,
+		Translations:  translations,
+		PartOfSpeech:  request.PartOfSpeech,
+		Tags:          tags,
+	}
+	if request.JLPTLevel != nil {
+		word.JLPTLevel = *request.JLPTLevel
 	}
 	if request.EasyPeriod != nil {
 		word.EasyPeriod = *request.EasyPeriod
@@ -248,7 +365,17 @@ func (s *adminWordService) UpdateWord(ctx context.Context, id int, request *mode
 		word.WordExampleAudio = audioURL
 	}
 
-	return s.wordRepo.Update(ctx, id, word)
+	if err := s.wordRepo.Update(ctx, id, word); err != nil {
+		return err
+	}
+
+	// Rebuild kanji links only if the word itself has been changed
+	if request.Word != "" {
+		if err := s.wordKanjiRepo.LinkWord(ctx, id); err != nil {
+			return fmt.Errorf("failed to link word to kanji: %w", err)
+		}
+	}
+	return nil
 }
 
 // validateWord validates a word for update
@@ -305,7 +432,7 @@ func (s *adminWordService) validateWord(ctx context.Context, request *models.Upd
 			validErrChan <- fmt.Errorf("extra hard period must be between 1 and 30")
 			return
 		}
-		validErrChan <- nil
+		validErrChan <- validateWordClassification(request.JLPTLevel, request.PartOfSpeech)
 	}()
 
 	for range 3 {
@@ -318,6 +445,94 @@ func (s *adminWordService) validateWord(ctx context.Context, request *models.Upd
 	return nil
 }
 
+// normalizeWordTranslations trims translations of a word and lowercases their language codes
+//
+// Languages with neither a translation nor an example translation are dropped.
+// Please reference normalizeLanguages function for more information about error values.
+func normalizeWordTranslations(translations map[string]models.WordTranslation) (map[string]models.WordTranslation, error) {
+	translations, err := normalizeLanguages(translations)
+	if err != nil {
+		return nil, err
+	}
+
+	for language, translation := range translations {
+		translation.Translation = strings.TrimSpace(translation.Translation)
+		translation.ExampleTranslation = strings.TrimSpace(translation.ExampleTranslation)
+		if translation.Translation == "" && translation.ExampleTranslation == "" {
+			delete(translations, language)
+			continue
+		}
+		translations[language] = translation
+	}
+	return translations, nil
+}
+
+// validateWordClassification validates classification of a word
+//
+// For successful results:
+//
+// - JLPT level (if provided) must be between 1 and 5
+//
+// - part of speech (if provided) must be one of PartOfSpeech constants
+func validateWordClassification(jlptLevel *int, partOfSpeech string) error {
+	if jlptLevel != nil && (*jlptLevel < 1 || *jlptLevel > 5) {
+		return fmt.Errorf("invalid jlpt level: must be between 1 and 5")
+	}
+	if partOfSpeech != "" && !validPartsOfSpeech[partOfSpeech] {
+		return fmt.Errorf("invalid part of speech: %s", partOfSpeech)
+	}
+	return nil
+}
+
+// normalizeWordTags trims and lowercases topic tags, empty tags and duplicates are dropped
+//
+// A nil list stays nil, so partial updates can leave tags unchanged.
+// If there are more than 10 tags or some tag is longer than 50 characters, the error will be returned.
+func normalizeWordTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := []string{}
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxWordTagLength {
+			return nil, fmt.Errorf("invalid tag '%s': must be at most %d characters long", tag, maxWordTagLength)
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxWordTags {
+		return nil, fmt.Errorf("invalid tags: at most %d tags are allowed", maxWordTags)
+	}
+	return normalized, nil
+}
+
+// SearchWords finds words by kanji, by reading in kana or romaji, or by translation in the locale
+//
+// Please reference parseSearchParameters function for more information about validation rules.
+func (s *adminWordService) SearchWords(ctx context.Context, query, locale string, limit int) ([]models.WordSearchResult, error) {
+	query, limit, languages, err := parseSearchParameters(query, locale, limit, s.locale)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.wordRepo.Search(ctx, query, limit, languages)
+}
+
+// GetTags retrieves all topic tags with the number of words they are attached to
+func (s *adminWordService) GetTags(ctx context.Context) ([]models.WordTag, error) {
+	tags, err := s.wordRepo.GetTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word tags: %w", err)
+	}
+	return tags, nil
+}
+
 // DeleteWord deletes a word by ID
 func (s *adminWordService) DeleteWord(ctx context.Context, id int) error {
 	if id <= 0 {
@@ -350,5 +565,215 @@ func (s *adminWordService) DeleteWord(ctx context.Context, id int) error {
 		}
 	}
 
+	// Additional examples are deleted together with the word, but their audio files are not
+	examples, err := s.wordExampleRepo.GetByWordID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get word examples: %w", err)
+	}
+	for _, example := range examples {
+		if err := s.deleteExampleAudio(ctx, example.ExampleAudio); err != nil {
+			return fmt.Errorf("word example audio file has not been deleted: %w", err)
+		}
+	}
+
 	return s.wordRepo.Delete(ctx, id)
 }
+
+// maxWordExampleLength is the maximum length of an example sentence, its translations and its source
+const maxWordExampleLength = 255
+
+// GetExamples retrieves additional examples of a word
+func (s *adminWordService) GetExamples(ctx context.Context, wordId int) ([]models.WordExample, error) {
+	if wordId <= 0 {
+		return nil, fmt.Errorf("invalid word id")
+	}
+	if _, err := s.wordRepo.GetByIDAdmin(ctx, wordId); err != nil {
+		return nil, fmt.Errorf("word not found")
+	}
+
+	examples, err := s.wordExampleRepo.GetByWordID(ctx, wordId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word examples: %w", err)
+	}
+	return examples, nil
+}
+
+// CreateExample adds an example sentence to a word
+//
+// For successful results:
+//
+// - the word must exist
+//
+// - the example sentence and its translation in the default locale are required
+//
+// Please reference validateWordExample function for more information about other validation rules.
+func (s *adminWordService) CreateExample(ctx context.Context, wordId int, request *models.CreateWordExampleRequest, audioFile multipart.File, audioFilename string) (int, error) {
+	if wordId <= 0 {
+		return 0, fmt.Errorf("invalid word id")
+	}
+
+	translations, err := normalizeExampleTranslations(request.Translations)
+	if err != nil {
+		return 0, err
+	}
+	example := &models.WordExample{
+		WordID:       wordId,
+		Example:      strings.TrimSpace(request.Example),
+		Translations: translations,
+		Source:       strings.TrimSpace(request.Source),
+	}
+	if example.Example == "" {
+		return 0, fmt.Errorf("validation error: example is required")
+	}
+	if example.Translations[s.locale.Default] == "" {
+		return 0, fmt.Errorf("validation error: translation in the default locale '%s' is required", s.locale.Default)
+	}
+	if err := validateWordExample(example); err != nil {
+		return 0, err
+	}
+
+	if _, err := s.wordRepo.GetByIDAdmin(ctx, wordId); err != nil {
+		return 0, fmt.Errorf("word not found")
+	}
+
+	// Handle example audio file upload if provided
+	if audioFile != nil && audioFilename != "" {
+		audioURL, err := uploadFileToMediaService(ctx, s.mediaBaseURL, s.apiKey, "word_example", audioFile, audioFilename)
+		if err != nil {
+			return 0, fmt.Errorf("failed to upload word example audio: %w", err)
+		}
+		example.ExampleAudio = audioURL
+	}
+
+	if err := s.wordExampleRepo.Create(ctx, example); err != nil {
+		return 0, fmt.Errorf("failed to create word example: %w", err)
+	}
+	return example.ID, nil
+}
+
+// UpdateExample updates an example sentence of a word (partial update)
+//
+// Empty fields are left unchanged, a new audio file replaces the old one.
+// Please reference validateWordExample function for more information about validation rules.
+func (s *adminWordService) UpdateExample(ctx context.Context, wordId, exampleId int, request *models.UpdateWordExampleRequest, audioFile multipart.File, audioFilename string) error {
+	if wordId <= 0 {
+		return fmt.Errorf("invalid word id")
+	}
+	if exampleId <= 0 {
+		return fmt.Errorf("invalid example id")
+	}
+
+	translations, err := normalizeExampleTranslations(request.Translations)
+	if err != nil {
+		return err
+	}
+	example := &models.WordExample{
+		Example:      strings.TrimSpace(request.Example),
+		Translations: translations,
+		Source:       strings.TrimSpace(request.Source),
+	}
+	if err := validateWordExample(example); err != nil {
+		return err
+	}
+
+	currentExample, err := s.wordExampleRepo.GetByID(ctx, wordId, exampleId)
+	if err != nil {
+		return fmt.Errorf("word example not found")
+	}
+
+	// Handle example audio file update if provided
+	if audioFile != nil && audioFilename != "" {
+		if err := s.deleteExampleAudio(ctx, currentExample.ExampleAudio); err != nil {
+			return fmt.Errorf("failed to delete old word example audio: %w", err)
+		}
+
+		audioURL, err := uploadFileToMediaService(ctx, s.mediaBaseURL, s.apiKey, "word_example", audioFile, audioFilename)
+		if err != nil {
+			return fmt.Errorf("failed to upload word example audio: %w", err)
+		}
+		example.ExampleAudio = audioURL
+	}
+
+	return s.wordExampleRepo.Update(ctx, wordId, exampleId, example)
+}
+
+// DeleteExample deletes an example sentence of a word together with its audio file
+func (s *adminWordService) DeleteExample(ctx context.Context, wordId, exampleId int) error {
+	if wordId <= 0 {
+		return fmt.Errorf("invalid word id")
+	}
+	if exampleId <= 0 {
+		return fmt.Errorf("invalid example id")
+	}
+
+	example, err := s.wordExampleRepo.GetByID(ctx, wordId, exampleId)
+	if err != nil {
+		return fmt.Errorf("word example not found")
+	}
+
+	if err := s.deleteExampleAudio(ctx, example.ExampleAudio); err != nil {
+		return fmt.Errorf("word example audio file has not been deleted: %w", err)
+	}
+
+	return s.wordExampleRepo.Delete(ctx, wordId, exampleId)
+}
+
+// deleteExampleAudio deletes an example audio file from media service if audio URL exists
+func (s *adminWordService) deleteExampleAudio(ctx context.Context, audioURL string) error {
+	if audioURL == "" || s.mediaBaseURL == "" || s.apiKey == "" {
+		return nil
+	}
+	fileID := extractFileIDFromURL(audioURL)
+	if fileID == "" {
+		return nil
+	}
+	return deleteFileFromMediaService(ctx, s.mediaBaseURL, s.apiKey, "word_example", fileID)
+}
+
+// normalizeExampleTranslations trims translations of an example and lowercases their language codes
+//
+// Empty translations are dropped.
+// Please reference normalizeLanguages function for more information about error values.
+func normalizeExampleTranslations(translations map[string]string) (map[string]string, error) {
+	translations, err := normalizeLanguages(translations)
+	if err != nil {
+		return nil, err
+	}
+
+	for language, translation := range translations {
+		translation = strings.TrimSpace(translation)
+		if translation == "" {
+			delete(translations, language)
+			continue
+		}
+		translations[language] = translation
+	}
+	return translations, nil
+}
+
+// validateWordExample validates lengths of example fields and ruby markup of the sentence
+//
+// For successful results the sentence, its translations and its source must be at most 255 characters long.
+// The sentence may annotate kanji with readings as "{漢字|かんじ}", please reference parseRuby function for more information.
+func validateWordExample(example *models.WordExample) error {
+	type exampleField struct {
+		name  string
+		value string
+	}
+	fields := []exampleField{
+		{"example", example.Example},
+		{"source", example.Source},
+	}
+	for _, language := range slices.Sorted(maps.Keys(example.Translations)) {
+		fields = append(fields, exampleField{language + " translation", example.Translations[language]})
+	}
+	for _, field := range fields {
+		if utf8.RuneCountInString(field.value) > maxWordExampleLength {
+			return fmt.Errorf("validation error: %s must be at most %d characters long", field.name, maxWordExampleLength)
+		}
+	}
+	if _, err := parseRuby(example.Example); err != nil {
+		return fmt.Errorf("validation error: %w", err)
+	}
+	return nil
+}
@@ -3,15 +3,20 @@ package services
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockAdminWordRepository is a mock implementation of AdminWordRepository
 type mockAdminWordRepository struct {
-	words         []models.Word
+	words         []models.WordListItem
 	word          *models.Word
 	existsByWord  bool
 	existsByClues bool
@@ -19,9 +24,13 @@ type mockAdminWordRepository struct {
 	createErr     error
 	updateErr     error
 	deleteErr     error
+	tags          []models.WordTag
+	createdWord   *models.Word
+	updatedWord   *models.Word
+	found         []models.WordSearchResult
 }
 
-func (m *mockAdminWordRepository) GetAllForAdmin(ctx context.Context, page, count int, search string) ([]models.Word, error) {
+func (m *mockAdminWordRepository) GetAllForAdmin(ctx context.Context, page, count int, search string, languages []string) ([]models.WordListItem, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -57,6 +66,7 @@ func (m *mockAdminWordRepository) Create(ctx context.Context, word *models.Word)
 		return m.err
 	}
 	word.ID = 1
+	m.createdWord = word
 	return nil
 }
 
@@ -64,6 +74,7 @@ func (m *mockAdminWordRepository) Update(ctx context.Context, id int, word *mode
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	m.updatedWord = word
 	return m.err
 }
 
@@ -74,14 +85,86 @@ func (m *mockAdminWordRepository) Delete(ctx context.Context, id int) error {
 	return m.err
 }
 
+func (m *mockAdminWordRepository) GetTags(ctx context.Context) ([]models.WordTag, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.tags, nil
+}
+
+func (m *mockAdminWordRepository) Search(ctx context.Context, query string, limit int, languages []string) ([]models.WordSearchResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.found, nil
+}
+
+// mockAdminWordExampleRepository is a mock implementation of AdminWordExampleRepository
+type mockAdminWordExampleRepository struct {
+	examples       []models.WordExample
+	example        *models.WordExample
+	err            error
+	createdExample *models.WordExample
+	updatedExample *models.WordExample
+	deletedID      int
+}
+
+func (m *mockAdminWordExampleRepository) GetByWordID(ctx context.Context, wordId int) ([]models.WordExample, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.examples, nil
+}
+
+func (m *mockAdminWordExampleRepository) GetByID(ctx context.Context, wordId, exampleId int) (*models.WordExample, error) {
+	if m.example == nil {
+		return nil, errors.New("word example not found")
+	}
+	return m.example, nil
+}
+
+func (m *mockAdminWordExampleRepository) Create(ctx context.Context, example *models.WordExample) error {
+	if m.err != nil {
+		return m.err
+	}
+	example.ID = 1
+	m.createdExample = example
+	return nil
+}
+
+func (m *mockAdminWordExampleRepository) Update(ctx context.Context, wordId, exampleId int, example *models.WordExample) error {
+	m.updatedExample = example
+	return m.err
+}
+
+func (m *mockAdminWordExampleRepository) Delete(ctx context.Context, wordId, exampleId int) error {
+	m.deletedID = exampleId
+	return m.err
+}
+
+// mockWordKanjiRepository is a mock implementation of WordKanjiRepository
+type mockWordKanjiRepository struct {
+	linkedWordIDs []int
+	err           error
+}
+
+func (m *mockWordKanjiRepository) LinkWord(ctx context.Context, wordID int) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.linkedWordIDs = append(m.linkedWordIDs, wordID)
+	return nil
+}
+
 func TestNewAdminWordService(t *testing.T) {
 	mockWordRepo := &mockAdminWordRepository{}
 	mockHistoryRepo := &mockDictionaryHistoryRepository{}
 
-	svc := NewAdminWordService(mockWordRepo, mockHistoryRepo, "", "")
+	svc := NewAdminWordService(mockWordRepo, &mockAdminWordExampleRepository{}, mockHistoryRepo, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 
 	assert.NotNil(t, svc)
 	assert.Equal(t, mockWordRepo, svc.wordRepo)
+	assert.NotNil(t, svc.wordExampleRepo)
 	assert.Equal(t, mockHistoryRepo, svc.dictionaryHistoryRepo)
 }
 
@@ -101,9 +184,9 @@ func TestAdminWordService_GetAllForAdmin(t *testing.T) {
 			count:  0,
 			search: "",
 			mockRepo: &mockAdminWordRepository{
-				words: []models.Word{
-					{ID: 1, Word: "水", PhoneticClues: "みず", EnglishTranslation: "water"},
-					{ID: 2, Word: "火", PhoneticClues: "ひ", EnglishTranslation: "fire"},
+				words: []models.WordListItem{
+					{ID: 1, Word: "水", PhoneticClues: "みず", Translation: "water"},
+					{ID: 2, Word: "火", PhoneticClues: "ひ", Translation: "fire"},
 				},
 			},
 			expectedError: false,
@@ -115,8 +198,8 @@ func TestAdminWordService_GetAllForAdmin(t *testing.T) {
 			count:  10,
 			search: "",
 			mockRepo: &mockAdminWordRepository{
-				words: []models.Word{
-					{ID: 1, Word: "水", PhoneticClues: "みず", EnglishTranslation: "water"},
+				words: []models.WordListItem{
+					{ID: 1, Word: "水", PhoneticClues: "みず", Translation: "water"},
 				},
 			},
 			expectedError: false,
@@ -128,8 +211,8 @@ func TestAdminWordService_GetAllForAdmin(t *testing.T) {
 			count:  20,
 			search: "water",
 			mockRepo: &mockAdminWordRepository{
-				words: []models.Word{
-					{ID: 1, Word: "水", PhoneticClues: "みず", EnglishTranslation: "water"},
+				words: []models.WordListItem{
+					{ID: 1, Word: "水", PhoneticClues: "みず", Translation: "water"},
 				},
 			},
 			expectedError: false,
@@ -152,7 +235,7 @@ func TestAdminWordService_GetAllForAdmin(t *testing.T) {
 			count:  20,
 			search: "",
 			mockRepo: &mockAdminWordRepository{
-				words: []models.Word{},
+				words: []models.WordListItem{},
 			},
 			expectedError: false,
 			expectedCount: 0,
@@ -161,7 +244,7 @@ func TestAdminWordService_GetAllForAdmin(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminWordService(tt.mockRepo, &mockDictionaryHistoryRepository{}, "", "")
+			svc := NewAdminWordService(tt.mockRepo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.GetAllForAdmin(ctx, tt.page, tt.count, tt.search)
@@ -191,10 +274,10 @@ func TestAdminWordService_GetByIDAdmin(t *testing.T) {
 			id:   1,
 			mockRepo: &mockAdminWordRepository{
 				word: &models.Word{
-					ID:                 1,
-					Word:               "水",
-					PhoneticClues:      "みず",
-					EnglishTranslation: "water",
+					ID:            1,
+					Word:          "水",
+					PhoneticClues: "みず",
+					Translations:  map[string]models.WordTranslation{"en": {Translation: "water"}},
 				},
 			},
 			expectedError: false,
@@ -227,7 +310,7 @@ func TestAdminWordService_GetByIDAdmin(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminWordService(tt.mockRepo, &mockDictionaryHistoryRepository{}, "", "")
+			svc := NewAdminWordService(tt.mockRepo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.GetByIDAdmin(ctx, tt.id)
@@ -256,9 +339,9 @@ func TestAdminWordService_CreateWord(t *testing.T) {
 		{
 			name: "success",
 			request: &models.CreateWordRequest{
-				Word:               "水",
-				PhoneticClues:      "みず",
-				EnglishTranslation: "water",
+				Word:          "水",
+				PhoneticClues: "みず",
+				Translations:  map[string]models.WordTranslation{"en": {Translation: "water"}},
 			},
 			mockRepo: &mockAdminWordRepository{
 				existsByWord: false,
@@ -291,9 +374,81 @@ func TestAdminWordService_CreateWord(t *testing.T) {
 			errorContains: "failed to check word existence",
 		},
 		{
-			name: "failed to create",
+			name: "invalid jlpt level",
+			request: &models.CreateWordRequest{
+				Word:      "水",
+				JLPTLevel: 6,
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "invalid jlpt level",
+		},
+		{
+			name: "invalid part of speech",
+			request: &models.CreateWordRequest{
+				Word:         "水",
+				PartOfSpeech: "adjective",
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "invalid part of speech: adjective",
+		},
+		{
+			name: "too many tags",
 			request: &models.CreateWordRequest{
 				Word: "水",
+				Tags: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "at most 10 tags",
+		},
+		{
+			name: "tag too long",
+			request: &models.CreateWordRequest{
+				Word: "水",
+				Tags: []string{strings.Repeat("a", 51)},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "must be at most 50 characters long",
+		},
+		{
+			name: "invalid ruby markup in example",
+			request: &models.CreateWordRequest{
+				Word:         "水",
+				Example:      "{水}を飲む",
+				Translations: map[string]models.WordTranslation{"en": {Translation: "water"}},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "validation error: invalid ruby markup",
+		},
+		{
+			name: "missing translation in the default locale",
+			request: &models.CreateWordRequest{
+				Word:         "水",
+				Translations: map[string]models.WordTranslation{"ru": {Translation: "вода"}, "en": {Translation: " "}},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "translation in the default locale 'en' is required",
+		},
+		{
+			name: "invalid translation language",
+			request: &models.CreateWordRequest{
+				Word:         "水",
+				Translations: map[string]models.WordTranslation{"en": {Translation: "water"}, "en_US": {Translation: "water"}},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "validation error: invalid language: en_US",
+		},
+		{
+			name: "failed to create",
+			request: &models.CreateWordRequest{
+				Word:         "水",
+				Translations: map[string]models.WordTranslation{"en": {Translation: "water"}},
 			},
 			mockRepo: &mockAdminWordRepository{
 				existsByWord: false,
@@ -307,7 +462,7 @@ func TestAdminWordService_CreateWord(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewAdminWordService(tt.mockRepo, &mockDictionaryHistoryRepository{}, "", "")
+			svc := NewAdminWordService(tt.mockRepo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			result, err := svc.CreateWord(ctx, tt.request, nil, "", nil, "")
@@ -326,6 +481,60 @@ func TestAdminWordService_CreateWord(t *testing.T) {
 	}
 }
 
+func TestAdminWordService_CreateWord_Classification(t *testing.T) {
+	repo := &mockAdminWordRepository{}
+	svc := NewAdminWordService(repo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+	_, err := svc.CreateWord(context.Background(), &models.CreateWordRequest{
+		Word:         "水",
+		Translations: map[string]models.WordTranslation{"en": {Translation: "water"}},
+		JLPTLevel:    5,
+		PartOfSpeech: models.PartOfSpeechNoun,
+		Tags:         []string{" Drink ", "nature", "drink", ""},
+	}, nil, "", nil, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, repo.createdWord.JLPTLevel)
+	assert.Equal(t, "noun", repo.createdWord.PartOfSpeech)
+	assert.Equal(t, []string{"drink", "nature"}, repo.createdWord.Tags, "tags are normalized and deduplicated")
+}
+
+func TestAdminWordService_LinksWordToKanji(t *testing.T) {
+	t.Run("created word is linked", func(t *testing.T) {
+		kanjiRepo := &mockWordKanjiRepository{}
+		svc := NewAdminWordService(&mockAdminWordRepository{}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, kanjiRepo, "", "", DefaultLocaleConfig())
+
+		id, err := svc.CreateWord(context.Background(), &models.CreateWordRequest{Word: "水曜日", Translations: map[string]models.WordTranslation{"en": {Translation: "Wednesday"}}}, nil, "", nil, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{id}, kanjiRepo.linkedWordIDs)
+	})
+
+	t.Run("link error on create", func(t *testing.T) {
+		kanjiRepo := &mockWordKanjiRepository{err: errors.New("database error")}
+		svc := NewAdminWordService(&mockAdminWordRepository{}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, kanjiRepo, "", "", DefaultLocaleConfig())
+
+		id, err := svc.CreateWord(context.Background(), &models.CreateWordRequest{Word: "水曜日", Translations: map[string]models.WordTranslation{"en": {Translation: "Wednesday"}}}, nil, "", nil, "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to link word to kanji")
+		assert.Equal(t, 0, id)
+	})
+
+	t.Run("word relinked only when changed", func(t *testing.T) {
+		kanjiRepo := &mockWordKanjiRepository{}
+		svc := NewAdminWordService(&mockAdminWordRepository{word: &models.Word{ID: 3}}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, kanjiRepo, "", "", DefaultLocaleConfig())
+
+		err := svc.UpdateWord(context.Background(), 3, &models.UpdateWordRequest{Translations: map[string]models.WordTranslation{"en": {Translation: "water"}}}, nil, "", nil, "")
+		assert.NoError(t, err)
+		assert.Empty(t, kanjiRepo.linkedWordIDs)
+
+		err = svc.UpdateWord(context.Background(), 3, &models.UpdateWordRequest{Word: "水"}, nil, "", nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{3}, kanjiRepo.linkedWordIDs)
+	})
+}
+
 func TestAdminWordService_UpdateWord(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -339,7 +548,7 @@ func TestAdminWordService_UpdateWord(t *testing.T) {
 			name: "success partial update",
 			id:   1,
 			request: &models.UpdateWordRequest{
-				EnglishTranslation: "updated water",
+				Translations: map[string]models.WordTranslation{"EN": {Translation: " updated water "}},
 			},
 			mockRepo:      &mockAdminWordRepository{},
 			expectedError: false,
@@ -450,11 +659,40 @@ func TestAdminWordService_UpdateWord(t *testing.T) {
 			expectedError: true,
 			errorContains: "failed to check clues existence",
 		},
+		{
+			name: "success remove all tags",
+			id:   1,
+			request: &models.UpdateWordRequest{
+				Tags: []string{},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: false,
+		},
+		{
+			name: "invalid jlpt level",
+			id:   1,
+			request: &models.UpdateWordRequest{
+				JLPTLevel: intPtr(0),
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "validation error: invalid jlpt level",
+		},
+		{
+			name: "too many tags",
+			id:   1,
+			request: &models.UpdateWordRequest{
+				Tags: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			mockRepo:      &mockAdminWordRepository{},
+			expectedError: true,
+			errorContains: "validation error: invalid tags",
+		},
 		{
 			name: "failed to update",
 			id:   1,
 			request: &models.UpdateWordRequest{
-				EnglishTranslation: "updated",
+				Translations: map[string]models.WordTranslation{"en": {Translation: "updated"}},
 			},
 			mockRepo: &mockAdminWordRepository{
 				updateErr: errors.New("update error"),
@@ -474,7 +712,7 @@ func TestAdminWordService_UpdateWord(t *testing.T) {
 					WordExampleAudio: "",
 				}
 			}
-			svc := NewAdminWordService(tt.mockRepo, &mockDictionaryHistoryRepository{}, "", "")
+			svc := NewAdminWordService(tt.mockRepo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			err := svc.UpdateWord(ctx, tt.id, tt.request, nil, "", nil, "")
@@ -491,6 +729,72 @@ func TestAdminWordService_UpdateWord(t *testing.T) {
 	}
 }
 
+func TestAdminWordService_UpdateWord_Tags(t *testing.T) {
+	t.Run("missing tags are left unchanged", func(t *testing.T) {
+		repo := &mockAdminWordRepository{word: &models.Word{ID: 1}}
+		svc := NewAdminWordService(repo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		err := svc.UpdateWord(context.Background(), 1, &models.UpdateWordRequest{JLPTLevel: intPtr(3)}, nil, "", nil, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, repo.updatedWord.JLPTLevel)
+		assert.Nil(t, repo.updatedWord.Tags)
+	})
+
+	t.Run("empty tags remove all tags", func(t *testing.T) {
+		repo := &mockAdminWordRepository{word: &models.Word{ID: 1}}
+		svc := NewAdminWordService(repo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		err := svc.UpdateWord(context.Background(), 1, &models.UpdateWordRequest{Tags: []string{" "}}, nil, "", nil, "")
+
+		require.NoError(t, err)
+		assert.NotNil(t, repo.updatedWord.Tags)
+		assert.Empty(t, repo.updatedWord.Tags)
+	})
+}
+
+func TestAdminWordService_SearchWords(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		found := []models.WordSearchResult{{ID: 1, Word: "食べる", PhoneticClues: "たべる", Translation: "to eat"}}
+		svc := NewAdminWordService(&mockAdminWordRepository{found: found}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		result, err := svc.SearchWords(context.Background(), "taberu", "en", 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, found, result)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		svc := NewAdminWordService(&mockAdminWordRepository{}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		result, err := svc.SearchWords(context.Background(), "", "en", 10)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "search query is required")
+	})
+}
+
+func TestAdminWordService_GetTags(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tags := []models.WordTag{{Tag: "food", WordCount: 2}}
+		svc := NewAdminWordService(&mockAdminWordRepository{tags: tags}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		result, err := svc.GetTags(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, tags, result)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		svc := NewAdminWordService(&mockAdminWordRepository{err: errors.New("database error")}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		result, err := svc.GetTags(context.Background())
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "failed to get word tags")
+	})
+}
+
 func TestAdminWordService_DeleteWord(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -540,7 +844,7 @@ func TestAdminWordService_DeleteWord(t *testing.T) {
 					WordExampleAudio: "",
 				}
 			}
-			svc := NewAdminWordService(tt.mockRepo, &mockDictionaryHistoryRepository{}, "", "")
+			svc := NewAdminWordService(tt.mockRepo, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
 			ctx := context.Background()
 
 			err := svc.DeleteWord(ctx, tt.id)
@@ -557,3 +861,330 @@ func TestAdminWordService_DeleteWord(t *testing.T) {
 	}
 }
 
+// newMediaDeleteServer starts a media service mock recording paths of deleted files
+func newMediaDeleteServer(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		mu.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return deleted
+	}
+}
+
+func TestAdminWordService_DeleteWord_ExampleAudio(t *testing.T) {
+	server, deleted := newMediaDeleteServer(t)
+	wordRepo := &mockAdminWordRepository{word: &models.Word{ID: 1, Word: "水"}}
+	exampleRepo := &mockAdminWordExampleRepository{examples: []models.WordExample{
+		{ID: 1, WordID: 1, Example: "水が冷たい", ExampleAudio: server.URL + "/media/word_example/a.mp3"},
+		{ID: 2, WordID: 1, Example: "水をください"},
+	}}
+	svc := NewAdminWordService(wordRepo, exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, server.URL, "test-key", DefaultLocaleConfig())
+
+	err := svc.DeleteWord(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/media/word_example/a.mp3"}, deleted())
+}
+
+func TestAdminWordService_GetExamples(t *testing.T) {
+	tests := []struct {
+		name          string
+		wordId        int
+		wordRepo      *mockAdminWordRepository
+		exampleRepo   *mockAdminWordExampleRepository
+		expectedError string
+		expectedCount int
+	}{
+		{
+			name:     "success",
+			wordId:   1,
+			wordRepo: &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo: &mockAdminWordExampleRepository{examples: []models.WordExample{
+				{ID: 1, WordID: 1, Example: "水が冷たい"},
+				{ID: 2, WordID: 1, Example: "水をください"},
+			}},
+			expectedCount: 2,
+		},
+		{
+			name:          "invalid word id",
+			wordId:        0,
+			wordRepo:      &mockAdminWordRepository{},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "invalid word id",
+		},
+		{
+			name:          "word not found",
+			wordId:        1,
+			wordRepo:      &mockAdminWordRepository{err: errors.New("word not found")},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "word not found",
+		},
+		{
+			name:          "repository error",
+			wordId:        1,
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{err: errors.New("database error")},
+			expectedError: "failed to get word examples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminWordService(tt.wordRepo, tt.exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+			result, err := svc.GetExamples(context.Background(), tt.wordId)
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, tt.expectedCount)
+			}
+		})
+	}
+}
+
+func TestAdminWordService_CreateExample(t *testing.T) {
+	tests := []struct {
+		name          string
+		wordId        int
+		request       *models.CreateWordExampleRequest
+		wordRepo      *mockAdminWordRepository
+		exampleRepo   *mockAdminWordExampleRepository
+		expectedError string
+		expected      *models.WordExample
+	}{
+		{
+			name:   "success with trimmed fields",
+			wordId: 1,
+			request: &models.CreateWordExampleRequest{Example: " 水が冷たい ",
+				Translations: map[string]string{"en": " The water is cold ", "RU": "Вода холодная", "de": " "}, Source: " NHK Easy "},
+			wordRepo:    &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo: &mockAdminWordExampleRepository{},
+			expected: &models.WordExample{ID: 1, WordID: 1, Example: "水が冷たい",
+				Translations: map[string]string{"en": "The water is cold", "ru": "Вода холодная"}, Source: "NHK Easy"},
+		},
+		{
+			name:          "invalid word id",
+			wordId:        0,
+			request:       &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold"}},
+			wordRepo:      &mockAdminWordRepository{},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "invalid word id",
+		},
+		{
+			name:          "missing example",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "  ", Translations: map[string]string{"en": "The water is cold"}},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "validation error: example is required",
+		},
+		{
+			name:          "missing translation in the default locale",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"ru": "Вода холодная"}},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "validation error: translation in the default locale 'en' is required",
+		},
+		{
+			name:          "invalid translation language",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold", "e n": "x"}},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "validation error: invalid language: e n",
+		},
+		{
+			name:   "source too long",
+			wordId: 1,
+			request: &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold"},
+				Source: strings.Repeat("本", 256)},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "validation error: source must be at most 255 characters long",
+		},
+		{
+			name:          "invalid ruby markup",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "{水|mizu}が冷たい", Translations: map[string]string{"en": "The water is cold"}},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "validation error: invalid ruby markup: reading 'mizu' must contain only kana",
+		},
+		{
+			name:          "word not found",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold"}},
+			wordRepo:      &mockAdminWordRepository{err: errors.New("word not found")},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "word not found",
+		},
+		{
+			name:          "repository error",
+			wordId:        1,
+			request:       &models.CreateWordExampleRequest{Example: "水が冷たい", Translations: map[string]string{"en": "The water is cold"}},
+			wordRepo:      &mockAdminWordRepository{word: &models.Word{ID: 1}},
+			exampleRepo:   &mockAdminWordExampleRepository{err: errors.New("database error")},
+			expectedError: "failed to create word example",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminWordService(tt.wordRepo, tt.exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+			id, err := svc.CreateExample(context.Background(), tt.wordId, tt.request, nil, "")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Equal(t, 0, id)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, id)
+				assert.Equal(t, tt.expected, tt.exampleRepo.createdExample)
+			}
+		})
+	}
+}
+
+func TestAdminWordService_UpdateExample(t *testing.T) {
+	tests := []struct {
+		name          string
+		wordId        int
+		exampleId     int
+		request       *models.UpdateWordExampleRequest
+		exampleRepo   *mockAdminWordExampleRepository
+		expectedError string
+		expected      *models.WordExample
+	}{
+		{
+			name:        "success partial update",
+			wordId:      1,
+			exampleId:   2,
+			request:     &models.UpdateWordExampleRequest{Translations: map[string]string{"de": "Das Wasser ist kalt"}, Source: "Genki I"},
+			exampleRepo: &mockAdminWordExampleRepository{example: &models.WordExample{ID: 2, WordID: 1}},
+			expected:    &models.WordExample{Translations: map[string]string{"de": "Das Wasser ist kalt"}, Source: "Genki I"},
+		},
+		{
+			name:          "invalid example id",
+			wordId:        1,
+			exampleId:     0,
+			request:       &models.UpdateWordExampleRequest{Source: "Genki I"},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "invalid example id",
+		},
+		{
+			name:          "example too long",
+			wordId:        1,
+			exampleId:     2,
+			request:       &models.UpdateWordExampleRequest{Example: strings.Repeat("水", 256)},
+			exampleRepo:   &mockAdminWordExampleRepository{example: &models.WordExample{ID: 2, WordID: 1}},
+			expectedError: "validation error: example must be at most 255 characters long",
+		},
+		{
+			name:          "unclosed ruby annotation",
+			wordId:        1,
+			exampleId:     2,
+			request:       &models.UpdateWordExampleRequest{Example: "{水|みず"},
+			exampleRepo:   &mockAdminWordExampleRepository{example: &models.WordExample{ID: 2, WordID: 1}},
+			expectedError: "validation error: invalid ruby markup: unclosed '{'",
+		},
+		{
+			name:          "translation too long",
+			wordId:        1,
+			exampleId:     2,
+			request:       &models.UpdateWordExampleRequest{Translations: map[string]string{"uk": strings.Repeat("в", 256)}},
+			exampleRepo:   &mockAdminWordExampleRepository{example: &models.WordExample{ID: 2, WordID: 1}},
+			expectedError: "validation error: uk translation must be at most 255 characters long",
+		},
+		{
+			name:          "example not found",
+			wordId:        1,
+			exampleId:     2,
+			request:       &models.UpdateWordExampleRequest{Source: "Genki I"},
+			exampleRepo:   &mockAdminWordExampleRepository{},
+			expectedError: "word example not found",
+		},
+		{
+			name:      "repository error",
+			wordId:    1,
+			exampleId: 2,
+			request:   &models.UpdateWordExampleRequest{Source: "Genki I"},
+			exampleRepo: &mockAdminWordExampleRepository{
+				example: &models.WordExample{ID: 2, WordID: 1},
+				err:     errors.New("database error"),
+			},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAdminWordService(&mockAdminWordRepository{}, tt.exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+			err := svc.UpdateExample(context.Background(), tt.wordId, tt.exampleId, tt.request, nil, "")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, tt.exampleRepo.updatedExample)
+			}
+		})
+	}
+}
+
+func TestAdminWordService_DeleteExample(t *testing.T) {
+	t.Run("success deletes audio file", func(t *testing.T) {
+		server, deleted := newMediaDeleteServer(t)
+		exampleRepo := &mockAdminWordExampleRepository{
+			example: &models.WordExample{ID: 2, WordID: 1, ExampleAudio: server.URL + "/media/word_example/b.mp3"},
+		}
+		svc := NewAdminWordService(&mockAdminWordRepository{}, exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, server.URL, "test-key", DefaultLocaleConfig())
+
+		err := svc.DeleteExample(context.Background(), 1, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, exampleRepo.deletedID)
+		assert.Equal(t, []string{"/media/word_example/b.mp3"}, deleted())
+	})
+
+	t.Run("invalid word id", func(t *testing.T) {
+		svc := NewAdminWordService(&mockAdminWordRepository{}, &mockAdminWordExampleRepository{}, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		err := svc.DeleteExample(context.Background(), 0, 2)
+
+		require.Error(t, err)
+		assert.Equal(t, "invalid word id", err.Error())
+	})
+
+	t.Run("example not found", func(t *testing.T) {
+		exampleRepo := &mockAdminWordExampleRepository{}
+		svc := NewAdminWordService(&mockAdminWordRepository{}, exampleRepo, &mockDictionaryHistoryRepository{}, &mockWordKanjiRepository{}, "", "", DefaultLocaleConfig())
+
+		err := svc.DeleteExample(context.Background(), 1, 2)
+
+		require.Error(t, err)
+		assert.Equal(t, "word example not found", err.Error())
+		assert.Equal(t, 0, exampleRepo.deletedID)
+	})
+}
@@ -3,152 +3,212 @@ package services
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/google/uuid"
 )
 
+// testSessionTTL defines how long a generated test can be submitted
+const testSessionTTL = 30 * time.Minute
+
 // CharactersRepository is the interface that wraps methods for Characters table data access
 type CharactersRepository interface {
 	// Method GetAll retrieve all hiragana/katakana characters from a database.
 	//
-	// "alphabetType" and "locale" parameters are used to configure return type of characters (hiragana or katakana) and reading (russian or english).
-	// Please reference AlphabetType and Locale constants for correct parameters values.
+	// "alphabetType" parameter is used to configure return type of characters (hiragana or katakana), please reference AlphabetType constants.
+	// "languages" parameter is the locale fallback chain, readings are taken in its first language which has them.
+	// "groups" parameter is used to limit characters to the listed character groups (all groups if empty).
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetAll(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale) ([]models.CharacterResponse, error)
+	GetAll(ctx context.Context, alphabetType models.AlphabetType, languages []string, groups []models.CharacterGroup) ([]models.CharacterResponse, error)
 	// Method GetByRowColumn retrieve hiragana/katakana characters from a database filtered by consonant or vowel group ("character" parameter).
 	//
 	// Please reference GetAll method for more information about parameters and error values.
-	GetByRowColumn(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, character string) ([]models.CharacterResponse, error)
+	GetByRowColumn(ctx context.Context, alphabetType models.AlphabetType, languages []string, character string, groups []models.CharacterGroup) ([]models.CharacterResponse, error)
 	// Method GetByID retrieve a character by its ID.
 	//
-	// "locale" parameter is used to configure return type of characters (hiragana or katakana) and reading (russian or english).
-	// Please reference Locale constants for correct parameter values.
+	// "languages" parameter is the locale fallback chain, the reading is taken in its first language which has it.
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetByID(ctx context.Context, id int, locale models.Locale) (*models.Character, error)
+	GetByID(ctx context.Context, id int, languages []string) (*models.Character, error)
 	// Method GetCharactersForReadingTest retrieve characters for reading test with defined IDs.
 	//
 	// This method returns a slice of ReadingTestItem objects with defined Id, each containing one correct character and two wrong characters.
 	// "alphabetType" parameter is used to identify the alphabet type.
-	// "locale" parameter is used to identify the locale.
+	// "languages" parameter is the locale fallback chain, readings are taken in its first language which has them.
 	// "characterIDs" parameter is used to identify the character IDs.
+	// "groups" parameter is used to limit wrong options to the listed character groups (all groups if empty).
 	//
 	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
-	GetCharactersForReadingTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.ReadingTestItem, error)
+	GetCharactersForReadingTest(ctx context.Context, alphabetType models.AlphabetType, languages []string, characterIDs []int, groups []models.CharacterGroup) ([]models.ReadingTestItem, error)
 	// Method GetCharactersForWritingTest retrieve characters for writing test with defined IDs.
 	//
 	// This method returns a slice of "characterIDs" WritingTestItem objects, each containing one correct character and two wrong characters.
 	// "alphabetType" parameter is used to identify the alphabet type.
-	// "locale" parameter is used to identify the locale.
+	// "languages" parameter is the locale fallback chain, readings are taken in its first language which has them.
 	// "characterIDs" parameter is used to identify the character IDs.
 	//
 	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
-	GetCharactersForWritingTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.WritingTestItem, error)
+	GetCharactersForWritingTest(ctx context.Context, alphabetType models.AlphabetType, languages []string, characterIDs []int) ([]models.WritingTestItem, error)
 	// Method GetCharactersForListeningTest retrieve characters for listening test with defined IDs.
 	//
 	// Please reference GetCharactersForReadingTest method for more information about parameters and error values.
-	GetCharactersForListeningTest(ctx context.Context, alphabetType models.AlphabetType, locale models.Locale, characterIDs []int) ([]models.ListeningTestItem, error)
+	GetCharactersForListeningTest(ctx context.Context, alphabetType models.AlphabetType, characterIDs []int, groups []models.CharacterGroup) ([]models.ListeningTestItem, error)
+	// Method GetCharactersForMatchingTest retrieve characters for matching test with defined IDs.
+	//
+	// This method returns a slice of MatchingTestItem objects, each containing a character of the "alphabetType" alphabet
+	// together with its counterpart and two wrong characters of the other alphabet.
+	// "characterIDs" parameter is used to identify the character IDs.
+	// "groups" parameter is used to limit wrong options to the listed character groups (all groups if empty).
+	//
+	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
+	GetCharactersForMatchingTest(ctx context.Context, alphabetType models.AlphabetType, characterIDs []int, groups []models.CharacterGroup) ([]models.MatchingTestItem, error)
 	// Method GetCharactersWithoutHistory retrieve characters that don't have CharacterLearnHistory records for the user.
 	//
 	// "alphabetType" parameter is used to identify the alphabet type.
 	// "userID" parameter is used to identify the user.
+	// "groups" parameter is used to limit characters to the listed character groups (all groups if empty).
 	// "count" parameter is used to identify the number of characters to retrieve.
 	//
 	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
-	GetCharactersWithoutHistory(ctx context.Context, userID int, alphabetType models.AlphabetType, count int) ([]int, error)
+	GetCharactersWithoutHistory(ctx context.Context, userID int, alphabetType models.AlphabetType, groups []models.CharacterGroup, count int) ([]int, error)
 	// Method GetCharactersWithLowestResults retrieve characters with lowest result values for the user.
 	//
 	// "alphabetType" parameter is used to identify the alphabet type.
 	// "userID" parameter is used to identify the user.
 	// "testTypeResultField" parameter is used to identify the test type result field.
+	// "groups" parameter is used to limit characters to the listed character groups (all groups if empty).
 	// "count" parameter is used to identify the number of characters to retrieve.
 	//
 	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
-	GetCharactersWithLowestResults(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, count int) ([]int, error)
+	GetCharactersWithLowestResults(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, groups []models.CharacterGroup, count int) ([]int, error)
+}
+
+// CharacterStrokeRepository is the interface that wraps methods for CharacterStrokes table data access
+type CharacterStrokeRepository interface {
+	// Method GetByCharacterID retrieve reference strokes of a character in stroke order.
+	//
+	// "characterID" parameter is used to identify the character.
+	// "alphabetType" parameter is used to identify the alphabet ("hiragana" or "katakana").
+	//
+	// If the character has no strokes, an empty slice will be returned.
+	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
+	GetByCharacterID(ctx context.Context, characterID int, alphabetType string) ([]models.CharacterStroke, error)
+}
+
+// CharacterMistakeRepository is the interface that wraps methods for reading user's mistakes from CharacterTestAttempts table
+type CharacterMistakeRepository interface {
+	// Method GetMistakenOptions retrieve wrong options chosen by the user instead of the characters.
+	//
+	// "userID" parameter is used to identify the user.
+	// "alphabetType" parameter is used to identify the alphabet ("hiragana" or "katakana").
+	// "characterIDs" parameter is used to identify the characters.
+	//
+	// Returns a map of character ID to chosen options, the most frequent mistake first.
+	// If some error will occur during data retrieval, the error will be returned together with "nil" value.
+	GetMistakenOptions(ctx context.Context, userID int, alphabetType string, characterIDs []int) (map[int][]string, error)
 }
 
+const (
+	// maxDrawnStrokes limits the number of strokes in a stroke exercise answer
+	maxDrawnStrokes = 30
+	// maxStrokePoints limits the number of points of a single drawn stroke
+	maxStrokePoints = 1000
+)
+
 type charactersService struct {
 	repo        CharactersRepository
 	historyRepo CharacterLearnHistoryRepository
+	sessionRepo TestSessionRepository
+	strokeRepo  CharacterStrokeRepository
+	mistakeRepo CharacterMistakeRepository
+	locale      LocaleConfig
 }
 
 // NewCharactersService creates a new character service
-func NewCharactersService(repo CharactersRepository, historyRepo CharacterLearnHistoryRepository) *charactersService {
+func NewCharactersService(repo CharactersRepository, historyRepo CharacterLearnHistoryRepository, sessionRepo TestSessionRepository, strokeRepo CharacterStrokeRepository, mistakeRepo CharacterMistakeRepository, locale LocaleConfig) *charactersService {
 	return &charactersService{
 		repo:        repo,
 		historyRepo: historyRepo,
+		sessionRepo: sessionRepo,
+		strokeRepo:  strokeRepo,
+		mistakeRepo: mistakeRepo,
+		locale:      locale,
 	}
 }
 
 // GetAll retrieves all characters filtered by alphabet type and locale
 //
-// Please reference validateAlphabetType and validateLocale methods for more information about parameters and error values.
-func (s *charactersService) GetAll(ctx context.Context, typeParam string, localeParam string) ([]models.CharacterResponse, error) {
+// Please reference validateAlphabetType, localeChain and parseCharacterGroups methods for more information about parameters and error values.
+func (s *charactersService) GetAll(ctx context.Context, typeParam string, localeParam string, groupsParam string) ([]models.CharacterResponse, error) {
 	alphabetType := models.AlphabetType(typeParam)
-	locale := models.Locale(localeParam)
-
 	if err := s.validateAlphabetType(alphabetType); err != nil {
 		return nil, err
 	}
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetAll(ctx, alphabetType, normalizedLocale)
+	return s.repo.GetAll(ctx, alphabetType, languages, groups)
 }
 
 // GetByRowColumn retrieves characters filtered by consonant or vowel
 //
-// Please reference validateAlphabetType and validateLocale methods for more information about parameters and error values.
-func (s *charactersService) GetByRowColumn(ctx context.Context, typeParam string, localeParam string, character string) ([]models.CharacterResponse, error) {
+// Please reference validateAlphabetType, localeChain and parseCharacterGroups methods for more information about parameters and error values.
+func (s *charactersService) GetByRowColumn(ctx context.Context, typeParam string, localeParam string, character string, groupsParam string) ([]models.CharacterResponse, error) {
 	alphabetType := models.AlphabetType(typeParam)
-	locale := models.Locale(localeParam)
-
 	if err := s.validateAlphabetType(alphabetType); err != nil {
 		return nil, err
 	}
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
 		return nil, err
 	}
 	if character == "" {
 		return nil, fmt.Errorf("character parameter is required")
 	}
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.repo.GetByRowColumn(ctx, alphabetType, normalizedLocale, character)
+	return s.repo.GetByRowColumn(ctx, alphabetType, languages, character, groups)
 }
 
 // GetByID retrieves a character by its ID
 //
-// For successful results localeParam must be either "ru" (Russian), "en" (English), or "de" (German - treated as English).
+// For successful results localeParam must be a valid language code, such as "en" or "pt-br".
 func (s *charactersService) GetByID(ctx context.Context, id int, localeParam string) (*models.Character, error) {
-	locale := models.Locale(localeParam)
-
 	if id <= 0 {
 		return nil, fmt.Errorf("invalid character id")
 	}
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.repo.GetByID(ctx, id, normalizedLocale)
+	return s.repo.GetByID(ctx, id, languages)
 }
 
 // GetReadingTest retrieves random characters for reading test
 //
 // For successful results alphabetTypeStr must be either "hiragana" or "katakana" (from URL path).
-// localeParam must be either "ru" (Russian), "en" (English), or "de" (German - treated as English).
+// localeParam must be a valid language code, readings missing in the locale are taken from its fallbacks.
 // count must be a positive integer.
 // userID is required - uses smart filtering based on user's learning history.
-func (s *charactersService) GetReadingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.ReadingTestItem, error) {
-	locale := models.Locale(localeParam)
-
+// groupsParam is a comma-separated list of character groups to test (all groups if empty).
+//
+// Wrong options become harder to tell apart from the correct character as the user's score for it grows.
+// Test items are stored in a new test session, the client receives only shuffled options.
+func (s *charactersService) GetReadingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.ReadingTestSession, error) {
 	var at models.AlphabetType
 	switch alphabetTypeStr {
 	case "hiragana":
@@ -159,39 +219,70 @@ func (s *charactersService) GetReadingTest(ctx context.Context, alphabetTypeStr
 		return nil, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetTypeStr)
 	}
 
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
 		return nil, err
 	}
 
 	// Determine the result field based on alphabet type and test type
-	var testTypeResultField string
-	if alphabetTypeStr == "hiragana" {
-		testTypeResultField = "hiragana_reading_result"
-	} else {
-		testTypeResultField = "katakana_reading_result"
+	resultField, err := lookupTestResultField(alphabetTypeStr, "reading")
+	if err != nil {
+		return nil, err
 	}
 
 	// Get character IDs with smart filtering
-	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, testTypeResultField, count)
+	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, resultField.column, groups, count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get character IDs with smart filtering: %w", err)
 	}
 
 	// Get all test items
-	return s.repo.GetCharactersForReadingTest(ctx, at, normalizedLocale, characterIDs)
+	items, err := s.repo.GetCharactersForReadingTest(ctx, at, languages, characterIDs, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make wrong options harder to rule out as the user progresses
+	distractorItems := make([]distractorItem, len(items))
+	for i := range items {
+		distractorItems[i] = distractorItem{characterID: items[i].ID, correctChar: items[i].CorrectChar, wrongOptions: &items[i].WrongOptions}
+	}
+	if err := s.selectDistractors(ctx, userID, at, languages, groups, resultField, distractorItems); err != nil {
+		return nil, fmt.Errorf("failed to select distractors: %w", err)
+	}
+
+	sessionItems := make([]models.TestSessionItem, len(items))
+	for i := range items {
+		items[i].Options = shuffleOptions(items[i].CorrectChar, items[i].WrongOptions)
+		sessionItems[i] = models.TestSessionItem{CharacterID: items[i].ID, CorrectAnswer: items[i].CorrectChar}
+	}
+
+	session, err := s.createTestSession(ctx, userID, alphabetTypeStr, "reading", sessionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReadingTestSession{
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt,
+		Items:     items,
+	}, nil
 }
 
 // GetWritingTest retrieves random characters for writing test
 //
 // For successful results alphabetTypeStr must be either "hiragana" or "katakana" (from URL path).
-// localeParam must be either "ru" (Russian), "en" (English), or "de" (German - treated as English).
+// localeParam must be a valid language code, readings missing in the locale are taken from its fallbacks.
 // count must be a positive integer.
 // userID is required - uses smart filtering based on user's learning history.
-func (s *charactersService) GetWritingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.WritingTestItem, error) {
-	locale := models.Locale(localeParam)
-
+// groupsParam is a comma-separated list of character groups to test (all groups if empty).
+//
+// Correct readings are stored in a new test session and are not returned to the client.
+func (s *charactersService) GetWritingTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.WritingTestSession, error) {
 	var at models.AlphabetType
 	switch alphabetTypeStr {
 	case "hiragana":
@@ -202,39 +293,135 @@ func (s *charactersService) GetWritingTest(ctx context.Context, alphabetTypeStr
 		return nil, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetTypeStr)
 	}
 
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
 		return nil, err
 	}
 
 	// Determine the result field based on alphabet type and test type
-	var testTypeResultField string
-	if alphabetTypeStr == "hiragana" {
-		testTypeResultField = "hiragana_writing_result"
-	} else {
-		testTypeResultField = "katakana_writing_result"
+	resultField, err := lookupTestResultField(alphabetTypeStr, "writing")
+	if err != nil {
+		return nil, err
 	}
 
 	// Get character IDs with smart filtering
-	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, testTypeResultField, count)
+	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, resultField.column, groups, count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get character IDs with smart filtering: %w", err)
 	}
 
 	// Get all test items
-	return s.repo.GetCharactersForWritingTest(ctx, at, normalizedLocale, characterIDs)
+	items, err := s.repo.GetCharactersForWritingTest(ctx, at, languages, characterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionItems := make([]models.TestSessionItem, len(items))
+	for i, item := range items {
+		sessionItems[i] = models.TestSessionItem{CharacterID: item.ID, CorrectAnswer: item.CorrectReading}
+	}
+
+	session, err := s.createTestSession(ctx, userID, alphabetTypeStr, "writing", sessionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WritingTestSession{
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt,
+		Items:     items,
+	}, nil
 }
 
 // GetListeningTest retrieves random characters for listening test
 //
 // For successful results alphabetTypeStr must be either "hiragana" or "katakana" (from URL path).
-// localeParam must be either "ru" (Russian), "en" (English), or "de" (German - treated as English).
+// localeParam must be a valid language code, readings missing in the locale are taken from its fallbacks.
 // count must be a positive integer.
 // userID is required - uses smart filtering based on user's learning history.
-func (s *charactersService) GetListeningTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int) ([]models.ListeningTestItem, error) {
-	locale := models.Locale(localeParam)
+// groupsParam is a comma-separated list of character groups to test (all groups if empty).
+//
+// Wrong options become harder to tell apart from the correct character as the user's score for it grows.
+// Test items are stored in a new test session, the client receives only shuffled options.
+func (s *charactersService) GetListeningTest(ctx context.Context, alphabetTypeStr string, localeParam string, count int, userID int, groupsParam string) (*models.ListeningTestSession, error) {
+	var at models.AlphabetType
+	switch alphabetTypeStr {
+	case "hiragana":
+		at = models.AlphabetTypeHiragana
+	case "katakana":
+		at = models.AlphabetTypeKatakana
+	default:
+		return nil, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetTypeStr)
+	}
+
+	languages, err := s.localeChain(localeParam)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
+		return nil, err
+	}
+
+	// Determine the result field based on alphabet type and test type
+	resultField, err := lookupTestResultField(alphabetTypeStr, "listening")
+	if err != nil {
+		return nil, err
+	}
+
+	// Get character IDs with smart filtering
+	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, resultField.column, groups, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get character IDs with smart filtering: %w", err)
+	}
 
+	// Get all test items
+	items, err := s.repo.GetCharactersForListeningTest(ctx, at, characterIDs, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make wrong options harder to rule out as the user progresses
+	distractorItems := make([]distractorItem, len(items))
+	for i := range items {
+		distractorItems[i] = distractorItem{characterID: items[i].ID, correctChar: items[i].CorrectChar, wrongOptions: &items[i].WrongOptions}
+	}
+	if err := s.selectDistractors(ctx, userID, at, languages, groups, resultField, distractorItems); err != nil {
+		return nil, fmt.Errorf("failed to select distractors: %w", err)
+	}
+
+	sessionItems := make([]models.TestSessionItem, len(items))
+	for i := range items {
+		items[i].Options = shuffleOptions(items[i].CorrectChar, items[i].WrongOptions)
+		sessionItems[i] = models.TestSessionItem{CharacterID: items[i].ID, CorrectAnswer: items[i].CorrectChar}
+	}
+
+	session, err := s.createTestSession(ctx, userID, alphabetTypeStr, "listening", sessionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListeningTestSession{
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt,
+		Items:     items,
+	}, nil
+}
+
+// GetMatchingTest retrieves random characters for matching test
+//
+// For successful results alphabetTypeStr must be either "hiragana" or "katakana" (from URL path), it is the alphabet of shown characters.
+// Options are characters of the other alphabet, so the learner matches hiragana with katakana or the reverse.
+// count must be a positive integer.
+// userID is required - uses smart filtering based on user's learning history.
+// groupsParam is a comma-separated list of character groups to test (all groups if empty).
+//
+// Test items are stored in a new test session, the client receives only shuffled options.
+func (s *charactersService) GetMatchingTest(ctx context.Context, alphabetTypeStr string, count int, userID int, groupsParam string) (*models.MatchingTestSession, error) {
 	var at models.AlphabetType
 	switch alphabetTypeStr {
 	case "hiragana":
@@ -245,28 +432,120 @@ func (s *charactersService) GetListeningTest(ctx context.Context, alphabetTypeSt
 		return nil, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetTypeStr)
 	}
 
-	// Normalize locale: treat "de" as "en"
-	normalizedLocale := s.normalizeLocale(locale)
-	if err := s.validateLocale(normalizedLocale); err != nil {
+	groups, err := parseCharacterGroups(groupsParam)
+	if err != nil {
 		return nil, err
 	}
 
 	// Determine the result field based on alphabet type and test type
-	var testTypeResultField string
-	if alphabetTypeStr == "hiragana" {
-		testTypeResultField = "hiragana_listening_result"
-	} else {
-		testTypeResultField = "katakana_listening_result"
+	resultField, err := lookupTestResultField(alphabetTypeStr, "matching")
+	if err != nil {
+		return nil, err
 	}
 
 	// Get character IDs with smart filtering
-	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, testTypeResultField, count)
+	characterIDs, err := s.getCharacterIDsWithSmartFiltering(ctx, userID, at, resultField.column, groups, count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get character IDs with smart filtering: %w", err)
 	}
 
 	// Get all test items
-	return s.repo.GetCharactersForListeningTest(ctx, at, normalizedLocale, characterIDs)
+	items, err := s.repo.GetCharactersForMatchingTest(ctx, at, characterIDs, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionItems := make([]models.TestSessionItem, len(items))
+	for i := range items {
+		items[i].Options = shuffleOptions(items[i].CorrectChar, items[i].WrongOptions)
+		sessionItems[i] = models.TestSessionItem{CharacterID: items[i].ID, CorrectAnswer: items[i].CorrectChar}
+	}
+
+	session, err := s.createTestSession(ctx, userID, alphabetTypeStr, "matching", sessionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MatchingTestSession{
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt,
+		Items:     items,
+	}, nil
+}
+
+// GetStrokes retrieves reference strokes of a character
+//
+// For successful results typeParam must be either "hr" or "kt".
+// If the character has no stroke data, "character strokes not found" error is returned.
+func (s *charactersService) GetStrokes(ctx context.Context, id int, typeParam string) ([]models.CharacterStroke, error) {
+	alphabetType := models.AlphabetType(typeParam)
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid character id")
+	}
+	if err := s.validateAlphabetType(alphabetType); err != nil {
+		return nil, err
+	}
+
+	alphabetName := "hiragana"
+	if alphabetType == models.AlphabetTypeKatakana {
+		alphabetName = "katakana"
+	}
+	strokes, err := s.strokeRepo.GetByCharacterID(ctx, id, alphabetName)
+	if err != nil {
+		return nil, err
+	}
+	if len(strokes) == 0 {
+		return nil, fmt.Errorf("character strokes not found")
+	}
+
+	return strokes, nil
+}
+
+// CheckStrokes checks strokes drawn by a user against reference strokes of a character
+//
+// For successful results alphabetTypeStr must be either "hiragana" or "katakana" (from URL path).
+// Drawn points must use the 109x109 KanjiVG coordinate space, every stroke is an ordered list of points from its start to its end.
+// Stroke count, order and direction are checked, feedback is returned for every drawn and every missing stroke.
+func (s *charactersService) CheckStrokes(ctx context.Context, alphabetTypeStr string, id int, strokes [][]models.StrokePoint) (*models.StrokeExerciseResult, error) {
+	if alphabetTypeStr != "hiragana" && alphabetTypeStr != "katakana" {
+		return nil, fmt.Errorf("invalid alphabet type: %s, must be 'hiragana' or 'katakana'", alphabetTypeStr)
+	}
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid character id")
+	}
+	if len(strokes) == 0 {
+		return nil, fmt.Errorf("strokes cannot be empty")
+	}
+	if len(strokes) > maxDrawnStrokes {
+		return nil, fmt.Errorf("too many strokes")
+	}
+	for _, stroke := range strokes {
+		if len(stroke) == 0 {
+			return nil, fmt.Errorf("stroke must contain at least one point")
+		}
+		if len(stroke) > maxStrokePoints {
+			return nil, fmt.Errorf("too many points in a stroke")
+		}
+	}
+
+	referenceStrokes, err := s.strokeRepo.GetByCharacterID(ctx, id, alphabetTypeStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(referenceStrokes) == 0 {
+		return nil, fmt.Errorf("character strokes not found")
+	}
+
+	reference := make([][]models.StrokePoint, len(referenceStrokes))
+	for i, stroke := range referenceStrokes {
+		points, err := parseSVGPath(stroke.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse stroke %d: %w", stroke.StrokeNumber, err)
+		}
+		reference[i] = points
+	}
+
+	return checkStrokes(reference, strokes), nil
 }
 
 // validateAlphabetType validates the alphabet type
@@ -277,26 +556,45 @@ func (s *charactersService) validateAlphabetType(at models.AlphabetType) error {
 	return nil
 }
 
-// normalizeLocale normalizes locale: treats "de" as "en"
-func (s *charactersService) normalizeLocale(locale models.Locale) models.Locale {
-	if locale == models.LocaleGerman {
-		return models.LocaleEnglish
+// localeChain validates the locale and returns languages in which readings of characters are looked up
+//
+// Please reference parseLocale function and LocaleConfig type for more information.
+func (s *charactersService) localeChain(localeParam string) ([]string, error) {
+	locale, err := parseLocale(localeParam)
+	if err != nil {
+		return nil, err
 	}
-	return locale
+	return s.locale.Chain(locale), nil
 }
 
-// validateLocale validates the locale
-func (s *charactersService) validateLocale(locale models.Locale) error {
-	if locale != models.LocaleEnglish && locale != models.LocaleRussian {
-		return fmt.Errorf("invalid locale: %s, must be 'en' or 'ru'", locale)
+// parseCharacterGroups parses a comma-separated list of character groups
+//
+// Empty parameter means all groups, so nil is returned. Duplicates are ignored.
+// If some of the groups is unknown, the error will be returned.
+func parseCharacterGroups(groupsParam string) ([]models.CharacterGroup, error) {
+	if strings.TrimSpace(groupsParam) == "" {
+		return nil, nil
+	}
+
+	var groups []models.CharacterGroup
+	seen := make(map[models.CharacterGroup]bool)
+	for _, part := range strings.Split(groupsParam, ",") {
+		group := models.CharacterGroup(strings.ToLower(strings.TrimSpace(part)))
+		if !group.IsValid() {
+			return nil, fmt.Errorf("invalid character group: %s, must be 'basic', 'dakuten', 'handakuten' or 'yoon'", group)
+		}
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
 	}
-	return nil
+	return groups, nil
 }
 
 // getCharacterIDsWithSmartFiltering retrieves character IDs with smart filtering based on user history
-func (s *charactersService) getCharacterIDsWithSmartFiltering(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, count int) ([]int, error) {
+func (s *charactersService) getCharacterIDsWithSmartFiltering(ctx context.Context, userID int, alphabetType models.AlphabetType, testTypeResultField string, groups []models.CharacterGroup, count int) ([]int, error) {
 	// Get character IDs without history first
-	characterIDs, err := s.repo.GetCharactersWithoutHistory(ctx, userID, alphabetType, count)
+	characterIDs, err := s.repo.GetCharactersWithoutHistory(ctx, userID, alphabetType, groups, count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get characters without history: %w", err)
 	}
@@ -304,7 +602,7 @@ func (s *charactersService) getCharacterIDsWithSmartFiltering(ctx context.Contex
 	// If not enough, get more from lowest results
 	if len(characterIDs) < count {
 		remainingCount := count - len(characterIDs)
-		lowestResultIDs, err := s.repo.GetCharactersWithLowestResults(ctx, userID, alphabetType, testTypeResultField, remainingCount)
+		lowestResultIDs, err := s.repo.GetCharactersWithLowestResults(ctx, userID, alphabetType, testTypeResultField, groups, remainingCount)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get characters with lowest results: %w", err)
 		}
@@ -312,3 +610,36 @@ func (s *charactersService) getCharacterIDsWithSmartFiltering(ctx context.Contex
 	}
 	return characterIDs, nil
 }
+
+// createTestSession stores a new test session for the user
+//
+// Expired sessions of the user are removed beforehand, so the table doesn't grow with abandoned tests.
+func (s *charactersService) createTestSession(ctx context.Context, userID int, alphabetType, testType string, items []models.TestSessionItem) (*models.TestSession, error) {
+	now := time.Now().UTC()
+	if err := s.sessionRepo.DeleteExpiredByUserID(ctx, userID, now); err != nil {
+		return nil, err
+	}
+
+	session := &models.TestSession{
+		ID:           uuid.NewString(),
+		UserID:       userID,
+		AlphabetType: alphabetType,
+		TestType:     testType,
+		Items:        items,
+		ExpiresAt:    now.Add(testSessionTTL).Truncate(time.Second),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// shuffleOptions returns correct and wrong options in random order
+func shuffleOptions(correct string, wrong []string) []string {
+	options := append([]string{correct}, wrong...)
+	rand.Shuffle(len(options), func(i, j int) {
+		options[i], options[j] = options[j], options[i]
+	})
+	return options
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/anki"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// DictionaryExportRepository is the interface that wraps methods for reading dictionary history for export
+type DictionaryExportRepository interface {
+	// GetExportEntries retrieves all words reviewed by a user together with their review state
+	//
+	// "userId" parameter is used to identify the user.
+	// "languages" parameter is the locale fallback chain, translations are taken in its first language which has them.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetExportEntries(ctx context.Context, userId int, languages []string) ([]models.DictionaryExportEntry, error)
+}
+
+// Name of the exported deck, Anki identifies the deck and its note type by it on repeated imports
+const exportDeckName = "JapaneseStudent Vocabulary"
+
+// exportAudioWorkers is the number of audio files downloaded from the media-service at the same time
+const exportAudioWorkers = 8
+
+// Fields of the note type of the exported deck
+var (
+	exportTextFields  = []string{"Word", "Reading", "Meaning", "Example", "ExampleMeaning"}
+	exportAudioFields = []string{"WordAudio", "ExampleAudio"}
+)
+
+// Card templates of the exported deck
+const (
+	exportFrontTemplate = `<div class="word">{{Word}}</div>`
+	exportBackTemplate  = `{{FrontSide}}<hr id="answer">{{Reading}}<br>{{Meaning}}<br><br>{{Example}}<br>{{ExampleMeaning}}`
+	exportAudioTemplate = `{{WordAudio}}{{ExampleAudio}}`
+)
+
+// dictionaryExportService implements DictionaryExportService
+type dictionaryExportService struct {
+	exportRepo   DictionaryExportRepository
+	mediaBaseURL string
+	apiKey       string
+	locale       LocaleConfig
+}
+
+// NewDictionaryExportService creates a new dictionary export service
+func NewDictionaryExportService(exportRepo DictionaryExportRepository, mediaBaseURL, apiKey string, locale LocaleConfig) *dictionaryExportService {
+	return &dictionaryExportService{
+		exportRepo:   exportRepo,
+		mediaBaseURL: mediaBaseURL,
+		apiKey:       apiKey,
+		locale:       locale,
+	}
+}
+
+// ExportDictionary builds a deck of all words reviewed by the user and returns the file content with its name
+//
+// For successful results:
+//
+// - format must be "apkg" or "tsv"
+//
+// - locale must be a valid language code, such as "en" or "pt-br"
+//
+// Translations missing in the locale are taken from its fallbacks.
+// Anki packages contain the word and example audio downloaded from the media-service and keep the review state
+// of every word: due day, interval, ease factor, repetitions and lapses. Audio which cannot be downloaded is left out.
+// TSV files contain only the text of the words.
+func (s *dictionaryExportService) ExportDictionary(ctx context.Context, userId int, format, locale string) ([]byte, string, error) {
+	exportFormat := models.DictionaryExportFormat(format)
+	if exportFormat != models.DictionaryExportFormatAPKG && exportFormat != models.DictionaryExportFormatTSV {
+		return nil, "", fmt.Errorf("invalid format: %s, must be 'apkg' or 'tsv'", format)
+	}
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, err := s.exportRepo.GetExportEntries(ctx, userId, s.locale.Chain(locale))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get dictionary export entries: %w", err)
+	}
+
+	deck := &anki.Deck{
+		Name:   exportDeckName,
+		Fields: exportTextFields,
+		Front:  exportFrontTemplate,
+		Back:   exportBackTemplate,
+		Notes:  make([]anki.DeckNote, len(entries)),
+	}
+	for i, entry := range entries {
+		deck.Notes[i] = anki.DeckNote{
+			GUID: "japanese-student-word-" + strconv.Itoa(entry.Word.ID),
+			Fields: []string{
+				entry.Word.Word,
+				entry.Word.PhoneticClues,
+				entry.Word.Translation,
+				stripRuby(entry.Word.Example),
+				entry.Word.ExampleTranslation,
+			},
+		}
+	}
+	filename := "japanese-student-vocabulary." + string(exportFormat)
+
+	if exportFormat == models.DictionaryExportFormatTSV {
+		data, err := anki.WriteText(deck)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to write deck: %w", err)
+		}
+		return data, filename, nil
+	}
+
+	deck.Fields = append(append([]string{}, exportTextFields...), exportAudioFields...)
+	deck.Back += exportAudioTemplate
+	deck.Media = s.downloadAudio(ctx, entries)
+	for i, entry := range entries {
+		wordAudio := extractFileIDFromURL(entry.Word.WordAudio)
+		exampleAudio := extractFileIDFromURL(entry.Word.WordExampleAudio)
+		deck.Notes[i].Fields = append(deck.Notes[i].Fields, soundReference(deck.Media, wordAudio), soundReference(deck.Media, exampleAudio))
+		deck.Notes[i].Schedule = &anki.Schedule{
+			Due:         entry.History.NextAppearance,
+			Interval:    entry.History.IntervalDays,
+			EaseFactor:  entry.History.EaseFactor,
+			Repetitions: entry.History.Repetitions,
+			Lapses:      entry.History.Lapses,
+		}
+	}
+
+	data, err := anki.WritePackage(deck, time.Now())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to write deck: %w", err)
+	}
+	return data, filename, nil
+}
+
+// soundReference returns a sound reference to a downloaded audio file or an empty value if it is missing
+func soundReference(media map[string][]byte, fileID string) string {
+	if _, ok := media[fileID]; !ok {
+		return ""
+	}
+	return anki.SoundReference(fileID)
+}
+
+// downloadAudio concurrently downloads word and example audio of the entries from the media-service
+//
+// The result maps file IDs to file contents, files which cannot be downloaded are left out.
+func (s *dictionaryExportService) downloadAudio(ctx context.Context, entries []models.DictionaryExportEntry) map[string][]byte {
+	media := make(map[string][]byte)
+	if s.mediaBaseURL == "" || s.apiKey == "" {
+		return media
+	}
+
+	// Media types of the files to download by their IDs
+	files := make(map[string]string)
+	for _, entry := range entries {
+		if fileID := extractFileIDFromURL(entry.Word.WordAudio); fileID != "" {
+			files[fileID] = "word"
+		}
+		if fileID := extractFileIDFromURL(entry.Word.WordExampleAudio); fileID != "" {
+			files[fileID] = "word_example"
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, exportAudioWorkers)
+	for fileID, mediaType := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			content, err := downloadFileFromMediaService(ctx, s.mediaBaseURL, s.apiKey, mediaType, fileID)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			media[fileID] = content
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return media
+}
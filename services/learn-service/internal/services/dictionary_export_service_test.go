@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/anki"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDictionaryExportRepository is a mock implementation of DictionaryExportRepository
+type mockDictionaryExportRepository struct {
+	entries   []models.DictionaryExportEntry
+	err       error
+	languages []string
+}
+
+func (m *mockDictionaryExportRepository) GetExportEntries(ctx context.Context, userId int, languages []string) ([]models.DictionaryExportEntry, error) {
+	m.languages = languages
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.entries, nil
+}
+
+// testExportEntries returns two reviewed words, the first one has word and example audio
+func testExportEntries(mediaURL string) []models.DictionaryExportEntry {
+	due := time.Now().AddDate(0, 0, 3)
+	return []models.DictionaryExportEntry{
+		{
+			Word: models.WordResponse{
+				ID: 1, Word: "水", PhoneticClues: "みず", Translation: "water", Example: "水を飲む", ExampleTranslation: "to drink water",
+				WordAudio: mediaURL + "/media/mizu.mp3", WordExampleAudio: mediaURL + "/media/missing.mp3",
+			},
+			History: models.DictionaryHistory{WordID: 1, EaseFactor: 2.36, IntervalDays: 6, Repetitions: 2, Lapses: 1, NextAppearance: due},
+		},
+		{
+			Word:    models.WordResponse{ID: 2, Word: "火", PhoneticClues: "ひ", Translation: "fire"},
+			History: models.DictionaryHistory{WordID: 2, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, NextAppearance: due},
+		},
+	}
+}
+
+func TestDictionaryExportService_ExportDictionary_Validation(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		locale        string
+		repo          *mockDictionaryExportRepository
+		errorContains string
+	}{
+		{
+			name:          "invalid format",
+			format:        "csv",
+			locale:        "en",
+			repo:          &mockDictionaryExportRepository{},
+			errorContains: "invalid format: csv",
+		},
+		{
+			name:          "invalid locale",
+			format:        "apkg",
+			locale:        "french",
+			repo:          &mockDictionaryExportRepository{},
+			errorContains: "invalid locale: french",
+		},
+		{
+			name:          "repository error",
+			format:        "tsv",
+			locale:        "en",
+			repo:          &mockDictionaryExportRepository{err: errors.New("database error")},
+			errorContains: "failed to get dictionary export entries",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDictionaryExportService(tt.repo, "", "", DefaultLocaleConfig())
+
+			data, filename, err := svc.ExportDictionary(context.Background(), 1, tt.format, tt.locale)
+
+			assert.Nil(t, data)
+			assert.Empty(t, filename)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestDictionaryExportService_ExportDictionary(t *testing.T) {
+	t.Run("tsv in locale", func(t *testing.T) {
+		repo := &mockDictionaryExportRepository{entries: testExportEntries("http://media")}
+		svc := NewDictionaryExportService(repo, "", "", DefaultLocaleConfig())
+
+		data, filename, err := svc.ExportDictionary(context.Background(), 1, "tsv", "de")
+		require.NoError(t, err)
+		assert.Equal(t, "japanese-student-vocabulary.tsv", filename)
+		assert.Equal(t, []string{"de", "en"}, repo.languages)
+
+		notes, err := anki.ReadText(data, '\t')
+		require.NoError(t, err)
+		require.Len(t, notes, 2)
+		assert.Equal(t, map[string]string{
+			"Word": "水", "Reading": "みず", "Meaning": "water", "Example": "水を飲む", "ExampleMeaning": "to drink water",
+		}, notes[0].Fields)
+	})
+
+	t.Run("apkg with audio from media service", func(t *testing.T) {
+		// Files are downloaded concurrently
+		var mu sync.Mutex
+		var apiKeys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			apiKeys = append(apiKeys, r.Header.Get("X-API-Key"))
+			mu.Unlock()
+			if r.URL.Path == "/media/word/mizu.mp3" {
+				w.Write([]byte("ID3 mizu"))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		repo := &mockDictionaryExportRepository{entries: testExportEntries(server.URL)}
+		svc := NewDictionaryExportService(repo, server.URL, "test-key", DefaultLocaleConfig())
+
+		data, filename, err := svc.ExportDictionary(context.Background(), 1, "apkg", "en")
+		require.NoError(t, err)
+		assert.Equal(t, "japanese-student-vocabulary.apkg", filename)
+		assert.Equal(t, []string{"en"}, repo.languages)
+		assert.Equal(t, []string{"test-key", "test-key"}, apiKeys)
+
+		pkg, err := anki.ReadPackage(data)
+		require.NoError(t, err)
+		require.Len(t, pkg.Notes, 2)
+		assert.Equal(t, "[sound:mizu.mp3]", pkg.Notes[0].Fields["WordAudio"])
+		assert.Empty(t, pkg.Notes[0].Fields["ExampleAudio"], "audio which cannot be downloaded is left out")
+		assert.Equal(t, "fire", pkg.Notes[1].Fields["Meaning"])
+
+		rc, err := pkg.OpenMedia("mizu.mp3")
+		require.NoError(t, err)
+		rc.Close()
+	})
+
+	t.Run("apkg without media service", func(t *testing.T) {
+		repo := &mockDictionaryExportRepository{entries: testExportEntries("http://media")}
+		svc := NewDictionaryExportService(repo, "", "", LocaleConfig{Default: "en", Fallbacks: map[string][]string{"uk": {"ru"}}})
+
+		data, _, err := svc.ExportDictionary(context.Background(), 1, "apkg", "UK")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"uk", "ru", "en"}, repo.languages)
+
+		pkg, err := anki.ReadPackage(data)
+		require.NoError(t, err)
+		require.Len(t, pkg.Notes, 2)
+		assert.Empty(t, pkg.Notes[0].Fields["WordAudio"])
+		assert.Empty(t, pkg.Notes[0].Fields["ExampleAudio"])
+	})
+}
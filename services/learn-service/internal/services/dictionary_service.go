@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
 )
@@ -13,27 +17,92 @@ type WordRepository interface {
 	// GetByIDs retrieves words by their IDs
 	//
 	// "wordIds" parameter is used to filter words by their IDs.
-	// "translationField" parameter is used to specify the field to use for translation.
-	// "exampleTranslationField" parameter is used to specify the field to use for example translation.
+	// "languages" parameter is the locale fallback chain, translations are taken in its first language which has them.
 	//
 	// If wrong parameters will be used or some error will occur during data retrieve, the error will be returned together with "nil" value.
-	GetByIDs(ctx context.Context, wordIds []int, translationField, exampleTranslationField string) ([]models.WordResponse, error)
+	GetByIDs(ctx context.Context, wordIds []int, languages []string) ([]models.WordResponse, error)
 	// GetExcludingIDs retrieves words not in the provided ID list
 	//
 	// "userId" parameter is used to identify the user.
 	// "excludeIds" parameter is used to filter words not in the provided ID list.
 	// "limit" parameter is used to specify the number of words to return.
-	// "translationField" parameter is used to specify the field to use for translation.
-	// "exampleTranslationField" parameter is used to specify the field to use for example translation.
+	// "filter" parameter is used to restrict words to chosen JLPT levels and topic tags.
 	//
 	// Please reference GetByIDs method for more information about other parameters and error values.
-	GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, translationField, exampleTranslationField string) ([]models.WordResponse, error)
+	GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, filter models.WordFilter, languages []string) ([]models.WordResponse, error)
 	// ValidateWordIDs checks if all word IDs exist in the database
 	//
 	// "wordIds" parameter is used to validate if all word IDs exist in the database.
 	//
 	// Please reference GetByIDs method for more information about other parameters and error values.
 	ValidateWordIDs(ctx context.Context, wordIds []int) (bool, error)
+	// CountUnseen counts words the user has never reviewed
+	//
+	// "userId" parameter is used to identify the user.
+	//
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	CountUnseen(ctx context.Context, userId int) (int, error)
+	// Search finds words by kanji, reading or translation, best matches first
+	//
+	// "query" parameter is the searched text, its reading may be written in hiragana, katakana or romaji.
+	// "limit" parameter is used to specify the maximum number of words to return.
+	// Translations are searched in the languages of "languages" and returned in the first of them which has them.
+	//
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	Search(ctx context.Context, query string, limit int, languages []string) ([]models.WordSearchResult, error)
+	// GetQuizDistractors retrieves words which may be offered as wrong answers of quiz questions about the words
+	//
+	// "wordIds" parameter is used to identify the words of the quiz.
+	// "count" parameter is used to specify the maximum number of distractors of every word.
+	// Words of the same part of speech or sharing a topic tag come first.
+	// The result maps word IDs to their distractors.
+	//
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	GetQuizDistractors(ctx context.Context, wordIds []int, count int, languages []string) (map[int][]models.WordQuizDistractor, error)
+}
+
+// UserWordRepository is the interface that wraps methods for reviewing custom words of users
+type UserWordRepository interface {
+	// GetByIDs retrieves custom words of the user by their IDs
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordIds" parameter is used to filter custom words by their IDs.
+	// Custom words have no locale-specific translations, the translation written by the user is returned.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByIDs(ctx context.Context, userId int, wordIds []int) ([]models.WordResponse, error)
+	// GetUnseen retrieves custom words the user has never reviewed, oldest first
+	//
+	// "userId" parameter is used to identify the user.
+	// "limit" parameter is used to specify the number of words to return.
+	//
+	// Please reference GetByIDs method for more information about error values.
+	GetUnseen(ctx context.Context, userId int, limit int) ([]models.WordResponse, error)
+	// ValidateIDs checks if all custom word IDs exist and belong to the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordIds" parameter is used to validate the custom word IDs.
+	//
+	// If some error will occur during validation, the error will be returned together with "false" value.
+	ValidateIDs(ctx context.Context, userId int, wordIds []int) (bool, error)
+	// CountUnseen counts custom words the user has never reviewed
+	//
+	// "userId" parameter is used to identify the user.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with 0 value.
+	CountUnseen(ctx context.Context, userId int) (int, error)
+}
+
+// WordExampleRepository is the interface that wraps methods for WordExamples table data access during reviews
+type WordExampleRepository interface {
+	// GetLocalizedByWordIDs retrieves additional examples of words with translations in the locale
+	//
+	// "wordIds" parameter is used to identify the words.
+	// "languages" parameter is the locale fallback chain, translations are taken in its first language which has them.
+	// The result maps word IDs to their examples in creation order, words without additional examples are absent.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetLocalizedByWordIDs(ctx context.Context, wordIds []int, languages []string) (map[int][]models.LocalizedWordExample, error)
 }
 
 // DictionaryHistoryRepository is the interface that wraps methods for DictionaryHistory table data access
@@ -42,62 +111,278 @@ type DictionaryHistoryRepository interface {
 	//
 	// "userId" parameter is used to identify the user.
 	// "limit" parameter is used to specify the number of words to return.
+	// Leeches are not returned.
 	// Please reference GetByIDs method for more information about other parameters and error values.
 	GetOldWordIds(ctx context.Context, userId int, limit int) ([]int, error)
+	// GetOldUserWordIds retrieves custom word IDs from dictionary history where NextAppearance <= current day
+	//
+	// "userId" parameter is used to identify the user.
+	// "limit" parameter is used to specify the number of custom words to return.
+	// Leeches are not returned.
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	GetOldUserWordIds(ctx context.Context, userId int, limit int) ([]int, error)
+	// GetDueCounts counts dictionary history records of a user by the day they come due
+	//
+	// "userId" parameter is used to identify the user.
+	// "days" parameter is used to specify the number of days after today to count.
+	// The result maps days from today to the number of words, overdue words are counted at day 0.
+	// Suspended words are not counted.
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	GetDueCounts(ctx context.Context, userId int, days int) (map[int]int, error)
+	// GetByUserIDAndWordIDs retrieves dictionary history records for a user and set of word IDs
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordIds" parameter is used to identify the words.
+	// Words which have never been reviewed by the user have no records.
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	GetByUserIDAndWordIDs(ctx context.Context, userId int, wordIds []int) ([]models.DictionaryHistory, error)
+	// GetByUserIDAndUserWordIDs retrieves dictionary history records for a user and set of custom word IDs
+	//
+	// "userId" parameter is used to identify the user.
+	// "userWordIds" parameter is used to identify the custom words.
+	// Please reference GetByUserIDAndWordIDs method for more information about other parameters and error values.
+	GetByUserIDAndUserWordIDs(ctx context.Context, userId int, userWordIds []int) ([]models.DictionaryHistory, error)
 	// UpsertResults inserts or updates dictionary history records
 	//
 	// "userId" parameter is used to identify the user.
-	// "results" parameter is used to submit the word learning results.
+	// "histories" parameter contains review states of the words, the next appearance is computed from their intervals.
+	// Records of custom words have UserWordID set instead of WordID.
 	// Please reference GetByIDs method for more information about other parameters and error values.
-	UpsertResults(ctx context.Context, userId int, results []models.WordResult) error
+	UpsertResults(ctx context.Context, userId int, histories []models.DictionaryHistory) error
+	// GetLeeches retrieves dictionary history records of words flagged as leeches for a user, most lapses first
+	//
+	// "userId" parameter is used to identify the user.
+	// Please reference GetByIDs method for more information about other parameters and error values.
+	GetLeeches(ctx context.Context, userId int) ([]models.DictionaryHistory, error)
+	// UnsuspendLeech removes the leech flag of a word keeping its review state
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the word, it is a custom word ID if "isCustom" is true.
+	// Returns false if the word is not a leech of the user.
+	// If some error occurs during data update, the error will be returned.
+	UnsuspendLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error)
+	// ResetLeech removes the leech flag of a word and resets its lapses and SM-2 state, the word comes due today
+	//
+	// Please reference UnsuspendLeech method for more information about parameters and error values.
+	ResetLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error)
+}
+
+// WordQuizSessionRepository is the interface that wraps methods for WordQuizSessions table data access
+type WordQuizSessionRepository interface {
+	// Create stores a new word quiz session
+	//
+	// "session" parameter is used to create the session record, its ID must be already generated.
+	// If some error occurs during data insertion, the error will be returned.
+	Create(ctx context.Context, session *models.WordQuizSession) error
+	// GetByID retrieves a word quiz session by its ID
+	//
+	// "id" parameter is used to identify the session.
+	// If the session is not found or some error occurs during data retrieval, the error will be returned together with "nil" value.
+	GetByID(ctx context.Context, id string) (*models.WordQuizSession, error)
+	// MarkSubmitted marks a word quiz session as submitted
+	//
+	// "id" parameter is used to identify the session.
+	// "submittedAt" parameter is used to set the submission time.
+	// Returns false if the session has already been submitted.
+	// If some error occurs during data update, the error will be returned.
+	MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error)
+	// ClearSubmitted marks a submitted word quiz session as not submitted again
+	//
+	// "id" parameter is used to identify the session.
+	// If some error occurs during data update, the error will be returned.
+	ClearSubmitted(ctx context.Context, id string) error
+	// DeleteExpiredByUserID deletes all word quiz sessions of a user that expired before the given time
+	//
+	// "userID" parameter is used to identify the user.
+	// "before" parameter is used to identify expired sessions.
+	// If some error occurs during data deletion, the error will be returned.
+	DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error
 }
 
 // dictionaryService implements DictionaryService
 type dictionaryService struct {
 	wordRepo              WordRepository
+	wordExampleRepo       WordExampleRepository
+	userWordRepo          UserWordRepository
 	dictionaryHistoryRepo DictionaryHistoryRepository
+	quizSessionRepo       WordQuizSessionRepository
+	kanjiRepo             KanjiLevelRepository
+	leech                 LeechConfig
+	locale                LocaleConfig
 }
 
 // NewDictionaryService creates a new dictionary service
 func NewDictionaryService(
 	wordRepo WordRepository,
+	wordExampleRepo WordExampleRepository,
+	userWordRepo UserWordRepository,
 	dictionaryHistoryRepo DictionaryHistoryRepository,
+	quizSessionRepo WordQuizSessionRepository,
+	kanjiRepo KanjiLevelRepository,
+	leech LeechConfig,
+	locale LocaleConfig,
 ) *dictionaryService {
 	return &dictionaryService{
 		wordRepo:              wordRepo,
+		wordExampleRepo:       wordExampleRepo,
+		userWordRepo:          userWordRepo,
 		dictionaryHistoryRepo: dictionaryHistoryRepo,
+		quizSessionRepo:       quizSessionRepo,
+		kanjiRepo:             kanjiRepo,
+		leech:                 leech,
+		locale:                locale,
 	}
 }
 
 // GetWordList retrieves a mixed list of old and new words for the user
 //
-// Please reference validateParameters method for more information about parameters and error values.
-func (s *dictionaryService) GetWordList(ctx context.Context, userId, newCount, oldCount int, locale string) ([]models.WordResponse, error) {
+// Custom words of the user are mixed into the list: due custom words take places of old words
+// and never reviewed custom words take places of new words before dictionary words do.
+// Dictionary words with several example sentences show the next sentence on every review.
+// Translations missing in the locale are taken from its fallbacks, please reference LocaleConfig for more information.
+//
+// "jlptLevels" and "tags" parameters are comma-separated lists restricting new dictionary words,
+// empty parameters mean no restriction. Old words are always reviewed.
+//
+// "furigana" and "furiganaLevel" parameters are the user's furigana settings, examples with ruby markup
+// are returned with readings shown according to them.
+//
+// Please reference validateParameters method, parseWordFilter and parseFuriganaSettings functions for more information about parameters and error values.
+func (s *dictionaryService) GetWordList(ctx context.Context, userId, newCount, oldCount int, locale, jlptLevels, tags, furigana string, furiganaLevel int) ([]models.WordResponse, error) {
 	if err := s.validateParameters(newCount, oldCount, locale); err != nil {
 		return nil, err
 	}
+	filter, err := parseWordFilter(jlptLevels, tags)
+	if err != nil {
+		return nil, err
+	}
+	furiganaSettings, err := parseFuriganaSettings(furigana, furiganaLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get old custom word IDs first, dictionary words fill the rest of old words
+	oldUserWordIds, err := s.dictionaryHistoryRepo.GetOldUserWordIds(ctx, userId, oldCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get old custom word IDs: %w", err)
+	}
+	var oldWordIds []int
+	if len(oldUserWordIds) < oldCount {
+		oldWordIds, err = s.dictionaryHistoryRepo.GetOldWordIds(ctx, userId, oldCount-len(oldUserWordIds))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get old word IDs: %w", err)
+		}
+	}
+
+	// Get never reviewed custom words, dictionary words fill the rest of new words
+	newUserWords, err := s.userWordRepo.GetUnseen(ctx, userId, newCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new custom words: %w", err)
+	}
+
+	languages := s.locale.Chain(normalizeLocale(locale))
+	words, err := s.getShuffledWordList(ctx, userId, oldWordIds, oldUserWordIds, newUserWords, newCount-len(newUserWords), filter, languages)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.annotateExamples(ctx, words, furiganaSettings); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// parseWordFilter parses comma-separated lists of JLPT levels and topic tags
+//
+// For successful results:
+//
+// - JLPT levels must be between 1 and 5
+//
+// - tags follow the same rules as tags of words
+//
+// Duplicates are ignored.
+func parseWordFilter(jlptLevelsParam, tagsParam string) (models.WordFilter, error) {
+	var filter models.WordFilter
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(jlptLevelsParam, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		level, err := strconv.Atoi(part)
+		if err != nil || level < 1 || level > 5 {
+			return models.WordFilter{}, fmt.Errorf("invalid jlpt level: must be between 1 and 5")
+		}
+		if !seen[level] {
+			seen[level] = true
+			filter.JLPTLevels = append(filter.JLPTLevels, level)
+		}
+	}
 
-	// Get old word IDs from dictionary history
-	oldWordIds, err := s.dictionaryHistoryRepo.GetOldWordIds(ctx, userId, oldCount)
+	tags, err := normalizeWordTags(strings.Split(tagsParam, ","))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get old word IDs: %w", err)
+		return models.WordFilter{}, err
+	}
+	if len(tags) > 0 {
+		filter.Tags = tags
 	}
+	return filter, nil
+}
+
+// Limits of word search parameters
+const (
+	maxSearchQueryLength = 100
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 50
+)
 
-	// Set locale-specific translations
-	var translationField, exampleTranslationField string
-	switch locale {
-	case "ru":
-		translationField = "russian_translation"
-		exampleTranslationField = "example_russian_translation"
-	case "de":
-		translationField = "english_translation"
-		exampleTranslationField = "example_english_translation"
-	default: // "en" or default
-		translationField = "german_translation"
-		exampleTranslationField = "example_german_translation"
+// SearchWords finds dictionary words by kanji, by reading in kana or romaji, or by translation in the locale
+//
+// Exact matches come first, then prefix matches, then translations containing the query as whole words.
+// Please reference parseSearchParameters function for more information about validation rules.
+func (s *dictionaryService) SearchWords(ctx context.Context, query, locale string, limit int) ([]models.WordSearchResult, error) {
+	query, limit, languages, err := parseSearchParameters(query, locale, limit, s.locale)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.getShuffledWordList(ctx, userId, oldWordIds, newCount, translationField, exampleTranslationField)
+	return s.wordRepo.Search(ctx, query, limit, languages)
+}
+
+// parseSearchParameters validates word search parameters and returns the trimmed query,
+// the limit and the language chain of the locale
+//
+// For successful results:
+//
+// - query must not be empty and must be at most 100 characters long
+//
+// - limit must be between 1 and 50, 0 means the default of 20
+//
+// - locale must be a valid language code, empty locale means the default locale
+func parseSearchParameters(query, locale string, limit int, localeConfig LocaleConfig) (string, int, []string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", 0, nil, fmt.Errorf("search query is required")
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return "", 0, nil, fmt.Errorf("search query must be at most %d characters long", maxSearchQueryLength)
+	}
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return "", 0, nil, fmt.Errorf("invalid limit: must be between 1 and %d", maxSearchLimit)
+	}
+
+	if strings.TrimSpace(locale) == "" {
+		locale = localeConfig.Default
+	}
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	return query, limit, localeConfig.Chain(locale), nil
 }
 
 // validateParameters validates the parameters for the GetWordList method
@@ -106,7 +391,7 @@ func (s *dictionaryService) GetWordList(ctx context.Context, userId, newCount, o
 //
 // - newCount and oldCount must be between 10 and 40
 //
-// - locale must be "en", "ru", or "de"
+// - locale must be a valid language code, such as "en" or "pt-br"
 //
 // If wrong parameters will be used or some error will occur during validation, the error will be returned.
 func (s *dictionaryService) validateParameters(newCount, oldCount int, locale string) error {
@@ -129,11 +414,8 @@ func (s *dictionaryService) validateParameters(newCount, oldCount int, locale st
 
 	// Validate locale
 	go func() {
-		if locale != "en" && locale != "ru" && locale != "de" {
-			errChan <- fmt.Errorf("invalid locale: %s, must be 'en', 'ru', or 'de'", locale)
-			return
-		}
-		errChan <- nil
+		_, err := parseLocale(locale)
+		errChan <- err
 	}()
 
 	for range 3 {
@@ -146,11 +428,29 @@ func (s *dictionaryService) validateParameters(newCount, oldCount int, locale st
 }
 
 // getShuffledWordList concurrently gets a shuffled list of old and new words for the user
-func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int, oldWordIds []int, newCount int, translationField, exampleTranslationField string) ([]models.WordResponse, error) {
+//
+// "newUserWords" parameter contains already retrieved new custom words, they are mixed into the list as they are.
+// "newCount" parameter is the number of new dictionary words to add.
+// "filter" parameter restricts new dictionary words.
+func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int, oldWordIds, oldUserWordIds []int, newUserWords []models.WordResponse, newCount int, filter models.WordFilter, languages []string) ([]models.WordResponse, error) {
 	// Prepare for concurrent operations
-	var allWords []models.WordResponse
-	wordsChan := make(chan []models.WordResponse, 2)
-	wordsErrChan := make(chan error, 1)
+	allWords := append([]models.WordResponse{}, newUserWords...)
+	wordsChan := make(chan []models.WordResponse, 3)
+	wordsErrChan := make(chan error, 3)
+
+	// Get old custom words
+	go func() {
+		if len(oldUserWordIds) == 0 {
+			wordsChan <- []models.WordResponse{}
+			return
+		}
+		words, err := s.userWordRepo.GetByIDs(ctx, userId, oldUserWordIds)
+		if err != nil {
+			wordsErrChan <- err
+			return
+		}
+		wordsChan <- words
+	}()
 
 	// Get old words
 	go func() {
@@ -158,7 +458,7 @@ func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int,
 			wordsChan <- []models.WordResponse{}
 			return
 		}
-		words, err := s.wordRepo.GetByIDs(ctx, oldWordIds, translationField, exampleTranslationField)
+		words, err := s.wordRepo.GetByIDs(ctx, oldWordIds, languages)
 		if err != nil {
 			wordsErrChan <- err
 			return
@@ -168,7 +468,11 @@ func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int,
 
 	// Get new words (not in old word IDs list)
 	go func() {
-		words, err := s.wordRepo.GetExcludingIDs(ctx, userId, oldWordIds, newCount, translationField, exampleTranslationField)
+		if newCount <= 0 {
+			wordsChan <- []models.WordResponse{}
+			return
+		}
+		words, err := s.wordRepo.GetExcludingIDs(ctx, userId, oldWordIds, newCount, filter, languages)
 		if err != nil {
 			wordsErrChan <- err
 			return
@@ -177,7 +481,7 @@ func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int,
 	}()
 
 	// Combine and shuffle
-	for range 2 {
+	for range 3 {
 		select {
 		case words := <-wordsChan:
 			allWords = append(allWords, words...)
@@ -185,43 +489,248 @@ func (s *dictionaryService) getShuffledWordList(ctx context.Context, userId int,
 			return nil, err
 		}
 	}
+	if err := s.rotateExamples(ctx, userId, allWords, languages); err != nil {
+		return nil, err
+	}
 	rand.Shuffle(len(allWords), func(i, j int) {
 		allWords[i], allWords[j] = allWords[j], allWords[i]
 	})
 	return allWords, nil
 }
 
-// SubmitWordResults validates and upserts word learning results
+// rotateExamples replaces examples of dictionary words with the ones due for the current review
+//
+// Examples of a word are its primary example followed by additional examples in creation order.
+// The shown example is chosen by the number of reviews of the word, so it moves to the next one after every review.
+// Custom words and words without additional examples are left as they are.
+func (s *dictionaryService) rotateExamples(ctx context.Context, userId int, words []models.WordResponse, languages []string) error {
+	var wordIds []int
+	for _, word := range words {
+		if !word.IsCustom {
+			wordIds = append(wordIds, word.ID)
+		}
+	}
+	if len(wordIds) == 0 {
+		return nil
+	}
+
+	examples, err := s.wordExampleRepo.GetLocalizedByWordIDs(ctx, wordIds, languages)
+	if err != nil {
+		return fmt.Errorf("failed to get word examples: %w", err)
+	}
+	if len(examples) == 0 {
+		return nil
+	}
+
+	// Only words with additional examples need their review count
+	var rotatedIds []int
+	for _, id := range wordIds {
+		if _, ok := examples[id]; ok {
+			rotatedIds = append(rotatedIds, id)
+		}
+	}
+	histories, err := s.dictionaryHistoryRepo.GetByUserIDAndWordIDs(ctx, userId, rotatedIds)
+	if err != nil {
+		return fmt.Errorf("failed to get dictionary history: %w", err)
+	}
+	reviewCounts := make(map[int]int, len(histories))
+	for _, history := range histories {
+		reviewCounts[history.WordID] = history.ReviewCount
+	}
+
+	for i := range words {
+		word := &words[i]
+		additional, ok := examples[word.ID]
+		if word.IsCustom || !ok {
+			continue
+		}
+
+		pool := make([]models.LocalizedWordExample, 0, len(additional)+1)
+		if word.Example != "" {
+			pool = append(pool, models.LocalizedWordExample{
+				WordID:       word.ID,
+				Example:      word.Example,
+				Translation:  word.ExampleTranslation,
+				ExampleAudio: word.WordExampleAudio,
+			})
+		}
+		pool = append(pool, additional...)
+
+		example := pool[reviewCounts[word.ID]%len(pool)]
+		word.Example = example.Example
+		word.ExampleTranslation = example.Translation
+		word.WordExampleAudio = example.ExampleAudio
+		word.ExampleSource = example.Source
+	}
+	return nil
+}
+
+// annotateExamples sets ruby texts of word examples with ruby markup and removes the markup from plain examples
+//
+// Examples without valid markup are left as they are.
+func (s *dictionaryService) annotateExamples(ctx context.Context, words []models.WordResponse, furigana furiganaSettings) error {
+	examples := make([]string, len(words))
+	for i, word := range words {
+		if plain := stripRuby(word.Example); plain != word.Example {
+			examples[i] = word.Example
+			words[i].Example = plain
+		}
+	}
+	annotated, err := furigana.annotate(ctx, s.kanjiRepo, examples)
+	if err != nil {
+		return err
+	}
+	for i := range words {
+		words[i].ExampleRuby = annotated[i]
+	}
+	return nil
+}
+
+// SubmitWordResults validates word review grades and schedules the next appearance of the words
 //
 // For successful results:
 //
 // - All word IDs must exist in the Word table
 //
-// - Period values must be between 1 and 30
+// - All custom word IDs must exist and belong to the user
+//
+// - Grade values must be between 1 and 4
+//
+// - Every word may be graded only once, otherwise a single request could move the word months ahead
+//
+// The next appearance is computed by the SM-2 scheduler from the grade and the review history of the word.
+// Words forgotten too many times are flagged as leeches, please reference LeechConfig for more information.
 func (s *dictionaryService) SubmitWordResults(ctx context.Context, userId int, results []models.WordResult) error {
 	if len(results) == 0 {
 		return fmt.Errorf("results list cannot be empty")
 	}
 
 	// Extract word IDs for validation
-	wordIds := make([]int, len(results))
-	for i, result := range results {
-		wordIds[i] = result.WordID
-		// Validate period
-		if result.Period < 1 || result.Period > 30 {
-			return fmt.Errorf("period must be between 1 and 30, got: %d", result.Period)
+	var wordIds, userWordIds []int
+	seen := make(map[reviewKey]bool, len(results))
+	for _, result := range results {
+		// Validate grade
+		if result.Grade < models.ReviewGradeAgain || result.Grade > models.ReviewGradeEasy {
+			return fmt.Errorf("grade must be between 1 and 4, got: %d", result.Grade)
+		}
+		key := reviewKey{id: result.WordID, custom: result.IsCustom}
+		if seen[key] {
+			return fmt.Errorf("duplicate result for the same word")
+		}
+		seen[key] = true
+		if result.IsCustom {
+			userWordIds = append(userWordIds, result.WordID)
+		} else {
+			wordIds = append(wordIds, result.WordID)
 		}
 	}
 
 	// Validate all word IDs exist
-	valid, err := s.wordRepo.ValidateWordIDs(ctx, wordIds)
+	if len(wordIds) > 0 {
+		valid, err := s.wordRepo.ValidateWordIDs(ctx, wordIds)
+		if err != nil {
+			return fmt.Errorf("failed to validate word IDs: %w", err)
+		}
+		if !valid {
+			return fmt.Errorf("one or more word IDs do not exist")
+		}
+	}
+	if len(userWordIds) > 0 {
+		valid, err := s.userWordRepo.ValidateIDs(ctx, userId, userWordIds)
+		if err != nil {
+			return fmt.Errorf("failed to validate custom word IDs: %w", err)
+		}
+		if !valid {
+			return fmt.Errorf("one or more custom word IDs do not exist")
+		}
+	}
+
+	existing, err := s.dictionaryHistoryRepo.GetByUserIDAndWordIDs(ctx, userId, wordIds)
 	if err != nil {
-		return fmt.Errorf("failed to validate word IDs: %w", err)
+		return fmt.Errorf("failed to get dictionary history: %w", err)
+	}
+	existingCustom, err := s.dictionaryHistoryRepo.GetByUserIDAndUserWordIDs(ctx, userId, userWordIds)
+	if err != nil {
+		return fmt.Errorf("failed to get dictionary history: %w", err)
+	}
+	states := make(map[reviewKey]models.DictionaryHistory, len(existing)+len(existingCustom))
+	for _, history := range existing {
+		states[reviewKey{id: history.WordID}] = history
 	}
-	if !valid {
-		return fmt.Errorf("one or more word IDs do not exist")
+	for _, history := range existingCustom {
+		states[reviewKey{id: history.UserWordID, custom: true}] = history
+	}
+
+	// Schedule the words in the order of their submission
+	histories := make([]models.DictionaryHistory, len(results))
+	for i, result := range results {
+		key := reviewKey{id: result.WordID, custom: result.IsCustom}
+		state, ok := states[key]
+		if !ok {
+			state = models.DictionaryHistory{UserID: userId}
+			if key.custom {
+				state.UserWordID = key.id
+			} else {
+				state.WordID = key.id
+			}
+		}
+		lapses := state.Lapses
+		state = scheduleReview(state, result.Grade)
+		if state.Lapses > lapses {
+			state = s.leech.flag(state)
+		}
+		state.ReviewCount++
+		histories[i] = state
 	}
 
 	// Upsert results
-	return s.dictionaryHistoryRepo.UpsertResults(ctx, userId, results)
+	return s.dictionaryHistoryRepo.UpsertResults(ctx, userId, histories)
+}
+
+// reviewKey identifies a reviewed word, IDs of dictionary and custom words overlap
+type reviewKey struct {
+	id     int
+	custom bool
+}
+
+// maxForecastDays is the longest period the review forecast can cover
+const maxForecastDays = 90
+
+// GetReviewForecast retrieves the dictionary review workload of the user
+//
+// For successful results:
+//
+// - days must be between 1 and 90
+//
+// The forecast contains the number of words due today, including overdue ones,
+// the number of words coming due on each of the following days and the number of words the user has never reviewed.
+// Custom words of the user are counted together with dictionary words.
+func (s *dictionaryService) GetReviewForecast(ctx context.Context, userId int, days int) (*models.ReviewForecast, error) {
+	if days < 1 || days > maxForecastDays {
+		return nil, fmt.Errorf("days must be between 1 and %d", maxForecastDays)
+	}
+
+	counts, err := s.dictionaryHistoryRepo.GetDueCounts(ctx, userId, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due counts: %w", err)
+	}
+
+	newWords, err := s.wordRepo.CountUnseen(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unseen words: %w", err)
+	}
+	newUserWords, err := s.userWordRepo.CountUnseen(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unseen custom words: %w", err)
+	}
+
+	forecast := &models.ReviewForecast{
+		Backlog:  counts[0],
+		NewWords: newWords + newUserWords,
+		Days:     make([]models.ReviewForecastDay, days),
+	}
+	for i := range forecast.Days {
+		forecast.Days[i] = models.ReviewForecastDay{Day: i + 1, Count: counts[i+1]}
+	}
+	return forecast, nil
 }
@@ -3,67 +3,233 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockWordRepository is a mock implementation of WordRepository
 type mockWordRepository struct {
 	words       []models.WordResponse
+	newWords    []models.WordResponse // Returned by GetExcludingIDs instead of words if set
 	valid       bool
+	unseen      int
 	err         error
 	validateErr error
+	validated   []int
+	filter      models.WordFilter
+	languages   []string
+	found       []models.WordSearchResult
+	distractors map[int][]models.WordQuizDistractor
+	search      struct {
+		query     string
+		limit     int
+		languages []string
+	}
 }
 
-func (m *mockWordRepository) GetByIDs(ctx context.Context, wordIds []int, translationField, exampleTranslationField string) ([]models.WordResponse, error) {
+func (m *mockWordRepository) GetByIDs(ctx context.Context, wordIds []int, languages []string) ([]models.WordResponse, error) {
+	m.languages = languages
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.words, nil
 }
 
-func (m *mockWordRepository) GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, translationField, exampleTranslationField string) ([]models.WordResponse, error) {
+func (m *mockWordRepository) GetExcludingIDs(ctx context.Context, userId int, excludeIds []int, limit int, filter models.WordFilter, languages []string) ([]models.WordResponse, error) {
+	m.filter = filter
+	m.languages = languages
 	if m.err != nil {
 		return nil, m.err
 	}
+	if m.newWords != nil {
+		return m.newWords, nil
+	}
 	return m.words, nil
 }
 
 func (m *mockWordRepository) ValidateWordIDs(ctx context.Context, wordIds []int) (bool, error) {
+	m.validated = wordIds
 	if m.validateErr != nil {
 		return false, m.validateErr
 	}
 	return m.valid, nil
 }
 
+func (m *mockWordRepository) CountUnseen(ctx context.Context, userId int) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.unseen, nil
+}
+
+func (m *mockWordRepository) Search(ctx context.Context, query string, limit int, languages []string) ([]models.WordSearchResult, error) {
+	m.search.query, m.search.limit, m.search.languages = query, limit, languages
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.found, nil
+}
+
+func (m *mockWordRepository) GetQuizDistractors(ctx context.Context, wordIds []int, count int, languages []string) (map[int][]models.WordQuizDistractor, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.distractors, nil
+}
+
+// mockUserWordRepository is a mock implementation of UserWordRepository
+type mockUserWordRepository struct {
+	words       []models.WordResponse
+	unseenWords []models.WordResponse
+	valid       bool
+	unseen      int
+	err         error
+	validateErr error
+	unseenLimit int
+}
+
+func (m *mockUserWordRepository) GetByIDs(ctx context.Context, userId int, wordIds []int) ([]models.WordResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.words, nil
+}
+
+func (m *mockUserWordRepository) GetUnseen(ctx context.Context, userId int, limit int) ([]models.WordResponse, error) {
+	m.unseenLimit = limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.unseenWords, nil
+}
+
+func (m *mockUserWordRepository) ValidateIDs(ctx context.Context, userId int, wordIds []int) (bool, error) {
+	if m.validateErr != nil {
+		return false, m.validateErr
+	}
+	return m.valid, nil
+}
+
+func (m *mockUserWordRepository) CountUnseen(ctx context.Context, userId int) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.unseen, nil
+}
+
 // mockDictionaryHistoryRepository is a mock implementation of DictionaryHistoryRepository
 type mockDictionaryHistoryRepository struct {
-	oldWordIds []int
-	err        error
-	upsertErr  error
+	oldWordIds      []int
+	oldUserWordIds  []int
+	oldWordLimit    int
+	dueCounts       map[int]int
+	histories       []models.DictionaryHistory
+	customHistories []models.DictionaryHistory
+	err             error
+	historyErr      error
+	upsertErr       error
+	upserted        []models.DictionaryHistory
+	leeches         []models.DictionaryHistory
+	leechFound      bool
+	leechUpdate     struct {
+		wordId   int
+		isCustom bool
+	}
 }
 
 func (m *mockDictionaryHistoryRepository) GetOldWordIds(ctx context.Context, userId int, limit int) ([]int, error) {
+	m.oldWordLimit = limit
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.oldWordIds, nil
 }
 
-func (m *mockDictionaryHistoryRepository) UpsertResults(ctx context.Context, userId int, results []models.WordResult) error {
+func (m *mockDictionaryHistoryRepository) GetOldUserWordIds(ctx context.Context, userId int, limit int) ([]int, error) {
+	return m.oldUserWordIds, nil
+}
+
+func (m *mockDictionaryHistoryRepository) GetDueCounts(ctx context.Context, userId int, days int) (map[int]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.dueCounts, nil
+}
+
+func (m *mockDictionaryHistoryRepository) GetByUserIDAndWordIDs(ctx context.Context, userId int, wordIds []int) ([]models.DictionaryHistory, error) {
+	if m.historyErr != nil {
+		return nil, m.historyErr
+	}
+	return m.histories, nil
+}
+
+func (m *mockDictionaryHistoryRepository) GetByUserIDAndUserWordIDs(ctx context.Context, userId int, userWordIds []int) ([]models.DictionaryHistory, error) {
+	if m.historyErr != nil {
+		return nil, m.historyErr
+	}
+	return m.customHistories, nil
+}
+
+func (m *mockDictionaryHistoryRepository) UpsertResults(ctx context.Context, userId int, histories []models.DictionaryHistory) error {
+	m.upserted = histories
 	return m.upsertErr
 }
 
+func (m *mockDictionaryHistoryRepository) GetLeeches(ctx context.Context, userId int) ([]models.DictionaryHistory, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.leeches, nil
+}
+
+func (m *mockDictionaryHistoryRepository) UnsuspendLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error) {
+	m.leechUpdate.wordId, m.leechUpdate.isCustom = wordId, isCustom
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.leechFound, nil
+}
+
+func (m *mockDictionaryHistoryRepository) ResetLeech(ctx context.Context, userId, wordId int, isCustom bool) (bool, error) {
+	m.leechUpdate.wordId, m.leechUpdate.isCustom = wordId, isCustom
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.leechFound, nil
+}
+
+// mockWordExampleRepository is a mock implementation of WordExampleRepository
+type mockWordExampleRepository struct {
+	examples map[int][]models.LocalizedWordExample
+	err      error
+	wordIds  []int
+}
+
+func (m *mockWordExampleRepository) GetLocalizedByWordIDs(ctx context.Context, wordIds []int, languages []string) (map[int][]models.LocalizedWordExample, error) {
+	m.wordIds = wordIds
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.examples, nil
+}
+
 func TestNewDictionaryService(t *testing.T) {
 	wordRepo := &mockWordRepository{}
+	userWordRepo := &mockUserWordRepository{}
 	historyRepo := &mockDictionaryHistoryRepository{}
 
-	svc := NewDictionaryService(wordRepo, historyRepo)
+	exampleRepo := &mockWordExampleRepository{}
+
+	svc := NewDictionaryService(wordRepo, exampleRepo, userWordRepo, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
 
 	assert.NotNil(t, svc)
 	assert.Equal(t, wordRepo, svc.wordRepo)
+	assert.Equal(t, exampleRepo, svc.wordExampleRepo)
+	assert.Equal(t, userWordRepo, svc.userWordRepo)
 	assert.Equal(t, historyRepo, svc.dictionaryHistoryRepo)
 }
 
@@ -181,7 +347,7 @@ func TestDictionaryService_GetWordList(t *testing.T) {
 			userId:        1,
 			newCount:      20,
 			oldCount:      20,
-			locale:        "fr",
+			locale:        "french",
 			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
 			expectedError: true,
@@ -298,9 +464,9 @@ func TestDictionaryService_GetWordList(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewDictionaryService(tt.wordRepo, tt.historyRepo)
+			svc := NewDictionaryService(tt.wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, tt.historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
 
-			result, err := svc.GetWordList(context.Background(), tt.userId, tt.newCount, tt.oldCount, tt.locale)
+			result, err := svc.GetWordList(context.Background(), tt.userId, tt.newCount, tt.oldCount, tt.locale, "", "", "", 0)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -319,87 +485,107 @@ func TestDictionaryService_GetWordList(t *testing.T) {
 
 func TestDictionaryService_SubmitWordResults(t *testing.T) {
 	tests := []struct {
-		name          string
-		userId        int
-		results       []models.WordResult
-		wordRepo      *mockWordRepository
-		historyRepo   *mockDictionaryHistoryRepository
-		expectedError bool
-		errorContains string
+		name              string
+		userId            int
+		results           []models.WordResult
+		wordRepo          *mockWordRepository
+		historyRepo       *mockDictionaryHistoryRepository
+		expectedError     bool
+		errorContains     string
+		expectedValidated []int
+		expectedUpserted  []models.DictionaryHistory
 	}{
 		{
-			name:   "success with valid results",
+			name:   "success with new words",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 3},
-				{WordID: 2, Period: 7},
+				{WordID: 1, Grade: models.ReviewGradeGood},
+				{WordID: 2, Grade: models.ReviewGradeAgain},
 			},
 			wordRepo: &mockWordRepository{
 				valid: true,
 			},
-			historyRepo:   &mockDictionaryHistoryRepository{},
-			expectedError: false,
+			historyRepo:       &mockDictionaryHistoryRepository{},
+			expectedError:     false,
+			expectedValidated: []int{1, 2},
+			expectedUpserted: []models.DictionaryHistory{
+				{WordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, ReviewCount: 1},
+				{WordID: 2, UserID: 1, EaseFactor: 2.18, IntervalDays: 1, ReviewCount: 1},
+			},
 		},
 		{
-			name:          "empty results list",
-			userId:        1,
-			results:       []models.WordResult{},
-			wordRepo:      &mockWordRepository{},
-			historyRepo:   &mockDictionaryHistoryRepository{},
-			expectedError: true,
-			errorContains: "results list cannot be empty",
+			name:   "success with reviewed words",
+			userId: 1,
+			results: []models.WordResult{
+				{WordID: 1, Grade: models.ReviewGradeGood},
+				{WordID: 2, Grade: models.ReviewGradeAgain},
+			},
+			wordRepo: &mockWordRepository{
+				valid: true,
+			},
+			historyRepo: &mockDictionaryHistoryRepository{
+				histories: []models.DictionaryHistory{
+					{ID: 10, WordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2, ReviewCount: 2},
+					{ID: 11, WordID: 2, UserID: 1, EaseFactor: 2.5, IntervalDays: 15, Repetitions: 3, ReviewCount: 3},
+				},
+			},
+			expectedError: false,
+			expectedUpserted: []models.DictionaryHistory{
+				{ID: 10, WordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 15, Repetitions: 3, ReviewCount: 3},
+				{ID: 11, WordID: 2, UserID: 1, EaseFactor: 2.18, IntervalDays: 1, Lapses: 1, ReviewCount: 4},
+			},
 		},
 		{
-			name:   "invalid period - too low",
+			name:   "repeated word is rejected",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 0},
+				{WordID: 1, Grade: models.ReviewGradeGood},
+				{WordID: 2, Grade: models.ReviewGradeGood},
+				{WordID: 1, Grade: models.ReviewGradeGood},
+			},
+			wordRepo: &mockWordRepository{
+				valid: true,
 			},
-			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
 			expectedError: true,
-			errorContains: "period must be between 1 and 30",
+			errorContains: "duplicate result for the same word",
 		},
 		{
-			name:   "invalid period - too high",
-			userId: 1,
-			results: []models.WordResult{
-				{WordID: 1, Period: 31},
-			},
+			name:          "empty results list",
+			userId:        1,
+			results:       []models.WordResult{},
 			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
 			expectedError: true,
-			errorContains: "period must be between 1 and 30",
+			errorContains: "results list cannot be empty",
 		},
 		{
-			name:   "boundary values - period at minimum",
+			name:   "invalid grade - too low",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 1},
-			},
-			wordRepo: &mockWordRepository{
-				valid: true,
+				{WordID: 1, Grade: 0},
 			},
+			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
-			expectedError: false,
+			expectedError: true,
+			errorContains: "grade must be between 1 and 4",
 		},
 		{
-			name:   "boundary values - period at maximum",
+			name:   "invalid grade - too high",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 30},
-			},
-			wordRepo: &mockWordRepository{
-				valid: true,
+				{WordID: 1, Grade: 5},
 			},
+			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
-			expectedError: false,
+			expectedError: true,
+			errorContains: "grade must be between 1 and 4",
 		},
 		{
 			name:   "database error on validate word IDs",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 3},
+				{WordID: 1, Grade: models.ReviewGradeGood},
 			},
 			wordRepo: &mockWordRepository{
 				validateErr: errors.New("database error"),
@@ -412,8 +598,8 @@ func TestDictionaryService_SubmitWordResults(t *testing.T) {
 			name:   "one or more word IDs do not exist",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 3},
-				{WordID: 999, Period: 7},
+				{WordID: 1, Grade: models.ReviewGradeGood},
+				{WordID: 999, Grade: models.ReviewGradeEasy},
 			},
 			wordRepo: &mockWordRepository{
 				valid: false,
@@ -423,52 +609,51 @@ func TestDictionaryService_SubmitWordResults(t *testing.T) {
 			errorContains: "one or more word IDs do not exist",
 		},
 		{
-			name:   "database error on upsert",
+			name:   "database error on get history",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 3},
+				{WordID: 1, Grade: models.ReviewGradeGood},
 			},
 			wordRepo: &mockWordRepository{
 				valid: true,
 			},
 			historyRepo: &mockDictionaryHistoryRepository{
-				upsertErr: errors.New("upsert error"),
+				historyErr: errors.New("database error"),
 			},
 			expectedError: true,
+			errorContains: "failed to get dictionary history",
 		},
 		{
-			name:   "success with multiple results and different periods",
+			name:   "database error on upsert",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 1},
-				{WordID: 2, Period: 3},
-				{WordID: 3, Period: 7},
-				{WordID: 4, Period: 14},
-				{WordID: 5, Period: 30},
+				{WordID: 1, Grade: models.ReviewGradeGood},
 			},
 			wordRepo: &mockWordRepository{
 				valid: true,
 			},
-			historyRepo:   &mockDictionaryHistoryRepository{},
-			expectedError: false,
+			historyRepo: &mockDictionaryHistoryRepository{
+				upsertErr: errors.New("upsert error"),
+			},
+			expectedError: true,
 		},
 		{
-			name:   "mixed valid and invalid periods - should fail on first invalid",
+			name:   "mixed valid and invalid grades - should fail on first invalid",
 			userId: 1,
 			results: []models.WordResult{
-				{WordID: 1, Period: 3},
-				{WordID: 2, Period: 0}, // Invalid
+				{WordID: 1, Grade: models.ReviewGradeGood},
+				{WordID: 2, Grade: 0}, // Invalid
 			},
 			wordRepo:      &mockWordRepository{},
 			historyRepo:   &mockDictionaryHistoryRepository{},
 			expectedError: true,
-			errorContains: "period must be between 1 and 30",
+			errorContains: "grade must be between 1 and 4",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			svc := NewDictionaryService(tt.wordRepo, tt.historyRepo)
+			svc := NewDictionaryService(tt.wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, tt.historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
 
 			err := svc.SubmitWordResults(context.Background(), tt.userId, tt.results)
 
@@ -479,7 +664,547 @@ func TestDictionaryService_SubmitWordResults(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				if tt.expectedValidated != nil {
+					assert.Equal(t, tt.expectedValidated, tt.wordRepo.validated)
+				}
+				assert.Len(t, tt.historyRepo.upserted, len(tt.expectedUpserted))
+				for i, expected := range tt.expectedUpserted {
+					actual := tt.historyRepo.upserted[i]
+					assert.Equal(t, expected.WordID, actual.WordID)
+					assert.Equal(t, expected.UserID, actual.UserID)
+					assert.InDelta(t, expected.EaseFactor, actual.EaseFactor, 1e-9)
+					assert.Equal(t, expected.IntervalDays, actual.IntervalDays)
+					assert.Equal(t, expected.Repetitions, actual.Repetitions)
+					assert.Equal(t, expected.Lapses, actual.Lapses)
+					assert.Equal(t, expected.ReviewCount, actual.ReviewCount)
+				}
 			}
 		})
 	}
 }
+
+func TestDictionaryService_GetReviewForecast(t *testing.T) {
+	tests := []struct {
+		name          string
+		days          int
+		wordRepo      *mockWordRepository
+		historyRepo   *mockDictionaryHistoryRepository
+		expectedError bool
+		errorContains string
+		expected      *models.ReviewForecast
+	}{
+		{
+			name:     "success",
+			days:     3,
+			wordRepo: &mockWordRepository{unseen: 40},
+			historyRepo: &mockDictionaryHistoryRepository{
+				dueCounts: map[int]int{0: 12, 1: 3, 3: 5},
+			},
+			expectedError: false,
+			expected: &models.ReviewForecast{
+				Backlog:  12,
+				NewWords: 40,
+				Days: []models.ReviewForecastDay{
+					{Day: 1, Count: 3},
+					{Day: 2, Count: 0},
+					{Day: 3, Count: 5},
+				},
+			},
+		},
+		{
+			name:          "success with no history",
+			days:          1,
+			wordRepo:      &mockWordRepository{unseen: 10},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			expectedError: false,
+			expected: &models.ReviewForecast{
+				NewWords: 10,
+				Days:     []models.ReviewForecastDay{{Day: 1, Count: 0}},
+			},
+		},
+		{
+			name:          "days too low",
+			days:          0,
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			expectedError: true,
+			errorContains: "days must be between 1 and 90",
+		},
+		{
+			name:          "days too high",
+			days:          91,
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			expectedError: true,
+			errorContains: "days must be between 1 and 90",
+		},
+		{
+			name:          "database error on due counts",
+			days:          7,
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{err: errors.New("database error")},
+			expectedError: true,
+			errorContains: "failed to get due counts",
+		},
+		{
+			name:          "database error on unseen words",
+			days:          7,
+			wordRepo:      &mockWordRepository{err: errors.New("database error")},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			expectedError: true,
+			errorContains: "failed to count unseen words",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDictionaryService(tt.wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, tt.historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			result, err := svc.GetReviewForecast(context.Background(), 1, tt.days)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errorContains != "" {
+					assert.Contains(t, err.Error(), tt.errorContains)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDictionaryService_GetWordList_CustomWords(t *testing.T) {
+	t.Run("custom words take places before dictionary words", func(t *testing.T) {
+		wordRepo := &mockWordRepository{
+			words: []models.WordResponse{{ID: 1, Word: "水", Translation: "water"}},
+		}
+		userWordRepo := &mockUserWordRepository{
+			words:       []models.WordResponse{{ID: 1, Word: "推し", Translation: "favourite", IsCustom: true}},
+			unseenWords: []models.WordResponse{{ID: 2, Word: "残業", Translation: "overtime", IsCustom: true}},
+		}
+		historyRepo := &mockDictionaryHistoryRepository{
+			oldUserWordIds: []int{1},
+			oldWordIds:     []int{1},
+		}
+		svc := NewDictionaryService(wordRepo, &mockWordExampleRepository{}, userWordRepo, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "", 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 10, userWordRepo.unseenLimit)
+		assert.Equal(t, 9, historyRepo.oldWordLimit, "due custom words reduce the number of old dictionary words")
+		assert.Len(t, result, 4)
+		custom := 0
+		for _, word := range result {
+			if word.IsCustom {
+				custom++
+			}
+		}
+		assert.Equal(t, 2, custom)
+	})
+
+	t.Run("database error on get new custom words", func(t *testing.T) {
+		svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{err: errors.New("database error")}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "", 0)
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "failed to get new custom words")
+	})
+}
+
+func TestDictionaryService_GetWordList_ExampleRotation(t *testing.T) {
+	primary := models.WordResponse{ID: 1, Word: "水", Translation: "water", Example: "水を飲む", ExampleTranslation: "drink water",
+		WordExampleAudio: "http://media/api/v6/media/a.mp3"}
+	additional := map[int][]models.LocalizedWordExample{
+		1: {
+			{WordID: 1, Example: "水が冷たい", Translation: "The water is cold", Source: "NHK Easy"},
+			{WordID: 1, Example: "水をください", Translation: "Water, please", ExampleAudio: "http://media/api/v6/media/c.mp3"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		word      models.WordResponse
+		examples  map[int][]models.LocalizedWordExample
+		histories []models.DictionaryHistory
+		expected  models.WordResponse
+	}{
+		{
+			name:     "never reviewed word shows its primary example",
+			word:     primary,
+			examples: additional,
+			expected: primary,
+		},
+		{
+			name:      "reviewed word shows the next example",
+			word:      primary,
+			examples:  additional,
+			histories: []models.DictionaryHistory{{WordID: 1, ReviewCount: 1}},
+			expected: models.WordResponse{ID: 1, Word: "水", Translation: "water", Example: "水が冷たい", ExampleTranslation: "The water is cold",
+				ExampleSource: "NHK Easy"},
+		},
+		{
+			name:      "examples start over after the last one",
+			word:      primary,
+			examples:  additional,
+			histories: []models.DictionaryHistory{{WordID: 1, ReviewCount: 5}},
+			expected: models.WordResponse{ID: 1, Word: "水", Translation: "water", Example: "水をください", ExampleTranslation: "Water, please",
+				WordExampleAudio: "http://media/api/v6/media/c.mp3"},
+		},
+		{
+			name:      "word without primary example rotates additional examples only",
+			word:      models.WordResponse{ID: 1, Word: "水", Translation: "water"},
+			examples:  additional,
+			histories: []models.DictionaryHistory{{WordID: 1, ReviewCount: 3}},
+			expected: models.WordResponse{ID: 1, Word: "水", Translation: "water", Example: "水をください", ExampleTranslation: "Water, please",
+				WordExampleAudio: "http://media/api/v6/media/c.mp3"},
+		},
+		{
+			name:      "word without additional examples is left as it is",
+			word:      primary,
+			examples:  map[int][]models.LocalizedWordExample{},
+			histories: []models.DictionaryHistory{{WordID: 1, ReviewCount: 1}},
+			expected:  primary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordRepo := &mockWordRepository{words: []models.WordResponse{tt.word}}
+			exampleRepo := &mockWordExampleRepository{examples: tt.examples}
+			historyRepo := &mockDictionaryHistoryRepository{oldWordIds: []int{1}, histories: tt.histories}
+			svc := NewDictionaryService(wordRepo, exampleRepo, &mockUserWordRepository{}, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "", 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1, 1}, exampleRepo.wordIds, "old and new words are both rotated")
+			assert.Len(t, result, 2)
+			for _, word := range result {
+				assert.Equal(t, tt.expected, word)
+			}
+		})
+	}
+
+	t.Run("custom words are not rotated", func(t *testing.T) {
+		userWordRepo := &mockUserWordRepository{
+			unseenWords: []models.WordResponse{{ID: 1, Word: "推し", Example: "推しが尊い", IsCustom: true}},
+		}
+		exampleRepo := &mockWordExampleRepository{examples: additional}
+		svc := NewDictionaryService(&mockWordRepository{}, exampleRepo, userWordRepo, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "", 0)
+
+		assert.NoError(t, err)
+		assert.Nil(t, exampleRepo.wordIds)
+		assert.Equal(t, []models.WordResponse{{ID: 1, Word: "推し", Example: "推しが尊い", IsCustom: true}}, result)
+	})
+
+	t.Run("database error on get examples", func(t *testing.T) {
+		wordRepo := &mockWordRepository{words: []models.WordResponse{primary}}
+		exampleRepo := &mockWordExampleRepository{err: errors.New("database error")}
+		svc := NewDictionaryService(wordRepo, exampleRepo, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "", 0)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get word examples")
+		assert.Nil(t, result)
+	})
+}
+
+func TestDictionaryService_GetWordList_Filter(t *testing.T) {
+	tests := []struct {
+		name           string
+		jlptLevels     string
+		tags           string
+		expectedFilter models.WordFilter
+		errorContains  string
+	}{
+		{
+			name:           "no filter",
+			expectedFilter: models.WordFilter{},
+		},
+		{
+			name:           "jlpt levels and tags",
+			jlptLevels:     "5, 4,5",
+			tags:           "Food, travel ,food",
+			expectedFilter: models.WordFilter{JLPTLevels: []int{5, 4}, Tags: []string{"food", "travel"}},
+		},
+		{
+			name:          "invalid jlpt level",
+			jlptLevels:    "6",
+			errorContains: "invalid jlpt level",
+		},
+		{
+			name:          "non-numeric jlpt level",
+			jlptLevels:    "N5",
+			errorContains: "invalid jlpt level",
+		},
+		{
+			name:          "tag too long",
+			tags:          strings.Repeat("a", 51),
+			errorContains: "invalid tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordRepo := &mockWordRepository{words: []models.WordResponse{{ID: 1, Word: "水", Translation: "water"}}}
+			svc := NewDictionaryService(wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", tt.jlptLevels, tt.tags, "", 0)
+
+			if tt.errorContains != "" {
+				assert.Nil(t, result)
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFilter, wordRepo.filter)
+		})
+	}
+}
+
+func TestDictionaryService_GetWordList_Locale(t *testing.T) {
+	tests := []struct {
+		name              string
+		locale            string
+		config            LocaleConfig
+		expectedLanguages []string
+	}{
+		{
+			name:              "english locale",
+			locale:            "en",
+			config:            DefaultLocaleConfig(),
+			expectedLanguages: []string{"en"},
+		},
+		{
+			name:              "german locale falls back to the default one",
+			locale:            "de",
+			config:            DefaultLocaleConfig(),
+			expectedLanguages: []string{"de", "en"},
+		},
+		{
+			name:              "regional locale with configured fallbacks",
+			locale:            " PT-BR ",
+			config:            LocaleConfig{Default: "en", Fallbacks: map[string][]string{"pt-br": {"es"}}},
+			expectedLanguages: []string{"pt-br", "es", "pt", "en"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wordRepo := &mockWordRepository{words: []models.WordResponse{{ID: 1, Word: "水", Translation: "water"}}}
+			svc := NewDictionaryService(wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), tt.config)
+
+			_, err := svc.GetWordList(context.Background(), 1, 10, 10, tt.locale, "", "", "", 0)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLanguages, wordRepo.languages)
+		})
+	}
+}
+
+func TestDictionaryService_GetWordList_Furigana(t *testing.T) {
+	newWords := func() []models.WordResponse {
+		return []models.WordResponse{
+			{ID: 1, Word: "漢字", Example: "{漢字|かんじ}を{書|か}く", Translation: "kanji"},
+			{ID: 2, Word: "水", Example: "水を飲む", Translation: "water"},
+			{ID: 3, Word: "本", Translation: "book"},
+		}
+	}
+
+	t.Run("above level", func(t *testing.T) {
+		wordRepo := &mockWordRepository{words: newWords()}
+		kanjiRepo := &mockKanjiLevelRepository{levels: map[string]int{"漢": 3, "字": 4, "書": 5}}
+		svc := NewDictionaryService(wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, kanjiRepo, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "above level", 4)
+
+		require.NoError(t, err)
+		byID := make(map[int]models.WordResponse, len(result))
+		for _, word := range result {
+			byID[word.ID] = word
+		}
+		assert.Equal(t, "漢字を書く", byID[1].Example)
+		require.NotNil(t, byID[1].ExampleRuby)
+		assert.Equal(t, []models.RubySegment{{Text: "漢字", Reading: "かんじ"}, {Text: "を書く"}}, byID[1].ExampleRuby.Segments)
+		assert.Equal(t, "<ruby>漢字<rp>(</rp><rt>かんじ</rt><rp>)</rp></ruby>を書く", byID[1].ExampleRuby.HTML)
+		// Examples without ruby markup are served only as plain text
+		assert.Equal(t, "水を飲む", byID[2].Example)
+		assert.Nil(t, byID[2].ExampleRuby)
+		assert.Nil(t, byID[3].ExampleRuby)
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		svc := NewDictionaryService(&mockWordRepository{words: newWords()}, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "sometimes", 0)
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "invalid furigana mode")
+
+		result, err = svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "above level", 7)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "furiganaLevel must be between 1 and 5")
+	})
+
+	t.Run("kanji repository error", func(t *testing.T) {
+		kanjiRepo := &mockKanjiLevelRepository{err: errors.New("database error")}
+		svc := NewDictionaryService(&mockWordRepository{words: newWords()}, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, kanjiRepo, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetWordList(context.Background(), 1, 10, 10, "en", "", "", "above level", 3)
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "failed to get kanji levels")
+	})
+}
+
+func TestDictionaryService_SearchWords(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             string
+		locale            string
+		limit             int
+		wordRepo          *mockWordRepository
+		expectedQuery     string
+		expectedLimit     int
+		expectedLanguages []string
+		errorContains     string
+	}{
+		{
+			name:              "success with defaults",
+			query:             "  taberu ",
+			wordRepo:          &mockWordRepository{found: []models.WordSearchResult{{ID: 1, Word: "食べる"}}},
+			expectedQuery:     "taberu",
+			expectedLimit:     20,
+			expectedLanguages: []string{"en"},
+		},
+		{
+			name:              "success with russian locale",
+			query:             "вода",
+			locale:            "ru",
+			limit:             5,
+			wordRepo:          &mockWordRepository{found: []models.WordSearchResult{{ID: 1, Word: "水"}}},
+			expectedQuery:     "вода",
+			expectedLimit:     5,
+			expectedLanguages: []string{"ru", "en"},
+		},
+		{
+			name:              "success with german locale",
+			query:             "Wasser",
+			locale:            "de",
+			wordRepo:          &mockWordRepository{found: []models.WordSearchResult{{ID: 1, Word: "水"}}},
+			expectedQuery:     "Wasser",
+			expectedLimit:     20,
+			expectedLanguages: []string{"de", "en"},
+		},
+		{
+			name:          "empty query",
+			query:         "   ",
+			wordRepo:      &mockWordRepository{},
+			errorContains: "search query is required",
+		},
+		{
+			name:          "query too long",
+			query:         strings.Repeat("水", 101),
+			wordRepo:      &mockWordRepository{},
+			errorContains: "search query must be at most 100 characters long",
+		},
+		{
+			name:          "invalid limit",
+			query:         "mizu",
+			limit:         51,
+			wordRepo:      &mockWordRepository{},
+			errorContains: "invalid limit",
+		},
+		{
+			name:          "invalid locale",
+			query:         "mizu",
+			locale:        "french",
+			wordRepo:      &mockWordRepository{},
+			errorContains: "invalid locale",
+		},
+		{
+			name:          "repository error",
+			query:         "mizu",
+			wordRepo:      &mockWordRepository{err: errors.New("database error")},
+			errorContains: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDictionaryService(tt.wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			result, err := svc.SearchWords(context.Background(), tt.query, tt.locale, tt.limit)
+
+			if tt.errorContains != "" {
+				assert.Nil(t, result)
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wordRepo.found, result)
+			assert.Equal(t, tt.expectedQuery, tt.wordRepo.search.query)
+			assert.Equal(t, tt.expectedLimit, tt.wordRepo.search.limit)
+			assert.Equal(t, tt.expectedLanguages, tt.wordRepo.search.languages)
+		})
+	}
+}
+
+func TestDictionaryService_SubmitWordResults_CustomWords(t *testing.T) {
+	t.Run("custom and dictionary words with the same ID are scheduled separately", func(t *testing.T) {
+		historyRepo := &mockDictionaryHistoryRepository{
+			customHistories: []models.DictionaryHistory{
+				{ID: 12, UserWordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+			},
+		}
+		svc := NewDictionaryService(&mockWordRepository{valid: true}, &mockWordExampleRepository{}, &mockUserWordRepository{valid: true}, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		err := svc.SubmitWordResults(context.Background(), 1, []models.WordResult{
+			{WordID: 1, IsCustom: true, Grade: models.ReviewGradeGood},
+			{WordID: 1, Grade: models.ReviewGradeGood},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, historyRepo.upserted, 2)
+		assert.Equal(t, models.DictionaryHistory{ID: 12, UserWordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2, ReviewCount: 1}, historyRepo.upserted[0])
+		assert.Equal(t, models.DictionaryHistory{WordID: 1, UserID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1, ReviewCount: 1}, historyRepo.upserted[1])
+	})
+
+	t.Run("custom word of another user", func(t *testing.T) {
+		historyRepo := &mockDictionaryHistoryRepository{}
+		svc := NewDictionaryService(&mockWordRepository{valid: true}, &mockWordExampleRepository{}, &mockUserWordRepository{valid: false}, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		err := svc.SubmitWordResults(context.Background(), 1, []models.WordResult{
+			{WordID: 7, IsCustom: true, Grade: models.ReviewGradeGood},
+		})
+
+		assert.EqualError(t, err, "one or more custom word IDs do not exist")
+		assert.Nil(t, historyRepo.upserted)
+	})
+
+	t.Run("database error on validate custom word IDs", func(t *testing.T) {
+		svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{validateErr: errors.New("database error")}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		err := svc.SubmitWordResults(context.Background(), 1, []models.WordResult{
+			{WordID: 7, IsCustom: true, Grade: models.ReviewGradeGood},
+		})
+
+		assert.ErrorContains(t, err, "failed to validate custom word IDs")
+	})
+}
+
+func TestDictionaryService_GetReviewForecast_CustomWords(t *testing.T) {
+	svc := NewDictionaryService(&mockWordRepository{unseen: 40}, &mockWordExampleRepository{}, &mockUserWordRepository{unseen: 3}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+	result, err := svc.GetReviewForecast(context.Background(), 1, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 43, result.NewWords)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// similarKana is a curated table of kana which learners often confuse with each other
+//
+// Every row is a set of mutually similar characters of the same alphabet.
+var similarKana = [][]string{
+	// Katakana
+	{"シ", "ツ"},
+	{"ソ", "ン"},
+	{"ク", "タ", "ケ"},
+	{"ウ", "ワ", "フ"},
+	{"コ", "ユ", "ロ"},
+	{"チ", "テ"},
+	{"ノ", "メ", "ヌ"},
+	{"ス", "ヌ"},
+	{"ア", "マ"},
+	{"セ", "ヤ"},
+	{"ル", "レ"},
+	{"ナ", "メ"},
+	// Hiragana
+	{"ぬ", "め"},
+	{"る", "ろ"},
+	{"わ", "れ", "ね"},
+	{"は", "ほ"},
+	{"さ", "ち", "き"},
+	{"い", "り"},
+	{"こ", "に"},
+	{"あ", "お", "め"},
+	{"け", "は"},
+	{"う", "つ"},
+}
+
+// similarKanaIndex maps a kana to characters it can be confused with
+var similarKanaIndex = buildSimilarKanaIndex(similarKana)
+
+// buildSimilarKanaIndex turns rows of similar kana into a lookup of similar characters for every kana
+func buildSimilarKanaIndex(rows [][]string) map[string][]string {
+	index := make(map[string][]string)
+	for _, row := range rows {
+		for _, kana := range row {
+			for _, similar := range row {
+				if similar != kana && !slices.Contains(index[kana], similar) {
+					index[kana] = append(index[kana], similar)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// Mastery thresholds deciding how many wrong options are confusable with the correct character
+const (
+	// confusableFromScore is the score from which one of the wrong options is confusable
+	confusableFromScore float32 = 0.4
+	// allConfusableFromScore is the score from which all wrong options are confusable
+	allConfusableFromScore float32 = 0.7
+)
+
+// confusableDistractorCount returns how many wrong options should be confusable with the correct character
+//
+// Beginners get random wrong options which are easy to rule out, the closer the character is to being
+// mastered, the more of its wrong options are replaced with similar looking characters.
+func confusableDistractorCount(score float32, optionsCount int) int {
+	switch {
+	case score >= allConfusableFromScore:
+		return optionsCount
+	case score >= confusableFromScore:
+		return min(1, optionsCount)
+	default:
+		return 0
+	}
+}
+
+// distractorItem gives access to a test item whose wrong options can be replaced
+type distractorItem struct {
+	characterID  int
+	correctChar  string
+	wrongOptions *[]string
+}
+
+// selectDistractors replaces random wrong options of test items with characters the learner may confuse with the correct one
+//
+// Candidates are characters the user has already mistaken for the correct one (most frequent first),
+// followed by the curated table of similar kana. Only characters of the tested alphabet and groups are used.
+// The number of replaced options depends on the user's score in "resultField" for the character.
+func (s *charactersService) selectDistractors(ctx context.Context, userID int, alphabetType models.AlphabetType, languages []string, groups []models.CharacterGroup, resultField testResultField, items []distractorItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	characterIDs := make([]int, len(items))
+	for i, item := range items {
+		characterIDs[i] = item.characterID
+	}
+
+	histories, err := s.historyRepo.GetByUserIDAndCharacterIDs(ctx, userID, characterIDs)
+	if err != nil {
+		return err
+	}
+	scores := make(map[int]float32, len(histories))
+	for i := range histories {
+		scores[histories[i].CharacterID] = *resultField.value(&histories[i])
+	}
+
+	alphabetName := "hiragana"
+	if alphabetType == models.AlphabetTypeKatakana {
+		alphabetName = "katakana"
+	}
+	mistakes, err := s.mistakeRepo.GetMistakenOptions(ctx, userID, alphabetName, characterIDs)
+	if err != nil {
+		return err
+	}
+
+	characters, err := s.repo.GetAll(ctx, alphabetType, languages, groups)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(characters))
+	for _, character := range characters {
+		allowed[character.Character] = true
+	}
+
+	for _, item := range items {
+		count := confusableDistractorCount(scores[item.characterID], len(*item.wrongOptions))
+		if count == 0 {
+			continue
+		}
+		similar := similarKanaIndex[item.correctChar]
+		shuffled := make([]string, len(similar))
+		for i, j := range rand.Perm(len(similar)) {
+			shuffled[i] = similar[j]
+		}
+		candidates := append(append([]string{}, mistakes[item.characterID]...), shuffled...)
+		*item.wrongOptions = pickDistractors(item.correctChar, *item.wrongOptions, candidates, allowed, count)
+	}
+	return nil
+}
+
+// pickDistractors returns wrong options with up to "count" confusable candidates in place of random ones
+//
+// Candidates are taken in order, skipping the correct character, duplicates and characters which are not allowed.
+// The remaining places are filled with random options, so the number of wrong options never changes.
+func pickDistractors(correct string, random, candidates []string, allowed map[string]bool, count int) []string {
+	picked := make([]string, 0, len(random))
+	for _, candidate := range candidates {
+		if len(picked) == count {
+			break
+		}
+		if candidate == correct || !allowed[candidate] || slices.Contains(picked, candidate) {
+			continue
+		}
+		picked = append(picked, candidate)
+	}
+	for _, option := range random {
+		if len(picked) == len(random) {
+			break
+		}
+		if !slices.Contains(picked, option) {
+			picked = append(picked, option)
+		}
+	}
+	return picked
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCharacterMistakeRepository is a mock implementation of CharacterMistakeRepository
+type mockCharacterMistakeRepository struct {
+	mistakes map[int][]string
+	err      error
+}
+
+func (m *mockCharacterMistakeRepository) GetMistakenOptions(ctx context.Context, userID int, alphabetType string, characterIDs []int) (map[int][]string, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.mistakes, nil
+}
+
+// mockAlphabetRepository is a mock implementation of CharactersRepository returning a fixed alphabet
+//
+// Only GetAll is implemented, other methods are not used by distractor selection.
+type mockAlphabetRepository struct {
+	CharactersRepository
+	characters []models.CharacterResponse
+}
+
+func (m *mockAlphabetRepository) GetAll(ctx context.Context, alphabetType models.AlphabetType, languages []string, groups []models.CharacterGroup) ([]models.CharacterResponse, error) {
+	return m.characters, nil
+}
+
+func TestSimilarKanaIndex(t *testing.T) {
+	assert.Contains(t, similarKanaIndex["シ"], "ツ")
+	assert.Contains(t, similarKanaIndex["ツ"], "シ")
+	assert.Contains(t, similarKanaIndex["ソ"], "ン")
+	assert.Contains(t, similarKanaIndex["ぬ"], "め")
+	assert.Contains(t, similarKanaIndex["ろ"], "る")
+	// A kana is never similar to itself and appears only once
+	for kana, similar := range similarKanaIndex {
+		assert.NotContains(t, similar, kana)
+		seen := make(map[string]bool)
+		for _, s := range similar {
+			assert.False(t, seen[s], "%s is listed twice for %s", s, kana)
+			seen[s] = true
+		}
+	}
+}
+
+func TestConfusableDistractorCount(t *testing.T) {
+	assert.Equal(t, 0, confusableDistractorCount(0, 2))
+	assert.Equal(t, 0, confusableDistractorCount(0.39, 2))
+	assert.Equal(t, 1, confusableDistractorCount(0.4, 2))
+	assert.Equal(t, 2, confusableDistractorCount(0.7, 2))
+	assert.Equal(t, 2, confusableDistractorCount(1, 2))
+	assert.Equal(t, 0, confusableDistractorCount(1, 0))
+}
+
+func TestPickDistractors(t *testing.T) {
+	allowed := map[string]bool{"シ": true, "ツ": true, "ソ": true, "ン": true, "カ": true, "キ": true}
+
+	tests := []struct {
+		name       string
+		correct    string
+		random     []string
+		candidates []string
+		count      int
+		expected   []string
+	}{
+		{
+			name:       "no confusable options",
+			correct:    "シ",
+			random:     []string{"カ", "キ"},
+			candidates: []string{"ツ"},
+			count:      0,
+			expected:   []string{"カ", "キ"},
+		},
+		{
+			name:       "one confusable option replaces a random one",
+			correct:    "シ",
+			random:     []string{"カ", "キ"},
+			candidates: []string{"ツ", "ソ"},
+			count:      1,
+			expected:   []string{"ツ", "カ"},
+		},
+		{
+			name:       "all options confusable",
+			correct:    "ソ",
+			random:     []string{"カ", "キ"},
+			candidates: []string{"ン", "シ"},
+			count:      2,
+			expected:   []string{"ン", "シ"},
+		},
+		{
+			name:       "correct, duplicate and not allowed candidates are skipped",
+			correct:    "シ",
+			random:     []string{"カ", "キ"},
+			candidates: []string{"シ", "ヌ", "ツ", "ツ"},
+			count:      2,
+			expected:   []string{"ツ", "カ"},
+		},
+		{
+			name:       "random option equal to a confusable one is not repeated",
+			correct:    "シ",
+			random:     []string{"ツ", "キ"},
+			candidates: []string{"ツ"},
+			count:      1,
+			expected:   []string{"ツ", "キ"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := pickDistractors(tt.correct, tt.random, tt.candidates, allowed, tt.count)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCharactersService_SelectDistractors(t *testing.T) {
+	alphabet := &mockAlphabetRepository{characters: []models.CharacterResponse{
+		{ID: 1, Character: "シ"}, {ID: 2, Character: "ツ"}, {ID: 3, Character: "ソ"},
+		{ID: 4, Character: "ン"}, {ID: 5, Character: "カ"}, {ID: 6, Character: "キ"},
+	}}
+	resultField, err := lookupTestResultField("katakana", "reading")
+	assert.NoError(t, err)
+
+	newItems := func() ([]distractorItem, [][]string) {
+		options := [][]string{{"カ", "キ"}, {"カ", "キ"}}
+		return []distractorItem{
+			{characterID: 1, correctChar: "シ", wrongOptions: &options[0]},
+			{characterID: 3, correctChar: "ソ", wrongOptions: &options[1]},
+		}, options
+	}
+
+	t.Run("difficulty follows mastery", func(t *testing.T) {
+		historyRepo := &mockHistoryRepository{histories: []models.CharacterLearnHistory{
+			{CharacterID: 1, KatakanaReadingResult: 0.1},
+			{CharacterID: 3, KatakanaReadingResult: 0.9},
+		}}
+		svc := NewCharactersService(alphabet, historyRepo, nil, nil, &mockCharacterMistakeRepository{}, DefaultLocaleConfig())
+		items, options := newItems()
+
+		err := svc.selectDistractors(context.Background(), 1, models.AlphabetTypeKatakana, []string{"en"}, nil, resultField, items)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"カ", "キ"}, options[0], "beginner keeps random options")
+		assert.Equal(t, []string{"ン", "カ"}, options[1], "only one similar kana is available, the rest stays random")
+	})
+
+	t.Run("user mistakes come first", func(t *testing.T) {
+		historyRepo := &mockHistoryRepository{histories: []models.CharacterLearnHistory{
+			{CharacterID: 1, KatakanaReadingResult: 0.5},
+		}}
+		mistakeRepo := &mockCharacterMistakeRepository{mistakes: map[int][]string{1: {"ソ"}}}
+		svc := NewCharactersService(alphabet, historyRepo, nil, nil, mistakeRepo, DefaultLocaleConfig())
+		items, options := newItems()
+
+		err := svc.selectDistractors(context.Background(), 1, models.AlphabetTypeKatakana, []string{"en"}, nil, resultField, items)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ソ", "カ"}, options[0])
+		assert.Equal(t, []string{"カ", "キ"}, options[1], "character without history keeps random options")
+	})
+
+	t.Run("mistake repository error", func(t *testing.T) {
+		svc := NewCharactersService(alphabet, &mockHistoryRepository{}, nil, nil, &mockCharacterMistakeRepository{err: errors.New("database error")}, DefaultLocaleConfig())
+		items, _ := newItems()
+
+		err := svc.selectDistractors(context.Background(), 1, models.AlphabetTypeKatakana, []string{"en"}, nil, resultField, items)
+
+		assert.EqualError(t, err, "database error")
+	})
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// KanjiLevelRepository is the interface that wraps methods for reading JLPT levels of kanji
+type KanjiLevelRepository interface {
+	// GetJLPTLevels retrieves JLPT levels of kanji characters
+	//
+	// "characters" parameter contains single kanji characters.
+	// Kanji missing in the Kanji table are missing in the result.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetJLPTLevels(ctx context.Context, characters []string) (map[string]int, error)
+}
+
+// defaultFuriganaLevel is the learner's JLPT level used when it is not provided
+const defaultFuriganaLevel = 5
+
+// furiganaSettings describes which annotated parts of a text are shown with furigana
+type furiganaSettings struct {
+	mode  models.FuriganaMode
+	level int // JLPT level of the learner, kanji of this and easier levels are known
+}
+
+// parseFuriganaSettings validates furigana parameters of the user
+//
+// For successful results:
+//
+// - mode must be "always", "above level" or "never", an empty mode means "always"
+//
+// - level must be between 1 and 5, 0 means level 5
+func parseFuriganaSettings(mode string, level int) (furiganaSettings, error) {
+	settings := furiganaSettings{mode: models.FuriganaMode(strings.ToLower(strings.TrimSpace(mode))), level: level}
+	if settings.mode == "" {
+		settings.mode = models.FuriganaModeAlways
+	}
+	if !settings.mode.IsValid() {
+		return furiganaSettings{}, fmt.Errorf("invalid furigana mode: %s, must be 'always', 'above level' or 'never'", mode)
+	}
+	if settings.level == 0 {
+		settings.level = defaultFuriganaLevel
+	}
+	if settings.level < 1 || settings.level > 5 {
+		return furiganaSettings{}, fmt.Errorf("furiganaLevel must be between 1 and 5")
+	}
+	return settings, nil
+}
+
+// parseRuby splits a text with ruby markup into segments
+//
+// Annotated parts are written as "{base|reading}", e.g. "{漢字|かんじ}を{読|よ}む".
+// The base must not be empty and the reading must contain only kana.
+// Braces are not allowed outside of annotations and annotations cannot be nested.
+func parseRuby(text string) ([]models.RubySegment, error) {
+	segments := []models.RubySegment{}
+	rest := text
+	for rest != "" {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			segments = append(segments, models.RubySegment{Text: rest})
+			break
+		}
+		if rest[start] == '}' {
+			return nil, fmt.Errorf("invalid ruby markup: unexpected '}'")
+		}
+		if start > 0 {
+			segments = append(segments, models.RubySegment{Text: rest[:start]})
+		}
+
+		rest = rest[start+1:]
+		end := strings.IndexAny(rest, "{}")
+		if end < 0 {
+			return nil, fmt.Errorf("invalid ruby markup: unclosed '{'")
+		}
+		if rest[end] == '{' {
+			return nil, fmt.Errorf("invalid ruby markup: annotations cannot be nested")
+		}
+		annotation := rest[:end]
+		rest = rest[end+1:]
+
+		base, reading, found := strings.Cut(annotation, "|")
+		if !found || base == "" || reading == "" || strings.Contains(reading, "|") {
+			return nil, fmt.Errorf("invalid ruby markup: '{%s}' must be written as {text|reading}", annotation)
+		}
+		if !isKana(reading) {
+			return nil, fmt.Errorf("invalid ruby markup: reading '%s' must contain only kana", reading)
+		}
+		segments = append(segments, models.RubySegment{Text: base, Reading: reading})
+	}
+	return segments, nil
+}
+
+// isKana checks if the text consists of hiragana, katakana and long vowel marks
+func isKana(text string) bool {
+	for _, r := range text {
+		if !unicode.In(r, unicode.Hiragana, unicode.Katakana) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// stripRuby removes ruby markup from a text, leaving only annotated parts without readings
+//
+// Texts with invalid markup are returned unchanged.
+func stripRuby(text string) string {
+	segments, err := parseRuby(text)
+	if err != nil {
+		return text
+	}
+	var plain strings.Builder
+	for _, segment := range segments {
+		plain.WriteString(segment.Text)
+	}
+	return plain.String()
+}
+
+// rubyHTML renders segments as escaped HTML, segments with readings become <ruby> elements
+//
+// <rp> elements keep readings in parentheses for browsers without ruby support.
+func rubyHTML(segments []models.RubySegment) string {
+	var result strings.Builder
+	for _, segment := range segments {
+		if segment.Reading == "" {
+			result.WriteString(html.EscapeString(segment.Text))
+			continue
+		}
+		result.WriteString("<ruby>")
+		result.WriteString(html.EscapeString(segment.Text))
+		result.WriteString("<rp>(</rp><rt>")
+		result.WriteString(html.EscapeString(segment.Reading))
+		result.WriteString("</rt><rp>)</rp></ruby>")
+	}
+	return result.String()
+}
+
+// annotate builds ruby texts with furigana shown according to the settings
+//
+// The result has an element for every text, empty texts give nil.
+// Texts with invalid markup, such as texts written before ruby markup was supported, are served as they are without furigana.
+// JLPT levels of kanji are retrieved only in "above level" mode.
+func (f furiganaSettings) annotate(ctx context.Context, kanjiRepo KanjiLevelRepository, texts []string) ([]*models.RubyText, error) {
+	parsed := make([][]models.RubySegment, len(texts))
+	var kanji []string
+	seen := make(map[string]bool)
+	for i, text := range texts {
+		if text == "" {
+			continue
+		}
+		segments, err := parseRuby(text)
+		if err != nil {
+			segments = []models.RubySegment{{Text: text}}
+		}
+		parsed[i] = segments
+
+		if f.mode != models.FuriganaModeAboveLevel {
+			continue
+		}
+		for _, segment := range segments {
+			if segment.Reading == "" {
+				continue
+			}
+			for _, r := range segment.Text {
+				if unicode.Is(unicode.Han, r) && !seen[string(r)] {
+					seen[string(r)] = true
+					kanji = append(kanji, string(r))
+				}
+			}
+		}
+	}
+
+	var levels map[string]int
+	if len(kanji) > 0 {
+		var err error
+		levels, err = kanjiRepo.GetJLPTLevels(ctx, kanji)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kanji levels: %w", err)
+		}
+	}
+
+	result := make([]*models.RubyText, len(texts))
+	for i, segments := range parsed {
+		if segments == nil {
+			continue
+		}
+		shown := make([]models.RubySegment, 0, len(segments))
+		for _, segment := range segments {
+			if segment.Reading != "" && !f.showFurigana(segment.Text, levels) {
+				segment.Reading = ""
+			}
+			// Parts without furigana are joined, so hidden annotations do not split the text
+			if last := len(shown) - 1; segment.Reading == "" && last >= 0 && shown[last].Reading == "" {
+				shown[last].Text += segment.Text
+				continue
+			}
+			shown = append(shown, segment)
+		}
+		result[i] = &models.RubyText{Segments: shown, HTML: rubyHTML(shown)}
+	}
+	return result, nil
+}
+
+// showFurigana checks if furigana of an annotated part are shown
+//
+// In "above level" mode furigana are shown if the part contains a kanji harder than the learner's level
+// or a kanji of unknown level.
+func (f furiganaSettings) showFurigana(text string, levels map[string]int) bool {
+	switch f.mode {
+	case models.FuriganaModeNever:
+		return false
+	case models.FuriganaModeAboveLevel:
+		for _, r := range text {
+			if !unicode.Is(unicode.Han, r) {
+				continue
+			}
+			if level, ok := levels[string(r)]; !ok || level < f.level {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
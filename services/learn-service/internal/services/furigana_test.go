@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKanjiLevelRepository is a mock implementation of KanjiLevelRepository
+type mockKanjiLevelRepository struct {
+	levels    map[string]int
+	err       error
+	requested []string
+}
+
+func (m *mockKanjiLevelRepository) GetJLPTLevels(ctx context.Context, characters []string) (map[string]int, error) {
+	m.requested = characters
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.levels, nil
+}
+
+func TestParseRuby(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expected      []models.RubySegment
+		errorContains string
+	}{
+		{
+			name: "annotated text",
+			text: "{日本|にほん}の{漢字|かんじ}を{読|よ}む",
+			expected: []models.RubySegment{
+				{Text: "日本", Reading: "にほん"},
+				{Text: "の"},
+				{Text: "漢字", Reading: "かんじ"},
+				{Text: "を"},
+				{Text: "読", Reading: "よ"},
+				{Text: "む"},
+			},
+		},
+		{
+			name:     "plain text",
+			text:     "水を飲む",
+			expected: []models.RubySegment{{Text: "水を飲む"}},
+		},
+		{
+			name:     "katakana reading",
+			text:     "{珈琲|コーヒー}",
+			expected: []models.RubySegment{{Text: "珈琲", Reading: "コーヒー"}},
+		},
+		{
+			name:     "empty text",
+			text:     "",
+			expected: []models.RubySegment{},
+		},
+		{name: "unexpected closing brace", text: "水}", errorContains: "unexpected '}'"},
+		{name: "unclosed annotation", text: "{水|みず", errorContains: "unclosed '{'"},
+		{name: "nested annotation", text: "{水{|みず}", errorContains: "cannot be nested"},
+		{name: "missing reading", text: "{水}", errorContains: "must be written as {text|reading}"},
+		{name: "empty base", text: "{|みず}", errorContains: "must be written as {text|reading}"},
+		{name: "several readings", text: "{水|み|ず}", errorContains: "must be written as {text|reading}"},
+		{name: "romaji reading", text: "{水|mizu}", errorContains: "must contain only kana"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, err := parseRuby(tt.text)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid ruby markup")
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, segments)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, segments)
+			}
+		})
+	}
+}
+
+func TestStripRuby(t *testing.T) {
+	assert.Equal(t, "日本の漢字を読む", stripRuby("{日本|にほん}の{漢字|かんじ}を{読|よ}む"))
+	assert.Equal(t, "水を飲む", stripRuby("水を飲む"))
+	// Invalid markup is kept as it is
+	assert.Equal(t, "{水}を飲む", stripRuby("{水}を飲む"))
+}
+
+func TestRubyHTML(t *testing.T) {
+	html := rubyHTML([]models.RubySegment{
+		{Text: "漢字", Reading: "かんじ"},
+		{Text: "を<b>読む</b>"},
+	})
+
+	assert.Equal(t, "<ruby>漢字<rp>(</rp><rt>かんじ</rt><rp>)</rp></ruby>を&lt;b&gt;読む&lt;/b&gt;", html)
+}
+
+func TestParseFuriganaSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          string
+		level         int
+		expected      furiganaSettings
+		errorContains string
+	}{
+		{name: "defaults", expected: furiganaSettings{mode: models.FuriganaModeAlways, level: 5}},
+		{name: "above level", mode: "above level", level: 3, expected: furiganaSettings{mode: models.FuriganaModeAboveLevel, level: 3}},
+		{name: "mode is case insensitive", mode: " Never ", expected: furiganaSettings{mode: models.FuriganaModeNever, level: 5}},
+		{name: "unknown mode", mode: "sometimes", errorContains: "invalid furigana mode: sometimes"},
+		{name: "level too high", mode: "always", level: 6, errorContains: "furiganaLevel must be between 1 and 5"},
+		{name: "negative level", mode: "always", level: -1, errorContains: "furiganaLevel must be between 1 and 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := parseFuriganaSettings(tt.mode, tt.level)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, settings)
+			}
+		})
+	}
+}
+
+func TestFuriganaSettings_Annotate(t *testing.T) {
+	text := "{日本|にほん}の{漢字|かんじ}を{読|よ}む"
+	levels := map[string]int{"日": 5, "本": 5, "漢": 3, "字": 4, "読": 4}
+
+	tests := []struct {
+		name      string
+		settings  furiganaSettings
+		expected  []models.RubySegment
+		requested bool
+	}{
+		{
+			name:     "always",
+			settings: furiganaSettings{mode: models.FuriganaModeAlways, level: 5},
+			expected: []models.RubySegment{
+				{Text: "日本", Reading: "にほん"},
+				{Text: "の"},
+				{Text: "漢字", Reading: "かんじ"},
+				{Text: "を"},
+				{Text: "読", Reading: "よ"},
+				{Text: "む"},
+			},
+		},
+		{
+			name:     "never",
+			settings: furiganaSettings{mode: models.FuriganaModeNever, level: 5},
+			expected: []models.RubySegment{{Text: "日本の漢字を読む"}},
+		},
+		{
+			name:     "above level shows only harder kanji",
+			settings: furiganaSettings{mode: models.FuriganaModeAboveLevel, level: 4},
+			expected: []models.RubySegment{
+				{Text: "日本の"},
+				{Text: "漢字", Reading: "かんじ"},
+				{Text: "を読む"},
+			},
+			requested: true,
+		},
+		{
+			name:      "above level with the hardest level hides all furigana",
+			settings:  furiganaSettings{mode: models.FuriganaModeAboveLevel, level: 1},
+			expected:  []models.RubySegment{{Text: "日本の漢字を読む"}},
+			requested: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kanjiRepo := &mockKanjiLevelRepository{levels: levels}
+
+			result, err := tt.settings.annotate(context.Background(), kanjiRepo, []string{text, ""})
+
+			require.NoError(t, err)
+			require.Len(t, result, 2)
+			require.NotNil(t, result[0])
+			assert.Equal(t, tt.expected, result[0].Segments)
+			assert.Equal(t, rubyHTML(tt.expected), result[0].HTML)
+			assert.Nil(t, result[1])
+			if tt.requested {
+				assert.ElementsMatch(t, []string{"日", "本", "漢", "字", "読"}, kanjiRepo.requested)
+			} else {
+				assert.Nil(t, kanjiRepo.requested)
+			}
+		})
+	}
+
+	t.Run("kanji of unknown level show furigana", func(t *testing.T) {
+		settings := furiganaSettings{mode: models.FuriganaModeAboveLevel, level: 5}
+		kanjiRepo := &mockKanjiLevelRepository{levels: map[string]int{}}
+
+		result, err := settings.annotate(context.Background(), kanjiRepo, []string{"{鬱|うつ}"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.RubySegment{{Text: "鬱", Reading: "うつ"}}, result[0].Segments)
+	})
+
+	t.Run("invalid markup is served as plain text", func(t *testing.T) {
+		settings := furiganaSettings{mode: models.FuriganaModeAlways, level: 5}
+
+		result, err := settings.annotate(context.Background(), &mockKanjiLevelRepository{}, []string{"{水}を飲む"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.RubySegment{{Text: "{水}を飲む"}}, result[0].Segments)
+		assert.Equal(t, "{水}を飲む", result[0].HTML)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		settings := furiganaSettings{mode: models.FuriganaModeAboveLevel, level: 3}
+		kanjiRepo := &mockKanjiLevelRepository{err: errors.New("database error")}
+
+		result, err := settings.annotate(context.Background(), kanjiRepo, []string{text})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get kanji levels")
+		assert.Nil(t, result)
+	})
+}
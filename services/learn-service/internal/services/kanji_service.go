@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// KanjiRepository is the interface that wraps methods for Kanji table data access
+type KanjiRepository interface {
+	// GetByJLPTLevel retrieves a paginated list of kanji with locale-specific meanings
+	//
+	// "level" parameter is used to filter kanji by JLPT level, 0 means all levels.
+	// "page" parameter is used to specify the page number.
+	// "count" parameter is used to specify the number of items per page.
+	// "languages" parameter is the locale fallback chain, meanings are taken in its first language which has them.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByJLPTLevel(ctx context.Context, level, page, count int, languages []string) ([]models.KanjiResponse, error)
+	// GetWordsByKanjiID retrieves words containing the kanji
+	//
+	// "kanjiID" parameter is used to identify the kanji.
+	// "languages" parameter is the locale fallback chain, translations are taken in its first language which has them.
+	//
+	// Please reference GetByJLPTLevel method for more information about error values.
+	GetWordsByKanjiID(ctx context.Context, kanjiID int, languages []string) ([]models.WordResponse, error)
+	// ExistsByID checks if a kanji with the ID exists
+	//
+	// "id" parameter is used to identify the kanji.
+	//
+	// If some error will occur during data check, the error will be returned together with "false" value.
+	ExistsByID(ctx context.Context, id int) (bool, error)
+}
+
+// kanjiService implements KanjiService
+type kanjiService struct {
+	kanjiRepo KanjiRepository
+	locale    LocaleConfig
+}
+
+// NewKanjiService creates a new kanji service
+func NewKanjiService(kanjiRepo KanjiRepository, locale LocaleConfig) *kanjiService {
+	return &kanjiService{
+		kanjiRepo: kanjiRepo,
+		locale:    locale,
+	}
+}
+
+// GetKanjiList retrieves a paginated list of kanji filtered by JLPT level
+//
+// For successful results:
+//
+// - level must be 0 (all levels) or between 1 and 5
+//
+// - locale must be a valid language code, such as "en" or "pt-br"
+//
+// Meanings missing in the locale are taken from its fallbacks.
+func (s *kanjiService) GetKanjiList(ctx context.Context, level, page, count int, locale string) ([]models.KanjiResponse, error) {
+	if level != 0 && (level < 1 || level > 5) {
+		return nil, fmt.Errorf("invalid jlpt level: must be between 1 and 5")
+	}
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 || count > 100 {
+		count = 50
+	}
+
+	kanji, err := s.kanjiRepo.GetByJLPTLevel(ctx, level, page, count, s.locale.Chain(locale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji: %w", err)
+	}
+	return kanji, nil
+}
+
+// GetKanjiWords retrieves words from the dictionary containing the kanji
+//
+// For successful results the kanji must exist and locale must be a valid language code.
+// Translations missing in the locale are taken from its fallbacks.
+func (s *kanjiService) GetKanjiWords(ctx context.Context, id int, locale string) ([]models.WordResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid kanji id")
+	}
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.kanjiRepo.ExistsByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check kanji existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("kanji not found")
+	}
+
+	words, err := s.kanjiRepo.GetWordsByKanjiID(ctx, id, s.locale.Chain(locale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kanji words: %w", err)
+	}
+	// Kanji pages show plain examples, furigana are served only with word lists
+	for i := range words {
+		words[i].Example = stripRuby(words[i].Example)
+	}
+	return words, nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKanjiRepository is a mock implementation of KanjiRepository
+type mockKanjiRepository struct {
+	kanji     []models.KanjiResponse
+	words     []models.WordResponse
+	exists    bool
+	err       error
+	languages []string
+}
+
+func (m *mockKanjiRepository) GetByJLPTLevel(ctx context.Context, level, page, count int, languages []string) ([]models.KanjiResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.languages = languages
+	return m.kanji, nil
+}
+
+func (m *mockKanjiRepository) GetWordsByKanjiID(ctx context.Context, kanjiID int, languages []string) ([]models.WordResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.languages = languages
+	return m.words, nil
+}
+
+func (m *mockKanjiRepository) ExistsByID(ctx context.Context, id int) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.exists, nil
+}
+
+func TestKanjiService_GetKanjiList(t *testing.T) {
+	tests := []struct {
+		name              string
+		level             int
+		locale            string
+		repo              *mockKanjiRepository
+		expectedLanguages []string
+		expectedError     string
+	}{
+		{
+			name:              "success with level",
+			level:             5,
+			locale:            "de",
+			repo:              &mockKanjiRepository{kanji: []models.KanjiResponse{{ID: 1, Character: "水"}}},
+			expectedLanguages: []string{"de", "en"},
+		},
+		{
+			name:              "success with all levels",
+			level:             0,
+			locale:            "ru",
+			repo:              &mockKanjiRepository{kanji: []models.KanjiResponse{{ID: 1, Character: "水"}}},
+			expectedLanguages: []string{"ru", "en"},
+		},
+		{
+			name:              "success with regional locale",
+			level:             5,
+			locale:            "pt-BR",
+			repo:              &mockKanjiRepository{kanji: []models.KanjiResponse{{ID: 1, Character: "水"}}},
+			expectedLanguages: []string{"pt-br", "pt", "en"},
+		},
+		{
+			name:          "invalid level",
+			level:         6,
+			locale:        "en",
+			repo:          &mockKanjiRepository{},
+			expectedError: "invalid jlpt level",
+		},
+		{
+			name:          "invalid locale",
+			level:         5,
+			locale:        "french",
+			repo:          &mockKanjiRepository{},
+			expectedError: "invalid locale",
+		},
+		{
+			name:          "repository error",
+			level:         5,
+			locale:        "en",
+			repo:          &mockKanjiRepository{err: errors.New("database error")},
+			expectedError: "failed to get kanji",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewKanjiService(tt.repo, DefaultLocaleConfig())
+
+			kanji, err := svc.GetKanjiList(context.Background(), tt.level, 1, 20, tt.locale)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, kanji)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, kanji, 1)
+			assert.Equal(t, tt.expectedLanguages, tt.repo.languages)
+		})
+	}
+}
+
+func TestKanjiService_GetKanjiWords(t *testing.T) {
+	tests := []struct {
+		name              string
+		id                int
+		locale            string
+		repo              *mockKanjiRepository
+		expectedLanguages []string
+		expectedError     string
+	}{
+		{
+			name:              "success",
+			id:                1,
+			locale:            "en",
+			repo:              &mockKanjiRepository{exists: true, words: []models.WordResponse{{ID: 1, Word: "水"}}},
+			expectedLanguages: []string{"en"},
+		},
+		{
+			name:          "invalid id",
+			id:            0,
+			locale:        "en",
+			repo:          &mockKanjiRepository{},
+			expectedError: "invalid kanji id",
+		},
+		{
+			name:          "kanji not found",
+			id:            1,
+			locale:        "en",
+			repo:          &mockKanjiRepository{exists: false},
+			expectedError: "kanji not found",
+		},
+		{
+			name:          "invalid locale",
+			id:            1,
+			locale:        "",
+			repo:          &mockKanjiRepository{exists: true},
+			expectedError: "invalid locale",
+		},
+		{
+			name:          "repository error",
+			id:            1,
+			locale:        "en",
+			repo:          &mockKanjiRepository{err: errors.New("database error")},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewKanjiService(tt.repo, DefaultLocaleConfig())
+
+			words, err := svc.GetKanjiWords(context.Background(), tt.id, tt.locale)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, words)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, words, 1)
+			assert.Equal(t, tt.expectedLanguages, tt.repo.languages)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// LeechAction represents what happens to a word flagged as a leech
+type LeechAction string
+
+const (
+	LeechActionSuspend LeechAction = "suspend" // The leech is not reviewed until it is unsuspended
+	LeechActionReview  LeechAction = "review"  // The leech leaves regular reviews and is reviewed from the leech list
+)
+
+// LeechConfig holds parameters of leech detection in dictionary reviews.
+//
+// A word is flagged as a leech when a lapse brings its lapses to Threshold. An unsuspended leech which keeps
+// being forgotten is flagged again every half of Threshold lapses, so it does not return to the leech list after every lapse.
+type LeechConfig struct {
+	Threshold int // Lapses after which a word is flagged as a leech, 0 disables leech detection
+	Action    LeechAction
+}
+
+// DefaultLeechConfig returns the leech configuration used when nothing else is configured
+func DefaultLeechConfig() LeechConfig {
+	return LeechConfig{
+		Threshold: 8,
+		Action:    LeechActionSuspend,
+	}
+}
+
+// flag flags a word as a leech if its lapses reached a leech threshold
+//
+// "history" parameter is the review state of a word right after a lapse.
+func (c LeechConfig) flag(history models.DictionaryHistory) models.DictionaryHistory {
+	if c.Threshold <= 0 || history.Lapses < c.Threshold {
+		return history
+	}
+	step := max(c.Threshold/2, 1)
+	if (history.Lapses-c.Threshold)%step != 0 {
+		return history
+	}
+	history.IsLeech = true
+	history.Suspended = c.Action == LeechActionSuspend
+	return history
+}
+
+// GetLeeches retrieves words of the user flagged as leeches, most lapses first
+//
+// For successful results locale must be a valid language code, translations missing in it are taken from its fallbacks.
+// Dictionary and custom words are listed together, custom words have IsCustom set.
+func (s *dictionaryService) GetLeeches(ctx context.Context, userId int, locale string) ([]models.Leech, error) {
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	histories, err := s.dictionaryHistoryRepo.GetLeeches(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leeches: %w", err)
+	}
+	var wordIds, userWordIds []int
+	for _, history := range histories {
+		if history.UserWordID != 0 {
+			userWordIds = append(userWordIds, history.UserWordID)
+		} else {
+			wordIds = append(wordIds, history.WordID)
+		}
+	}
+
+	words := make(map[reviewKey]models.WordResponse, len(histories))
+	if len(wordIds) > 0 {
+		dictionaryWords, err := s.wordRepo.GetByIDs(ctx, wordIds, s.locale.Chain(locale))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get words: %w", err)
+		}
+		for _, word := range dictionaryWords {
+			words[reviewKey{id: word.ID}] = word
+		}
+	}
+	if len(userWordIds) > 0 {
+		customWords, err := s.userWordRepo.GetByIDs(ctx, userId, userWordIds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom words: %w", err)
+		}
+		for _, word := range customWords {
+			words[reviewKey{id: word.ID, custom: true}] = word
+		}
+	}
+
+	leeches := make([]models.Leech, 0, len(histories))
+	for _, history := range histories {
+		key := reviewKey{id: history.WordID}
+		if history.UserWordID != 0 {
+			key = reviewKey{id: history.UserWordID, custom: true}
+		}
+		word, ok := words[key]
+		if !ok {
+			continue
+		}
+		word.Example = stripRuby(word.Example)
+		leeches = append(leeches, models.Leech{
+			Word:           word,
+			Lapses:         history.Lapses,
+			Suspended:      history.Suspended,
+			NextAppearance: history.NextAppearance,
+		})
+	}
+	return leeches, nil
+}
+
+// UnsuspendLeech returns a leech of the user to regular reviews keeping its review state
+//
+// "wordId" parameter is a custom word ID if "isCustom" is true.
+func (s *dictionaryService) UnsuspendLeech(ctx context.Context, userId, wordId int, isCustom bool) error {
+	if wordId <= 0 {
+		return fmt.Errorf("invalid word id")
+	}
+	found, err := s.dictionaryHistoryRepo.UnsuspendLeech(ctx, userId, wordId, isCustom)
+	if err != nil {
+		return fmt.Errorf("failed to unsuspend leech: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("leech not found")
+	}
+	return nil
+}
+
+// ResetLeech returns a leech of the user to regular reviews as a word learned from scratch
+//
+// Lapses and the SM-2 state of the word are reset and it comes due today.
+// Please reference UnsuspendLeech method for more information about parameters.
+func (s *dictionaryService) ResetLeech(ctx context.Context, userId, wordId int, isCustom bool) error {
+	if wordId <= 0 {
+		return fmt.Errorf("invalid word id")
+	}
+	found, err := s.dictionaryHistoryRepo.ResetLeech(ctx, userId, wordId, isCustom)
+	if err != nil {
+		return fmt.Errorf("failed to reset leech: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("leech not found")
+	}
+	return nil
+}
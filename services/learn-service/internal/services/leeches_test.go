@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeechConfig_Flag(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            LeechConfig
+		lapses            int
+		expectedLeech     bool
+		expectedSuspended bool
+	}{
+		{name: "below threshold", config: DefaultLeechConfig(), lapses: 7},
+		{name: "threshold reached", config: DefaultLeechConfig(), lapses: 8, expectedLeech: true, expectedSuspended: true},
+		{name: "between repeated flags", config: DefaultLeechConfig(), lapses: 10},
+		{name: "flagged again after half of threshold", config: DefaultLeechConfig(), lapses: 12, expectedLeech: true, expectedSuspended: true},
+		{name: "review action does not suspend", config: LeechConfig{Threshold: 8, Action: LeechActionReview}, lapses: 8, expectedLeech: true},
+		{name: "threshold of one flags every lapse", config: LeechConfig{Threshold: 1, Action: LeechActionSuspend}, lapses: 3, expectedLeech: true, expectedSuspended: true},
+		{name: "detection disabled", config: LeechConfig{Threshold: 0, Action: LeechActionSuspend}, lapses: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := tt.config.flag(models.DictionaryHistory{WordID: 1, Lapses: tt.lapses})
+
+			assert.Equal(t, tt.expectedLeech, history.IsLeech)
+			assert.Equal(t, tt.expectedSuspended, history.Suspended)
+			assert.Equal(t, tt.lapses, history.Lapses)
+		})
+	}
+}
+
+func TestDictionaryService_SubmitWordResults_Leeches(t *testing.T) {
+	tests := []struct {
+		name              string
+		history           models.DictionaryHistory
+		grade             int
+		expectedLeech     bool
+		expectedSuspended bool
+	}{
+		{
+			name:              "lapse reaching threshold flags leech",
+			history:           models.DictionaryHistory{WordID: 1, UserID: 1, EaseFactor: 1.3, IntervalDays: 3, Repetitions: 2, Lapses: 7},
+			grade:             models.ReviewGradeAgain,
+			expectedLeech:     true,
+			expectedSuspended: true,
+		},
+		{
+			name:    "lapse below threshold",
+			history: models.DictionaryHistory{WordID: 1, UserID: 1, EaseFactor: 1.3, IntervalDays: 3, Repetitions: 2, Lapses: 5},
+			grade:   models.ReviewGradeAgain,
+		},
+		{
+			name:          "successful review of a leech keeps the flag",
+			history:       models.DictionaryHistory{WordID: 1, UserID: 1, EaseFactor: 1.3, IntervalDays: 1, Lapses: 8, IsLeech: true},
+			grade:         models.ReviewGradeGood,
+			expectedLeech: true,
+		},
+		{
+			name:    "failed relearning of unsuspended leech is not a lapse",
+			history: models.DictionaryHistory{WordID: 1, UserID: 1, EaseFactor: 1.3, IntervalDays: 1, Lapses: 8},
+			grade:   models.ReviewGradeAgain,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := &mockDictionaryHistoryRepository{histories: []models.DictionaryHistory{tt.history}}
+			svc := NewDictionaryService(&mockWordRepository{valid: true}, &mockWordExampleRepository{}, &mockUserWordRepository{}, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			err := svc.SubmitWordResults(context.Background(), 1, []models.WordResult{{WordID: 1, Grade: tt.grade}})
+
+			require.NoError(t, err)
+			require.Len(t, historyRepo.upserted, 1)
+			assert.Equal(t, tt.expectedLeech, historyRepo.upserted[0].IsLeech)
+			assert.Equal(t, tt.expectedSuspended, historyRepo.upserted[0].Suspended)
+		})
+	}
+}
+
+func TestDictionaryService_GetLeeches(t *testing.T) {
+	nextAppearance := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("dictionary and custom leeches", func(t *testing.T) {
+		wordRepo := &mockWordRepository{words: []models.WordResponse{
+			{ID: 1, Word: "水", Translation: "water", Example: "{水|みず}を飲む"},
+		}}
+		userWordRepo := &mockUserWordRepository{words: []models.WordResponse{
+			{ID: 1, Word: "猫", Translation: "cat", IsCustom: true},
+		}}
+		historyRepo := &mockDictionaryHistoryRepository{leeches: []models.DictionaryHistory{
+			{UserWordID: 1, Lapses: 12, IsLeech: true, NextAppearance: nextAppearance},
+			{WordID: 1, Lapses: 8, IsLeech: true, Suspended: true, NextAppearance: nextAppearance},
+			{WordID: 2, Lapses: 8, IsLeech: true, Suspended: true, NextAppearance: nextAppearance},
+		}}
+		svc := NewDictionaryService(wordRepo, &mockWordExampleRepository{}, userWordRepo, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetLeeches(context.Background(), 1, "ru")
+
+		require.NoError(t, err)
+		assert.Equal(t, []models.Leech{
+			{Word: models.WordResponse{ID: 1, Word: "猫", Translation: "cat", IsCustom: true}, Lapses: 12, NextAppearance: nextAppearance},
+			{Word: models.WordResponse{ID: 1, Word: "水", Translation: "water", Example: "水を飲む"}, Lapses: 8, Suspended: true, NextAppearance: nextAppearance},
+		}, result)
+		assert.Equal(t, []string{"ru", "en"}, wordRepo.languages)
+	})
+
+	t.Run("no leeches", func(t *testing.T) {
+		svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetLeeches(context.Background(), 1, "en")
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{}, &mockDictionaryHistoryRepository{}, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetLeeches(context.Background(), 1, "french")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid locale")
+		assert.Nil(t, result)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		historyRepo := &mockDictionaryHistoryRepository{err: errors.New("database error")}
+		svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{}, historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+		result, err := svc.GetLeeches(context.Background(), 1, "en")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get leeches")
+		assert.Nil(t, result)
+	})
+}
+
+func TestDictionaryService_UpdateLeech(t *testing.T) {
+	updates := map[string]func(svc *dictionaryService) func(ctx context.Context, userId, wordId int, isCustom bool) error{
+		"unsuspend": func(svc *dictionaryService) func(ctx context.Context, userId, wordId int, isCustom bool) error {
+			return svc.UnsuspendLeech
+		},
+		"reset": func(svc *dictionaryService) func(ctx context.Context, userId, wordId int, isCustom bool) error {
+			return svc.ResetLeech
+		},
+	}
+
+	tests := []struct {
+		name          string
+		wordId        int
+		historyRepo   *mockDictionaryHistoryRepository
+		errorContains string
+	}{
+		{name: "success", wordId: 5, historyRepo: &mockDictionaryHistoryRepository{leechFound: true}},
+		{name: "not a leech", wordId: 5, historyRepo: &mockDictionaryHistoryRepository{}, errorContains: "leech not found"},
+		{name: "invalid word id", wordId: 0, historyRepo: &mockDictionaryHistoryRepository{}, errorContains: "invalid word id"},
+		{name: "repository error", wordId: 5, historyRepo: &mockDictionaryHistoryRepository{err: errors.New("database error")}, errorContains: "database error"},
+	}
+
+	for action, update := range updates {
+		for _, tt := range tests {
+			t.Run(action+" "+tt.name, func(t *testing.T) {
+				historyRepo := *tt.historyRepo
+				svc := NewDictionaryService(&mockWordRepository{}, &mockWordExampleRepository{}, &mockUserWordRepository{}, &historyRepo, &mockWordQuizSessionRepository{}, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+				err := update(svc)(context.Background(), 1, tt.wordId, true)
+
+				if tt.errorContains != "" {
+					assert.Error(t, err)
+					assert.Contains(t, err.Error(), tt.errorContains)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, 5, historyRepo.leechUpdate.wordId)
+					assert.True(t, historyRepo.leechUpdate.isCustom)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/transliteration"
+)
+
+// validateQuizBlockData validates data of a quiz block
+//
+// For successful results:
+//
+// - the data must be a JSON object with at least one question and passing score between 0 and 100
+//
+// - single choice questions must have at least 2 options and exactly one correct option
+//
+// - multiple choice questions must have at least 2 options and at least one correct option, all different
+//
+// - fill-in-the-blank questions must have at least one non-empty accepted answer
+//
+// - ordering questions must have at least 2 different options and correct options must list every option once
+func validateQuizBlockData(data json.RawMessage) error {
+	var quizData models.QuizBlockData
+	if err := json.Unmarshal(data, &quizData); err != nil {
+		return fmt.Errorf("invalid quiz block data")
+	}
+	if len(quizData.Questions) == 0 {
+		return fmt.Errorf("quiz must have at least one question")
+	}
+	if quizData.PassingScore < 0 || quizData.PassingScore > 100 {
+		return fmt.Errorf("passing score must be between 0 and 100")
+	}
+
+	for i, question := range quizData.Questions {
+		if err := validateQuizQuestion(question); err != nil {
+			return fmt.Errorf("invalid quiz question %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateQuizQuestion validates a single question of a quiz block
+func validateQuizQuestion(question models.QuizQuestion) error {
+	if strings.TrimSpace(question.Question) == "" {
+		return fmt.Errorf("question text is required")
+	}
+
+	switch question.Type {
+	case models.QuizQuestionTypeSingleChoice, models.QuizQuestionTypeMultipleChoice, models.QuizQuestionTypeOrdering:
+		if len(question.Options) < 2 {
+			return fmt.Errorf("at least 2 options are required")
+		}
+		if len(question.CorrectOptions) == 0 {
+			return fmt.Errorf("correct options are required")
+		}
+		seen := make(map[int]bool, len(question.CorrectOptions))
+		for _, option := range question.CorrectOptions {
+			if option < 0 || option >= len(question.Options) {
+				return fmt.Errorf("correct option is out of range")
+			}
+			if seen[option] {
+				return fmt.Errorf("correct options must be unique")
+			}
+			seen[option] = true
+		}
+		if question.Type == models.QuizQuestionTypeSingleChoice && len(question.CorrectOptions) != 1 {
+			return fmt.Errorf("single choice question must have exactly one correct option")
+		}
+		if question.Type == models.QuizQuestionTypeOrdering {
+			if len(question.CorrectOptions) != len(question.Options) {
+				return fmt.Errorf("correct order must include every option")
+			}
+			// Ordering answers are graded by option values, so equal options would make the order ambiguous
+			if len(slices.Compact(slices.Sorted(slices.Values(question.Options)))) != len(question.Options) {
+				return fmt.Errorf("ordering options must be unique")
+			}
+		}
+	case models.QuizQuestionTypeFillBlank:
+		if !slices.ContainsFunc(question.Answers, func(answer string) bool { return normalizeQuizAnswer(answer) != "" }) {
+			return fmt.Errorf("at least one accepted answer is required")
+		}
+	default:
+		return fmt.Errorf("invalid question type")
+	}
+	return nil
+}
+
+// hideQuizAnswers removes correct options and accepted answers from quiz blocks
+//
+// Options of ordering questions are shuffled, because the stored order may itself be the correct one.
+// Blocks with data other than a quiz are left as they are.
+func hideQuizAnswers(blocks []models.LessonBlockResponse) error {
+	for i, block := range blocks {
+		if block.BlockType != models.BlockTypeQuiz {
+			continue
+		}
+		var quizData models.QuizBlockData
+		if err := json.Unmarshal(block.BlockData, &quizData); err != nil {
+			continue
+		}
+		for j, question := range quizData.Questions {
+			if question.Type == models.QuizQuestionTypeOrdering {
+				quizData.Questions[j].Options = shuffleOrderingOptions(question)
+			}
+			quizData.Questions[j].CorrectOptions = nil
+			quizData.Questions[j].Answers = nil
+		}
+		data, err := json.Marshal(quizData)
+		if err != nil {
+			return fmt.Errorf("failed to marshal quiz block data: %w", err)
+		}
+		blocks[i].BlockData = data
+	}
+	return nil
+}
+
+// shuffleOrderingOptions returns options of an ordering question in a random order different from the correct one
+func shuffleOrderingOptions(question models.QuizQuestion) []string {
+	correct := correctOrder(question)
+	options := slices.Clone(question.Options)
+	// Options of a valid question are unique, so the loop ends with the probability of at least 1/2 on every pass
+	for slices.Equal(options, correct) {
+		rand.Shuffle(len(options), func(i, j int) {
+			options[i], options[j] = options[j], options[i]
+		})
+	}
+	return options
+}
+
+// correctOrder returns options of an ordering question in the correct order
+func correctOrder(question models.QuizQuestion) []string {
+	order := make([]string, 0, len(question.CorrectOptions))
+	for _, option := range question.CorrectOptions {
+		if option >= 0 && option < len(question.Options) {
+			order = append(order, question.Options[option])
+		}
+	}
+	return order
+}
+
+// gradeQuiz grades answers to the questions of a quiz
+//
+// Answers must be given in the order of the questions. Choice answers are correct when exactly
+// the correct options are chosen in any order, ordering answers must list the option values in the correct order.
+// Fill-in-the-blank answers are compared with accepted answers after normalization,
+// please reference isAcceptedQuizAnswer function for more information.
+func gradeQuiz(quizData *models.QuizBlockData, answers []models.QuizAnswer) *models.QuizResult {
+	result := &models.QuizResult{
+		PassingScore: quizData.PassingScore,
+		Correct:      make([]bool, len(quizData.Questions)),
+	}
+
+	correctCount := 0
+	for i, question := range quizData.Questions {
+		answer := answers[i]
+		switch question.Type {
+		case models.QuizQuestionTypeSingleChoice, models.QuizQuestionTypeMultipleChoice:
+			chosen := slices.Clone(answer.Options)
+			correct := slices.Clone(question.CorrectOptions)
+			slices.Sort(chosen)
+			slices.Sort(correct)
+			result.Correct[i] = slices.Equal(slices.Compact(chosen), correct)
+		case models.QuizQuestionTypeOrdering:
+			result.Correct[i] = slices.Equal(answer.Order, correctOrder(question))
+		case models.QuizQuestionTypeFillBlank:
+			result.Correct[i] = isAcceptedQuizAnswer(question.Answers, answer.Text)
+		}
+		if result.Correct[i] {
+			correctCount++
+		}
+	}
+
+	if len(quizData.Questions) > 0 {
+		result.Score = correctCount * 100 / len(quizData.Questions)
+	}
+	result.Passed = result.Score >= quizData.PassingScore
+	return result
+}
+
+// isAcceptedQuizAnswer checks a typed answer against the accepted answers of a fill-in-the-blank question
+//
+// Answers are compared after normalization, so letter case, width, extra spaces and katakana versus hiragana
+// do not matter, while kana must match exactly, e.g. "を" is not accepted for "お".
+// Answers typed in Latin or Cyrillic letters are also accepted if they are a valid romanization of the answer,
+// e.g. "neko" for "ねこ", distinctions which romanization does not keep are ignored for them.
+func isAcceptedQuizAnswer(accepted []string, answer string) bool {
+	normalized := normalizeQuizAnswer(answer)
+	if normalized == "" {
+		return false
+	}
+	romanized := strings.IndexFunc(normalized, func(r rune) bool { return unicode.In(r, unicode.Latin, unicode.Cyrillic) }) >= 0
+	for _, acceptedAnswer := range accepted {
+		normalizedAccepted := normalizeQuizAnswer(acceptedAnswer)
+		if normalizedAccepted == "" {
+			continue
+		}
+		if normalized == normalizedAccepted || (romanized && transliteration.Equivalent(normalizedAccepted, normalized)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeQuizAnswer normalizes a typed quiz answer for comparison
+//
+// Full-width Latin letters, digits and spaces are converted to their ASCII forms, spaces are collapsed,
+// katakana is converted to hiragana and the text is lowercased.
+func normalizeQuizAnswer(text string) string {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '　':
+			return ' '
+		case r >= '！' && r <= '～':
+			return r - 0xFEE0
+		}
+		return r
+	}, text)
+	return transliteration.ToHiragana(strings.ToLower(strings.Join(strings.Fields(text), " ")))
+}
+
+// SubmitQuiz grades answers to a quiz block of a lesson and stores the attempt
+//
+// Answers must be given for every question in the order of the questions.
+// Returns "quiz not found" error if the lesson has no quiz block with the ID.
+func (s *userLessonService) SubmitQuiz(ctx context.Context, lessonSlug string, blockID, userID int, answers []models.QuizAnswer) (*models.QuizResult, error) {
+	lesson, err := s.lessonRepo.GetBySlug(ctx, lessonSlug, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lesson: %w", err)
+	}
+	if err := s.checkUnlocked(ctx, lesson, userID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := s.blockRepo.GetByLessonID(ctx, lesson.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lesson blocks: %w", err)
+	}
+	index := slices.IndexFunc(blocks, func(block models.LessonBlockResponse) bool {
+		return block.ID == blockID && block.BlockType == models.BlockTypeQuiz
+	})
+	if index == -1 {
+		return nil, fmt.Errorf("quiz not found")
+	}
+	var quizData models.QuizBlockData
+	if err := json.Unmarshal(blocks[index].BlockData, &quizData); err != nil {
+		return nil, fmt.Errorf("invalid quiz block data")
+	}
+
+	if len(answers) != len(quizData.Questions) {
+		return nil, fmt.Errorf("answers count must match questions count")
+	}
+	result := gradeQuiz(&quizData, answers)
+
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal quiz answers: %w", err)
+	}
+	attempt := &models.LessonQuizAttempt{
+		UserID:  userID,
+		BlockID: blockID,
+		Score:   result.Score,
+		Answers: answersJSON,
+	}
+	if err := s.quizAttemptRepo.Create(ctx, attempt); err != nil {
+		return nil, fmt.Errorf("failed to save quiz attempt: %w", err)
+	}
+
+	return result, nil
+}
+
+// checkQuizzesPassed returns "lesson quizzes are not passed" error if the best score of the user
+// in any quiz block of the lesson with a passing score is lower than it
+func (s *userLessonService) checkQuizzesPassed(ctx context.Context, lessonID, userID int) error {
+	blocks, err := s.blockRepo.GetByLessonID(ctx, lessonID)
+	if err != nil {
+		return fmt.Errorf("failed to get lesson blocks: %w", err)
+	}
+
+	passingScores := make(map[int]int)
+	var blockIDs []int
+	for _, block := range blocks {
+		if block.BlockType != models.BlockTypeQuiz {
+			continue
+		}
+		var quizData models.QuizBlockData
+		if err := json.Unmarshal(block.BlockData, &quizData); err != nil || quizData.PassingScore == 0 {
+			continue
+		}
+		passingScores[block.ID] = quizData.PassingScore
+		blockIDs = append(blockIDs, block.ID)
+	}
+	if len(blockIDs) == 0 {
+		return nil
+	}
+
+	bestScores, err := s.quizAttemptRepo.GetBestScores(ctx, userID, blockIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get quiz scores: %w", err)
+	}
+	for _, blockID := range blockIDs {
+		if score, ok := bestScores[blockID]; !ok || score < passingScores[blockID] {
+			return fmt.Errorf("lesson quizzes are not passed")
+		}
+	}
+	return nil
+}
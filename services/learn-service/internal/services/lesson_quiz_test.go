@@ -0,0 +1,425 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testQuizData is quiz block data with a question of every type
+const testQuizData = `{
+	"passingScore": 75,
+	"questions": [
+		{"type": "single_choice", "question": "猫?", "options": ["dog", "cat", "bird"], "correctOptions": [1]},
+		{"type": "multiple_choice", "question": "Kana?", "options": ["あ", "A", "ア"], "correctOptions": [0, 2]},
+		{"type": "fill_blank", "question": "ねこ in katakana?", "answers": ["ネコ"]},
+		{"type": "ordering", "question": "Order", "options": ["は", "わたし", "がくせい", "です"], "correctOptions": [1, 0, 2, 3]}
+	]
+}`
+
+func TestValidateQuizBlockData(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		errorContains string
+	}{
+		{name: "question of every type", data: testQuizData},
+		{name: "not an object", data: `"quiz"`, errorContains: "invalid quiz block data"},
+		{name: "no questions", data: `{"questions": []}`, errorContains: "quiz must have at least one question"},
+		{
+			name:          "passing score out of range",
+			data:          `{"passingScore": 101, "questions": [{"type": "fill_blank", "question": "Q", "answers": ["a"]}]}`,
+			errorContains: "passing score must be between 0 and 100",
+		},
+		{
+			name:          "empty question text",
+			data:          `{"questions": [{"type": "fill_blank", "question": " ", "answers": ["a"]}]}`,
+			errorContains: "question text is required",
+		},
+		{
+			name:          "invalid question type",
+			data:          `{"questions": [{"type": "essay", "question": "Q"}]}`,
+			errorContains: "invalid quiz question 1: invalid question type",
+		},
+		{
+			name:          "single option",
+			data:          `{"questions": [{"type": "single_choice", "question": "Q", "options": ["a"], "correctOptions": [0]}]}`,
+			errorContains: "at least 2 options are required",
+		},
+		{
+			name:          "no correct options",
+			data:          `{"questions": [{"type": "multiple_choice", "question": "Q", "options": ["a", "b"]}]}`,
+			errorContains: "correct options are required",
+		},
+		{
+			name:          "correct option out of range",
+			data:          `{"questions": [{"type": "single_choice", "question": "Q", "options": ["a", "b"], "correctOptions": [2]}]}`,
+			errorContains: "correct option is out of range",
+		},
+		{
+			name:          "duplicated correct options",
+			data:          `{"questions": [{"type": "multiple_choice", "question": "Q", "options": ["a", "b"], "correctOptions": [1, 1]}]}`,
+			errorContains: "correct options must be unique",
+		},
+		{
+			name:          "single choice with several correct options",
+			data:          `{"questions": [{"type": "single_choice", "question": "Q", "options": ["a", "b"], "correctOptions": [0, 1]}]}`,
+			errorContains: "exactly one correct option",
+		},
+		{
+			name:          "incomplete correct order",
+			data:          `{"questions": [{"type": "ordering", "question": "Q", "options": ["a", "b", "c"], "correctOptions": [2, 0]}]}`,
+			errorContains: "correct order must include every option",
+		},
+		{
+			name:          "equal ordering options",
+			data:          `{"questions": [{"type": "ordering", "question": "Q", "options": ["a", "b", "a"], "correctOptions": [0, 1, 2]}]}`,
+			errorContains: "ordering options must be unique",
+		},
+		{
+			name:          "fill blank without answers",
+			data:          `{"questions": [{"type": "fill_blank", "question": "Q", "answers": ["  "]}]}`,
+			errorContains: "at least one accepted answer is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateQuizBlockData(json.RawMessage(tt.data))
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGradeQuiz(t *testing.T) {
+	var quizData models.QuizBlockData
+	require.NoError(t, json.Unmarshal([]byte(testQuizData), &quizData))
+
+	tests := []struct {
+		name            string
+		answers         []models.QuizAnswer
+		expectedScore   int
+		expectedPassed  bool
+		expectedCorrect []bool
+	}{
+		{
+			name: "all correct",
+			answers: []models.QuizAnswer{
+				{Options: []int{1}},
+				{Options: []int{2, 0}},
+				{Text: "ねこ"},
+				{Order: []string{"わたし", "は", "がくせい", "です"}},
+			},
+			expectedScore:   100,
+			expectedPassed:  true,
+			expectedCorrect: []bool{true, true, true, true},
+		},
+		{
+			name: "partially chosen multiple choice and wrong order",
+			answers: []models.QuizAnswer{
+				{Options: []int{1}},
+				{Options: []int{0}},
+				{Text: "neko"},
+				{Order: []string{"は", "わたし", "がくせい", "です"}},
+			},
+			expectedScore:   50,
+			expectedPassed:  false,
+			expectedCorrect: []bool{true, false, true, false},
+		},
+		{
+			name: "extra option chosen",
+			answers: []models.QuizAnswer{
+				{Options: []int{1, 2}},
+				{Options: []int{0, 1, 2}},
+				{Text: "いぬ"},
+				{},
+			},
+			expectedScore:   0,
+			expectedPassed:  false,
+			expectedCorrect: []bool{false, false, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := gradeQuiz(&quizData, tt.answers)
+
+			assert.Equal(t, tt.expectedScore, result.Score)
+			assert.Equal(t, 75, result.PassingScore)
+			assert.Equal(t, tt.expectedPassed, result.Passed)
+			assert.Equal(t, tt.expectedCorrect, result.Correct)
+		})
+	}
+}
+
+func TestIsAcceptedQuizAnswer(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted []string
+		answer   string
+		expected bool
+	}{
+		{name: "exact answer", accepted: []string{"ねこ"}, answer: "ねこ", expected: true},
+		{name: "katakana answer", accepted: []string{"ねこ"}, answer: "ネコ", expected: true},
+		{name: "romanized answer", accepted: []string{"ねこ"}, answer: " Neko ", expected: true},
+		{name: "other romanization system", accepted: []string{"ふじさん"}, answer: "huzisan", expected: true},
+		{name: "full-width answer", accepted: []string{"Tokyo tower"}, answer: "ＴＯＫＹＯ　ｔｏｗｅｒ", expected: true},
+		{name: "extra spaces", accepted: []string{"New York"}, answer: "new   york", expected: true},
+		{name: "any accepted answer", accepted: []string{"猫", "ねこ"}, answer: "猫", expected: true},
+		{name: "particle を is not お", accepted: []string{"を"}, answer: "お", expected: false},
+		{name: "particle を in katakana", accepted: []string{"を"}, answer: "ヲ", expected: true},
+		{name: "づ is not ず", accepted: []string{"つづく"}, answer: "つずく", expected: false},
+		{name: "long vowel spelling", accepted: []string{"とおり"}, answer: "とうり", expected: false},
+		{name: "romanized づ", accepted: []string{"つづく"}, answer: "tsuzuku", expected: true},
+		{name: "wrong answer", accepted: []string{"ねこ"}, answer: "いぬ", expected: false},
+		{name: "empty answer", accepted: []string{"ねこ"}, answer: " ", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isAcceptedQuizAnswer(tt.accepted, tt.answer))
+		})
+	}
+}
+
+func TestUserLessonService_GetLesson_HidesQuizAnswers(t *testing.T) {
+	lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1, Completed: true}}
+	blockRepo := &mockLessonBlockRepository{blocks: []models.LessonBlockResponse{
+		{ID: 5, BlockType: models.BlockTypeQuiz, BlockOrder: 1, BlockData: json.RawMessage(testQuizData)},
+	}}
+	svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo, blockRepo, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+	_, blocks, err := svc.GetLesson(context.Background(), "test-lesson", 1, "", 0)
+
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.NotContains(t, string(blocks[0].BlockData), "correctOptions")
+	assert.NotContains(t, string(blocks[0].BlockData), "answers")
+
+	var quizData models.QuizBlockData
+	require.NoError(t, json.Unmarshal(blocks[0].BlockData, &quizData))
+	assert.Equal(t, 75, quizData.PassingScore)
+	if assert.Len(t, quizData.Questions, 4) {
+		assert.Equal(t, []string{"dog", "cat", "bird"}, quizData.Questions[0].Options)
+		assert.ElementsMatch(t, []string{"は", "わたし", "がくせい", "です"}, quizData.Questions[3].Options)
+		assert.NotEqual(t, []string{"わたし", "は", "がくせい", "です"}, quizData.Questions[3].Options)
+	}
+}
+
+func TestShuffleOrderingOptions(t *testing.T) {
+	// Options entered in their natural order are the most common case, the shown order must never be the correct one
+	question := models.QuizQuestion{
+		Type:           models.QuizQuestionTypeOrdering,
+		Question:       "Order",
+		Options:        []string{"first", "second"},
+		CorrectOptions: []int{0, 1},
+	}
+
+	for range 20 {
+		options := shuffleOrderingOptions(question)
+
+		assert.Equal(t, []string{"second", "first"}, options)
+	}
+	assert.Equal(t, []string{"first", "second"}, question.Options)
+}
+
+func TestUserLessonService_SubmitQuiz(t *testing.T) {
+	blocks := []models.LessonBlockResponse{
+		{ID: 4, BlockType: models.BlockTypeText, BlockOrder: 1, BlockData: json.RawMessage(`{"content":"猫"}`)},
+		{ID: 5, BlockType: models.BlockTypeQuiz, BlockOrder: 2, BlockData: json.RawMessage(testQuizData)},
+	}
+	answers := []models.QuizAnswer{
+		{Options: []int{1}},
+		{Options: []int{0, 2}},
+		{Text: "ネコ"},
+		{Order: []string{"は", "わたし", "がくせい", "です"}},
+	}
+
+	tests := []struct {
+		name            string
+		blockID         int
+		answers         []models.QuizAnswer
+		lessonRepo      *mockLessonRepository
+		blockRepo       *mockLessonBlockRepository
+		quizAttemptRepo *mockLessonQuizAttemptRepository
+		expectedScore   int
+		errorContains   string
+	}{
+		{
+			name:            "success",
+			blockID:         5,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			expectedScore:   75,
+		},
+		{
+			name:            "lesson not found",
+			blockID:         5,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{getBySlugErr: errors.New("lesson not found")},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			errorContains:   "failed to get lesson: lesson not found",
+		},
+		{
+			name:            "block is not a quiz",
+			blockID:         4,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			errorContains:   "quiz not found",
+		},
+		{
+			name:            "block of another lesson",
+			blockID:         6,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			errorContains:   "quiz not found",
+		},
+		{
+			name:            "answers count mismatch",
+			blockID:         5,
+			answers:         answers[:2],
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			errorContains:   "answers count must match questions count",
+		},
+		{
+			name:            "blocks error",
+			blockID:         5,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{err: errors.New("database error")},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+			errorContains:   "failed to get lesson blocks",
+		},
+		{
+			name:            "attempt save error",
+			blockID:         5,
+			answers:         answers,
+			lessonRepo:      &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}},
+			blockRepo:       &mockLessonBlockRepository{blocks: blocks},
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{createErr: errors.New("database error")},
+			errorContains:   "failed to save quiz attempt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserLessonService(&mockCourseRepository{}, tt.lessonRepo, tt.blockRepo, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, tt.quizAttemptRepo, &mockKanjiLevelRepository{})
+
+			result, err := svc.SubmitQuiz(context.Background(), "test-lesson", tt.blockID, 1, tt.answers)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedScore, result.Score)
+				assert.True(t, result.Passed)
+				assert.Equal(t, []bool{true, true, true, false}, result.Correct)
+				assert.True(t, tt.quizAttemptRepo.createCalled)
+				assert.Equal(t, tt.expectedScore, tt.quizAttemptRepo.createdScore)
+			}
+		})
+	}
+}
+
+func TestUserLessonService_SubmitQuiz_LockedLesson(t *testing.T) {
+	lessonRepo := &mockLessonRepository{
+		lesson: &models.LessonListItem{ID: 2, CourseID: 1},
+		lessons: []models.LessonListItem{
+			{ID: 1, Order: 1},
+			{ID: 2, Order: 2},
+		},
+	}
+	courseRepo := &mockCourseRepository{navigation: models.CourseNavigationSequential}
+	quizAttemptRepo := &mockLessonQuizAttemptRepository{}
+	svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, quizAttemptRepo, &mockKanjiLevelRepository{})
+
+	result, err := svc.SubmitQuiz(context.Background(), "test-lesson", 5, 1, nil)
+
+	assert.EqualError(t, err, "lesson is locked")
+	assert.Nil(t, result)
+	assert.False(t, quizAttemptRepo.createCalled)
+}
+
+func TestUserLessonService_ToggleLessonCompletion_Quizzes(t *testing.T) {
+	blocks := []models.LessonBlockResponse{
+		{ID: 5, BlockType: models.BlockTypeQuiz, BlockOrder: 1, BlockData: json.RawMessage(testQuizData)},
+		{ID: 6, BlockType: models.BlockTypeQuiz, BlockOrder: 2, BlockData: json.RawMessage(`{"questions":[{"type":"fill_blank","question":"Q","answers":["a"]}]}`)},
+	}
+
+	tests := []struct {
+		name             string
+		exists           bool
+		quizAttemptRepo  *mockLessonQuizAttemptRepository
+		expectedBlockIDs []int
+		errorContains    string
+		shouldCreate     bool
+	}{
+		{
+			name:             "passing score reached",
+			quizAttemptRepo:  &mockLessonQuizAttemptRepository{bestScores: map[int]int{5: 75}},
+			expectedBlockIDs: []int{5},
+			shouldCreate:     true,
+		},
+		{
+			name:             "passing score not reached",
+			quizAttemptRepo:  &mockLessonQuizAttemptRepository{bestScores: map[int]int{5: 50}},
+			expectedBlockIDs: []int{5},
+			errorContains:    "lesson quizzes are not passed",
+		},
+		{
+			name:             "quiz not attempted",
+			quizAttemptRepo:  &mockLessonQuizAttemptRepository{bestScores: map[int]int{}},
+			expectedBlockIDs: []int{5},
+			errorContains:    "lesson quizzes are not passed",
+		},
+		{
+			name:             "scores error",
+			quizAttemptRepo:  &mockLessonQuizAttemptRepository{scoresErr: errors.New("database error")},
+			expectedBlockIDs: []int{5},
+			errorContains:    "failed to get quiz scores",
+		},
+		{
+			name:            "uncompleting does not check quizzes",
+			exists:          true,
+			quizAttemptRepo: &mockLessonQuizAttemptRepository{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}}
+			historyRepo := &mockLessonUserHistoryRepository{exists: tt.exists}
+			svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo, &mockLessonBlockRepository{blocks: blocks}, historyRepo, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, tt.quizAttemptRepo, &mockKanjiLevelRepository{})
+
+			err := svc.ToggleLessonCompletion(context.Background(), "test-lesson", 1)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.shouldCreate, historyRepo.createCalled)
+			assert.Equal(t, tt.expectedBlockIDs, tt.quizAttemptRepo.scoresBlockIDs)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// LocaleConfig holds settings of localized content: word and example translations and character readings
+//
+// Content missing in the requested locale is taken from its fallbacks, then from the base language
+// of a regional locale ("pt" for "pt-br") and finally from the default locale.
+type LocaleConfig struct {
+	Default   string              // Locale used when content has no translation in the requested locale and its fallbacks
+	Fallbacks map[string][]string // Locales tried in order before the default one, by requested locale
+}
+
+// DefaultLocaleConfig returns the locale configuration used when nothing else is configured
+func DefaultLocaleConfig() LocaleConfig {
+	return LocaleConfig{
+		Default: "en",
+	}
+}
+
+// Chain returns languages in which localized content is looked up for the locale, in order of preference
+//
+// The chain starts with the locale itself and contains no duplicates.
+func (c LocaleConfig) Chain(locale string) []string {
+	chain := []string{locale}
+	add := func(language string) {
+		if language != "" && !slices.Contains(chain, language) {
+			chain = append(chain, language)
+		}
+	}
+
+	for _, fallback := range c.Fallbacks[locale] {
+		add(fallback)
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		add(base)
+	}
+	add(c.Default)
+	return chain
+}
+
+// normalizeLocale converts the locale of a request to the lowercase form used by translations
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.TrimSpace(locale))
+}
+
+// parseLocale normalizes the locale of a request
+//
+// If the locale is not a valid language code, such as "en" or "pt-br", the error will be returned.
+func parseLocale(locale string) (string, error) {
+	normalized := normalizeLocale(locale)
+	if !models.Locale(normalized).IsValid() {
+		return "", fmt.Errorf("invalid locale: %s", locale)
+	}
+	return normalized, nil
+}
+
+// normalizeLanguages lowercases language codes of translations and validates them
+//
+// A nil map stays nil, so partial updates can leave translations unchanged.
+// If some language code is invalid, the error will be returned.
+func normalizeLanguages[T any](translations map[string]T) (map[string]T, error) {
+	if translations == nil {
+		return nil, nil
+	}
+
+	normalized := make(map[string]T, len(translations))
+	for _, language := range slices.Sorted(maps.Keys(translations)) {
+		code := normalizeLocale(language)
+		if !models.Locale(code).IsValid() {
+			return nil, fmt.Errorf("validation error: invalid language: %s", language)
+		}
+		normalized[code] = translations[language]
+	}
+	return normalized, nil
+}
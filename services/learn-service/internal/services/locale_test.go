@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocaleConfig_Chain(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   LocaleConfig
+		locale   string
+		expected []string
+	}{
+		{
+			name:     "default locale",
+			config:   DefaultLocaleConfig(),
+			locale:   "en",
+			expected: []string{"en"},
+		},
+		{
+			name:     "locale without fallbacks",
+			config:   DefaultLocaleConfig(),
+			locale:   "fr",
+			expected: []string{"fr", "en"},
+		},
+		{
+			name:     "configured fallbacks",
+			config:   LocaleConfig{Default: "en", Fallbacks: map[string][]string{"uk": {"ru", "en"}}},
+			locale:   "uk",
+			expected: []string{"uk", "ru", "en"},
+		},
+		{
+			name:     "regional locale falls back to its base language",
+			config:   LocaleConfig{Default: "de", Fallbacks: map[string][]string{"pt-br": {"es"}}},
+			locale:   "pt-br",
+			expected: []string{"pt-br", "es", "pt", "de"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.Chain(tt.locale))
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	locale, err := parseLocale(" PT-BR ")
+	require.NoError(t, err)
+	assert.Equal(t, "pt-br", locale)
+
+	for _, invalid := range []string{"", "english", "en_US", "e"} {
+		_, err := parseLocale(invalid)
+		assert.ErrorContains(t, err, "invalid locale", invalid)
+	}
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	normalized, err := normalizeLanguages(map[string]string{"EN": "water", "pt-BR": "água"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"en": "water", "pt-br": "água"}, normalized)
+
+	normalized, err = normalizeLanguages[string](nil)
+	require.NoError(t, err)
+	assert.Nil(t, normalized)
+
+	_, err = normalizeLanguages(map[string]string{"english": "water"})
+	assert.EqualError(t, err, "validation error: invalid language: english")
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"math"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// SM-2 scheduling parameters
+const (
+	// initialEaseFactor is the ease factor of a word reviewed for the first time
+	initialEaseFactor = 2.5
+	// minEaseFactor keeps intervals of difficult words from shrinking forever
+	minEaseFactor = 1.3
+	// maxReviewInterval is the longest interval between reviews in days
+	maxReviewInterval = 365
+)
+
+// scheduleReview computes the next review state of a word from the current one and the grade of the review
+//
+// The schedule follows the SM-2 algorithm. Grades 1-4 correspond to SM-2 qualities 2-5, so "again" is a failed recall.
+// A failed recall starts the word over with a one day interval and counts a lapse if the word was already learned.
+// Successful recalls give intervals of 1 and 6 days, then the previous interval is multiplied by the ease factor.
+// The ease factor grows after easy recalls and shrinks after hard and failed ones.
+//
+// "history" parameter is the current state, a zero value means the word has never been reviewed.
+// The grade is expected to be valid, please reference ReviewGrade constants.
+func scheduleReview(history models.DictionaryHistory, grade int) models.DictionaryHistory {
+	if history.EaseFactor == 0 {
+		history.EaseFactor = initialEaseFactor
+	}
+	quality := float64(grade + 1)
+
+	if grade == models.ReviewGradeAgain {
+		if history.Repetitions > 0 {
+			history.Lapses++
+		}
+		history.Repetitions = 0
+		history.IntervalDays = 1
+	} else {
+		history.Repetitions++
+		switch history.Repetitions {
+		case 1:
+			history.IntervalDays = 1
+		case 2:
+			history.IntervalDays = 6
+		default:
+			history.IntervalDays = int(math.Round(float64(history.IntervalDays) * history.EaseFactor))
+		}
+		history.IntervalDays = min(history.IntervalDays, maxReviewInterval)
+	}
+
+	history.EaseFactor += 0.1 - (5-quality)*(0.08+(5-quality)*0.02)
+	history.EaseFactor = max(history.EaseFactor, minEaseFactor)
+	return history
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		history  models.DictionaryHistory
+		grade    int
+		expected models.DictionaryHistory
+	}{
+		{
+			name:     "new word recalled",
+			history:  models.DictionaryHistory{WordID: 1},
+			grade:    models.ReviewGradeGood,
+			expected: models.DictionaryHistory{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+		},
+		{
+			name:     "new word recalled easily",
+			history:  models.DictionaryHistory{WordID: 1},
+			grade:    models.ReviewGradeEasy,
+			expected: models.DictionaryHistory{WordID: 1, EaseFactor: 2.6, IntervalDays: 1, Repetitions: 1},
+		},
+		{
+			name:     "new word forgotten is not a lapse",
+			history:  models.DictionaryHistory{WordID: 1},
+			grade:    models.ReviewGradeAgain,
+			expected: models.DictionaryHistory{WordID: 1, EaseFactor: 2.18, IntervalDays: 1},
+		},
+		{
+			name:     "second recall gives six days",
+			history:  models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+			grade:    models.ReviewGradeGood,
+			expected: models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+		},
+		{
+			name:     "later recall multiplies interval by ease factor",
+			history:  models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+			grade:    models.ReviewGradeGood,
+			expected: models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 15, Repetitions: 3},
+		},
+		{
+			name:     "hard recall lowers ease factor",
+			history:  models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
+			grade:    models.ReviewGradeHard,
+			expected: models.DictionaryHistory{EaseFactor: 2.36, IntervalDays: 15, Repetitions: 3},
+		},
+		{
+			name:     "learned word forgotten counts a lapse",
+			history:  models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 15, Repetitions: 3, Lapses: 1},
+			grade:    models.ReviewGradeAgain,
+			expected: models.DictionaryHistory{EaseFactor: 2.18, IntervalDays: 1, Lapses: 2},
+		},
+		{
+			name:     "ease factor does not fall below minimum",
+			history:  models.DictionaryHistory{EaseFactor: 1.4, IntervalDays: 3, Repetitions: 3},
+			grade:    models.ReviewGradeAgain,
+			expected: models.DictionaryHistory{EaseFactor: 1.3, IntervalDays: 1, Lapses: 1},
+		},
+		{
+			name:     "interval is capped",
+			history:  models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 300, Repetitions: 5},
+			grade:    models.ReviewGradeGood,
+			expected: models.DictionaryHistory{EaseFactor: 2.5, IntervalDays: 365, Repetitions: 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := scheduleReview(tt.history, tt.grade)
+
+			assert.Equal(t, tt.expected.WordID, result.WordID)
+			assert.InDelta(t, tt.expected.EaseFactor, result.EaseFactor, 1e-9)
+			assert.Equal(t, tt.expected.IntervalDays, result.IntervalDays)
+			assert.Equal(t, tt.expected.Repetitions, result.Repetitions)
+			assert.Equal(t, tt.expected.Lapses, result.Lapses)
+		})
+	}
+}
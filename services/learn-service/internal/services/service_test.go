@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,9 @@ import (
 type mockHistoryRepository struct {
 	histories     []models.CharacterLearnHistory
 	userHistories []models.UserLearnHistory
+	upserted      []models.CharacterLearnHistory
+	attempts      []models.CharacterTestAttempt
+	upsertErr     error
 	err           error
 }
 
@@ -30,10 +34,15 @@ func (m *mockHistoryRepository) GetByUserID(ctx context.Context, userID int) ([]
 	return m.userHistories, nil
 }
 
-func (m *mockHistoryRepository) Upsert(ctx context.Context, histories []models.CharacterLearnHistory) error {
+func (m *mockHistoryRepository) Upsert(ctx context.Context, histories []models.CharacterLearnHistory, attempts []models.CharacterTestAttempt) error {
 	if m.err != nil {
 		return m.err
 	}
+	if m.upsertErr != nil {
+		return m.upsertErr
+	}
+	m.upserted = histories
+	m.attempts = attempts
 	return nil
 }
 
@@ -41,6 +50,71 @@ func (m *mockHistoryRepository) LowerResultsByUserID(ctx context.Context, userID
 	return m.err
 }
 
+// mockAttemptRepository is a mock implementation of CharacterTestAttemptRepository
+type mockAttemptRepository struct {
+	attempts []models.CharacterTestAttempt
+	points   []models.CharacterAccuracyPoint
+	err      error
+}
+
+func (m *mockAttemptRepository) GetByUserID(ctx context.Context, userID, characterID, page, count int) ([]models.CharacterTestAttempt, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.attempts, nil
+}
+
+func (m *mockAttemptRepository) GetAccuracyByDay(ctx context.Context, userID, characterID, days int) ([]models.CharacterAccuracyPoint, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.points, nil
+}
+
+// mockTestSessionRepository is a mock implementation of TestSessionRepository
+type mockTestSessionRepository struct {
+	session      *models.TestSession
+	created      *models.TestSession
+	alreadyTaken bool
+	cleared      bool
+	clearErr     error
+	err          error
+}
+
+func (m *mockTestSessionRepository) Create(ctx context.Context, session *models.TestSession) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.created = session
+	return nil
+}
+
+func (m *mockTestSessionRepository) GetByID(ctx context.Context, id string) (*models.TestSession, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.session == nil || m.session.ID != id {
+		return nil, errors.New("test session not found")
+	}
+	return m.session, nil
+}
+
+func (m *mockTestSessionRepository) MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return !m.alreadyTaken, nil
+}
+
+func (m *mockTestSessionRepository) ClearSubmitted(ctx context.Context, id string) error {
+	m.cleared = true
+	return m.clearErr
+}
+
+func (m *mockTestSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error {
+	return m.err
+}
+
 // mockCharactersRepository is a mock implementation of TestResultCharactersRepository
 type mockCharactersRepository struct {
 	totalCount int
@@ -54,7 +128,168 @@ func (m *mockCharactersRepository) GetTotalCount(ctx context.Context) (int, erro
 	return m.totalCount, nil
 }
 
-// ... existing code ...
+func TestTestResultService_SubmitTestResults(t *testing.T) {
+	mastered := models.UserLearnHistory{
+		HiraganaReadingResult:   0.9,
+		HiraganaWritingResult:   0.9,
+		HiraganaListeningResult: 0.9,
+		KatakanaReadingResult:   0.9,
+		KatakanaWritingResult:   0.9,
+		KatakanaListeningResult: 0.9,
+	}
+	almostMastered := mastered
+	almostMastered.KatakanaListeningResult = 0.79
+
+	tests := []struct {
+		name           string
+		alphabetType   string
+		testType       string
+		results        []models.TestResultItem
+		repeat         string
+		mockRepo       *mockHistoryRepository
+		totalCount     int
+		expectedError  bool
+		errorContains  string
+		expectedScores map[int]float32
+		expectedRepeat bool
+	}{
+		{
+			name:         "new record moves score one step from zero",
+			alphabetType: "hiragana",
+			testType:     "reading",
+			results: []models.TestResultItem{
+				{CharacterID: 1, Passed: true},
+				{CharacterID: 2, Passed: false},
+			},
+			mockRepo:       &mockHistoryRepository{},
+			totalCount:     46,
+			expectedScores: map[int]float32{1: 0.3, 2: 0},
+		},
+		{
+			name:         "existing score moves towards the answer",
+			alphabetType: "hiragana",
+			testType:     "reading",
+			results: []models.TestResultItem{
+				{CharacterID: 1, Passed: true},
+				{CharacterID: 2, Passed: false},
+			},
+			mockRepo: &mockHistoryRepository{
+				histories: []models.CharacterLearnHistory{
+					{UserID: 1, CharacterID: 1, HiraganaReadingResult: 0.5},
+					{UserID: 1, CharacterID: 2, HiraganaReadingResult: 1},
+				},
+			},
+			totalCount:     46,
+			expectedScores: map[int]float32{1: 0.65, 2: 0.7},
+		},
+		{
+			name:         "ask for repeat when all characters are above threshold",
+			alphabetType: "katakana",
+			testType:     "listening",
+			results:      []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			mockRepo: &mockHistoryRepository{
+				userHistories: []models.UserLearnHistory{mastered, mastered},
+			},
+			totalCount:     2,
+			expectedRepeat: true,
+		},
+		{
+			name:         "no repeat when one category is below threshold",
+			alphabetType: "katakana",
+			testType:     "listening",
+			results:      []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			mockRepo: &mockHistoryRepository{
+				userHistories: []models.UserLearnHistory{mastered, almostMastered},
+			},
+			totalCount:     2,
+			expectedRepeat: false,
+		},
+		{
+			name:         "no completion check when repeat is not in question",
+			alphabetType: "katakana",
+			testType:     "listening",
+			results:      []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			repeat:       "ignore",
+			mockRepo: &mockHistoryRepository{
+				userHistories: []models.UserLearnHistory{mastered, mastered},
+			},
+			totalCount:     2,
+			expectedRepeat: false,
+		},
+		{
+			name:          "invalid alphabet type",
+			alphabetType:  "kanji",
+			testType:      "reading",
+			results:       []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			mockRepo:      &mockHistoryRepository{},
+			expectedError: true,
+			errorContains: "invalid alphabet type",
+		},
+		{
+			name:          "invalid test type",
+			alphabetType:  "hiragana",
+			testType:      "speaking",
+			results:       []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			mockRepo:      &mockHistoryRepository{},
+			expectedError: true,
+			errorContains: "invalid test type",
+		},
+		{
+			name:          "repository error",
+			alphabetType:  "hiragana",
+			testType:      "reading",
+			results:       []models.TestResultItem{{CharacterID: 1, Passed: true}},
+			mockRepo:      &mockHistoryRepository{err: errors.New("database error")},
+			expectedError: true,
+			errorContains: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCharRepo := &mockCharactersRepository{totalCount: tt.totalCount}
+			svc := NewTestResultService(tt.mockRepo, mockCharRepo, &mockAttemptRepository{}, &mockTestSessionRepository{}, DefaultMasteryConfig())
+			ctx := context.Background()
+
+			result, err := svc.SubmitTestResults(ctx, 1, tt.alphabetType, tt.testType, tt.results, tt.repeat)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errorContains != "" {
+					assert.Contains(t, err.Error(), tt.errorContains)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Equal(t, tt.expectedRepeat, result.AskForRepeat)
+			for _, history := range tt.mockRepo.upserted {
+				if expected, ok := tt.expectedScores[history.CharacterID]; ok {
+					assert.InDelta(t, expected, history.HiraganaReadingResult, 0.0001)
+				}
+			}
+		})
+	}
+}
+
+func TestTestResultService_NextScore(t *testing.T) {
+	svc := NewTestResultService(&mockHistoryRepository{}, &mockCharactersRepository{}, &mockAttemptRepository{}, &mockTestSessionRepository{}, DefaultMasteryConfig())
+
+	// A single lucky answer must not reach the pass threshold
+	score := svc.nextScore(0, true)
+	assert.Less(t, score, DefaultMasteryConfig().PassThreshold)
+
+	// Consecutive correct answers eventually reach it
+	for range 4 {
+		score = svc.nextScore(score, true)
+	}
+	assert.GreaterOrEqual(t, score, DefaultMasteryConfig().PassThreshold)
+
+	// Scores never leave the [0, 1] range
+	assert.Equal(t, float32(0), svc.nextScore(0, false))
+	assert.LessOrEqual(t, svc.nextScore(1, true), float32(1))
+}
 
 func TestTestResultService_DropUserMarks(t *testing.T) {
 	tests := []struct {
@@ -86,7 +321,7 @@ func TestTestResultService_DropUserMarks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCharRepo := &mockCharactersRepository{totalCount: 46}
-			svc := NewTestResultService(tt.mockRepo, mockCharRepo)
+			svc := NewTestResultService(tt.mockRepo, mockCharRepo, &mockAttemptRepository{}, &mockTestSessionRepository{}, DefaultMasteryConfig())
 			ctx := context.Background()
 
 			err := svc.DropUserMarks(ctx, tt.userID)
@@ -102,3 +337,365 @@ func TestTestResultService_DropUserMarks(t *testing.T) {
 		})
 	}
 }
+
+func TestTestResultService_SubmitTestResults_LogsAttempts(t *testing.T) {
+	historyRepo := &mockHistoryRepository{}
+	svc := NewTestResultService(historyRepo, &mockCharactersRepository{totalCount: 46}, &mockAttemptRepository{}, &mockTestSessionRepository{}, DefaultMasteryConfig())
+
+	_, err := svc.SubmitTestResults(context.Background(), 7, "Katakana", "Reading", []models.TestResultItem{
+		{CharacterID: 1, Passed: true, ChosenOption: "ツ", ResponseTimeMs: 1200},
+		{CharacterID: 2, Passed: false, ChosenOption: "ソ", ResponseTimeMs: 3400},
+	}, "ignore")
+
+	assert.NoError(t, err)
+	assert.Len(t, historyRepo.attempts, 2)
+	assert.Equal(t, 7, historyRepo.attempts[0].UserID)
+	assert.Equal(t, "katakana", historyRepo.attempts[0].AlphabetType)
+	assert.Equal(t, "reading", historyRepo.attempts[0].TestType)
+	assert.Empty(t, historyRepo.attempts[0].ChosenOption, "chosen option is only kept for wrong answers")
+	assert.Equal(t, "ソ", historyRepo.attempts[1].ChosenOption)
+	assert.Equal(t, 3400, historyRepo.attempts[1].ResponseTimeMs)
+
+	// Negative response time is rejected before anything is saved
+	historyRepo.attempts = nil
+	_, err = svc.SubmitTestResults(context.Background(), 7, "hiragana", "reading", []models.TestResultItem{
+		{CharacterID: 1, Passed: true, ResponseTimeMs: -1},
+	}, "ignore")
+	assert.EqualError(t, err, "response time cannot be negative")
+	assert.Nil(t, historyRepo.attempts)
+
+	// Attempts are saved together with the history, so its errors are propagated
+	historyRepo.upsertErr = errors.New("database error")
+	_, err = svc.SubmitTestResults(context.Background(), 7, "hiragana", "reading", []models.TestResultItem{
+		{CharacterID: 1, Passed: true},
+	}, "ignore")
+	assert.EqualError(t, err, "failed to save test results: database error")
+}
+
+func TestTestResultService_SubmitTestResults_Matching(t *testing.T) {
+	historyRepo := &mockHistoryRepository{
+		histories: []models.CharacterLearnHistory{
+			{UserID: 1, CharacterID: 1, HiraganaReadingResult: 0.5, KatakanaMatchingResult: 0.5},
+		},
+	}
+	svc := NewTestResultService(historyRepo, &mockCharactersRepository{totalCount: 46}, &mockAttemptRepository{}, &mockTestSessionRepository{}, DefaultMasteryConfig())
+
+	_, err := svc.SubmitTestResults(context.Background(), 1, "katakana", "matching", []models.TestResultItem{
+		{CharacterID: 1, Passed: true},
+	}, "ignore")
+
+	assert.NoError(t, err)
+	assert.Len(t, historyRepo.upserted, 1)
+	assert.InDelta(t, 0.65, historyRepo.upserted[0].KatakanaMatchingResult, 0.0001)
+	assert.InDelta(t, 0.5, historyRepo.upserted[0].HiraganaReadingResult, 0.0001, "other categories are not changed")
+	assert.Equal(t, "matching", historyRepo.attempts[0].TestType)
+}
+
+func TestTestResultService_GetAttemptTimeline(t *testing.T) {
+	attemptRepo := &mockAttemptRepository{
+		attempts: []models.CharacterTestAttempt{{ID: 1, CharacterID: 1, Passed: true}},
+	}
+	svc := NewTestResultService(&mockHistoryRepository{}, &mockCharactersRepository{}, attemptRepo, &mockTestSessionRepository{}, DefaultMasteryConfig())
+
+	attempts, err := svc.GetAttemptTimeline(context.Background(), 1, 0, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 1)
+
+	_, err = svc.GetAttemptTimeline(context.Background(), 1, -1, 1, 10)
+	assert.EqualError(t, err, "invalid character id")
+}
+
+func TestTestResultService_GetAccuracyOverTime(t *testing.T) {
+	tests := []struct {
+		name          string
+		characterID   int
+		days          int
+		mockRepo      *mockAttemptRepository
+		expectedError string
+		expectedCount int
+	}{
+		{
+			name:        "success",
+			characterID: 1,
+			days:        30,
+			mockRepo: &mockAttemptRepository{
+				points: []models.CharacterAccuracyPoint{{CharacterID: 1, Attempts: 2, PassedAttempts: 1, Accuracy: 0.5}},
+			},
+			expectedCount: 1,
+		},
+		{
+			name:          "days too small",
+			days:          0,
+			mockRepo:      &mockAttemptRepository{},
+			expectedError: "days must be between 1 and 365",
+		},
+		{
+			name:          "days too large",
+			days:          366,
+			mockRepo:      &mockAttemptRepository{},
+			expectedError: "days must be between 1 and 365",
+		},
+		{
+			name:          "repository error",
+			days:          7,
+			mockRepo:      &mockAttemptRepository{err: errors.New("database error")},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTestResultService(&mockHistoryRepository{}, &mockCharactersRepository{}, tt.mockRepo, &mockTestSessionRepository{}, DefaultMasteryConfig())
+
+			points, err := svc.GetAccuracyOverTime(context.Background(), 1, tt.characterID, tt.days)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, points, tt.expectedCount)
+		})
+	}
+}
+
+func TestTestResultService_SubmitTestSession(t *testing.T) {
+	newSession := func(testType string, expiresIn time.Duration) *models.TestSession {
+		return &models.TestSession{
+			ID:           "session-1",
+			UserID:       1,
+			AlphabetType: "hiragana",
+			TestType:     testType,
+			Items: []models.TestSessionItem{
+				{CharacterID: 1, CorrectAnswer: map[string]string{"reading": "あ", "writing": "a"}[testType]},
+				{CharacterID: 2, CorrectAnswer: map[string]string{"reading": "い", "writing": "i"}[testType]},
+			},
+			ExpiresAt: time.Now().Add(expiresIn),
+		}
+	}
+	submittedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		userID        int
+		sessionID     string
+		sessionRepo   *mockTestSessionRepository
+		answers       []models.TestSessionAnswer
+		upsertErr     error
+		expectedError string
+		validate      func(*testing.T, *models.SubmitTestSessionResult, *mockHistoryRepository)
+	}{
+		{
+			name:        "success reading test",
+			userID:      1,
+			sessionID:   "session-1",
+			sessionRepo: &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers: []models.TestSessionAnswer{
+				{CharacterID: 1, Answer: "あ", ResponseTimeMs: 800},
+				{CharacterID: 2, Answer: "う"},
+			},
+			validate: func(t *testing.T, result *models.SubmitTestSessionResult, historyRepo *mockHistoryRepository) {
+				assert.Len(t, result.Results, 2)
+				assert.True(t, result.Results[0].Passed)
+				assert.False(t, result.Results[1].Passed)
+				assert.Equal(t, "い", result.Results[1].CorrectAnswer)
+				assert.Len(t, historyRepo.attempts, 2)
+				assert.Equal(t, "う", historyRepo.attempts[1].ChosenOption)
+			},
+		},
+		{
+			name:        "writing test is case insensitive and unanswered items fail",
+			userID:      1,
+			sessionID:   "session-1",
+			sessionRepo: &mockTestSessionRepository{session: newSession("writing", time.Minute)},
+			answers: []models.TestSessionAnswer{
+				{CharacterID: 1, Answer: " A "},
+			},
+			validate: func(t *testing.T, result *models.SubmitTestSessionResult, historyRepo *mockHistoryRepository) {
+				assert.True(t, result.Results[0].Passed)
+				assert.False(t, result.Results[1].Passed)
+				assert.Equal(t, "writing", historyRepo.attempts[0].TestType)
+			},
+		},
+		{
+			name:          "session of another user",
+			userID:        2,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "test session not found",
+		},
+		{
+			name:          "unknown session",
+			userID:        1,
+			sessionID:     "session-2",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "test session not found",
+		},
+		{
+			name:          "expired session",
+			userID:        1,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", -time.Minute)},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "test session has expired",
+		},
+		{
+			name:      "already submitted session",
+			userID:    1,
+			sessionID: "session-1",
+			sessionRepo: &mockTestSessionRepository{session: func() *models.TestSession {
+				session := newSession("reading", time.Minute)
+				session.SubmittedAt = &submittedAt
+				return session
+			}()},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "test session has already been submitted",
+		},
+		{
+			name:          "concurrent replay",
+			userID:        1,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute), alreadyTaken: true},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "test session has already been submitted",
+		},
+		{
+			name:          "answer for foreign character",
+			userID:        1,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers:       []models.TestSessionAnswer{{CharacterID: 3, Answer: "う"}},
+			expectedError: "answer does not belong to the test session",
+		},
+		{
+			name:        "duplicate answer",
+			userID:      1,
+			sessionID:   "session-1",
+			sessionRepo: &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers: []models.TestSessionAnswer{
+				{CharacterID: 1, Answer: "あ"},
+				{CharacterID: 1, Answer: "い"},
+			},
+			expectedError: "duplicate answer for the same character",
+		},
+		{
+			name:          "results are not saved",
+			userID:        1,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute)},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			upsertErr:     errors.New("database error"),
+			expectedError: "failed to save test results: database error",
+		},
+		{
+			name:          "results are not saved and session is not released",
+			userID:        1,
+			sessionID:     "session-1",
+			sessionRepo:   &mockTestSessionRepository{session: newSession("reading", time.Minute), clearErr: errors.New("clear error")},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			upsertErr:     errors.New("database error"),
+			expectedError: "failed to save test results: database error; clear error",
+		},
+		{
+			name:          "empty session id",
+			userID:        1,
+			sessionRepo:   &mockTestSessionRepository{},
+			answers:       []models.TestSessionAnswer{{CharacterID: 1, Answer: "あ"}},
+			expectedError: "session id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := &mockHistoryRepository{upsertErr: tt.upsertErr}
+			svc := NewTestResultService(historyRepo, &mockCharactersRepository{totalCount: 46}, &mockAttemptRepository{}, tt.sessionRepo, DefaultMasteryConfig())
+
+			result, err := svc.SubmitTestSession(context.Background(), tt.userID, tt.sessionID, tt.answers, "ignore")
+
+			// The session is released only if its results could not be saved
+			assert.Equal(t, tt.upsertErr != nil, tt.sessionRepo.cleared)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				assert.Nil(t, historyRepo.attempts)
+				return
+			}
+			assert.NoError(t, err)
+			tt.validate(t, result, historyRepo)
+		})
+	}
+}
+
+func TestGradeAnswer(t *testing.T) {
+	tests := []struct {
+		name          string
+		testType      string
+		correctAnswer string
+		answer        string
+		expected      bool
+	}{
+		{name: "reading exact match", testType: "reading", correctAnswer: "し", answer: "し", expected: true},
+		{name: "reading other character", testType: "reading", correctAnswer: "し", answer: "ち", expected: false},
+		{name: "writing same spelling", testType: "writing", correctAnswer: "shi", answer: "SHI", expected: true},
+		{name: "writing kunrei spelling", testType: "writing", correctAnswer: "shi", answer: "si", expected: true},
+		{name: "writing hepburn spelling", testType: "writing", correctAnswer: "tu", answer: "tsu", expected: true},
+		{name: "writing nihon-shiki spelling", testType: "writing", correctAnswer: "ji", answer: "di", expected: true},
+		{name: "writing yoon", testType: "writing", correctAnswer: "sha", answer: "sya", expected: true},
+		{name: "writing latin answer for polivanov reading", testType: "writing", correctAnswer: "си", answer: "si", expected: true},
+		{name: "writing wrong reading", testType: "writing", correctAnswer: "shi", answer: "chi", expected: false},
+		{name: "writing empty answer", testType: "writing", correctAnswer: "shi", answer: " ", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gradeAnswer(tt.testType, tt.correctAnswer, tt.answer))
+		})
+	}
+}
+
+func TestParseCharacterGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         string
+		expected      []models.CharacterGroup
+		expectedError bool
+	}{
+		{
+			name:     "empty parameter means all groups",
+			param:    "",
+			expected: nil,
+		},
+		{
+			name:     "several groups",
+			param:    "basic, Dakuten,yoon",
+			expected: []models.CharacterGroup{models.CharacterGroupBasic, models.CharacterGroupDakuten, models.CharacterGroupYoon},
+		},
+		{
+			name:     "duplicates are ignored",
+			param:    "handakuten,handakuten",
+			expected: []models.CharacterGroup{models.CharacterGroupHandakuten},
+		},
+		{
+			name:          "unknown group",
+			param:         "basic,voiced",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := parseCharacterGroups(tt.param)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid character group")
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, groups)
+			}
+		})
+	}
+}
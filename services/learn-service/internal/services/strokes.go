@@ -0,0 +1,417 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+const (
+	// strokeSamplePoints is the number of points every stroke is resampled to before comparison
+	strokeSamplePoints = 16
+	// strokeTolerance is the maximal mean distance between a drawn and a reference stroke (KanjiVG box is 109x109)
+	strokeTolerance = 15.0
+	// curveSegments is the number of segments used to approximate a single Bézier curve
+	curveSegments = 8
+)
+
+// kanjiVGStrokeID matches ids of KanjiVG stroke paths, e.g. "kvg:03042-s1"
+var kanjiVGStrokeID = regexp.MustCompile(`-s(\d+)$`)
+
+// parseKanjiVGStrokes extracts stroke paths from a KanjiVG SVG document
+//
+// Stroke paths are identified by the "-sN" suffix of their id, N is the stroke number.
+// Stroke numbers must form the sequence 1..N and every path must be supported by parseSVGPath.
+func parseKanjiVGStrokes(svg io.Reader) ([]models.CharacterStroke, error) {
+	decoder := xml.NewDecoder(svg)
+	decoder.Strict = false
+
+	var strokes []models.CharacterStroke
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid KanjiVG file: %w", err)
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "path" {
+			continue
+		}
+		var id, path string
+		for _, attr := range element.Attr {
+			switch attr.Name.Local {
+			case "id":
+				id = attr.Value
+			case "d":
+				path = attr.Value
+			}
+		}
+		match := kanjiVGStrokeID.FindStringSubmatch(id)
+		if match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		if _, err := parseSVGPath(path); err != nil {
+			return nil, fmt.Errorf("invalid path of stroke %d: %w", number, err)
+		}
+		strokes = append(strokes, models.CharacterStroke{StrokeNumber: number, Path: path})
+	}
+
+	if len(strokes) == 0 {
+		return nil, fmt.Errorf("KanjiVG file contains no strokes")
+	}
+	slices.SortFunc(strokes, func(a, b models.CharacterStroke) int { return a.StrokeNumber - b.StrokeNumber })
+	for i, stroke := range strokes {
+		if stroke.StrokeNumber != i+1 {
+			return nil, fmt.Errorf("KanjiVG file has inconsistent stroke numbers")
+		}
+	}
+
+	return strokes, nil
+}
+
+// parseSVGPath converts SVG path data into a polyline
+//
+// Supported commands are M, L, H, V, C, S, Q, T and Z in absolute and relative form, which covers KanjiVG paths.
+// Curves are approximated with curveSegments straight segments.
+func parseSVGPath(d string) ([]models.StrokePoint, error) {
+	tokens, err := tokenizeSVGPath(d)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("path is empty")
+	}
+
+	var points []models.StrokePoint
+	var current, start, lastControl models.StrokePoint
+	var command, previous byte
+	pos := 0
+
+	// next reads a coordinate pair, relative commands are resolved against the current point
+	next := func(relative bool) (models.StrokePoint, error) {
+		if pos+1 >= len(tokens) || tokens[pos].command != 0 || tokens[pos+1].command != 0 {
+			return models.StrokePoint{}, fmt.Errorf("missing coordinates for command %c", command)
+		}
+		p := models.StrokePoint{X: tokens[pos].value, Y: tokens[pos+1].value}
+		pos += 2
+		if relative {
+			p.X += current.X
+			p.Y += current.Y
+		}
+		return p, nil
+	}
+	nextValue := func() (float64, error) {
+		if pos >= len(tokens) || tokens[pos].command != 0 {
+			return 0, fmt.Errorf("missing value for command %c", command)
+		}
+		pos++
+		return tokens[pos-1].value, nil
+	}
+
+	for pos < len(tokens) {
+		if tokens[pos].command != 0 {
+			command = tokens[pos].command
+			pos++
+		} else if command == 0 {
+			return nil, fmt.Errorf("path must start with a command")
+		}
+		relative := command >= 'a' && command <= 'z'
+
+		switch upper := command &^ 0x20; upper {
+		case 'M':
+			p, err := next(relative)
+			if err != nil {
+				return nil, err
+			}
+			current, start = p, p
+			points = append(points, p)
+			// Following coordinate pairs are implicit line commands
+			if relative {
+				command = 'l'
+			} else {
+				command = 'L'
+			}
+		case 'L':
+			p, err := next(relative)
+			if err != nil {
+				return nil, err
+			}
+			current = p
+			points = append(points, p)
+		case 'H', 'V':
+			v, err := nextValue()
+			if err != nil {
+				return nil, err
+			}
+			if upper == 'H' {
+				if relative {
+					v += current.X
+				}
+				current.X = v
+			} else {
+				if relative {
+					v += current.Y
+				}
+				current.Y = v
+			}
+			points = append(points, current)
+		case 'C', 'S':
+			var c1 models.StrokePoint
+			if upper == 'S' {
+				c1 = current
+				if previous == 'C' || previous == 'S' {
+					c1 = reflectPoint(lastControl, current)
+				}
+			} else if c1, err = next(relative); err != nil {
+				return nil, err
+			}
+			c2, err := next(relative)
+			if err != nil {
+				return nil, err
+			}
+			end, err := next(relative)
+			if err != nil {
+				return nil, err
+			}
+			points = append(points, sampleCubic(current, c1, c2, end)...)
+			current, lastControl = end, c2
+		case 'Q', 'T':
+			var c models.StrokePoint
+			if upper == 'T' {
+				c = current
+				if previous == 'Q' || previous == 'T' {
+					c = reflectPoint(lastControl, current)
+				}
+			} else if c, err = next(relative); err != nil {
+				return nil, err
+			}
+			end, err := next(relative)
+			if err != nil {
+				return nil, err
+			}
+			// A quadratic curve is a cubic one with control points at 2/3 of the way to the quadratic control point
+			c1 := models.StrokePoint{X: current.X + 2.0/3.0*(c.X-current.X), Y: current.Y + 2.0/3.0*(c.Y-current.Y)}
+			c2 := models.StrokePoint{X: end.X + 2.0/3.0*(c.X-end.X), Y: end.Y + 2.0/3.0*(c.Y-end.Y)}
+			points = append(points, sampleCubic(current, c1, c2, end)...)
+			current, lastControl = end, c
+		case 'Z':
+			// Closepath takes no coordinates, so a command must follow it
+			if pos < len(tokens) && tokens[pos].command == 0 {
+				return nil, fmt.Errorf("unexpected coordinates after command %c", command)
+			}
+			current = start
+			points = append(points, start)
+		default:
+			return nil, fmt.Errorf("unsupported path command %c", command)
+		}
+		previous = command &^ 0x20
+	}
+
+	return points, nil
+}
+
+// svgPathToken is either a command letter or a number of SVG path data
+type svgPathToken struct {
+	command byte
+	value   float64
+}
+
+// tokenizeSVGPath splits SVG path data into commands and numbers
+//
+// Numbers may be separated by spaces, commas or just by a sign ("10-5") or a second dot ("1.5.5").
+func tokenizeSVGPath(d string) ([]svgPathToken, error) {
+	var tokens []svgPathToken
+	for i := 0; i < len(d); {
+		ch := d[i]
+		switch {
+		case ch == ' ' || ch == ',' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", ch) >= 0:
+			tokens = append(tokens, svgPathToken{command: ch})
+			i++
+		case ch == '-' || ch == '+' || ch == '.' || (ch >= '0' && ch <= '9'):
+			j := i
+			if d[j] == '-' || d[j] == '+' {
+				j++
+			}
+			seenDot, seenExp := false, false
+			for j < len(d) {
+				c := d[j]
+				if c >= '0' && c <= '9' {
+					j++
+				} else if c == '.' && !seenDot && !seenExp {
+					seenDot = true
+					j++
+				} else if (c == 'e' || c == 'E') && !seenExp && j > i {
+					seenExp = true
+					j++
+					if j < len(d) && (d[j] == '-' || d[j] == '+') {
+						j++
+					}
+				} else {
+					break
+				}
+			}
+			value, err := strconv.ParseFloat(d[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q in path", d[i:j])
+			}
+			tokens = append(tokens, svgPathToken{value: value})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q in path", ch)
+		}
+	}
+	return tokens, nil
+}
+
+// reflectPoint reflects a control point about the current point
+func reflectPoint(control, current models.StrokePoint) models.StrokePoint {
+	return models.StrokePoint{X: 2*current.X - control.X, Y: 2*current.Y - control.Y}
+}
+
+// sampleCubic approximates a cubic Bézier curve with points, the start point is not included
+func sampleCubic(p0, p1, p2, p3 models.StrokePoint) []models.StrokePoint {
+	points := make([]models.StrokePoint, curveSegments)
+	for i := 1; i <= curveSegments; i++ {
+		t := float64(i) / curveSegments
+		mt := 1 - t
+		a, b, c, d := mt*mt*mt, 3*mt*mt*t, 3*mt*t*t, t*t*t
+		points[i-1] = models.StrokePoint{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		}
+	}
+	return points
+}
+
+// resampleStroke returns "n" points evenly distributed along the stroke
+//
+// A stroke without length (a dot) is resampled to "n" copies of its first point.
+func resampleStroke(points []models.StrokePoint, n int) []models.StrokePoint {
+	result := make([]models.StrokePoint, n)
+	lengths := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		lengths[i] = lengths[i-1] + distance(points[i-1], points[i])
+	}
+	total := lengths[len(lengths)-1]
+	if total == 0 {
+		for i := range result {
+			result[i] = points[0]
+		}
+		return result
+	}
+
+	segment := 1
+	for i := range n {
+		target := total * float64(i) / float64(n-1)
+		for segment < len(points)-1 && lengths[segment] < target {
+			segment++
+		}
+		segmentLength := lengths[segment] - lengths[segment-1]
+		ratio := 0.0
+		if segmentLength > 0 {
+			ratio = (target - lengths[segment-1]) / segmentLength
+		}
+		from, to := points[segment-1], points[segment]
+		result[i] = models.StrokePoint{X: from.X + ratio*(to.X-from.X), Y: from.Y + ratio*(to.Y-from.Y)}
+	}
+	return result
+}
+
+// strokeDeviation returns the mean distance between corresponding points of two resampled strokes
+//
+// If "reversed" is true, the second stroke is traversed from its end.
+func strokeDeviation(a, b []models.StrokePoint, reversed bool) float64 {
+	sum := 0.0
+	for i := range a {
+		j := i
+		if reversed {
+			j = len(b) - 1 - i
+		}
+		sum += distance(a[i], b[j])
+	}
+	return sum / float64(len(a))
+}
+
+// distance returns the euclidean distance between two points
+func distance(a, b models.StrokePoint) float64 {
+	return math.Hypot(a.X-b.X, a.Y-b.Y)
+}
+
+// roundDeviation rounds a deviation to two decimal places for the response
+func roundDeviation(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// checkStrokes compares drawn strokes with reference strokes
+//
+// Every drawn stroke is compared with the reference stroke at the same position.
+// If it doesn't match, it is checked whether it is drawn backwards or matches another reference stroke.
+// The exercise is passed when stroke counts are equal and every stroke is correct.
+func checkStrokes(reference, drawn [][]models.StrokePoint) *models.StrokeExerciseResult {
+	refSamples := make([][]models.StrokePoint, len(reference))
+	for i, stroke := range reference {
+		refSamples[i] = resampleStroke(stroke, strokeSamplePoints)
+	}
+
+	result := &models.StrokeExerciseResult{
+		Passed:              len(reference) == len(drawn),
+		ExpectedStrokeCount: len(reference),
+		DrawnStrokeCount:    len(drawn),
+		Strokes:             make([]models.StrokeFeedback, 0, max(len(reference), len(drawn))),
+	}
+
+	for i, stroke := range drawn {
+		feedback := models.StrokeFeedback{StrokeNumber: i + 1}
+		if i >= len(refSamples) {
+			feedback.Status = models.StrokeStatusExtra
+			result.Passed = false
+			result.Strokes = append(result.Strokes, feedback)
+			continue
+		}
+
+		sample := resampleStroke(stroke, strokeSamplePoints)
+		deviation := strokeDeviation(sample, refSamples[i], false)
+		feedback.Deviation = roundDeviation(deviation)
+		switch {
+		case deviation <= strokeTolerance:
+			feedback.Status = models.StrokeStatusCorrect
+		case strokeDeviation(sample, refSamples[i], true) <= strokeTolerance:
+			feedback.Status = models.StrokeStatusWrongDirection
+		default:
+			feedback.Status = models.StrokeStatusWrongShape
+			for j, ref := range refSamples {
+				if j != i && strokeDeviation(sample, ref, false) <= strokeTolerance {
+					feedback.Status = models.StrokeStatusWrongOrder
+					feedback.ExpectedStrokeNumber = j + 1
+					break
+				}
+			}
+		}
+		if feedback.Status != models.StrokeStatusCorrect {
+			result.Passed = false
+		}
+		result.Strokes = append(result.Strokes, feedback)
+	}
+
+	for i := len(drawn); i < len(reference); i++ {
+		result.Strokes = append(result.Strokes, models.StrokeFeedback{
+			StrokeNumber: i + 1,
+			Status:       models.StrokeStatusMissing,
+		})
+	}
+
+	return result
+}
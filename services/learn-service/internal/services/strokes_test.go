@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCharacterStrokeRepository is a mock implementation of CharacterStrokeRepository
+type mockCharacterStrokeRepository struct {
+	strokes []models.CharacterStroke
+	err     error
+}
+
+func (m *mockCharacterStrokeRepository) GetByCharacterID(ctx context.Context, characterID int, alphabetType string) ([]models.CharacterStroke, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.strokes, nil
+}
+
+func TestParseSVGPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		expectedFirst models.StrokePoint
+		expectedLast  models.StrokePoint
+		errorContains string
+	}{
+		{
+			name:          "absolute lines",
+			path:          "M10,20L30,40L50,60",
+			expectedFirst: models.StrokePoint{X: 10, Y: 20},
+			expectedLast:  models.StrokePoint{X: 50, Y: 60},
+		},
+		{
+			name:          "relative curves with compact numbers",
+			path:          "M22.25,34.5c0.5,2.25,0.75,6.5,1,12.25s-1-2.5.75-2.75",
+			expectedFirst: models.StrokePoint{X: 22.25, Y: 34.5},
+			expectedLast:  models.StrokePoint{X: 24, Y: 44},
+		},
+		{
+			name:          "implicit line after move and horizontal line",
+			path:          "m10 10 5 5h10v-5",
+			expectedFirst: models.StrokePoint{X: 10, Y: 10},
+			expectedLast:  models.StrokePoint{X: 25, Y: 10},
+		},
+		{
+			name:          "quadratic curve",
+			path:          "M0,0Q50,50,100,0",
+			expectedFirst: models.StrokePoint{X: 0, Y: 0},
+			expectedLast:  models.StrokePoint{X: 100, Y: 0},
+		},
+		{
+			name:          "missing coordinates",
+			path:          "M10,20L30",
+			errorContains: "missing coordinates",
+		},
+		{
+			name:          "no leading command",
+			path:          "10,20",
+			errorContains: "must start with a command",
+		},
+		{
+			name:          "closed path followed by a move",
+			path:          "M0,0L10,0L10,10zM5,5l1,1",
+			expectedFirst: models.StrokePoint{X: 0, Y: 0},
+			expectedLast:  models.StrokePoint{X: 6, Y: 6},
+		},
+		{
+			name:          "coordinates after closepath",
+			path:          "M1,1Z5,5",
+			errorContains: "unexpected coordinates after command Z",
+		},
+		{
+			name:          "unsupported arc",
+			path:          "M0,0A5,5,0,0,1,10,10",
+			errorContains: "unsupported path command",
+		},
+		{
+			name:          "invalid character",
+			path:          "M0,0#",
+			errorContains: "unexpected character",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := parseSVGPath(tt.path)
+
+			if tt.errorContains != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, points)
+			assert.InDelta(t, tt.expectedFirst.X, points[0].X, 0.001)
+			assert.InDelta(t, tt.expectedFirst.Y, points[0].Y, 0.001)
+			assert.InDelta(t, tt.expectedLast.X, points[len(points)-1].X, 0.001)
+			assert.InDelta(t, tt.expectedLast.Y, points[len(points)-1].Y, 0.001)
+		})
+	}
+}
+
+func TestCheckStrokes(t *testing.T) {
+	// Reference strokes of "い" simplified to straight lines
+	first := []models.StrokePoint{{X: 25, Y: 30}, {X: 30, Y: 80}}
+	second := []models.StrokePoint{{X: 70, Y: 35}, {X: 80, Y: 65}}
+	reversed := func(stroke []models.StrokePoint) []models.StrokePoint {
+		return []models.StrokePoint{stroke[1], stroke[0]}
+	}
+	reference := [][]models.StrokePoint{first, second}
+
+	tests := []struct {
+		name             string
+		drawn            [][]models.StrokePoint
+		expectedPassed   bool
+		expectedStatuses []models.StrokeStatus
+	}{
+		{
+			name: "correct strokes drawn with small deviation",
+			drawn: [][]models.StrokePoint{
+				{{X: 26, Y: 31}, {X: 28, Y: 55}, {X: 31, Y: 78}},
+				{{X: 71, Y: 36}, {X: 79, Y: 66}},
+			},
+			expectedPassed:   true,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusCorrect, models.StrokeStatusCorrect},
+		},
+		{
+			name:             "stroke drawn backwards",
+			drawn:            [][]models.StrokePoint{reversed(first), second},
+			expectedPassed:   false,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusWrongDirection, models.StrokeStatusCorrect},
+		},
+		{
+			name:             "strokes drawn in wrong order",
+			drawn:            [][]models.StrokePoint{second, first},
+			expectedPassed:   false,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusWrongOrder, models.StrokeStatusWrongOrder},
+		},
+		{
+			name:             "stroke of a different shape",
+			drawn:            [][]models.StrokePoint{first, {{X: 10, Y: 100}, {X: 100, Y: 100}}},
+			expectedPassed:   false,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusCorrect, models.StrokeStatusWrongShape},
+		},
+		{
+			name:             "missing stroke",
+			drawn:            [][]models.StrokePoint{first},
+			expectedPassed:   false,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusCorrect, models.StrokeStatusMissing},
+		},
+		{
+			name:             "extra stroke",
+			drawn:            [][]models.StrokePoint{first, second, {{X: 50, Y: 50}}},
+			expectedPassed:   false,
+			expectedStatuses: []models.StrokeStatus{models.StrokeStatusCorrect, models.StrokeStatusCorrect, models.StrokeStatusExtra},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkStrokes(reference, tt.drawn)
+
+			assert.Equal(t, tt.expectedPassed, result.Passed)
+			assert.Equal(t, 2, result.ExpectedStrokeCount)
+			assert.Equal(t, len(tt.drawn), result.DrawnStrokeCount)
+			require.Len(t, result.Strokes, len(tt.expectedStatuses))
+			for i, status := range tt.expectedStatuses {
+				assert.Equal(t, i+1, result.Strokes[i].StrokeNumber)
+				assert.Equal(t, status, result.Strokes[i].Status)
+			}
+		})
+	}
+
+	t.Run("wrong order points to the matched reference stroke", func(t *testing.T) {
+		result := checkStrokes(reference, [][]models.StrokePoint{second, first})
+
+		assert.Equal(t, 2, result.Strokes[0].ExpectedStrokeNumber)
+		assert.Equal(t, 1, result.Strokes[1].ExpectedStrokeNumber)
+	})
+}
+
+func TestCharactersService_CheckStrokes(t *testing.T) {
+	reference := []models.CharacterStroke{
+		{CharacterID: 2, AlphabetType: "hiragana", StrokeNumber: 1, Path: "M25,30L30,80"},
+		{CharacterID: 2, AlphabetType: "hiragana", StrokeNumber: 2, Path: "M70,35L80,65"},
+	}
+	validStrokes := [][]models.StrokePoint{
+		{{X: 25, Y: 30}, {X: 30, Y: 80}},
+		{{X: 70, Y: 35}, {X: 80, Y: 65}},
+	}
+
+	tests := []struct {
+		name          string
+		alphabetType  string
+		id            int
+		strokes       [][]models.StrokePoint
+		strokeRepo    *mockCharacterStrokeRepository
+		expectedError string
+	}{
+		{
+			name:         "success",
+			alphabetType: "hiragana",
+			id:           2,
+			strokes:      validStrokes,
+			strokeRepo:   &mockCharacterStrokeRepository{strokes: reference},
+		},
+		{
+			name:          "invalid alphabet type",
+			alphabetType:  "hr",
+			id:            2,
+			strokes:       validStrokes,
+			strokeRepo:    &mockCharacterStrokeRepository{},
+			expectedError: "invalid alphabet type",
+		},
+		{
+			name:          "invalid id",
+			alphabetType:  "hiragana",
+			id:            0,
+			strokes:       validStrokes,
+			strokeRepo:    &mockCharacterStrokeRepository{},
+			expectedError: "invalid character id",
+		},
+		{
+			name:          "empty strokes",
+			alphabetType:  "hiragana",
+			id:            2,
+			strokes:       nil,
+			strokeRepo:    &mockCharacterStrokeRepository{},
+			expectedError: "strokes cannot be empty",
+		},
+		{
+			name:          "stroke without points",
+			alphabetType:  "hiragana",
+			id:            2,
+			strokes:       [][]models.StrokePoint{{}},
+			strokeRepo:    &mockCharacterStrokeRepository{},
+			expectedError: "stroke must contain at least one point",
+		},
+		{
+			name:          "too many strokes",
+			alphabetType:  "hiragana",
+			id:            2,
+			strokes:       make([][]models.StrokePoint, maxDrawnStrokes+1),
+			strokeRepo:    &mockCharacterStrokeRepository{},
+			expectedError: "too many strokes",
+		},
+		{
+			name:          "no stroke data",
+			alphabetType:  "katakana",
+			id:            2,
+			strokes:       validStrokes,
+			strokeRepo:    &mockCharacterStrokeRepository{strokes: []models.CharacterStroke{}},
+			expectedError: "character strokes not found",
+		},
+		{
+			name:          "repository error",
+			alphabetType:  "hiragana",
+			id:            2,
+			strokes:       validStrokes,
+			strokeRepo:    &mockCharacterStrokeRepository{err: errors.New("database error")},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewCharactersService(nil, nil, nil, tt.strokeRepo, nil, DefaultLocaleConfig())
+
+			result, err := svc.CheckStrokes(context.Background(), tt.alphabetType, tt.id, tt.strokes)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, result.Passed)
+			assert.Len(t, result.Strokes, 2)
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/transliteration"
 )
 
 // CharacterLearnHistoryRepository is the interface that wraps methods for CharacterLearnHistory table data access
@@ -22,11 +25,12 @@ type CharacterLearnHistoryRepository interface {
 	// If no records are found, an empty slice will be returned.
 	// If some error occurs during data retrieval, the error will be returned.
 	GetByUserID(ctx context.Context, userID int) ([]models.UserLearnHistory, error)
-	// Method Upsert updates or creates a list of learn history records.
+	// Method Upsert updates or creates a list of learn history records and stores test attempts in one transaction.
 	//
 	// "histories" parameter is used to update or create a list of learn history records.
-	// If some error occurs during data upsert, the error will be returned.
-	Upsert(ctx context.Context, histories []models.CharacterLearnHistory) error
+	// "attempts" parameter is used to create the attempt records (may be empty).
+	// If some error occurs during data upsert, nothing is saved and the error will be returned.
+	Upsert(ctx context.Context, histories []models.CharacterLearnHistory, attempts []models.CharacterTestAttempt) error
 	// LowerResultsByUserID lowers all result values by 0.01 for all CharacterLearnHistory records for a user
 	//
 	// "userID" parameter is used to identify the user.
@@ -42,24 +46,195 @@ type TestResultCharactersRepository interface {
 	GetTotalCount(ctx context.Context) (int, error)
 }
 
+// CharacterTestAttemptRepository is the interface that wraps methods for CharacterTestAttempts table data access
+type CharacterTestAttemptRepository interface {
+	// Method GetByUserID retrieves a paginated timeline of test attempts for a user, newest first.
+	//
+	// "userID" parameter is used to identify the user.
+	// "characterID" parameter is used to filter attempts by character (0 means all characters).
+	// "page" and "count" parameters are used for pagination.
+	// If no records are found, an empty slice will be returned.
+	// If some error occurs during data retrieval, the error will be returned.
+	GetByUserID(ctx context.Context, userID, characterID, page, count int) ([]models.CharacterTestAttempt, error)
+	// Method GetAccuracyByDay retrieves per-day accuracy of a user for each practised character.
+	//
+	// "days" parameter is used to limit the period (including the current day).
+	//
+	// Please reference GetByUserID method for more information about other parameters and error values.
+	GetAccuracyByDay(ctx context.Context, userID, characterID, days int) ([]models.CharacterAccuracyPoint, error)
+}
+
+// maxAnswerLength limits the length of a raw answer, it matches the size of the stored chosen option
+const maxAnswerLength = 20
+
+// TestSessionRepository is the interface that wraps methods for TestSessions table data access
+type TestSessionRepository interface {
+	// Method Create stores a new test session.
+	//
+	// "session" parameter is used to create the session record, its ID must be already generated.
+	// If some error occurs during data insertion, the error will be returned.
+	Create(ctx context.Context, session *models.TestSession) error
+	// Method GetByID retrieves a test session by its ID.
+	//
+	// "id" parameter is used to identify the session.
+	// If the session is not found or some error occurs during data retrieval, the error will be returned together with "nil" value.
+	GetByID(ctx context.Context, id string) (*models.TestSession, error)
+	// Method MarkSubmitted marks a test session as submitted.
+	//
+	// "id" parameter is used to identify the session.
+	// "submittedAt" parameter is used to set the submission time.
+	// Returns false if the session has already been submitted.
+	// If some error occurs during data update, the error will be returned.
+	MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error)
+	// Method ClearSubmitted marks a submitted test session as not submitted again.
+	//
+	// "id" parameter is used to identify the session.
+	// If some error occurs during data update, the error will be returned.
+	ClearSubmitted(ctx context.Context, id string) error
+	// Method DeleteExpiredByUserID deletes all test sessions of a user that expired before the given time.
+	//
+	// "userID" parameter is used to identify the user.
+	// "before" parameter is used to identify expired sessions.
+	// If some error occurs during data deletion, the error will be returned.
+	DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error
+}
+
+// MasteryConfig holds parameters of the character mastery model.
+//
+// Each submitted answer moves the stored score towards 1 (passed) or 0 (failed)
+// using an exponential moving average: score = score + LearningRate * (target - score).
+type MasteryConfig struct {
+	LearningRate  float32 // Weight of the newest answer, must be in (0, 1]
+	PassThreshold float32 // Score from which a character is considered learned in a category, must be in (0, 1]
+}
+
+// DefaultMasteryConfig returns the mastery configuration used when nothing else is configured
+//
+// With these values a character needs five consecutive correct answers to be considered learned.
+func DefaultMasteryConfig() MasteryConfig {
+	return MasteryConfig{
+		LearningRate:  0.3,
+		PassThreshold: 0.8,
+	}
+}
+
 // testResultService implements TestResultService
 type testResultService struct {
 	historyRepo CharacterLearnHistoryRepository
 	charRepo    TestResultCharactersRepository
+	attemptRepo CharacterTestAttemptRepository
+	sessionRepo TestSessionRepository
+	mastery     MasteryConfig
 }
 
 // NewTestResultService creates a new test result service
-func NewTestResultService(historyRepo CharacterLearnHistoryRepository, charRepo TestResultCharactersRepository) *testResultService {
+func NewTestResultService(historyRepo CharacterLearnHistoryRepository, charRepo TestResultCharactersRepository, attemptRepo CharacterTestAttemptRepository, sessionRepo TestSessionRepository, mastery MasteryConfig) *testResultService {
 	return &testResultService{
 		historyRepo: historyRepo,
 		charRepo:    charRepo,
+		attemptRepo: attemptRepo,
+		sessionRepo: sessionRepo,
+		mastery:     mastery,
+	}
+}
+
+// SubmitTestSession grades raw answers against a stored test session and saves the results
+//
+// The session must belong to the user, must not be expired and must not be submitted before.
+// Answers may only reference characters of the session, each character at most once.
+// Items without an answer are graded as failed.
+//
+// Please reference SubmitTestResults method for more information about "repeat" parameter and returned values.
+func (s *testResultService) SubmitTestSession(ctx context.Context, userID int, sessionID string, answers []models.TestSessionAnswer, repeat string) (*models.SubmitTestSessionResult, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session id is required")
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	// Sessions of other users are reported as missing, so their existence is not revealed
+	if session.UserID != userID {
+		return nil, fmt.Errorf("test session not found")
+	}
+	if session.SubmittedAt != nil {
+		return nil, fmt.Errorf("test session has already been submitted")
+	}
+	now := time.Now().UTC()
+	if !now.Before(session.ExpiresAt) {
+		return nil, fmt.Errorf("test session has expired")
+	}
+
+	// Validate answers against session items
+	correctAnswers := make(map[int]string, len(session.Items))
+	for _, item := range session.Items {
+		correctAnswers[item.CharacterID] = item.CorrectAnswer
+	}
+	answerMap := make(map[int]models.TestSessionAnswer, len(answers))
+	for _, answer := range answers {
+		if _, ok := correctAnswers[answer.CharacterID]; !ok {
+			return nil, fmt.Errorf("answer does not belong to the test session")
+		}
+		if _, ok := answerMap[answer.CharacterID]; ok {
+			return nil, fmt.Errorf("duplicate answer for the same character")
+		}
+		if answer.ResponseTimeMs < 0 {
+			return nil, fmt.Errorf("response time cannot be negative")
+		}
+		if utf8.RuneCountInString(answer.Answer) > maxAnswerLength {
+			return nil, fmt.Errorf("answer is too long")
+		}
+		answerMap[answer.CharacterID] = answer
+	}
+
+	// Mark the session as submitted before saving results, so concurrent replays are rejected
+	marked, err := s.sessionRepo.MarkSubmitted(ctx, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, fmt.Errorf("test session has already been submitted")
+	}
+
+	// Grade answers
+	results := make([]models.TestResultItem, len(session.Items))
+	graded := make([]models.GradedAnswer, len(session.Items))
+	for i, item := range session.Items {
+		answer := answerMap[item.CharacterID]
+		passed := gradeAnswer(session.TestType, item.CorrectAnswer, answer.Answer)
+		results[i] = models.TestResultItem{
+			CharacterID:    item.CharacterID,
+			Passed:         passed,
+			ChosenOption:   strings.TrimSpace(answer.Answer),
+			ResponseTimeMs: answer.ResponseTimeMs,
+		}
+		graded[i] = models.GradedAnswer{
+			CharacterID:   item.CharacterID,
+			Passed:        passed,
+			CorrectAnswer: item.CorrectAnswer,
+		}
 	}
+
+	submitResult, err := s.SubmitTestResults(ctx, userID, session.AlphabetType, session.TestType, results, repeat)
+	if err != nil {
+		// Release the session, so the user can retry the submission instead of losing the results
+		if clearErr := s.sessionRepo.ClearSubmitted(ctx, session.ID); clearErr != nil {
+			return nil, fmt.Errorf("%w; %w", err, clearErr)
+		}
+		return nil, err
+	}
+
+	return &models.SubmitTestSessionResult{
+		AskForRepeat: submitResult.AskForRepeat,
+		Results:      graded,
+	}, nil
 }
 
 // SubmitTestResults processes and saves test results
 //
 // For successful results alphabetType must be either "hiragana" or "katakana".
-// testType must be either "reading", "writing", or "listening".
+// testType must be one of the registered test types: "reading", "writing", "listening", or "matching".
 // results must be a non-empty array of TestResultItem.
 // repeat parameter indicates if user wants to repeat alphabet after completing all characters ("in question" by default).
 //
@@ -71,21 +246,20 @@ func (s *testResultService) SubmitTestResults(ctx context.Context, userID int, a
 		repeat = "in question"
 	}
 
-	// Validate alphabet type
+	// Validate alphabet type and test type
 	alphabetTypeLower := strings.ToLower(alphabetType)
-	if alphabetTypeLower != "hiragana" && alphabetTypeLower != "katakana" {
-		return nil, fmt.Errorf("invalid alphabet type, must be 'hiragana' or 'katakana'")
-	}
-
-	// Validate test type
 	testTypeLower := strings.ToLower(testType)
-	if testTypeLower != "reading" && testTypeLower != "writing" && testTypeLower != "listening" {
-		return nil, fmt.Errorf("invalid test type, must be 'reading', 'writing', or 'listening'")
+	resultField, err := lookupTestResultField(alphabetTypeLower, testTypeLower)
+	if err != nil {
+		return nil, err
 	}
 
 	// Extract character IDs
 	characterIDs := make([]int, len(results))
 	for i, result := range results {
+		if result.ResponseTimeMs < 0 {
+			return nil, fmt.Errorf("response time cannot be negative")
+		}
 		characterIDs[i] = result.CharacterID
 	}
 
@@ -101,53 +275,44 @@ func (s *testResultService) SubmitTestResults(ctx context.Context, userID int, a
 		existingMap[existingHistories[i].CharacterID] = &existingHistories[i]
 	}
 
-	// Determine which column to update based on alphabet type and test type
-	var updateField func(*models.CharacterLearnHistory, float32)
-
-	switch {
-	case alphabetTypeLower == "hiragana" && testTypeLower == "reading":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.HiraganaReadingResult = val }
-	case alphabetTypeLower == "hiragana" && testTypeLower == "writing":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.HiraganaWritingResult = val }
-	case alphabetTypeLower == "hiragana" && testTypeLower == "listening":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.HiraganaListeningResult = val }
-	case alphabetTypeLower == "katakana" && testTypeLower == "reading":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.KatakanaReadingResult = val }
-	case alphabetTypeLower == "katakana" && testTypeLower == "writing":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.KatakanaWritingResult = val }
-	case alphabetTypeLower == "katakana" && testTypeLower == "listening":
-		updateField = func(h *models.CharacterLearnHistory, val float32) { h.KatakanaListeningResult = val }
-	default:
-		return nil, fmt.Errorf("invalid alphabet type or test type")
-	}
-
 	// Prepare histories for batch insert or update
 	var toUpdate []models.CharacterLearnHistory
 
 	for _, result := range results {
-		var resultValue float32 = 0
-		if result.Passed {
-			resultValue = 1
-		}
-
-		if existing, ok := existingMap[result.CharacterID]; ok {
-			// Update existing record
-			updateField(existing, resultValue)
-			toUpdate = append(toUpdate, *existing)
-		} else {
-			// Create new record
-			history := models.CharacterLearnHistory{
+		existing, ok := existingMap[result.CharacterID]
+		if !ok {
+			// Create new record, every score of a new record starts from 0
+			existing = &models.CharacterLearnHistory{
 				UserID:      userID,
 				CharacterID: result.CharacterID,
 			}
-			updateField(&history, resultValue)
-			toUpdate = append(toUpdate, history)
+			existingMap[result.CharacterID] = existing
 		}
+		score := resultField.value(existing)
+		*score = s.nextScore(*score, result.Passed)
+		toUpdate = append(toUpdate, *existing)
 	}
 
-	// Upsert the results
-	if err := s.historyRepo.Upsert(ctx, toUpdate); err != nil {
-		return nil, err
+	// Log every answer separately, so the learning progress can be traced over time
+	attempts := make([]models.CharacterTestAttempt, len(results))
+	for i, result := range results {
+		attempts[i] = models.CharacterTestAttempt{
+			UserID:         userID,
+			CharacterID:    result.CharacterID,
+			AlphabetType:   alphabetTypeLower,
+			TestType:       testTypeLower,
+			Passed:         result.Passed,
+			ResponseTimeMs: result.ResponseTimeMs,
+		}
+		// Chosen option only makes sense for a wrong answer
+		if !result.Passed {
+			attempts[i].ChosenOption = result.ChosenOption
+		}
+	}
+
+	// Upsert the results together with the attempts
+	if err := s.historyRepo.Upsert(ctx, toUpdate, attempts); err != nil {
+		return nil, fmt.Errorf("failed to save test results: %w", err)
 	}
 
 	// Check if user has maximum marks for all characters (only if repeat is "in question")
@@ -170,7 +335,91 @@ func (s *testResultService) GetUserHistory(ctx context.Context, userID int) ([]m
 	return s.historyRepo.GetByUserID(ctx, userID)
 }
 
-// checkAllCharactersCompleted checks if user has maximum marks for all characters in all categories
+// GetAttemptTimeline retrieves a paginated list of user's test attempts, newest first
+//
+// "characterID" equal to 0 returns attempts for all characters.
+// Non-positive "page" and "count" values are replaced with defaults (1 and 50), "count" is limited to 100.
+func (s *testResultService) GetAttemptTimeline(ctx context.Context, userID, characterID, page, count int) ([]models.CharacterTestAttempt, error) {
+	if characterID < 0 {
+		return nil, fmt.Errorf("invalid character id")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if count < 1 {
+		count = 50
+	}
+	count = min(count, 100)
+
+	return s.attemptRepo.GetByUserID(ctx, userID, characterID, page, count)
+}
+
+// GetAccuracyOverTime retrieves per-day accuracy of a user for each practised character
+//
+// "characterID" equal to 0 returns accuracy for all characters.
+// "days" must be between 1 and 365.
+func (s *testResultService) GetAccuracyOverTime(ctx context.Context, userID, characterID, days int) ([]models.CharacterAccuracyPoint, error) {
+	if characterID < 0 {
+		return nil, fmt.Errorf("invalid character id")
+	}
+	if days < 1 || days > 365 {
+		return nil, fmt.Errorf("days must be between 1 and 365")
+	}
+
+	return s.attemptRepo.GetAccuracyByDay(ctx, userID, characterID, days)
+}
+
+// gradeAnswer checks a raw answer against the correct one
+//
+// Writing test answers are typed readings, so they are compared case-insensitively
+// and any valid romanization of the same kana is accepted, e.g. "si" for "shi" or "tu" for "tsu".
+// Reading and listening test answers are chosen characters and must match exactly.
+func gradeAnswer(testType, correctAnswer, answer string) bool {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return false
+	}
+	if testType == "writing" {
+		return strings.EqualFold(answer, strings.TrimSpace(correctAnswer)) || transliteration.Equivalent(correctAnswer, answer)
+	}
+	return answer == correctAnswer
+}
+
+// nextScore moves the mastery score towards 1 for a passed answer or towards 0 for a failed one
+//
+// The result is always kept within [0, 1].
+func (s *testResultService) nextScore(score float32, passed bool) float32 {
+	var target float32 = 0
+	if passed {
+		target = 1
+	}
+	next := score + s.mastery.LearningRate*(target-score)
+	return min(max(next, 0), 1)
+}
+
+// isMastered checks if all result categories of a history record reach the pass threshold
+//
+// Matching results are not considered, they only show how well both alphabets are connected
+// and the characters are already mastered through reading, writing and listening in each alphabet.
+func (s *testResultService) isMastered(history models.UserLearnHistory) bool {
+	for _, score := range []float32{
+		history.HiraganaReadingResult,
+		history.HiraganaWritingResult,
+		history.HiraganaListeningResult,
+		history.KatakanaReadingResult,
+		history.KatakanaWritingResult,
+		history.KatakanaListeningResult,
+	} {
+		if score < s.mastery.PassThreshold {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAllCharactersCompleted checks if user has mastered all characters in all categories
+//
+// A character is mastered when each of its six result categories reaches the configured pass threshold.
 func (s *testResultService) checkAllCharactersCompleted(ctx context.Context, userID int) (bool, error) {
 	// Get total character count
 	totalCharacters, err := s.charRepo.GetTotalCount(ctx)
@@ -184,23 +433,15 @@ func (s *testResultService) checkAllCharactersCompleted(ctx context.Context, use
 		return false, err
 	}
 
-	// Calculate total sum of all result categories for all characters
-	var totalSum float32 = 0
+	// Count characters mastered in every category
+	masteredCount := 0
 	for _, history := range histories {
-		totalSum += history.HiraganaReadingResult +
-			history.HiraganaWritingResult +
-			history.HiraganaListeningResult +
-			history.KatakanaReadingResult +
-			history.KatakanaWritingResult +
-			history.KatakanaListeningResult
+		if s.isMastered(history) {
+			masteredCount++
+		}
 	}
 
-	// Maximum possible sum = total characters * 6 (one for each category)
-	expectedMax := float32(totalCharacters) * 6.0
-
-	// Check if total sum equals expected maximum (with small tolerance for floating point)
-	const tolerance float32 = 0.001
-	return totalSum >= expectedMax-tolerance && totalSum <= expectedMax+tolerance, nil
+	return totalCharacters > 0 && masteredCount >= totalCharacters, nil
 }
 
 // DropUserMarks lowers all CharacterLearnHistory results by 0.01 for a user
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// testResultField describes a CharacterLearnHistory result field updated by a test
+type testResultField struct {
+	column string                                       // Column name in character_learn_history table
+	value  func(*models.CharacterLearnHistory) *float32 // Pointer to the score of a history record
+}
+
+// testTypes lists registered test types in the order they are reported to the client
+var testTypes = []string{"reading", "writing", "listening", "matching"}
+
+// testResultFields maps alphabet type and test type to the result field updated by the test
+//
+// A new test type is registered by adding it to testTypes and adding its fields for both alphabets.
+// For a matching test the alphabet type is the alphabet of the shown character.
+var testResultFields = map[string]map[string]testResultField{
+	"hiragana": {
+		"reading": {
+			column: "hiragana_reading_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.HiraganaReadingResult },
+		},
+		"writing": {
+			column: "hiragana_writing_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.HiraganaWritingResult },
+		},
+		"listening": {
+			column: "hiragana_listening_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.HiraganaListeningResult },
+		},
+		"matching": {
+			column: "hiragana_matching_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.HiraganaMatchingResult },
+		},
+	},
+	"katakana": {
+		"reading": {
+			column: "katakana_reading_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.KatakanaReadingResult },
+		},
+		"writing": {
+			column: "katakana_writing_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.KatakanaWritingResult },
+		},
+		"listening": {
+			column: "katakana_listening_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.KatakanaListeningResult },
+		},
+		"matching": {
+			column: "katakana_matching_result",
+			value:  func(h *models.CharacterLearnHistory) *float32 { return &h.KatakanaMatchingResult },
+		},
+	},
+}
+
+// lookupTestResultField returns the result field registered for the alphabet type and test type
+//
+// Both parameters are expected in lower case.
+// If the alphabet type or the test type is not registered, the error will be returned.
+func lookupTestResultField(alphabetType, testType string) (testResultField, error) {
+	fields, ok := testResultFields[alphabetType]
+	if !ok {
+		return testResultField{}, fmt.Errorf("invalid alphabet type, must be 'hiragana' or 'katakana'")
+	}
+	field, ok := fields[testType]
+	if !ok {
+		return testResultField{}, fmt.Errorf("invalid test type, must be %s", quoteTestTypes())
+	}
+	return field, nil
+}
+
+// quoteTestTypes returns registered test types as a quoted list, e.g. "'reading', 'writing', or 'listening'"
+func quoteTestTypes() string {
+	quoted := make([]string, len(testTypes))
+	for i, testType := range testTypes {
+		quoted[i] = "'" + testType + "'"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + ", or " + quoted[len(quoted)-1]
+}
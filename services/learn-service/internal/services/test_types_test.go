@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupTestResultField(t *testing.T) {
+	tests := []struct {
+		name           string
+		alphabetType   string
+		testType       string
+		expectedColumn string
+		expectedError  string
+	}{
+		{name: "hiragana reading", alphabetType: "hiragana", testType: "reading", expectedColumn: "hiragana_reading_result"},
+		{name: "katakana listening", alphabetType: "katakana", testType: "listening", expectedColumn: "katakana_listening_result"},
+		{name: "hiragana matching", alphabetType: "hiragana", testType: "matching", expectedColumn: "hiragana_matching_result"},
+		{name: "katakana matching", alphabetType: "katakana", testType: "matching", expectedColumn: "katakana_matching_result"},
+		{
+			name:          "unknown alphabet type",
+			alphabetType:  "kanji",
+			testType:      "reading",
+			expectedError: "invalid alphabet type, must be 'hiragana' or 'katakana'",
+		},
+		{
+			name:          "unknown test type",
+			alphabetType:  "hiragana",
+			testType:      "speaking",
+			expectedError: "invalid test type, must be 'reading', 'writing', 'listening', or 'matching'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, err := lookupTestResultField(tt.alphabetType, tt.testType)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedColumn, field.column)
+		})
+	}
+}
+
+func TestTestResultFields_Registry(t *testing.T) {
+	// Every registered test type must have a field for both alphabets, each pointing to its own score
+	var history models.CharacterLearnHistory
+	columns := make(map[string]bool)
+	scores := make(map[*float32]bool)
+	for _, alphabetType := range []string{"hiragana", "katakana"} {
+		for _, testType := range testTypes {
+			field, err := lookupTestResultField(alphabetType, testType)
+			assert.NoError(t, err)
+			assert.False(t, columns[field.column], "column %s is registered twice", field.column)
+			assert.False(t, scores[field.value(&history)], "score of %s %s is registered twice", alphabetType, testType)
+			columns[field.column] = true
+			scores[field.value(&history)] = true
+		}
+	}
+	assert.Len(t, columns, len(testTypes)*2)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/transliteration"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// maxTransliterationLength is the maximum length of a text to transliterate in characters
+const maxTransliterationLength = 5000
+
+// transliterationService implements TransliterationService
+type transliterationService struct{}
+
+// NewTransliterationService creates a new transliteration service
+func NewTransliterationService() *transliterationService {
+	return &transliterationService{}
+}
+
+// Transliterate converts text between kana and romanization scripts
+//
+// For successful results:
+//
+// - text must not be empty and must be at most 5000 characters long
+//
+// - from and to must be "hiragana", "katakana", "hepburn", "kunrei", "nihon-shiki" or "polivanov"
+func (s *transliterationService) Transliterate(ctx context.Context, text, from, to string) (*models.TransliterationResponse, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("invalid text, must not be empty")
+	}
+	if utf8.RuneCountInString(text) > maxTransliterationLength {
+		return nil, fmt.Errorf("invalid text, must be at most %d characters long", maxTransliterationLength)
+	}
+	fromScript, err := transliteration.ParseScript(from)
+	if err != nil {
+		return nil, err
+	}
+	toScript, err := transliteration.ParseScript(to)
+	if err != nil {
+		return nil, err
+	}
+
+	segments, err := transliteration.Transliterate(text, fromScript, toScript)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.TransliterationResponse{
+		From:     string(fromScript),
+		To:       string(toScript),
+		Segments: make([]models.TransliterationSegment, len(segments)),
+	}
+	var converted strings.Builder
+	for i, segment := range segments {
+		response.Segments[i] = models.TransliterationSegment{Source: segment.Source, Target: segment.Target}
+		converted.WriteString(segment.Target)
+	}
+	response.Text = converted.String()
+	return response, nil
+}
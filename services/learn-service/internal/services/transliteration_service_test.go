@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterationService_Transliterate(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		from          string
+		to            string
+		expectedError string
+		validate      func(*testing.T, *models.TransliterationResponse)
+	}{
+		{
+			name: "hiragana to hepburn",
+			text: "きって",
+			from: "hiragana",
+			to:   "hepburn",
+			validate: func(t *testing.T, result *models.TransliterationResponse) {
+				assert.Equal(t, "kitte", result.Text)
+				assert.Equal(t, "hiragana", result.From)
+				assert.Equal(t, "hepburn", result.To)
+				assert.Equal(t, []models.TransliterationSegment{
+					{Source: "き", Target: "ki"},
+					{Source: "っ", Target: "t"},
+					{Source: "て", Target: "te"},
+				}, result.Segments)
+			},
+		},
+		{
+			name: "romaji to katakana with long vowel",
+			text: "rāmen",
+			from: "Hepburn",
+			to:   "katakana",
+			validate: func(t *testing.T, result *models.TransliterationResponse) {
+				assert.Equal(t, "ラアメン", result.Text)
+				assert.Equal(t, "hepburn", result.From)
+				assert.Equal(t, models.TransliterationSegment{Source: "rā", Target: "ラア"}, result.Segments[0])
+			},
+		},
+		{
+			name: "hiragana to polivanov",
+			text: "せんせい",
+			from: "hiragana",
+			to:   "polivanov",
+			validate: func(t *testing.T, result *models.TransliterationResponse) {
+				assert.Equal(t, "сэнсэй", result.Text)
+			},
+		},
+		{
+			name:          "empty text",
+			text:          "  ",
+			from:          "hiragana",
+			to:            "hepburn",
+			expectedError: "invalid text, must not be empty",
+		},
+		{
+			name:          "too long text",
+			text:          strings.Repeat("か", maxTransliterationLength+1),
+			from:          "hiragana",
+			to:            "hepburn",
+			expectedError: "invalid text, must be at most 5000 characters long",
+		},
+		{
+			name:          "invalid source script",
+			text:          "かな",
+			from:          "kanji",
+			to:            "hepburn",
+			expectedError: `invalid script "kanji", must be 'hiragana', 'katakana', 'hepburn', 'kunrei', 'nihon-shiki' or 'polivanov'`,
+		},
+		{
+			name:          "invalid target script",
+			text:          "かな",
+			from:          "hiragana",
+			to:            "",
+			expectedError: `invalid script "", must be 'hiragana', 'katakana', 'hepburn', 'kunrei', 'nihon-shiki' or 'polivanov'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTransliterationService()
+
+			result, err := svc.Transliterate(context.Background(), tt.text, tt.from, tt.to)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, result)
+		})
+	}
+}
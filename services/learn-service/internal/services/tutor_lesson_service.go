@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"slices"
@@ -163,6 +164,36 @@ type TutorLessonRepository interface {
 	//
 	// Returns a boolean and an error if any.
 	CheckOwnership(ctx context.Context, id, tutorID int) (bool, error)
+	// UpdateMinKanaMastery sets the kana mastery percent needed to unlock a lesson
+	//
+	// "ctx" is the context for the request.
+	// "id" is the ID of the lesson.
+	// "minKanaMastery" is the kana mastery percent, 0 removes the requirement.
+	//
+	// Returns an error if any.
+	UpdateMinKanaMastery(ctx context.Context, id, minKanaMastery int) error
+	// ValidateIDs checks if all lesson IDs exist
+	//
+	// "ctx" is the context for the request.
+	// "ids" is the list of lesson IDs.
+	//
+	// Returns a boolean and an error if any.
+	ValidateIDs(ctx context.Context, ids []int) (bool, error)
+	// GetPrerequisiteIDs retrieves prerequisite lesson IDs for a set of lessons
+	//
+	// "ctx" is the context for the request.
+	// "lessonIDs" is the list of lesson IDs.
+	//
+	// Returns a map from lesson ID to IDs of its prerequisite lessons and an error if any.
+	GetPrerequisiteIDs(ctx context.Context, lessonIDs []int) (map[int][]int, error)
+	// SetPrerequisites replaces prerequisite lessons of a lesson
+	//
+	// "ctx" is the context for the request.
+	// "lessonID" is the ID of the lesson.
+	// "prerequisiteIDs" is the list of prerequisite lesson IDs, an empty list removes the prerequisites.
+	//
+	// Returns an error if any.
+	SetPrerequisites(ctx context.Context, lessonID int, prerequisiteIDs []int) error
 }
 
 // TutorLessonBlockRepository defines methods for lesson block data access for tutors
@@ -322,6 +353,10 @@ func (s *tutorLessonService) CreateCourse(ctx context.Context, req *models.Creat
 		Title:           req.Title,
 		ShortSummary:    req.ShortSummary,
 		ComplexityLevel: req.ComplexityLevel,
+		Navigation:      req.Navigation,
+	}
+	if course.Navigation == "" {
+		course.Navigation = models.CourseNavigationFree
 	}
 
 	err := s.courseRepo.Create(ctx, course)
@@ -337,6 +372,9 @@ func (s *tutorLessonService) validateCreateCourse(ctx context.Context, req *mode
 	if req.Slug == "" || req.Title == "" || req.ShortSummary == "" || req.ComplexityLevel == "" {
 		return fmt.Errorf("all fields are required")
 	}
+	if req.Navigation != "" && !s.isValidNavigation(req.Navigation) {
+		return fmt.Errorf("invalid course navigation")
+	}
 
 	// Prepare for concurrent check
 	errorChan := make(chan error, 3)
@@ -418,6 +456,9 @@ func (s *tutorLessonService) UpdateCourse(ctx context.Context, courseID int, tut
 	if req.ComplexityLevel != course.ComplexityLevel {
 		updateCourse.ComplexityLevel = req.ComplexityLevel
 	}
+	if req.Navigation != course.Navigation {
+		updateCourse.Navigation = req.Navigation
+	}
 	// tutorID is nil, it means that the course is being updated by an admin
 	if req.AuthorID != nil && tutorID == nil {
 		updateCourse.AuthorID = *req.AuthorID
@@ -434,9 +475,12 @@ func (s *tutorLessonService) validateUpdateCourse(ctx context.Context, course *m
 	}
 
 	// Validate if any field is provided
-	if req.Slug == "" && req.Title == "" && req.ShortSummary == "" && req.ComplexityLevel == "" && req.AuthorID == nil {
+	if req.Slug == "" && req.Title == "" && req.ShortSummary == "" && req.ComplexityLevel == "" && req.Navigation == "" && req.AuthorID == nil {
 		return fmt.Errorf("at least one field must be provided")
 	}
+	if req.Navigation != "" && !s.isValidNavigation(req.Navigation) {
+		return fmt.Errorf("invalid course navigation")
+	}
 
 	// Prepare for concurrent check
 	errorChan := make(chan error, 3)
@@ -534,6 +578,9 @@ func (s *tutorLessonService) GetLessonsForCourse(ctx context.Context, courseID i
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := s.setPrerequisiteIDs(ctx, lessons); err != nil {
+		return nil, nil, err
+	}
 
 	// If tutorID is not nil, it means that the course is being retrieved by a tutor, so we already know the tutor
 	if tutorID != nil {
@@ -564,11 +611,12 @@ func (s *tutorLessonService) CreateLesson(ctx context.Context, tutorID *int, req
 	}
 
 	lesson := &models.Lesson{
-		Slug:         req.Slug,
-		CourseID:     req.CourseID,
-		Title:        req.Title,
-		ShortSummary: req.ShortSummary,
-		Order:        req.Order,
+		Slug:           req.Slug,
+		CourseID:       req.CourseID,
+		Title:          req.Title,
+		ShortSummary:   req.ShortSummary,
+		Order:          req.Order,
+		MinKanaMastery: req.MinKanaMastery,
 	}
 
 	err = s.lessonRepo.Create(ctx, lesson)
@@ -576,6 +624,12 @@ func (s *tutorLessonService) CreateLesson(ctx context.Context, tutorID *int, req
 		return 0, fmt.Errorf("failed to create lesson: %w", err)
 	}
 
+	if len(req.PrerequisiteIDs) > 0 {
+		if err := s.lessonRepo.SetPrerequisites(ctx, lesson.ID, req.PrerequisiteIDs); err != nil {
+			return 0, fmt.Errorf("failed to set lesson prerequisites: %w", err)
+		}
+	}
+
 	return lesson.ID, nil
 }
 
@@ -584,6 +638,9 @@ func (s *tutorLessonService) validateCreateLesson(ctx context.Context, tutorID *
 	if req.Slug == "" || req.CourseID == 0 || req.Title == "" || req.ShortSummary == "" || req.Order <= 0 {
 		return fmt.Errorf("all fields are required and order must be greater than 0")
 	}
+	if err := s.validateUnlockRules(ctx, 0, &req.MinKanaMastery, req.PrerequisiteIDs); err != nil {
+		return err
+	}
 
 	// Prepare for concurrent check
 	errorChan := make(chan error, 3)
@@ -695,28 +752,50 @@ func (s *tutorLessonService) UpdateLesson(ctx context.Context, lessonID int, tut
 		}
 	}
 
-	updateLesson := &models.Lesson{
-		ID:           lessonID,
-		Slug:         req.Slug,
-		Title:        req.Title,
-		ShortSummary: req.ShortSummary,
+	// Skip the lesson update if only unlock rules are provided
+	if req.Slug != "" || req.Title != "" || req.ShortSummary != "" || req.Order != nil || req.CourseID != nil {
+		updateLesson := &models.Lesson{
+			ID:           lessonID,
+			Slug:         req.Slug,
+			Title:        req.Title,
+			ShortSummary: req.ShortSummary,
+		}
+		if courseIDToCheck != lesson.CourseID {
+			updateLesson.CourseID = courseIDToCheck
+		}
+		if req.Order != nil {
+			updateLesson.Order = *req.Order
+		}
+
+		if err := s.lessonRepo.Update(ctx, updateLesson); err != nil {
+			return err
+		}
 	}
-	if courseIDToCheck != lesson.CourseID {
-		updateLesson.CourseID = courseIDToCheck
+
+	if req.MinKanaMastery != nil && *req.MinKanaMastery != lesson.MinKanaMastery {
+		if err := s.lessonRepo.UpdateMinKanaMastery(ctx, lessonID, *req.MinKanaMastery); err != nil {
+			return err
+		}
 	}
-	if req.Order != nil {
-		updateLesson.Order = *req.Order
+	if req.PrerequisiteIDs != nil {
+		if err := s.lessonRepo.SetPrerequisites(ctx, lessonID, req.PrerequisiteIDs); err != nil {
+			return fmt.Errorf("failed to set lesson prerequisites: %w", err)
+		}
 	}
 
-	return s.lessonRepo.Update(ctx, updateLesson)
+	return nil
 }
 
 // validateUpdateLesson validates the update lesson request
 func (s *tutorLessonService) validateUpdateLesson(ctx context.Context, lesson *models.Lesson, req *models.UpdateLessonRequest, courseID int) error {
 	// Validate if any field is provided
-	if req.Slug == "" && req.Title == "" && req.ShortSummary == "" && req.Order == nil && req.CourseID == nil {
+	if req.Slug == "" && req.Title == "" && req.ShortSummary == "" && req.Order == nil && req.CourseID == nil &&
+		req.MinKanaMastery == nil && req.PrerequisiteIDs == nil {
 		return fmt.Errorf("at least one field must be provided")
 	}
+	if err := s.validateUnlockRules(ctx, lesson.ID, req.MinKanaMastery, req.PrerequisiteIDs); err != nil {
+		return err
+	}
 
 	// Prepare for concurrent check
 	errorChan := make(chan error, 3)
@@ -808,6 +887,13 @@ func (s *tutorLessonService) GetFullLessonInfo(ctx context.Context, lessonID int
 		}
 	}
 
+	// Get prerequisites
+	prerequisites, err := s.lessonRepo.GetPrerequisiteIDs(ctx, []int{lessonID})
+	if err != nil {
+		return nil, nil, err
+	}
+	lesson.PrerequisiteIDs = prerequisites[lessonID]
+
 	// Get blocks
 	blocks, err := s.blockRepo.GetByLessonID(ctx, lessonID)
 	if err != nil {
@@ -853,6 +939,16 @@ func (s *tutorLessonService) CreateLessonBlock(ctx context.Context, tutorID *int
 	if !s.isValidBlockType(req.BlockType) {
 		return 0, fmt.Errorf("invalid block type")
 	}
+	if req.BlockType == models.BlockTypeText {
+		if err := validateTextBlockData(req.BlockData); err != nil {
+			return 0, err
+		}
+	}
+	if req.BlockType == models.BlockTypeQuiz {
+		if err := validateQuizBlockData(req.BlockData); err != nil {
+			return 0, err
+		}
+	}
 
 	// Check ownership (if tutorID is not nil, it means that the lesson block is being created by a tutor)
 	if tutorID != nil {
@@ -941,6 +1037,28 @@ func (s *tutorLessonService) UpdateLessonBlock(ctx context.Context, blockID int,
 		return fmt.Errorf("invalid block type")
 	}
 
+	// Text content is validated if it changes or the block becomes a text block
+	if req.BlockType == models.BlockTypeText || (req.BlockData != nil && block.BlockType == models.BlockTypeText && req.BlockType == "") {
+		blockData := block.BlockData
+		if req.BlockData != nil {
+			blockData = *req.BlockData
+		}
+		if err := validateTextBlockData(blockData); err != nil {
+			return err
+		}
+	}
+
+	// Quiz questions are validated if they change or the block becomes a quiz block
+	if req.BlockType == models.BlockTypeQuiz || (req.BlockData != nil && block.BlockType == models.BlockTypeQuiz && req.BlockType == "") {
+		blockData := block.BlockData
+		if req.BlockData != nil {
+			blockData = *req.BlockData
+		}
+		if err := validateQuizBlockData(blockData); err != nil {
+			return err
+		}
+	}
+
 	// Handle order conflicts if order is provided
 	if req.BlockOrder != nil && *req.BlockOrder > 0 && *req.BlockOrder != block.BlockOrder {
 		exists, err := s.blockRepo.ExistsByOrderInLesson(ctx, lessonIDToCheck, *req.BlockOrder)
@@ -1078,6 +1196,86 @@ func (s *tutorLessonService) DeleteTutorMedia(ctx context.Context, mediaID int,
 
 // Helper functions
 
+// validateUnlockRules validates the minimum kana mastery and prerequisites of a lesson
+//
+// "lessonID" is 0 for a new lesson, otherwise prerequisites are also checked for cycles.
+// Nil values are not validated.
+func (s *tutorLessonService) validateUnlockRules(ctx context.Context, lessonID int, minKanaMastery *int, prerequisiteIDs []int) error {
+	if minKanaMastery != nil && (*minKanaMastery < 0 || *minKanaMastery > 100) {
+		return fmt.Errorf("min kana mastery must be between 0 and 100")
+	}
+	if len(prerequisiteIDs) == 0 {
+		return nil
+	}
+
+	seen := make(map[int]bool, len(prerequisiteIDs))
+	for _, id := range prerequisiteIDs {
+		if id <= 0 || seen[id] {
+			return fmt.Errorf("prerequisite lesson IDs must be positive and unique")
+		}
+		if id == lessonID {
+			return fmt.Errorf("lesson cannot be its own prerequisite")
+		}
+		seen[id] = true
+	}
+
+	valid, err := s.lessonRepo.ValidateIDs(ctx, prerequisiteIDs)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("prerequisite lessons do not exist")
+	}
+
+	// A new lesson is not a prerequisite of any lesson yet, so it cannot be a part of a cycle
+	if lessonID == 0 {
+		return nil
+	}
+
+	// Walk through prerequisites of the prerequisites, reaching the lesson itself means a cycle
+	frontier := prerequisiteIDs
+	for len(frontier) > 0 {
+		prerequisites, err := s.lessonRepo.GetPrerequisiteIDs(ctx, frontier)
+		if err != nil {
+			return err
+		}
+		var next []int
+		for _, ids := range prerequisites {
+			for _, id := range ids {
+				if id == lessonID {
+					return fmt.Errorf("prerequisites cannot form a cycle")
+				}
+				if !seen[id] {
+					seen[id] = true
+					next = append(next, id)
+				}
+			}
+		}
+		frontier = next
+	}
+	return nil
+}
+
+// setPrerequisiteIDs sets prerequisite lesson IDs of lessons
+func (s *tutorLessonService) setPrerequisiteIDs(ctx context.Context, lessons []models.Lesson) error {
+	lessonIDs := make([]int, len(lessons))
+	for i, lesson := range lessons {
+		lessonIDs[i] = lesson.ID
+	}
+	prerequisites, err := s.lessonRepo.GetPrerequisiteIDs(ctx, lessonIDs)
+	if err != nil {
+		return err
+	}
+	for i := range lessons {
+		lessons[i].PrerequisiteIDs = prerequisites[lessons[i].ID]
+	}
+	return nil
+}
+
+func (s *tutorLessonService) isValidNavigation(navigation models.CourseNavigation) bool {
+	return navigation == models.CourseNavigationFree || navigation == models.CourseNavigationSequential
+}
+
 func (s *tutorLessonService) isValidComplexityLevel(level models.ComplexityLevel) bool {
 	validLevels := []models.ComplexityLevel{
 		models.ComplexityLevelAbsoluteBeginner,
@@ -1096,10 +1294,26 @@ func (s *tutorLessonService) isValidBlockType(blockType models.BlockType) bool {
 		models.BlockTypeText,
 		models.BlockTypeDocument,
 		models.BlockTypeList,
+		models.BlockTypeQuiz,
 	}
 	return slices.Contains(validTypes, blockType)
 }
 
+// validateTextBlockData validates data of a text block
+//
+// For successful results the data must be a JSON object and its content must have valid ruby markup,
+// please reference parseRuby function for more information.
+func validateTextBlockData(data json.RawMessage) error {
+	var textData models.TextBlockData
+	if err := json.Unmarshal(data, &textData); err != nil {
+		return fmt.Errorf("invalid text block data")
+	}
+	if _, err := parseRuby(textData.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (s *tutorLessonService) isValidMediaType(mediaType models.MediaType) bool {
 	validTypes := []models.MediaType{
 		models.MediaTypeVideo,
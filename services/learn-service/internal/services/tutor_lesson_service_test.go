@@ -2,11 +2,13 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockTutorCourseRepository is a mock implementation of TutorCourseRepository
@@ -21,6 +23,8 @@ type mockTutorCourseRepository struct {
 	updateErr       error
 	deleteErr       error
 	checkOwnership  bool
+	created         *models.Course
+	updated         *models.Course
 }
 
 func (m *mockTutorCourseRepository) GetByID(ctx context.Context, id int) (*models.Course, error) {
@@ -63,6 +67,7 @@ func (m *mockTutorCourseRepository) Create(ctx context.Context, course *models.C
 		return m.createErr
 	}
 	course.ID = 1
+	m.created = course
 	return m.err
 }
 
@@ -70,6 +75,7 @@ func (m *mockTutorCourseRepository) Update(ctx context.Context, course *models.C
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	m.updated = course
 	return m.err
 }
 
@@ -97,6 +103,12 @@ type mockTutorLessonRepository struct {
 	updateErr    error
 	deleteErr    error
 	checkOwnership bool
+	prerequisites  map[int][]int
+	validIDs       bool
+	created        *models.Lesson
+	updated        *models.Lesson
+	minKanaMastery *int
+	prerequisiteIDs []int
 }
 
 func (m *mockTutorLessonRepository) GetByID(ctx context.Context, id int) (*models.Lesson, error) {
@@ -132,6 +144,7 @@ func (m *mockTutorLessonRepository) Create(ctx context.Context, lesson *models.L
 		return m.createErr
 	}
 	lesson.ID = 1
+	m.created = lesson
 	return m.err
 }
 
@@ -139,6 +152,7 @@ func (m *mockTutorLessonRepository) Update(ctx context.Context, lesson *models.L
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	m.updated = lesson
 	return m.err
 }
 
@@ -156,6 +170,33 @@ func (m *mockTutorLessonRepository) CheckOwnership(ctx context.Context, id, tuto
 	return m.checkOwnership, nil
 }
 
+func (m *mockTutorLessonRepository) UpdateMinKanaMastery(ctx context.Context, id, minKanaMastery int) error {
+	m.minKanaMastery = &minKanaMastery
+	return m.err
+}
+
+func (m *mockTutorLessonRepository) ValidateIDs(ctx context.Context, ids []int) (bool, error) {
+	return m.validIDs, m.err
+}
+
+func (m *mockTutorLessonRepository) GetPrerequisiteIDs(ctx context.Context, lessonIDs []int) (map[int][]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	prerequisites := make(map[int][]int)
+	for _, id := range lessonIDs {
+		if ids, ok := m.prerequisites[id]; ok {
+			prerequisites[id] = ids
+		}
+	}
+	return prerequisites, nil
+}
+
+func (m *mockTutorLessonRepository) SetPrerequisites(ctx context.Context, lessonID int, prerequisiteIDs []int) error {
+	m.prerequisiteIDs = prerequisiteIDs
+	return m.err
+}
+
 // mockTutorLessonBlockRepository is a minimal mock for testing
 type mockTutorLessonBlockRepository struct {
 	blocks []models.LessonBlockResponse
@@ -390,6 +431,355 @@ func TestTutorLessonService_GetCoursesShortInfo(t *testing.T) {
 	}
 }
 
+func TestTutorLessonService_CreateLessonBlock_TextContent(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockType     models.BlockType
+		blockData     string
+		errorContains string
+	}{
+		{name: "text with ruby markup", blockType: models.BlockTypeText, blockData: `{"content":"{漢字|かんじ}を{読|よ}む"}`},
+		{name: "plain text", blockType: models.BlockTypeText, blockData: `{"content":"Hello"}`},
+		{name: "invalid ruby markup", blockType: models.BlockTypeText, blockData: `{"content":"{漢字}を読む"}`, errorContains: "invalid ruby markup"},
+		{name: "text data is not an object", blockType: models.BlockTypeText, blockData: `"Hello"`, errorContains: "invalid text block data"},
+		{name: "other blocks are not checked", blockType: models.BlockTypeVideo, blockData: `{"content":"{漢字}"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTutorLessonService(&mockTutorCourseRepository{}, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+			id, err := svc.CreateLessonBlock(context.Background(), nil, &models.CreateLessonBlockRequest{
+				LessonID:   1,
+				BlockType:  tt.blockType,
+				BlockOrder: 1,
+				BlockData:  json.RawMessage(tt.blockData),
+			})
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Equal(t, 0, id)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, id)
+			}
+		})
+	}
+}
+
+func TestTutorLessonService_UpdateLessonBlock_TextContent(t *testing.T) {
+	invalidData := json.RawMessage(`{"content":"{漢字"}`)
+	validData := json.RawMessage(`{"content":"{漢字|かんじ}"}`)
+
+	tests := []struct {
+		name          string
+		block         *models.LessonBlock
+		req           *models.UpdateLessonBlockRequest
+		errorContains string
+	}{
+		{
+			name:  "new content of a text block",
+			block: &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeText, BlockData: validData},
+			req:   &models.UpdateLessonBlockRequest{BlockData: &validData},
+		},
+		{
+			name:          "invalid content of a text block",
+			block:         &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeText, BlockData: validData},
+			req:           &models.UpdateLessonBlockRequest{BlockData: &invalidData},
+			errorContains: "invalid ruby markup",
+		},
+		{
+			name:          "block becomes a text block with invalid content",
+			block:         &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeList, BlockData: invalidData},
+			req:           &models.UpdateLessonBlockRequest{BlockType: models.BlockTypeText},
+			errorContains: "invalid ruby markup",
+		},
+		{
+			name:  "order change does not check content",
+			block: &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeText, BlockData: invalidData},
+			req:   &models.UpdateLessonBlockRequest{BlockOrder: intPtr(2)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockRepo := &mockTutorLessonBlockRepository{block: tt.block}
+			svc := NewTutorLessonService(&mockTutorCourseRepository{}, &mockTutorLessonRepository{}, blockRepo, &mockTutorMediaRepository{}, "", "")
+
+			err := svc.UpdateLessonBlock(context.Background(), 1, nil, tt.req)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTutorLessonService_LessonBlock_QuizContent(t *testing.T) {
+	validData := json.RawMessage(`{"passingScore":80,"questions":[{"type":"single_choice","question":"猫?","options":["dog","cat"],"correctOptions":[1]}]}`)
+	invalidData := json.RawMessage(`{"questions":[{"type":"single_choice","question":"猫?","options":["dog","cat"],"correctOptions":[2]}]}`)
+
+	t.Run("create quiz block", func(t *testing.T) {
+		svc := NewTutorLessonService(&mockTutorCourseRepository{}, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+		id, err := svc.CreateLessonBlock(context.Background(), nil, &models.CreateLessonBlockRequest{
+			LessonID:   1,
+			BlockType:  models.BlockTypeQuiz,
+			BlockOrder: 1,
+			BlockData:  validData,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, id)
+	})
+
+	t.Run("create quiz block with invalid questions", func(t *testing.T) {
+		svc := NewTutorLessonService(&mockTutorCourseRepository{}, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+		id, err := svc.CreateLessonBlock(context.Background(), nil, &models.CreateLessonBlockRequest{
+			LessonID:   1,
+			BlockType:  models.BlockTypeQuiz,
+			BlockOrder: 1,
+			BlockData:  invalidData,
+		})
+
+		assert.ErrorContains(t, err, "correct option is out of range")
+		assert.Equal(t, 0, id)
+	})
+
+	updateTests := []struct {
+		name          string
+		block         *models.LessonBlock
+		req           *models.UpdateLessonBlockRequest
+		errorContains string
+	}{
+		{
+			name:  "new questions of a quiz block",
+			block: &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeQuiz, BlockData: validData},
+			req:   &models.UpdateLessonBlockRequest{BlockData: &validData},
+		},
+		{
+			name:          "invalid questions of a quiz block",
+			block:         &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeQuiz, BlockData: validData},
+			req:           &models.UpdateLessonBlockRequest{BlockData: &invalidData},
+			errorContains: "correct option is out of range",
+		},
+		{
+			name:          "block becomes a quiz block with invalid data",
+			block:         &models.LessonBlock{ID: 1, LessonID: 1, BlockType: models.BlockTypeList, BlockData: json.RawMessage(`{"items":[]}`)},
+			req:           &models.UpdateLessonBlockRequest{BlockType: models.BlockTypeQuiz},
+			errorContains: "quiz must have at least one question",
+		},
+	}
+
+	for _, tt := range updateTests {
+		t.Run(tt.name, func(t *testing.T) {
+			blockRepo := &mockTutorLessonBlockRepository{block: tt.block}
+			svc := NewTutorLessonService(&mockTutorCourseRepository{}, &mockTutorLessonRepository{}, blockRepo, &mockTutorMediaRepository{}, "", "")
+
+			err := svc.UpdateLessonBlock(context.Background(), 1, nil, tt.req)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTutorLessonService_CreateCourse_Navigation(t *testing.T) {
+	tests := []struct {
+		name               string
+		navigation         models.CourseNavigation
+		expectedNavigation models.CourseNavigation
+		errorContains      string
+	}{
+		{name: "free by default", expectedNavigation: models.CourseNavigationFree},
+		{name: "sequential", navigation: models.CourseNavigationSequential, expectedNavigation: models.CourseNavigationSequential},
+		{name: "invalid navigation", navigation: "random", errorContains: "invalid course navigation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courseRepo := &mockTutorCourseRepository{}
+			svc := NewTutorLessonService(courseRepo, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+			id, err := svc.CreateCourse(context.Background(), &models.CreateCourseRequest{
+				Slug:            "kana-basics",
+				AuthorID:        1,
+				Title:           "Kana Basics",
+				ShortSummary:    "Summary",
+				ComplexityLevel: models.ComplexityLevelBeginner,
+				Navigation:      tt.navigation,
+			})
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, courseRepo.created)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, id)
+				assert.Equal(t, tt.expectedNavigation, courseRepo.created.Navigation)
+			}
+		})
+	}
+}
+
+func TestTutorLessonService_UpdateCourse_Navigation(t *testing.T) {
+	course := &models.Course{ID: 1, AuthorID: 1, Slug: "kana-basics", Title: "Kana Basics", Navigation: models.CourseNavigationFree}
+
+	t.Run("navigation only", func(t *testing.T) {
+		courseRepo := &mockTutorCourseRepository{course: course}
+		svc := NewTutorLessonService(courseRepo, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+		err := svc.UpdateCourse(context.Background(), 1, intPtr(1), &models.UpdateCourseRequest{Navigation: models.CourseNavigationSequential})
+
+		require.NoError(t, err)
+		assert.Equal(t, models.CourseNavigationSequential, courseRepo.updated.Navigation)
+	})
+
+	t.Run("invalid navigation", func(t *testing.T) {
+		courseRepo := &mockTutorCourseRepository{course: course}
+		svc := NewTutorLessonService(courseRepo, &mockTutorLessonRepository{}, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+		err := svc.UpdateCourse(context.Background(), 1, intPtr(1), &models.UpdateCourseRequest{Navigation: "random"})
+
+		assert.ErrorContains(t, err, "invalid course navigation")
+		assert.Nil(t, courseRepo.updated)
+	})
+}
+
+func TestTutorLessonService_CreateLesson_UnlockRules(t *testing.T) {
+	tests := []struct {
+		name            string
+		minKanaMastery  int
+		prerequisiteIDs []int
+		validIDs        bool
+		errorContains   string
+	}{
+		{name: "without unlock rules"},
+		{name: "with unlock rules", minKanaMastery: 80, prerequisiteIDs: []int{2, 3}, validIDs: true},
+		{name: "kana mastery above 100", minKanaMastery: 101, errorContains: "min kana mastery must be between 0 and 100"},
+		{name: "negative kana mastery", minKanaMastery: -1, errorContains: "min kana mastery must be between 0 and 100"},
+		{name: "duplicate prerequisites", prerequisiteIDs: []int{2, 2}, validIDs: true, errorContains: "must be positive and unique"},
+		{name: "invalid prerequisite id", prerequisiteIDs: []int{0}, validIDs: true, errorContains: "must be positive and unique"},
+		{name: "prerequisites do not exist", prerequisiteIDs: []int{2, 999}, errorContains: "prerequisite lessons do not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lessonRepo := &mockTutorLessonRepository{validIDs: tt.validIDs}
+			svc := NewTutorLessonService(&mockTutorCourseRepository{}, lessonRepo, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+			id, err := svc.CreateLesson(context.Background(), nil, &models.CreateLessonRequest{
+				Slug:            "hiragana-a",
+				CourseID:        1,
+				Title:           "Hiragana A",
+				ShortSummary:    "Summary",
+				Order:           1,
+				MinKanaMastery:  tt.minKanaMastery,
+				PrerequisiteIDs: tt.prerequisiteIDs,
+			})
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, lessonRepo.created)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 1, id)
+				assert.Equal(t, tt.minKanaMastery, lessonRepo.created.MinKanaMastery)
+				assert.Equal(t, tt.prerequisiteIDs, lessonRepo.prerequisiteIDs)
+			}
+		})
+	}
+}
+
+func TestTutorLessonService_UpdateLesson_UnlockRules(t *testing.T) {
+	tests := []struct {
+		name                   string
+		req                    *models.UpdateLessonRequest
+		prerequisites          map[int][]int
+		expectedMinKanaMastery *int
+		expectedPrerequisites  []int
+		expectedLessonUpdate   bool
+		errorContains          string
+	}{
+		{
+			name:                   "unlock rules only",
+			req:                    &models.UpdateLessonRequest{MinKanaMastery: intPtr(70), PrerequisiteIDs: []int{2}},
+			expectedMinKanaMastery: intPtr(70),
+			expectedPrerequisites:  []int{2},
+		},
+		{
+			name:                  "empty list removes prerequisites",
+			req:                   &models.UpdateLessonRequest{PrerequisiteIDs: []int{}},
+			expectedPrerequisites: []int{},
+		},
+		{
+			name:                 "lesson fields with unchanged kana mastery",
+			req:                  &models.UpdateLessonRequest{Title: "New Title", MinKanaMastery: intPtr(50)},
+			expectedLessonUpdate: true,
+		},
+		{
+			name:          "lesson is its own prerequisite",
+			req:           &models.UpdateLessonRequest{PrerequisiteIDs: []int{1}},
+			errorContains: "lesson cannot be its own prerequisite",
+		},
+		{
+			name:          "prerequisites form a cycle",
+			req:           &models.UpdateLessonRequest{PrerequisiteIDs: []int{2}},
+			prerequisites: map[int][]int{2: {3}, 3: {1}},
+			errorContains: "prerequisites cannot form a cycle",
+		},
+		{
+			name:          "invalid kana mastery",
+			req:           &models.UpdateLessonRequest{MinKanaMastery: intPtr(120)},
+			errorContains: "min kana mastery must be between 0 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lessonRepo := &mockTutorLessonRepository{
+				lesson:        &models.Lesson{ID: 1, CourseID: 1, Slug: "hiragana-a", Title: "Hiragana A", Order: 1, MinKanaMastery: 50},
+				prerequisites: tt.prerequisites,
+				validIDs:      true,
+			}
+			svc := NewTutorLessonService(&mockTutorCourseRepository{}, lessonRepo, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+			err := svc.UpdateLesson(context.Background(), 1, nil, tt.req)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				assert.Nil(t, lessonRepo.prerequisiteIDs)
+				assert.Nil(t, lessonRepo.minKanaMastery)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLessonUpdate, lessonRepo.updated != nil)
+			assert.Equal(t, tt.expectedMinKanaMastery, lessonRepo.minKanaMastery)
+			assert.Equal(t, tt.expectedPrerequisites, lessonRepo.prerequisiteIDs)
+		})
+	}
+}
+
+func TestTutorLessonService_GetFullLessonInfo_Prerequisites(t *testing.T) {
+	lessonRepo := &mockTutorLessonRepository{
+		lesson:        &models.Lesson{ID: 3, CourseID: 1, Slug: "hiragana-ka", Title: "Hiragana KA", Order: 2, MinKanaMastery: 40},
+		prerequisites: map[int][]int{3: {1, 2}},
+	}
+	svc := NewTutorLessonService(&mockTutorCourseRepository{}, lessonRepo, &mockTutorLessonBlockRepository{}, &mockTutorMediaRepository{}, "", "")
+
+	lesson, _, err := svc.GetFullLessonInfo(context.Background(), 3, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, lesson.PrerequisiteIDs)
+	assert.Equal(t, 40, lesson.MinKanaMastery)
+}
+
 // Helper function to create int pointer
 func intPtr(i int) *int {
 	return &i
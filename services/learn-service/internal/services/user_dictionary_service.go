@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+)
+
+// UserDictionaryRepository is the interface that wraps methods for custom words and decks data access
+type UserDictionaryRepository interface {
+	// GetDecks retrieves all custom decks of the user with the number of words in every deck
+	//
+	// "userId" parameter is used to identify the user.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetDecks(ctx context.Context, userId int) ([]models.UserDeck, error)
+	// DeckExists checks if a deck belongs to the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to identify the deck.
+	//
+	// If some error will occur during data check, the error will be returned together with "false" value.
+	DeckExists(ctx context.Context, userId, deckId int) (bool, error)
+	// DeckExistsByName checks if the user has another deck with the same name
+	//
+	// "userId" parameter is used to identify the user.
+	// "name" parameter is used to check if a deck with the same name exists.
+	// "excludeDeckId" parameter is used to skip the deck being renamed, 0 for a new deck.
+	//
+	// If some error will occur during data check, the error will be returned together with "false" value.
+	DeckExistsByName(ctx context.Context, userId int, name string, excludeDeckId int) (bool, error)
+	// CreateDeck creates a new deck
+	//
+	// "deck" parameter is used to create a new deck, its ID is set after creation.
+	//
+	// If some error will occur during data creation, the error will be returned.
+	CreateDeck(ctx context.Context, deck *models.UserDeck) error
+	// UpdateDeck renames a deck of the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to identify the deck.
+	// "name" parameter is used to specify the new name of the deck.
+	//
+	// If some error will occur during data update, the error will be returned.
+	UpdateDeck(ctx context.Context, userId, deckId int, name string) error
+	// DeleteDeck deletes a deck of the user, words of the deck are kept without a deck
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to identify the deck.
+	//
+	// If the deck does not belong to the user or some error will occur during data deletion, the error will be returned.
+	DeleteDeck(ctx context.Context, userId, deckId int) error
+	// GetWords retrieves custom words of the user, newest first
+	//
+	// "userId" parameter is used to identify the user.
+	// "deckId" parameter is used to filter words by their deck, nil returns all words.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetWords(ctx context.Context, userId int, deckId *int) ([]models.UserWord, error)
+	// WordExists checks if a custom word belongs to the user
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the custom word.
+	//
+	// If some error will occur during data check, the error will be returned together with "false" value.
+	WordExists(ctx context.Context, userId, wordId int) (bool, error)
+	// CreateWord creates a new custom word
+	//
+	// "word" parameter is used to create a new custom word, its ID is set after creation.
+	//
+	// If some error will occur during data creation, the error will be returned.
+	CreateWord(ctx context.Context, word *models.UserWord) error
+	// UpdateWord updates a custom word of the user (partial update)
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the custom word.
+	// "word" parameter contains the fields to update, empty fields are left unchanged.
+	// A nil DeckID leaves the deck unchanged, 0 removes the word from its deck.
+	//
+	// If some error will occur during data update, the error will be returned.
+	UpdateWord(ctx context.Context, userId, wordId int, word *models.UserWord) error
+	// DeleteWord deletes a custom word of the user together with its review history
+	//
+	// "userId" parameter is used to identify the user.
+	// "wordId" parameter is used to identify the custom word.
+	//
+	// If the word does not belong to the user or some error will occur during data deletion, the error will be returned.
+	DeleteWord(ctx context.Context, userId, wordId int) error
+}
+
+// Maximum lengths of custom deck and word fields in characters
+const (
+	maxUserDeckNameLength               = 100
+	maxUserWordLength                   = 100
+	maxUserWordPhoneticCluesLength      = 100
+	maxUserWordTranslationLength        = 255
+	maxUserWordExampleLength            = 500
+	maxUserWordExampleTranslationLength = 500
+)
+
+// userDictionaryService implements UserDictionaryService
+type userDictionaryService struct {
+	repo UserDictionaryRepository
+}
+
+// NewUserDictionaryService creates a new user dictionary service
+func NewUserDictionaryService(repo UserDictionaryRepository) *userDictionaryService {
+	return &userDictionaryService{
+		repo: repo,
+	}
+}
+
+// GetDecks retrieves all custom decks of the user
+func (s *userDictionaryService) GetDecks(ctx context.Context, userId int) ([]models.UserDeck, error) {
+	return s.repo.GetDecks(ctx, userId)
+}
+
+// CreateDeck creates a new custom deck of the user
+//
+// For successful results:
+//
+// - name must not be empty and must be at most 100 characters long
+//
+// - the user must not have a deck with the same name
+func (s *userDictionaryService) CreateDeck(ctx context.Context, userId int, req *models.CreateUserDeckRequest) (int, error) {
+	name, err := s.validateDeckName(ctx, userId, req.Name, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	deck := &models.UserDeck{UserID: userId, Name: name}
+	if err := s.repo.CreateDeck(ctx, deck); err != nil {
+		return 0, err
+	}
+
+	return deck.ID, nil
+}
+
+// UpdateDeck renames a custom deck of the user
+//
+// Please reference CreateDeck method for more information about validation rules.
+func (s *userDictionaryService) UpdateDeck(ctx context.Context, userId, deckId int, req *models.UpdateUserDeckRequest) error {
+	if err := s.checkDeck(ctx, userId, deckId); err != nil {
+		return err
+	}
+
+	name, err := s.validateDeckName(ctx, userId, req.Name, deckId)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateDeck(ctx, userId, deckId, name)
+}
+
+// DeleteDeck deletes a custom deck of the user, words of the deck stay in the personal dictionary
+func (s *userDictionaryService) DeleteDeck(ctx context.Context, userId, deckId int) error {
+	return s.repo.DeleteDeck(ctx, userId, deckId)
+}
+
+// validateDeckName trims the deck name and checks its length and uniqueness among decks of the user
+func (s *userDictionaryService) validateDeckName(ctx context.Context, userId int, name string, excludeDeckId int) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("deck name is required")
+	}
+	if utf8.RuneCountInString(name) > maxUserDeckNameLength {
+		return "", fmt.Errorf("deck name must be at most %d characters long", maxUserDeckNameLength)
+	}
+
+	exists, err := s.repo.DeckExistsByName(ctx, userId, name, excludeDeckId)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", fmt.Errorf("deck '%s' already exists", name)
+	}
+
+	return name, nil
+}
+
+// checkDeck checks that the deck belongs to the user
+func (s *userDictionaryService) checkDeck(ctx context.Context, userId, deckId int) error {
+	exists, err := s.repo.DeckExists(ctx, userId, deckId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("deck not found")
+	}
+	return nil
+}
+
+// GetWords retrieves custom words of the user
+//
+// If deckId is not nil, the deck must belong to the user and only its words are returned.
+func (s *userDictionaryService) GetWords(ctx context.Context, userId int, deckId *int) ([]models.UserWord, error) {
+	if deckId != nil {
+		if err := s.checkDeck(ctx, userId, *deckId); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.GetWords(ctx, userId, deckId)
+}
+
+// CreateWord saves a custom word to the personal dictionary of the user
+//
+// For successful results:
+//
+// - word and translation are required
+//
+// - fields must not exceed their maximum lengths
+//
+// - the deck, if provided, must belong to the user
+//
+// The word appears in the daily review as a new word.
+func (s *userDictionaryService) CreateWord(ctx context.Context, userId int, req *models.CreateUserWordRequest) (int, error) {
+	word := &models.UserWord{
+		UserID:             userId,
+		DeckID:             req.DeckID,
+		Word:               strings.TrimSpace(req.Word),
+		PhoneticClues:      strings.TrimSpace(req.PhoneticClues),
+		Translation:        strings.TrimSpace(req.Translation),
+		Example:            strings.TrimSpace(req.Example),
+		ExampleTranslation: strings.TrimSpace(req.ExampleTranslation),
+	}
+	if word.Word == "" || word.Translation == "" {
+		return 0, fmt.Errorf("word and translation are required")
+	}
+	if err := validateUserWordFields(word); err != nil {
+		return 0, err
+	}
+	if word.DeckID != nil {
+		if err := s.checkDeck(ctx, userId, *word.DeckID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := s.repo.CreateWord(ctx, word); err != nil {
+		return 0, err
+	}
+
+	return word.ID, nil
+}
+
+// UpdateWord updates a custom word of the user
+//
+// Empty fields are left unchanged, deckId 0 removes the word from its deck.
+// Please reference CreateWord method for more information about validation rules.
+func (s *userDictionaryService) UpdateWord(ctx context.Context, userId, wordId int, req *models.UpdateUserWordRequest) error {
+	exists, err := s.repo.WordExists(ctx, userId, wordId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("word not found")
+	}
+
+	word := &models.UserWord{
+		DeckID:             req.DeckID,
+		Word:               strings.TrimSpace(req.Word),
+		PhoneticClues:      strings.TrimSpace(req.PhoneticClues),
+		Translation:        strings.TrimSpace(req.Translation),
+		Example:            strings.TrimSpace(req.Example),
+		ExampleTranslation: strings.TrimSpace(req.ExampleTranslation),
+	}
+	if word.DeckID == nil && word.Word == "" && word.PhoneticClues == "" && word.Translation == "" &&
+		word.Example == "" && word.ExampleTranslation == "" {
+		return fmt.Errorf("no fields to update")
+	}
+	if err := validateUserWordFields(word); err != nil {
+		return err
+	}
+	if word.DeckID != nil && *word.DeckID != 0 {
+		if err := s.checkDeck(ctx, userId, *word.DeckID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.UpdateWord(ctx, userId, wordId, word)
+}
+
+// DeleteWord deletes a custom word of the user together with its review history
+func (s *userDictionaryService) DeleteWord(ctx context.Context, userId, wordId int) error {
+	return s.repo.DeleteWord(ctx, userId, wordId)
+}
+
+// validateUserWordFields checks maximum lengths of custom word fields
+func validateUserWordFields(word *models.UserWord) error {
+	fields := []struct {
+		name      string
+		value     string
+		maxLength int
+	}{
+		{"word", word.Word, maxUserWordLength},
+		{"phoneticClues", word.PhoneticClues, maxUserWordPhoneticCluesLength},
+		{"translation", word.Translation, maxUserWordTranslationLength},
+		{"example", word.Example, maxUserWordExampleLength},
+		{"exampleTranslation", word.ExampleTranslation, maxUserWordExampleTranslationLength},
+	}
+	for _, field := range fields {
+		if utf8.RuneCountInString(field.value) > field.maxLength {
+			return fmt.Errorf("%s must be at most %d characters long", field.name, field.maxLength)
+		}
+	}
+	return nil
+}
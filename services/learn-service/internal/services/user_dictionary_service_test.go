@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUserDictionaryRepository is a mock implementation of UserDictionaryRepository
+type mockUserDictionaryRepository struct {
+	decks           []models.UserDeck
+	words           []models.UserWord
+	deckExists      bool
+	deckNameExists  bool
+	wordExists      bool
+	err             error
+	createErr       error
+	createdDeck     *models.UserDeck
+	createdWord     *models.UserWord
+	updatedDeckName string
+	updatedWord     *models.UserWord
+	excludedDeckId  int
+	requestedDeckId *int
+}
+
+func (m *mockUserDictionaryRepository) GetDecks(ctx context.Context, userId int) ([]models.UserDeck, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.decks, nil
+}
+
+func (m *mockUserDictionaryRepository) DeckExists(ctx context.Context, userId, deckId int) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.deckExists, nil
+}
+
+func (m *mockUserDictionaryRepository) DeckExistsByName(ctx context.Context, userId int, name string, excludeDeckId int) (bool, error) {
+	m.excludedDeckId = excludeDeckId
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.deckNameExists, nil
+}
+
+func (m *mockUserDictionaryRepository) CreateDeck(ctx context.Context, deck *models.UserDeck) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	deck.ID = 1
+	m.createdDeck = deck
+	return nil
+}
+
+func (m *mockUserDictionaryRepository) UpdateDeck(ctx context.Context, userId, deckId int, name string) error {
+	m.updatedDeckName = name
+	return m.err
+}
+
+func (m *mockUserDictionaryRepository) DeleteDeck(ctx context.Context, userId, deckId int) error {
+	return m.err
+}
+
+func (m *mockUserDictionaryRepository) GetWords(ctx context.Context, userId int, deckId *int) ([]models.UserWord, error) {
+	m.requestedDeckId = deckId
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.words, nil
+}
+
+func (m *mockUserDictionaryRepository) WordExists(ctx context.Context, userId, wordId int) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return m.wordExists, nil
+}
+
+func (m *mockUserDictionaryRepository) CreateWord(ctx context.Context, word *models.UserWord) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	word.ID = 5
+	m.createdWord = word
+	return nil
+}
+
+func (m *mockUserDictionaryRepository) UpdateWord(ctx context.Context, userId, wordId int, word *models.UserWord) error {
+	m.updatedWord = word
+	return m.err
+}
+
+func (m *mockUserDictionaryRepository) DeleteWord(ctx context.Context, userId, wordId int) error {
+	return m.err
+}
+
+func TestNewUserDictionaryService(t *testing.T) {
+	repo := &mockUserDictionaryRepository{}
+
+	svc := NewUserDictionaryService(repo)
+
+	assert.NotNil(t, svc)
+	assert.Equal(t, repo, svc.repo)
+}
+
+func TestUserDictionaryService_CreateDeck(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.CreateUserDeckRequest
+		repo          *mockUserDictionaryRepository
+		expectedID    int
+		errorContains string
+	}{
+		{
+			name:       "success with trimmed name",
+			req:        &models.CreateUserDeckRequest{Name: "  Anime  "},
+			repo:       &mockUserDictionaryRepository{},
+			expectedID: 1,
+		},
+		{
+			name:          "empty name",
+			req:           &models.CreateUserDeckRequest{Name: "   "},
+			repo:          &mockUserDictionaryRepository{},
+			errorContains: "deck name is required",
+		},
+		{
+			name:          "name too long",
+			req:           &models.CreateUserDeckRequest{Name: strings.Repeat("単", 101)},
+			repo:          &mockUserDictionaryRepository{},
+			errorContains: "deck name must be at most 100 characters long",
+		},
+		{
+			name:          "deck already exists",
+			req:           &models.CreateUserDeckRequest{Name: "Anime"},
+			repo:          &mockUserDictionaryRepository{deckNameExists: true},
+			errorContains: "deck 'Anime' already exists",
+		},
+		{
+			name:          "database error",
+			req:           &models.CreateUserDeckRequest{Name: "Anime"},
+			repo:          &mockUserDictionaryRepository{createErr: errors.New("database error")},
+			errorContains: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserDictionaryService(tt.repo)
+
+			id, err := svc.CreateDeck(context.Background(), 1, tt.req)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Equal(t, 0, id)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedID, id)
+				assert.Equal(t, &models.UserDeck{ID: 1, UserID: 1, Name: "Anime"}, tt.repo.createdDeck)
+			}
+		})
+	}
+}
+
+func TestUserDictionaryService_UpdateDeck(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := &mockUserDictionaryRepository{deckExists: true}
+		svc := NewUserDictionaryService(repo)
+
+		err := svc.UpdateDeck(context.Background(), 1, 2, &models.UpdateUserDeckRequest{Name: "Work"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Work", repo.updatedDeckName)
+		assert.Equal(t, 2, repo.excludedDeckId, "the renamed deck does not conflict with itself")
+	})
+
+	t.Run("deck of another user", func(t *testing.T) {
+		svc := NewUserDictionaryService(&mockUserDictionaryRepository{deckExists: false})
+
+		err := svc.UpdateDeck(context.Background(), 1, 2, &models.UpdateUserDeckRequest{Name: "Work"})
+
+		assert.EqualError(t, err, "deck not found")
+	})
+}
+
+func TestUserDictionaryService_GetWords(t *testing.T) {
+	t.Run("all words", func(t *testing.T) {
+		repo := &mockUserDictionaryRepository{words: []models.UserWord{{ID: 5, Word: "推し"}}}
+		svc := NewUserDictionaryService(repo)
+
+		words, err := svc.GetWords(context.Background(), 1, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, words, 1)
+		assert.Nil(t, repo.requestedDeckId)
+	})
+
+	t.Run("deck of another user", func(t *testing.T) {
+		svc := NewUserDictionaryService(&mockUserDictionaryRepository{deckExists: false})
+
+		words, err := svc.GetWords(context.Background(), 1, intPtr(2))
+
+		assert.Nil(t, words)
+		assert.EqualError(t, err, "deck not found")
+	})
+}
+
+func TestUserDictionaryService_CreateWord(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.CreateUserWordRequest
+		repo          *mockUserDictionaryRepository
+		errorContains string
+	}{
+		{
+			name: "success in deck",
+			req:  &models.CreateUserWordRequest{DeckID: intPtr(2), Word: " 推し ", PhoneticClues: "おし", Translation: "favourite"},
+			repo: &mockUserDictionaryRepository{deckExists: true},
+		},
+		{
+			name:          "missing translation",
+			req:           &models.CreateUserWordRequest{Word: "推し"},
+			repo:          &mockUserDictionaryRepository{},
+			errorContains: "word and translation are required",
+		},
+		{
+			name:          "example too long",
+			req:           &models.CreateUserWordRequest{Word: "推し", Translation: "favourite", Example: strings.Repeat("あ", 501)},
+			repo:          &mockUserDictionaryRepository{},
+			errorContains: "example must be at most 500 characters long",
+		},
+		{
+			name:          "deck of another user",
+			req:           &models.CreateUserWordRequest{DeckID: intPtr(3), Word: "推し", Translation: "favourite"},
+			repo:          &mockUserDictionaryRepository{deckExists: false},
+			errorContains: "deck not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserDictionaryService(tt.repo)
+
+			id, err := svc.CreateWord(context.Background(), 1, tt.req)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, tt.repo.createdWord)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, 5, id)
+				assert.Equal(t, "推し", tt.repo.createdWord.Word)
+				assert.Equal(t, 1, tt.repo.createdWord.UserID)
+				assert.Equal(t, intPtr(2), tt.repo.createdWord.DeckID)
+			}
+		})
+	}
+}
+
+func TestUserDictionaryService_UpdateWord(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *models.UpdateUserWordRequest
+		repo          *mockUserDictionaryRepository
+		errorContains string
+	}{
+		{
+			name: "remove word from deck",
+			req:  &models.UpdateUserWordRequest{DeckID: intPtr(0)},
+			repo: &mockUserDictionaryRepository{wordExists: true},
+		},
+		{
+			name:          "word of another user",
+			req:           &models.UpdateUserWordRequest{Translation: "favourite"},
+			repo:          &mockUserDictionaryRepository{wordExists: false},
+			errorContains: "word not found",
+		},
+		{
+			name:          "no fields to update",
+			req:           &models.UpdateUserWordRequest{Word: "  "},
+			repo:          &mockUserDictionaryRepository{wordExists: true},
+			errorContains: "no fields to update",
+		},
+		{
+			name:          "move word to deck of another user",
+			req:           &models.UpdateUserWordRequest{DeckID: intPtr(3)},
+			repo:          &mockUserDictionaryRepository{wordExists: true, deckExists: false},
+			errorContains: "deck not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserDictionaryService(tt.repo)
+
+			err := svc.UpdateWord(context.Background(), 1, 5, tt.req)
+
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, tt.repo.updatedWord)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.req.DeckID, tt.repo.updatedWord.DeckID)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 )
@@ -23,12 +26,19 @@ type CourseRepository interface {
 	// "userID" is the ID of the user.
 	// "complexityLevel" is the complexity level of the courses to retrieve.
 	// "search" is the search query for the courses.
-	// "isMine" is a flag to filter courses by user's completion history.
+	// "isMine" is a flag to filter courses the user is enrolled in.
 	// "page" is the page number to retrieve.
 	// "count" is the number of items per page.
 	//
 	// Returns a list of courses and an error if any.
 	GetAll(ctx context.Context, userID int, complexityLevel *models.ComplexityLevel, search string, isMine bool, page, count int) ([]models.CourseDetailResponse, error)
+	// GetNavigation retrieves the navigation mode of a course
+	//
+	// "ctx" is the context for the request.
+	// "id" is the ID of the course.
+	//
+	// Returns the navigation mode and an error if any.
+	GetNavigation(ctx context.Context, id int) (models.CourseNavigation, error)
 }
 
 // LessonRepository defines methods for lesson data access
@@ -49,6 +59,13 @@ type LessonRepository interface {
 	//
 	// Returns a list of lessons and an error if any.
 	GetByCourseIDWithCompletion(ctx context.Context, courseID, userID int) ([]models.LessonListItem, error)
+	// GetPrerequisiteIDs retrieves prerequisite lesson IDs for a set of lessons
+	//
+	// "ctx" is the context for the request.
+	// "lessonIDs" is the list of lesson IDs.
+	//
+	// Returns a map from lesson ID to IDs of its prerequisite lessons and an error if any.
+	GetPrerequisiteIDs(ctx context.Context, lessonIDs []int) (map[int][]int, error)
 }
 
 // LessonBlockRepository defines methods for lesson block data access
@@ -97,13 +114,91 @@ type LessonUserHistoryRepository interface {
 	//
 	// Returns the number of completed lessons and an error if any.
 	CountCompletedLessonsByCourse(ctx context.Context, userID, courseID int) (int, error)
+	// GetCompletedLessonIDs retrieves IDs of the given lessons completed by a user
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	// "lessonIDs" is the list of lesson IDs to check.
+	//
+	// Returns a list of completed lesson IDs and an error if any.
+	GetCompletedLessonIDs(ctx context.Context, userID int, lessonIDs []int) ([]int, error)
+}
+
+// KanaMasteryRepository defines methods for kana mastery data access
+type KanaMasteryRepository interface {
+	// GetKanaMastery calculates the overall kana mastery of a user
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	//
+	// Returns the mastery from 0 to 1 and an error if any.
+	GetKanaMastery(ctx context.Context, userID int) (float32, error)
+}
+
+// LessonQuizAttemptRepository defines methods for lesson quiz attempt data access
+type LessonQuizAttemptRepository interface {
+	// Create creates a new lesson quiz attempt
+	//
+	// "ctx" is the context for the request.
+	// "attempt" is the lesson quiz attempt to create.
+	//
+	// Returns an error if any.
+	Create(ctx context.Context, attempt *models.LessonQuizAttempt) error
+	// GetBestScores retrieves the best scores of a user for quiz blocks
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	// "blockIDs" is the list of quiz block IDs.
+	//
+	// Returns a map from block ID to the best score, blocks without attempts are not included, and an error if any.
+	GetBestScores(ctx context.Context, userID int, blockIDs []int) (map[int]int, error)
+}
+
+// CourseEnrollmentRepository defines methods for course enrollment data access
+type CourseEnrollmentRepository interface {
+	// Create enrolls a user in a course
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	// "courseID" is the ID of the course.
+	//
+	// Returns false if the user is already enrolled in the course and an error if any.
+	Create(ctx context.Context, userID, courseID int) (bool, error)
+	// Delete unenrolls a user from a course
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	// "courseID" is the ID of the course.
+	//
+	// Returns "enrollment not found" error if the user is not enrolled in the course.
+	Delete(ctx context.Context, userID, courseID int) error
+	// TouchActivity updates the last activity time of a user in a course, enrolling the user if needed
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	// "courseID" is the ID of the course.
+	//
+	// Returns an error if any.
+	TouchActivity(ctx context.Context, userID, courseID int) error
+	// GetIncompleteLessons retrieves incomplete lessons in the enrolled courses of a user
+	//
+	// "ctx" is the context for the request.
+	// "userID" is the ID of the user.
+	//
+	// Lessons are grouped by course, courses with the most recent activity come first and lessons of a course are sorted by order.
+	// Returns an empty slice if there is no incomplete lesson in the enrolled courses.
+	GetIncompleteLessons(ctx context.Context, userID int) ([]models.ContinueLessonResponse, error)
 }
 
 type userLessonService struct {
-	courseRepo  CourseRepository
-	lessonRepo  LessonRepository
-	blockRepo   LessonBlockRepository
-	historyRepo LessonUserHistoryRepository
+	courseRepo      CourseRepository
+	lessonRepo      LessonRepository
+	blockRepo       LessonBlockRepository
+	historyRepo     LessonUserHistoryRepository
+	enrollmentRepo  CourseEnrollmentRepository
+	masteryRepo     KanaMasteryRepository
+	quizAttemptRepo LessonQuizAttemptRepository
+	kanjiRepo       KanjiLevelRepository
 }
 
 // NewUserLessonService creates a new user lesson service
@@ -112,12 +207,20 @@ func NewUserLessonService(
 	lessonRepo LessonRepository,
 	blockRepo LessonBlockRepository,
 	historyRepo LessonUserHistoryRepository,
+	enrollmentRepo CourseEnrollmentRepository,
+	masteryRepo KanaMasteryRepository,
+	quizAttemptRepo LessonQuizAttemptRepository,
+	kanjiRepo KanjiLevelRepository,
 ) *userLessonService {
 	return &userLessonService{
-		courseRepo:  courseRepo,
-		lessonRepo:  lessonRepo,
-		blockRepo:   blockRepo,
-		historyRepo: historyRepo,
+		courseRepo:      courseRepo,
+		lessonRepo:      lessonRepo,
+		blockRepo:       blockRepo,
+		historyRepo:     historyRepo,
+		enrollmentRepo:  enrollmentRepo,
+		masteryRepo:     masteryRepo,
+		quizAttemptRepo: quizAttemptRepo,
+		kanjiRepo:       kanjiRepo,
 	}
 }
 
@@ -130,10 +233,17 @@ func (s *userLessonService) GetCoursesList(ctx context.Context, userID int, comp
 		count = 10
 	}
 
-	return s.courseRepo.GetAll(ctx, userID, complexityLevel, search, isMine, page, count)
+	courses, err := s.courseRepo.GetAll(ctx, userID, complexityLevel, search, isMine, page, count)
+	if err != nil {
+		return nil, err
+	}
+	for i := range courses {
+		courses[i].ProgressPercent = progressPercent(courses[i].CompletedLessons, courses[i].TotalLessons)
+	}
+	return courses, nil
 }
 
-// GetLessonsInCourse retrieves course details with lesson list and completion status
+// GetLessonsInCourse retrieves course details with lesson list, completion and lock status
 func (s *userLessonService) GetLessonsInCourse(ctx context.Context, courseSlug string, userID int) (*models.CourseDetailResponse, []models.LessonListItem, error) {
 	// Get course by slug
 	course, err := s.courseRepo.GetBySlug(ctx, courseSlug, userID)
@@ -146,37 +256,169 @@ func (s *userLessonService) GetLessonsInCourse(ctx context.Context, courseSlug s
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get lessons: %w", err)
 	}
+	if err := s.setLocks(ctx, course.Navigation, lessons, userID); err != nil {
+		return nil, nil, err
+	}
 
+	for i := range lessons {
+		lessons[i].ID = 0
+	}
 	course.ID = 0
+	course.ProgressPercent = progressPercent(course.CompletedLessons, course.TotalLessons)
 	return course, lessons, nil
 }
 
+// EnrollInCourse enrolls a user in a course
+func (s *userLessonService) EnrollInCourse(ctx context.Context, courseSlug string, userID int) error {
+	course, err := s.courseRepo.GetBySlug(ctx, courseSlug, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get course: %w", err)
+	}
+
+	created, err := s.enrollmentRepo.Create(ctx, userID, course.ID)
+	if err != nil {
+		return fmt.Errorf("failed to enroll in course: %w", err)
+	}
+	if !created {
+		return fmt.Errorf("already enrolled in course")
+	}
+
+	return nil
+}
+
+// UnenrollFromCourse removes a course from the user's courses
+//
+// Completed lessons are kept, so enrolling in the course again restores the progress.
+func (s *userLessonService) UnenrollFromCourse(ctx context.Context, courseSlug string, userID int) error {
+	course, err := s.courseRepo.GetBySlug(ctx, courseSlug, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get course: %w", err)
+	}
+
+	if err := s.enrollmentRepo.Delete(ctx, userID, course.ID); err != nil {
+		if err.Error() == "enrollment not found" {
+			return fmt.Errorf("not enrolled in course")
+		}
+		return fmt.Errorf("failed to unenroll from course: %w", err)
+	}
+
+	return nil
+}
+
+// GetContinueLesson retrieves the lesson the user continues learning from
+//
+// It is the first incomplete and unlocked lesson by order in the enrolled course with the most recent activity
+// which has such lessons.
+// Returns "next lesson not found" error if there is no lesson to continue from.
+func (s *userLessonService) GetContinueLesson(ctx context.Context, userID int) (*models.ContinueLessonResponse, error) {
+	lessons, err := s.enrollmentRepo.GetIncompleteLessons(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lessons are grouped by course, so locks of every course are checked once
+	courseID := 0
+	var unlocked map[int]bool
+	for i, lesson := range lessons {
+		if lesson.CourseID != courseID {
+			courseID = lesson.CourseID
+			courseLessons, err := s.getCourseLessonsWithLocks(ctx, courseID, userID)
+			if err != nil {
+				return nil, err
+			}
+			unlocked = make(map[int]bool, len(courseLessons))
+			for _, courseLesson := range courseLessons {
+				unlocked[courseLesson.ID] = !courseLesson.Locked
+			}
+		}
+		if unlocked[lesson.LessonID] {
+			return &lessons[i], nil
+		}
+	}
+	return nil, fmt.Errorf("next lesson not found")
+}
+
+// progressPercent calculates the percentage of completed lessons of a course rounded down
+func progressPercent(completed, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return completed * 100 / total
+}
+
 // GetLesson retrieves a full lesson with blocks and completion status
-func (s *userLessonService) GetLesson(ctx context.Context, lessonSlug string, userID int) (*models.LessonListItem, []models.LessonBlockResponse, error) {
+//
+// Text blocks are returned with furigana shown according to the user's furigana settings,
+// please reference parseFuriganaSettings function for more information about furigana parameters.
+// Correct answers of quiz blocks are hidden.
+func (s *userLessonService) GetLesson(ctx context.Context, lessonSlug string, userID int, furigana string, furiganaLevel int) (*models.LessonListItem, []models.LessonBlockResponse, error) {
+	furiganaSettings, err := parseFuriganaSettings(furigana, furiganaLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Get lesson by slug
 	lesson, err := s.lessonRepo.GetBySlug(ctx, lessonSlug, userID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get lesson: %w", err)
 	}
+	if err := s.checkUnlocked(ctx, lesson, userID); err != nil {
+		return nil, nil, err
+	}
 
 	// Get lesson blocks
 	blocks, err := s.blockRepo.GetByLessonID(ctx, lesson.ID)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get lesson blocks: %w", err)
 	}
+	if err := s.annotateTextBlocks(ctx, blocks, furiganaSettings); err != nil {
+		return nil, nil, err
+	}
+	if err := hideQuizAnswers(blocks); err != nil {
+		return nil, nil, err
+	}
 
 	lesson.CourseID = 0 // Clear course ID to avoid leaking course information
 	lesson.ID = 0       // Clear lesson ID to avoid leaking lesson information
 	return lesson, blocks, nil
 }
 
+// annotateTextBlocks sets ruby texts of text block contents
+//
+// Blocks with data other than a JSON object are left as they are.
+func (s *userLessonService) annotateTextBlocks(ctx context.Context, blocks []models.LessonBlockResponse, furigana furiganaSettings) error {
+	contents := make([]string, len(blocks))
+	for i, block := range blocks {
+		if block.BlockType != models.BlockTypeText {
+			continue
+		}
+		var textData models.TextBlockData
+		if err := json.Unmarshal(block.BlockData, &textData); err == nil {
+			contents[i] = textData.Content
+		}
+	}
+	annotated, err := furigana.annotate(ctx, s.kanjiRepo, contents)
+	if err != nil {
+		return err
+	}
+	for i := range blocks {
+		blocks[i].Ruby = annotated[i]
+	}
+	return nil
+}
+
 // ToggleLessonCompletion toggles lesson completion status
+//
+// A lesson can be completed only if the user has reached the passing score in all its quiz blocks which require it.
 func (s *userLessonService) ToggleLessonCompletion(ctx context.Context, lessonSlug string, userID int) error {
 	// Get lesson by slug
 	lesson, err := s.lessonRepo.GetBySlug(ctx, lessonSlug, userID)
 	if err != nil {
 		return fmt.Errorf("failed to get lesson: %w", err)
 	}
+	if err := s.checkUnlocked(ctx, lesson, userID); err != nil {
+		return err
+	}
 
 	// Check if history record exists
 	exists, err := s.historyRepo.Exists(ctx, userID, lesson.CourseID, lesson.ID)
@@ -191,6 +433,10 @@ func (s *userLessonService) ToggleLessonCompletion(ctx context.Context, lessonSl
 			return fmt.Errorf("failed to delete history record: %w", err)
 		}
 	} else {
+		if err := s.checkQuizzesPassed(ctx, lesson.ID, userID); err != nil {
+			return err
+		}
+
 		// Create history record (complete)
 		history := &models.LessonUserHistory{
 			UserID:   userID,
@@ -203,5 +449,107 @@ func (s *userLessonService) ToggleLessonCompletion(ctx context.Context, lessonSl
 		}
 	}
 
+	// Record the activity, completing a lesson also enrolls the user in its course
+	if err := s.enrollmentRepo.TouchActivity(ctx, userID, lesson.CourseID); err != nil {
+		return fmt.Errorf("failed to update course activity: %w", err)
+	}
+
+	return nil
+}
+
+// checkUnlocked returns "lesson is locked" error if the lesson is locked for the user
+func (s *userLessonService) checkUnlocked(ctx context.Context, lesson *models.LessonListItem, userID int) error {
+	// Completed lessons are never locked, so they can always be reviewed or uncompleted
+	if lesson.Completed {
+		return nil
+	}
+
+	lessons, err := s.getCourseLessonsWithLocks(ctx, lesson.CourseID, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, courseLesson := range lessons {
+		if courseLesson.ID == lesson.ID && courseLesson.Locked {
+			return fmt.Errorf("lesson is locked")
+		}
+	}
+	return nil
+}
+
+// getCourseLessonsWithLocks retrieves lessons of a course with completion and lock status for the user
+func (s *userLessonService) getCourseLessonsWithLocks(ctx context.Context, courseID, userID int) ([]models.LessonListItem, error) {
+	navigation, err := s.courseRepo.GetNavigation(ctx, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get course: %w", err)
+	}
+	lessons, err := s.lessonRepo.GetByCourseIDWithCompletion(ctx, courseID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+	if err := s.setLocks(ctx, navigation, lessons, userID); err != nil {
+		return nil, err
+	}
+	return lessons, nil
+}
+
+// setLocks sets lock status of course lessons sorted by order
+//
+// Completed lessons are never locked. Other lessons are locked if:
+//   - the course is sequential and any previous lesson is not completed;
+//   - any of their prerequisite lessons is not completed;
+//   - kana mastery of the user is lower than the minimum kana mastery of the lesson.
+func (s *userLessonService) setLocks(ctx context.Context, navigation models.CourseNavigation, lessons []models.LessonListItem, userID int) error {
+	lessonIDs := make([]int, len(lessons))
+	for i, lesson := range lessons {
+		lessonIDs[i] = lesson.ID
+	}
+	prerequisites, err := s.lessonRepo.GetPrerequisiteIDs(ctx, lessonIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get lesson prerequisites: %w", err)
+	}
+
+	// Collect requirements of incomplete lessons, prerequisites may belong to other courses
+	var prerequisiteIDs []int
+	needsMastery := false
+	for _, lesson := range lessons {
+		if lesson.Completed {
+			continue
+		}
+		prerequisiteIDs = append(prerequisiteIDs, prerequisites[lesson.ID]...)
+		needsMastery = needsMastery || lesson.MinKanaMastery > 0
+	}
+
+	completed := make(map[int]bool)
+	if len(prerequisiteIDs) > 0 {
+		slices.Sort(prerequisiteIDs)
+		completedIDs, err := s.historyRepo.GetCompletedLessonIDs(ctx, userID, slices.Compact(prerequisiteIDs))
+		if err != nil {
+			return fmt.Errorf("failed to get completed lessons: %w", err)
+		}
+		for _, id := range completedIDs {
+			completed[id] = true
+		}
+	}
+
+	masteryPercent := 0
+	if needsMastery {
+		mastery, err := s.masteryRepo.GetKanaMastery(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get kana mastery: %w", err)
+		}
+		masteryPercent = int(math.Round(float64(mastery) * 100))
+	}
+
+	previousCompleted := true
+	for i := range lessons {
+		lesson := &lessons[i]
+		if !lesson.Completed {
+			lesson.Locked = (navigation == models.CourseNavigationSequential && !previousCompleted) ||
+				lesson.MinKanaMastery > masteryPercent ||
+				slices.ContainsFunc(prerequisites[lesson.ID], func(id int) bool { return !completed[id] })
+		}
+		previousCompleted = previousCompleted && lesson.Completed
+	}
 	return nil
 }
@@ -2,19 +2,23 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // mockCourseRepository is a mock implementation of CourseRepository
 type mockCourseRepository struct {
-	course       *models.CourseDetailResponse
-	courses      []models.CourseDetailResponse
-	err          error
-	getBySlugErr error
+	course        *models.CourseDetailResponse
+	courses       []models.CourseDetailResponse
+	err           error
+	getBySlugErr  error
+	navigation    models.CourseNavigation
+	navigationErr error
 }
 
 func (m *mockCourseRepository) GetBySlug(ctx context.Context, slug string, userID int) (*models.CourseDetailResponse, error) {
@@ -34,12 +38,21 @@ func (m *mockCourseRepository) GetAll(ctx context.Context, userID int, complexit
 	return m.courses, nil
 }
 
+func (m *mockCourseRepository) GetNavigation(ctx context.Context, id int) (models.CourseNavigation, error) {
+	if m.navigationErr != nil {
+		return "", m.navigationErr
+	}
+	return m.navigation, nil
+}
+
 // mockLessonRepository is a mock implementation of LessonRepository
 type mockLessonRepository struct {
-	lesson       *models.LessonListItem
-	lessons      []models.LessonListItem
-	err          error
-	getBySlugErr error
+	lesson           *models.LessonListItem
+	lessons          []models.LessonListItem
+	err              error
+	getBySlugErr     error
+	prerequisites    map[int][]int
+	prerequisitesErr error
 }
 
 func (m *mockLessonRepository) GetBySlug(ctx context.Context, slug string, userID int) (*models.LessonListItem, error) {
@@ -59,6 +72,13 @@ func (m *mockLessonRepository) GetByCourseIDWithCompletion(ctx context.Context,
 	return m.lessons, nil
 }
 
+func (m *mockLessonRepository) GetPrerequisiteIDs(ctx context.Context, lessonIDs []int) (map[int][]int, error) {
+	if m.prerequisitesErr != nil {
+		return nil, m.prerequisitesErr
+	}
+	return m.prerequisites, nil
+}
+
 // mockLessonBlockRepository is a mock implementation of LessonBlockRepository
 type mockLessonBlockRepository struct {
 	blocks []models.LessonBlockResponse
@@ -83,6 +103,7 @@ type mockLessonUserHistoryRepository struct {
 	count        int
 	createCalled bool
 	deleteCalled bool
+	completedIDs []int
 }
 
 func (m *mockLessonUserHistoryRepository) Exists(ctx context.Context, userID, courseID, lessonID int) (bool, error) {
@@ -109,19 +130,107 @@ func (m *mockLessonUserHistoryRepository) CountCompletedLessonsByCourse(ctx cont
 	return m.count, nil
 }
 
+func (m *mockLessonUserHistoryRepository) GetCompletedLessonIDs(ctx context.Context, userID int, lessonIDs []int) ([]int, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.completedIDs, nil
+}
+
+// mockKanaMasteryRepository is a mock implementation of KanaMasteryRepository
+type mockKanaMasteryRepository struct {
+	mastery float32
+	err     error
+	called  bool
+}
+
+func (m *mockKanaMasteryRepository) GetKanaMastery(ctx context.Context, userID int) (float32, error) {
+	m.called = true
+	return m.mastery, m.err
+}
+
+// mockLessonQuizAttemptRepository is a mock implementation of LessonQuizAttemptRepository
+type mockLessonQuizAttemptRepository struct {
+	bestScores     map[int]int
+	createErr      error
+	scoresErr      error
+	createdScore   int
+	createCalled   bool
+	scoresBlockIDs []int
+}
+
+func (m *mockLessonQuizAttemptRepository) Create(ctx context.Context, attempt *models.LessonQuizAttempt) error {
+	m.createCalled = true
+	m.createdScore = attempt.Score
+	return m.createErr
+}
+
+func (m *mockLessonQuizAttemptRepository) GetBestScores(ctx context.Context, userID int, blockIDs []int) (map[int]int, error) {
+	m.scoresBlockIDs = blockIDs
+	if m.scoresErr != nil {
+		return nil, m.scoresErr
+	}
+	return m.bestScores, nil
+}
+
+// mockCourseEnrollmentRepository is a mock implementation of CourseEnrollmentRepository
+type mockCourseEnrollmentRepository struct {
+	created         bool
+	createErr       error
+	deleteErr       error
+	touchErr        error
+	incomplete      []models.ContinueLessonResponse
+	incompleteErr   error
+	courseID        int
+	touchedCourseID int
+}
+
+func (m *mockCourseEnrollmentRepository) Create(ctx context.Context, userID, courseID int) (bool, error) {
+	m.courseID = courseID
+	if m.createErr != nil {
+		return false, m.createErr
+	}
+	return m.created, nil
+}
+
+func (m *mockCourseEnrollmentRepository) Delete(ctx context.Context, userID, courseID int) error {
+	m.courseID = courseID
+	return m.deleteErr
+}
+
+func (m *mockCourseEnrollmentRepository) TouchActivity(ctx context.Context, userID, courseID int) error {
+	m.touchedCourseID = courseID
+	return m.touchErr
+}
+
+func (m *mockCourseEnrollmentRepository) GetIncompleteLessons(ctx context.Context, userID int) ([]models.ContinueLessonResponse, error) {
+	if m.incompleteErr != nil {
+		return nil, m.incompleteErr
+	}
+	return m.incomplete, nil
+}
+
 func TestNewUserLessonService(t *testing.T) {
 	courseRepo := &mockCourseRepository{}
 	lessonRepo := &mockLessonRepository{}
 	blockRepo := &mockLessonBlockRepository{}
 	historyRepo := &mockLessonUserHistoryRepository{}
+	enrollmentRepo := &mockCourseEnrollmentRepository{}
+	masteryRepo := &mockKanaMasteryRepository{}
+	quizAttemptRepo := &mockLessonQuizAttemptRepository{}
+	kanjiRepo := &mockKanjiLevelRepository{}
 
-	svc := NewUserLessonService(courseRepo, lessonRepo, blockRepo, historyRepo)
+	svc := NewUserLessonService(courseRepo, lessonRepo, blockRepo, historyRepo, enrollmentRepo, masteryRepo, quizAttemptRepo, kanjiRepo)
 
 	assert.NotNil(t, svc)
 	assert.Equal(t, courseRepo, svc.courseRepo)
 	assert.Equal(t, lessonRepo, svc.lessonRepo)
 	assert.Equal(t, blockRepo, svc.blockRepo)
 	assert.Equal(t, historyRepo, svc.historyRepo)
+	assert.Equal(t, enrollmentRepo, svc.enrollmentRepo)
+	assert.Equal(t, masteryRepo, svc.masteryRepo)
+	assert.Equal(t, quizAttemptRepo, svc.quizAttemptRepo)
+	assert.Equal(t, kanjiRepo, svc.kanjiRepo)
 }
 
 func TestUserLessonService_GetCoursesList(t *testing.T) {
@@ -252,6 +361,10 @@ func TestUserLessonService_GetCoursesList(t *testing.T) {
 				&mockLessonRepository{},
 				&mockLessonBlockRepository{},
 				&mockLessonUserHistoryRepository{},
+				&mockCourseEnrollmentRepository{},
+				&mockKanaMasteryRepository{},
+				&mockLessonQuizAttemptRepository{},
+				&mockKanjiLevelRepository{},
 			)
 
 			result, err := svc.GetCoursesList(
@@ -352,6 +465,10 @@ func TestUserLessonService_GetLessonsInCourse(t *testing.T) {
 				tt.lessonRepo,
 				&mockLessonBlockRepository{},
 				&mockLessonUserHistoryRepository{},
+				&mockCourseEnrollmentRepository{},
+				&mockKanaMasteryRepository{},
+				&mockLessonQuizAttemptRepository{},
+				&mockKanjiLevelRepository{},
 			)
 
 			course, lessons, err := svc.GetLessonsInCourse(context.Background(), tt.courseSlug, tt.userID)
@@ -451,9 +568,13 @@ func TestUserLessonService_GetLesson(t *testing.T) {
 				tt.lessonRepo,
 				tt.blockRepo,
 				&mockLessonUserHistoryRepository{},
+				&mockCourseEnrollmentRepository{},
+				&mockKanaMasteryRepository{},
+				&mockLessonQuizAttemptRepository{},
+				&mockKanjiLevelRepository{},
 			)
 
-			lesson, blocks, err := svc.GetLesson(context.Background(), tt.lessonSlug, tt.userID)
+			lesson, blocks, err := svc.GetLesson(context.Background(), tt.lessonSlug, tt.userID, "", 0)
 
 			if tt.expectedError {
 				assert.Error(t, err)
@@ -476,6 +597,67 @@ func TestUserLessonService_GetLesson(t *testing.T) {
 	}
 }
 
+func TestUserLessonService_GetLesson_Furigana(t *testing.T) {
+	lessonRepo := func() *mockLessonRepository {
+		return &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1, Title: "Test Lesson"}}
+	}
+	blockRepo := func() *mockLessonBlockRepository {
+		return &mockLessonBlockRepository{blocks: []models.LessonBlockResponse{
+			{ID: 1, BlockType: models.BlockTypeText, BlockData: json.RawMessage(`{"content":"{漢字|かんじ}を{書|か}く"}`)},
+			{ID: 2, BlockType: models.BlockTypeVideo, BlockData: json.RawMessage(`{"content":"{漢字|かんじ}"}`)},
+			{ID: 3, BlockType: models.BlockTypeText, BlockData: json.RawMessage(`"not an object"`)},
+		}}
+	}
+
+	t.Run("above level", func(t *testing.T) {
+		kanjiRepo := &mockKanjiLevelRepository{levels: map[string]int{"漢": 3, "字": 4, "書": 5}}
+		svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo(), blockRepo(), &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, kanjiRepo)
+
+		_, blocks, err := svc.GetLesson(context.Background(), "test-lesson", 1, "above level", 4)
+
+		assert.NoError(t, err)
+		assert.Len(t, blocks, 3)
+		if assert.NotNil(t, blocks[0].Ruby) {
+			assert.Equal(t, []models.RubySegment{{Text: "漢字", Reading: "かんじ"}, {Text: "を書く"}}, blocks[0].Ruby.Segments)
+			assert.Equal(t, "<ruby>漢字<rp>(</rp><rt>かんじ</rt><rp>)</rp></ruby>を書く", blocks[0].Ruby.HTML)
+		}
+		assert.Nil(t, blocks[1].Ruby)
+		assert.Nil(t, blocks[2].Ruby)
+	})
+
+	t.Run("never", func(t *testing.T) {
+		svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo(), blockRepo(), &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		_, blocks, err := svc.GetLesson(context.Background(), "test-lesson", 1, "never", 0)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, blocks[0].Ruby) {
+			assert.Equal(t, []models.RubySegment{{Text: "漢字を書く"}}, blocks[0].Ruby.Segments)
+		}
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo(), blockRepo(), &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		lesson, blocks, err := svc.GetLesson(context.Background(), "test-lesson", 1, "sometimes", 0)
+
+		assert.ErrorContains(t, err, "invalid furigana mode")
+		assert.Nil(t, lesson)
+		assert.Nil(t, blocks)
+	})
+
+	t.Run("kanji repository error", func(t *testing.T) {
+		kanjiRepo := &mockKanjiLevelRepository{err: errors.New("database error")}
+		svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo(), blockRepo(), &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, kanjiRepo)
+
+		lesson, blocks, err := svc.GetLesson(context.Background(), "test-lesson", 1, "above level", 3)
+
+		assert.ErrorContains(t, err, "failed to get kanji levels")
+		assert.Nil(t, lesson)
+		assert.Nil(t, blocks)
+	})
+}
+
 func TestUserLessonService_ToggleLessonCompletion(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -606,12 +788,17 @@ func TestUserLessonService_ToggleLessonCompletion(t *testing.T) {
 				createErr: tt.historyRepo.createErr,
 				deleteErr: tt.historyRepo.deleteErr,
 			}
+			enrollmentRepo := &mockCourseEnrollmentRepository{}
 
 			svc := NewUserLessonService(
 				&mockCourseRepository{},
 				tt.lessonRepo,
 				&mockLessonBlockRepository{},
 				historyRepo,
+				enrollmentRepo,
+				&mockKanaMasteryRepository{},
+				&mockLessonQuizAttemptRepository{},
+				&mockKanjiLevelRepository{},
 			)
 
 			err := svc.ToggleLessonCompletion(context.Background(), tt.lessonSlug, tt.userID)
@@ -623,6 +810,7 @@ func TestUserLessonService_ToggleLessonCompletion(t *testing.T) {
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, 1, enrollmentRepo.touchedCourseID, "TouchActivity should have been called")
 			}
 
 			if tt.shouldCreate {
@@ -633,3 +821,395 @@ func TestUserLessonService_ToggleLessonCompletion(t *testing.T) {
 		})
 	}
 }
+
+func TestUserLessonService_ToggleLessonCompletion_ActivityError(t *testing.T) {
+	lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 2}}
+	enrollmentRepo := &mockCourseEnrollmentRepository{touchErr: errors.New("database error")}
+	svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, enrollmentRepo, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+	err := svc.ToggleLessonCompletion(context.Background(), "test-lesson", 1)
+
+	assert.ErrorContains(t, err, "failed to update course activity")
+	assert.Equal(t, 2, enrollmentRepo.touchedCourseID)
+}
+
+func TestUserLessonService_CourseProgress(t *testing.T) {
+	t.Run("courses list", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{courses: []models.CourseDetailResponse{
+			{Title: "Course 1", TotalLessons: 3, CompletedLessons: 2},
+			{Title: "Course 2", TotalLessons: 4, CompletedLessons: 4},
+			{Title: "Empty course"},
+		}}
+		svc := NewUserLessonService(courseRepo, &mockLessonRepository{}, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		courses, err := svc.GetCoursesList(context.Background(), 1, nil, "", true, 1, 10)
+
+		assert.NoError(t, err)
+		if assert.Len(t, courses, 3) {
+			assert.Equal(t, 66, courses[0].ProgressPercent)
+			assert.Equal(t, 100, courses[1].ProgressPercent)
+			assert.Equal(t, 0, courses[2].ProgressPercent)
+		}
+	})
+
+	t.Run("course details", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{course: &models.CourseDetailResponse{ID: 1, Title: "Course 1", TotalLessons: 4, CompletedLessons: 1}}
+		svc := NewUserLessonService(courseRepo, &mockLessonRepository{}, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		course, _, err := svc.GetLessonsInCourse(context.Background(), "course-1", 1)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, course) {
+			assert.Equal(t, 25, course.ProgressPercent)
+		}
+	})
+}
+
+func TestUserLessonService_EnrollInCourse(t *testing.T) {
+	tests := []struct {
+		name           string
+		courseRepo     *mockCourseRepository
+		enrollmentRepo *mockCourseEnrollmentRepository
+		errorContains  string
+	}{
+		{
+			name:           "success",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{created: true},
+		},
+		{
+			name:           "already enrolled",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{created: false},
+			errorContains:  "already enrolled in course",
+		},
+		{
+			name:           "course not found",
+			courseRepo:     &mockCourseRepository{getBySlugErr: errors.New("course not found")},
+			enrollmentRepo: &mockCourseEnrollmentRepository{},
+			errorContains:  "failed to get course: course not found",
+		},
+		{
+			name:           "repository error",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{createErr: errors.New("database error")},
+			errorContains:  "failed to enroll in course",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserLessonService(tt.courseRepo, &mockLessonRepository{}, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, tt.enrollmentRepo, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+			err := svc.EnrollInCourse(context.Background(), "course", 1)
+
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, tt.enrollmentRepo.courseID)
+			}
+		})
+	}
+}
+
+func TestUserLessonService_UnenrollFromCourse(t *testing.T) {
+	tests := []struct {
+		name           string
+		courseRepo     *mockCourseRepository
+		enrollmentRepo *mockCourseEnrollmentRepository
+		expectedError  string
+	}{
+		{
+			name:           "success",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{},
+		},
+		{
+			name:           "not enrolled",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{deleteErr: errors.New("enrollment not found")},
+			expectedError:  "not enrolled in course",
+		},
+		{
+			name:           "course not found",
+			courseRepo:     &mockCourseRepository{getBySlugErr: errors.New("course not found")},
+			enrollmentRepo: &mockCourseEnrollmentRepository{},
+			expectedError:  "failed to get course: course not found",
+		},
+		{
+			name:           "repository error",
+			courseRepo:     &mockCourseRepository{course: &models.CourseDetailResponse{ID: 3}},
+			enrollmentRepo: &mockCourseEnrollmentRepository{deleteErr: errors.New("database error")},
+			expectedError:  "failed to unenroll from course: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserLessonService(tt.courseRepo, &mockLessonRepository{}, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, tt.enrollmentRepo, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+			err := svc.UnenrollFromCourse(context.Background(), "course", 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 3, tt.enrollmentRepo.courseID)
+			}
+		})
+	}
+}
+
+func TestUserLessonService_GetContinueLesson(t *testing.T) {
+	incomplete := []models.ContinueLessonResponse{
+		{CourseID: 1, LessonID: 2, CourseSlug: "course", LessonSlug: "lesson-2", Order: 2},
+		{CourseID: 1, LessonID: 3, CourseSlug: "course", LessonSlug: "lesson-3", Order: 3},
+	}
+
+	tests := []struct {
+		name           string
+		enrollmentRepo *mockCourseEnrollmentRepository
+		courseRepo     *mockCourseRepository
+		lessonRepo     *mockLessonRepository
+		expected       *models.ContinueLessonResponse
+		expectedError  string
+	}{
+		{
+			name:           "first incomplete lesson",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incomplete: incomplete},
+			courseRepo:     &mockCourseRepository{navigation: models.CourseNavigationSequential},
+			lessonRepo: &mockLessonRepository{lessons: []models.LessonListItem{
+				{ID: 1, Order: 1, Completed: true},
+				{ID: 2, Order: 2},
+				{ID: 3, Order: 3},
+			}},
+			expected: &incomplete[0],
+		},
+		{
+			name:           "locked lessons are skipped",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incomplete: incomplete},
+			courseRepo:     &mockCourseRepository{navigation: models.CourseNavigationFree},
+			lessonRepo: &mockLessonRepository{
+				lessons: []models.LessonListItem{
+					{ID: 1, Order: 1, Completed: true},
+					{ID: 2, Order: 2},
+					{ID: 3, Order: 3},
+				},
+				prerequisites: map[int][]int{2: {10}},
+			},
+			expected: &incomplete[1],
+		},
+		{
+			name:           "all incomplete lessons are locked",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incomplete: incomplete},
+			courseRepo:     &mockCourseRepository{navigation: models.CourseNavigationFree},
+			lessonRepo: &mockLessonRepository{lessons: []models.LessonListItem{
+				{ID: 1, Order: 1, Completed: true},
+				{ID: 2, Order: 2, MinKanaMastery: 50},
+				{ID: 3, Order: 3, MinKanaMastery: 50},
+			}},
+			expectedError: "next lesson not found",
+		},
+		{
+			name:           "nothing to continue",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incomplete: []models.ContinueLessonResponse{}},
+			courseRepo:     &mockCourseRepository{},
+			lessonRepo:     &mockLessonRepository{},
+			expectedError:  "next lesson not found",
+		},
+		{
+			name:           "incomplete lessons error",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incompleteErr: errors.New("database error")},
+			courseRepo:     &mockCourseRepository{},
+			lessonRepo:     &mockLessonRepository{},
+			expectedError:  "database error",
+		},
+		{
+			name:           "course navigation error",
+			enrollmentRepo: &mockCourseEnrollmentRepository{incomplete: incomplete},
+			courseRepo:     &mockCourseRepository{navigationErr: errors.New("database error")},
+			lessonRepo:     &mockLessonRepository{},
+			expectedError:  "failed to get course: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewUserLessonService(tt.courseRepo, tt.lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, tt.enrollmentRepo, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+			lesson, err := svc.GetContinueLesson(context.Background(), 1)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, lesson)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, lesson)
+		})
+	}
+}
+
+func TestUserLessonService_GetLessonsInCourse_Locks(t *testing.T) {
+	tests := []struct {
+		name            string
+		navigation      models.CourseNavigation
+		lessons         []models.LessonListItem
+		prerequisites   map[int][]int
+		completedIDs    []int
+		mastery         float32
+		expectedLocked  []bool
+		expectedMastery bool
+	}{
+		{
+			name:       "free navigation",
+			navigation: models.CourseNavigationFree,
+			lessons: []models.LessonListItem{
+				{ID: 1, Order: 1},
+				{ID: 2, Order: 2},
+			},
+			expectedLocked: []bool{false, false},
+		},
+		{
+			name:       "sequential navigation locks lessons after the first incomplete one",
+			navigation: models.CourseNavigationSequential,
+			lessons: []models.LessonListItem{
+				{ID: 1, Order: 1, Completed: true},
+				{ID: 2, Order: 2},
+				{ID: 3, Order: 3},
+				{ID: 4, Order: 4, Completed: true},
+			},
+			expectedLocked: []bool{false, false, true, false},
+		},
+		{
+			name:       "incomplete prerequisite from another course",
+			navigation: models.CourseNavigationFree,
+			lessons: []models.LessonListItem{
+				{ID: 1, Order: 1},
+				{ID: 2, Order: 2},
+				{ID: 3, Order: 3},
+			},
+			prerequisites:  map[int][]int{2: {10}, 3: {11, 12}},
+			completedIDs:   []int{10, 11},
+			expectedLocked: []bool{false, false, true},
+		},
+		{
+			name:       "kana mastery reaches minimum",
+			navigation: models.CourseNavigationFree,
+			lessons: []models.LessonListItem{
+				{ID: 1, Order: 1, MinKanaMastery: 50},
+				{ID: 2, Order: 2, MinKanaMastery: 70},
+				{ID: 3, Order: 3, MinKanaMastery: 90, Completed: true},
+			},
+			mastery:         0.7,
+			expectedLocked:  []bool{false, false, false},
+			expectedMastery: true,
+		},
+		{
+			name:       "kana mastery below minimum locks lesson",
+			navigation: models.CourseNavigationFree,
+			lessons: []models.LessonListItem{
+				{ID: 1, Order: 1, MinKanaMastery: 80},
+			},
+			mastery:         0.55,
+			expectedLocked:  []bool{true},
+			expectedMastery: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			courseRepo := &mockCourseRepository{course: &models.CourseDetailResponse{ID: 1, Title: "Kana", Navigation: tt.navigation}}
+			lessonRepo := &mockLessonRepository{lessons: tt.lessons, prerequisites: tt.prerequisites}
+			historyRepo := &mockLessonUserHistoryRepository{completedIDs: tt.completedIDs}
+			masteryRepo := &mockKanaMasteryRepository{mastery: tt.mastery}
+			svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, historyRepo, &mockCourseEnrollmentRepository{}, masteryRepo, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+			_, lessons, err := svc.GetLessonsInCourse(context.Background(), "kana", 1)
+
+			require.NoError(t, err)
+			require.Len(t, lessons, len(tt.expectedLocked))
+			for i, locked := range tt.expectedLocked {
+				assert.Equal(t, locked, lessons[i].Locked, "lesson %d", i+1)
+				assert.Equal(t, 0, lessons[i].ID)
+			}
+			assert.Equal(t, tt.expectedMastery, masteryRepo.called)
+		})
+	}
+}
+
+func TestUserLessonService_LockedLesson(t *testing.T) {
+	lessons := func() []models.LessonListItem {
+		return []models.LessonListItem{
+			{ID: 1, Order: 1},
+			{ID: 2, Order: 2},
+		}
+	}
+
+	t.Run("get locked lesson", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{navigation: models.CourseNavigationSequential}
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 2, CourseID: 1}, lessons: lessons()}
+		svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		lesson, blocks, err := svc.GetLesson(context.Background(), "lesson-2", 1, "", 0)
+
+		assert.EqualError(t, err, "lesson is locked")
+		assert.Nil(t, lesson)
+		assert.Nil(t, blocks)
+	})
+
+	t.Run("get unlocked lesson", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{navigation: models.CourseNavigationSequential}
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 1, CourseID: 1}, lessons: lessons()}
+		svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		lesson, _, err := svc.GetLesson(context.Background(), "lesson-1", 1, "", 0)
+
+		require.NoError(t, err)
+		assert.False(t, lesson.Locked)
+	})
+
+	t.Run("complete locked lesson", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{navigation: models.CourseNavigationSequential}
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 2, CourseID: 1}, lessons: lessons()}
+		historyRepo := &mockLessonUserHistoryRepository{}
+		svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, historyRepo, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		err := svc.ToggleLessonCompletion(context.Background(), "lesson-2", 1)
+
+		assert.EqualError(t, err, "lesson is locked")
+		assert.False(t, historyRepo.createCalled)
+	})
+
+	t.Run("uncomplete lesson is never locked", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{navigationErr: errors.New("should not be called")}
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 2, CourseID: 1, Completed: true}}
+		historyRepo := &mockLessonUserHistoryRepository{exists: true}
+		svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, historyRepo, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		err := svc.ToggleLessonCompletion(context.Background(), "lesson-2", 1)
+
+		require.NoError(t, err)
+		assert.True(t, historyRepo.deleteCalled)
+	})
+
+	t.Run("navigation error", func(t *testing.T) {
+		courseRepo := &mockCourseRepository{navigationErr: errors.New("database error")}
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 2, CourseID: 1}}
+		svc := NewUserLessonService(courseRepo, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		_, _, err := svc.GetLesson(context.Background(), "lesson-2", 1, "", 0)
+
+		assert.ErrorContains(t, err, "failed to get course")
+	})
+
+	t.Run("prerequisites error", func(t *testing.T) {
+		lessonRepo := &mockLessonRepository{lesson: &models.LessonListItem{ID: 2, CourseID: 1}, lessons: lessons(), prerequisitesErr: errors.New("database error")}
+		svc := NewUserLessonService(&mockCourseRepository{}, lessonRepo, &mockLessonBlockRepository{}, &mockLessonUserHistoryRepository{}, &mockCourseEnrollmentRepository{}, &mockKanaMasteryRepository{}, &mockLessonQuizAttemptRepository{}, &mockKanjiLevelRepository{})
+
+		_, _, err := svc.GetLesson(context.Background(), "lesson-2", 1, "", 0)
+
+		assert.ErrorContains(t, err, "failed to get lesson prerequisites")
+	})
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/anki"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"go.uber.org/zap"
+)
+
+// WordImportJobRepository is the interface that wraps methods for WordImportJob table data access
+type WordImportJobRepository interface {
+	// Method Create creates a new word import job and sets its ID.
+	//
+	// "job" parameter is used to create a new word import job.
+	//
+	// If some error will occur during data creation, the error will be returned.
+	Create(ctx context.Context, job *models.WordImportJob) error
+	// Method UpdateProgress saves the status, counters and error report of a word import job.
+	//
+	// "job" parameter is used to identify the job and provides the values to save.
+	//
+	// If some error will occur during data update, the error will be returned.
+	UpdateProgress(ctx context.Context, job *models.WordImportJob) error
+	// Method GetByID retrieves a word import job by its ID.
+	//
+	// "id" parameter is used to identify the job.
+	//
+	// If some error will occur during data retrieve, the error will be returned together with "nil" value.
+	GetByID(ctx context.Context, id int) (*models.WordImportJob, error)
+	// Method FailRunning marks all running word import jobs as failed.
+	//
+	// If some error will occur during data update, the error will be returned together with "0" value,
+	// otherwise the number of failed jobs will be returned.
+	FailRunning(ctx context.Context) (int, error)
+}
+
+// Review periods of imported words in days
+const (
+	importEasyPeriod      = 1
+	importNormalPeriod    = 3
+	importHardPeriod      = 7
+	importExtraHardPeriod = 14
+)
+
+// importProgressInterval is the number of notes after which the progress of an import job is saved
+const importProgressInterval = 25
+
+// maxImportErrors is the maximum number of errors kept in the report of an import job,
+// further errors are only counted, so the report saved on every progress update stays small
+const maxImportErrors = 1000
+
+// wordImportService implements WordImportService
+type wordImportService struct {
+	wordRepo      AdminWordRepository
+	wordKanjiRepo WordKanjiRepository
+	jobRepo       WordImportJobRepository
+	mediaBaseURL  string
+	apiKey        string
+	logger        *zap.Logger
+}
+
+// NewWordImportService creates a new word import service
+func NewWordImportService(
+	wordRepo AdminWordRepository,
+	wordKanjiRepo WordKanjiRepository,
+	jobRepo WordImportJobRepository,
+	mediaBaseURL, apiKey string,
+	logger *zap.Logger,
+) *wordImportService {
+	return &wordImportService{
+		wordRepo:      wordRepo,
+		wordKanjiRepo: wordKanjiRepo,
+		jobRepo:       jobRepo,
+		mediaBaseURL:  mediaBaseURL,
+		apiKey:        apiKey,
+		logger:        logger,
+	}
+}
+
+// StartImport validates an imported file and starts a background job creating words from its notes
+//
+// For successful results:
+//
+// - The file must be an Anki package (.apkg), a CSV file (.csv) or a TSV file (.tsv or .txt)
+//
+// - The mapping must name fields for the word and its phonetic clues, every mapped field must exist in the file
+//
+// - Audio fields may be mapped only for Anki packages
+//
+// The file is parsed before the job is created, so a broken file is reported immediately.
+// Notes whose word or phonetic clues already exist are skipped. The job keeps running after the request is finished,
+// its progress and error report can be retrieved with GetImportJob method.
+func (s *wordImportService) StartImport(ctx context.Context, filename string, data []byte, mapping *models.WordImportMapping) (*models.WordImportJob, error) {
+	format, err := detectImportFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil || mapping.Word == "" || mapping.PhoneticClues == "" {
+		return nil, fmt.Errorf("invalid mapping, word and phoneticClues fields are required")
+	}
+	if format != models.WordImportFormatAPKG && (mapping.WordAudio != "" || mapping.WordExampleAudio != "") {
+		return nil, fmt.Errorf("invalid mapping, audio fields are supported only for Anki packages")
+	}
+	if mapping, err = normalizeImportMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	var pkg *anki.Package
+	var notes []anki.Note
+	switch format {
+	case models.WordImportFormatAPKG:
+		pkg, err = anki.ReadPackage(data)
+		if pkg != nil {
+			notes = pkg.Notes
+		}
+	case models.WordImportFormatCSV:
+		notes, err = anki.ReadText(data, ',')
+	default:
+		notes, err = anki.ReadText(data, '\t')
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid file: %w", err)
+	}
+	if len(notes) == 0 {
+		return nil, fmt.Errorf("invalid file: no notes found")
+	}
+	if err := validateImportMapping(mapping, notes); err != nil {
+		return nil, err
+	}
+
+	job := &models.WordImportJob{
+		Filename: filepath.Base(filename),
+		Format:   format,
+		Status:   models.WordImportStatusRunning,
+		Total:    len(notes),
+		Errors:   []models.WordImportError{},
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create word import job: %w", err)
+	}
+
+	// The job is updated by the background goroutine, so a copy of its initial state is returned
+	started := *job
+
+	// The job must outlive the request, so only values of the request context are kept
+	go s.runImport(context.WithoutCancel(ctx), job, notes, pkg, mapping)
+
+	return &started, nil
+}
+
+// GetImportJob retrieves a word import job with its progress and error report
+func (s *wordImportService) GetImportJob(ctx context.Context, id int) (*models.WordImportJob, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("invalid word import job id")
+	}
+
+	return s.jobRepo.GetByID(ctx, id)
+}
+
+// FailInterruptedImports marks word import jobs left running by a previous run of the service as failed
+//
+// Imports run in goroutines of the service, so no job can be running at startup.
+// Returns the number of failed jobs.
+func (s *wordImportService) FailInterruptedImports(ctx context.Context) (int, error) {
+	return s.jobRepo.FailRunning(ctx)
+}
+
+// detectImportFormat detects the format of an imported file by its extension
+func detectImportFormat(filename string) (models.WordImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".apkg":
+		return models.WordImportFormatAPKG, nil
+	case ".csv":
+		return models.WordImportFormatCSV, nil
+	case ".tsv", ".txt":
+		return models.WordImportFormatTSV, nil
+	default:
+		return "", fmt.Errorf("invalid file format, must be .apkg, .csv, .tsv or .txt")
+	}
+}
+
+// validateImportMapping checks that every mapped field exists in at least one note
+func validateImportMapping(mapping *models.WordImportMapping, notes []anki.Note) error {
+	for _, field := range mappedFields(mapping) {
+		found := false
+		for _, note := range notes {
+			if _, ok := note.Fields[field]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid mapping, field %q not found in the file", field)
+		}
+	}
+	return nil
+}
+
+// normalizeImportMapping returns a copy of the mapping with lowercased language codes of translations
+//
+// If some language code is invalid, the error will be returned.
+func normalizeImportMapping(mapping *models.WordImportMapping) (*models.WordImportMapping, error) {
+	normalized := *mapping
+	var err error
+	if normalized.Translations, err = normalizeLanguages(mapping.Translations); err != nil {
+		return nil, fmt.Errorf("invalid mapping, %w", err)
+	}
+	if normalized.ExampleTranslations, err = normalizeLanguages(mapping.ExampleTranslations); err != nil {
+		return nil, fmt.Errorf("invalid mapping, %w", err)
+	}
+	return &normalized, nil
+}
+
+// mappedFields returns the names of all mapped fields, translations in order of language codes
+func mappedFields(mapping *models.WordImportMapping) []string {
+	all := []string{mapping.Word, mapping.PhoneticClues, mapping.Example}
+	for _, language := range slices.Sorted(maps.Keys(mapping.Translations)) {
+		all = append(all, mapping.Translations[language])
+	}
+	for _, language := range slices.Sorted(maps.Keys(mapping.ExampleTranslations)) {
+		all = append(all, mapping.ExampleTranslations[language])
+	}
+	all = append(all, mapping.WordAudio, mapping.WordExampleAudio)
+
+	var fields []string
+	for _, field := range all {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// runImport creates words from the notes one by one and saves the progress of the job
+//
+// Errors of intermediate progress updates are ignored, the next update saves the whole state of the job again.
+// Errors of the final update are logged, as the job would otherwise be left running until the service restarts.
+// A panic stops the import and marks the job as failed instead of stopping the whole service.
+func (s *wordImportService) runImport(ctx context.Context, job *models.WordImportJob, notes []anki.Note, pkg *anki.Package, mapping *models.WordImportMapping) {
+	defer func() {
+		if r := recover(); r != nil {
+			finishedAt := time.Now()
+			job.Status = models.WordImportStatusFailed
+			job.FinishedAt = &finishedAt
+			addImportError(job, models.WordImportError{Row: job.Processed + 1, Error: fmt.Sprintf("import stopped unexpectedly: %v", r)})
+			s.finishImport(ctx, job)
+		}
+	}()
+
+	for i, note := range notes {
+		s.importNote(ctx, job, i+1, note, pkg, mapping)
+		job.Processed++
+		if job.Processed%importProgressInterval == 0 && job.Processed < job.Total {
+			_ = s.jobRepo.UpdateProgress(ctx, job)
+		}
+	}
+
+	finishedAt := time.Now()
+	job.Status = models.WordImportStatusCompleted
+	job.FinishedAt = &finishedAt
+	s.finishImport(ctx, job)
+}
+
+// finishImport saves the final state of an import job and logs the error if it cannot be saved
+func (s *wordImportService) finishImport(ctx context.Context, job *models.WordImportJob) {
+	if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+		s.logger.Error("failed to save the final state of a word import job", zap.Int("jobID", job.ID), zap.Error(err))
+	}
+}
+
+// addImportError adds an error to the report of an import job, or only counts it once the report is full
+func addImportError(job *models.WordImportJob, importError models.WordImportError) {
+	if len(job.Errors) >= maxImportErrors {
+		job.TruncatedErrors++
+		return
+	}
+	job.Errors = append(job.Errors, importError)
+}
+
+// importNote creates a word from a single note and updates the counters and the error report of the job
+func (s *wordImportService) importNote(ctx context.Context, job *models.WordImportJob, row int, note anki.Note, pkg *anki.Package, mapping *models.WordImportMapping) {
+	value := func(field string) string {
+		if field == "" {
+			return ""
+		}
+		return anki.PlainText(note.Fields[field])
+	}
+	word := &models.Word{
+		Word:            value(mapping.Word),
+		PhoneticClues:   value(mapping.PhoneticClues),
+		Example:         value(mapping.Example),
+		Translations:    make(map[string]models.WordTranslation),
+		EasyPeriod:      importEasyPeriod,
+		NormalPeriod:    importNormalPeriod,
+		HardPeriod:      importHardPeriod,
+		ExtraHardPeriod: importExtraHardPeriod,
+	}
+	for language, field := range mapping.Translations {
+		translation := word.Translations[language]
+		translation.Translation = value(field)
+		word.Translations[language] = translation
+	}
+	for language, field := range mapping.ExampleTranslations {
+		translation := word.Translations[language]
+		translation.ExampleTranslation = value(field)
+		word.Translations[language] = translation
+	}
+	report := func(message string) {
+		addImportError(job, models.WordImportError{Row: row, Word: word.Word, Error: message})
+	}
+	fail := func(message string) {
+		job.Failed++
+		report(message)
+	}
+	skip := func(message string) {
+		job.Skipped++
+		report(message)
+	}
+
+	if word.Word == "" {
+		fail("word is empty")
+		return
+	}
+	if word.PhoneticClues == "" {
+		fail("phonetic clues are empty")
+		return
+	}
+
+	exists, err := s.wordRepo.ExistsByWord(ctx, word.Word)
+	if err != nil {
+		fail(fmt.Sprintf("failed to check word existence: %v", err))
+		return
+	}
+	if exists {
+		skip(fmt.Sprintf("word '%s' already exists", word.Word))
+		return
+	}
+	exists, err = s.wordRepo.ExistsByClues(ctx, word.PhoneticClues)
+	if err != nil {
+		fail(fmt.Sprintf("failed to check clues existence: %v", err))
+		return
+	}
+	if exists {
+		skip(fmt.Sprintf("phonetic clues '%s' already exists", word.PhoneticClues))
+		return
+	}
+
+	// Missing or failed audio is reported, but does not prevent the word from being created
+	if pkg != nil && mapping.WordAudio != "" {
+		audioURL, err := s.uploadSound(ctx, pkg, "word", note.Fields[mapping.WordAudio])
+		if err != nil {
+			report(fmt.Sprintf("failed to upload word audio: %v", err))
+		}
+		word.WordAudio = audioURL
+	}
+	if pkg != nil && mapping.WordExampleAudio != "" {
+		audioURL, err := s.uploadSound(ctx, pkg, "word_example", note.Fields[mapping.WordExampleAudio])
+		if err != nil {
+			report(fmt.Sprintf("failed to upload word example audio: %v", err))
+		}
+		word.WordExampleAudio = audioURL
+	}
+
+	if err := s.wordRepo.Create(ctx, word); err != nil {
+		fail(fmt.Sprintf("failed to create word: %v", err))
+		return
+	}
+	job.Created++
+
+	if err := s.wordKanjiRepo.LinkWord(ctx, word.ID); err != nil {
+		report(fmt.Sprintf("failed to link word to kanji: %v", err))
+	}
+}
+
+// uploadSound uploads the first sound referenced by a field value to the media-service
+//
+// An empty URL is returned if the field has no sound references.
+func (s *wordImportService) uploadSound(ctx context.Context, pkg *anki.Package, mediaType, fieldValue string) (string, error) {
+	sounds := anki.SoundFiles(fieldValue)
+	if len(sounds) == 0 {
+		return "", nil
+	}
+
+	file, err := pkg.OpenMedia(sounds[0])
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return uploadFileToMediaService(ctx, s.mediaBaseURL, s.apiKey, mediaType, file, sounds[0])
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// mockImportWordRepository is a mock implementation of AdminWordRepository keeping created words
+//
+// Only methods used by the import are implemented.
+type mockImportWordRepository struct {
+	AdminWordRepository
+	words     map[string]bool
+	clues     map[string]bool
+	created   []models.Word
+	existsErr error
+	createErr error
+	panicWord string // Creating this word panics
+}
+
+func (m *mockImportWordRepository) ExistsByWord(ctx context.Context, word string) (bool, error) {
+	if m.existsErr != nil {
+		return false, m.existsErr
+	}
+	return m.words[word], nil
+}
+
+func (m *mockImportWordRepository) ExistsByClues(ctx context.Context, clues string) (bool, error) {
+	if m.existsErr != nil {
+		return false, m.existsErr
+	}
+	return m.clues[clues], nil
+}
+
+func (m *mockImportWordRepository) Create(ctx context.Context, word *models.Word) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	if word.Word == m.panicWord {
+		panic("unexpected word")
+	}
+	if m.words == nil {
+		m.words = make(map[string]bool)
+		m.clues = make(map[string]bool)
+	}
+	m.words[word.Word] = true
+	m.clues[word.PhoneticClues] = true
+	m.created = append(m.created, *word)
+	word.ID = len(m.created)
+	return nil
+}
+
+// mockWordImportJobRepository is a mock implementation of WordImportJobRepository
+//
+// The "done" channel is closed when the job is saved as completed or failed.
+type mockWordImportJobRepository struct {
+	job         *models.WordImportJob
+	final       models.WordImportJob
+	createErr   error
+	done        chan struct{}
+	failedCount int
+	failErr     error
+	updateErr   error
+}
+
+func (m *mockWordImportJobRepository) Create(ctx context.Context, job *models.WordImportJob) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	job.ID = 1
+	m.job = job
+	return nil
+}
+
+func (m *mockWordImportJobRepository) UpdateProgress(ctx context.Context, job *models.WordImportJob) error {
+	if job.Status == models.WordImportStatusCompleted || job.Status == models.WordImportStatusFailed {
+		m.final = *job
+		close(m.done)
+	}
+	return m.updateErr
+}
+
+func (m *mockWordImportJobRepository) GetByID(ctx context.Context, id int) (*models.WordImportJob, error) {
+	if m.job == nil || m.job.ID != id {
+		return nil, errors.New("word import job not found")
+	}
+	return m.job, nil
+}
+
+func (m *mockWordImportJobRepository) FailRunning(ctx context.Context) (int, error) {
+	if m.failErr != nil {
+		return 0, m.failErr
+	}
+	return m.failedCount, nil
+}
+
+// waitForImport waits until the import job is completed and returns its final state
+func waitForImport(t *testing.T, jobRepo *mockWordImportJobRepository) models.WordImportJob {
+	t.Helper()
+	select {
+	case <-jobRepo.done:
+		return jobRepo.final
+	case <-time.After(5 * time.Second):
+		t.Fatal("word import job has not been completed")
+		return models.WordImportJob{}
+	}
+}
+
+func TestWordImportService_StartImport_Validation(t *testing.T) {
+	mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading"}
+
+	tests := []struct {
+		name          string
+		filename      string
+		data          string
+		mapping       *models.WordImportMapping
+		jobRepo       *mockWordImportJobRepository
+		errorContains string
+	}{
+		{
+			name:          "unsupported extension",
+			filename:      "words.xlsx",
+			data:          "Expression,Reading\n水,みず\n",
+			mapping:       mapping,
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "invalid file format",
+		},
+		{
+			name:          "missing mapping",
+			filename:      "words.csv",
+			data:          "Expression,Reading\n水,みず\n",
+			mapping:       nil,
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "word and phoneticClues fields are required",
+		},
+		{
+			name:          "missing phonetic clues mapping",
+			filename:      "words.csv",
+			data:          "Expression,Reading\n水,みず\n",
+			mapping:       &models.WordImportMapping{Word: "Expression"},
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "word and phoneticClues fields are required",
+		},
+		{
+			name:          "audio mapping for text file",
+			filename:      "words.csv",
+			data:          "Expression,Reading,Audio\n水,みず,[sound:mizu.mp3]\n",
+			mapping:       &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading", WordAudio: "Audio"},
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "audio fields are supported only for Anki packages",
+		},
+		{
+			name:          "broken package",
+			filename:      "deck.apkg",
+			data:          "not a zip archive",
+			mapping:       mapping,
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "invalid file",
+		},
+		{
+			name:          "no notes",
+			filename:      "words.csv",
+			data:          "Expression,Reading\n",
+			mapping:       mapping,
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "no notes found",
+		},
+		{
+			name:          "mapped field not in file",
+			filename:      "words.csv",
+			data:          "Expression,Reading\n水,みず\n",
+			mapping:       &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading", Translations: map[string]string{"en": "Meaning"}},
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: `field "Meaning" not found`,
+		},
+		{
+			name:          "invalid translation language",
+			filename:      "words.csv",
+			data:          "Expression,Reading,Meaning\n水,みず,water\n",
+			mapping:       &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading", Translations: map[string]string{"english": "Meaning"}},
+			jobRepo:       &mockWordImportJobRepository{},
+			errorContains: "invalid language: english",
+		},
+		{
+			name:          "database error on job creation",
+			filename:      "words.csv",
+			data:          "Expression,Reading\n水,みず\n",
+			mapping:       mapping,
+			jobRepo:       &mockWordImportJobRepository{createErr: errors.New("database error")},
+			errorContains: "failed to create word import job",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, tt.jobRepo, "", "", zap.NewNop())
+
+			job, err := svc.StartImport(context.Background(), tt.filename, []byte(tt.data), tt.mapping)
+
+			assert.Nil(t, job)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestWordImportService_StartImport(t *testing.T) {
+	t.Run("csv import with duplicates and invalid rows", func(t *testing.T) {
+		wordRepo := &mockImportWordRepository{
+			words: map[string]bool{"火": true},
+			clues: map[string]bool{"ひ": true},
+		}
+		kanjiRepo := &mockWordKanjiRepository{}
+		jobRepo := &mockWordImportJobRepository{done: make(chan struct{})}
+		svc := NewWordImportService(wordRepo, kanjiRepo, jobRepo, "", "", zap.NewNop())
+
+		data := "Expression,Reading,Meaning\n" +
+			"水,みず,<b>water</b>\n" + // created, HTML is removed
+			"火,ひ,fire\n" + // existing word
+			"水,すい,water\n" + // duplicate inside the file
+			"日,ひ,sun\n" + // existing phonetic clues
+			",き,tree\n" + // empty word
+			"山,,mountain\n" // empty phonetic clues
+		mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading", Translations: map[string]string{"EN": "Meaning"}}
+
+		job, err := svc.StartImport(context.Background(), "words.csv", []byte(data), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, 1, job.ID)
+		assert.Equal(t, models.WordImportFormatCSV, job.Format)
+		assert.Equal(t, 6, job.Total)
+
+		final := waitForImport(t, jobRepo)
+		assert.Equal(t, models.WordImportStatusCompleted, final.Status)
+		assert.NotNil(t, final.FinishedAt)
+		assert.Equal(t, 6, final.Processed)
+		assert.Equal(t, 1, final.Created)
+		assert.Equal(t, 3, final.Skipped)
+		assert.Equal(t, 2, final.Failed)
+
+		require.Len(t, wordRepo.created, 1)
+		created := wordRepo.created[0]
+		assert.Equal(t, "水", created.Word)
+		assert.Equal(t, "みず", created.PhoneticClues)
+		assert.Equal(t, map[string]models.WordTranslation{"en": {Translation: "water"}}, created.Translations)
+		assert.Equal(t, importEasyPeriod, created.EasyPeriod)
+		assert.Equal(t, importExtraHardPeriod, created.ExtraHardPeriod)
+		assert.Equal(t, []int{1}, kanjiRepo.linkedWordIDs)
+
+		require.Len(t, final.Errors, 5)
+		assert.Equal(t, models.WordImportError{Row: 2, Word: "火", Error: "word '火' already exists"}, final.Errors[0])
+		assert.Equal(t, models.WordImportError{Row: 3, Word: "水", Error: "word '水' already exists"}, final.Errors[1])
+		assert.Equal(t, models.WordImportError{Row: 4, Word: "日", Error: "phonetic clues 'ひ' already exists"}, final.Errors[2])
+		assert.Equal(t, models.WordImportError{Row: 5, Error: "word is empty"}, final.Errors[3])
+		assert.Equal(t, models.WordImportError{Row: 6, Word: "山", Error: "phonetic clues are empty"}, final.Errors[4])
+	})
+
+	t.Run("tsv import with repository errors", func(t *testing.T) {
+		wordRepo := &mockImportWordRepository{createErr: errors.New("database error")}
+		jobRepo := &mockWordImportJobRepository{done: make(chan struct{})}
+		svc := NewWordImportService(wordRepo, &mockWordKanjiRepository{}, jobRepo, "", "", zap.NewNop())
+
+		data := "#separator:tab\n#columns:Expression\tReading\n水\tみず\n"
+		mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading"}
+
+		job, err := svc.StartImport(context.Background(), "export.txt", []byte(data), mapping)
+		require.NoError(t, err)
+		assert.Equal(t, models.WordImportFormatTSV, job.Format)
+
+		final := waitForImport(t, jobRepo)
+		assert.Equal(t, 1, final.Failed)
+		assert.Equal(t, 0, final.Created)
+		require.Len(t, final.Errors, 1)
+		assert.Contains(t, final.Errors[0].Error, "failed to create word")
+	})
+}
+
+func TestWordImportService_StartImport_ErrorReportLimit(t *testing.T) {
+	jobRepo := &mockWordImportJobRepository{done: make(chan struct{})}
+	svc := NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, jobRepo, "", "", zap.NewNop())
+
+	data := "Expression,Reading\n" + strings.Repeat(",き\n", maxImportErrors+5)
+	mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading"}
+
+	_, err := svc.StartImport(context.Background(), "words.csv", []byte(data), mapping)
+	require.NoError(t, err)
+
+	final := waitForImport(t, jobRepo)
+	assert.Equal(t, maxImportErrors+5, final.Failed)
+	assert.Len(t, final.Errors, maxImportErrors)
+	assert.Equal(t, 5, final.TruncatedErrors)
+	assert.Equal(t, maxImportErrors, final.Errors[maxImportErrors-1].Row)
+}
+
+func TestWordImportService_StartImport_FinalUpdateError(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	jobRepo := &mockWordImportJobRepository{done: make(chan struct{}), updateErr: errors.New("database error")}
+	svc := NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, jobRepo, "", "", zap.New(core))
+
+	data := "Expression,Reading\n水,みず\n"
+	mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading"}
+
+	_, err := svc.StartImport(context.Background(), "words.csv", []byte(data), mapping)
+	require.NoError(t, err)
+
+	waitForImport(t, jobRepo)
+	assert.Eventually(t, func() bool {
+		return logs.FilterMessage("failed to save the final state of a word import job").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWordImportService_StartImport_Panic(t *testing.T) {
+	wordRepo := &mockImportWordRepository{panicWord: "火"}
+	jobRepo := &mockWordImportJobRepository{done: make(chan struct{})}
+	svc := NewWordImportService(wordRepo, &mockWordKanjiRepository{}, jobRepo, "", "", zap.NewNop())
+
+	data := "Expression,Reading\n水,みず\n火,ひ\n山,やま\n"
+	mapping := &models.WordImportMapping{Word: "Expression", PhoneticClues: "Reading"}
+
+	_, err := svc.StartImport(context.Background(), "words.csv", []byte(data), mapping)
+	require.NoError(t, err)
+
+	final := waitForImport(t, jobRepo)
+	assert.Equal(t, models.WordImportStatusFailed, final.Status)
+	assert.NotNil(t, final.FinishedAt)
+	assert.Equal(t, 1, final.Processed)
+	assert.Equal(t, 1, final.Created)
+	require.Len(t, final.Errors, 1)
+	assert.Equal(t, 2, final.Errors[0].Row)
+	assert.Contains(t, final.Errors[0].Error, "import stopped unexpectedly")
+}
+
+func TestWordImportService_FailInterruptedImports(t *testing.T) {
+	svc := NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, &mockWordImportJobRepository{failedCount: 2}, "", "", zap.NewNop())
+
+	count, err := svc.FailInterruptedImports(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	svc = NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, &mockWordImportJobRepository{failErr: errors.New("database error")}, "", "", zap.NewNop())
+
+	count, err = svc.FailInterruptedImports(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestWordImportService_GetImportJob(t *testing.T) {
+	jobRepo := &mockWordImportJobRepository{job: &models.WordImportJob{ID: 3, Status: models.WordImportStatusRunning}}
+	svc := NewWordImportService(&mockImportWordRepository{}, &mockWordKanjiRepository{}, jobRepo, "", "", zap.NewNop())
+
+	job, err := svc.GetImportJob(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, models.WordImportStatusRunning, job.Status)
+
+	job, err = svc.GetImportJob(context.Background(), 0)
+	assert.Nil(t, job)
+	assert.EqualError(t, err, "invalid word import job id")
+
+	job, err = svc.GetImportJob(context.Background(), 4)
+	assert.Nil(t, job)
+	assert.Error(t, err)
+}
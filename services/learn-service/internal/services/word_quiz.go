@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxQuizWordCount limits the number of questions of a vocabulary quiz
+	maxQuizWordCount = 40
+	// quizWrongOptions is the number of wrong answers offered for every quiz question
+	quizWrongOptions = 3
+	// maxQuizAnswerLength limits the length of a quiz answer, it matches the size of a stored translation
+	maxQuizAnswerLength = 255
+)
+
+// GetWordQuiz builds a vocabulary quiz of dictionary words for the user
+//
+// For successful results:
+//
+// - mode must be "reverse", "choice" or "listening", please reference WordQuizMode constants
+//
+// - count must be between 1 and 40
+//
+// - locale must be a valid language code, translations missing in it are taken from its fallbacks
+//
+// Words due for review come first, never reviewed words fill the rest, so quiz results follow the review schedule.
+// Words without a translation in the locale chain, or without word audio in listening quizzes, are skipped.
+// Wrong answers are taken from words of the same part of speech or topic first.
+// Quiz items are stored in a new quiz session, the client receives only shuffled options.
+func (s *dictionaryService) GetWordQuiz(ctx context.Context, userId int, mode string, count int, locale string) (*models.WordQuiz, error) {
+	quizMode := models.WordQuizMode(strings.ToLower(strings.TrimSpace(mode)))
+	if !quizMode.IsValid() {
+		return nil, fmt.Errorf("invalid quiz mode: %s, must be 'reverse', 'choice' or 'listening'", mode)
+	}
+	if count < 1 || count > maxQuizWordCount {
+		return nil, fmt.Errorf("count must be between 1 and %d", maxQuizWordCount)
+	}
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+	languages := s.locale.Chain(locale)
+
+	words, err := s.getQuizWords(ctx, userId, quizMode, count, languages)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no words available for the quiz")
+	}
+
+	wordIds := make([]int, len(words))
+	for i, word := range words {
+		wordIds[i] = word.ID
+	}
+	// Twice as many candidates as needed, some of them may repeat the correct answer or each other
+	distractors, err := s.wordRepo.GetQuizDistractors(ctx, wordIds, 2*quizWrongOptions, languages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quiz distractors: %w", err)
+	}
+
+	items := make([]models.WordQuizItem, len(words))
+	sessionItems := make([]models.WordQuizSessionItem, len(words))
+	for i, word := range words {
+		items[i] = newWordQuizItem(quizMode, word, distractors[word.ID])
+		items[i].Options = shuffleOptions(items[i].CorrectAnswer, items[i].WrongOptions)
+		sessionItems[i] = models.WordQuizSessionItem{WordID: word.ID, CorrectAnswer: items[i].CorrectAnswer}
+	}
+
+	session, err := s.createQuizSession(ctx, userId, quizMode, sessionItems)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.WordQuiz{
+		SessionID: session.ID,
+		Mode:      quizMode,
+		ExpiresAt: session.ExpiresAt,
+		Items:     items,
+	}, nil
+}
+
+// getQuizWords retrieves shuffled words for a quiz, due words first
+//
+// Words which cannot be asked in the quiz mode are left out.
+func (s *dictionaryService) getQuizWords(ctx context.Context, userId int, mode models.WordQuizMode, count int, languages []string) ([]models.WordResponse, error) {
+	oldWordIds, err := s.dictionaryHistoryRepo.GetOldWordIds(ctx, userId, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get old word IDs: %w", err)
+	}
+	oldWords, err := s.wordRepo.GetByIDs(ctx, oldWordIds, languages)
+	if err != nil {
+		return nil, err
+	}
+
+	var words []models.WordResponse
+	for _, word := range oldWords {
+		if quizWordAllowed(mode, word) {
+			words = append(words, word)
+		}
+	}
+
+	if len(words) < count {
+		filter := models.WordFilter{WithWordAudio: mode == models.WordQuizModeListening}
+		newWords, err := s.wordRepo.GetExcludingIDs(ctx, userId, oldWordIds, count-len(words), filter, languages)
+		if err != nil {
+			return nil, err
+		}
+		for _, word := range newWords {
+			if quizWordAllowed(mode, word) {
+				words = append(words, word)
+			}
+		}
+	}
+
+	rand.Shuffle(len(words), func(i, j int) {
+		words[i], words[j] = words[j], words[i]
+	})
+	return words, nil
+}
+
+// quizWordAllowed checks if a question about the word can be asked in the quiz mode
+func quizWordAllowed(mode models.WordQuizMode, word models.WordResponse) bool {
+	if word.Translation == "" {
+		return false
+	}
+	return mode != models.WordQuizModeListening || word.WordAudio != ""
+}
+
+// newWordQuizItem builds a quiz question about the word
+//
+// Wrong options are distinct, non-empty and differ from the correct answer, so fewer of them may remain
+// in a small dictionary.
+func newWordQuizItem(mode models.WordQuizMode, word models.WordResponse, distractors []models.WordQuizDistractor) models.WordQuizItem {
+	item := models.WordQuizItem{WordID: word.ID}
+	answer := func(d models.WordQuizDistractor) string { return d.Word }
+	switch mode {
+	case models.WordQuizModeChoice:
+		item.Prompt = word.Word
+		item.CorrectAnswer = word.Translation
+		answer = func(d models.WordQuizDistractor) string { return d.Translation }
+	case models.WordQuizModeReverse:
+		item.Prompt = word.Translation
+		item.CorrectAnswer = word.Word
+	case models.WordQuizModeListening:
+		item.AudioURL = word.WordAudio
+		item.CorrectAnswer = word.Word
+	}
+
+	seen := map[string]bool{item.CorrectAnswer: true}
+	for _, distractor := range distractors {
+		option := answer(distractor)
+		if option == "" || seen[option] {
+			continue
+		}
+		seen[option] = true
+		item.WrongOptions = append(item.WrongOptions, option)
+		if len(item.WrongOptions) == quizWrongOptions {
+			break
+		}
+	}
+	return item
+}
+
+// createQuizSession stores quiz items in a new quiz session
+//
+// Expired quiz sessions of the user are deleted first.
+func (s *dictionaryService) createQuizSession(ctx context.Context, userId int, mode models.WordQuizMode, items []models.WordQuizSessionItem) (*models.WordQuizSession, error) {
+	now := time.Now().UTC()
+	if err := s.quizSessionRepo.DeleteExpiredByUserID(ctx, userId, now); err != nil {
+		return nil, err
+	}
+
+	session := &models.WordQuizSession{
+		ID:        uuid.NewString(),
+		UserID:    userId,
+		Mode:      mode,
+		Items:     items,
+		ExpiresAt: now.Add(testSessionTTL).Truncate(time.Second),
+	}
+	if err := s.quizSessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// SubmitWordQuiz grades answers of a quiz session and schedules the next appearance of its words
+//
+// For successful results:
+//
+// - the session must belong to the user, must not be submitted and must not be expired
+//
+// - every answer must belong to a question of the session, a question can be answered only once
+//
+// Questions without an answer are failed. A correct answer is graded as "good" and a wrong one as "again",
+// then the words are scheduled in the same way as self-graded reviews, please reference SubmitWordResults method.
+func (s *dictionaryService) SubmitWordQuiz(ctx context.Context, userId int, sessionId string, answers []models.WordQuizAnswer) ([]models.GradedWordAnswer, error) {
+	if sessionId == "" {
+		return nil, fmt.Errorf("session id is required")
+	}
+
+	session, err := s.quizSessionRepo.GetByID(ctx, sessionId)
+	if err != nil {
+		return nil, err
+	}
+	// Sessions of other users are reported as missing, so their existence is not revealed
+	if session.UserID != userId {
+		return nil, fmt.Errorf("word quiz session not found")
+	}
+	if session.SubmittedAt != nil {
+		return nil, fmt.Errorf("word quiz session has already been submitted")
+	}
+	now := time.Now().UTC()
+	if !now.Before(session.ExpiresAt) {
+		return nil, fmt.Errorf("word quiz session has expired")
+	}
+
+	// Validate answers against session items
+	correctAnswers := make(map[int]string, len(session.Items))
+	for _, item := range session.Items {
+		correctAnswers[item.WordID] = item.CorrectAnswer
+	}
+	answerMap := make(map[int]string, len(answers))
+	for _, answer := range answers {
+		if _, ok := correctAnswers[answer.WordID]; !ok {
+			return nil, fmt.Errorf("answer does not belong to the word quiz session")
+		}
+		if _, ok := answerMap[answer.WordID]; ok {
+			return nil, fmt.Errorf("duplicate answer for the same word")
+		}
+		if utf8.RuneCountInString(answer.Answer) > maxQuizAnswerLength {
+			return nil, fmt.Errorf("answer is too long")
+		}
+		answerMap[answer.WordID] = answer.Answer
+	}
+
+	// Mark the session as submitted before saving results, so concurrent replays are rejected
+	marked, err := s.quizSessionRepo.MarkSubmitted(ctx, session.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, fmt.Errorf("word quiz session has already been submitted")
+	}
+
+	// Grade answers
+	results := make([]models.WordResult, len(session.Items))
+	graded := make([]models.GradedWordAnswer, len(session.Items))
+	for i, item := range session.Items {
+		passed := strings.TrimSpace(answerMap[item.WordID]) == item.CorrectAnswer
+		grade := models.ReviewGradeAgain
+		if passed {
+			grade = models.ReviewGradeGood
+		}
+		results[i] = models.WordResult{WordID: item.WordID, Grade: grade}
+		graded[i] = models.GradedWordAnswer{
+			WordID:        item.WordID,
+			Passed:        passed,
+			CorrectAnswer: item.CorrectAnswer,
+		}
+	}
+
+	if err := s.SubmitWordResults(ctx, userId, results); err != nil {
+		// Release the session, so the user can retry the submission instead of losing the results
+		if clearErr := s.quizSessionRepo.ClearSubmitted(ctx, session.ID); clearErr != nil {
+			return nil, fmt.Errorf("%w; %w", err, clearErr)
+		}
+		return nil, err
+	}
+
+	return graded, nil
+}
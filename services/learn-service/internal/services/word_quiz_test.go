@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/services/learn-service/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWordQuizSessionRepository is a mock implementation of WordQuizSessionRepository
+type mockWordQuizSessionRepository struct {
+	session      *models.WordQuizSession
+	created      *models.WordQuizSession
+	alreadyTaken bool
+	cleared      bool
+	clearErr     error
+	err          error
+}
+
+func (m *mockWordQuizSessionRepository) Create(ctx context.Context, session *models.WordQuizSession) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.created = session
+	return nil
+}
+
+func (m *mockWordQuizSessionRepository) GetByID(ctx context.Context, id string) (*models.WordQuizSession, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.session == nil || m.session.ID != id {
+		return nil, errors.New("word quiz session not found")
+	}
+	return m.session, nil
+}
+
+func (m *mockWordQuizSessionRepository) MarkSubmitted(ctx context.Context, id string, submittedAt time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	return !m.alreadyTaken, nil
+}
+
+func (m *mockWordQuizSessionRepository) ClearSubmitted(ctx context.Context, id string) error {
+	m.cleared = true
+	return m.clearErr
+}
+
+func (m *mockWordQuizSessionRepository) DeleteExpiredByUserID(ctx context.Context, userID int, before time.Time) error {
+	return m.err
+}
+
+func TestDictionaryService_GetWordQuiz(t *testing.T) {
+	water := models.WordResponse{ID: 1, Word: "水", Translation: "water", WordAudio: "http://media/1"}
+	fire := models.WordResponse{ID: 2, Word: "火", Translation: "fire"}
+	untranslated := models.WordResponse{ID: 3, Word: "木", WordAudio: "http://media/3"}
+	distractors := map[int][]models.WordQuizDistractor{
+		1: {
+			{WordID: 4, Word: "お茶", Translation: "tea"},
+			{WordID: 5, Word: "湯", Translation: "water"},
+			{WordID: 6, Word: "酒", Translation: "tea"},
+			{WordID: 7, Word: "", Translation: ""},
+			{WordID: 8, Word: "牛乳", Translation: "milk"},
+			{WordID: 9, Word: "氷", Translation: "ice"},
+			{WordID: 10, Word: "雨", Translation: "rain"},
+		},
+		2: {{WordID: 11, Word: "火", Translation: "flame"}},
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		count         int
+		locale        string
+		wordRepo      *mockWordRepository
+		historyRepo   *mockDictionaryHistoryRepository
+		sessionRepo   *mockWordQuizSessionRepository
+		expectedError string
+		validate      func(*testing.T, *models.WordQuiz, *mockWordRepository, *mockWordQuizSessionRepository)
+	}{
+		{
+			name:        "choice quiz with deduplicated distractors",
+			mode:        "choice",
+			count:       5,
+			locale:      "en",
+			wordRepo:    &mockWordRepository{words: []models.WordResponse{water, untranslated}, newWords: []models.WordResponse{fire}, distractors: distractors},
+			historyRepo: &mockDictionaryHistoryRepository{oldWordIds: []int{1, 3}},
+			sessionRepo: &mockWordQuizSessionRepository{},
+			validate: func(t *testing.T, quiz *models.WordQuiz, wordRepo *mockWordRepository, sessionRepo *mockWordQuizSessionRepository) {
+				require.Len(t, quiz.Items, 2)
+				assert.Equal(t, models.WordQuizModeChoice, quiz.Mode)
+				assert.False(t, wordRepo.filter.WithWordAudio)
+				items := map[int]models.WordQuizItem{}
+				for _, item := range quiz.Items {
+					items[item.WordID] = item
+				}
+				assert.Equal(t, "水", items[1].Prompt)
+				assert.Equal(t, []string{"tea", "milk", "ice"}, items[1].WrongOptions)
+				assert.ElementsMatch(t, []string{"water", "tea", "milk", "ice"}, items[1].Options)
+				assert.Equal(t, []string{"flame"}, items[2].WrongOptions)
+				assert.ElementsMatch(t, []string{"fire", "flame"}, items[2].Options)
+
+				require.NotNil(t, sessionRepo.created)
+				assert.Equal(t, quiz.SessionID, sessionRepo.created.ID)
+				assert.Equal(t, 1, sessionRepo.created.UserID)
+				assert.ElementsMatch(t, []models.WordQuizSessionItem{
+					{WordID: 1, CorrectAnswer: "water"},
+					{WordID: 2, CorrectAnswer: "fire"},
+				}, sessionRepo.created.Items)
+				assert.True(t, quiz.ExpiresAt.After(time.Now()))
+			},
+		},
+		{
+			name:        "reverse quiz asks for the word",
+			mode:        " Reverse ",
+			count:       1,
+			locale:      "en",
+			wordRepo:    &mockWordRepository{words: []models.WordResponse{water}, distractors: distractors},
+			historyRepo: &mockDictionaryHistoryRepository{oldWordIds: []int{1}},
+			sessionRepo: &mockWordQuizSessionRepository{},
+			validate: func(t *testing.T, quiz *models.WordQuiz, wordRepo *mockWordRepository, sessionRepo *mockWordQuizSessionRepository) {
+				require.Len(t, quiz.Items, 1)
+				assert.Equal(t, models.WordQuizModeReverse, quiz.Mode)
+				assert.Equal(t, "water", quiz.Items[0].Prompt)
+				assert.Equal(t, []string{"お茶", "湯", "酒"}, quiz.Items[0].WrongOptions)
+				assert.Contains(t, quiz.Items[0].Options, "水")
+				assert.Equal(t, "水", sessionRepo.created.Items[0].CorrectAnswer)
+			},
+		},
+		{
+			name:        "listening quiz uses only words with audio",
+			mode:        "listening",
+			count:       3,
+			locale:      "pt-br",
+			wordRepo:    &mockWordRepository{words: []models.WordResponse{fire}, newWords: []models.WordResponse{water}, distractors: distractors},
+			historyRepo: &mockDictionaryHistoryRepository{oldWordIds: []int{2}},
+			sessionRepo: &mockWordQuizSessionRepository{},
+			validate: func(t *testing.T, quiz *models.WordQuiz, wordRepo *mockWordRepository, sessionRepo *mockWordQuizSessionRepository) {
+				require.Len(t, quiz.Items, 1)
+				assert.Equal(t, 1, quiz.Items[0].WordID)
+				assert.Empty(t, quiz.Items[0].Prompt)
+				assert.Equal(t, "http://media/1", quiz.Items[0].AudioURL)
+				assert.True(t, wordRepo.filter.WithWordAudio)
+				assert.Equal(t, []string{"pt-br", "pt", "en"}, wordRepo.languages)
+			},
+		},
+		{
+			name:          "invalid mode",
+			mode:          "writing",
+			count:         10,
+			locale:        "en",
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			expectedError: "invalid quiz mode: writing, must be 'reverse', 'choice' or 'listening'",
+		},
+		{
+			name:          "count too large",
+			mode:          "choice",
+			count:         41,
+			locale:        "en",
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			expectedError: "count must be between 1 and 40",
+		},
+		{
+			name:          "invalid locale",
+			mode:          "choice",
+			count:         10,
+			locale:        "english",
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			expectedError: "invalid locale",
+		},
+		{
+			name:          "no words",
+			mode:          "choice",
+			count:         10,
+			locale:        "en",
+			wordRepo:      &mockWordRepository{words: []models.WordResponse{untranslated}},
+			historyRepo:   &mockDictionaryHistoryRepository{},
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			expectedError: "no words available for the quiz",
+		},
+		{
+			name:          "history repository error",
+			mode:          "choice",
+			count:         10,
+			locale:        "en",
+			wordRepo:      &mockWordRepository{},
+			historyRepo:   &mockDictionaryHistoryRepository{err: errors.New("database error")},
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			expectedError: "failed to get old word IDs",
+		},
+		{
+			name:          "session repository error",
+			mode:          "choice",
+			count:         1,
+			locale:        "en",
+			wordRepo:      &mockWordRepository{words: []models.WordResponse{water}},
+			historyRepo:   &mockDictionaryHistoryRepository{oldWordIds: []int{1}},
+			sessionRepo:   &mockWordQuizSessionRepository{err: errors.New("database error")},
+			expectedError: "database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewDictionaryService(tt.wordRepo, &mockWordExampleRepository{}, &mockUserWordRepository{}, tt.historyRepo, tt.sessionRepo, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			quiz, err := svc.GetWordQuiz(context.Background(), 1, tt.mode, tt.count, tt.locale)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, quiz)
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, quiz, tt.wordRepo, tt.sessionRepo)
+		})
+	}
+}
+
+func TestDictionaryService_SubmitWordQuiz(t *testing.T) {
+	newSession := func(expiresIn time.Duration) *models.WordQuizSession {
+		return &models.WordQuizSession{
+			ID:     "quiz-1",
+			UserID: 1,
+			Mode:   models.WordQuizModeChoice,
+			Items: []models.WordQuizSessionItem{
+				{WordID: 1, CorrectAnswer: "water"},
+				{WordID: 2, CorrectAnswer: "fire"},
+			},
+			ExpiresAt: time.Now().Add(expiresIn),
+		}
+	}
+	submittedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		userID        int
+		sessionID     string
+		sessionRepo   *mockWordQuizSessionRepository
+		answers       []models.WordQuizAnswer
+		upsertErr     error
+		expectedError string
+		validate      func(*testing.T, []models.GradedWordAnswer, *mockDictionaryHistoryRepository)
+	}{
+		{
+			name:        "success with unanswered question",
+			userID:      1,
+			sessionID:   "quiz-1",
+			sessionRepo: &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:     []models.WordQuizAnswer{{WordID: 1, Answer: " water "}},
+			validate: func(t *testing.T, results []models.GradedWordAnswer, historyRepo *mockDictionaryHistoryRepository) {
+				assert.Equal(t, []models.GradedWordAnswer{
+					{WordID: 1, Passed: true, CorrectAnswer: "water"},
+					{WordID: 2, Passed: false, CorrectAnswer: "fire"},
+				}, results)
+				require.Len(t, historyRepo.upserted, 2)
+				assert.Equal(t, 1, historyRepo.upserted[0].WordID)
+				assert.Equal(t, 2, historyRepo.upserted[1].WordID)
+			},
+		},
+		{
+			name:          "session of another user",
+			userID:        2,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "word quiz session not found",
+		},
+		{
+			name:          "unknown session",
+			userID:        1,
+			sessionID:     "quiz-2",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "word quiz session not found",
+		},
+		{
+			name:          "expired session",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(-time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "word quiz session has expired",
+		},
+		{
+			name:      "already submitted session",
+			userID:    1,
+			sessionID: "quiz-1",
+			sessionRepo: &mockWordQuizSessionRepository{session: func() *models.WordQuizSession {
+				session := newSession(time.Minute)
+				session.SubmittedAt = &submittedAt
+				return session
+			}()},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "word quiz session has already been submitted",
+		},
+		{
+			name:          "concurrent replay",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute), alreadyTaken: true},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "word quiz session has already been submitted",
+		},
+		{
+			name:          "answer for foreign word",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 3, Answer: "tree"}},
+			expectedError: "answer does not belong to the word quiz session",
+		},
+		{
+			name:        "duplicate answer",
+			userID:      1,
+			sessionID:   "quiz-1",
+			sessionRepo: &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers: []models.WordQuizAnswer{
+				{WordID: 1, Answer: "water"},
+				{WordID: 1, Answer: "fire"},
+			},
+			expectedError: "duplicate answer for the same word",
+		},
+		{
+			name:          "results are not saved",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			upsertErr:     errors.New("database error"),
+			expectedError: "database error",
+		},
+		{
+			name:          "results are not saved and session is not released",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute), clearErr: errors.New("clear error")},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			upsertErr:     errors.New("database error"),
+			expectedError: "database error; clear error",
+		},
+		{
+			name:          "answer too long",
+			userID:        1,
+			sessionID:     "quiz-1",
+			sessionRepo:   &mockWordQuizSessionRepository{session: newSession(time.Minute)},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: strings.Repeat("a", 256)}},
+			expectedError: "answer is too long",
+		},
+		{
+			name:          "empty session id",
+			userID:        1,
+			sessionRepo:   &mockWordQuizSessionRepository{},
+			answers:       []models.WordQuizAnswer{{WordID: 1, Answer: "water"}},
+			expectedError: "session id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyRepo := &mockDictionaryHistoryRepository{upsertErr: tt.upsertErr}
+			svc := NewDictionaryService(&mockWordRepository{valid: true}, &mockWordExampleRepository{}, &mockUserWordRepository{}, historyRepo, tt.sessionRepo, &mockKanjiLevelRepository{}, DefaultLeechConfig(), DefaultLocaleConfig())
+
+			results, err := svc.SubmitWordQuiz(context.Background(), tt.userID, tt.sessionID, tt.answers)
+
+			// The session is released only if its results could not be saved
+			assert.Equal(t, tt.upsertErr != nil, tt.sessionRepo.cleared)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				assert.Nil(t, results)
+				if tt.upsertErr == nil {
+					assert.Nil(t, historyRepo.upserted)
+				}
+				return
+			}
+			require.NoError(t, err)
+			tt.validate(t, results, historyRepo)
+		})
+	}
+}
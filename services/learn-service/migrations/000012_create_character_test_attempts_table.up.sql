@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS character_test_attempts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    character_id INT NOT NULL,
+    alphabet_type ENUM('hiragana', 'katakana') NOT NULL,
+    test_type ENUM('reading', 'writing', 'listening') NOT NULL,
+    passed BOOLEAN NOT NULL,
+    chosen_option VARCHAR(20) NULL,
+    response_time_ms INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+    INDEX idx_user_created_at (user_id, created_at),
+    INDEX idx_user_character (user_id, character_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
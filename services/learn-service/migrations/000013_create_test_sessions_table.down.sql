@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS test_sessions;
+
@@ -0,0 +1,10 @@
+DELETE FROM characters WHERE character_group != 'basic';
+
+ALTER TABLE characters
+    DROP INDEX idx_character_group,
+    DROP COLUMN character_group,
+    MODIFY consonant VARCHAR(1) NOT NULL,
+    MODIFY english_reading VARCHAR(3) NOT NULL,
+    MODIFY russian_reading VARCHAR(3) NOT NULL,
+    MODIFY katakana VARCHAR(1) NOT NULL,
+    MODIFY hiragana VARCHAR(1) NOT NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE characters
+    ADD COLUMN character_group ENUM('basic', 'dakuten', 'handakuten', 'yoon') NOT NULL DEFAULT 'basic' AFTER vowel,
+    MODIFY consonant VARCHAR(3) NOT NULL,
+    MODIFY english_reading VARCHAR(5) NOT NULL,
+    MODIFY russian_reading VARCHAR(5) NOT NULL,
+    MODIFY katakana VARCHAR(3) NOT NULL,
+    MODIFY hiragana VARCHAR(3) NOT NULL,
+    ADD INDEX idx_character_group (character_group);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS character_strokes (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    character_id INT NOT NULL,
+    alphabet_type ENUM('hiragana', 'katakana') NOT NULL,
+    stroke_number INT NOT NULL,
+    path TEXT NOT NULL,
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_character_alphabet_stroke (character_id, alphabet_type, stroke_number)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
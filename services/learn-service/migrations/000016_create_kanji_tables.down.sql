@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS kanji_words;
+DROP TABLE IF EXISTS kanji;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS kanji (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    kanji_character VARCHAR(1) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+    onyomi VARCHAR(100) NOT NULL,
+    kunyomi VARCHAR(100) NOT NULL,
+    english_meaning VARCHAR(100) NOT NULL,
+    russian_meaning VARCHAR(100) NOT NULL,
+    german_meaning VARCHAR(100) NOT NULL,
+    stroke_count INT NOT NULL,
+    jlpt_level TINYINT NOT NULL,
+    radicals VARCHAR(50) NOT NULL,
+    audio VARCHAR(500) NULL,
+    UNIQUE KEY uk_kanji_character (kanji_character),
+    INDEX idx_jlpt_level (jlpt_level)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS kanji_words (
+    kanji_id INT NOT NULL,
+    word_id INT NOT NULL,
+    PRIMARY KEY (kanji_id, word_id),
+    FOREIGN KEY (kanji_id) REFERENCES kanji(id) ON DELETE CASCADE,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    INDEX idx_word_id (word_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+DELETE FROM test_sessions WHERE test_type = 'matching';
+
+ALTER TABLE test_sessions
+    MODIFY test_type ENUM('reading', 'writing', 'listening') NOT NULL;
+
+DELETE FROM character_test_attempts WHERE test_type = 'matching';
+
+ALTER TABLE character_test_attempts
+    MODIFY test_type ENUM('reading', 'writing', 'listening') NOT NULL;
+
+ALTER TABLE character_learn_history
+    DROP COLUMN katakana_matching_result,
+    DROP COLUMN hiragana_matching_result;
@@ -0,0 +1,9 @@
+ALTER TABLE character_learn_history
+    ADD COLUMN hiragana_matching_result FLOAT DEFAULT 0 AFTER katakana_listening_result,
+    ADD COLUMN katakana_matching_result FLOAT DEFAULT 0 AFTER hiragana_matching_result;
+
+ALTER TABLE character_test_attempts
+    MODIFY test_type ENUM('reading', 'writing', 'listening', 'matching') NOT NULL;
+
+ALTER TABLE test_sessions
+    MODIFY test_type ENUM('reading', 'writing', 'listening', 'matching') NOT NULL;
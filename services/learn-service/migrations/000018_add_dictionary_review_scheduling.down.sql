@@ -0,0 +1,6 @@
+ALTER TABLE dictionary_history
+    DROP COLUMN last_reviewed_at,
+    DROP COLUMN lapses,
+    DROP COLUMN repetitions,
+    DROP COLUMN interval_days,
+    DROP COLUMN ease_factor;
@@ -0,0 +1,11 @@
+ALTER TABLE dictionary_history
+    ADD COLUMN ease_factor FLOAT NOT NULL DEFAULT 2.5 AFTER user_id,
+    ADD COLUMN interval_days INT NOT NULL DEFAULT 0 AFTER ease_factor,
+    ADD COLUMN repetitions INT NOT NULL DEFAULT 0 AFTER interval_days,
+    ADD COLUMN lapses INT NOT NULL DEFAULT 0 AFTER repetitions,
+    ADD COLUMN last_reviewed_at DATETIME NULL AFTER next_appearance;
+
+-- Reviewed words keep their progress, the days left until the next review become the interval
+UPDATE dictionary_history
+SET interval_days = GREATEST(DATEDIFF(next_appearance, CURDATE()), 1),
+    repetitions = IF(DATEDIFF(next_appearance, CURDATE()) >= 6, 2, 1);
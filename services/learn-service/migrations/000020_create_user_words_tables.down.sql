@@ -0,0 +1,8 @@
+DELETE FROM dictionary_history WHERE user_word_id IS NOT NULL;
+ALTER TABLE dictionary_history
+    DROP FOREIGN KEY fk_dictionary_history_user_word,
+    DROP INDEX unique_user_user_word,
+    DROP COLUMN user_word_id,
+    MODIFY COLUMN word_id INT NOT NULL;
+DROP TABLE IF EXISTS user_words;
+DROP TABLE IF EXISTS user_decks;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS user_decks (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_user_deck_name (user_id, name),
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS user_words (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    deck_id INT NULL,
+    word VARCHAR(100) NOT NULL,
+    phonetic_clues VARCHAR(100) NOT NULL DEFAULT '',
+    translation VARCHAR(255) NOT NULL,
+    example VARCHAR(500) NOT NULL DEFAULT '',
+    example_translation VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_user_words_deck FOREIGN KEY (deck_id) REFERENCES user_decks(id) ON DELETE SET NULL,
+    INDEX idx_user_id (user_id),
+    INDEX idx_deck_id (deck_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE dictionary_history
+    MODIFY COLUMN word_id INT NULL,
+    ADD COLUMN user_word_id INT NULL AFTER word_id,
+    ADD CONSTRAINT fk_dictionary_history_user_word FOREIGN KEY (user_word_id) REFERENCES user_words(id) ON DELETE CASCADE,
+    ADD UNIQUE KEY unique_user_user_word (user_id, user_word_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS word_tags;
+
+ALTER TABLE words
+    DROP INDEX idx_jlpt_level,
+    DROP COLUMN part_of_speech,
+    DROP COLUMN jlpt_level;
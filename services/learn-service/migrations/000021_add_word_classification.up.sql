@@ -0,0 +1,12 @@
+ALTER TABLE words
+    ADD COLUMN jlpt_level TINYINT NULL AFTER extra_hard_period,
+    ADD COLUMN part_of_speech VARCHAR(20) NULL AFTER jlpt_level,
+    ADD INDEX idx_jlpt_level (jlpt_level);
+
+CREATE TABLE IF NOT EXISTS word_tags (
+    word_id INT NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (word_id, tag),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    INDEX idx_tag (tag)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
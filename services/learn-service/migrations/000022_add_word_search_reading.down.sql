@@ -0,0 +1,3 @@
+ALTER TABLE words
+    DROP INDEX idx_search_reading,
+    DROP COLUMN search_reading;
@@ -0,0 +1,3 @@
+ALTER TABLE words
+    ADD COLUMN search_reading VARCHAR(120) NULL AFTER phonetic_clues,
+    ADD INDEX idx_search_reading (search_reading);
@@ -0,0 +1,4 @@
+ALTER TABLE dictionary_history
+    DROP COLUMN review_count;
+
+DROP TABLE IF EXISTS word_examples;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS word_examples (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    word_id INT NOT NULL,
+    example VARCHAR(255) NOT NULL,
+    example_russian_translation VARCHAR(255) NOT NULL DEFAULT '',
+    example_english_translation VARCHAR(255) NOT NULL DEFAULT '',
+    example_german_translation VARCHAR(255) NOT NULL DEFAULT '',
+    example_audio VARCHAR(500) NULL,
+    source VARCHAR(255) NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    INDEX idx_word_id (word_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE dictionary_history
+    ADD COLUMN review_count INT NOT NULL DEFAULT 0 AFTER lapses;
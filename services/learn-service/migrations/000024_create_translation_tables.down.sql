@@ -0,0 +1,59 @@
+ALTER TABLE characters
+    ADD COLUMN english_reading VARCHAR(5) NOT NULL DEFAULT '' AFTER character_group,
+    ADD COLUMN russian_reading VARCHAR(5) NOT NULL DEFAULT '' AFTER english_reading;
+
+ALTER TABLE kanji
+    ADD COLUMN english_meaning VARCHAR(100) NOT NULL DEFAULT '' AFTER kunyomi,
+    ADD COLUMN russian_meaning VARCHAR(100) NOT NULL DEFAULT '' AFTER english_meaning,
+    ADD COLUMN german_meaning VARCHAR(100) NOT NULL DEFAULT '' AFTER russian_meaning;
+
+ALTER TABLE word_examples
+    ADD COLUMN example_russian_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example,
+    ADD COLUMN example_english_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example_russian_translation,
+    ADD COLUMN example_german_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example_english_translation;
+
+ALTER TABLE words
+    ADD COLUMN russian_translation VARCHAR(60) NOT NULL DEFAULT '' AFTER search_reading,
+    ADD COLUMN english_translation VARCHAR(60) NOT NULL DEFAULT '' AFTER russian_translation,
+    ADD COLUMN german_translation VARCHAR(60) NOT NULL DEFAULT '' AFTER english_translation,
+    ADD COLUMN example_russian_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example,
+    ADD COLUMN example_english_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example_russian_translation,
+    ADD COLUMN example_german_translation VARCHAR(255) NOT NULL DEFAULT '' AFTER example_english_translation;
+
+UPDATE characters c
+    LEFT JOIN character_readings en ON en.character_id = c.id AND en.language = 'en'
+    LEFT JOIN character_readings ru ON ru.character_id = c.id AND ru.language = 'ru'
+SET c.english_reading = COALESCE(LEFT(en.reading, 5), ''),
+    c.russian_reading = COALESCE(LEFT(ru.reading, 5), '');
+
+UPDATE kanji k
+    LEFT JOIN kanji_meanings en ON en.kanji_id = k.id AND en.language = 'en'
+    LEFT JOIN kanji_meanings ru ON ru.kanji_id = k.id AND ru.language = 'ru'
+    LEFT JOIN kanji_meanings de ON de.kanji_id = k.id AND de.language = 'de'
+SET k.english_meaning = COALESCE(en.meaning, ''),
+    k.russian_meaning = COALESCE(ru.meaning, ''),
+    k.german_meaning = COALESCE(de.meaning, '');
+
+UPDATE word_examples e
+    LEFT JOIN word_example_translations en ON en.example_id = e.id AND en.language = 'en'
+    LEFT JOIN word_example_translations ru ON ru.example_id = e.id AND ru.language = 'ru'
+    LEFT JOIN word_example_translations de ON de.example_id = e.id AND de.language = 'de'
+SET e.example_english_translation = COALESCE(en.translation, ''),
+    e.example_russian_translation = COALESCE(ru.translation, ''),
+    e.example_german_translation = COALESCE(de.translation, '');
+
+UPDATE words w
+    LEFT JOIN word_translations en ON en.word_id = w.id AND en.language = 'en'
+    LEFT JOIN word_translations ru ON ru.word_id = w.id AND ru.language = 'ru'
+    LEFT JOIN word_translations de ON de.word_id = w.id AND de.language = 'de'
+SET w.english_translation = COALESCE(LEFT(en.translation, 60), ''),
+    w.russian_translation = COALESCE(LEFT(ru.translation, 60), ''),
+    w.german_translation = COALESCE(LEFT(de.translation, 60), ''),
+    w.example_english_translation = COALESCE(en.example_translation, ''),
+    w.example_russian_translation = COALESCE(ru.example_translation, ''),
+    w.example_german_translation = COALESCE(de.example_translation, '');
+
+DROP TABLE IF EXISTS kanji_meanings;
+DROP TABLE IF EXISTS character_readings;
+DROP TABLE IF EXISTS word_example_translations;
+DROP TABLE IF EXISTS word_translations;
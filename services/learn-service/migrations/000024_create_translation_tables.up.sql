@@ -0,0 +1,81 @@
+CREATE TABLE IF NOT EXISTS word_translations (
+    word_id INT NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    translation VARCHAR(255) NOT NULL DEFAULT '',
+    example_translation VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (word_id, language),
+    FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+    INDEX idx_language_translation (language, translation)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS word_example_translations (
+    example_id INT NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    translation VARCHAR(255) NOT NULL,
+    PRIMARY KEY (example_id, language),
+    FOREIGN KEY (example_id) REFERENCES word_examples(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS character_readings (
+    character_id INT NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    reading VARCHAR(10) NOT NULL,
+    PRIMARY KEY (character_id, language),
+    FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS kanji_meanings (
+    kanji_id INT NOT NULL,
+    language VARCHAR(10) NOT NULL,
+    meaning VARCHAR(100) NOT NULL,
+    PRIMARY KEY (kanji_id, language),
+    FOREIGN KEY (kanji_id) REFERENCES kanji(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO word_translations (word_id, language, translation, example_translation)
+SELECT id, 'en', english_translation, example_english_translation FROM words
+UNION ALL
+SELECT id, 'ru', russian_translation, example_russian_translation FROM words
+UNION ALL
+SELECT id, 'de', german_translation, example_german_translation FROM words;
+
+INSERT INTO word_example_translations (example_id, language, translation)
+SELECT id, 'en', example_english_translation FROM word_examples WHERE example_english_translation != ''
+UNION ALL
+SELECT id, 'ru', example_russian_translation FROM word_examples WHERE example_russian_translation != ''
+UNION ALL
+SELECT id, 'de', example_german_translation FROM word_examples WHERE example_german_translation != '';
+
+INSERT INTO character_readings (character_id, language, reading)
+SELECT id, 'en', english_reading FROM characters
+UNION ALL
+SELECT id, 'ru', russian_reading FROM characters;
+
+INSERT INTO kanji_meanings (kanji_id, language, meaning)
+SELECT id, 'en', english_meaning FROM kanji WHERE english_meaning != ''
+UNION ALL
+SELECT id, 'ru', russian_meaning FROM kanji WHERE russian_meaning != ''
+UNION ALL
+SELECT id, 'de', german_meaning FROM kanji WHERE german_meaning != '';
+
+ALTER TABLE words
+    DROP COLUMN russian_translation,
+    DROP COLUMN english_translation,
+    DROP COLUMN german_translation,
+    DROP COLUMN example_russian_translation,
+    DROP COLUMN example_english_translation,
+    DROP COLUMN example_german_translation;
+
+ALTER TABLE word_examples
+    DROP COLUMN example_russian_translation,
+    DROP COLUMN example_english_translation,
+    DROP COLUMN example_german_translation;
+
+ALTER TABLE characters
+    DROP COLUMN english_reading,
+    DROP COLUMN russian_reading;
+
+ALTER TABLE kanji
+    DROP COLUMN english_meaning,
+    DROP COLUMN russian_meaning,
+    DROP COLUMN german_meaning;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS word_quiz_sessions;
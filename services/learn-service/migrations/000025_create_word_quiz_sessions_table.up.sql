@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS word_quiz_sessions (
+    id CHAR(36) PRIMARY KEY,
+    user_id INT NOT NULL,
+    mode ENUM('reverse', 'choice', 'listening') NOT NULL,
+    items JSON NOT NULL,
+    expires_at DATETIME NOT NULL,
+    submitted_at DATETIME NULL,
+    INDEX idx_user_id (user_id),
+    INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
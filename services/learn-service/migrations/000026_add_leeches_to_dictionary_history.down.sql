@@ -0,0 +1,4 @@
+ALTER TABLE dictionary_history
+    DROP INDEX idx_user_leech,
+    DROP COLUMN suspended,
+    DROP COLUMN is_leech;
@@ -0,0 +1,4 @@
+ALTER TABLE dictionary_history
+    ADD COLUMN is_leech BOOLEAN NOT NULL DEFAULT FALSE AFTER review_count,
+    ADD COLUMN suspended BOOLEAN NOT NULL DEFAULT FALSE AFTER is_leech,
+    ADD INDEX idx_user_leech (user_id, is_leech);
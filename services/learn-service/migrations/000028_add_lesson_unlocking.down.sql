@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS lesson_prerequisites;
+
+ALTER TABLE lessons DROP COLUMN min_kana_mastery;
+
+ALTER TABLE courses DROP COLUMN navigation;
@@ -0,0 +1,14 @@
+ALTER TABLE courses
+    ADD COLUMN navigation ENUM('free', 'sequential') NOT NULL DEFAULT 'free' AFTER complexity_level;
+
+ALTER TABLE lessons
+    ADD COLUMN min_kana_mastery TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER `order`;
+
+CREATE TABLE IF NOT EXISTS lesson_prerequisites (
+    lesson_id INT NOT NULL,
+    prerequisite_lesson_id INT NOT NULL,
+    PRIMARY KEY (lesson_id, prerequisite_lesson_id),
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (prerequisite_lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    INDEX idx_prerequisite_lesson_id (prerequisite_lesson_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
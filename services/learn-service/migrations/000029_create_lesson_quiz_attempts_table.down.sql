@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS lesson_quiz_attempts;
+
+DELETE FROM lesson_blocks WHERE block_type = 'quiz';
+
+ALTER TABLE lesson_blocks
+    MODIFY block_type ENUM('video', 'audio', 'text', 'document', 'list') NOT NULL;
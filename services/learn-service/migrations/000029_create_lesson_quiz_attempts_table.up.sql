@@ -0,0 +1,13 @@
+ALTER TABLE lesson_blocks
+    MODIFY block_type ENUM('video', 'audio', 'text', 'document', 'list', 'quiz') NOT NULL;
+
+CREATE TABLE IF NOT EXISTS lesson_quiz_attempts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    user_id INT NOT NULL,
+    block_id INT NOT NULL,
+    score TINYINT UNSIGNED NOT NULL,
+    answers JSON NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (block_id) REFERENCES lesson_blocks(id) ON DELETE CASCADE,
+    INDEX idx_user_block (user_id, block_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
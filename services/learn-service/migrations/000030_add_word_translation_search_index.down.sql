@@ -0,0 +1,2 @@
+ALTER TABLE word_translations
+    DROP INDEX ft_translation;
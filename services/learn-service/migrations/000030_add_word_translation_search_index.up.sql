@@ -0,0 +1,2 @@
+ALTER TABLE word_translations
+    ADD FULLTEXT INDEX ft_translation (translation);
@@ -0,0 +1,2 @@
+ALTER TABLE word_import_jobs
+    DROP COLUMN truncated_errors;
@@ -0,0 +1,2 @@
+ALTER TABLE word_import_jobs
+    ADD COLUMN truncated_errors INT NOT NULL DEFAULT 0 AFTER errors;
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
+	"time"
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
 	_ "github.com/go-sql-driver/mysql"
@@ -17,6 +20,7 @@ import (
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/models"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/repositories"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/learn-service/internal/services"
+	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/anki"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/auth/middleware"
 	"github.com/Sheliakhin-Golang-portfolio/JapaneseStudent/libs/config"
 	"github.com/stretchr/testify/assert"
@@ -52,57 +56,111 @@ func seedTestData(t *testing.T, db *sql.DB) {
 
 	// Insert full hiragana/katakana alphabet
 	query := `
-		INSERT INTO characters (consonant, vowel, english_reading, russian_reading, katakana, hiragana) VALUES
-		('', 'a', 'a', 'а', 'ア', 'あ'),
-		('', 'i', 'i', 'и', 'イ', 'い'),
-		('', 'u', 'u', 'у', 'ウ', 'う'),
-		('', 'e', 'e', 'э', 'エ', 'え'),
-		('', 'o', 'o', 'о', 'オ', 'お'),
-		('k', 'a', 'ka', 'ка', 'カ', 'か'),
-		('k', 'i', 'ki', 'ки', 'キ', 'き'),
-		('k', 'u', 'ku', 'ку', 'ク', 'く'),
-		('k', 'e', 'ke', 'кэ', 'ケ', 'け'),
-		('k', 'o', 'ko', 'ко', 'コ', 'こ'),
-		('s', 'a', 'sa', 'са', 'サ', 'さ'),
-		('s', 'i', 'shi', 'си', 'シ', 'し'),
-		('s', 'u', 'su', 'су', 'ス', 'す'),
-		('s', 'e', 'se', 'сэ', 'セ', 'せ'),
-		('s', 'o', 'so', 'со', 'ソ', 'そ'),
-		('t', 'a', 'ta', 'та', 'タ', 'た'),
-		('t', 'i', 'chi', 'ти', 'チ', 'ち'),
-		('t', 'u', 'tsu', 'цу', 'ツ', 'つ'),
-		('t', 'e', 'te', 'тэ', 'テ', 'て'),
-		('t', 'o', 'to', 'то', 'ト', 'と'),
-		('n', 'a', 'na', 'на', 'ナ', 'な'),
-		('n', 'i', 'ni', 'ни', 'ニ', 'に'),
-		('n', 'u', 'nu', 'ну', 'ヌ', 'ぬ'),
-		('n', 'e', 'ne', 'нэ', 'ネ', 'ね'),
-		('n', 'o', 'no', 'но', 'ノ', 'の'),
-		('h', 'a', 'ha', 'ха', 'ハ', 'は'),
-		('h', 'i', 'hi', 'хи', 'ヒ', 'ひ'),
-		('h', 'u', 'fu', 'фу', 'フ', 'ふ'),
-		('h', 'e', 'he', 'хэ', 'ヘ', 'へ'),
-		('h', 'o', 'ho', 'хо', 'ホ', 'ほ'),
-		('m', 'a', 'ma', 'ма', 'マ', 'ま'),
-		('m', 'i', 'mi', 'ми', 'ミ', 'み'),
-		('m', 'u', 'mu', 'му', 'ム', 'む'),
-		('m', 'e', 'me', 'мэ', 'メ', 'め'),
-		('m', 'o', 'mo', 'мо', 'モ', 'も'),
-		('y', 'a', 'ya', 'я', 'ヤ', 'や'),
-		('y', 'u', 'yu', 'ю', 'ユ', 'ゆ'),
-		('y', 'o', 'yo', 'ё', 'ヨ', 'よ'),
-		('r', 'a', 'ra', 'ра', 'ラ', 'ら'),
-		('r', 'i', 'ri', 'ри', 'リ', 'り'),
-		('r', 'u', 'ru', 'ру', 'ル', 'る'),
-		('r', 'e', 're', 'рэ', 'レ', 'れ'),
-		('r', 'o', 'ro', 'ро', 'ロ', 'ろ'),
-		('w', 'a', 'wa', 'ва', 'ワ', 'わ'),
-		('w', 'o', 'wo', 'во', 'ヲ', 'を'),
-		('', 'n', 'n', 'н', 'ン', 'ん');
+		INSERT INTO characters (consonant, vowel, katakana, hiragana) VALUES
+		('', 'a', 'ア', 'あ'),
+		('', 'i', 'イ', 'い'),
+		('', 'u', 'ウ', 'う'),
+		('', 'e', 'エ', 'え'),
+		('', 'o', 'オ', 'お'),
+		('k', 'a', 'カ', 'か'),
+		('k', 'i', 'キ', 'き'),
+		('k', 'u', 'ク', 'く'),
+		('k', 'e', 'ケ', 'け'),
+		('k', 'o', 'コ', 'こ'),
+		('s', 'a', 'サ', 'さ'),
+		('s', 'i', 'シ', 'し'),
+		('s', 'u', 'ス', 'す'),
+		('s', 'e', 'セ', 'せ'),
+		('s', 'o', 'ソ', 'そ'),
+		('t', 'a', 'タ', 'た'),
+		('t', 'i', 'チ', 'ち'),
+		('t', 'u', 'ツ', 'つ'),
+		('t', 'e', 'テ', 'て'),
+		('t', 'o', 'ト', 'と'),
+		('n', 'a', 'ナ', 'な'),
+		('n', 'i', 'ニ', 'に'),
+		('n', 'u', 'ヌ', 'ぬ'),
+		('n', 'e', 'ネ', 'ね'),
+		('n', 'o', 'ノ', 'の'),
+		('h', 'a', 'ハ', 'は'),
+		('h', 'i', 'ヒ', 'ひ'),
+		('h', 'u', 'フ', 'ふ'),
+		('h', 'e', 'ヘ', 'へ'),
+		('h', 'o', 'ホ', 'ほ'),
+		('m', 'a', 'マ', 'ま'),
+		('m', 'i', 'ミ', 'み'),
+		('m', 'u', 'ム', 'む'),
+		('m', 'e', 'メ', 'め'),
+		('m', 'o', 'モ', 'も'),
+		('y', 'a', 'ヤ', 'や'),
+		('y', 'u', 'ユ', 'ゆ'),
+		('y', 'o', 'ヨ', 'よ'),
+		('r', 'a', 'ラ', 'ら'),
+		('r', 'i', 'リ', 'り'),
+		('r', 'u', 'ル', 'る'),
+		('r', 'e', 'レ', 'れ'),
+		('r', 'o', 'ロ', 'ろ'),
+		('w', 'a', 'ワ', 'わ'),
+		('w', 'o', 'ヲ', 'を'),
+		('', 'n', 'ン', 'ん');
 	`
 
 	_, err = db.Exec(query)
 	require.NoError(t, err, "Failed to seed test data")
+
+	// Insert readings of the alphabet, IDs follow the order of insertion
+	query = `
+		INSERT INTO character_readings (character_id, language, reading) VALUES
+		(1, 'en', 'a'), (1, 'ru', 'а'),
+		(2, 'en', 'i'), (2, 'ru', 'и'),
+		(3, 'en', 'u'), (3, 'ru', 'у'),
+		(4, 'en', 'e'), (4, 'ru', 'э'),
+		(5, 'en', 'o'), (5, 'ru', 'о'),
+		(6, 'en', 'ka'), (6, 'ru', 'ка'),
+		(7, 'en', 'ki'), (7, 'ru', 'ки'),
+		(8, 'en', 'ku'), (8, 'ru', 'ку'),
+		(9, 'en', 'ke'), (9, 'ru', 'кэ'),
+		(10, 'en', 'ko'), (10, 'ru', 'ко'),
+		(11, 'en', 'sa'), (11, 'ru', 'са'),
+		(12, 'en', 'shi'), (12, 'ru', 'си'),
+		(13, 'en', 'su'), (13, 'ru', 'су'),
+		(14, 'en', 'se'), (14, 'ru', 'сэ'),
+		(15, 'en', 'so'), (15, 'ru', 'со'),
+		(16, 'en', 'ta'), (16, 'ru', 'та'),
+		(17, 'en', 'chi'), (17, 'ru', 'ти'),
+		(18, 'en', 'tsu'), (18, 'ru', 'цу'),
+		(19, 'en', 'te'), (19, 'ru', 'тэ'),
+		(20, 'en', 'to'), (20, 'ru', 'то'),
+		(21, 'en', 'na'), (21, 'ru', 'на'),
+		(22, 'en', 'ni'), (22, 'ru', 'ни'),
+		(23, 'en', 'nu'), (23, 'ru', 'ну'),
+		(24, 'en', 'ne'), (24, 'ru', 'нэ'),
+		(25, 'en', 'no'), (25, 'ru', 'но'),
+		(26, 'en', 'ha'), (26, 'ru', 'ха'),
+		(27, 'en', 'hi'), (27, 'ru', 'хи'),
+		(28, 'en', 'fu'), (28, 'ru', 'фу'),
+		(29, 'en', 'he'), (29, 'ru', 'хэ'),
+		(30, 'en', 'ho'), (30, 'ru', 'хо'),
+		(31, 'en', 'ma'), (31, 'ru', 'ма'),
+		(32, 'en', 'mi'), (32, 'ru', 'ми'),
+		(33, 'en', 'mu'), (33, 'ru', 'му'),
+		(34, 'en', 'me'), (34, 'ru', 'мэ'),
+		(35, 'en', 'mo'), (35, 'ru', 'мо'),
+		(36, 'en', 'ya'), (36, 'ru', 'я'),
+		(37, 'en', 'yu'), (37, 'ru', 'ю'),
+		(38, 'en', 'yo'), (38, 'ru', 'ё'),
+		(39, 'en', 'ra'), (39, 'ru', 'ра'),
+		(40, 'en', 'ri'), (40, 'ru', 'ри'),
+		(41, 'en', 'ru'), (41, 'ru', 'ру'),
+		(42, 'en', 're'), (42, 'ru', 'рэ'),
+		(43, 'en', 'ro'), (43, 'ru', 'ро'),
+		(44, 'en', 'wa'), (44, 'ru', 'ва'),
+		(45, 'en', 'wo'), (45, 'ru', 'во'),
+		(46, 'en', 'n'), (46, 'ru', 'н');
+	`
+
+	_, err = db.Exec(query)
+	require.NoError(t, err, "Failed to seed character readings")
 }
 
 // cleanupTestData removes all test data
@@ -110,8 +168,26 @@ func cleanupTestData(t *testing.T, db *sql.DB) {
 	t.Helper()
 	_, err := db.Exec("DELETE FROM dictionary_history")
 	require.NoError(t, err, "Failed to cleanup dictionary_history")
+	_, err = db.Exec("DELETE FROM user_words")
+	require.NoError(t, err, "Failed to cleanup user_words")
+	_, err = db.Exec("DELETE FROM user_decks")
+	require.NoError(t, err, "Failed to cleanup user_decks")
 	_, err = db.Exec("DELETE FROM character_learn_history")
 	require.NoError(t, err, "Failed to cleanup character_learn_history")
+	_, err = db.Exec("DELETE FROM character_test_attempts")
+	require.NoError(t, err, "Failed to cleanup character_test_attempts")
+	_, err = db.Exec("DELETE FROM test_sessions")
+	require.NoError(t, err, "Failed to cleanup test_sessions")
+	_, err = db.Exec("DELETE FROM word_quiz_sessions")
+	require.NoError(t, err, "Failed to cleanup word_quiz_sessions")
+	_, err = db.Exec("DELETE FROM character_strokes")
+	require.NoError(t, err, "Failed to cleanup character_strokes")
+	_, err = db.Exec("DELETE FROM kanji")
+	require.NoError(t, err, "Failed to cleanup kanji")
+	_, err = db.Exec("DELETE FROM word_tags")
+	require.NoError(t, err, "Failed to cleanup word_tags")
+	_, err = db.Exec("DELETE FROM word_examples")
+	require.NoError(t, err, "Failed to cleanup word_examples")
 	_, err = db.Exec("DELETE FROM words")
 	require.NoError(t, err, "Failed to cleanup words")
 	_, err = db.Exec("DELETE FROM characters")
@@ -122,16 +198,23 @@ func cleanupTestData(t *testing.T, db *sql.DB) {
 func setupTestRouter(db *sql.DB, logger *zap.Logger) chi.Router {
 	repo := repositories.NewCharactersRepository(db)
 	historyRepo := repositories.NewCharacterLearnHistoryRepository(db)
-	svc := services.NewCharactersService(repo, historyRepo)
+	sessionRepo := repositories.NewTestSessionRepository(db)
+	svc := services.NewCharactersService(repo, historyRepo, sessionRepo, repositories.NewCharacterStrokeRepository(db), repositories.NewCharacterTestAttemptRepository(db), services.DefaultLocaleConfig())
 	charHandler := handlers.NewCharactersHandler(svc, logger)
 
-	testResultSvc := services.NewTestResultService(historyRepo, repo)
+	testResultSvc := services.NewTestResultService(historyRepo, repo, repositories.NewCharacterTestAttemptRepository(db), sessionRepo, services.DefaultMasteryConfig())
 	testResultHandler := handlers.NewTestResultHandler(testResultSvc, logger)
 
 	wordRepo := repositories.NewWordRepository(db)
 	dictionaryHistoryRepo := repositories.NewDictionaryHistoryRepository(db)
-	dictionarySvc := services.NewDictionaryService(wordRepo, dictionaryHistoryRepo)
-	dictionaryHandler := handlers.NewDictionaryHandler(dictionarySvc, logger)
+	userWordRepo := repositories.NewUserWordRepository(db)
+	dictionarySvc := services.NewDictionaryService(wordRepo, repositories.NewWordExampleRepository(db), userWordRepo, dictionaryHistoryRepo, repositories.NewWordQuizSessionRepository(db), repositories.NewKanjiRepository(db), services.DefaultLeechConfig(), services.DefaultLocaleConfig())
+	dictionaryExportSvc := services.NewDictionaryExportService(dictionaryHistoryRepo, "", "", services.DefaultLocaleConfig())
+	dictionaryHandler := handlers.NewDictionaryHandler(dictionarySvc, dictionaryExportSvc, logger)
+	userDictionaryHandler := handlers.NewUserDictionaryHandler(services.NewUserDictionaryService(userWordRepo), logger)
+
+	kanjiHandler := handlers.NewKanjiHandler(services.NewKanjiService(repositories.NewKanjiRepository(db), services.DefaultLocaleConfig()), logger)
+	transliterationHandler := handlers.NewTransliterationHandler(services.NewTransliterationService(), logger)
 
 	r := chi.NewRouter()
 	r.Route("/api/v6", func(r chi.Router) {
@@ -140,6 +223,7 @@ func setupTestRouter(db *sql.DB, logger *zap.Logger) chi.Router {
 			r.Get("/", charHandler.GetAll)
 			r.Get("/row-column", charHandler.GetByRowColumn)
 			r.Get("/{id}", charHandler.GetByID)
+			r.Get("/{id}/strokes", charHandler.GetStrokes)
 		})
 
 		// Register all test routes together
@@ -149,9 +233,12 @@ func setupTestRouter(db *sql.DB, logger *zap.Logger) chi.Router {
 			// Character test routes
 			r.Get("/{type}/reading", charHandler.GetReadingTest)
 			r.Get("/{type}/writing", charHandler.GetWritingTest)
+			r.Get("/{type}/matching", charHandler.GetMatchingTest)
+			r.Post("/{type}/strokes/{id}", charHandler.CheckStrokes)
 			// Test result routes
-			r.Post("/{type}/{testType}", testResultHandler.SubmitTestResult)
+			r.Post("/sessions/{sessionId}", testResultHandler.SubmitTestSession)
 			r.Get("/history", testResultHandler.GetUserHistory)
+			r.Get("/attempts", testResultHandler.GetAttemptTimeline)
 		})
 
 		// Register dictionary routes
@@ -159,7 +246,18 @@ func setupTestRouter(db *sql.DB, logger *zap.Logger) chi.Router {
 			r.Use(authMiddleware)
 			r.Get("/", dictionaryHandler.GetWordList)
 			r.Post("/results", dictionaryHandler.SubmitWordResults)
+			r.Get("/forecast", dictionaryHandler.GetReviewForecast)
+			r.Get("/export", dictionaryHandler.ExportDictionary)
 		})
+
+		// Register personal dictionary routes
+		userDictionaryHandler.RegisterRoutes(r, authMiddleware)
+
+		// Register kanji routes
+		kanjiHandler.RegisterRoutes(r)
+
+		// Register transliteration routes
+		transliterationHandler.RegisterRoutes(r)
 	})
 
 	return r
@@ -218,11 +316,11 @@ func setupTestSchemaForMain(db *sql.DB) {
 			id INT PRIMARY KEY AUTO_INCREMENT,
 			consonant VARCHAR(10) NOT NULL,
 			vowel VARCHAR(10) NOT NULL,
-			english_reading VARCHAR(50) NOT NULL,
-			russian_reading VARCHAR(50) NOT NULL,
+			character_group ENUM('basic', 'dakuten', 'handakuten', 'yoon') NOT NULL DEFAULT 'basic',
 			katakana VARCHAR(10) NOT NULL,
 			hiragana VARCHAR(10) NOT NULL,
 			INDEX idx_consonant (consonant),
+			INDEX idx_character_group (character_group),
 			INDEX idx_vowel (vowel),
 			INDEX idx_katakana (katakana),
 			INDEX idx_hiragana (hiragana)
@@ -240,6 +338,8 @@ func setupTestSchemaForMain(db *sql.DB) {
 			katakana_reading_result FLOAT DEFAULT 0,
 			katakana_writing_result FLOAT DEFAULT 0,
 			katakana_listening_result FLOAT DEFAULT 0,
+			hiragana_matching_result FLOAT DEFAULT 0,
+			katakana_matching_result FLOAT DEFAULT 0,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			UNIQUE KEY unique_user_character (user_id, character_id),
@@ -247,24 +347,129 @@ func setupTestSchemaForMain(db *sql.DB) {
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 
+	attemptsTable := `
+		CREATE TABLE IF NOT EXISTS character_test_attempts (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			user_id INT NOT NULL,
+			character_id INT NOT NULL,
+			alphabet_type ENUM('hiragana', 'katakana') NOT NULL,
+			test_type ENUM('reading', 'writing', 'listening', 'matching') NOT NULL,
+			passed BOOLEAN NOT NULL,
+			chosen_option VARCHAR(20) NULL,
+			response_time_ms INT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	sessionsTable := `
+		CREATE TABLE IF NOT EXISTS test_sessions (
+			id CHAR(36) PRIMARY KEY,
+			user_id INT NOT NULL,
+			alphabet_type ENUM('hiragana', 'katakana') NOT NULL,
+			test_type ENUM('reading', 'writing', 'listening', 'matching') NOT NULL,
+			items JSON NOT NULL,
+			expires_at DATETIME NOT NULL,
+			submitted_at DATETIME NULL
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	wordQuizSessionsTable := `
+		CREATE TABLE IF NOT EXISTS word_quiz_sessions (
+			id CHAR(36) PRIMARY KEY,
+			user_id INT NOT NULL,
+			mode ENUM('reverse', 'choice', 'listening') NOT NULL,
+			items JSON NOT NULL,
+			expires_at DATETIME NOT NULL,
+			submitted_at DATETIME NULL
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	strokesTable := `
+		CREATE TABLE IF NOT EXISTS character_strokes (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			character_id INT NOT NULL,
+			alphabet_type ENUM('hiragana', 'katakana') NOT NULL,
+			stroke_number INT NOT NULL,
+			path TEXT NOT NULL,
+			FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+			UNIQUE KEY uk_character_alphabet_stroke (character_id, alphabet_type, stroke_number)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
 	wordsTable := `
 		CREATE TABLE IF NOT EXISTS words (
 			id INT PRIMARY KEY AUTO_INCREMENT,
 			word VARCHAR(255) NOT NULL,
 			phonetic_clues VARCHAR(255) NOT NULL,
-			russian_translation VARCHAR(255) NOT NULL,
-			english_translation VARCHAR(255) NOT NULL,
-			german_translation VARCHAR(255) NOT NULL,
+			search_reading VARCHAR(120) NULL,
 			example TEXT NOT NULL,
-			example_russian_translation TEXT NOT NULL,
-			example_english_translation TEXT NOT NULL,
-			example_german_translation TEXT NOT NULL,
 			easy_period INT NOT NULL DEFAULT 1,
 			normal_period INT NOT NULL DEFAULT 3,
 			hard_period INT NOT NULL DEFAULT 7,
 			extra_hard_period INT NOT NULL DEFAULT 14,
 			word_audio VARCHAR(500) NULL,
-			word_example_audio VARCHAR(500) NULL
+			word_example_audio VARCHAR(500) NULL,
+			jlpt_level TINYINT NULL,
+			part_of_speech VARCHAR(20) NULL,
+			INDEX idx_jlpt_level (jlpt_level),
+			INDEX idx_search_reading (search_reading)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	characterReadingsTable := `
+		CREATE TABLE IF NOT EXISTS character_readings (
+			character_id INT NOT NULL,
+			language VARCHAR(10) NOT NULL,
+			reading VARCHAR(10) NOT NULL,
+			PRIMARY KEY (character_id, language),
+			FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	wordTranslationsTable := `
+		CREATE TABLE IF NOT EXISTS word_translations (
+			word_id INT NOT NULL,
+			language VARCHAR(10) NOT NULL,
+			translation VARCHAR(255) NOT NULL DEFAULT '',
+			example_translation VARCHAR(255) NOT NULL DEFAULT '',
+			PRIMARY KEY (word_id, language),
+			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+			INDEX idx_language_translation (language, translation),
+			FULLTEXT INDEX ft_translation (translation)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	wordTagsTable := `
+		CREATE TABLE IF NOT EXISTS word_tags (
+			word_id INT NOT NULL,
+			tag VARCHAR(50) NOT NULL,
+			PRIMARY KEY (word_id, tag),
+			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+			INDEX idx_tag (tag)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	wordExamplesTable := `
+		CREATE TABLE IF NOT EXISTS word_examples (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			word_id INT NOT NULL,
+			example VARCHAR(255) NOT NULL,
+			example_audio VARCHAR(500) NULL,
+			source VARCHAR(255) NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+			INDEX idx_word_id (word_id)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	wordExampleTranslationsTable := `
+		CREATE TABLE IF NOT EXISTS word_example_translations (
+			example_id INT NOT NULL,
+			language VARCHAR(10) NOT NULL,
+			translation VARCHAR(255) NOT NULL,
+			PRIMARY KEY (example_id, language),
+			FOREIGN KEY (example_id) REFERENCES word_examples(id) ON DELETE CASCADE
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 
@@ -272,19 +477,103 @@ func setupTestSchemaForMain(db *sql.DB) {
 		CREATE TABLE IF NOT EXISTS dictionary_history (
 			id INT PRIMARY KEY AUTO_INCREMENT,
 			user_id INT NOT NULL,
-			word_id INT NOT NULL,
+			word_id INT NULL,
+			user_word_id INT NULL,
+			ease_factor FLOAT NOT NULL DEFAULT 2.5,
+			interval_days INT NOT NULL DEFAULT 0,
+			repetitions INT NOT NULL DEFAULT 0,
+			lapses INT NOT NULL DEFAULT 0,
+			review_count INT NOT NULL DEFAULT 0,
+			is_leech BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
 			next_appearance DATE NOT NULL,
+			last_reviewed_at DATETIME NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 			UNIQUE KEY unique_user_word (user_id, word_id),
+			UNIQUE KEY unique_user_user_word (user_id, user_word_id),
+			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE,
+			FOREIGN KEY (user_word_id) REFERENCES user_words(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	userDecksTable := `
+		CREATE TABLE IF NOT EXISTS user_decks (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			user_id INT NOT NULL,
+			name VARCHAR(100) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE KEY unique_user_deck_name (user_id, name)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	userWordsTable := `
+		CREATE TABLE IF NOT EXISTS user_words (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			user_id INT NOT NULL,
+			deck_id INT NULL,
+			word VARCHAR(100) NOT NULL,
+			phonetic_clues VARCHAR(100) NOT NULL DEFAULT '',
+			translation VARCHAR(255) NOT NULL,
+			example VARCHAR(500) NOT NULL DEFAULT '',
+			example_translation VARCHAR(500) NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (deck_id) REFERENCES user_decks(id) ON DELETE SET NULL
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	kanjiTable := `
+		CREATE TABLE IF NOT EXISTS kanji (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			kanji_character VARCHAR(1) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+			onyomi VARCHAR(100) NOT NULL,
+			kunyomi VARCHAR(100) NOT NULL,
+			stroke_count INT NOT NULL,
+			jlpt_level TINYINT NOT NULL,
+			radicals VARCHAR(50) NOT NULL,
+			audio VARCHAR(500) NULL,
+			UNIQUE KEY uk_kanji_character (kanji_character)
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	kanjiMeaningsTable := `
+		CREATE TABLE IF NOT EXISTS kanji_meanings (
+			kanji_id INT NOT NULL,
+			language VARCHAR(10) NOT NULL,
+			meaning VARCHAR(100) NOT NULL,
+			PRIMARY KEY (kanji_id, language),
+			FOREIGN KEY (kanji_id) REFERENCES kanji(id) ON DELETE CASCADE
+		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+	`
+
+	kanjiWordsTable := `
+		CREATE TABLE IF NOT EXISTS kanji_words (
+			kanji_id INT NOT NULL,
+			word_id INT NOT NULL,
+			PRIMARY KEY (kanji_id, word_id),
+			FOREIGN KEY (kanji_id) REFERENCES kanji(id) ON DELETE CASCADE,
 			FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE
 		) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 	`
 
 	db.Exec(charactersTable)
+	db.Exec(characterReadingsTable)
 	db.Exec(historyTable)
+	db.Exec(attemptsTable)
+	db.Exec(sessionsTable)
+	db.Exec(wordQuizSessionsTable)
+	db.Exec(strokesTable)
 	db.Exec(wordsTable)
+	db.Exec(wordTranslationsTable)
+	db.Exec(wordTagsTable)
+	db.Exec(wordExamplesTable)
+	db.Exec(wordExampleTranslationsTable)
+	db.Exec(userDecksTable)
+	db.Exec(userWordsTable)
 	db.Exec(dictionaryHistoryTable)
+	db.Exec(kanjiTable)
+	db.Exec(kanjiMeaningsTable)
+	db.Exec(kanjiWordsTable)
 }
 
 func TestIntegration_GetAllCharacters(t *testing.T) {
@@ -341,6 +630,31 @@ func TestIntegration_GetAllCharacters(t *testing.T) {
 				assert.Greater(t, len(chars), 0)
 			},
 		},
+		{
+			name:           "basic group only",
+			queryParams:    "?type=hr&locale=en&groups=basic",
+			expectedStatus: http.StatusOK,
+			expectedCount:  46,
+			validateFunc: func(t *testing.T, chars []models.CharacterResponse) {
+				for _, char := range chars {
+					assert.Equal(t, models.CharacterGroupBasic, char.Group)
+				}
+			},
+		},
+		{
+			name:           "groups without seeded characters",
+			queryParams:    "?type=hr&locale=en&groups=dakuten,yoon",
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+			validateFunc:   nil,
+		},
+		{
+			name:           "invalid group",
+			queryParams:    "?type=hr&locale=en&groups=invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedCount:  0,
+			validateFunc:   nil,
+		},
 		{
 			name:           "invalid alphabet type",
 			queryParams:    "?type=invalid&locale=en",
@@ -478,7 +792,7 @@ func TestIntegration_GetByID(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, char *models.Character) {
 				assert.Equal(t, 1, char.ID)
-				assert.Equal(t, "a", char.EnglishReading)
+				assert.Equal(t, "a", char.Reading)
 				assert.Equal(t, "あ", char.Hiragana)
 				assert.Equal(t, "ア", char.Katakana)
 			},
@@ -490,7 +804,17 @@ func TestIntegration_GetByID(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, char *models.Character) {
 				assert.Equal(t, 1, char.ID)
-				assert.Equal(t, "а", char.RussianReading)
+				assert.Equal(t, "а", char.Reading)
+			},
+		},
+		{
+			name:           "get character by id falls back to the default locale",
+			id:             "1",
+			queryParams:    "?locale=de",
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, char *models.Character) {
+				assert.Equal(t, 1, char.ID)
+				assert.Equal(t, "a", char.Reading)
 			},
 		},
 		{
@@ -562,13 +886,14 @@ func TestIntegration_GetReadingTest(t *testing.T) {
 			expectedCount:  10, // testCount constant
 			validateFunc: func(t *testing.T, items []models.ReadingTestItem) {
 				for _, item := range items {
-					assert.NotEmpty(t, item.CorrectChar)
 					assert.NotEmpty(t, item.Reading)
-					assert.Len(t, item.WrongOptions, 2)
-					// Verify wrong options are different from correct char
-					for _, wrong := range item.WrongOptions {
-						assert.NotEqual(t, item.CorrectChar, wrong)
-					}
+					assert.Len(t, item.Options, 3)
+					// Verify options are different from each other
+					assert.NotEqual(t, item.Options[0], item.Options[1])
+					assert.NotEqual(t, item.Options[1], item.Options[2])
+					assert.NotEqual(t, item.Options[0], item.Options[2])
+					// Verify correct answer is not leaked
+					assert.Empty(t, item.CorrectChar)
 				}
 			},
 		},
@@ -580,9 +905,8 @@ func TestIntegration_GetReadingTest(t *testing.T) {
 			expectedCount:  10,
 			validateFunc: func(t *testing.T, items []models.ReadingTestItem) {
 				for _, item := range items {
-					assert.NotEmpty(t, item.CorrectChar)
 					assert.NotEmpty(t, item.Reading)
-					assert.Len(t, item.WrongOptions, 2)
+					assert.Len(t, item.Options, 3)
 				}
 			},
 		},
@@ -615,13 +939,78 @@ func TestIntegration_GetReadingTest(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
 			if tt.expectedStatus == http.StatusOK {
-				var result []models.ReadingTestItem
+				var result models.ReadingTestSession
 				err := json.NewDecoder(w.Body).Decode(&result)
 				require.NoError(t, err)
-				assert.Len(t, result, tt.expectedCount)
+				assert.NotEmpty(t, result.SessionID)
+				assert.Len(t, result.Items, tt.expectedCount)
 
 				if tt.validateFunc != nil {
-					tt.validateFunc(t, result)
+					tt.validateFunc(t, result.Items)
+				}
+			}
+		})
+	}
+}
+
+func TestIntegration_GetMatchingTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	seedTestData(t, testDB)
+	defer cleanupTestData(t, testDB)
+
+	tests := []struct {
+		name           string
+		alphabetType   string
+		expectedStatus int
+		optionTable    *unicode.RangeTable
+	}{
+		{
+			name:           "hiragana shown, katakana options",
+			alphabetType:   "hiragana",
+			expectedStatus: http.StatusOK,
+			optionTable:    unicode.Katakana,
+		},
+		{
+			name:           "katakana shown, hiragana options",
+			alphabetType:   "katakana",
+			expectedStatus: http.StatusOK,
+			optionTable:    unicode.Hiragana,
+		},
+		{
+			name:           "invalid alphabet type",
+			alphabetType:   "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v6/tests/"+tt.alphabetType+"/matching?count=5", nil)
+			req = req.WithContext(middleware.SetUserID(req.Context(), 1))
+			w := httptest.NewRecorder()
+
+			testRouter.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var result models.MatchingTestSession
+				err := json.NewDecoder(w.Body).Decode(&result)
+				require.NoError(t, err)
+				assert.NotEmpty(t, result.SessionID)
+				assert.Len(t, result.Items, 5)
+				for _, item := range result.Items {
+					assert.NotEmpty(t, item.Character)
+					assert.False(t, unicode.Is(tt.optionTable, []rune(item.Character)[0]), "shown character must belong to the tested alphabet")
+					assert.Len(t, item.Options, 3)
+					for _, option := range item.Options {
+						assert.True(t, unicode.Is(tt.optionTable, []rune(option)[0]), "option %s must belong to the other alphabet", option)
+					}
+					// Verify correct answer is not leaked
+					assert.Empty(t, item.CorrectChar)
 				}
 			}
 		})
@@ -653,7 +1042,8 @@ func TestIntegration_GetWritingTest(t *testing.T) {
 			validateFunc: func(t *testing.T, items []models.WritingTestItem) {
 				for _, item := range items {
 					assert.NotEmpty(t, item.Character)
-					assert.NotEmpty(t, item.CorrectReading)
+					// Verify correct answer is not leaked
+					assert.Empty(t, item.CorrectReading)
 				}
 			},
 		},
@@ -666,7 +1056,6 @@ func TestIntegration_GetWritingTest(t *testing.T) {
 			validateFunc: func(t *testing.T, items []models.WritingTestItem) {
 				for _, item := range items {
 					assert.NotEmpty(t, item.Character)
-					assert.NotEmpty(t, item.CorrectReading)
 				}
 			},
 		},
@@ -699,13 +1088,14 @@ func TestIntegration_GetWritingTest(t *testing.T) {
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
 			if tt.expectedStatus == http.StatusOK {
-				var result []models.WritingTestItem
+				var result models.WritingTestSession
 				err := json.NewDecoder(w.Body).Decode(&result)
 				require.NoError(t, err)
-				assert.Len(t, result, tt.expectedCount)
+				assert.NotEmpty(t, result.SessionID)
+				assert.Len(t, result.Items, tt.expectedCount)
 
 				if tt.validateFunc != nil {
-					tt.validateFunc(t, result)
+					tt.validateFunc(t, result.Items)
 				}
 			}
 		})
@@ -724,7 +1114,7 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("GetAll hiragana english", func(t *testing.T) {
-		result, err := repo.GetAll(ctx, models.AlphabetTypeHiragana, models.LocaleEnglish)
+		result, err := repo.GetAll(ctx, models.AlphabetTypeHiragana, []string{"en"}, nil)
 		require.NoError(t, err)
 		assert.Greater(t, len(result), 0)
 		assert.Equal(t, "あ", result[0].Character)
@@ -732,7 +1122,7 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 	})
 
 	t.Run("GetByRowColumn with vowel", func(t *testing.T) {
-		result, err := repo.GetByRowColumn(ctx, models.AlphabetTypeHiragana, models.LocaleEnglish, "a")
+		result, err := repo.GetByRowColumn(ctx, models.AlphabetTypeHiragana, []string{"en"}, "a", nil)
 		require.NoError(t, err)
 		assert.Greater(t, len(result), 0)
 		for _, char := range result {
@@ -741,16 +1131,16 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 	})
 
 	t.Run("GetByID", func(t *testing.T) {
-		result, err := repo.GetByID(ctx, 1, models.LocaleEnglish)
+		result, err := repo.GetByID(ctx, 1, []string{"en"})
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, 1, result.ID)
-		assert.Equal(t, "a", result.EnglishReading)
+		assert.Equal(t, "a", result.Reading)
 	})
 
 	t.Run("GetCharactersForReadingTest", func(t *testing.T) {
 		// Get some character IDs first
-		allChars, err := repo.GetAll(ctx, models.AlphabetTypeHiragana, models.LocaleEnglish)
+		allChars, err := repo.GetAll(ctx, models.AlphabetTypeHiragana, []string{"en"}, nil)
 		require.NoError(t, err)
 		require.GreaterOrEqual(t, len(allChars), 5)
 
@@ -759,7 +1149,7 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 			characterIDs[i] = allChars[i].ID
 		}
 
-		result, err := repo.GetCharactersForReadingTest(ctx, models.AlphabetTypeHiragana, models.LocaleEnglish, characterIDs)
+		result, err := repo.GetCharactersForReadingTest(ctx, models.AlphabetTypeHiragana, []string{"en"}, characterIDs, nil)
 		require.NoError(t, err)
 		assert.Len(t, result, 5)
 		for _, item := range result {
@@ -771,7 +1161,7 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 
 	t.Run("GetCharactersForWritingTest", func(t *testing.T) {
 		// Get some character IDs first
-		allChars, err := repo.GetAll(ctx, models.AlphabetTypeKatakana, models.LocaleRussian)
+		allChars, err := repo.GetAll(ctx, models.AlphabetTypeKatakana, []string{"ru", "en"}, nil)
 		require.NoError(t, err)
 		require.GreaterOrEqual(t, len(allChars), 5)
 
@@ -780,7 +1170,7 @@ func TestIntegration_RepositoryLayer(t *testing.T) {
 			characterIDs[i] = allChars[i].ID
 		}
 
-		result, err := repo.GetCharactersForWritingTest(ctx, models.AlphabetTypeKatakana, models.LocaleRussian, characterIDs)
+		result, err := repo.GetCharactersForWritingTest(ctx, models.AlphabetTypeKatakana, []string{"ru", "en"}, characterIDs)
 		require.NoError(t, err)
 		assert.Len(t, result, 5)
 		for _, item := range result {
@@ -800,17 +1190,18 @@ func TestIntegration_ServiceLayer(t *testing.T) {
 
 	repo := repositories.NewCharactersRepository(testDB)
 	historyRepo := repositories.NewCharacterLearnHistoryRepository(testDB)
-	svc := services.NewCharactersService(repo, historyRepo)
+	sessionRepo := repositories.NewTestSessionRepository(testDB)
+	svc := services.NewCharactersService(repo, historyRepo, sessionRepo, repositories.NewCharacterStrokeRepository(testDB), repositories.NewCharacterTestAttemptRepository(testDB), services.DefaultLocaleConfig())
 	ctx := context.Background()
 
 	t.Run("GetAll", func(t *testing.T) {
-		result, err := svc.GetAll(ctx, "hr", "en")
+		result, err := svc.GetAll(ctx, "hr", "en", "")
 		require.NoError(t, err)
 		assert.Greater(t, len(result), 0)
 	})
 
 	t.Run("GetByRowColumn", func(t *testing.T) {
-		result, err := svc.GetByRowColumn(ctx, "hr", "en", "a")
+		result, err := svc.GetByRowColumn(ctx, "hr", "en", "a", "")
 		require.NoError(t, err)
 		assert.Greater(t, len(result), 0)
 	})
@@ -823,19 +1214,44 @@ func TestIntegration_ServiceLayer(t *testing.T) {
 	})
 
 	t.Run("GetReadingTest", func(t *testing.T) {
-		result, err := svc.GetReadingTest(ctx, "hiragana", "en", 10, 1)
+		result, err := svc.GetReadingTest(ctx, "hiragana", "en", 10, 1, "")
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 10)
+
+		// Verify correct answers are kept in the stored session
+		session, err := sessionRepo.GetByID(ctx, result.SessionID)
 		require.NoError(t, err)
-		assert.Len(t, result, 10)
+		assert.Equal(t, 1, session.UserID)
+		assert.Equal(t, "reading", session.TestType)
+		require.Len(t, session.Items, 10)
+		for i, item := range session.Items {
+			assert.Equal(t, result.Items[i].ID, item.CharacterID)
+			assert.Contains(t, result.Items[i].Options, item.CorrectAnswer)
+		}
 	})
 
 	t.Run("GetWritingTest", func(t *testing.T) {
-		result, err := svc.GetWritingTest(ctx, "katakana", "ru", 10, 1)
+		result, err := svc.GetWritingTest(ctx, "katakana", "ru", 10, 1, "")
 		require.NoError(t, err)
-		assert.Len(t, result, 10)
+		assert.Len(t, result.Items, 10)
 	})
 }
 
-func TestIntegration_SubmitTestResults(t *testing.T) {
+// createTestSession stores a test session with defined items for the user
+func createTestSession(t *testing.T, id string, userID int, alphabetType, testType string, expiresAt time.Time, items []models.TestSessionItem) {
+	t.Helper()
+	err := repositories.NewTestSessionRepository(testDB).Create(context.Background(), &models.TestSession{
+		ID:           id,
+		UserID:       userID,
+		AlphabetType: alphabetType,
+		TestType:     testType,
+		Items:        items,
+		ExpiresAt:    expiresAt.UTC().Truncate(time.Second),
+	})
+	require.NoError(t, err, "Failed to create test session")
+}
+
+func TestIntegration_SubmitTestSession(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
 	}
@@ -848,26 +1264,70 @@ func TestIntegration_SubmitTestResults(t *testing.T) {
 	_, err := testDB.Exec("DELETE FROM character_learn_history")
 	require.NoError(t, err)
 
+	validUntil := time.Now().Add(time.Hour)
+	createTestSession(t, "session-hiragana-reading", 1, "hiragana", "reading", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+		{CharacterID: 2, CorrectAnswer: "い"},
+	})
+	createTestSession(t, "session-katakana-writing", 2, "katakana", "writing", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "a"},
+	})
+	createTestSession(t, "session-hiragana-listening", 3, "hiragana", "listening", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+	})
+	createTestSession(t, "session-first", 4, "hiragana", "reading", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+	})
+	createTestSession(t, "session-second", 4, "hiragana", "reading", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+	})
+	createTestSession(t, "session-expired", 5, "hiragana", "reading", time.Now().Add(-time.Minute), []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+	})
+	createTestSession(t, "session-foreign", 6, "hiragana", "reading", validUntil, []models.TestSessionItem{
+		{CharacterID: 1, CorrectAnswer: "あ"},
+	})
+
+	submit := func(userID int, sessionID string, answers []map[string]any) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]any{
+			"answers": answers,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v6/tests/sessions/"+sessionID, bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(middleware.SetUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+
 	tests := []struct {
 		name           string
 		userID         int
-		alphabetType   string
-		testType       string
-		results        []map[string]any
+		sessionID      string
+		answers        []map[string]any
 		expectedStatus int
 		validateFunc   func(*testing.T, *httptest.ResponseRecorder)
 	}{
 		{
-			name:         "success submit hiragana reading results",
-			userID:       1,
-			alphabetType: "hiragana",
-			testType:     "reading",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
-				{"characterId": 2, "passed": false},
+			name:      "success submit hiragana reading answers",
+			userID:    1,
+			sessionID: "session-hiragana-reading",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
+				{"characterId": 2, "answer": "う"},
 			},
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				// Verify answers were graded on the server
+				var response struct {
+					Results []models.GradedAnswer `json:"results"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+				require.Len(t, response.Results, 2)
+				assert.True(t, response.Results[0].Passed)
+				assert.False(t, response.Results[1].Passed)
+				assert.Equal(t, "い", response.Results[1].CorrectAnswer)
+
 				// Verify database records were created
 				var count int
 				err := testDB.QueryRow("SELECT COUNT(*) FROM character_learn_history WHERE user_id = ?", 1).Scan(&count)
@@ -878,113 +1338,122 @@ func TestIntegration_SubmitTestResults(t *testing.T) {
 				var result float64
 				err = testDB.QueryRow("SELECT hiragana_reading_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 1, 1).Scan(&result)
 				require.NoError(t, err)
-				assert.Equal(t, 1.0, result)
+				assert.InDelta(t, 0.3, result, 0.001)
 
 				err = testDB.QueryRow("SELECT hiragana_reading_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 1, 2).Scan(&result)
 				require.NoError(t, err)
 				assert.Equal(t, 0.0, result)
+
+				// Verify every answer was logged as a separate attempt
+				err = testDB.QueryRow("SELECT COUNT(*) FROM character_test_attempts WHERE user_id = ?", 1).Scan(&count)
+				require.NoError(t, err)
+				assert.Equal(t, 2, count)
 			},
 		},
 		{
-			name:         "success submit katakana writing results",
-			userID:       2,
-			alphabetType: "katakana",
-			testType:     "writing",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
+			name:      "success submit katakana writing answers",
+			userID:    2,
+			sessionID: "session-katakana-writing",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "A"},
 			},
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var result float64
 				err := testDB.QueryRow("SELECT katakana_writing_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 2, 1).Scan(&result)
 				require.NoError(t, err)
-				assert.Equal(t, 1.0, result)
+				assert.InDelta(t, 0.3, result, 0.001)
 			},
 		},
 		{
-			name:         "success submit listening results",
-			userID:       3,
-			alphabetType: "hiragana",
-			testType:     "listening",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
+			name:      "success submit listening answers",
+			userID:    3,
+			sessionID: "session-hiragana-listening",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
 			},
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var result float64
 				err := testDB.QueryRow("SELECT hiragana_listening_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 3, 1).Scan(&result)
 				require.NoError(t, err)
-				assert.Equal(t, 1.0, result)
+				assert.InDelta(t, 0.3, result, 0.001)
 			},
 		},
 		{
-			name:         "success update existing history",
-			userID:       4,
-			alphabetType: "hiragana",
-			testType:     "reading",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
+			name:      "success update existing history and reject replay",
+			userID:    4,
+			sessionID: "session-first",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
 			},
 			expectedStatus: http.StatusOK,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
-				// First submission should create record with 1.0
+				// First submission should create record with the first mastery step
 				var result float64
 				err := testDB.QueryRow("SELECT hiragana_reading_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 4, 1).Scan(&result)
 				require.NoError(t, err)
-				assert.Equal(t, 1.0, result)
+				assert.InDelta(t, 0.3, result, 0.001)
 
-				// Submit again with failed result
-				body, _ := json.Marshal(map[string]any{
-					"results": []map[string]any{{"characterId": 1, "passed": false}},
-				})
-				req := httptest.NewRequest(http.MethodPost, "/api/v6/tests/hiragana/reading", bytes.NewBuffer(body))
-				req.Header.Set("Content-Type", "application/json")
-				req = req.WithContext(middleware.SetUserID(req.Context(), 4))
-				w2 := httptest.NewRecorder()
-				testRouter.ServeHTTP(w2, req)
+				// Replaying the same session must not change the result
+				w2 := submit(4, "session-first", []map[string]any{{"characterId": 1, "answer": "あ"}})
+				assert.Equal(t, http.StatusConflict, w2.Code)
 
-				// Should update to new value (replaces existing, not averaging)
+				// Submit another session with wrong answer
+				w3 := submit(4, "session-second", []map[string]any{{"characterId": 1, "answer": "お"}})
+				assert.Equal(t, http.StatusOK, w3.Code)
+
+				// Should move the score towards 0 instead of replacing it
 				err = testDB.QueryRow("SELECT hiragana_reading_result FROM character_learn_history WHERE user_id = ? AND character_id = ?", 4, 1).Scan(&result)
 				require.NoError(t, err)
-				assert.Equal(t, 0.0, result)
+				assert.InDelta(t, 0.21, result, 0.001)
 			},
 		},
 		{
-			name:         "invalid alphabet type",
-			userID:       5,
-			alphabetType: "kanji",
-			testType:     "reading",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
+			name:      "expired session",
+			userID:    5,
+			sessionID: "session-expired",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusGone,
 			validateFunc:   nil,
 		},
 		{
-			name:         "invalid test type",
-			userID:       5,
-			alphabetType: "hiragana",
-			testType:     "speaking",
-			results: []map[string]any{
-				{"characterId": 1, "passed": true},
+			name:      "session of another user",
+			userID:    5,
+			sessionID: "session-foreign",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
+			},
+			expectedStatus: http.StatusNotFound,
+			validateFunc:   nil,
+		},
+		{
+			name:      "answer for character outside of session",
+			userID:    6,
+			sessionID: "session-foreign",
+			answers: []map[string]any{
+				{"characterId": 2, "answer": "い"},
 			},
 			expectedStatus: http.StatusBadRequest,
 			validateFunc:   nil,
 		},
+		{
+			name:      "unknown session",
+			userID:    1,
+			sessionID: "session-unknown",
+			answers: []map[string]any{
+				{"characterId": 1, "answer": "あ"},
+			},
+			expectedStatus: http.StatusNotFound,
+			validateFunc:   nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			body, _ := json.Marshal(map[string]any{
-				"results": tt.results,
-			})
-			url := fmt.Sprintf("/api/v6/tests/%s/%s", tt.alphabetType, tt.testType)
-			req := httptest.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
-			req.Header.Set("Content-Type", "application/json")
-			req = req.WithContext(middleware.SetUserID(req.Context(), tt.userID))
-			w := httptest.NewRecorder()
-
-			testRouter.ServeHTTP(w, req)
+			w := submit(tt.userID, tt.sessionID, tt.answers)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
 			if tt.validateFunc != nil {
@@ -1112,7 +1581,7 @@ func TestIntegration_CharacterLearnHistoryRepository(t *testing.T) {
 			{UserID: 2, CharacterID: 1, HiraganaReadingResult: 1.0},
 			{UserID: 2, CharacterID: 2, HiraganaWritingResult: 0.9},
 		}
-		err := historyRepo.Upsert(ctx, histories)
+		err := historyRepo.Upsert(ctx, histories, nil)
 		require.NoError(t, err)
 
 		// Verify records were created
@@ -1127,7 +1596,7 @@ func TestIntegration_CharacterLearnHistoryRepository(t *testing.T) {
 		histories := []models.CharacterLearnHistory{
 			{UserID: 2, CharacterID: 1, HiraganaReadingResult: 0.5},
 		}
-		err := historyRepo.Upsert(ctx, histories)
+		err := historyRepo.Upsert(ctx, histories, nil)
 		require.NoError(t, err)
 
 		// Verify record was updated
@@ -1142,7 +1611,7 @@ func TestIntegration_CharacterLearnHistoryRepository(t *testing.T) {
 		histories := []models.CharacterLearnHistory{
 			{UserID: 3, CharacterID: 99999, HiraganaReadingResult: 1.0},
 		}
-		err := historyRepo.Upsert(ctx, histories)
+		err := historyRepo.Upsert(ctx, histories, nil)
 		assert.Error(t, err)
 
 		// Verify no record was created
@@ -1153,6 +1622,198 @@ func TestIntegration_CharacterLearnHistoryRepository(t *testing.T) {
 	})
 }
 
+func TestIntegration_CheckStrokes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	seedTestData(t, testDB)
+	defer cleanupTestData(t, testDB)
+
+	// Character with ID 2 is "い", it consists of two strokes
+	strokeRepo := repositories.NewCharacterStrokeRepository(testDB)
+	err := strokeRepo.ReplaceForCharacter(context.Background(), 2, "hiragana", []models.CharacterStroke{
+		{StrokeNumber: 1, Path: "M25,30L30,80"},
+		{StrokeNumber: 2, Path: "M70,35L80,65"},
+	})
+	require.NoError(t, err)
+
+	t.Run("get reference strokes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/characters/2/strokes?type=hr", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var strokes []models.CharacterStroke
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&strokes))
+		assert.Len(t, strokes, 2)
+	})
+
+	t.Run("no katakana strokes", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/characters/2/strokes?type=kt", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedPassed bool
+		expectedFirst  models.StrokeStatus
+	}{
+		{
+			name:           "correct strokes",
+			body:           `{"strokes":[[{"x":25,"y":31},{"x":28,"y":55},{"x":30,"y":79}],[{"x":70,"y":36},{"x":80,"y":64}]]}`,
+			expectedStatus: http.StatusOK,
+			expectedPassed: true,
+			expectedFirst:  models.StrokeStatusCorrect,
+		},
+		{
+			name:           "first stroke drawn backwards",
+			body:           `{"strokes":[[{"x":30,"y":80},{"x":25,"y":30}],[{"x":70,"y":35},{"x":80,"y":65}]]}`,
+			expectedStatus: http.StatusOK,
+			expectedPassed: false,
+			expectedFirst:  models.StrokeStatusWrongDirection,
+		},
+		{
+			name:           "empty strokes",
+			body:           `{"strokes":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v6/tests/hiragana/strokes/2", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			testRouter.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var result models.StrokeExerciseResult
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+				assert.Equal(t, tt.expectedPassed, result.Passed)
+				require.Len(t, result.Strokes, 2)
+				assert.Equal(t, tt.expectedFirst, result.Strokes[0].Status)
+			}
+		})
+	}
+}
+
+func TestIntegration_Kanji(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	seedTestData(t, testDB)
+	defer cleanupTestData(t, testDB)
+
+	_, err := testDB.Exec(`
+		INSERT INTO words (word, phonetic_clues, example) VALUES
+		('水', 'みず', '水を飲む'),
+		('水曜日', 'すいようび', '水曜日に会う'),
+		('火', 'ひ', '火をつける')
+	`)
+	require.NoError(t, err)
+	_, err = testDB.Exec(`
+		INSERT INTO word_translations (word_id, language, translation, example_translation) VALUES
+		(1, 'en', 'water', 'drink water'), (1, 'ru', 'вода', 'пить воду'), (1, 'de', 'Wasser', 'Wasser trinken'),
+		(2, 'en', 'Wednesday', 'meet on Wednesday'), (2, 'ru', 'среда', 'встретиться в среду'), (2, 'de', 'Mittwoch', 'am Mittwoch treffen'),
+		(3, 'en', 'fire', 'light a fire'), (3, 'ru', 'огонь', 'зажечь огонь'), (3, 'de', 'Feuer', 'Feuer machen')
+	`)
+	require.NoError(t, err)
+
+	// Kanji is created through the admin service to link it to the words
+	adminSvc := services.NewAdminKanjiService(repositories.NewKanjiRepository(testDB), "", "", services.DefaultLocaleConfig())
+	kanjiID, err := adminSvc.CreateKanji(context.Background(), &models.CreateKanjiRequest{
+		Character:   "水",
+		Onyomi:      "スイ",
+		Kunyomi:     "みず",
+		Meanings:    map[string]string{"en": "water", "ru": "вода", "de": "Wasser"},
+		StrokeCount: 4,
+		JLPTLevel:   5,
+		Radicals:    "水",
+	}, nil, "")
+	require.NoError(t, err)
+
+	t.Run("browse by JLPT level", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/kanji?level=5&locale=de", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var kanji []models.KanjiResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&kanji))
+		require.Len(t, kanji, 1)
+		assert.Equal(t, "Wasser", kanji[0].Meaning)
+	})
+
+	t.Run("meaning falls back to the default locale", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/kanji?level=5&locale=fr", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var kanji []models.KanjiResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&kanji))
+		require.Len(t, kanji, 1)
+		assert.Equal(t, "water", kanji[0].Meaning)
+	})
+
+	t.Run("no kanji on other level", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/kanji?level=1", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var kanji []models.KanjiResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&kanji))
+		assert.Empty(t, kanji)
+	})
+
+	t.Run("invalid level", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/kanji?level=7", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("words containing kanji", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v6/kanji/%d/words?locale=en", kanjiID), nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var words []models.WordResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&words))
+		require.Len(t, words, 2)
+		assert.Equal(t, "water", words[0].Translation)
+		assert.Equal(t, "Wednesday", words[1].Translation)
+	})
+
+	t.Run("unknown kanji", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/kanji/999999/words", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 // Benchmark tests
 func BenchmarkIntegration_GetAll(b *testing.B) {
 	if testing.Short() {
@@ -1170,6 +1831,61 @@ func BenchmarkIntegration_GetAll(b *testing.B) {
 	}
 }
 
+func TestIntegration_Transliterate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	t.Run("query text", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/transliterate?from=hiragana&to=hepburn&text="+url.QueryEscape("まっちゃ"), nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.TransliterationResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, "matcha", result.Text)
+		assert.Equal(t, []models.TransliterationSegment{
+			{Source: "ま", Target: "ma"},
+			{Source: "っ", Target: "t"},
+			{Source: "ちゃ", Target: "cha"},
+		}, result.Segments)
+	})
+
+	t.Run("body text", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"text": "Tōkyō"})
+		req := httptest.NewRequest(http.MethodPost, "/api/v6/transliterate?from=hepburn&to=katakana", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var result models.TransliterationResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, "トウキョウ", result.Text)
+		assert.Equal(t, "Tō", result.Segments[0].Source)
+	})
+
+	t.Run("invalid script", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v6/transliterate?from=kanji&to=hepburn&text=abc", nil)
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v6/transliterate?from=hepburn&to=hiragana", bytes.NewReader([]byte("{")))
+		w := httptest.NewRecorder()
+
+		testRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestIntegration_Dictionary(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -1181,14 +1897,21 @@ func TestIntegration_Dictionary(t *testing.T) {
 
 	// Seed words
 	_, err := testDB.Exec(`
-		INSERT INTO words (word, phonetic_clues, russian_translation, english_translation, german_translation, 
-		                   example, example_russian_translation, example_english_translation, example_german_translation,
-		                   easy_period, normal_period, hard_period, extra_hard_period) VALUES
-		('水', 'みず', 'вода', 'water', 'Wasser', '水を飲む', 'пить воду', 'drink water', 'Wasser trinken', 1, 3, 7, 14),
-		('火', 'ひ', 'огонь', 'fire', 'Feuer', '火をつける', 'зажечь огонь', 'light a fire', 'Feuer anzünden', 1, 3, 7, 14),
-		('風', 'かぜ', 'ветер', 'wind', 'Wind', '風が吹く', 'дует ветер', 'wind blows', 'Wind weht', 1, 3, 7, 14),
-		('木', 'き', 'дерево', 'tree', 'Baum', '木を植える', 'посадить дерево', 'plant a tree', 'Baum pflanzen', 1, 3, 7, 14),
-		('土', 'つち', 'земля', 'earth', 'Erde', '土を耕す', 'обрабатывать землю', 'till the earth', 'Erde bestellen', 1, 3, 7, 14)
+		INSERT INTO words (word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period) VALUES
+		('水', 'みず', '水を飲む', 1, 3, 7, 14),
+		('火', 'ひ', '火をつける', 1, 3, 7, 14),
+		('風', 'かぜ', '風が吹く', 1, 3, 7, 14),
+		('木', 'き', '木を植える', 1, 3, 7, 14),
+		('土', 'つち', '土を耕す', 1, 3, 7, 14)
+	`)
+	require.NoError(t, err, "Failed to seed words")
+	_, err = testDB.Exec(`
+		INSERT INTO word_translations (word_id, language, translation, example_translation) VALUES
+		(1, 'en', 'water', 'drink water'), (1, 'ru', 'вода', 'пить воду'), (1, 'de', 'Wasser', 'Wasser trinken'),
+		(2, 'en', 'fire', 'light a fire'), (2, 'ru', 'огонь', 'зажечь огонь'), (2, 'de', 'Feuer', 'Feuer anzünden'),
+		(3, 'en', 'wind', 'wind blows'), (3, 'ru', 'ветер', 'дует ветер'), (3, 'de', 'Wind', 'Wind weht'),
+		(4, 'en', 'tree', 'plant a tree'), (4, 'ru', 'дерево', 'посадить дерево'), (4, 'de', 'Baum', 'Baum pflanzen'),
+		(5, 'en', 'earth', 'till the earth'), (5, 'ru', 'земля', 'обрабатывать землю'), (5, 'de', 'Erde', 'Erde bestellen')
 	`)
 	require.NoError(t, err, "Failed to seed words")
 
@@ -1260,11 +1983,40 @@ func TestIntegration_Dictionary(t *testing.T) {
 				assert.Contains(t, response["error"], "newWordCount must be between 10 and 40")
 			},
 		},
+		{
+			name:           "success search words by translation",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/search?q=wat",
+			requestBody:    nil,
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response []models.WordSearchResult
+				err := json.NewDecoder(w.Body).Decode(&response)
+				require.NoError(t, err)
+				require.Len(t, response, 1)
+				assert.Equal(t, "水", response[0].Word)
+			},
+		},
+		{
+			name:           "search words without query",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/search",
+			requestBody:    nil,
+			expectedStatus: http.StatusBadRequest,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]string
+				err := json.NewDecoder(w.Body).Decode(&response)
+				require.NoError(t, err)
+				assert.Contains(t, response["error"], "search query is required")
+			},
+		},
 		{
 			name:           "invalid locale",
 			userID:         1,
 			method:         http.MethodGet,
-			url:            "/api/v6/words?newCount=20&oldCount=20&locale=fr",
+			url:            "/api/v6/words?newCount=20&oldCount=20&locale=french",
 			requestBody:    nil,
 			expectedStatus: http.StatusBadRequest,
 			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
@@ -1281,8 +2033,8 @@ func TestIntegration_Dictionary(t *testing.T) {
 			url:    "/api/v6/words/results",
 			requestBody: map[string]any{
 				"results": []map[string]any{
-					{"wordId": 1, "period": 3},
-					{"wordId": 2, "period": 7},
+					{"wordId": 1, "grade": 3},
+					{"wordId": 2, "grade": 1},
 				},
 			},
 			expectedStatus: http.StatusNoContent,
@@ -1295,13 +2047,13 @@ func TestIntegration_Dictionary(t *testing.T) {
 			},
 		},
 		{
-			name:   "invalid period - too low",
+			name:   "invalid grade - too low",
 			userID: 1,
 			method: http.MethodPost,
 			url:    "/api/v6/words/results",
 			requestBody: map[string]any{
 				"results": []map[string]any{
-					{"wordId": 1, "period": 0},
+					{"wordId": 1, "grade": 0},
 				},
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -1309,7 +2061,7 @@ func TestIntegration_Dictionary(t *testing.T) {
 				var response map[string]string
 				err := json.NewDecoder(w.Body).Decode(&response)
 				require.NoError(t, err)
-				assert.Contains(t, response["error"], "period must be between 1 and 30")
+				assert.Contains(t, response["error"], "grade must be between 1 and 4")
 			},
 		},
 		{
@@ -1319,7 +2071,7 @@ func TestIntegration_Dictionary(t *testing.T) {
 			url:    "/api/v6/words/results",
 			requestBody: map[string]any{
 				"results": []map[string]any{
-					{"wordId": 999, "period": 3},
+					{"wordId": 999, "grade": 3},
 				},
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -1346,6 +2098,64 @@ func TestIntegration_Dictionary(t *testing.T) {
 				assert.Contains(t, response["error"], "results array cannot be empty")
 			},
 		},
+		{
+			name:           "success get review forecast",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/forecast?days=3",
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var forecast models.ReviewForecast
+				err := json.NewDecoder(w.Body).Decode(&forecast)
+				require.NoError(t, err)
+				// Both submitted words are due tomorrow, the other three words are new
+				assert.Equal(t, 0, forecast.Backlog)
+				assert.Equal(t, 3, forecast.NewWords)
+				require.Len(t, forecast.Days, 3)
+				assert.Equal(t, 1, forecast.Days[0].Day)
+				assert.Equal(t, 2, forecast.Days[0].Count)
+			},
+		},
+		{
+			name:           "invalid forecast days",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/forecast?days=91",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success export dictionary as tsv",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/export?format=tsv&locale=en",
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "japanese-student-vocabulary.tsv")
+				// Only the two submitted words are exported
+				notes, err := anki.ReadText(w.Body.Bytes(), '\t')
+				require.NoError(t, err)
+				assert.Len(t, notes, 2)
+			},
+		},
+		{
+			name:           "success export dictionary as apkg",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/export",
+			expectedStatus: http.StatusOK,
+			validateFunc: func(t *testing.T, w *httptest.ResponseRecorder) {
+				pkg, err := anki.ReadPackage(w.Body.Bytes())
+				require.NoError(t, err)
+				assert.Len(t, pkg.Notes, 2)
+			},
+		},
+		{
+			name:           "invalid export format",
+			userID:         1,
+			method:         http.MethodGet,
+			url:            "/api/v6/words/export?format=csv",
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1372,6 +2182,116 @@ func TestIntegration_Dictionary(t *testing.T) {
 	}
 }
 
+func TestIntegration_UserDictionary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	cleanupTestData(t, testDB)
+	seedTestData(t, testDB)
+	defer cleanupTestData(t, testDB)
+
+	doRequest := func(t *testing.T, userID int, method, url string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body != nil {
+			data, _ := json.Marshal(body)
+			req = httptest.NewRequest(method, url, bytes.NewBuffer(data))
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req = httptest.NewRequest(method, url, nil)
+		}
+		req = req.WithContext(middleware.SetUserID(req.Context(), userID))
+		w := httptest.NewRecorder()
+		testRouter.ServeHTTP(w, req)
+		return w
+	}
+	createdID := func(t *testing.T, w *httptest.ResponseRecorder) int {
+		t.Helper()
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response map[string]any
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return int(response["id"].(float64))
+	}
+
+	deckID := createdID(t, doRequest(t, 1, http.MethodPost, "/api/v6/dictionary/decks", map[string]any{"name": "Anime"}))
+	wordID := createdID(t, doRequest(t, 1, http.MethodPost, "/api/v6/dictionary/words", map[string]any{
+		"deckId":        deckID,
+		"word":          "推し",
+		"phoneticClues": "おし",
+		"translation":   "favourite",
+	}))
+
+	t.Run("duplicate deck name", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodPost, "/api/v6/dictionary/decks", map[string]any{"name": "Anime"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rename deck to its current name", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodPatch, fmt.Sprintf("/api/v6/dictionary/decks/%d", deckID), map[string]any{"name": "Anime"})
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("get decks with word count", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodGet, "/api/v6/dictionary/decks", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var decks []models.UserDeck
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&decks))
+		require.Len(t, decks, 1)
+		assert.Equal(t, 1, decks[0].WordCount)
+	})
+
+	t.Run("words of another user are hidden", func(t *testing.T) {
+		w := doRequest(t, 2, http.MethodGet, fmt.Sprintf("/api/v6/dictionary/words?deckId=%d", deckID), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		w = doRequest(t, 2, http.MethodDelete, fmt.Sprintf("/api/v6/dictionary/words/%d", wordID), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("custom word is reviewed with the daily word list", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodGet, "/api/v6/words?newCount=10&oldCount=10&locale=en", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var words []models.WordResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&words))
+		require.Len(t, words, 1)
+		assert.Equal(t, wordID, words[0].ID)
+		assert.True(t, words[0].IsCustom)
+
+		w = doRequest(t, 1, http.MethodPost, "/api/v6/words/results", map[string]any{
+			"results": []map[string]any{{"wordId": wordID, "isCustom": true, "grade": 3}},
+		})
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		var count int
+		err := testDB.QueryRow("SELECT COUNT(*) FROM dictionary_history WHERE user_id = ? AND user_word_id = ?", 1, wordID).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("delete deck keeps its words", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodDelete, fmt.Sprintf("/api/v6/dictionary/decks/%d", deckID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		w = doRequest(t, 1, http.MethodGet, "/api/v6/dictionary/words", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		var words []models.UserWord
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&words))
+		require.Len(t, words, 1)
+		assert.Nil(t, words[0].DeckID)
+	})
+
+	t.Run("delete word removes its history", func(t *testing.T) {
+		w := doRequest(t, 1, http.MethodDelete, fmt.Sprintf("/api/v6/dictionary/words/%d", wordID), nil)
+		require.Equal(t, http.StatusNoContent, w.Code)
+
+		var count int
+		err := testDB.QueryRow("SELECT COUNT(*) FROM dictionary_history WHERE user_id = ?", 1).Scan(&count)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
+
 func TestIntegration_DictionaryRepositoryLayer(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -1383,11 +2303,15 @@ func TestIntegration_DictionaryRepositoryLayer(t *testing.T) {
 
 	// Seed words
 	_, err := testDB.Exec(`
-		INSERT INTO words (word, phonetic_clues, russian_translation, english_translation, german_translation, 
-		                   example, example_russian_translation, example_english_translation, example_german_translation,
-		                   easy_period, normal_period, hard_period, extra_hard_period) VALUES
-		('水', 'みず', 'вода', 'water', 'Wasser', '水を飲む', 'пить воду', 'drink water', 'Wasser trinken', 1, 3, 7, 14),
-		('火', 'ひ', 'огонь', 'fire', 'Feuer', '火をつける', 'зажечь огонь', 'light a fire', 'Feuer anzünden', 1, 3, 7, 14)
+		INSERT INTO words (word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period) VALUES
+		('水', 'みず', '水を飲む', 1, 3, 7, 14),
+		('火', 'ひ', '火をつける', 1, 3, 7, 14)
+	`)
+	require.NoError(t, err)
+	_, err = testDB.Exec(`
+		INSERT INTO word_translations (word_id, language, translation, example_translation) VALUES
+		(1, 'en', 'water', 'drink water'), (1, 'ru', 'вода', 'пить воду'), (1, 'de', 'Wasser', 'Wasser trinken'),
+		(2, 'en', 'fire', 'light a fire'), (2, 'ru', 'огонь', 'зажечь огонь'), (2, 'de', 'Feuer', 'Feuer anzünden')
 	`)
 	require.NoError(t, err)
 
@@ -1396,17 +2320,42 @@ func TestIntegration_DictionaryRepositoryLayer(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("WordRepository GetByIDs", func(t *testing.T) {
-		words, err := wordRepo.GetByIDs(ctx, []int{1, 2}, "english_translation", "example_english_translation")
+		words, err := wordRepo.GetByIDs(ctx, []int{1, 2}, []string{"en"})
 		require.NoError(t, err)
 		assert.Len(t, words, 2)
 	})
 
 	t.Run("WordRepository GetExcludingIDs", func(t *testing.T) {
-		words, err := wordRepo.GetExcludingIDs(ctx, 1, []int{1}, 1, "english_translation", "example_english_translation")
+		words, err := wordRepo.GetExcludingIDs(ctx, 1, []int{1}, 1, models.WordFilter{}, []string{"en"})
 		require.NoError(t, err)
 		assert.LessOrEqual(t, len(words), 1)
 	})
 
+	t.Run("WordRepository GetExcludingIDs with filter", func(t *testing.T) {
+		_, err := testDB.Exec("UPDATE words SET jlpt_level = 5 WHERE id = 2")
+		require.NoError(t, err)
+		_, err = testDB.Exec("INSERT INTO word_tags (word_id, tag) VALUES (2, 'nature')")
+		require.NoError(t, err)
+		defer testDB.Exec("DELETE FROM word_tags")
+
+		words, err := wordRepo.GetExcludingIDs(ctx, 1, []int{}, 10, models.WordFilter{JLPTLevels: []int{5}, Tags: []string{"nature"}}, []string{"en"})
+		require.NoError(t, err)
+		require.Len(t, words, 1)
+		assert.Equal(t, 2, words[0].ID)
+	})
+
+	t.Run("WordRepository Search", func(t *testing.T) {
+		_, err := wordRepo.FillSearchReadings(ctx)
+		require.NoError(t, err)
+
+		for _, query := range []string{"mizu", "ミズ", "みず", "水", "wat"} {
+			words, err := wordRepo.Search(ctx, query, 10, []string{"en"})
+			require.NoError(t, err, query)
+			require.NotEmpty(t, words, query)
+			assert.Equal(t, "水", words[0].Word, query)
+		}
+	})
+
 	t.Run("WordRepository ValidateWordIDs", func(t *testing.T) {
 		valid, err := wordRepo.ValidateWordIDs(ctx, []int{1, 2})
 		require.NoError(t, err)
@@ -1432,11 +2381,11 @@ func TestIntegration_DictionaryRepositoryLayer(t *testing.T) {
 	})
 
 	t.Run("DictionaryHistoryRepository UpsertResults", func(t *testing.T) {
-		results := []models.WordResult{
-			{WordID: 1, Period: 3},
-			{WordID: 2, Period: 7},
+		histories := []models.DictionaryHistory{
+			{WordID: 1, EaseFactor: 2.5, IntervalDays: 1, Repetitions: 1},
+			{WordID: 2, EaseFactor: 2.5, IntervalDays: 6, Repetitions: 2},
 		}
-		err := historyRepo.UpsertResults(ctx, 2, results)
+		err := historyRepo.UpsertResults(ctx, 2, histories)
 		require.NoError(t, err)
 
 		// Verify records were created
@@ -1444,6 +2393,17 @@ func TestIntegration_DictionaryRepositoryLayer(t *testing.T) {
 		err = testDB.QueryRow("SELECT COUNT(*) FROM dictionary_history WHERE user_id = ?", 2).Scan(&count)
 		require.NoError(t, err)
 		assert.Equal(t, 2, count)
+
+		stored, err := historyRepo.GetByUserIDAndWordIDs(ctx, 2, []int{1, 2})
+		require.NoError(t, err)
+		require.Len(t, stored, 2)
+		for _, history := range stored {
+			assert.NotNil(t, history.LastReviewedAt)
+			if history.WordID == 2 {
+				assert.Equal(t, 6, history.IntervalDays)
+				assert.Equal(t, 2, history.Repetitions)
+			}
+		}
 	})
 }
 
@@ -1458,32 +2418,85 @@ func TestIntegration_DictionaryServiceLayer(t *testing.T) {
 
 	// Seed words
 	_, err := testDB.Exec(`
-		INSERT INTO words (word, phonetic_clues, russian_translation, english_translation, german_translation, 
-		                   example, example_russian_translation, example_english_translation, example_german_translation,
-		                   easy_period, normal_period, hard_period, extra_hard_period) VALUES
-		('水', 'みず', 'вода', 'water', 'Wasser', '水を飲む', 'пить воду', 'drink water', 'Wasser trinken', 1, 3, 7, 14),
-		('火', 'ひ', 'огонь', 'fire', 'Feuer', '火をつける', 'зажечь огонь', 'light a fire', 'Feuer anzünden', 1, 3, 7, 14),
-		('風', 'かぜ', 'ветер', 'wind', 'Wind', '風が吹く', 'дует ветер', 'wind blows', 'Wind weht', 1, 3, 7, 14)
+		INSERT INTO words (word, phonetic_clues, example, easy_period, normal_period, hard_period, extra_hard_period) VALUES
+		('水', 'みず', '水を飲む', 1, 3, 7, 14),
+		('火', 'ひ', '火をつける', 1, 3, 7, 14),
+		('風', 'かぜ', '風が吹く', 1, 3, 7, 14)
+	`)
+	require.NoError(t, err)
+	_, err = testDB.Exec(`
+		INSERT INTO word_translations (word_id, language, translation, example_translation) VALUES
+		(1, 'en', 'water', 'drink water'), (1, 'ru', 'вода', 'пить воду'), (1, 'de', 'Wasser', 'Wasser trinken'),
+		(2, 'en', 'fire', 'light a fire'), (2, 'ru', 'огонь', 'зажечь огонь'), (2, 'de', 'Feuer', 'Feuer anzünden'),
+		(3, 'en', 'wind', 'wind blows'), (3, 'ru', 'ветер', 'дует ветер'), (3, 'de', 'Wind', 'Wind weht')
 	`)
 	require.NoError(t, err)
 
 	wordRepo := repositories.NewWordRepository(testDB)
 	historyRepo := repositories.NewDictionaryHistoryRepository(testDB)
-	dictionarySvc := services.NewDictionaryService(wordRepo, historyRepo)
+	dictionarySvc := services.NewDictionaryService(wordRepo, repositories.NewWordExampleRepository(testDB), repositories.NewUserWordRepository(testDB), historyRepo, repositories.NewWordQuizSessionRepository(testDB), repositories.NewKanjiRepository(testDB), services.DefaultLeechConfig(), services.DefaultLocaleConfig())
 	ctx := context.Background()
 
 	t.Run("GetWordList", func(t *testing.T) {
-		words, err := dictionarySvc.GetWordList(ctx, 1, 10, 10, "en")
+		words, err := dictionarySvc.GetWordList(ctx, 1, 10, 10, "en", "", "", "", 0)
 		require.NoError(t, err)
 		assert.LessOrEqual(t, len(words), 20)
 	})
 
 	t.Run("SubmitWordResults", func(t *testing.T) {
 		results := []models.WordResult{
-			{WordID: 1, Period: 3},
-			{WordID: 2, Period: 7},
+			{WordID: 1, Grade: models.ReviewGradeGood},
+			{WordID: 2, Grade: models.ReviewGradeAgain},
 		}
 		err := dictionarySvc.SubmitWordResults(ctx, 1, results)
 		require.NoError(t, err)
+
+		// A second good recall of the word gives six days
+		err = dictionarySvc.SubmitWordResults(ctx, 1, []models.WordResult{{WordID: 1, Grade: models.ReviewGradeGood}})
+		require.NoError(t, err)
+
+		var intervalDays, repetitions, reviewCount int
+		err = testDB.QueryRow("SELECT interval_days, repetitions, review_count FROM dictionary_history WHERE user_id = ? AND word_id = ?", 1, 1).
+			Scan(&intervalDays, &repetitions, &reviewCount)
+		require.NoError(t, err)
+		assert.Equal(t, 6, intervalDays)
+		assert.Equal(t, 2, repetitions)
+		assert.Equal(t, 2, reviewCount)
+	})
+
+	t.Run("GetWordList rotates examples", func(t *testing.T) {
+		var wordId int
+		err := testDB.QueryRow("SELECT id FROM words WHERE word = '風'").Scan(&wordId)
+		require.NoError(t, err)
+		result, err := testDB.Exec(`
+			INSERT INTO word_examples (word_id, example, source)
+			VALUES (?, '風が強い', 'NHK Easy')
+		`, wordId)
+		require.NoError(t, err)
+		exampleId, err := result.LastInsertId()
+		require.NoError(t, err)
+		_, err = testDB.Exec(`
+			INSERT INTO word_example_translations (example_id, language, translation)
+			VALUES (?, 'ru', 'Сильный ветер'), (?, 'en', 'The wind is strong')
+		`, exampleId, exampleId)
+		require.NoError(t, err)
+		_, err = testDB.Exec(`
+			INSERT INTO dictionary_history (user_id, word_id, review_count, next_appearance)
+			VALUES (3, ?, 1, DATE_SUB(CURDATE(), INTERVAL 1 DAY))
+		`, wordId)
+		require.NoError(t, err)
+
+		words, err := dictionarySvc.GetWordList(ctx, 3, 10, 10, "ru", "", "", "", 0)
+		require.NoError(t, err)
+		var found bool
+		for _, word := range words {
+			if word.ID == wordId {
+				found = true
+				assert.Equal(t, "風が強い", word.Example)
+				assert.Equal(t, "Сильный ветер", word.ExampleTranslation)
+				assert.Equal(t, "NHK Easy", word.ExampleSource)
+			}
+		}
+		assert.True(t, found, "the word due for review must be in the list")
 	})
 }
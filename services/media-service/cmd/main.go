@@ -111,7 +111,9 @@ func main() {
 	}
 
 	// Initialize handlers
-	mediaHandler := handlers.NewMediaHandler(mediaService, logger.Logger, baseURL, authMw)
+	// Downloads are also allowed with the API key, so other services can fetch protected files
+	downloadMw := authMiddleware.APIKeyOrAuthMiddleware(cfg.APIKey, authMw)
+	mediaHandler := handlers.NewMediaHandler(mediaService, logger.Logger, baseURL, downloadMw)
 
 	// Setup router
 	r := chi.NewRouter()
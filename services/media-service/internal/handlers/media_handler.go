@@ -124,7 +124,7 @@ func (h *MediaHandler) GetMetadata(w http.ResponseWriter, r *http.Request) {
 
 // DownloadFile handles GET /media/{mediaType}/{filename}
 // @Summary Download media file
-// @Description Download a media file. Character files are public, others require authentication. Audio/video support range requests.
+// @Description Download a media file. Character files are public, others require authentication or the API key of a service. Audio/video support range requests.
 // @Tags media
 // @Accept json
 // @Produce application/octet-stream
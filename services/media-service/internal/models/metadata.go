@@ -16,6 +16,7 @@ const (
 	MediaTypeCharacter   MediaType = "character"
 	MediaTypeWord        MediaType = "word"
 	MediaTypeWordExample MediaType = "word_example"
+	MediaTypeKanji       MediaType = "kanji"
 	MediaTypeLessonAudio MediaType = "lesson_audio"
 	MediaTypeLessonVideo MediaType = "lesson_video"
 	MediaTypeLessonDoc   MediaType = "lesson_doc"
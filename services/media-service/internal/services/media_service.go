@@ -174,6 +174,7 @@ func (s *MediaService) IsValidMediaType(mediaType string) bool {
 	case models.MediaTypeCharacter,
 		models.MediaTypeWord,
 		models.MediaTypeWordExample,
+		models.MediaTypeKanji,
 		models.MediaTypeLessonAudio,
 		models.MediaTypeLessonVideo,
 		models.MediaTypeLessonDoc,
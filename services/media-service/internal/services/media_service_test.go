@@ -564,6 +564,11 @@ func TestMediaService_IsValidMediaType(t *testing.T) {
 			mediaType:     "word_example",
 			expectedValid: true,
 		},
+		{
+			name:          "valid kanji",
+			mediaType:     "kanji",
+			expectedValid: true,
+		},
 		{
 			name:          "valid lesson_audio",
 			mediaType:     "lesson_audio",